@@ -0,0 +1,93 @@
+package inventory
+
+import (
+	"github.com/Dynatrace/dynatrace-operator/cmd/config"
+	csiinventory "github.com/Dynatrace/dynatrace-operator/pkg/controllers/csi/inventory"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/env"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	use = "csi-inventory"
+
+	namespaceFlagName      = "namespace"
+	namespaceFlagShorthand = "n"
+	portFlagName           = "port"
+	outputFlagName         = "output"
+	outputFlagShorthand    = "o"
+
+	outputTable = "table"
+	outputJson  = "json"
+)
+
+var (
+	namespaceFlagValue string
+	portFlagValue      string
+	outputFlagValue    string
+)
+
+type CommandBuilder struct {
+	configProvider config.Provider
+}
+
+func NewCsiInventoryCommandBuilder() CommandBuilder {
+	return CommandBuilder{}
+}
+
+func (builder CommandBuilder) SetConfigProvider(provider config.Provider) CommandBuilder {
+	builder.configProvider = provider
+	return builder
+}
+
+func (builder CommandBuilder) Build() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   use,
+		Short: "Show what the CSI driver holds on each node",
+		Long: "Collects the inventory of all CSI driver pods via port-forwarding: " +
+			"tenants, code module versions and image digests, their disk usage, mounted volumes and failed mount attempts.",
+		RunE: builder.buildRun(),
+	}
+
+	addFlags(cmd)
+
+	return cmd
+}
+
+func addFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVarP(&namespaceFlagValue, namespaceFlagName, namespaceFlagShorthand, env.DefaultNamespace(), "Namespace of the CSI driver.")
+	cmd.PersistentFlags().StringVar(&portFlagValue, portFlagName, csiinventory.DefaultPort, "Port of the inventory endpoint of the CSI driver pods.")
+	cmd.PersistentFlags().StringVarP(&outputFlagValue, outputFlagName, outputFlagShorthand, outputTable, "Output format, one of: table, json.")
+}
+
+func (builder CommandBuilder) buildRun() func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		if outputFlagValue != outputTable && outputFlagValue != outputJson {
+			return errors.Errorf("unknown output format '%s'", outputFlagValue)
+		}
+
+		kubeConfig, err := builder.configProvider.GetConfig()
+		if err != nil {
+			return err
+		}
+
+		clientSet, err := kubernetes.NewForConfig(kubeConfig)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		inventories, err := collectInventories(cmd.Context(), clientSet, namespaceFlagValue, newPortForwardGetter(kubeConfig, clientSet, portFlagValue), cmd.ErrOrStderr())
+		if err != nil {
+			return err
+		}
+
+		if outputFlagValue == outputJson {
+			return printJson(cmd.OutOrStdout(), inventories)
+		}
+
+		printTable(cmd.OutOrStdout(), inventories)
+
+		return nil
+	}
+}
@@ -0,0 +1,189 @@
+package inventory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+
+	dtcsi "github.com/Dynatrace/dynatrace-operator/pkg/controllers/csi"
+	csiinventory "github.com/Dynatrace/dynatrace-operator/pkg/controllers/csi/inventory"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// inventoryGetter gets the inventory of a single CSI driver pod.
+type inventoryGetter func(ctx context.Context, pod corev1.Pod) (*csiinventory.Inventory, error)
+
+// collectInventories gets the inventory of every running pod of the CSI driver daemonset.
+// Pods that can't be reached are reported to errOut, so the inventory of the other nodes is still shown.
+func collectInventories(ctx context.Context, clientSet kubernetes.Interface, namespace string, getInventory inventoryGetter, errOut io.Writer) ([]csiinventory.Inventory, error) {
+	daemonSet, err := clientSet.AppsV1().DaemonSets(namespace).Get(ctx, dtcsi.DaemonSetName, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to get the CSI driver daemonset")
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(daemonSet.Spec.Selector)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	pods, err := clientSet.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to list the CSI driver pods")
+	}
+
+	inventories := make([]csiinventory.Inventory, 0, len(pods.Items))
+	failed := 0
+
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodRunning {
+			fmt.Fprintf(errOut, "skipping pod %s on node %s, it is %s\n", pod.Name, pod.Spec.NodeName, pod.Status.Phase)
+			continue
+		}
+
+		inventory, err := getInventory(ctx, pod)
+		if err != nil {
+			fmt.Fprintf(errOut, "failed to get the inventory of pod %s on node %s: %s\n", pod.Name, pod.Spec.NodeName, err)
+			failed++
+
+			continue
+		}
+
+		inventories = append(inventories, *inventory)
+	}
+
+	if len(inventories) == 0 && failed > 0 {
+		return nil, errors.New("failed to get the inventory of any CSI driver pod")
+	}
+
+	sort.Slice(inventories, func(i, j int) bool {
+		return inventories[i].NodeName < inventories[j].NodeName
+	})
+
+	return inventories, nil
+}
+
+func decodeInventory(response []byte, pod corev1.Pod) (*csiinventory.Inventory, error) {
+	var inventory csiinventory.Inventory
+	if err := json.Unmarshal(response, &inventory); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if inventory.NodeName == "" {
+		inventory.NodeName = pod.Spec.NodeName
+	}
+
+	return &inventory, nil
+}
+
+func printJson(out io.Writer, inventories []csiinventory.Inventory) error {
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+
+	return errors.WithStack(encoder.Encode(inventories))
+}
+
+func printTable(out io.Writer, inventories []csiinventory.Inventory) {
+	writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(writer, "NODE\tTENANTS\tMOUNTED VOLUMES\tFAILING VOLUMES\tFAILED MOUNT ATTEMPTS\tCODE MODULES\tDISK USAGE")
+
+	var total nodeSummary
+
+	for _, inventory := range inventories {
+		summary := summarize(inventory)
+		total.add(summary)
+		fmt.Fprintf(writer, "%s\t%s\n", inventory.NodeName, summary)
+	}
+
+	fmt.Fprintf(writer, "TOTAL\t%s\n", total)
+	fmt.Fprintln(writer)
+	fmt.Fprintln(writer, "NODE\tCODE MODULE\tARCH\tTENANT\tMOUNTED VOLUMES\tDISK USAGE")
+
+	for _, inventory := range inventories {
+		for _, codeModule := range inventory.CodeModules {
+			fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%d\t%s\n",
+				inventory.NodeName,
+				codeModule.Name,
+				orNone(codeModule.Arch),
+				orNone(codeModule.TenantUUID),
+				codeModule.MountedVolumes,
+				formatBytes(codeModule.SizeBytes))
+		}
+	}
+
+	_ = writer.Flush()
+}
+
+type nodeSummary struct {
+	tenants             int
+	mountedVolumes      int
+	failingVolumes      int
+	failedMountAttempts int
+	codeModules         int
+	diskUsageBytes      int64
+}
+
+func summarize(inventory csiinventory.Inventory) nodeSummary {
+	summary := nodeSummary{
+		tenants:        len(inventory.Tenants),
+		codeModules:    len(inventory.CodeModules),
+		diskUsageBytes: inventory.DiskUsageBytes,
+	}
+
+	for _, tenant := range inventory.Tenants {
+		summary.mountedVolumes += tenant.MountedVolumes
+		summary.failingVolumes += tenant.FailingVolumes
+		summary.failedMountAttempts += tenant.FailedMountAttempts
+	}
+
+	return summary
+}
+
+func (summary *nodeSummary) add(other nodeSummary) {
+	summary.tenants += other.tenants
+	summary.mountedVolumes += other.mountedVolumes
+	summary.failingVolumes += other.failingVolumes
+	summary.failedMountAttempts += other.failedMountAttempts
+	summary.codeModules += other.codeModules
+	summary.diskUsageBytes += other.diskUsageBytes
+}
+
+func (summary nodeSummary) String() string {
+	return fmt.Sprintf("%d\t%d\t%d\t%d\t%d\t%s",
+		summary.tenants,
+		summary.mountedVolumes,
+		summary.failingVolumes,
+		summary.failedMountAttempts,
+		summary.codeModules,
+		formatBytes(summary.diskUsageBytes))
+}
+
+func formatBytes(size int64) string {
+	const unit = 1024
+
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
+
+func orNone(value string) string {
+	if value == "" {
+		return "-"
+	}
+
+	return value
+}
@@ -0,0 +1,137 @@
+package inventory
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	dtcsi "github.com/Dynatrace/dynatrace-operator/pkg/controllers/csi"
+	csiinventory "github.com/Dynatrace/dynatrace-operator/pkg/controllers/csi/inventory"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const testNamespace = "dynatrace"
+
+var testSelector = map[string]string{"app.kubernetes.io/component": "csi-driver"}
+
+func createTestPod(name, nodeName string, phase corev1.PodPhase) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace, Labels: testSelector},
+		Spec:       corev1.PodSpec{NodeName: nodeName},
+		Status:     corev1.PodStatus{Phase: phase},
+	}
+}
+
+func createTestClientSet(objects ...runtime.Object) *fake.Clientset {
+	daemonSet := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: dtcsi.DaemonSetName, Namespace: testNamespace},
+		Spec:       appsv1.DaemonSetSpec{Selector: &metav1.LabelSelector{MatchLabels: testSelector}},
+	}
+
+	return fake.NewSimpleClientset(append(objects, daemonSet)...)
+}
+
+func createTestInventoryGetter(inventories map[string]*csiinventory.Inventory) inventoryGetter {
+	return func(_ context.Context, pod corev1.Pod) (*csiinventory.Inventory, error) {
+		inventory, ok := inventories[pod.Name]
+		if !ok {
+			return nil, errors.New("connection refused")
+		}
+
+		return inventory, nil
+	}
+}
+
+func TestCollectInventories(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("collects the inventory of all running pods", func(t *testing.T) {
+		clientSet := createTestClientSet(
+			createTestPod("csi-a", "node-a", corev1.PodRunning),
+			createTestPod("csi-b", "node-b", corev1.PodRunning),
+			createTestPod("csi-c", "node-c", corev1.PodPending),
+		)
+		getInventory := createTestInventoryGetter(map[string]*csiinventory.Inventory{
+			"csi-b": {NodeName: "node-b", DiskUsageBytes: 2},
+			"csi-a": {NodeName: "node-a", DiskUsageBytes: 1},
+			"csi-c": {NodeName: "node-c"},
+		})
+		errOut := &bytes.Buffer{}
+
+		inventories, err := collectInventories(ctx, clientSet, testNamespace, getInventory, errOut)
+		require.NoError(t, err)
+
+		require.Len(t, inventories, 2)
+		assert.Equal(t, "node-a", inventories[0].NodeName)
+		assert.Equal(t, "node-b", inventories[1].NodeName)
+		assert.Contains(t, errOut.String(), "skipping pod csi-c on node node-c")
+	})
+	t.Run("unreachable pods are reported", func(t *testing.T) {
+		clientSet := createTestClientSet(
+			createTestPod("csi-a", "node-a", corev1.PodRunning),
+			createTestPod("csi-b", "node-b", corev1.PodRunning),
+		)
+		getInventory := createTestInventoryGetter(map[string]*csiinventory.Inventory{"csi-a": {NodeName: "node-a"}})
+		errOut := &bytes.Buffer{}
+
+		inventories, err := collectInventories(ctx, clientSet, testNamespace, getInventory, errOut)
+		require.NoError(t, err)
+
+		assert.Len(t, inventories, 1)
+		assert.Contains(t, errOut.String(), "failed to get the inventory of pod csi-b on node node-b")
+	})
+	t.Run("fails if no pod is reachable", func(t *testing.T) {
+		clientSet := createTestClientSet(createTestPod("csi-a", "node-a", corev1.PodRunning))
+
+		_, err := collectInventories(ctx, clientSet, testNamespace, createTestInventoryGetter(nil), io.Discard)
+		require.Error(t, err)
+	})
+	t.Run("fails without daemonset", func(t *testing.T) {
+		_, err := collectInventories(ctx, fake.NewSimpleClientset(), testNamespace, createTestInventoryGetter(nil), io.Discard)
+		require.Error(t, err)
+	})
+}
+
+func TestPrintTable(t *testing.T) {
+	inventories := []csiinventory.Inventory{
+		{
+			NodeName: "node-a",
+			Tenants: []csiinventory.Tenant{
+				{TenantUUID: "tenant", MountedVolumes: 2, FailingVolumes: 1, FailedMountAttempts: 3},
+			},
+			CodeModules: []csiinventory.CodeModule{
+				{Name: "1.2.3", Arch: "arm", SizeBytes: 3 * 1024 * 1024, MountedVolumes: 2},
+			},
+			DiskUsageBytes: 3 * 1024 * 1024,
+		},
+		{
+			NodeName:       "node-b",
+			Tenants:        []csiinventory.Tenant{{TenantUUID: "tenant", MountedVolumes: 1}},
+			DiskUsageBytes: 512,
+		},
+	}
+	out := &bytes.Buffer{}
+
+	printTable(out, inventories)
+
+	lines := bytes.Split(out.Bytes(), []byte("\n"))
+	assert.Regexp(t, `^node-a\s+1\s+2\s+1\s+3\s+1\s+3\.0 MiB$`, string(lines[1]))
+	assert.Regexp(t, `^node-b\s+1\s+1\s+0\s+0\s+0\s+512 B$`, string(lines[2]))
+	assert.Regexp(t, `^TOTAL\s+2\s+3\s+1\s+3\s+1\s+3\.0 MiB$`, string(lines[3]))
+	assert.Regexp(t, `^node-a\s+1\.2\.3\s+arm\s+-\s+2\s+3\.0 MiB$`, string(lines[6]))
+}
+
+func TestFormatBytes(t *testing.T) {
+	assert.Equal(t, "0 B", formatBytes(0))
+	assert.Equal(t, "1023 B", formatBytes(1023))
+	assert.Equal(t, "1.0 KiB", formatBytes(1024))
+	assert.Equal(t, "1.5 GiB", formatBytes(3*512*1024*1024))
+}
@@ -0,0 +1,92 @@
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	csiinventory "github.com/Dynatrace/dynatrace-operator/pkg/controllers/csi/inventory"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+)
+
+const localhost = "127.0.0.1"
+
+// newPortForwardGetter gets the inventory through a port-forward to the pod,
+// because the CSI driver only serves it on the loopback interface of the pod.
+func newPortForwardGetter(kubeConfig *rest.Config, clientSet kubernetes.Interface, port string) inventoryGetter {
+	return func(ctx context.Context, pod corev1.Pod) (*csiinventory.Inventory, error) {
+		roundTripper, upgrader, err := spdy.RoundTripperFor(kubeConfig)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		url := clientSet.CoreV1().RESTClient().Post().
+			Resource("pods").
+			Namespace(pod.Namespace).
+			Name(pod.Name).
+			SubResource("portforward").
+			URL()
+		dialer := spdy.NewDialer(upgrader, &http.Client{Transport: roundTripper}, http.MethodPost, url)
+
+		stopChan := make(chan struct{})
+		readyChan := make(chan struct{})
+		defer close(stopChan)
+
+		// local port 0 lets the forwarder pick a free port
+		forwarder, err := portforward.NewOnAddresses(dialer, []string{localhost}, []string{"0:" + port}, stopChan, readyChan, io.Discard, io.Discard)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		forwardErr := make(chan error, 1)
+
+		go func() {
+			forwardErr <- forwarder.ForwardPorts()
+		}()
+
+		select {
+		case <-readyChan:
+		case err := <-forwardErr:
+			return nil, errors.WithMessage(err, "failed to forward the inventory port")
+		case <-ctx.Done():
+			return nil, errors.WithStack(ctx.Err())
+		}
+
+		ports, err := forwarder.GetPorts()
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		return requestInventory(ctx, fmt.Sprintf("http://%s:%d%s", localhost, ports[0].Local, csiinventory.Endpoint), pod)
+	}
+}
+
+func requestInventory(ctx context.Context, url string, pod corev1.Pod) (*csiinventory.Inventory, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("inventory endpoint responded with %d: %s", response.StatusCode, body)
+	}
+
+	return decodeInventory(body, pod)
+}
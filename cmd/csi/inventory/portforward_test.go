@@ -0,0 +1,43 @@
+package inventory
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	csiinventory "github.com/Dynatrace/dynatrace-operator/pkg/controllers/csi/inventory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestRequestInventory(t *testing.T) {
+	ctx := context.Background()
+	pod := *createTestPod("csi-a", "node-a", corev1.PodRunning)
+
+	t.Run("decodes the inventory", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			assert.Equal(t, csiinventory.Endpoint, request.URL.Path)
+			_ = json.NewEncoder(writer).Encode(csiinventory.Inventory{DiskUsageBytes: 1})
+		}))
+		defer server.Close()
+
+		inventory, err := requestInventory(ctx, server.URL+csiinventory.Endpoint, pod)
+		require.NoError(t, err)
+
+		assert.Equal(t, "node-a", inventory.NodeName)
+		assert.Equal(t, int64(1), inventory.DiskUsageBytes)
+	})
+	t.Run("fails if the inventory isn't collected yet", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+			http.Error(writer, "inventory is not yet collected", http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		_, err := requestInventory(ctx, server.URL+csiinventory.Endpoint, pod)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "503")
+	})
+}
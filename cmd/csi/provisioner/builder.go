@@ -17,7 +17,7 @@ import (
 
 const use = "csi-provisioner"
 
-var probeAddress string
+var nodeId, probeAddress string
 
 type CommandBuilder struct {
 	configProvider  config.Provider
@@ -59,6 +59,7 @@ func (builder CommandBuilder) setFilesystem(filesystem afero.Fs) CommandBuilder
 func (builder CommandBuilder) getCsiOptions() dtcsi.CSIOptions {
 	if builder.csiOptions == nil {
 		builder.csiOptions = &dtcsi.CSIOptions{
+			NodeId:  nodeId,
 			RootDir: dtcsi.DataPath,
 		}
 	}
@@ -94,6 +95,7 @@ func (builder CommandBuilder) Build() *cobra.Command {
 }
 
 func addFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVar(&nodeId, "node-id", "", "node id")
 	cmd.PersistentFlags().StringVar(&probeAddress, "health-probe-bind-address", ":10090", "The address the probe endpoint binds to.")
 }
 
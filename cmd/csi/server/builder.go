@@ -5,6 +5,7 @@ import (
 	cmdManager "github.com/Dynatrace/dynatrace-operator/cmd/manager"
 	dtcsi "github.com/Dynatrace/dynatrace-operator/pkg/controllers/csi"
 	csidriver "github.com/Dynatrace/dynatrace-operator/pkg/controllers/csi/driver"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/csi/inventory"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/csi/metadata"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/otel"
 	"github.com/Dynatrace/dynatrace-operator/pkg/version"
@@ -17,7 +18,7 @@ import (
 
 const use = "csi-server"
 
-var nodeId, probeAddress, endpoint string
+var nodeId, probeAddress, endpoint, inventoryAddress string
 
 type CommandBuilder struct {
 	configProvider  config.Provider
@@ -91,6 +92,7 @@ func (builder CommandBuilder) Build() *cobra.Command {
 	}
 
 	addFlags(cmd)
+	cmd.AddCommand(newMigrateCommand())
 
 	return cmd
 }
@@ -99,6 +101,7 @@ func addFlags(cmd *cobra.Command) {
 	cmd.PersistentFlags().StringVar(&nodeId, "node-id", "", "node id")
 	cmd.PersistentFlags().StringVar(&endpoint, "endpoint", "unix:///tmp/csi.sock", "CSI endpoint")
 	cmd.PersistentFlags().StringVar(&probeAddress, "health-probe-bind-address", defaultProbeAddress, "The address the probe endpoint binds to.")
+	cmd.PersistentFlags().StringVar(&inventoryAddress, "inventory-bind-address", inventory.DefaultBindAddress, "The address the inventory endpoint binds to, the endpoint is disabled if empty.")
 }
 
 func (builder CommandBuilder) buildRun() func(*cobra.Command, []string) error {
@@ -135,6 +138,16 @@ func (builder CommandBuilder) buildRun() func(*cobra.Command, []string) error {
 			return err
 		}
 
+		if inventoryAddress != "" {
+			csiOptions := builder.getCsiOptions()
+			collector := inventory.NewCollector(access, builder.getFilesystem(), metadata.PathResolver{RootDir: csiOptions.RootDir}, csiOptions.NodeId)
+
+			err = inventory.NewServer(inventoryAddress, collector).SetupWithManager(csiManager)
+			if err != nil {
+				return err
+			}
+		}
+
 		err = csiManager.Start(signalHandler)
 		return errors.WithStack(err)
 	}
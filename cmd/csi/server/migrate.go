@@ -0,0 +1,89 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	dtcsi "github.com/Dynatrace/dynatrace-operator/pkg/controllers/csi"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/csi/metadata"
+	"github.com/spf13/cobra"
+)
+
+const (
+	migrateUse = "migrate"
+
+	databaseFlagName = "database"
+	dryRunFlagName   = "dry-run"
+	toFlagName       = "to"
+)
+
+var (
+	databaseFlagValue string
+	dryRunFlagValue   bool
+	toFlagValue       int
+)
+
+// newMigrateCommand creates the command to migrate the metadata database manually, e.g. to downgrade it before rolling back the operator.
+// The csi pods migrate the database to the latest schema version on startup anyway.
+func newMigrateCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   migrateUse,
+		Short: "Migrate the schema of the CSI metadata database",
+		Long: "Migrate the schema of the CSI metadata database to the given version. " +
+			"A backup of the database is created next to it before it is changed. " +
+			"Downgrading drops the columns and tables of the reverted migrations.",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runMigrate(cmd.Context(), cmd.OutOrStdout())
+		},
+	}
+
+	cmd.Flags().StringVar(&databaseFlagValue, databaseFlagName, dtcsi.MetadataAccessPath, "Path of the metadata database.")
+	cmd.Flags().BoolVar(&dryRunFlagValue, dryRunFlagName, false, "Apply the migrations and roll them back, to check that they succeed.")
+	cmd.Flags().IntVar(&toFlagValue, toFlagName, metadata.LatestSchemaVersion, "Schema version the database is migrated to.")
+
+	return cmd
+}
+
+func runMigrate(ctx context.Context, out io.Writer) error {
+	migrator, err := metadata.OpenMigrator(databaseFlagValue)
+	if err != nil {
+		return err
+	}
+	defer migrator.Close()
+
+	if err := migrator.CheckIntegrity(ctx); err != nil {
+		return err
+	}
+
+	plan, err := migrator.SetDryRun(dryRunFlagValue).Migrate(ctx, toFlagValue)
+	if err != nil {
+		return err
+	}
+
+	printMigrationPlan(out, plan)
+
+	return nil
+}
+
+func printMigrationPlan(out io.Writer, plan metadata.MigrationPlan) {
+	if len(plan.Migrations) == 0 {
+		fmt.Fprintf(out, "schema version %d, nothing to migrate\n", plan.From)
+		return
+	}
+
+	direction := "up"
+	if plan.IsDowngrade() {
+		direction = "down"
+	}
+
+	fmt.Fprintf(out, "schema version %d -> %d\n", plan.From, plan.To)
+
+	for _, migration := range plan.Migrations {
+		fmt.Fprintf(out, "  %s %d: %s\n", direction, migration.Version, migration.Description)
+	}
+
+	if dryRunFlagValue {
+		fmt.Fprintln(out, "dry run, the migrations were rolled back")
+	}
+}
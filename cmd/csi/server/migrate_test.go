@@ -0,0 +1,72 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/csi/metadata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func runMigrateCommand(t *testing.T, args ...string) (string, error) {
+	out := &bytes.Buffer{}
+	cmd := NewCsiServerCommandBuilder().Build()
+	cmd.SetOut(out)
+	cmd.SetArgs(append([]string{migrateUse}, args...))
+
+	err := cmd.Execute()
+
+	return out.String(), err
+}
+
+func getSchemaVersion(t *testing.T, path string) int {
+	migrator, err := metadata.OpenMigrator(path)
+	require.NoError(t, err)
+
+	defer migrator.Close()
+
+	version, err := migrator.Version(context.Background())
+	require.NoError(t, err)
+
+	return version
+}
+
+func TestMigrateCommand(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "csi.db")
+
+	t.Run("dry run", func(t *testing.T) {
+		out, err := runMigrateCommand(t, "--database", path, "--dry-run")
+		require.NoError(t, err)
+
+		assert.Contains(t, out, fmt.Sprintf("schema version 0 -> %d", metadata.LatestSchemaVersion))
+		assert.Contains(t, out, "up 1: create the dynakubes, volumes and osagent_volumes tables")
+		assert.Contains(t, out, "dry run")
+		assert.Equal(t, 0, getSchemaVersion(t, path))
+	})
+	t.Run("migrate to latest version", func(t *testing.T) {
+		_, err := runMigrateCommand(t, "--database", path)
+		require.NoError(t, err)
+
+		assert.Equal(t, metadata.LatestSchemaVersion, getSchemaVersion(t, path))
+
+		out, err := runMigrateCommand(t, "--database", path)
+		require.NoError(t, err)
+		assert.Contains(t, out, "nothing to migrate")
+	})
+	t.Run("downgrade", func(t *testing.T) {
+		out, err := runMigrateCommand(t, "--database", path, "--to", "4")
+		require.NoError(t, err)
+
+		assert.Contains(t, out, "down 6: add column Arch to volumes")
+		assert.Equal(t, 4, getSchemaVersion(t, path))
+		assert.FileExists(t, metadata.BackupPath(path, metadata.LatestSchemaVersion))
+	})
+	t.Run("unknown version", func(t *testing.T) {
+		_, err := runMigrateCommand(t, "--database", path, "--to", "100")
+		require.Error(t, err)
+	})
+}
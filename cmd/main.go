@@ -19,8 +19,10 @@ import (
 
 	cmdConfig "github.com/Dynatrace/dynatrace-operator/cmd/config"
 	csiInit "github.com/Dynatrace/dynatrace-operator/cmd/csi/init"
+	csiInventory "github.com/Dynatrace/dynatrace-operator/cmd/csi/inventory"
 	csiProvisioner "github.com/Dynatrace/dynatrace-operator/cmd/csi/provisioner"
 	csiServer "github.com/Dynatrace/dynatrace-operator/cmd/csi/server"
+	"github.com/Dynatrace/dynatrace-operator/cmd/mirror"
 	"github.com/Dynatrace/dynatrace-operator/cmd/operator"
 	"github.com/Dynatrace/dynatrace-operator/cmd/standalone"
 	"github.com/Dynatrace/dynatrace-operator/cmd/startup_probe"
@@ -78,6 +80,11 @@ func createCsiProvisionerCommandBuilder() csiProvisioner.CommandBuilder {
 		SetConfigProvider(cmdConfig.NewKubeConfigProvider())
 }
 
+func createCsiInventoryCommandBuilder() csiInventory.CommandBuilder {
+	return csiInventory.NewCsiInventoryCommandBuilder().
+		SetConfigProvider(cmdConfig.NewKubeConfigProvider())
+}
+
 func createTroubleshootCommandBuilder() troubleshoot.CommandBuilder {
 	return troubleshoot.NewTroubleshootCommandBuilder().
 		SetConfigProvider(cmdConfig.NewKubeConfigProvider())
@@ -88,6 +95,10 @@ func createSupportArchiveCommandBuilder() support_archive.CommandBuilder {
 		SetConfigProvider(cmdConfig.NewKubeConfigProvider())
 }
 
+func createMirrorCommandBuilder() mirror.CommandBuilder {
+	return mirror.NewCommandBuilder()
+}
+
 func createStartupProbe() startup_probe.CommandBuilder {
 	return startup_probe.NewCommandBuilder()
 }
@@ -110,6 +121,8 @@ func main() {
 		createSupportArchiveCommandBuilder().Build(),
 		createStartupProbe().Build(),
 		createCsiInitCommandBuilder().Build(),
+		createCsiInventoryCommandBuilder().Build(),
+		createMirrorCommandBuilder().Build(),
 	)
 
 	err := cmd.Execute()
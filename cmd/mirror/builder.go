@@ -0,0 +1,143 @@
+package mirror
+
+import (
+	"context"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/arch"
+	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/logger"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	use = "mirror"
+
+	apiUrlFlagName         = "api-url"
+	apiTokenFlagName       = "api-token"
+	paasTokenFlagName      = "paas-token"
+	outputFlagName         = "output"
+	osFlagName             = "os"
+	installerTypesFlagName = "installer-types"
+	flavorsFlagName        = "flavors"
+	archsFlagName          = "archs"
+	technologiesFlagName   = "technologies"
+	versionsFlagName       = "versions"
+	imageRegistryFlagName  = "image-registry"
+	registryFlagName       = "registry"
+	skipCertCheckFlagName  = "skip-cert-check"
+
+	defaultOutput   = "dynatrace-mirror"
+	defaultVersions = 1
+)
+
+var (
+	log = logger.Factory.GetLogger("mirror")
+
+	apiUrlFlagValue         string
+	apiTokenFlagValue       string
+	paasTokenFlagValue      string
+	outputFlagValue         string
+	osFlagValue             string
+	installerTypesFlagValue []string
+	flavorsFlagValue        []string
+	archsFlagValue          []string
+	technologiesFlagValue   []string
+	versionsFlagValue       int
+	imageRegistryFlagValue  string
+	registryFlagValue       string
+	skipCertCheckFlagValue  bool
+)
+
+type CommandBuilder struct {
+}
+
+func NewCommandBuilder() CommandBuilder {
+	return CommandBuilder{}
+}
+
+func (builder CommandBuilder) Build() *cobra.Command {
+	cmd := &cobra.Command{
+		Use: use,
+		Long: "Export agent packages, versions, the process module config and image references of a Dynatrace environment " +
+			"into a directory or an OCI registry, to be used as deployment API mirror in air-gapped clusters",
+		RunE: builder.buildRun(),
+	}
+
+	addFlags(cmd)
+
+	cmd.SilenceUsage = true
+
+	return cmd
+}
+
+func addFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringVar(&apiUrlFlagValue, apiUrlFlagName, "", "Dynatrace API URL, e.g. https://<environment-id>.live.dynatrace.com/api")
+	cmd.PersistentFlags().StringVar(&apiTokenFlagValue, apiTokenFlagName, "", "Dynatrace API token, used for the image references")
+	cmd.PersistentFlags().StringVar(&paasTokenFlagValue, paasTokenFlagName, "", "Dynatrace PaaS token, used for the agent packages. Defaults to the API token.")
+	cmd.PersistentFlags().StringVar(&outputFlagValue, outputFlagName, defaultOutput, "Directory the mirror is written to.")
+	cmd.PersistentFlags().StringVar(&osFlagValue, osFlagName, dtclient.OsUnix, "Operating system of the exported agent packages.")
+	cmd.PersistentFlags().StringSliceVar(&installerTypesFlagValue, installerTypesFlagName, []string{dtclient.InstallerTypePaaS, dtclient.InstallerTypeDefault}, "Installer types of the exported agent packages.")
+	cmd.PersistentFlags().StringSliceVar(&flavorsFlagValue, flavorsFlagName, []string{arch.FlavorDefault, arch.FlavorMultidistro}, "Flavors of the exported agent packages, the default installer type is always exported with the default flavor.")
+	cmd.PersistentFlags().StringSliceVar(&archsFlagValue, archsFlagName, []string{arch.ArchX86}, "Architectures of the exported agent packages.")
+	cmd.PersistentFlags().StringSliceVar(&technologiesFlagValue, technologiesFlagName, nil, "Technologies contained in the exported agent packages, all by default.")
+	cmd.PersistentFlags().IntVar(&versionsFlagValue, versionsFlagName, defaultVersions, "Number of the most recent agent versions which are exported.")
+	cmd.PersistentFlags().StringVar(&imageRegistryFlagValue, imageRegistryFlagName, "", "Registry the images were copied to, the exported image references are changed to point to it.")
+	cmd.PersistentFlags().StringVar(&registryFlagValue, registryFlagName, "", "Image reference the mirror is pushed to as OCI artifact, in addition to writing it to the output directory.")
+	cmd.PersistentFlags().BoolVar(&skipCertCheckFlagValue, skipCertCheckFlagName, false, "Skip the certificate validation of the Dynatrace API.")
+}
+
+func (builder CommandBuilder) buildRun() func(*cobra.Command, []string) error {
+	return func(cmd *cobra.Command, _ []string) error {
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+
+		if apiUrlFlagValue == "" {
+			return errors.Errorf("--%s is required", apiUrlFlagName)
+		}
+
+		if versionsFlagValue < 1 {
+			return errors.Errorf("--%s must be at least 1", versionsFlagName)
+		}
+
+		paasToken := paasTokenFlagValue
+		if paasToken == "" {
+			paasToken = apiTokenFlagValue
+		}
+
+		dynatraceClient, err := dtclient.NewClient(apiUrlFlagValue, apiTokenFlagValue, paasToken,
+			dtclient.SkipCertificateValidation(skipCertCheckFlagValue))
+		if err != nil {
+			return err
+		}
+
+		err = newExporter(dynatraceClient, outputFlagValue, exportOptions{
+			os:             osFlagValue,
+			installerTypes: installerTypesFlagValue,
+			flavors:        flavorsFlagValue,
+			archs:          archsFlagValue,
+			technologies:   technologiesFlagValue,
+			versions:       versionsFlagValue,
+			imageRegistry:  imageRegistryFlagValue,
+		}).export(ctx)
+		if err != nil {
+			return err
+		}
+
+		log.Info("mirror exported", "directory", outputFlagValue)
+
+		if registryFlagValue == "" {
+			return nil
+		}
+
+		err = push(ctx, outputFlagValue, registryFlagValue)
+		if err != nil {
+			return err
+		}
+
+		log.Info("mirror pushed", "image", registryFlagValue)
+		return nil
+	}
+}
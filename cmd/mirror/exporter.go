@@ -0,0 +1,220 @@
+package mirror
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/arch"
+	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
+	"github.com/Dynatrace/dynatrace-operator/pkg/version"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/pkg/errors"
+)
+
+const (
+	dirPermissions  = 0755
+	filePermissions = 0644
+)
+
+type exportOptions struct {
+	os             string
+	installerTypes []string
+	flavors        []string
+	archs          []string
+	technologies   []string
+	versions       int
+	imageRegistry  string
+}
+
+// exporter writes the responses of the deployment API into a directory, with the layout read by dtclient.Mirror
+type exporter struct {
+	dtc  dtclient.Client
+	dir  string
+	opts exportOptions
+}
+
+func newExporter(dtc dtclient.Client, dir string, opts exportOptions) *exporter {
+	return &exporter{
+		dtc:  dtc,
+		dir:  dir,
+		opts: opts,
+	}
+}
+
+func (exporter *exporter) export(ctx context.Context) error {
+	for _, installerType := range exporter.opts.installerTypes {
+		for _, flavor := range exporter.flavors(installerType) {
+			for _, agentArch := range exporter.opts.archs {
+				err := exporter.exportAgents(ctx, installerType, flavor, agentArch)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	err := exporter.exportProcessModuleConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	return exporter.exportImages(ctx)
+}
+
+// flavors returns the exported flavors of the installer type, the default installer type only has the default flavor
+func (exporter *exporter) flavors(installerType string) []string {
+	if installerType == dtclient.InstallerTypeDefault {
+		return []string{arch.FlavorDefault}
+	}
+	return exporter.opts.flavors
+}
+
+func (exporter *exporter) exportAgents(ctx context.Context, installerType, flavor, agentArch string) error {
+	availableVersions, err := exporter.dtc.GetAgentVersions(ctx, exporter.opts.os, installerType, flavor, agentArch)
+	if err != nil {
+		return errors.WithMessagef(err, "failed to get agent versions of %s/%s/%s/%s", exporter.opts.os, installerType, flavor, agentArch)
+	}
+
+	versions := newestVersions(availableVersions, exporter.opts.versions)
+	if len(versions) == 0 {
+		log.Info("no agent versions available", "os", exporter.opts.os, "type", installerType, "flavor", flavor, "arch", agentArch)
+		return nil
+	}
+
+	for _, agentVersion := range versions {
+		err = exporter.exportAgent(ctx, installerType, flavor, agentArch, agentVersion)
+		if err != nil {
+			return err
+		}
+	}
+
+	return exporter.writeJSON(dtclient.MirrorAgentVersionsPath(exporter.opts.os, installerType, flavor, agentArch), dtclient.MirrorAgentVersions{
+		LatestAgentVersion: versions[0],
+		AvailableVersions:  versions,
+	})
+}
+
+func (exporter *exporter) exportAgent(ctx context.Context, installerType, flavor, agentArch, agentVersion string) error {
+	agentPath := exporter.path(dtclient.MirrorAgentPath(exporter.opts.os, installerType, flavor, agentArch, agentVersion))
+	if _, err := os.Stat(agentPath); err == nil {
+		log.Info("agent package already exported", "path", agentPath)
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(agentPath), dirPermissions); err != nil {
+		return errors.WithStack(err)
+	}
+
+	// the package is downloaded to a temporary file, so an interrupted export isn't mistaken for a complete package
+	tmpFile, err := os.CreateTemp(filepath.Dir(agentPath), filepath.Base(agentPath)+".*")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	log.Info("exporting agent package", "os", exporter.opts.os, "type", installerType, "flavor", flavor, "arch", agentArch, "version", agentVersion)
+
+	err = exporter.dtc.GetAgent(ctx, exporter.opts.os, installerType, flavor, agentArch, agentVersion, exporter.opts.technologies, false, tmpFile)
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return errors.WithMessagef(err, "failed to export agent package %s", agentPath)
+	}
+
+	return errors.WithStack(os.Rename(tmpFile.Name(), agentPath))
+}
+
+func (exporter *exporter) exportProcessModuleConfig(ctx context.Context) error {
+	processModuleConfig, err := exporter.dtc.GetProcessModuleConfig(ctx, 0)
+	if err != nil {
+		return errors.WithMessage(err, "failed to get process module config")
+	}
+
+	return exporter.writeJSON(dtclient.MirrorProcessModuleConfigPath, processModuleConfig)
+}
+
+// exportImages exports the references of the latest images, images which aren't available for the environment are left out
+func (exporter *exporter) exportImages(ctx context.Context) error {
+	images := dtclient.MirrorImages{
+		OneAgent:    exporter.latestImage(ctx, "oneagent", exporter.dtc.GetLatestOneAgentImage),
+		CodeModules: exporter.latestImage(ctx, "codemodules", exporter.dtc.GetLatestCodeModulesImage),
+		ActiveGate:  exporter.latestImage(ctx, "activegate", exporter.dtc.GetLatestActiveGateImage),
+	}
+
+	return exporter.writeJSON(dtclient.MirrorImagesPath, images)
+}
+
+func (exporter *exporter) latestImage(ctx context.Context, component string, getLatestImage func(context.Context) (*dtclient.LatestImageInfo, error)) *dtclient.LatestImageInfo {
+	image, err := getLatestImage(ctx)
+	if err != nil {
+		log.Info("image reference not exported", "component", component, "error", err.Error())
+		return nil
+	}
+
+	if exporter.opts.imageRegistry == "" {
+		return image
+	}
+
+	repository, err := name.NewRepository(image.Source)
+	if err != nil {
+		log.Info("image reference not exported", "component", component, "error", err.Error())
+		return nil
+	}
+
+	return &dtclient.LatestImageInfo{
+		Source: exporter.opts.imageRegistry + "/" + repository.RepositoryStr(),
+		Tag:    image.Tag,
+	}
+}
+
+func (exporter *exporter) writeJSON(filePath string, value any) error {
+	content, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	target := exporter.path(filePath)
+	if err := os.MkdirAll(filepath.Dir(target), dirPermissions); err != nil {
+		return errors.WithStack(err)
+	}
+
+	return errors.WithStack(os.WriteFile(target, content, filePermissions))
+}
+
+func (exporter *exporter) path(filePath string) string {
+	return filepath.Join(exporter.dir, filepath.FromSlash(filePath))
+}
+
+// newestVersions returns up to count versions, the newest first, malformed versions are ignored
+func newestVersions(availableVersions []string, count int) []string {
+	type parsedVersion struct {
+		raw      string
+		semantic version.SemanticVersion
+	}
+
+	versions := make([]parsedVersion, 0, len(availableVersions))
+	for _, availableVersion := range availableVersions {
+		semanticVersion, err := version.ExtractSemanticVersion(availableVersion)
+		if err != nil {
+			log.Info("ignoring agent version", "version", availableVersion, "error", err.Error())
+			continue
+		}
+		versions = append(versions, parsedVersion{raw: availableVersion, semantic: semanticVersion})
+	}
+
+	slices.SortFunc(versions, func(a, b parsedVersion) int {
+		return version.CompareSemanticVersions(b.semantic, a.semantic)
+	})
+
+	versions = versions[:min(max(count, 0), len(versions))]
+	newest := make([]string, 0, len(versions))
+	for _, parsed := range versions {
+		newest = append(newest, parsed.raw)
+	}
+
+	return newest
+}
@@ -0,0 +1,114 @@
+package mirror
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/arch"
+	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
+	dtclientmock "github.com/Dynatrace/dynatrace-operator/test/mocks/pkg/clients/dynatrace"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testVersion       = "1.281.0.20231116-123456"
+	testOldVersion    = "1.279.0.20231018-123456"
+	testOldestVersion = "1.277.0.20230920-123456"
+)
+
+func TestExport(t *testing.T) {
+	mirrorDir := t.TempDir()
+
+	mockClient := dtclientmock.NewClient(t)
+	mockClient.On("GetAgentVersions", mock.Anything, dtclient.OsUnix, dtclient.InstallerTypePaaS, arch.FlavorMultidistro, arch.ArchX86).
+		Return([]string{testOldestVersion, testVersion, "invalid", testOldVersion}, nil).Once()
+	mockClient.On("GetAgentVersions", mock.Anything, dtclient.OsUnix, dtclient.InstallerTypeDefault, arch.FlavorDefault, arch.ArchX86).
+		Return([]string{testOldVersion}, nil).Once()
+	mockClient.On("GetAgent", mock.Anything, dtclient.OsUnix, mock.Anything, mock.Anything, arch.ArchX86, mock.Anything, []string{"java"}, false, mock.Anything).
+		Run(func(args mock.Arguments) {
+			_, _ = io.WriteString(args.Get(8).(io.Writer), "zip-"+args.String(2)+"-"+args.String(5))
+		}).
+		Return(nil).Times(3)
+	mockClient.On("GetProcessModuleConfig", mock.Anything, uint(0)).
+		Return(&dtclient.ProcessModuleConfig{Revision: 3, Properties: []dtclient.ProcessModuleProperty{{Section: "general", Key: "key", Value: "value"}}}, nil).Once()
+	mockClient.On("GetLatestOneAgentImage", mock.Anything).
+		Return(&dtclient.LatestImageInfo{Source: "test.live.dynatrace.com/linux/oneagent", Tag: "1.281.0"}, nil).Once()
+	mockClient.On("GetLatestCodeModulesImage", mock.Anything).
+		Return(&dtclient.LatestImageInfo{Source: "test.live.dynatrace.com/linux/codemodules", Tag: "1.281.0"}, nil).Once()
+	mockClient.On("GetLatestActiveGateImage", mock.Anything).
+		Return(nil, errors.New("not available")).Once()
+
+	err := newExporter(mockClient, mirrorDir, exportOptions{
+		os:             dtclient.OsUnix,
+		installerTypes: []string{dtclient.InstallerTypePaaS, dtclient.InstallerTypeDefault},
+		flavors:        []string{arch.FlavorMultidistro},
+		archs:          []string{arch.ArchX86},
+		technologies:   []string{"java"},
+		versions:       2,
+		imageRegistry:  "registry.example.com/dynatrace",
+	}).export(context.Background())
+	require.NoError(t, err)
+
+	// the exported mirror is read by the Dynatrace client
+	dtc, err := dtclient.NewClient("https://test.live.dynatrace.com/api", "api-token", "paas-token", dtclient.Mirror("file://"+mirrorDir))
+	require.NoError(t, err)
+
+	versions, err := dtc.GetAgentVersions(context.Background(), dtclient.OsUnix, dtclient.InstallerTypePaaS, arch.FlavorMultidistro, arch.ArchX86)
+	require.NoError(t, err)
+	assert.Equal(t, []string{testVersion, testOldVersion}, versions)
+
+	var agent bytes.Buffer
+	err = dtc.GetLatestAgent(context.Background(), dtclient.OsUnix, dtclient.InstallerTypePaaS, arch.FlavorMultidistro, arch.ArchX86, nil, false, &agent)
+	require.NoError(t, err)
+	assert.Equal(t, "zip-paas-"+testVersion, agent.String())
+
+	agent.Reset()
+	err = dtc.GetAgent(context.Background(), dtclient.OsUnix, dtclient.InstallerTypeDefault, arch.FlavorDefault, arch.ArchX86, testOldVersion, nil, false, &agent)
+	require.NoError(t, err)
+	assert.Equal(t, "zip-default-"+testOldVersion, agent.String())
+
+	processModuleConfig, err := dtc.GetProcessModuleConfig(context.Background(), 0)
+	require.NoError(t, err)
+	assert.Equal(t, uint(3), processModuleConfig.Revision)
+
+	image, err := dtc.GetLatestCodeModulesImage(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "registry.example.com/dynatrace/linux/codemodules:1.281.0", image.String())
+
+	_, err = dtc.GetLatestActiveGateImage(context.Background())
+	require.Error(t, err)
+}
+
+func TestExportAgentsFailure(t *testing.T) {
+	mockClient := dtclientmock.NewClient(t)
+	mockClient.On("GetAgentVersions", mock.Anything, dtclient.OsUnix, dtclient.InstallerTypePaaS, arch.FlavorDefault, arch.ArchX86).
+		Return([]string{testVersion}, nil).Once()
+	mockClient.On("GetAgent", mock.Anything, dtclient.OsUnix, dtclient.InstallerTypePaaS, arch.FlavorDefault, arch.ArchX86, testVersion, mock.Anything, false, mock.Anything).
+		Return(errors.New("connection reset")).Once()
+
+	mirrorDir := t.TempDir()
+	exporter := newExporter(mockClient, mirrorDir, exportOptions{
+		os:             dtclient.OsUnix,
+		installerTypes: []string{dtclient.InstallerTypePaaS},
+		flavors:        []string{arch.FlavorDefault},
+		archs:          []string{arch.ArchX86},
+		versions:       1,
+	})
+	require.Error(t, exporter.export(context.Background()))
+
+	assert.NoFileExists(t, exporter.path(dtclient.MirrorAgentPath(dtclient.OsUnix, dtclient.InstallerTypePaaS, arch.FlavorDefault, arch.ArchX86, testVersion)))
+	assert.NoFileExists(t, exporter.path(dtclient.MirrorAgentVersionsPath(dtclient.OsUnix, dtclient.InstallerTypePaaS, arch.FlavorDefault, arch.ArchX86)))
+}
+
+func TestNewestVersions(t *testing.T) {
+	availableVersions := []string{testOldVersion, "invalid", testVersion, testOldestVersion}
+
+	assert.Equal(t, []string{testVersion}, newestVersions(availableVersions, 1))
+	assert.Equal(t, []string{testVersion, testOldVersion, testOldestVersion}, newestVersions(availableVersions, 5))
+	assert.Empty(t, newestVersions(nil, 1))
+}
@@ -0,0 +1,101 @@
+package mirror
+
+import (
+	"archive/tar"
+	"context"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/pkg/errors"
+)
+
+// push uploads the mirror directory as single layer OCI image, so it can be copied into the air-gapped environment with the usual image tooling.
+// The registry credentials are taken from the docker config of the current user.
+func push(ctx context.Context, dir, reference string) error {
+	ref, err := name.ParseReference(reference)
+	if err != nil {
+		return errors.WithMessagef(err, "invalid image reference %s", reference)
+	}
+
+	layer, err := tarball.LayerFromOpener(func() (io.ReadCloser, error) {
+		return tarDirectory(dir), nil
+	}, tarball.WithMediaType(types.OCILayer))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	image, err := mutate.AppendLayers(empty.Image, layer)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	image = mutate.MediaType(image, types.OCIManifestSchema1)
+	image = mutate.ConfigMediaType(image, types.OCIConfigJSON)
+
+	err = remote.Write(ref, image, remote.WithContext(ctx), remote.WithAuthFromKeychain(authn.DefaultKeychain))
+	return errors.WithMessagef(err, "failed to push mirror to %s", reference)
+}
+
+// tarDirectory streams the files of the directory as tar archive, with paths relative to the directory
+func tarDirectory(dir string) io.ReadCloser {
+	reader, writer := io.Pipe()
+
+	go func() {
+		tarWriter := tar.NewWriter(writer)
+		err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil || path == dir {
+				return err
+			}
+			return addToTar(tarWriter, dir, path, entry)
+		})
+		if err == nil {
+			err = tarWriter.Close()
+		}
+		writer.CloseWithError(err)
+	}()
+
+	return reader
+}
+
+func addToTar(tarWriter *tar.Writer, dir, path string, entry fs.DirEntry) error {
+	info, err := entry.Info()
+	if err != nil {
+		return err
+	}
+
+	header, err := tar.FileInfoHeader(info, "")
+	if err != nil {
+		return err
+	}
+
+	relativePath, err := filepath.Rel(dir, path)
+	if err != nil {
+		return err
+	}
+	header.Name = filepath.ToSlash(relativePath)
+
+	if err := tarWriter.WriteHeader(header); err != nil {
+		return err
+	}
+
+	if !entry.Type().IsRegular() {
+		return nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = io.Copy(tarWriter, file)
+	return err
+}
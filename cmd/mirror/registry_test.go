@@ -0,0 +1,59 @@
+package mirror
+
+import (
+	"archive/tar"
+	"context"
+	"io"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPush(t *testing.T) {
+	registryServer := httptest.NewServer(registry.New())
+	defer registryServer.Close()
+
+	registryURL, err := url.Parse(registryServer.URL)
+	require.NoError(t, err)
+
+	mirrorDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(mirrorDir, "agent", "unix"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(mirrorDir, "agent", "unix", "versions.json"), []byte("{}"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(mirrorDir, "images.json"), []byte("{}"), 0644))
+
+	reference := registryURL.Host + "/dynatrace/mirror:latest"
+	require.NoError(t, push(context.Background(), mirrorDir, reference))
+
+	ref, err := name.ParseReference(reference)
+	require.NoError(t, err)
+	image, err := remote.Image(ref)
+	require.NoError(t, err)
+	layers, err := image.Layers()
+	require.NoError(t, err)
+	require.Len(t, layers, 1)
+
+	content, err := layers[0].Uncompressed()
+	require.NoError(t, err)
+	defer content.Close()
+
+	var files []string
+	tarReader := tar.NewReader(content)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		files = append(files, header.Name)
+	}
+
+	assert.ElementsMatch(t, []string{"agent", "agent/unix", "agent/unix/versions.json", "images.json"}, files)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/cmd/config"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme"
 	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/httptransport"
 	"github.com/Dynatrace/dynatrace-operator/pkg/oci/dockerkeychain"
 	"github.com/Dynatrace/dynatrace-operator/pkg/oci/registry"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/env"
@@ -160,15 +161,14 @@ func runChecksForDynakube(ctx context.Context, baseLog logr.Logger, apiReader cl
 	return checkProxySettings(ctx, log, apiReader, &dynakube)
 }
 
+// createTransport uses the transport of the given http client as base if it has one,
+// otherwise the transport is shared with the Dynatrace client created for the same DynaKube
 func createTransport(ctx context.Context, apiReader client.Reader, dynakube *dynatracev1beta1.DynaKube, httpClient *http.Client) (*http.Transport, error) {
-	var transport *http.Transport
 	if httpClient != nil && httpClient.Transport != nil {
-		transport = httpClient.Transport.(*http.Transport).Clone()
-	} else {
-		transport = http.DefaultTransport.(*http.Transport).Clone()
+		return registry.PrepareTransportForDynaKube(ctx, apiReader, httpClient.Transport.(*http.Transport).Clone(), dynakube)
 	}
 
-	return registry.PrepareTransportForDynaKube(ctx, apiReader, transport, dynakube)
+	return httptransport.Shared().ForDynaKube(ctx, apiReader, dynakube)
 }
 
 func getDynakubes(ctx context.Context, log logr.Logger, apiReader client.Reader, namespaceName string, dynakubeName string) ([]dynatracev1beta1.DynaKube, error) {
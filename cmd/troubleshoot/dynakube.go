@@ -3,8 +3,10 @@ package troubleshoot
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/apierrors"
 	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/dtpullsecret"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/dynatraceclient"
@@ -104,6 +106,11 @@ func checkIfDynatraceApiSecretHasApiToken(ctx context.Context, baseLog logr.Logg
 		return nil, errors.Wrapf(err, "'%s:%s' secret is missing or invalid", dynakube.Namespace, dynakube.Tokens())
 	}
 
+	if tokens.UsesOAuth() {
+		logInfof(log, "secret tokens '%s' and '%s' exist, the oauth client is used to access the API", dtclient.DynatraceOAuthClientID, dtclient.DynatraceOAuthClientSecret)
+		return tokens, nil
+	}
+
 	_, hasApiToken := tokens[dtclient.DynatraceApiToken]
 	if !hasApiToken {
 		return nil, errors.New(fmt.Sprintf("'%s' token is missing in '%s:%s' secret", dtclient.DynatraceApiToken, dynakube.Namespace, dynakube.Tokens()))
@@ -151,8 +158,13 @@ func checkDynatraceApiTokenScopes(ctx context.Context, baseLog logr.Logger, apiR
 		return errors.Wrapf(err, "invalid '%s:%s' secret", dynakube.Namespace, dynakube.Tokens())
 	}
 
-	if err = tokens.VerifyScopes(dtc); err != nil {
-		return errors.Wrapf(err, "invalid '%s:%s' secret", dynakube.Namespace, dynakube.Tokens())
+	missingOptionalScopes, err := tokens.VerifyScopes(ctx, dtc)
+	if err != nil {
+		return wrapApiError(err, fmt.Sprintf("invalid '%s:%s' secret", dynakube.Namespace, dynakube.Tokens()))
+	}
+
+	if len(missingOptionalScopes) > 0 {
+		logWarningf(log, "token scopes are missing, dependent features are disabled: [ %s ]", strings.Join(missingOptionalScopes, ", "))
 	}
 
 	logInfof(log, "token scopes are valid")
@@ -173,15 +185,38 @@ func checkApiUrlForLatestAgentVersion(ctx context.Context, baseLog logr.Logger,
 		return errors.Wrap(err, "failed to build DynatraceAPI client")
 	}
 
-	_, err = dtc.GetLatestAgentVersion(dtclient.OsUnix, dtclient.InstallerTypeDefault)
+	_, err = dtc.GetLatestAgentVersion(ctx, dtclient.OsUnix, dtclient.InstallerTypeDefault)
 	if err != nil {
-		return errors.Wrap(err, "failed to connect to DynatraceAPI")
+		return wrapApiError(err, "failed to connect to DynatraceAPI")
+	}
+
+	if dynatraceApiSecretTokens.UsesOAuth() {
+		logInfof(log, "OAuth client is valid, can pull latest agent version")
+		return nil
 	}
 
 	logInfof(log, "API token is valid, can pull latest agent version")
 	return nil
 }
 
+// apiErrorHints explain how to resolve the classified errors of the Dynatrace API
+var apiErrorHints = map[error]string{
+	apierrors.ErrUnauthorized: "the token or the oauth client is invalid or expired",
+	apierrors.ErrForbidden:    "the token or the oauth client lacks permissions, check the required scopes",
+	apierrors.ErrNotFound:     "check that the apiUrl of the DynaKube is correct and ends with /api",
+	apierrors.ErrRateLimited:  "the request limit of the Dynatrace environment is reached, try again later",
+	apierrors.ErrServer:       "the Dynatrace API is currently unavailable, try again later",
+	apierrors.ErrNetwork:      "the Dynatrace API can't be reached, check the apiUrl, the proxy and the network policies",
+	apierrors.ErrTLS:          "the certificate of the Dynatrace API isn't trusted, check the trustedCAs of the DynaKube",
+}
+
+func wrapApiError(err error, message string) error {
+	if hint, ok := apiErrorHints[apierrors.Class(err)]; ok {
+		return errors.Wrapf(err, "%s, %s", message, hint)
+	}
+	return errors.Wrap(err, message)
+}
+
 func checkPullSecretExists(ctx context.Context, baseLog logr.Logger, apiReader client.Reader, dynakube *dynatracev1beta1.DynaKube) (corev1.Secret, error) {
 	log := baseLog.WithName(dynakubeCheckLoggerName)
 
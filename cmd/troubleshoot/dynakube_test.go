@@ -2,10 +2,13 @@ package troubleshoot
 
 import (
 	"context"
+	"net/http"
 	"testing"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme"
 	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/apierrors"
+	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/address"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
@@ -131,6 +134,35 @@ func TestDynatraceSecret(t *testing.T) {
 		_, err := checkIfDynatraceApiSecretHasApiToken(context.Background(), getNullLogger(t), clt, dynakube)
 		assert.NoErrorf(t, err, "Dynatrace secret does not have required tokens")
 	})
+	t.Run("Dynatrace secret has oauth client credentials instead of apiToken", func(t *testing.T) {
+		dynakube := testNewDynakubeBuilder(testNamespace, testDynakube).withTokens(testDynatraceSecret).build()
+		clt := fake.NewClientBuilder().
+			WithScheme(scheme.Scheme).
+			WithObjects(
+				dynakube,
+				testBuildNamespace(testNamespace),
+				testNewSecretBuilder(testNamespace, testDynatraceSecret).dataAppend("oauthClientId", "client-id").dataAppend("oauthClientSecret", "client-secret").dataAppend("paasToken", testPaasToken).build(),
+			).
+			Build()
+
+		tokens, err := checkIfDynatraceApiSecretHasApiToken(context.Background(), getNullLogger(t), clt, dynakube)
+		assert.NoErrorf(t, err, "Dynatrace secret does not have required tokens")
+		assert.True(t, tokens.UsesOAuth())
+	})
+	t.Run("Dynatrace secret has only oauth client credentials", func(t *testing.T) {
+		dynakube := testNewDynakubeBuilder(testNamespace, testDynakube).withTokens(testDynatraceSecret).build()
+		clt := fake.NewClientBuilder().
+			WithScheme(scheme.Scheme).
+			WithObjects(
+				dynakube,
+				testBuildNamespace(testNamespace),
+				testNewSecretBuilder(testNamespace, testDynatraceSecret).dataAppend("oauthClientId", "client-id").dataAppend("oauthClientSecret", "client-secret").build(),
+			).
+			Build()
+
+		_, err := checkIfDynatraceApiSecretHasApiToken(context.Background(), getNullLogger(t), clt, dynakube)
+		assert.Errorf(t, err, "paas or api token is missing")
+	})
 	t.Run("Dynatrace secret - apiToken is missing", func(t *testing.T) {
 		dynakube := testNewDynakubeBuilder(testNamespace, testDynakube).withTokens(testDynatraceSecret).build()
 		clt := fake.NewClientBuilder().
@@ -147,6 +179,20 @@ func TestDynatraceSecret(t *testing.T) {
 	})
 }
 
+func TestWrapApiError(t *testing.T) {
+	t.Run("classified errors get a hint", func(t *testing.T) {
+		err := wrapApiError(dtclient.ServerError{Code: http.StatusUnauthorized, Message: "Token Authentication failed"}, "failed to connect to DynatraceAPI")
+
+		assert.EqualError(t, err, "failed to connect to DynatraceAPI, the token or the oauth client is invalid or expired: dynatrace server error 401: Token Authentication failed")
+		assert.ErrorIs(t, err, apierrors.ErrUnauthorized)
+	})
+	t.Run("other errors are only wrapped", func(t *testing.T) {
+		err := wrapApiError(errors.New("unexpected"), "failed to connect to DynatraceAPI")
+
+		assert.EqualError(t, err, "failed to connect to DynatraceAPI: unexpected")
+	})
+}
+
 func TestPullSecret(t *testing.T) {
 	t.Run("custom pull secret exists", func(t *testing.T) {
 		dynakube := testNewDynakubeBuilder(testNamespace, testDynakube).withCustomPullSecret(testSecretName).build()
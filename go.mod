@@ -31,6 +31,7 @@ require (
 	golang.org/x/exp v0.0.0-20230817173708-d852ddb80c63
 	golang.org/x/net v0.18.0
 	golang.org/x/oauth2 v0.14.0
+	golang.org/x/sync v0.3.0
 	golang.org/x/sys v0.14.0
 	google.golang.org/grpc v1.59.0
 	istio.io/api v1.20.0
@@ -100,7 +101,6 @@ require (
 	github.com/yusufpapurcu/wmi v1.2.3 // indirect
 	go.opentelemetry.io/proto/otlp v1.0.0 // indirect
 	go.uber.org/multierr v1.11.0 // indirect
-	golang.org/x/sync v0.3.0 // indirect
 	golang.org/x/term v0.14.0 // indirect
 	golang.org/x/text v0.14.0 // indirect
 	golang.org/x/time v0.3.0 // indirect
@@ -0,0 +1,42 @@
+// +kubebuilder:object:generate=true
+// +k8s:openapi-gen=true
+package maintenancewindow
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Window defines a recurring period in which the operator is allowed to update already deployed components.
+// It is either defined by a cron schedule and a duration or by weekdays and a time range.
+type Window struct {
+	// Cron expression with the fields minute, hour, day of month, month and day of week that defines when the window opens, e.g. `0 2 * * SAT`.
+	// Requires a duration and can't be combined with days, start and end.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Schedule",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:text"}
+	Schedule string `json:"schedule,omitempty"`
+
+	// How long the window stays open after it was opened by the schedule, e.g. `2h` or `90m`. At most 7 days.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Duration",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:text"}
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// Weekdays on which the window opens, e.g. `Mon` or `Saturday`. The window opens every day if no day is given.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Days",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:text"}
+	Days []string `json:"days,omitempty"`
+
+	// Time of day the window opens, in the format HH:MM.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Start",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:text"}
+	Start string `json:"start,omitempty"`
+
+	// Time of day the window closes, in the format HH:MM. If it is not after start, the window closes on the next day.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="End",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:text"}
+	End string `json:"end,omitempty"`
+
+	// IANA name of the time zone the window is defined in, e.g. `Europe/Vienna`. Defaults to UTC.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Time zone",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:text"}
+	TimeZone string `json:"timeZone,omitempty"`
+}
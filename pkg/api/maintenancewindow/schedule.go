@@ -0,0 +1,341 @@
+package maintenancewindow
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	// the operator image doesn't ship a time zone database
+	_ "time/tzdata"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	maxDuration = 7 * 24 * time.Hour
+
+	// a schedule like `0 0 29 2 *` opens only every fourth year
+	searchHorizon = 4*366*24*time.Hour + maxDuration
+
+	timeOfDayLayout = "15:04"
+)
+
+var (
+	weekdayNames = map[string]int{
+		"sun": 0, "sunday": 0,
+		"mon": 1, "monday": 1,
+		"tue": 2, "tuesday": 2,
+		"wed": 3, "wednesday": 3,
+		"thu": 4, "thursday": 4,
+		"fri": 5, "friday": 5,
+		"sat": 6, "saturday": 6,
+	}
+	monthNames = map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}
+)
+
+// schedule is the parsed form of a Window, both ways to define a window are mapped to the start times of the window and its duration
+// +kubebuilder:object:generate=false
+type schedule struct {
+	minutes     uint64
+	hours       uint64
+	daysOfMonth uint64
+	months      uint64
+	daysOfWeek  uint64
+
+	// in cron, if both day fields are restricted, a day matching either of them is enough
+	anyDayOfMonth bool
+	anyDayOfWeek  bool
+
+	duration time.Duration
+	location *time.Location
+}
+
+// cronField describes the allowed values of one field of a cron expression
+// +kubebuilder:object:generate=false
+type cronField struct {
+	name  string
+	min   int
+	max   int
+	names map[string]int
+}
+
+var cronFields = [5]cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: monthNames},
+	// 7 is an alias for sunday
+	{name: "day of week", min: 0, max: 7, names: weekdayNames},
+}
+
+// Validate returns an error if the window is not defined correctly
+func (window Window) Validate() error {
+	_, err := window.parse()
+	return err
+}
+
+// IsOpen returns true if the given time is inside the window
+func (window Window) IsOpen(now time.Time) (bool, error) {
+	parsed, err := window.parse()
+	if err != nil {
+		return false, err
+	}
+	return parsed.isOpen(now), nil
+}
+
+// DeferUpdatesUntil returns nil if updates are allowed at the given time, because no window is defined or one of the windows is open.
+// Otherwise, it returns the time the next window opens.
+func DeferUpdatesUntil(windows []Window, now time.Time) (*metav1.Time, error) {
+	if len(windows) == 0 {
+		return nil, nil
+	}
+
+	var nextOpening *time.Time
+	for i, window := range windows {
+		parsed, err := window.parse()
+		if err != nil {
+			return nil, errors.WithMessagef(err, "invalid maintenance window %d", i)
+		}
+		if parsed.isOpen(now) {
+			return nil, nil
+		}
+
+		opening, found := parsed.nextStart(now)
+		if found && (nextOpening == nil || opening.Before(*nextOpening)) {
+			nextOpening = &opening
+		}
+	}
+
+	if nextOpening == nil {
+		return nil, errors.New("none of the maintenance windows will ever open")
+	}
+	deferredUntil := metav1.NewTime(*nextOpening)
+	return &deferredUntil, nil
+}
+
+func (window Window) parse() (*schedule, error) {
+	location, err := time.LoadLocation(window.TimeZone)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "unknown time zone %q", window.TimeZone)
+	}
+
+	var parsed *schedule
+	switch {
+	case window.Schedule != "" && (len(window.Days) > 0 || window.Start != "" || window.End != ""):
+		return nil, errors.New("schedule can't be combined with days, start and end")
+	case window.Schedule != "":
+		parsed, err = parseCron(window.Schedule, window.Duration)
+	case window.Start != "" || window.End != "":
+		parsed, err = parseTimeRange(window.Days, window.Start, window.End, window.Duration)
+	default:
+		return nil, errors.New("either schedule or start and end have to be set")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	parsed.location = location
+	return parsed, nil
+}
+
+func parseCron(expression string, duration *metav1.Duration) (*schedule, error) {
+	if duration == nil || duration.Duration <= 0 || duration.Duration > maxDuration {
+		return nil, errors.Errorf("schedule requires a duration greater than 0 and at most %s", maxDuration)
+	}
+
+	fields := strings.Fields(expression)
+	if len(fields) != len(cronFields) {
+		return nil, errors.Errorf("schedule %q has to consist of %d fields", expression, len(cronFields))
+	}
+
+	var values [len(cronFields)]uint64
+	for i, field := range fields {
+		parsedField, err := cronFields[i].parse(field)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "invalid schedule %q", expression)
+		}
+		values[i] = parsedField
+	}
+
+	daysOfWeek := values[4]
+	if daysOfWeek&(1<<7) != 0 {
+		daysOfWeek |= 1
+	}
+
+	return &schedule{
+		minutes:       values[0],
+		hours:         values[1],
+		daysOfMonth:   values[2],
+		months:        values[3],
+		daysOfWeek:    daysOfWeek,
+		anyDayOfMonth: strings.HasPrefix(fields[2], "*"),
+		anyDayOfWeek:  strings.HasPrefix(fields[4], "*"),
+		duration:      duration.Duration,
+	}, nil
+}
+
+// parse supports `*`, single values, ranges like `1-5`, steps like `*/15` or `0-30/10` and lists of them like `1,15`
+func (field cronField) parse(value string) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(value, ",") {
+		rangePart, step := part, 1
+		if before, after, found := strings.Cut(part, "/"); found {
+			parsedStep, err := strconv.Atoi(after)
+			if err != nil || parsedStep <= 0 {
+				return 0, errors.Errorf("invalid step %q in %s field", after, field.name)
+			}
+			rangePart, step = before, parsedStep
+		}
+
+		low, high := field.min, field.max
+		if rangePart != "*" {
+			lowPart, highPart, isRange := strings.Cut(rangePart, "-")
+			var err error
+			low, err = field.parseValue(lowPart)
+			if err != nil {
+				return 0, err
+			}
+			high = low
+			if isRange {
+				high, err = field.parseValue(highPart)
+				if err != nil {
+					return 0, err
+				}
+			} else if step > 1 {
+				high = field.max
+			}
+		}
+		if low > high {
+			return 0, errors.Errorf("invalid range %q in %s field", rangePart, field.name)
+		}
+
+		for i := low; i <= high; i += step {
+			bits |= 1 << uint(i)
+		}
+	}
+	return bits, nil
+}
+
+func (field cronField) parseValue(value string) (int, error) {
+	if named, ok := field.names[strings.ToLower(value)]; ok {
+		return named, nil
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed < field.min || parsed > field.max {
+		return 0, errors.Errorf("invalid value %q in %s field, has to be between %d and %d", value, field.name, field.min, field.max)
+	}
+	return parsed, nil
+}
+
+func parseTimeRange(days []string, start, end string, duration *metav1.Duration) (*schedule, error) {
+	if duration != nil {
+		return nil, errors.New("duration can only be used with a schedule, use start and end instead")
+	}
+
+	startTime, err := time.Parse(timeOfDayLayout, start)
+	if err != nil {
+		return nil, errors.Errorf("invalid start %q, has to be in the format HH:MM", start)
+	}
+	endTime, err := time.Parse(timeOfDayLayout, end)
+	if err != nil {
+		return nil, errors.Errorf("invalid end %q, has to be in the format HH:MM", end)
+	}
+
+	length := endTime.Sub(startTime)
+	if length <= 0 {
+		length += 24 * time.Hour
+	}
+
+	var daysOfWeek uint64
+	for _, day := range days {
+		weekday, ok := weekdayNames[strings.ToLower(day)]
+		if !ok {
+			return nil, errors.Errorf("invalid day %q", day)
+		}
+		daysOfWeek |= 1 << uint(weekday)
+	}
+
+	return &schedule{
+		minutes:       1 << uint(startTime.Minute()),
+		hours:         1 << uint(startTime.Hour()),
+		daysOfMonth:   allValues(cronFields[2]),
+		months:        allValues(cronFields[3]),
+		daysOfWeek:    daysOfWeek,
+		anyDayOfMonth: true,
+		anyDayOfWeek:  len(days) == 0,
+		duration:      length,
+	}, nil
+}
+
+func allValues(field cronField) uint64 {
+	bits, _ := field.parse("*")
+	return bits
+}
+
+// isOpen checks if the window was opened within its duration before now
+func (s *schedule) isOpen(now time.Time) bool {
+	now = now.In(s.location)
+	for start := now.Truncate(time.Minute); start.Add(s.duration).After(now); start = start.Add(-time.Minute) {
+		if s.isStart(start) {
+			return true
+		}
+	}
+	return false
+}
+
+// nextStart finds the next time the window opens after now, it skips days and hours that can't match
+func (s *schedule) nextStart(now time.Time) (time.Time, bool) {
+	current := now.In(s.location).Truncate(time.Minute).Add(time.Minute)
+	limit := now.Add(searchHorizon)
+
+	for current.Before(limit) {
+		var next time.Time
+		switch {
+		case !s.matchesDay(current):
+			next = time.Date(current.Year(), current.Month(), current.Day()+1, 0, 0, 0, 0, s.location)
+		case !has(s.hours, current.Hour()):
+			next = time.Date(current.Year(), current.Month(), current.Day(), current.Hour()+1, 0, 0, 0, s.location)
+		case has(s.minutes, current.Minute()):
+			return current, true
+		}
+
+		// daylight saving time transitions can move the wall clock backwards
+		if !next.After(current) {
+			next = current.Add(time.Minute)
+		}
+		current = next
+	}
+	return time.Time{}, false
+}
+
+func (s *schedule) isStart(t time.Time) bool {
+	return has(s.minutes, t.Minute()) && has(s.hours, t.Hour()) && s.matchesDay(t)
+}
+
+func (s *schedule) matchesDay(t time.Time) bool {
+	if !has(s.months, int(t.Month())) {
+		return false
+	}
+
+	dayOfMonth := has(s.daysOfMonth, t.Day())
+	dayOfWeek := has(s.daysOfWeek, int(t.Weekday()))
+	switch {
+	case s.anyDayOfMonth && s.anyDayOfWeek:
+		return true
+	case s.anyDayOfMonth:
+		return dayOfWeek
+	case s.anyDayOfWeek:
+		return dayOfMonth
+	default:
+		return dayOfMonth || dayOfWeek
+	}
+}
+
+func has(bits uint64, value int) bool {
+	return bits&(1<<uint(value)) != 0
+}
@@ -0,0 +1,123 @@
+package maintenancewindow
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// 2024-01-06 is a saturday
+var saturdayMorning = time.Date(2024, 1, 6, 9, 30, 0, 0, time.UTC)
+
+func duration(d time.Duration) *metav1.Duration {
+	return &metav1.Duration{Duration: d}
+}
+
+func TestValidate(t *testing.T) {
+	valid := []Window{
+		{Schedule: "0 2 * * SAT", Duration: duration(2 * time.Hour)},
+		{Schedule: "*/15 1-3,22 1,15 jan-jun 1-5", Duration: duration(10 * time.Minute), TimeZone: "Europe/Vienna"},
+		{Schedule: "0 0 * * 7", Duration: duration(time.Hour)},
+		{Days: []string{"Mon", "friday"}, Start: "22:00", End: "02:00"},
+		{Start: "01:00", End: "03:30", TimeZone: "America/New_York"},
+	}
+	for _, window := range valid {
+		assert.NoError(t, window.Validate(), "%+v", window)
+	}
+
+	invalid := []Window{
+		{},
+		{Schedule: "0 2 * * SAT"},
+		{Schedule: "0 2 * * SAT", Duration: duration(8 * 24 * time.Hour)},
+		{Schedule: "0 2 * *", Duration: duration(time.Hour)},
+		{Schedule: "60 2 * * *", Duration: duration(time.Hour)},
+		{Schedule: "0 5-2 * * *", Duration: duration(time.Hour)},
+		{Schedule: "*/0 2 * * *", Duration: duration(time.Hour)},
+		{Schedule: "0 2 * * *", Duration: duration(time.Hour), Start: "01:00"},
+		{Start: "25:00", End: "02:00"},
+		{Start: "01:00"},
+		{Start: "01:00", End: "02:00", Days: []string{"someday"}},
+		{Start: "01:00", End: "02:00", Duration: duration(time.Hour)},
+		{Start: "01:00", End: "02:00", TimeZone: "Mars/Olympus_Mons"},
+	}
+	for _, window := range invalid {
+		assert.Error(t, window.Validate(), "%+v", window)
+	}
+}
+
+func TestIsOpen(t *testing.T) {
+	testCases := []struct {
+		name   string
+		window Window
+		now    time.Time
+		open   bool
+	}{
+		{"inside cron window", Window{Schedule: "0 8 * * SAT", Duration: duration(2 * time.Hour)}, saturdayMorning, true},
+		{"after cron window", Window{Schedule: "0 8 * * SAT", Duration: duration(time.Hour)}, saturdayMorning, false},
+		{"cron window on other day", Window{Schedule: "0 8 * * SUN", Duration: duration(2 * time.Hour)}, saturdayMorning, false},
+		{"cron window closes exactly at end", Window{Schedule: "30 8 * * *", Duration: duration(time.Hour)}, saturdayMorning, false},
+		{"cron window opens exactly at start", Window{Schedule: "30 9 * * *", Duration: duration(time.Minute)}, saturdayMorning, true},
+		{"cron window from previous day", Window{Schedule: "0 22 * * FRI", Duration: duration(12 * time.Hour)}, saturdayMorning, true},
+		{"either day field matches", Window{Schedule: "0 9 1 * SAT", Duration: duration(time.Hour)}, saturdayMorning, true},
+		{"inside time range", Window{Days: []string{"Saturday"}, Start: "09:00", End: "10:00"}, saturdayMorning, true},
+		{"time range on every day", Window{Start: "09:00", End: "10:00"}, saturdayMorning, true},
+		{"time range on other day", Window{Days: []string{"Sun"}, Start: "09:00", End: "10:00"}, saturdayMorning, false},
+		{"time range over midnight", Window{Days: []string{"fri"}, Start: "22:00", End: "10:00"}, saturdayMorning, true},
+		{"time range in other time zone", Window{Start: "09:00", End: "10:00", TimeZone: "Europe/Vienna"}, saturdayMorning, false},
+		{"time range in matching time zone", Window{Start: "10:00", End: "11:00", TimeZone: "Europe/Vienna"}, saturdayMorning, true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			open, err := testCase.window.IsOpen(testCase.now)
+			require.NoError(t, err)
+			assert.Equal(t, testCase.open, open)
+		})
+	}
+}
+
+func TestDeferUpdatesUntil(t *testing.T) {
+	t.Run("updates are allowed without windows", func(t *testing.T) {
+		deferredUntil, err := DeferUpdatesUntil(nil, saturdayMorning)
+		require.NoError(t, err)
+		assert.Nil(t, deferredUntil)
+	})
+	t.Run("updates are allowed if any window is open", func(t *testing.T) {
+		windows := []Window{
+			{Schedule: "0 2 * * SUN", Duration: duration(time.Hour)},
+			{Start: "09:00", End: "10:00"},
+		}
+		deferredUntil, err := DeferUpdatesUntil(windows, saturdayMorning)
+		require.NoError(t, err)
+		assert.Nil(t, deferredUntil)
+	})
+	t.Run("updates are deferred until the earliest next window", func(t *testing.T) {
+		windows := []Window{
+			{Schedule: "0 2 * * SUN", Duration: duration(time.Hour)},
+			{Days: []string{"Sat"}, Start: "23:00", End: "23:30", TimeZone: "Europe/Vienna"},
+		}
+		deferredUntil, err := DeferUpdatesUntil(windows, saturdayMorning)
+		require.NoError(t, err)
+		require.NotNil(t, deferredUntil)
+		assert.True(t, time.Date(2024, 1, 6, 22, 0, 0, 0, time.UTC).Equal(deferredUntil.Time), deferredUntil.String())
+	})
+	t.Run("next window in a later month", func(t *testing.T) {
+		windows := []Window{{Schedule: "15 3 29 2 *", Duration: duration(time.Hour)}}
+		deferredUntil, err := DeferUpdatesUntil(windows, saturdayMorning)
+		require.NoError(t, err)
+		require.NotNil(t, deferredUntil)
+		assert.True(t, time.Date(2024, 2, 29, 3, 15, 0, 0, time.UTC).Equal(deferredUntil.Time), deferredUntil.String())
+	})
+	t.Run("error if a window never opens", func(t *testing.T) {
+		windows := []Window{{Schedule: "0 0 31 2 *", Duration: duration(time.Hour)}}
+		_, err := DeferUpdatesUntil(windows, saturdayMorning)
+		require.Error(t, err)
+	})
+	t.Run("error for invalid window", func(t *testing.T) {
+		_, err := DeferUpdatesUntil([]Window{{Schedule: "invalid"}}, saturdayMorning)
+		require.Error(t, err)
+	})
+}
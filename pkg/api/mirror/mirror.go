@@ -0,0 +1,21 @@
+// +kubebuilder:object:generate=true
+// +k8s:openapi-gen=true
+package mirror
+
+// Spec configures a mirror of the Dynatrace deployment API, for clusters which can't reach the Dynatrace environment.
+// Agent packages, versions, the process module config and image references are read from the mirror instead of the Dynatrace API.
+// The mirror is created with the `dynatrace-operator mirror` command.
+type Spec struct {
+	// URL of the mirror, either a web server (http:// or https://) or a directory (file://).
+	// A directory has to be mounted into the operator, and into the CSI driver or the application pods when code modules are downloaded.
+	// +kubebuilder:validation:Pattern=`^(https?|file)://`
+	URL string `json:"url"`
+}
+
+// GetURL returns the URL of the mirror, or an empty string if no mirror is configured
+func (spec *Spec) GetURL() string {
+	if spec == nil {
+		return ""
+	}
+	return spec.URL
+}
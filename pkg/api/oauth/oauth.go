@@ -0,0 +1,33 @@
+// +kubebuilder:object:generate=true
+// +k8s:openapi-gen=true
+package oauth
+
+// Spec configures the OAuth client the operator uses instead of the api and paas token to authenticate against the Dynatrace API.
+// The client id and secret are read from the token source with the keys oauthClientId and oauthClientSecret.
+// Pull secrets and the init secret of the code modules still need a paas or api token, as they are used outside of the operator.
+type Spec struct {
+	// Token endpoint URL of Dynatrace SSO. Defaults to https://sso.dynatrace.com/sso/oauth2/token.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^https?://`
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// URN identifying the account or environment the OAuth client belongs to, e.g. urn:dtaccount:<account-uuid>.
+	// +optional
+	Resource string `json:"resource,omitempty"`
+}
+
+// GetEndpoint returns the configured token endpoint, or an empty string if the default one should be used
+func (spec *Spec) GetEndpoint() string {
+	if spec == nil {
+		return ""
+	}
+	return spec.Endpoint
+}
+
+// GetResource returns the configured resource, or an empty string if none is configured
+func (spec *Spec) GetResource() string {
+	if spec == nil {
+		return ""
+	}
+	return spec.Resource
+}
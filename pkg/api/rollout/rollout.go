@@ -0,0 +1,84 @@
+// +kubebuilder:object:generate=true
+// +k8s:openapi-gen=true
+package rollout
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+type FailurePolicy string
+
+const (
+	// FailurePolicyPause stops updating further nodes until the updated pods are ready again
+	FailurePolicyPause FailurePolicy = "Pause"
+
+	// FailurePolicyRollback reverts the DaemonSet to the previous template and restarts the already updated pods with it
+	FailurePolicyRollback FailurePolicy = "Rollback"
+)
+
+type Phase string
+
+const (
+	PhaseProgressing Phase = "Progressing"
+	PhaseCompleted   Phase = "Completed"
+	PhasePaused      Phase = "Paused"
+	PhaseRolledBack  Phase = "RolledBack"
+)
+
+// Strategy defines a staged rollout of the pods of a DaemonSet.
+// The canary nodes are updated first, afterwards the remaining nodes are updated pool by pool in batches.
+// Each stage is only started after all already updated pods are ready.
+type Strategy struct {
+	// Label selector of the nodes that are updated first.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Canary nodes",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:selector:core:v1:Node"}
+	Canary *metav1.LabelSelector `json:"canary,omitempty"`
+
+	// Node label whose values group the remaining nodes into node pools, e.g. `topology.kubernetes.io/zone`.
+	// The pools are updated one after the other, in alphabetical order of the label values. Nodes without the label are updated last.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Node pool label",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:text"}
+	NodePoolLabel string `json:"nodePoolLabel,omitempty"`
+
+	// Number or percentage of the nodes of a node pool that are updated at the same time. Defaults to 25%.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Batch size",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:text"}
+	BatchSize *intstr.IntOrString `json:"batchSize,omitempty"`
+
+	// How long the updated pods of a stage may take to become ready before the stage is considered failed. Defaults to 10m.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Health timeout",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:text"}
+	HealthTimeout *metav1.Duration `json:"healthTimeout,omitempty"`
+
+	// What happens if a stage failed, `Pause` stops the rollout until the updated pods are ready again,
+	// `Rollback` reverts the updated pods to the previous version. Defaults to Pause.
+	// +kubebuilder:validation:Enum=Pause;Rollback
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Failure policy",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:select:Pause","urn:alm:descriptor:com.tectonic.ui:select:Rollback"}
+	FailurePolicy FailurePolicy `json:"failurePolicy,omitempty"`
+}
+
+// Status shows the progress of a staged rollout
+type Status struct {
+	// Hash of the DaemonSet that is rolled out
+	TemplateHash string `json:"templateHash,omitempty"`
+
+	// Phase of the rollout, one of Progressing, Completed, Paused or RolledBack
+	Phase Phase `json:"phase,omitempty"`
+
+	// Stage that is currently rolled out, `canary` or the node pool
+	Stage string `json:"stage,omitempty"`
+
+	// Time the current stage was started
+	StageStartedAt *metav1.Time `json:"stageStartedAt,omitempty"`
+
+	// Number of nodes whose pod runs the rolled out version and is ready
+	UpdatedNodes int32 `json:"updatedNodes,omitempty"`
+
+	// Number of nodes the DaemonSet runs on
+	TotalNodes int32 `json:"totalNodes,omitempty"`
+
+	// Nodes whose updated pods didn't become ready in time
+	FailedNodes []string `json:"failedNodes,omitempty"`
+}
@@ -0,0 +1,81 @@
+package rollout
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	defaultHealthTimeout = 10 * time.Minute
+)
+
+var defaultBatchSize = intstr.FromString("25%")
+
+// Validate returns an error if the strategy is not defined correctly
+func (strategy *Strategy) Validate() error {
+	if strategy.Canary != nil {
+		if _, err := metav1.LabelSelectorAsSelector(strategy.Canary); err != nil {
+			return errors.WithMessage(err, "invalid canary selector")
+		}
+	}
+
+	if strategy.NodePoolLabel != "" {
+		if messages := validation.IsQualifiedName(strategy.NodePoolLabel); len(messages) > 0 {
+			return errors.Errorf("invalid node pool label %q: %s", strategy.NodePoolLabel, messages[0])
+		}
+	}
+
+	if strategy.BatchSize != nil {
+		batchSize, err := intstr.GetScaledValueFromIntOrPercent(strategy.BatchSize, 100, true)
+		if err != nil {
+			return errors.WithMessage(err, "invalid batch size")
+		}
+		if batchSize <= 0 {
+			return errors.Errorf("batch size %s has to be greater than 0", strategy.BatchSize.String())
+		}
+	}
+
+	if strategy.HealthTimeout != nil && strategy.HealthTimeout.Duration <= 0 {
+		return errors.Errorf("health timeout %s has to be greater than 0", strategy.HealthTimeout.Duration)
+	}
+	return nil
+}
+
+// GetBatchSize returns the number of nodes of a node pool with the given size that are updated at the same time, at least 1
+func (strategy *Strategy) GetBatchSize(poolSize int) int {
+	batchSize := &defaultBatchSize
+	if strategy.BatchSize != nil {
+		batchSize = strategy.BatchSize
+	}
+
+	scaled, err := intstr.GetScaledValueFromIntOrPercent(batchSize, poolSize, true)
+	if err != nil || scaled < 1 {
+		return 1
+	}
+	return scaled
+}
+
+// GetHealthTimeout returns how long the updated pods of a stage may take to become ready
+func (strategy *Strategy) GetHealthTimeout() time.Duration {
+	if strategy.HealthTimeout == nil {
+		return defaultHealthTimeout
+	}
+	return strategy.HealthTimeout.Duration
+}
+
+// GetFailurePolicy returns the policy applied when a stage failed, Pause if unset
+func (strategy *Strategy) GetFailurePolicy() FailurePolicy {
+	if strategy.FailurePolicy == "" {
+		return FailurePolicyPause
+	}
+	return strategy.FailurePolicy
+}
+
+// IsInProgress is true while pods are restarted, including a rollback
+func (status *Status) IsInProgress() bool {
+	return status != nil && (status.Phase == PhaseProgressing || status.Stage != "")
+}
@@ -0,0 +1,67 @@
+package rollout
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestValidate(t *testing.T) {
+	batchSize := func(value intstr.IntOrString) *intstr.IntOrString {
+		return &value
+	}
+
+	valid := []Strategy{
+		{},
+		{
+			Canary:        &metav1.LabelSelector{MatchLabels: map[string]string{"canary": "true"}},
+			NodePoolLabel: "topology.kubernetes.io/zone",
+			BatchSize:     batchSize(intstr.FromInt(3)),
+			HealthTimeout: &metav1.Duration{Duration: time.Minute},
+			FailurePolicy: FailurePolicyRollback,
+		},
+		{BatchSize: batchSize(intstr.FromString("10%"))},
+	}
+	for _, strategy := range valid {
+		assert.NoError(t, strategy.Validate(), "%+v", strategy)
+	}
+
+	invalid := []Strategy{
+		{Canary: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "canary", Operator: "Unknown"}}}},
+		{NodePoolLabel: "not a label"},
+		{BatchSize: batchSize(intstr.FromInt(0))},
+		{BatchSize: batchSize(intstr.FromString("0%"))},
+		{BatchSize: batchSize(intstr.FromString("many"))},
+		{HealthTimeout: &metav1.Duration{Duration: -time.Minute}},
+	}
+	for _, strategy := range invalid {
+		assert.Error(t, strategy.Validate(), "%+v", strategy)
+	}
+}
+
+func TestGetBatchSize(t *testing.T) {
+	t.Run("defaults to a quarter of the pool", func(t *testing.T) {
+		strategy := Strategy{}
+		assert.Equal(t, 3, strategy.GetBatchSize(10))
+		assert.Equal(t, 1, strategy.GetBatchSize(1))
+	})
+	t.Run("absolute batch size", func(t *testing.T) {
+		batchSize := intstr.FromInt(2)
+		strategy := Strategy{BatchSize: &batchSize}
+		assert.Equal(t, 2, strategy.GetBatchSize(10))
+	})
+	t.Run("at least one node", func(t *testing.T) {
+		batchSize := intstr.FromString("1%")
+		strategy := Strategy{BatchSize: &batchSize}
+		assert.Equal(t, 1, strategy.GetBatchSize(10))
+	})
+}
+
+func TestDefaults(t *testing.T) {
+	strategy := Strategy{}
+	assert.Equal(t, defaultHealthTimeout, strategy.GetHealthTimeout())
+	assert.Equal(t, FailurePolicyPause, strategy.GetFailurePolicy())
+}
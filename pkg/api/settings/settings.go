@@ -0,0 +1,72 @@
+// +kubebuilder:object:generate=true
+// +k8s:openapi-gen=true
+package settings
+
+import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ScopeEnvironment is the scope of settings objects which apply to the whole Dynatrace environment
+	ScopeEnvironment = "environment"
+
+	// ScopeKubernetesCluster is replaced by the monitored entity of the Kubernetes cluster the DynaKube is deployed in
+	ScopeKubernetesCluster = "KUBERNETES_CLUSTER"
+)
+
+// Spec defines a Dynatrace settings object, which is created, updated and deleted by the operator via the Settings 2.0 API.
+// Changes of the object in Dynatrace are detected and reverted to the value defined in the DynaKube.
+type Spec struct {
+	// Name identifies the settings object within the DynaKube, the object id in the status is tracked by it.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// ID of the settings schema, e.g. builtin:anomaly-detection.kubernetes.workload
+	// +kubebuilder:validation:MinLength=1
+	SchemaId string `json:"schemaId"`
+
+	// Version of the settings schema. The latest version is used if not set.
+	// +optional
+	SchemaVersion string `json:"schemaVersion,omitempty"`
+
+	// Scope of the settings object, e.g. the id of a monitored entity. Defaults to environment.
+	// KUBERNETES_CLUSTER refers to the monitored entity of the Kubernetes cluster the DynaKube is deployed in.
+	// +optional
+	Scope string `json:"scope,omitempty"`
+
+	// Value of the settings object as defined by the schema. Properties which aren't set are left to the defaults of Dynatrace.
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Value apiextensionsv1.JSON `json:"value"`
+}
+
+// Status of a settings object managed by the operator
+type Status struct {
+	// Name of the settings object in the DynaKube
+	Name string `json:"name"`
+
+	// ID of the settings schema
+	SchemaId string `json:"schemaId"`
+
+	// Scope the settings object was created in, with KUBERNETES_CLUSTER resolved to the monitored entity
+	Scope string `json:"scope,omitempty"`
+
+	// ID of the settings object in Dynatrace
+	ObjectId string `json:"objectId,omitempty"`
+
+	// Hash of the spec the settings object was last reconciled with, used to detect changes of the spec
+	Hash string `json:"hash,omitempty"`
+
+	// Time the settings object was last compared with Dynatrace
+	LastRequest metav1.Time `json:"lastRequest,omitempty"`
+}
+
+// GetScope returns the configured scope, or the environment scope if none is configured
+func (spec Spec) GetScope() string {
+	if spec.Scope == "" {
+		return ScopeEnvironment
+	}
+	return spec.Scope
+}
@@ -22,6 +22,8 @@ type VersionStatus struct {
 	ImageID string `json:"imageID,omitempty"`
 	// Image version
 	Version string `json:"version,omitempty"`
+	// Reason why the version was chosen, set if a version policy is used, a downgrade was blocked or an update is deferred
+	Reason string `json:"reason,omitempty"`
 	// Image type
 	Type string `json:"type,omitempty"`
 	// Indicates when the last check for a new version was performed
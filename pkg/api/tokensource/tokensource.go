@@ -0,0 +1,108 @@
+// +kubebuilder:object:generate=true
+// +k8s:openapi-gen=true
+package tokensource
+
+import (
+	"net/url"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// Type selects where the operator reads the tokens used for connecting to Dynatrace from.
+// +kubebuilder:validation:Enum=secret;file;http
+type Type string
+
+const (
+	// SecretType reads the tokens from the Kubernetes secret named in spec.tokens
+	SecretType Type = "secret"
+	// FileType reads the tokens from files mounted into the pods of the operator
+	FileType Type = "file"
+	// HTTPType reads the tokens from a secret store with a Vault KV compatible HTTP API
+	HTTPType Type = "http"
+)
+
+// Spec configures where the tokens used for connecting to Dynatrace are read from.
+type Spec struct {
+	// Where the tokens are read from, one of secret, file or http. Defaults to secret.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Token source type",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:select:secret","urn:alm:descriptor:com.tectonic.ui:select:file","urn:alm:descriptor:com.tectonic.ui:select:http"}
+	Type Type `json:"type,omitempty"`
+
+	// Reads the tokens from files, e.g. mounted by the Secrets Store CSI driver. Required for the file type.
+	// +optional
+	File *FileSpec `json:"file,omitempty"`
+
+	// Reads the tokens from a secret store with a Vault KV compatible HTTP API. Required for the http type.
+	// +optional
+	HTTP *HTTPSpec `json:"http,omitempty"`
+}
+
+// FileSpec points to a directory holding one file per token, named after the token (apiToken, paasToken, dataIngestToken).
+// The directory has to be mounted into the operator, webhook and CSI driver pods. The files are read again on every reconcile,
+// so rotated tokens are picked up without restarting the pods.
+type FileSpec struct {
+	// Absolute path of the directory holding the token files.
+	// +kubebuilder:validation:Pattern=`^/`
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Token directory",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:text"}
+	Path string `json:"path"`
+}
+
+// HTTPSpec configures a secret store with a Vault KV compatible HTTP API.
+// The tokens are read with GET <url>/v1/<path>, KV version 1 and version 2 responses are supported.
+type HTTPSpec struct {
+	// Address of the secret store, e.g. https://vault.vault.svc:8200.
+	// +kubebuilder:validation:Pattern=`^https?://`
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Secret store URL",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:text"}
+	URL string `json:"url"`
+
+	// Path of the secret including the mount, e.g. secret/data/dynatrace for KV version 2.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Secret path",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:text"}
+	Path string `json:"path"`
+
+	// File in the operator pods holding the token sent in the X-Vault-Token header, e.g. written by an agent sidecar.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Secret store token file",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:text"}
+	TokenFile string `json:"tokenFile,omitempty"`
+
+	// Namespace sent in the X-Vault-Namespace header.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Secret store namespace",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:text"}
+	Namespace string `json:"namespace,omitempty"`
+
+	// File in the operator pods holding the PEM encoded CA certificates used to verify the secret store. Defaults to the system certificates.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Secret store CA file",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:text"}
+	CAFile string `json:"caFile,omitempty"`
+}
+
+// GetType returns the configured type, SecretType if unset
+func (spec *Spec) GetType() Type {
+	if spec == nil || spec.Type == "" {
+		return SecretType
+	}
+	return spec.Type
+}
+
+// Validate checks that the section required by the type is set
+func (spec *Spec) Validate() error {
+	switch spec.GetType() {
+	case SecretType:
+		return nil
+	case FileType:
+		if spec.File == nil || !filepath.IsAbs(spec.File.Path) {
+			return errors.New("the file token source requires an absolute path in the file section")
+		}
+		return nil
+	case HTTPType:
+		if spec.HTTP == nil || spec.HTTP.Path == "" {
+			return errors.New("the http token source requires a url and a path in the http section")
+		}
+		parsedURL, err := url.Parse(spec.HTTP.URL)
+		if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || parsedURL.Host == "" {
+			return errors.Errorf("the url %q of the http token source is invalid", spec.HTTP.URL)
+		}
+		return nil
+	}
+	return errors.Errorf("unknown token source type %q", spec.Type)
+}
@@ -4,7 +4,7 @@ import (
 	"testing"
 	"time"
 
-	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1alpha1/dynakube"
+	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
@@ -29,30 +29,30 @@ const (
 	testStatusOneAgentInstanceKey = "test-instance"
 )
 
-func TestConversion_ConvertTFrom_Create(t *testing.T) {
+func TestConversion_ConvertTo_Create(t *testing.T) {
 	autoUpdate := true
-	oldDynakube := &dynakube.DynaKube{
+	oldDynakube := &DynaKube{
 		ObjectMeta: prepareObjectMeta(),
-		Spec: dynakube.DynaKubeSpec{
+		Spec: DynaKubeSpec{
 			APIURL: testAPIURL,
 			Tokens: testToken,
 
-			OneAgent: dynakube.OneAgentSpec{
+			OneAgent: OneAgentSpec{
 				AutoUpdate: &autoUpdate,
 			},
 
-			ClassicFullStack: dynakube.FullStackSpec{
+			ClassicFullStack: FullStackSpec{
 				Enabled: true,
 			},
-			KubernetesMonitoringSpec: dynakube.KubernetesMonitoringSpec{
-				CapabilityProperties: dynakube.CapabilityProperties{
+			KubernetesMonitoringSpec: KubernetesMonitoringSpec{
+				CapabilityProperties: CapabilityProperties{
 					Enabled: true,
 				},
 			},
 		},
 	}
-	convertedDynakube := &DynaKube{}
-	err := convertedDynakube.ConvertFrom(oldDynakube)
+	convertedDynakube := &dynatracev1beta1.DynaKube{}
+	err := oldDynakube.ConvertTo(convertedDynakube)
 	require.NoError(t, err)
 
 	assert.Equal(t, oldDynakube.ObjectMeta.Namespace, convertedDynakube.ObjectMeta.Namespace)
@@ -65,30 +65,30 @@ func TestConversion_ConvertTFrom_Create(t *testing.T) {
 	assert.Equal(t, oldDynakube.Spec.OneAgent.AutoUpdate, convertedDynakube.Spec.OneAgent.ClassicFullStack.AutoUpdate)
 }
 
-func TestConversion_ConvertFrom(t *testing.T) {
+func TestConversion_ConvertTo(t *testing.T) {
 	trueVal := true
 	time := metav1.Now()
-	oldDynakube := &dynakube.DynaKube{
+	oldDynakube := &DynaKube{
 		ObjectMeta: prepareObjectMeta(),
-		Spec: dynakube.DynaKubeSpec{
+		Spec: DynaKubeSpec{
 			APIURL:           testUrl,
 			Tokens:           testToken,
 			CustomPullSecret: testCustomPullSecret,
 			SkipCertCheck:    true,
-			Proxy: &dynakube.DynaKubeProxy{
+			Proxy: &DynaKubeProxy{
 				Value: testProxyValue,
 			},
 			TrustedCAs:  testTrustedCAs,
 			NetworkZone: testNetworkZone,
 			EnableIstio: true,
 
-			OneAgent: dynakube.OneAgentSpec{
+			OneAgent: OneAgentSpec{
 				Version:    testOneAgentVersion,
 				Image:      testOneAgentImage,
 				AutoUpdate: &trueVal,
 			},
 
-			ClassicFullStack: dynakube.FullStackSpec{
+			ClassicFullStack: FullStackSpec{
 				Enabled: true,
 				NodeSelector: map[string]string{
 					"key": "value",
@@ -128,19 +128,19 @@ func TestConversion_ConvertFrom(t *testing.T) {
 				UseImmutableImage:   true,
 			},
 
-			ActiveGate: dynakube.ActiveGateSpec{
+			ActiveGate: ActiveGateSpec{
 				Image:      testActiveGateImage,
 				AutoUpdate: &trueVal,
 			},
 
-			RoutingSpec: dynakube.RoutingSpec{
+			RoutingSpec: RoutingSpec{
 				CapabilityProperties: prepareAlphaCapability(),
 			},
-			KubernetesMonitoringSpec: dynakube.KubernetesMonitoringSpec{
+			KubernetesMonitoringSpec: KubernetesMonitoringSpec{
 				CapabilityProperties: prepareAlphaCapability(),
 			},
 		},
-		Status: dynakube.DynaKubeStatus{
+		Status: DynaKubeStatus{
 			Phase:                            "test-phase",
 			UpdatedTimestamp:                 time,
 			LastAPITokenProbeTimestamp:       &time,
@@ -158,22 +158,22 @@ func TestConversion_ConvertFrom(t *testing.T) {
 					Message:            "message",
 				},
 			},
-			ActiveGate: dynakube.ActiveGateStatus{
-				ImageStatus: dynakube.ImageStatus{
+			ActiveGate: ActiveGateStatus{
+				ImageStatus: ImageStatus{
 					ImageHash:               "test-activegate-imagehash",
 					ImageVersion:            "test-activegate-imageversion",
 					LastImageProbeTimestamp: &time,
 				},
 			},
-			OneAgent: dynakube.OneAgentStatus{
-				ImageStatus: dynakube.ImageStatus{
+			OneAgent: OneAgentStatus{
+				ImageStatus: ImageStatus{
 					ImageHash:               "test-oneagent-imagehash",
 					ImageVersion:            "test-oneagent-imageversion",
 					LastImageProbeTimestamp: &time,
 				},
 				UseImmutableImage: true,
 				Version:           "test-oneagent-version",
-				Instances: map[string]dynakube.OneAgentInstance{
+				Instances: map[string]OneAgentInstance{
 					testStatusOneAgentInstanceKey: {
 						PodName:   "test-instance-podname",
 						Version:   "test-instance-version",
@@ -185,8 +185,8 @@ func TestConversion_ConvertFrom(t *testing.T) {
 		},
 	}
 
-	convertedDynakube := &DynaKube{}
-	err := convertedDynakube.ConvertFrom(oldDynakube)
+	convertedDynakube := &dynatracev1beta1.DynaKube{}
+	err := oldDynakube.ConvertTo(convertedDynakube)
 	require.NoError(t, err)
 
 	assert.Equal(t, oldDynakube.ObjectMeta.Namespace, convertedDynakube.ObjectMeta.Namespace)
@@ -251,13 +251,13 @@ func prepareObjectMeta() metav1.ObjectMeta {
 	}
 }
 
-func prepareAlphaCapability() dynakube.CapabilityProperties {
+func prepareAlphaCapability() CapabilityProperties {
 	intVal := int32(3)
-	return dynakube.CapabilityProperties{
+	return CapabilityProperties{
 		Enabled:  true,
 		Replicas: &intVal,
 		Group:    "test-activegate-group",
-		CustomProperties: &dynakube.DynaKubeValueSource{
+		CustomProperties: &DynaKubeValueSource{
 			Value: "test-routing-value",
 		},
 		Resources: corev1.ResourceRequirements{
@@ -294,7 +294,7 @@ func prepareAlphaCapability() dynakube.CapabilityProperties {
 	}
 }
 
-func compareAlphaCapability(t *testing.T, expectedCapability dynakube.CapabilityProperties, actualCapability CapabilityProperties) {
+func compareAlphaCapability(t *testing.T, expectedCapability CapabilityProperties, actualCapability dynatracev1beta1.CapabilityProperties) {
 	assert.Equal(t, expectedCapability.Replicas, actualCapability.Replicas)
 	assert.Equal(t, expectedCapability.Group, actualCapability.Group)
 	assert.Equal(t, expectedCapability.CustomProperties.ValueFrom, actualCapability.CustomProperties.ValueFrom)
@@ -306,24 +306,24 @@ func compareAlphaCapability(t *testing.T, expectedCapability dynakube.Capability
 	assert.Equal(t, expectedCapability.Env, actualCapability.Env)
 }
 
-func TestConversion_ConvertTo(t *testing.T) {
+func TestConversion_ConvertFrom(t *testing.T) {
 	timeNow := metav1.Now()
-	oldDynakube := &DynaKube{
+	oldDynakube := &dynatracev1beta1.DynaKube{
 		ObjectMeta: prepareObjectMeta(),
-		Spec: DynaKubeSpec{
+		Spec: dynatracev1beta1.DynaKubeSpec{
 			APIURL:           testUrl,
 			Tokens:           testToken,
 			CustomPullSecret: testCustomPullSecret,
 			SkipCertCheck:    true,
-			Proxy: &DynaKubeProxy{
+			Proxy: &dynatracev1beta1.DynaKubeProxy{
 				Value: testProxyValue,
 			},
 			TrustedCAs:  testTrustedCAs,
 			NetworkZone: testNetworkZone,
 			EnableIstio: true,
 
-			OneAgent: OneAgentSpec{
-				ClassicFullStack: &HostInjectSpec{
+			OneAgent: dynatracev1beta1.OneAgentSpec{
+				ClassicFullStack: &dynatracev1beta1.HostInjectSpec{
 					Image:   testOneAgentImage,
 					Version: testOneAgentVersion,
 					NodeSelector: map[string]string{
@@ -363,17 +363,17 @@ func TestConversion_ConvertTo(t *testing.T) {
 				},
 			},
 
-			Routing: RoutingSpec{
+			Routing: dynatracev1beta1.RoutingSpec{
 				Enabled:              true,
 				CapabilityProperties: prepareBetaCapability(),
 			},
 
-			KubernetesMonitoring: KubernetesMonitoringSpec{
+			KubernetesMonitoring: dynatracev1beta1.KubernetesMonitoringSpec{
 				Enabled:              true,
 				CapabilityProperties: prepareBetaCapability(),
 			},
 		},
-		Status: DynaKubeStatus{
+		Status: dynatracev1beta1.DynaKubeStatus{
 			Phase:                   "test-phase",
 			UpdatedTimestamp:        timeNow,
 			LastTokenProbeTimestamp: &timeNow,
@@ -387,9 +387,9 @@ func TestConversion_ConvertTo(t *testing.T) {
 					Message:            "message",
 				},
 			},
-			ActiveGate: ActiveGateStatus{},
-			OneAgent: OneAgentStatus{
-				Instances: map[string]OneAgentInstance{
+			ActiveGate: dynatracev1beta1.ActiveGateStatus{},
+			OneAgent: dynatracev1beta1.OneAgentStatus{
+				Instances: map[string]dynatracev1beta1.OneAgentInstance{
 					testStatusOneAgentInstanceKey: {
 						PodName:   "test-instance-podname",
 						IPAddress: "test-instance-ip",
@@ -399,8 +399,8 @@ func TestConversion_ConvertTo(t *testing.T) {
 		},
 	}
 
-	convertedDynakube := &dynakube.DynaKube{}
-	err := oldDynakube.ConvertTo(convertedDynakube)
+	convertedDynakube := &DynaKube{}
+	err := convertedDynakube.ConvertFrom(oldDynakube)
 	require.NoError(t, err)
 
 	assert.Equal(t, oldDynakube.ObjectMeta.Namespace, convertedDynakube.ObjectMeta.Namespace)
@@ -459,7 +459,7 @@ func TestConversion_ConvertTo(t *testing.T) {
 	assert.Equal(t, oldDynakube.Status.UpdatedTimestamp, convertedDynakube.Status.UpdatedTimestamp)
 }
 
-func compareBetaCapability(t *testing.T, expectedCapability CapabilityProperties, actualCapability dynakube.CapabilityProperties) {
+func compareBetaCapability(t *testing.T, expectedCapability dynatracev1beta1.CapabilityProperties, actualCapability CapabilityProperties) {
 	assert.Equal(t, expectedCapability.Replicas, actualCapability.Replicas)
 	assert.Equal(t, expectedCapability.Group, actualCapability.Group)
 	assert.Equal(t, expectedCapability.CustomProperties.ValueFrom, actualCapability.CustomProperties.ValueFrom)
@@ -471,12 +471,12 @@ func compareBetaCapability(t *testing.T, expectedCapability CapabilityProperties
 	assert.Equal(t, expectedCapability.Env, actualCapability.Env)
 }
 
-func prepareBetaCapability() CapabilityProperties {
+func prepareBetaCapability() dynatracev1beta1.CapabilityProperties {
 	intVal := int32(3)
-	return CapabilityProperties{
+	return dynatracev1beta1.CapabilityProperties{
 		Replicas: &intVal,
 		Group:    "test-activegate-group",
-		CustomProperties: &DynaKubeValueSource{
+		CustomProperties: &dynatracev1beta1.DynaKubeValueSource{
 			Value: "test-routing-value",
 		},
 		Resources: corev1.ResourceRequirements{
@@ -5,6 +5,7 @@
 package edgeconnect
 
 import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/maintenancewindow"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/status"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
@@ -65,6 +66,11 @@ type EdgeConnectSpec struct { //nolint:revive
 	// Host patterns to be set in the tenant, only considered when provisioning is enabled.
 	// +kubebuilder:validation:Optional
 	HostPatterns []string `json:"hostPatterns,omitempty"`
+
+	// Windows in which the operator is allowed to update the EdgeConnect deployment, e.g. to roll out a new version.
+	// Updates are allowed at any time if no window is defined.
+	// +kubebuilder:validation:Optional
+	MaintenanceWindows []maintenancewindow.Window `json:"maintenanceWindows,omitempty"`
 }
 
 type OAuthSpec struct {
@@ -104,6 +110,9 @@ type EdgeConnectStatus struct { //nolint:revive
 
 	// Conditions includes status about the current state of the instance
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Time the next maintenance window opens, only set while updates of the deployment are deferred
+	UpdatesDeferredUntil *metav1.Time `json:"updatesDeferredUntil,omitempty"`
 }
 
 // SetPhase sets the status phase on the EdgeConnect object
@@ -2,9 +2,11 @@ package edgeconnect
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -14,6 +16,12 @@ const (
 	MaxNameLength = 40
 
 	defaultEdgeConnectRepository = "docker.io/dynatrace/edgeconnect"
+
+	// UpdatePendingConditionType is set while an update of the deployment waits for the next maintenance window
+	UpdatePendingConditionType = "UpdatePending"
+
+	// ReasonOutsideMaintenanceWindow is set on the UpdatePending condition when an update was deferred
+	ReasonOutsideMaintenanceWindow = "OutsideMaintenanceWindow"
 )
 
 func (edgeConnect *EdgeConnect) Image() string {
@@ -42,3 +50,23 @@ func (edgeConnect *EdgeConnect) PullSecretWithoutData() corev1.Secret {
 		},
 	}
 }
+
+// IsUpdateDeferred returns true if updates of the deployment have to wait for the next maintenance window
+func (edgeConnect *EdgeConnect) IsUpdateDeferred() bool {
+	return edgeConnect.Status.UpdatesDeferredUntil != nil
+}
+
+// SetConditionUpdatePending marks that an update of the deployment was deferred until the next maintenance window
+func (edgeConnect *EdgeConnect) SetConditionUpdatePending() {
+	message := "updates are deferred until the next maintenance window"
+	if edgeConnect.Status.UpdatesDeferredUntil != nil {
+		message = fmt.Sprintf("%s at %s", message, edgeConnect.Status.UpdatesDeferredUntil.UTC().Format(time.RFC3339))
+	}
+	meta.SetStatusCondition(&edgeConnect.Status.Conditions, metav1.Condition{
+		Type:               UpdatePendingConditionType,
+		Status:             metav1.ConditionTrue,
+		Reason:             ReasonOutsideMaintenanceWindow,
+		Message:            message,
+		ObservedGeneration: edgeConnect.Generation,
+	})
+}
@@ -20,6 +20,7 @@ limitations under the License.
 package edgeconnect
 
 import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/maintenancewindow"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
@@ -137,6 +138,13 @@ func (in *EdgeConnectSpec) DeepCopyInto(out *EdgeConnectSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]maintenancewindow.Window, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EdgeConnectSpec.
@@ -161,6 +169,10 @@ func (in *EdgeConnectStatus) DeepCopyInto(out *EdgeConnectStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.UpdatesDeferredUntil != nil {
+		in, out := &in.UpdatesDeferredUntil, &out.UpdatesDeferredUntil
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EdgeConnectStatus.
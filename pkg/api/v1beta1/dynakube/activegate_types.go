@@ -1,7 +1,10 @@
 package dynakube
 
 import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/versionpolicy"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 type CapabilityDisplayName string
@@ -81,6 +84,93 @@ type ActiveGateSpec struct {
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Annotations",order=27,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:text"}
 	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Restricts the ActiveGate versions used for automatic updates, either by a semantic version constraint or by staying minor versions behind the latest one.
+	// +optional
+	VersionPolicy *versionpolicy.Spec `json:"versionPolicy,omitempty"`
+
+	// Scales the ActiveGate pods with a HorizontalPodAutoscaler. If set, replicas is only used as the initial number of pods, limited by minReplicas and maxReplicas.
+	// +optional
+	Autoscaling *ActiveGateAutoscalingSpec `json:"autoscaling,omitempty"`
+
+	// Protects the ActiveGate pods from voluntary disruptions, like node drains, with a PodDisruptionBudget.
+	// +optional
+	PodDisruptionBudget *ActiveGatePodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
+
+	// Additional groups of ActiveGate pods, each with its own capabilities and placement.
+	// Every pool gets its own StatefulSet, Service and tenant secret. The TLS secret, DNS policy, priority class, annotations
+	// and version policy of this section apply to the pools too.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="ActiveGate pools",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:hidden"}
+	Pools []ActiveGatePoolSpec `json:"pools,omitempty"`
+}
+
+// ActiveGatePoolSpec defines a group of ActiveGate pods with its own capabilities and placement
+type ActiveGatePoolSpec struct {
+	// Name of the pool, it is part of the names of the StatefulSet, Service and tenant secret of the pool.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Name",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:text"}
+	Name string `json:"name"`
+
+	// Activegate capabilities enabled for the pool (routing, kubernetes-monitoring, metrics-ingest, dynatrace-api)
+	// +kubebuilder:validation:MinItems=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Capabilities",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:text"}
+	Capabilities []CapabilityDisplayName `json:"capabilities"`
+
+	// Network zone of the ActiveGate pods of the pool. Defaults to the network zone of the DynaKube.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Network Zone",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:text"}
+	NetworkZone string `json:"networkZone,omitempty"`
+
+	CapabilityProperties `json:",inline"`
+}
+
+// ActiveGateAutoscalingSpec defines the HorizontalPodAutoscaler of the ActiveGate StatefulSet
+type ActiveGateAutoscalingSpec struct {
+	// Lower limit for the number of ActiveGate pods. Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Min replicas",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:podCount"}
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// Upper limit for the number of ActiveGate pods.
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Max replicas",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:podCount"}
+	MaxReplicas int32 `json:"maxReplicas"`
+
+	// Target average CPU utilization of the ActiveGate pods, in percent of their CPU request.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Target CPU utilization",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:number"}
+	TargetCPUUtilization *int32 `json:"targetCPUUtilization,omitempty"`
+
+	// Target average memory utilization of the ActiveGate pods, in percent of their memory request.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Target memory utilization",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:number"}
+	TargetMemoryUtilization *int32 `json:"targetMemoryUtilization,omitempty"`
+
+	// Additional metrics to scale on, e.g. a custom metric of the ActiveGate pods.
+	// For details, see Scaling on custom metrics (https://kubernetes.io/docs/tasks/run-application/horizontal-pod-autoscale/#scaling-on-custom-metrics).
+	// If no metric is set at all, the ActiveGate pods are scaled to an average CPU utilization of 80%.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Metrics",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:hidden"}
+	Metrics []autoscalingv2.MetricSpec `json:"metrics,omitempty"`
+}
+
+// ActiveGatePodDisruptionBudgetSpec defines the PodDisruptionBudget of the ActiveGate StatefulSet, only one of its fields can be set
+type ActiveGatePodDisruptionBudgetSpec struct {
+	// Number or percentage of ActiveGate pods that have to stay available during a voluntary disruption.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Min available",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:text"}
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+
+	// Number or percentage of ActiveGate pods that may be unavailable during a voluntary disruption. Defaults to 1 if minAvailable is not set.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Max unavailable",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:text"}
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
 }
 
 // CapabilityProperties is a struct which can be embedded by ActiveGate capabilities
@@ -0,0 +1,156 @@
+package dynakube
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ReadyConditionType summarizes the conditions of all components, it follows the kstatus conventions
+	// so `kubectl wait --for=condition=Ready` can be used
+	ReadyConditionType string = "Ready"
+
+	// ConnectionInfoConditionType identifies the condition of the tenant connection info
+	ConnectionInfoConditionType string = "ConnectionInfo"
+
+	// PullSecretConditionType identifies the condition of the generated pull secret
+	PullSecretConditionType string = "PullSecret"
+
+	// VersionConditionType identifies the condition of the version status of all components
+	VersionConditionType string = "Version"
+
+	// ActiveGateConditionType identifies the condition of the ActiveGate StatefulSets
+	ActiveGateConditionType string = "ActiveGate"
+
+	// OneAgentConditionType identifies the condition of the OneAgent DaemonSet
+	OneAgentConditionType string = "OneAgent"
+
+	// AppInjectionConditionType identifies the condition of the app injection setup
+	AppInjectionConditionType string = "AppInjection"
+
+	// SettingsConditionType identifies the condition of the settings objects managed via the Settings 2.0 API
+	SettingsConditionType string = "Settings"
+
+	// UpdatePendingConditionType is set while an update of a deployed component waits for the next maintenance window,
+	// it is not summarized by the Ready condition
+	UpdatePendingConditionType string = "UpdatePending"
+
+	// DynatraceEventsConditionType is false while events can't be sent to Dynatrace, because the tokens lack the events ingest scope,
+	// it is not summarized by the Ready condition
+	DynatraceEventsConditionType string = "DynatraceEvents"
+)
+
+// Possible reasons for the component conditions
+const (
+	// ReasonReconciled is set when the component was reconciled successfully
+	ReasonReconciled string = "Reconciled"
+
+	// ReasonReconcileError is set when the reconciliation of the component failed
+	ReasonReconcileError string = "ReconcileError"
+
+	// ReasonRolloutInProgress is set when not all pods of the component are ready yet
+	ReasonRolloutInProgress string = "RolloutInProgress"
+
+	// ReasonRolloutPostponed is set when the rollout of the component has to wait for another component
+	ReasonRolloutPostponed string = "RolloutPostponed"
+
+	// ReasonRolloutPaused is set when a staged rollout stopped because the updated pods didn't become ready in time
+	ReasonRolloutPaused string = "RolloutPaused"
+
+	// ReasonRolledBack is set when a staged rollout was reverted because the updated pods didn't become ready in time
+	ReasonRolledBack string = "RolledBack"
+
+	// ReasonAllComponentsReady is set on the Ready condition when every component is ready
+	ReasonAllComponentsReady string = "AllComponentsReady"
+
+	// ReasonComponentsNotReady is set on the Ready condition when at least one component is not ready
+	ReasonComponentsNotReady string = "ComponentsNotReady"
+
+	// ReasonOffline is set on the ConnectionInfo condition when the Dynatrace API isn't reachable,
+	// a deployment API mirror is used and the last known connection info is kept
+	ReasonOffline string = "Offline"
+
+	// ReasonOutsideMaintenanceWindow is set on the UpdatePending condition when an update was deferred
+	ReasonOutsideMaintenanceWindow string = "OutsideMaintenanceWindow"
+
+	// ReasonDowngradeBlocked is set on the Version condition when the latest version is older than the deployed one and the downgrade isn't allowed
+	ReasonDowngradeBlocked string = "DowngradeBlocked"
+)
+
+// Reasons set on the Token and Ready conditions instead of the generic error reasons when a request to the Dynatrace API failed
+const (
+	// ReasonUnauthorized is set when the token or the oauth client is invalid or expired
+	ReasonUnauthorized string = "Unauthorized"
+
+	// ReasonForbidden is set when the token or the oauth client lacks permissions
+	ReasonForbidden string = "Forbidden"
+
+	// ReasonMissingScopes is set when the token or the oauth client lacks scopes required by the DynaKube
+	ReasonMissingScopes string = "MissingScopes"
+
+	// ReasonNotFound is set when the Dynatrace API responded that a requested object doesn't exist
+	ReasonNotFound string = "NotFound"
+
+	// ReasonRateLimited is set when the request limit of the Dynatrace environment is reached
+	ReasonRateLimited string = "RateLimited"
+
+	// ReasonServerError is set when the Dynatrace API failed to handle the request
+	ReasonServerError string = "ServerError"
+
+	// ReasonNetworkError is set when no connection to the Dynatrace API could be established
+	ReasonNetworkError string = "NetworkError"
+
+	// ReasonTLSError is set when the certificate of the Dynatrace API isn't trusted
+	ReasonTLSError string = "TLSError"
+)
+
+// ComponentConditionTypes are the condition types that are summarized by the Ready condition, in the order they are reconciled
+var ComponentConditionTypes = []string{
+	TokenConditionType,
+	ConnectionInfoConditionType,
+	PullSecretConditionType,
+	VersionConditionType,
+	AppInjectionConditionType,
+	ActiveGateConditionType,
+	OneAgentConditionType,
+	SettingsConditionType,
+}
+
+// SetCondition sets the condition with the current generation of the DynaKube as observedGeneration.
+// The lastTransitionTime is only changed if the status of the condition changes.
+func (dk *DynaKube) SetCondition(conditionType string, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&dk.Status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: dk.Generation,
+	})
+}
+
+// SetConditionReconciled marks the component as reconciled successfully
+func (dk *DynaKube) SetConditionReconciled(conditionType string) {
+	dk.SetCondition(conditionType, metav1.ConditionTrue, ReasonReconciled, "")
+}
+
+// SetConditionError marks the component as failed with the message of the given error
+func (dk *DynaKube) SetConditionError(conditionType string, err error) {
+	dk.SetCondition(conditionType, metav1.ConditionFalse, ReasonReconcileError, err.Error())
+}
+
+// RemoveCondition removes the condition of a component that is not deployed
+func (dk *DynaKube) RemoveCondition(conditionType string) {
+	meta.RemoveStatusCondition(&dk.Status.Conditions, conditionType)
+}
+
+// SetConditionUpdatePending marks that an update of a deployed component was deferred until the next maintenance window
+func (dk *DynaKube) SetConditionUpdatePending() {
+	message := "updates are deferred until the next maintenance window"
+	if dk.Status.UpdatesDeferredUntil != nil {
+		message = fmt.Sprintf("%s at %s", message, dk.Status.UpdatesDeferredUntil.UTC().Format(time.RFC3339))
+	}
+	dk.SetCondition(UpdatePendingConditionType, metav1.ConditionTrue, ReasonOutsideMaintenanceWindow, message)
+}
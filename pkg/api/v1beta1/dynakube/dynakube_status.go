@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/rollout"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/settings"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/status"
 	containerv1 "github.com/google/go-containerregistry/pkg/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -31,6 +33,12 @@ type DynaKubeStatus struct { // nolint:revive
 	// Conditions includes status about the current state of the instance
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 
+	// Time the next maintenance window opens, only set while updates of deployed components are deferred
+	UpdatesDeferredUntil *metav1.Time `json:"updatesDeferredUntil,omitempty"`
+
+	// Settings objects created by the operator in Dynatrace
+	Settings []settings.Status `json:"settings,omitempty"`
+
 	// Observed state of ActiveGate
 	ActiveGate ActiveGateStatus `json:"activeGate,omitempty"`
 
@@ -50,6 +58,9 @@ type DynaKubeStatus struct { // nolint:revive
 type DynatraceApiStatus struct {
 	// Time of the last token request
 	LastTokenScopeRequest metav1.Time `json:"lastTokenScopeRequest,omitempty"`
+
+	// Hash of the tokens of the last reconciliation, used to detect a rotation of the tokens
+	TokenHash string `json:"tokenHash,omitempty"`
 }
 
 func GetCacheValidMessage(functionName string, lastRequestTimestamp metav1.Time, timeout time.Duration) string {
@@ -100,6 +111,16 @@ type ActiveGateStatus struct {
 
 	// Information about Active Gate's connections
 	ConnectionInfoStatus ActiveGateConnectionInfoStatus `json:"connectionInfoStatus,omitempty"`
+
+	// Versions of the ActiveGate pools
+	Pools []ActiveGatePoolStatus `json:"pools,omitempty"`
+}
+
+type ActiveGatePoolStatus struct {
+	status.VersionStatus `json:",inline"`
+
+	// Name of the pool
+	Name string `json:"name"`
 }
 
 type CodeModulesStatus struct {
@@ -123,6 +144,9 @@ type OneAgentStatus struct {
 	// +kubebuilder:validation:Schemaless
 	// +kubebuilder:pruning:PreserveUnknownFields
 	Healthcheck *containerv1.HealthConfig `json:"healthcheck,omitempty"`
+
+	// Progress of the staged rollout of the OneAgent DaemonSet
+	Rollout *rollout.Status `json:"rollout,omitempty"`
 }
 
 type OneAgentInstance struct {
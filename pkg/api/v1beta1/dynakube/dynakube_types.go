@@ -4,6 +4,11 @@
 package dynakube
 
 import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/maintenancewindow"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/mirror"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/oauth"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/settings"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/tokensource"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -29,6 +34,9 @@ const (
 
 	// ReasonTokenError is set when an unknown error has been found when verifying the token
 	ReasonTokenError string = "TokenError"
+
+	// ReasonTokenUnverified is set when the token scopes can't be verified, because the Dynatrace API isn't reachable and a deployment API mirror is used
+	ReasonTokenUnverified string = "TokenUnverified"
 )
 
 type DynaKubeProxy struct { // nolint:revive
@@ -92,6 +100,21 @@ type DynaKubeSpec struct { // nolint:revive
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Tenant specific secrets",order=2,xDescriptors="urn:alm:descriptor:io.kubernetes:Secret"
 	Tokens string `json:"tokens,omitempty"`
 
+	// Where the tokens used for connecting to Dynatrace are read from. Defaults to the secret named in tokens.
+	// +optional
+	TokenSource *tokensource.Spec `json:"tokenSource,omitempty"`
+
+	// OAuth client used instead of the api and paas token to authenticate against the Dynatrace API.
+	// Its client id and secret are read from the token source with the keys oauthClientId and oauthClientSecret.
+	// A paas or api token is still required for pull secrets and code modules.
+	// +optional
+	OAuth *oauth.Spec `json:"oauth,omitempty"`
+
+	// Mirror of the Dynatrace deployment API, used in air-gapped clusters instead of the Dynatrace API for downloading agents and looking up versions and images.
+	// Connection info and token scopes are only verified while the Dynatrace API is reachable.
+	// +optional
+	Mirror *mirror.Spec `json:"mirror,omitempty"`
+
 	// Disable certificate check for the connection between Dynatrace Operator and the Dynatrace Cluster.
 	// Set to true if you want to skip certification validation checks.
 	// +optional
@@ -138,6 +161,21 @@ type DynaKubeSpec struct { // nolint:revive
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Pod Selector",order=18,xDescriptors="urn:alm:descriptor:com.tectonic.ui:selector:core:v1:Pod"
 	PodSelector metav1.LabelSelector `json:"podSelector,omitempty"`
 
+	// Windows in which the operator is allowed to update already deployed components, e.g. to roll out a new version.
+	// Outside of them, changes of the version status and of the DaemonSets, StatefulSets and Deployments are deferred.
+	// Updates are allowed at any time if no window is defined.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Maintenance windows",xDescriptors="urn:alm:descriptor:com.tectonic.ui:advanced"
+	MaintenanceWindows []maintenancewindow.Window `json:"maintenanceWindows,omitempty"`
+
+	// Dynatrace settings objects managed by the operator via the Settings 2.0 API, e.g. anomaly detection for workloads or the Kubernetes event forwarding.
+	// Objects removed from this list are deleted in Dynatrace, changes made in Dynatrace are reverted.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Settings",xDescriptors="urn:alm:descriptor:com.tectonic.ui:advanced"
+	Settings []settings.Spec `json:"settings,omitempty"`
+
 	// General configuration about OneAgent instances.
 	// You can't enable more than one module (classicFullStack, cloudNativeFullStack, hostMonitoring, or applicationMonitoring).
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="OneAgent",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
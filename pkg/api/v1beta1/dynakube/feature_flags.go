@@ -61,6 +61,9 @@ const (
 	AnnotationFeatureHostsRequests        = AnnotationFeaturePrefix + "hosts-requests"
 	AnnotationFeatureNoProxy              = AnnotationFeaturePrefix + "no-proxy"
 	AnnotationFeatureApiRequestThreshold  = AnnotationFeaturePrefix + "dynatrace-api-request-threshold"
+	AnnotationFeatureApiRequestRetries    = AnnotationFeaturePrefix + "dynatrace-api-request-retries"
+	AnnotationFeatureApiRequestMaxBackoff = AnnotationFeaturePrefix + "dynatrace-api-request-max-backoff-seconds"
+	AnnotationFeatureApiRequestTimeout    = AnnotationFeaturePrefix + "dynatrace-api-request-timeout-seconds"
 
 	// oneAgent
 
@@ -106,6 +109,13 @@ const (
 	// replicas for the synthetic monitoring
 	AnnotationFeatureSyntheticReplicas = AnnotationFeaturePrefix + "synthetic-replicas"
 
+	// events sent to the Dynatrace events API
+	AnnotationFeatureOneAgentVersionEvents   = AnnotationFeaturePrefix + "oneagent-version-events"
+	AnnotationFeatureActiveGateVersionEvents = AnnotationFeaturePrefix + "activegate-version-events"
+	AnnotationFeatureInstallFailureEvents    = AnnotationFeaturePrefix + "install-failure-events"
+	AnnotationFeatureTokenScopeEvents        = AnnotationFeaturePrefix + "token-scope-events"
+	AnnotationFeatureInjectionFailureEvents  = AnnotationFeaturePrefix + "injection-failure-events"
+
 	falsePhrase  = "false"
 	truePhrase   = "true"
 	silentPhrase = "silent"
@@ -121,6 +131,9 @@ const (
 const (
 	DefaultMaxFailedCsiMountAttempts        = 10
 	DefaultMinRequestThresholdMinutes       = 15
+	DefaultApiRequestRetries                = 3
+	DefaultApiRequestMaxBackoffSeconds      = 30
+	DefaultApiRequestTimeoutSeconds         = 120
 	IstioDefaultOneAgentInitialConnectRetry = 6000
 )
 
@@ -183,6 +196,35 @@ func (dk *DynaKube) FeatureApiRequestThreshold() time.Duration {
 	return time.Duration(interval) * time.Minute
 }
 
+// FeatureApiRequestRetries is a feature flag to configure how often the dtClient retries requests which failed with a transient error,
+// e.g. because of rate limiting. 0 disables retries.
+func (dk *DynaKube) FeatureApiRequestRetries() int {
+	retries := dk.getFeatureFlagInt(AnnotationFeatureApiRequestRetries, DefaultApiRequestRetries)
+	if retries < 0 {
+		retries = DefaultApiRequestRetries
+	}
+	return retries
+}
+
+// FeatureApiRequestMaxBackoff is a feature flag to configure the longest time the dtClient waits before retrying a request.
+func (dk *DynaKube) FeatureApiRequestMaxBackoff() time.Duration {
+	seconds := dk.getFeatureFlagInt(AnnotationFeatureApiRequestMaxBackoff, DefaultApiRequestMaxBackoffSeconds)
+	if seconds < 0 {
+		seconds = DefaultApiRequestMaxBackoffSeconds
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// FeatureApiRequestTimeout is a feature flag to configure how long a single attempt of a request to the Dynatrace API may take.
+// Downloads of agent packages aren't limited by it.
+func (dk *DynaKube) FeatureApiRequestTimeout() time.Duration {
+	seconds := dk.getFeatureFlagInt(AnnotationFeatureApiRequestTimeout, DefaultApiRequestTimeoutSeconds)
+	if seconds <= 0 {
+		seconds = DefaultApiRequestTimeoutSeconds
+	}
+	return time.Duration(seconds) * time.Second
+}
+
 // FeatureOneAgentMaxUnavailable is a feature flag to configure maxUnavailable on the OneAgent DaemonSets rolling upgrades.
 func (dk *DynaKube) FeatureOneAgentMaxUnavailable() int {
 	return dk.getFeatureFlagInt(AnnotationFeatureOneAgentMaxUnavailable, 1)
@@ -384,3 +426,37 @@ func (dk *DynaKube) FeatureSyntheticReplicas() int32 {
 func (dk *DynaKube) FeatureInitContainerSeccomp() bool {
 	return dk.getFeatureFlagRaw(AnnotationFeatureInitContainerSeccomp) == truePhrase
 }
+
+// FeatureOneAgentVersionEvents is a feature flag to send an event to Dynatrace when the OneAgent version changes.
+func (dk *DynaKube) FeatureOneAgentVersionEvents() bool {
+	return dk.getFeatureFlagRaw(AnnotationFeatureOneAgentVersionEvents) == truePhrase
+}
+
+// FeatureActiveGateVersionEvents is a feature flag to send an event to Dynatrace when the ActiveGate version changes.
+func (dk *DynaKube) FeatureActiveGateVersionEvents() bool {
+	return dk.getFeatureFlagRaw(AnnotationFeatureActiveGateVersionEvents) == truePhrase
+}
+
+// FeatureInstallFailureEvents is a feature flag to send an event to Dynatrace when the CSI driver fails to install the code modules.
+func (dk *DynaKube) FeatureInstallFailureEvents() bool {
+	return dk.getFeatureFlagRaw(AnnotationFeatureInstallFailureEvents) == truePhrase
+}
+
+// FeatureTokenScopeEvents is a feature flag to send an event to Dynatrace when the tokens miss required scopes.
+func (dk *DynaKube) FeatureTokenScopeEvents() bool {
+	return dk.getFeatureFlagRaw(AnnotationFeatureTokenScopeEvents) == truePhrase
+}
+
+// FeatureInjectionFailureEvents is a feature flag to send an event to Dynatrace when the webhook fails to inject into a pod.
+func (dk *DynaKube) FeatureInjectionFailureEvents() bool {
+	return dk.getFeatureFlagRaw(AnnotationFeatureInjectionFailureEvents) == truePhrase
+}
+
+// FeatureDynatraceEvents is true, if any event is sent to Dynatrace.
+func (dk *DynaKube) FeatureDynatraceEvents() bool {
+	return dk.FeatureOneAgentVersionEvents() ||
+		dk.FeatureActiveGateVersionEvents() ||
+		dk.FeatureInstallFailureEvents() ||
+		dk.FeatureTokenScopeEvents() ||
+		dk.FeatureInjectionFailureEvents()
+}
@@ -5,6 +5,7 @@ import (
 	"regexp"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -343,3 +344,54 @@ func TestAgentInitialConnectRetry(t *testing.T) {
 		require.Equal(t, 5, initialRetry)
 	})
 }
+
+func TestApiRequestRetries(t *testing.T) {
+	t.Run("default", func(t *testing.T) {
+		dynakube := createDynakubeEmptyDynakube()
+
+		assert.Equal(t, DefaultApiRequestRetries, dynakube.FeatureApiRequestRetries())
+		assert.Equal(t, DefaultApiRequestMaxBackoffSeconds*time.Second, dynakube.FeatureApiRequestMaxBackoff())
+		assert.Equal(t, DefaultApiRequestTimeoutSeconds*time.Second, dynakube.FeatureApiRequestTimeout())
+	})
+	t.Run("configured", func(t *testing.T) {
+		dynakube := createDynakubeEmptyDynakube()
+		dynakube.Annotations[AnnotationFeatureApiRequestRetries] = "0"
+		dynakube.Annotations[AnnotationFeatureApiRequestMaxBackoff] = "5"
+		dynakube.Annotations[AnnotationFeatureApiRequestTimeout] = "10"
+
+		assert.Equal(t, 0, dynakube.FeatureApiRequestRetries())
+		assert.Equal(t, 5*time.Second, dynakube.FeatureApiRequestMaxBackoff())
+		assert.Equal(t, 10*time.Second, dynakube.FeatureApiRequestTimeout())
+	})
+	t.Run("negative values fall back to the default", func(t *testing.T) {
+		dynakube := createDynakubeEmptyDynakube()
+		dynakube.Annotations[AnnotationFeatureApiRequestRetries] = "-1"
+		dynakube.Annotations[AnnotationFeatureApiRequestMaxBackoff] = "-1"
+		dynakube.Annotations[AnnotationFeatureApiRequestTimeout] = "-1"
+
+		assert.Equal(t, DefaultApiRequestRetries, dynakube.FeatureApiRequestRetries())
+		assert.Equal(t, DefaultApiRequestMaxBackoffSeconds*time.Second, dynakube.FeatureApiRequestMaxBackoff())
+		assert.Equal(t, DefaultApiRequestTimeoutSeconds*time.Second, dynakube.FeatureApiRequestTimeout())
+	})
+}
+
+func TestDynatraceEvents(t *testing.T) {
+	t.Run("disabled by default", func(t *testing.T) {
+		dynakube := createDynakubeEmptyDynakube()
+
+		assert.False(t, dynakube.FeatureOneAgentVersionEvents())
+		assert.False(t, dynakube.FeatureActiveGateVersionEvents())
+		assert.False(t, dynakube.FeatureInstallFailureEvents())
+		assert.False(t, dynakube.FeatureTokenScopeEvents())
+		assert.False(t, dynakube.FeatureInjectionFailureEvents())
+		assert.False(t, dynakube.FeatureDynatraceEvents())
+	})
+	t.Run("enabled per event type", func(t *testing.T) {
+		dynakube := createDynakubeEmptyDynakube()
+		dynakube.Annotations[AnnotationFeatureInstallFailureEvents] = "true"
+
+		assert.True(t, dynakube.FeatureInstallFailureEvents())
+		assert.False(t, dynakube.FeatureOneAgentVersionEvents())
+		assert.True(t, dynakube.FeatureDynatraceEvents())
+	})
+}
@@ -1,6 +1,8 @@
 package dynakube
 
 import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/rollout"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/versionpolicy"
 	corev1 "k8s.io/api/core/v1"
 )
 
@@ -57,6 +59,15 @@ type HostInjectSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Automatically update Agent",order=13,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
 	AutoUpdate *bool `json:"autoUpdate,omitempty"`
 
+	// Restricts the OneAgent versions used for automatic updates, either by a semantic version constraint or by staying minor versions behind the latest one.
+	// +optional
+	VersionPolicy *versionpolicy.Spec `json:"versionPolicy,omitempty"`
+
+	// Updates the OneAgent pods in stages, first on canary nodes, then node pool by node pool, instead of all at once.
+	// A stage is only started after the OneAgent pods of the previous stages are ready.
+	// +optional
+	Rollout *rollout.Strategy `json:"rollout,omitempty"`
+
 	// Set the DNS Policy for OneAgent pods. For details, see Pods DNS Policy (https://kubernetes.io/docs/concepts/services-networking/dns-pod-service/#pod-s-dns-policy).
 	// +optional
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="DNS Policy",order=24,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:text"}
@@ -123,6 +134,10 @@ type AppInjectionSpec struct {
 	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="CodeModulesImage",order=12,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:text"}
 	CodeModulesImage string `json:"codeModulesImage,omitempty"`
 
+	// Restricts the CodeModules versions used for automatic updates, either by a semantic version constraint or by staying minor versions behind the latest one.
+	// +optional
+	CodeModulesVersionPolicy *versionpolicy.Spec `json:"codeModulesVersionPolicy,omitempty"`
+
 	// Define resources requests and limits for the initContainer. For details, see Managing resources for containers
 	// (https://kubernetes.io/docs/concepts/configuration/manage-resources-containers).
 	// +optional
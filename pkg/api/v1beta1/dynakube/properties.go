@@ -22,9 +22,15 @@ import (
 	"strings"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/rollout"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/settings"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/status"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/tokensource"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/versionpolicy"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/timeprovider"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -40,6 +46,8 @@ const (
 	OneAgentConnectionInfoConfigMapSuffix   = "-oneagent-connection-info"
 	ActiveGateConnectionInfoConfigMapSuffix = "-activegate-connection-info"
 	AuthTokenSecretSuffix                   = "-activegate-authtoken-secret"
+	ActiveGatePoolPrefix                    = "activegate-"
+	ActiveGatePoolTenantSecretSuffix        = "-tenant-secret"
 	PodNameOsAgent                          = "oneagent"
 
 	defaultActiveGateImage = "/linux/activegate:latest"
@@ -68,6 +76,7 @@ func (dk *DynaKube) ApiUrlHost() string {
 func (dk *DynaKube) NeedsActiveGate() bool {
 	return dk.DeprecatedActiveGateMode() ||
 		dk.ActiveGateMode() ||
+		dk.HasActiveGatePools() ||
 		dk.IsSyntheticMonitoringEnabled()
 }
 
@@ -190,6 +199,11 @@ func (dk *DynaKube) ActivegateTenantSecret() string {
 	return dk.Name + ActiveGateTenantSecretSuffix
 }
 
+// ActiveGatePoolTenantSecret returns the name of the secret containing the tenant token for the ActiveGates of a pool
+func (dk *DynaKube) ActiveGatePoolTenantSecret(poolName string) string {
+	return dk.Name + "-" + ActiveGatePoolShortName(poolName) + ActiveGatePoolTenantSecretSuffix
+}
+
 // OneagentTenantSecret returns the name of the secret containing the token for the OneAgent
 func (dk *DynaKube) OneagentTenantSecret() string {
 	return dk.Name + OneAgentTenantSecretSuffix
@@ -293,6 +307,81 @@ func (dk *DynaKube) DefaultActiveGateImage() string {
 	return apiUrlHost + defaultActiveGateImage
 }
 
+// ActiveGateVersionPolicy provides the version policy for the ActiveGate provided in the Spec.
+func (dk *DynaKube) ActiveGateVersionPolicy() *versionpolicy.Spec {
+	return dk.Spec.ActiveGate.VersionPolicy
+}
+
+// ActiveGateAutoscaling provides the HorizontalPodAutoscaler settings for the ActiveGate provided in the Spec.
+func (dk *DynaKube) ActiveGateAutoscaling() *ActiveGateAutoscalingSpec {
+	return dk.Spec.ActiveGate.Autoscaling
+}
+
+// ActiveGatePodDisruptionBudget provides the PodDisruptionBudget settings for the ActiveGate provided in the Spec.
+func (dk *DynaKube) ActiveGatePodDisruptionBudget() *ActiveGatePodDisruptionBudgetSpec {
+	return dk.Spec.ActiveGate.PodDisruptionBudget
+}
+
+// HasActiveGatePools returns true if additional ActiveGate pools are defined in the Spec.
+func (dk *DynaKube) HasActiveGatePools() bool {
+	return len(dk.Spec.ActiveGate.Pools) > 0
+}
+
+// ActiveGatePoolShortName returns the name of the resources of an ActiveGate pool, without the DynaKube name as prefix
+func ActiveGatePoolShortName(poolName string) string {
+	return ActiveGatePoolPrefix + poolName
+}
+
+// ActiveGatePoolVersionStatus returns the version status of the given ActiveGate pool, nil if there is none yet
+func (dk *DynaKube) ActiveGatePoolVersionStatus(poolName string) *status.VersionStatus {
+	for i := range dk.Status.ActiveGate.Pools {
+		if dk.Status.ActiveGate.Pools[i].Name == poolName {
+			return &dk.Status.ActiveGate.Pools[i].VersionStatus
+		}
+	}
+	return nil
+}
+
+// ForActiveGatePool returns a copy of the DynaKube whose ActiveGate section is replaced by the given pool,
+// so the ActiveGate of the pool can be rendered like the one of the ActiveGate section.
+func (dk *DynaKube) ForActiveGatePool(pool ActiveGatePoolSpec) *DynaKube {
+	poolDynakube := dk.DeepCopy()
+	poolDynakube.Spec.ActiveGate.Capabilities = pool.Capabilities
+	poolDynakube.Spec.ActiveGate.CapabilityProperties = *pool.CapabilityProperties.DeepCopy()
+	poolDynakube.Spec.ActiveGate.Autoscaling = nil
+	poolDynakube.Spec.ActiveGate.PodDisruptionBudget = nil
+	poolDynakube.Spec.ActiveGate.Pools = nil
+	if pool.NetworkZone != "" {
+		poolDynakube.Spec.NetworkZone = pool.NetworkZone
+	}
+
+	if versionStatus := dk.ActiveGatePoolVersionStatus(pool.Name); versionStatus != nil && versionStatus.ImageID != "" {
+		poolDynakube.Status.ActiveGate.VersionStatus = *versionStatus
+	}
+	poolDynakube.Status.ActiveGate.Pools = nil
+	return poolDynakube
+}
+
+// GetMinReplicas returns the lower limit for the number of ActiveGate pods, 1 if unset
+func (autoscaling *ActiveGateAutoscalingSpec) GetMinReplicas() int32 {
+	if autoscaling.MinReplicas == nil {
+		return 1
+	}
+	return *autoscaling.MinReplicas
+}
+
+// GetInitialReplicas returns the number of ActiveGate pods the StatefulSet is created with, before the HorizontalPodAutoscaler takes over
+func (autoscaling *ActiveGateAutoscalingSpec) GetInitialReplicas(replicas *int32) int32 {
+	initial := autoscaling.GetMinReplicas()
+	if replicas != nil && *replicas > initial {
+		initial = *replicas
+	}
+	if autoscaling.MaxReplicas > 0 && initial > autoscaling.MaxReplicas {
+		initial = autoscaling.MaxReplicas
+	}
+	return initial
+}
+
 func (dk *DynaKube) deprecatedActiveGateImage() string {
 	if dk.Spec.KubernetesMonitoring.Image != "" {
 		return dk.Spec.KubernetesMonitoring.Image
@@ -366,6 +455,17 @@ func (dk *DynaKube) CustomCodeModulesVersion() string {
 	return dk.CustomOneAgentVersion()
 }
 
+// CodeModulesVersionPolicy provides the version policy for the CodeModules provided in the Spec.
+func (dk *DynaKube) CodeModulesVersionPolicy() *versionpolicy.Spec {
+	switch {
+	case dk.CloudNativeFullstackMode():
+		return dk.Spec.OneAgent.CloudNativeFullStack.CodeModulesVersionPolicy
+	case dk.ApplicationMonitoringMode():
+		return dk.Spec.OneAgent.ApplicationMonitoring.CodeModulesVersionPolicy
+	}
+	return nil
+}
+
 // OneAgentImage provides the image reference set in Status for the OneAgent.
 // Format: repo@sha256:digest
 func (dk *DynaKube) OneAgentImage() string {
@@ -392,6 +492,32 @@ func (dk *DynaKube) CustomOneAgentVersion() string {
 	return ""
 }
 
+// OneAgentVersionPolicy provides the version policy for the OneAgent provided in the Spec.
+func (dk *DynaKube) OneAgentVersionPolicy() *versionpolicy.Spec {
+	switch {
+	case dk.ClassicFullStackMode():
+		return dk.Spec.OneAgent.ClassicFullStack.VersionPolicy
+	case dk.CloudNativeFullstackMode():
+		return dk.Spec.OneAgent.CloudNativeFullStack.VersionPolicy
+	case dk.HostMonitoringMode():
+		return dk.Spec.OneAgent.HostMonitoring.VersionPolicy
+	}
+	return nil
+}
+
+// OneAgentRolloutStrategy provides the staged rollout strategy for the OneAgent DaemonSet provided in the Spec.
+func (dk *DynaKube) OneAgentRolloutStrategy() *rollout.Strategy {
+	switch {
+	case dk.ClassicFullStackMode():
+		return dk.Spec.OneAgent.ClassicFullStack.Rollout
+	case dk.CloudNativeFullstackMode():
+		return dk.Spec.OneAgent.CloudNativeFullStack.Rollout
+	case dk.HostMonitoringMode():
+		return dk.Spec.OneAgent.HostMonitoring.Rollout
+	}
+	return nil
+}
+
 // CustomOneAgentImage provides the image reference for the OneAgent provided in the Spec.
 func (dk *DynaKube) CustomOneAgentImage() string {
 	switch {
@@ -407,12 +533,18 @@ func (dk *DynaKube) CustomOneAgentImage() string {
 
 // DefaultOneAgentImage provides the image reference for the OneAgent from tenant registry.
 func (dk *DynaKube) DefaultOneAgentImage() string {
+	return dk.DefaultOneAgentImageForVersion(dk.CustomOneAgentVersion())
+}
+
+// DefaultOneAgentImageForVersion provides the image reference for the given OneAgent version from tenant registry, latest if the version is empty.
+// Format: repo:tag
+func (dk *DynaKube) DefaultOneAgentImageForVersion(version string) string {
 	if dk.Spec.APIURL == "" {
 		return ""
 	}
 
 	tag := api.LatestTag
-	if version := dk.CustomOneAgentVersion(); version != "" {
+	if version != "" {
 		truncatedVersion := truncateBuildDate(version)
 		tag = truncatedVersion
 	}
@@ -447,6 +579,36 @@ func (dk *DynaKube) Tokens() string {
 	return dk.Name
 }
 
+// TokenSourceType returns where the tokens used for connecting to Dynatrace are read from, the token secret by default
+func (dk *DynaKube) TokenSourceType() tokensource.Type {
+	return dk.Spec.TokenSource.GetType()
+}
+
+// MirrorURL returns the URL of the deployment API mirror, or an empty string if the Dynatrace API is used
+func (dk *DynaKube) MirrorURL() string {
+	return dk.Spec.Mirror.GetURL()
+}
+
+// UsesMirror returns true if agents, versions and images are read from a mirror of the deployment API
+func (dk *DynaKube) UsesMirror() bool {
+	return dk.MirrorURL() != ""
+}
+
+// ManagesSettings returns true if settings objects are defined, or settings objects created before still have to be deleted
+func (dk *DynaKube) ManagesSettings() bool {
+	return len(dk.Spec.Settings) > 0 || len(dk.Status.Settings) > 0
+}
+
+// ManagesKubernetesClusterSettings returns true if a settings object is defined in the scope of the Kubernetes cluster
+func (dk *DynaKube) ManagesKubernetesClusterSettings() bool {
+	for _, settingsSpec := range dk.Spec.Settings {
+		if settingsSpec.Scope == settings.ScopeKubernetesCluster {
+			return true
+		}
+	}
+	return false
+}
+
 // TenantUUIDFromApiUrl gets the tenantUUID from the ApiUrl present in the struct, if the tenant is aliased then the alias will be returned
 func (dk *DynaKube) TenantUUIDFromApiUrl() (string, error) {
 	return tenantUUID(dk.Spec.APIURL)
@@ -542,6 +704,16 @@ func (dk *DynaKube) IsTokenScopeVerificationAllowed(timeProvider *timeprovider.P
 	return timeProvider.IsOutdated(&dk.Status.DynatraceApi.LastTokenScopeRequest, dk.FeatureApiRequestThreshold())
 }
 
+// IsDynatraceEventsIngestAllowed returns false if the last token verification found the events ingest scope missing
+func (dk *DynaKube) IsDynatraceEventsIngestAllowed() bool {
+	return !meta.IsStatusConditionFalse(dk.Status.Conditions, DynatraceEventsConditionType)
+}
+
 func (dk *DynaKube) IsOneAgentCommunicationRouteClear() bool {
 	return len(dk.Status.OneAgent.ConnectionInfoStatus.CommunicationHosts) > 0
 }
+
+// IsUpdateDeferred returns true if updates of already deployed components have to wait for the next maintenance window
+func (dk *DynaKube) IsUpdateDeferred() bool {
+	return dk.Status.UpdatesDeferredUntil != nil
+}
@@ -29,7 +29,11 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
-const testAPIURL = "http://test-endpoint/api"
+const (
+	testAPIURL    = "http://test-endpoint/api"
+	testName      = "test-name"
+	testNamespace = "test-namespace"
+)
 
 func TestDefaultActiveGateImage(t *testing.T) {
 	t.Run(`ActiveGateImage with no API URL`, func(t *testing.T) {
@@ -58,6 +62,70 @@ func TestCustomActiveGateImage(t *testing.T) {
 	})
 }
 
+func TestForActiveGatePool(t *testing.T) {
+	pool := ActiveGatePoolSpec{
+		Name:         "zone-a",
+		Capabilities: []CapabilityDisplayName{RoutingCapability.DisplayName},
+		NetworkZone:  "zone-a",
+		CapabilityProperties: CapabilityProperties{
+			Image: "registry/my/activegate:pool",
+		},
+	}
+	replicas := int32(3)
+	dk := DynaKube{
+		ObjectMeta: metav1.ObjectMeta{Name: testName, Namespace: testNamespace},
+		Spec: DynaKubeSpec{
+			NetworkZone: "default-zone",
+			ActiveGate: ActiveGateSpec{
+				Capabilities:         []CapabilityDisplayName{KubeMonCapability.DisplayName},
+				CapabilityProperties: CapabilityProperties{Image: "registry/my/activegate:latest", Replicas: &replicas},
+				Autoscaling:          &ActiveGateAutoscalingSpec{MaxReplicas: 5},
+				Pools:                []ActiveGatePoolSpec{pool},
+			},
+		},
+		Status: DynaKubeStatus{
+			ActiveGate: ActiveGateStatus{
+				VersionStatus: status.VersionStatus{ImageID: "registry/my/activegate@sha256:main"},
+				Pools: []ActiveGatePoolStatus{
+					{Name: "zone-a", VersionStatus: status.VersionStatus{ImageID: "registry/my/activegate@sha256:pool"}},
+				},
+			},
+		},
+	}
+
+	t.Run(`ActiveGate section is replaced by the pool`, func(t *testing.T) {
+		poolDynakube := dk.ForActiveGatePool(pool)
+
+		assert.Equal(t, pool.Capabilities, poolDynakube.Spec.ActiveGate.Capabilities)
+		assert.Equal(t, pool.CapabilityProperties, poolDynakube.Spec.ActiveGate.CapabilityProperties)
+		assert.Equal(t, "zone-a", poolDynakube.Spec.NetworkZone)
+		assert.Nil(t, poolDynakube.Spec.ActiveGate.Autoscaling)
+		assert.Nil(t, poolDynakube.Spec.ActiveGate.Pools)
+		assert.Equal(t, "registry/my/activegate@sha256:pool", poolDynakube.ActiveGateImage())
+		assert.Nil(t, poolDynakube.Status.ActiveGate.Pools)
+	})
+	t.Run(`original DynaKube is not modified`, func(t *testing.T) {
+		dk.ForActiveGatePool(pool)
+
+		assert.Equal(t, "default-zone", dk.Spec.NetworkZone)
+		assert.Equal(t, []CapabilityDisplayName{KubeMonCapability.DisplayName}, dk.Spec.ActiveGate.Capabilities)
+		assert.Equal(t, "registry/my/activegate@sha256:main", dk.ActiveGateImage())
+	})
+	t.Run(`network zone and image of the DynaKube are used if not set for the pool`, func(t *testing.T) {
+		poolDynakube := dk.ForActiveGatePool(ActiveGatePoolSpec{Name: "zone-b", Capabilities: pool.Capabilities})
+
+		assert.Equal(t, "default-zone", poolDynakube.Spec.NetworkZone)
+		assert.Equal(t, "registry/my/activegate@sha256:main", poolDynakube.ActiveGateImage())
+	})
+	t.Run(`names of the pool`, func(t *testing.T) {
+		assert.True(t, dk.HasActiveGatePools())
+		assert.True(t, dk.NeedsActiveGate())
+		assert.Equal(t, "activegate-zone-a", ActiveGatePoolShortName("zone-a"))
+		assert.Equal(t, testName+"-activegate-zone-a-tenant-secret", dk.ActiveGatePoolTenantSecret("zone-a"))
+		assert.Nil(t, dk.ActiveGatePoolVersionStatus("zone-b"))
+	})
+}
+
 func TestDynaKube_UseCSIDriver(t *testing.T) {
 	t.Run(`DynaKube with application monitoring without csi driver`, func(t *testing.T) {
 		dk := DynaKube{
@@ -20,11 +20,57 @@ limitations under the License.
 package dynakube
 
 import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/maintenancewindow"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/mirror"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/oauth"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/rollout"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/settings"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/tokensource"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/versionpolicy"
+	"k8s.io/api/autoscaling/v2"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActiveGateAutoscalingSpec) DeepCopyInto(out *ActiveGateAutoscalingSpec) {
+	*out = *in
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TargetCPUUtilization != nil {
+		in, out := &in.TargetCPUUtilization, &out.TargetCPUUtilization
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TargetMemoryUtilization != nil {
+		in, out := &in.TargetMemoryUtilization, &out.TargetMemoryUtilization
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = make([]v2.MetricSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActiveGateAutoscalingSpec.
+func (in *ActiveGateAutoscalingSpec) DeepCopy() *ActiveGateAutoscalingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ActiveGateAutoscalingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ActiveGateCapability) DeepCopyInto(out *ActiveGateCapability) {
 	*out = *in
@@ -56,6 +102,68 @@ func (in *ActiveGateConnectionInfoStatus) DeepCopy() *ActiveGateConnectionInfoSt
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActiveGatePodDisruptionBudgetSpec) DeepCopyInto(out *ActiveGatePodDisruptionBudgetSpec) {
+	*out = *in
+	if in.MinAvailable != nil {
+		in, out := &in.MinAvailable, &out.MinAvailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActiveGatePodDisruptionBudgetSpec.
+func (in *ActiveGatePodDisruptionBudgetSpec) DeepCopy() *ActiveGatePodDisruptionBudgetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ActiveGatePodDisruptionBudgetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActiveGatePoolSpec) DeepCopyInto(out *ActiveGatePoolSpec) {
+	*out = *in
+	if in.Capabilities != nil {
+		in, out := &in.Capabilities, &out.Capabilities
+		*out = make([]CapabilityDisplayName, len(*in))
+		copy(*out, *in)
+	}
+	in.CapabilityProperties.DeepCopyInto(&out.CapabilityProperties)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActiveGatePoolSpec.
+func (in *ActiveGatePoolSpec) DeepCopy() *ActiveGatePoolSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ActiveGatePoolSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActiveGatePoolStatus) DeepCopyInto(out *ActiveGatePoolStatus) {
+	*out = *in
+	in.VersionStatus.DeepCopyInto(&out.VersionStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActiveGatePoolStatus.
+func (in *ActiveGatePoolStatus) DeepCopy() *ActiveGatePoolStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ActiveGatePoolStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ActiveGateSpec) DeepCopyInto(out *ActiveGateSpec) {
 	*out = *in
@@ -72,6 +180,28 @@ func (in *ActiveGateSpec) DeepCopyInto(out *ActiveGateSpec) {
 			(*out)[key] = val
 		}
 	}
+	if in.VersionPolicy != nil {
+		in, out := &in.VersionPolicy, &out.VersionPolicy
+		*out = new(versionpolicy.Spec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(ActiveGateAutoscalingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(ActiveGatePodDisruptionBudgetSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Pools != nil {
+		in, out := &in.Pools, &out.Pools
+		*out = make([]ActiveGatePoolSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActiveGateSpec.
@@ -89,6 +219,13 @@ func (in *ActiveGateStatus) DeepCopyInto(out *ActiveGateStatus) {
 	*out = *in
 	in.VersionStatus.DeepCopyInto(&out.VersionStatus)
 	in.ConnectionInfoStatus.DeepCopyInto(&out.ConnectionInfoStatus)
+	if in.Pools != nil {
+		in, out := &in.Pools, &out.Pools
+		*out = make([]ActiveGatePoolStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActiveGateStatus.
@@ -104,6 +241,11 @@ func (in *ActiveGateStatus) DeepCopy() *ActiveGateStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AppInjectionSpec) DeepCopyInto(out *AppInjectionSpec) {
 	*out = *in
+	if in.CodeModulesVersionPolicy != nil {
+		in, out := &in.CodeModulesVersionPolicy, &out.CodeModulesVersionPolicy
+		*out = new(versionpolicy.Spec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.InitResources != nil {
 		in, out := &in.InitResources, &out.InitResources
 		*out = new(v1.ResourceRequirements)
@@ -344,6 +486,21 @@ func (in *DynaKubeProxy) DeepCopy() *DynaKubeProxy {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DynaKubeSpec) DeepCopyInto(out *DynaKubeSpec) {
 	*out = *in
+	if in.TokenSource != nil {
+		in, out := &in.TokenSource, &out.TokenSource
+		*out = new(tokensource.Spec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OAuth != nil {
+		in, out := &in.OAuth, &out.OAuth
+		*out = new(oauth.Spec)
+		**out = **in
+	}
+	if in.Mirror != nil {
+		in, out := &in.Mirror, &out.Mirror
+		*out = new(mirror.Spec)
+		**out = **in
+	}
 	if in.Proxy != nil {
 		in, out := &in.Proxy, &out.Proxy
 		*out = new(DynaKubeProxy)
@@ -351,6 +508,20 @@ func (in *DynaKubeSpec) DeepCopyInto(out *DynaKubeSpec) {
 	}
 	in.NamespaceSelector.DeepCopyInto(&out.NamespaceSelector)
 	in.PodSelector.DeepCopyInto(&out.PodSelector)
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]maintenancewindow.Window, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Settings != nil {
+		in, out := &in.Settings, &out.Settings
+		*out = make([]settings.Spec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	in.OneAgent.DeepCopyInto(&out.OneAgent)
 	in.ActiveGate.DeepCopyInto(&out.ActiveGate)
 	in.Routing.DeepCopyInto(&out.Routing)
@@ -382,6 +553,17 @@ func (in *DynaKubeStatus) DeepCopyInto(out *DynaKubeStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.UpdatesDeferredUntil != nil {
+		in, out := &in.UpdatesDeferredUntil, &out.UpdatesDeferredUntil
+		*out = (*in).DeepCopy()
+	}
+	if in.Settings != nil {
+		in, out := &in.Settings, &out.Settings
+		*out = make([]settings.Status, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	in.ActiveGate.DeepCopyInto(&out.ActiveGate)
 	in.OneAgent.DeepCopyInto(&out.OneAgent)
 	in.CodeModules.DeepCopyInto(&out.CodeModules)
@@ -445,6 +627,16 @@ func (in *HostInjectSpec) DeepCopyInto(out *HostInjectSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.VersionPolicy != nil {
+		in, out := &in.VersionPolicy, &out.VersionPolicy
+		*out = new(versionpolicy.Spec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(rollout.Strategy)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Annotations != nil {
 		in, out := &in.Annotations, &out.Annotations
 		*out = make(map[string]string, len(*in))
@@ -594,6 +786,11 @@ func (in *OneAgentStatus) DeepCopyInto(out *OneAgentStatus) {
 		*out = (*in).DeepCopy()
 	}
 	in.ConnectionInfoStatus.DeepCopyInto(&out.ConnectionInfoStatus)
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(rollout.Status)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OneAgentStatus.
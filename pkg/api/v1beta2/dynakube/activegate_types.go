@@ -0,0 +1,216 @@
+package dynakube
+
+import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/versionpolicy"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+type CapabilityDisplayName string
+
+type ActiveGateSpec struct {
+
+	// Activegate capabilities enabled (routing, kubernetes-monitoring, metrics-ingest, dynatrace-api)
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Capabilities",order=10,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:text"}
+	Capabilities []CapabilityDisplayName `json:"capabilities,omitempty"`
+
+	CapabilityProperties `json:",inline"`
+
+	// The name of a secret containing ActiveGate TLS cert+key and password. If not set, self-signed certificate is used.
+	// server.p12: certificate+key pair in pkcs12 format
+	// password: passphrase to read server.p12
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="TlsSecretName",order=10,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:text"}
+	TlsSecretName string `json:"tlsSecretName,omitempty"`
+
+	// Sets DNS Policy for the ActiveGate pods
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="DNS Policy",order=24,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:text"}
+	DNSPolicy corev1.DNSPolicy `json:"dnsPolicy,omitempty"`
+
+	// If specified, indicates the pod's priority. Name must be defined by creating a PriorityClass object with that
+	// name. If not specified the setting will be removed from the StatefulSet.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Priority Class name",order=23,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:io.kubernetes:PriorityClass"}
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// Adds additional annotations to the ActiveGate pods
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Annotations",order=27,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:text"}
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Enables automatic updates of the ActiveGate to new versions.
+	// Enabled by default.
+	// +optional
+	AutoUpdate *bool `json:"autoUpdate,omitempty"`
+
+	// Restricts the ActiveGate versions used for automatic updates, either by a semantic version constraint or by staying minor versions behind the latest one.
+	// +optional
+	VersionPolicy *versionpolicy.Spec `json:"versionPolicy,omitempty"`
+
+	// Uses the raw ActiveGate image, the tenant UUID, token and communication endpoints are provided by the Dynatrace Operator.
+	// Enabled by default.
+	// +optional
+	RawImage *bool `json:"rawImage,omitempty"`
+
+	// Generates and uses an ActiveGate authentication token.
+	// Enabled by default.
+	// +optional
+	AuthToken *bool `json:"authToken,omitempty"`
+
+	// Enables the AppArmor profile for the ActiveGate container.
+	// Disabled by default.
+	// +optional
+	AppArmor *bool `json:"appArmor,omitempty"`
+
+	// Mounts the root filesystem of the ActiveGate container read-only.
+	// Enabled by default.
+	// +optional
+	ReadOnlyFilesystem *bool `json:"readOnlyFilesystem,omitempty"`
+
+	// Ignores the proxy set in the DynaKube for the ActiveGate.
+	// Disabled by default.
+	// +optional
+	IgnoreProxy *bool `json:"ignoreProxy,omitempty"`
+
+	// Scales the ActiveGate pods with a HorizontalPodAutoscaler. If set, replicas is only used as the initial number of pods, limited by minReplicas and maxReplicas.
+	// +optional
+	Autoscaling *ActiveGateAutoscalingSpec `json:"autoscaling,omitempty"`
+
+	// Protects the ActiveGate pods from voluntary disruptions, like node drains, with a PodDisruptionBudget.
+	// +optional
+	PodDisruptionBudget *ActiveGatePodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
+
+	// Additional groups of ActiveGate pods, each with its own capabilities and placement.
+	// Every pool gets its own StatefulSet, Service and tenant secret. The TLS secret, DNS policy, priority class, annotations
+	// and version policy of this section apply to the pools too.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="ActiveGate pools",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:hidden"}
+	Pools []ActiveGatePoolSpec `json:"pools,omitempty"`
+}
+
+// ActiveGatePoolSpec defines a group of ActiveGate pods with its own capabilities and placement
+type ActiveGatePoolSpec struct {
+	// Name of the pool, it is part of the names of the StatefulSet, Service and tenant secret of the pool.
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Name",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:text"}
+	Name string `json:"name"`
+
+	// Activegate capabilities enabled for the pool (routing, kubernetes-monitoring, metrics-ingest, dynatrace-api)
+	// +kubebuilder:validation:MinItems=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Capabilities",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:text"}
+	Capabilities []CapabilityDisplayName `json:"capabilities"`
+
+	// Network zone of the ActiveGate pods of the pool. Defaults to the network zone of the DynaKube.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Network Zone",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:text"}
+	NetworkZone string `json:"networkZone,omitempty"`
+
+	CapabilityProperties `json:",inline"`
+}
+
+// ActiveGateAutoscalingSpec defines the HorizontalPodAutoscaler of the ActiveGate StatefulSet
+type ActiveGateAutoscalingSpec struct {
+	// Lower limit for the number of ActiveGate pods. Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Min replicas",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:podCount"}
+	MinReplicas *int32 `json:"minReplicas,omitempty"`
+
+	// Upper limit for the number of ActiveGate pods.
+	// +kubebuilder:validation:Minimum=1
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Max replicas",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:podCount"}
+	MaxReplicas int32 `json:"maxReplicas"`
+
+	// Target average CPU utilization of the ActiveGate pods, in percent of their CPU request.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Target CPU utilization",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:number"}
+	TargetCPUUtilization *int32 `json:"targetCPUUtilization,omitempty"`
+
+	// Target average memory utilization of the ActiveGate pods, in percent of their memory request.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Target memory utilization",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:number"}
+	TargetMemoryUtilization *int32 `json:"targetMemoryUtilization,omitempty"`
+
+	// Additional metrics to scale on, e.g. a custom metric of the ActiveGate pods.
+	// For details, see Scaling on custom metrics (https://kubernetes.io/docs/tasks/run-application/horizontal-pod-autoscale/#scaling-on-custom-metrics).
+	// If no metric is set at all, the ActiveGate pods are scaled to an average CPU utilization of 80%.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Metrics",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:hidden"}
+	Metrics []autoscalingv2.MetricSpec `json:"metrics,omitempty"`
+}
+
+// ActiveGatePodDisruptionBudgetSpec defines the PodDisruptionBudget of the ActiveGate StatefulSet, only one of its fields can be set
+type ActiveGatePodDisruptionBudgetSpec struct {
+	// Number or percentage of ActiveGate pods that have to stay available during a voluntary disruption.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Min available",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:text"}
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+
+	// Number or percentage of ActiveGate pods that may be unavailable during a voluntary disruption. Defaults to 1 if minAvailable is not set.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Max unavailable",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:text"}
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// CapabilityProperties is a struct which can be embedded by ActiveGate capabilities
+// Such as KubernetesMonitoring or Routing
+// It encapsulates common properties
+type CapabilityProperties struct {
+	// Amount of replicas for your ActiveGates
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Replicas",order=30,xDescriptors="urn:alm:descriptor:com.tectonic.ui:podCount"
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// The ActiveGate container image. Defaults to the latest ActiveGate image provided by the registry on the tenant
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Image",order=10,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:text"}
+	Image string `json:"image,omitempty"`
+
+	// Set activation group for ActiveGate
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Activation group",order=31,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:text"}
+	Group string `json:"group,omitempty"`
+
+	// Add a custom properties file by providing it as a value or reference it from a secret
+	// +optional
+	// If referenced from a secret, make sure the key is called 'customProperties'
+	CustomProperties *DynaKubeValueSource `json:"customProperties,omitempty"`
+
+	// Define resources requests and limits for single ActiveGate pods
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Resource Requirements",order=34,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:resourceRequirements"}
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Node selector to control the selection of nodes
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Node Selector",order=35,xDescriptors="urn:alm:descriptor:com.tectonic.ui:selector:Node"
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Set tolerations for the ActiveGate pods
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Tolerations",order=36,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:hidden"}
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Adds additional labels for the ActiveGate pods
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Labels",order=37,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:text"}
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// List of environment variables to set for the ActiveGate
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Environment variables",order=39,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:hidden"}
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Environment variables"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:advanced,urn:alm:descriptor:com.tectonic.ui:text"
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// Adds TopologySpreadConstraints for the ActiveGate pods
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="topologySpreadConstraints",order=40,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:hidden"}
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+}
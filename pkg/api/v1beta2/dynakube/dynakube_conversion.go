@@ -0,0 +1,270 @@
+package dynakube
+
+import (
+	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// ConvertTo converts v1beta2 to v1beta1
+func (src *DynaKube) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*dynatracev1beta1.DynaKube)
+	src.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+
+	// DynakubeSpec
+	dst.Spec.APIURL = src.Spec.APIURL
+	dst.Spec.Tokens = src.Spec.Tokens
+	dst.Spec.TokenSource = src.Spec.TokenSource
+	dst.Spec.OAuth = src.Spec.OAuth
+	dst.Spec.Mirror = src.Spec.Mirror
+	dst.Spec.CustomPullSecret = src.Spec.CustomPullSecret
+	dst.Spec.SkipCertCheck = src.Spec.SkipCertCheck
+	dst.Spec.Proxy = (*dynatracev1beta1.DynaKubeProxy)(src.Spec.Proxy)
+	dst.Spec.TrustedCAs = src.Spec.TrustedCAs
+	dst.Spec.NetworkZone = src.Spec.NetworkZone
+	dst.Spec.EnableIstio = src.Spec.EnableIstio
+	dst.Spec.NamespaceSelector = src.Spec.NamespaceSelector
+	dst.Spec.PodSelector = src.Spec.PodSelector
+	dst.Spec.MaintenanceWindows = src.Spec.MaintenanceWindows
+	dst.Spec.Settings = src.Spec.Settings
+
+	// OneAgent
+	dst.Spec.OneAgent.ClassicFullStack = (*dynatracev1beta1.HostInjectSpec)(src.Spec.OneAgent.ClassicFullStack)
+	dst.Spec.OneAgent.HostMonitoring = (*dynatracev1beta1.HostInjectSpec)(src.Spec.OneAgent.HostMonitoring)
+
+	if src.Spec.OneAgent.CloudNativeFullStack != nil {
+		dst.Spec.OneAgent.CloudNativeFullStack = &dynatracev1beta1.CloudNativeFullStackSpec{
+			HostInjectSpec:   dynatracev1beta1.HostInjectSpec(src.Spec.OneAgent.CloudNativeFullStack.HostInjectSpec),
+			AppInjectionSpec: dynatracev1beta1.AppInjectionSpec(src.Spec.OneAgent.CloudNativeFullStack.AppInjectionSpec),
+		}
+	}
+
+	if src.Spec.OneAgent.ApplicationMonitoring != nil {
+		dst.Spec.OneAgent.ApplicationMonitoring = &dynatracev1beta1.ApplicationMonitoringSpec{
+			AppInjectionSpec: dynatracev1beta1.AppInjectionSpec(src.Spec.OneAgent.ApplicationMonitoring.AppInjectionSpec),
+			Version:          src.Spec.OneAgent.ApplicationMonitoring.Version,
+			UseCSIDriver:     src.Spec.OneAgent.ApplicationMonitoring.UseCSIDriver,
+		}
+	}
+
+	// ActiveGates
+	dst.Spec.ActiveGate.Capabilities = nil
+	for _, capability := range src.Spec.ActiveGate.Capabilities {
+		dst.Spec.ActiveGate.Capabilities = append(dst.Spec.ActiveGate.Capabilities, dynatracev1beta1.CapabilityDisplayName(capability))
+	}
+	convertToBetaCapabilityProperties(&dst.Spec.ActiveGate.CapabilityProperties, &src.Spec.ActiveGate.CapabilityProperties)
+	dst.Spec.ActiveGate.TlsSecretName = src.Spec.ActiveGate.TlsSecretName
+	dst.Spec.ActiveGate.DNSPolicy = src.Spec.ActiveGate.DNSPolicy
+	dst.Spec.ActiveGate.PriorityClassName = src.Spec.ActiveGate.PriorityClassName
+	dst.Spec.ActiveGate.Annotations = src.Spec.ActiveGate.Annotations
+	dst.Spec.ActiveGate.VersionPolicy = src.Spec.ActiveGate.VersionPolicy
+	dst.Spec.ActiveGate.Autoscaling = (*dynatracev1beta1.ActiveGateAutoscalingSpec)(src.Spec.ActiveGate.Autoscaling)
+	dst.Spec.ActiveGate.PodDisruptionBudget = (*dynatracev1beta1.ActiveGatePodDisruptionBudgetSpec)(src.Spec.ActiveGate.PodDisruptionBudget)
+	dst.Spec.ActiveGate.Pools = nil
+	for _, pool := range src.Spec.ActiveGate.Pools {
+		dst.Spec.ActiveGate.Pools = append(dst.Spec.ActiveGate.Pools, convertToBetaActiveGatePool(pool))
+	}
+
+	dst.Spec.Routing.Enabled = src.Spec.Routing.Enabled
+	convertToBetaCapabilityProperties(&dst.Spec.Routing.CapabilityProperties, &src.Spec.Routing.CapabilityProperties)
+	dst.Spec.KubernetesMonitoring.Enabled = src.Spec.KubernetesMonitoring.Enabled
+	convertToBetaCapabilityProperties(&dst.Spec.KubernetesMonitoring.CapabilityProperties, &src.Spec.KubernetesMonitoring.CapabilityProperties)
+
+	// Typed settings, stored as feature flags in v1beta1
+	src.convertToFeatureFlags(dst)
+
+	// Status
+	dst.Status.Phase = src.Status.Phase
+	dst.Status.UpdatedTimestamp = src.Status.UpdatedTimestamp
+	dst.Status.LastTokenProbeTimestamp = src.Status.LastTokenProbeTimestamp
+	dst.Status.KubeSystemUUID = src.Status.KubeSystemUUID
+	dst.Status.Conditions = src.Status.Conditions
+	dst.Status.UpdatesDeferredUntil = src.Status.UpdatesDeferredUntil
+	dst.Status.Settings = src.Status.Settings
+
+	dst.Status.ActiveGate.VersionStatus = src.Status.ActiveGate.VersionStatus
+	dst.Status.ActiveGate.ConnectionInfoStatus.ConnectionInfoStatus = dynatracev1beta1.ConnectionInfoStatus(src.Status.ActiveGate.ConnectionInfoStatus.ConnectionInfoStatus)
+	dst.Status.ActiveGate.Pools = nil
+	for _, pool := range src.Status.ActiveGate.Pools {
+		dst.Status.ActiveGate.Pools = append(dst.Status.ActiveGate.Pools, dynatracev1beta1.ActiveGatePoolStatus(pool))
+	}
+
+	dst.Status.OneAgent.VersionStatus = src.Status.OneAgent.VersionStatus
+	dst.Status.OneAgent.Instances = nil
+	if src.Status.OneAgent.Instances != nil {
+		dst.Status.OneAgent.Instances = map[string]dynatracev1beta1.OneAgentInstance{}
+		for key, value := range src.Status.OneAgent.Instances {
+			dst.Status.OneAgent.Instances[key] = dynatracev1beta1.OneAgentInstance(value)
+		}
+	}
+	dst.Status.OneAgent.LastInstanceStatusUpdate = src.Status.OneAgent.LastInstanceStatusUpdate
+	dst.Status.OneAgent.ConnectionInfoStatus.ConnectionInfoStatus = dynatracev1beta1.ConnectionInfoStatus(src.Status.OneAgent.ConnectionInfoStatus.ConnectionInfoStatus)
+	dst.Status.OneAgent.ConnectionInfoStatus.CommunicationHosts = nil
+	for _, host := range src.Status.OneAgent.ConnectionInfoStatus.CommunicationHosts {
+		dst.Status.OneAgent.ConnectionInfoStatus.CommunicationHosts = append(dst.Status.OneAgent.ConnectionInfoStatus.CommunicationHosts, dynatracev1beta1.CommunicationHostStatus(host))
+	}
+	dst.Status.OneAgent.Healthcheck = src.Status.OneAgent.Healthcheck
+	dst.Status.OneAgent.Rollout = src.Status.OneAgent.Rollout
+
+	dst.Status.CodeModules.VersionStatus = src.Status.CodeModules.VersionStatus
+	dst.Status.Synthetic.VersionStatus = src.Status.Synthetic.VersionStatus
+	dst.Status.DynatraceApi = dynatracev1beta1.DynatraceApiStatus(src.Status.DynatraceApi)
+
+	return nil
+}
+
+func convertToBetaCapabilityProperties(dst *dynatracev1beta1.CapabilityProperties, src *CapabilityProperties) {
+	dst.Replicas = src.Replicas
+	dst.Image = src.Image
+	dst.Group = src.Group
+	dst.CustomProperties = (*dynatracev1beta1.DynaKubeValueSource)(src.CustomProperties)
+	dst.Resources = src.Resources
+	dst.NodeSelector = src.NodeSelector
+	dst.Tolerations = src.Tolerations
+	dst.Labels = src.Labels
+	dst.Env = src.Env
+	dst.TopologySpreadConstraints = src.TopologySpreadConstraints
+}
+
+func convertToBetaActiveGatePool(src ActiveGatePoolSpec) dynatracev1beta1.ActiveGatePoolSpec {
+	dst := dynatracev1beta1.ActiveGatePoolSpec{
+		Name:        src.Name,
+		NetworkZone: src.NetworkZone,
+	}
+	for _, capability := range src.Capabilities {
+		dst.Capabilities = append(dst.Capabilities, dynatracev1beta1.CapabilityDisplayName(capability))
+	}
+	convertToBetaCapabilityProperties(&dst.CapabilityProperties, &src.CapabilityProperties)
+	return dst
+}
+
+// ConvertFrom converts v1beta1 to v1beta2
+func (dst *DynaKube) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*dynatracev1beta1.DynaKube)
+	src.ObjectMeta.DeepCopyInto(&dst.ObjectMeta)
+
+	// DynakubeSpec
+	dst.Spec.APIURL = src.Spec.APIURL
+	dst.Spec.Tokens = src.Spec.Tokens
+	dst.Spec.TokenSource = src.Spec.TokenSource
+	dst.Spec.OAuth = src.Spec.OAuth
+	dst.Spec.Mirror = src.Spec.Mirror
+	dst.Spec.CustomPullSecret = src.Spec.CustomPullSecret
+	dst.Spec.SkipCertCheck = src.Spec.SkipCertCheck
+	dst.Spec.Proxy = (*DynaKubeProxy)(src.Spec.Proxy)
+	dst.Spec.TrustedCAs = src.Spec.TrustedCAs
+	dst.Spec.NetworkZone = src.Spec.NetworkZone
+	dst.Spec.EnableIstio = src.Spec.EnableIstio
+	dst.Spec.NamespaceSelector = src.Spec.NamespaceSelector
+	dst.Spec.PodSelector = src.Spec.PodSelector
+	dst.Spec.MaintenanceWindows = src.Spec.MaintenanceWindows
+	dst.Spec.Settings = src.Spec.Settings
+
+	// OneAgent
+	dst.Spec.OneAgent.ClassicFullStack = (*HostInjectSpec)(src.Spec.OneAgent.ClassicFullStack)
+	dst.Spec.OneAgent.HostMonitoring = (*HostInjectSpec)(src.Spec.OneAgent.HostMonitoring)
+
+	if src.Spec.OneAgent.CloudNativeFullStack != nil {
+		dst.Spec.OneAgent.CloudNativeFullStack = &CloudNativeFullStackSpec{
+			HostInjectSpec:   HostInjectSpec(src.Spec.OneAgent.CloudNativeFullStack.HostInjectSpec),
+			AppInjectionSpec: AppInjectionSpec(src.Spec.OneAgent.CloudNativeFullStack.AppInjectionSpec),
+		}
+	}
+
+	if src.Spec.OneAgent.ApplicationMonitoring != nil {
+		dst.Spec.OneAgent.ApplicationMonitoring = &ApplicationMonitoringSpec{
+			AppInjectionSpec: AppInjectionSpec(src.Spec.OneAgent.ApplicationMonitoring.AppInjectionSpec),
+			Version:          src.Spec.OneAgent.ApplicationMonitoring.Version,
+			UseCSIDriver:     src.Spec.OneAgent.ApplicationMonitoring.UseCSIDriver,
+		}
+	}
+
+	// ActiveGates
+	dst.Spec.ActiveGate.Capabilities = nil
+	for _, capability := range src.Spec.ActiveGate.Capabilities {
+		dst.Spec.ActiveGate.Capabilities = append(dst.Spec.ActiveGate.Capabilities, CapabilityDisplayName(capability))
+	}
+	convertFromBetaCapabilityProperties(&dst.Spec.ActiveGate.CapabilityProperties, &src.Spec.ActiveGate.CapabilityProperties)
+	dst.Spec.ActiveGate.TlsSecretName = src.Spec.ActiveGate.TlsSecretName
+	dst.Spec.ActiveGate.DNSPolicy = src.Spec.ActiveGate.DNSPolicy
+	dst.Spec.ActiveGate.PriorityClassName = src.Spec.ActiveGate.PriorityClassName
+	dst.Spec.ActiveGate.Annotations = src.Spec.ActiveGate.Annotations
+	dst.Spec.ActiveGate.VersionPolicy = src.Spec.ActiveGate.VersionPolicy
+	dst.Spec.ActiveGate.Autoscaling = (*ActiveGateAutoscalingSpec)(src.Spec.ActiveGate.Autoscaling)
+	dst.Spec.ActiveGate.PodDisruptionBudget = (*ActiveGatePodDisruptionBudgetSpec)(src.Spec.ActiveGate.PodDisruptionBudget)
+	dst.Spec.ActiveGate.Pools = nil
+	for _, pool := range src.Spec.ActiveGate.Pools {
+		dst.Spec.ActiveGate.Pools = append(dst.Spec.ActiveGate.Pools, convertFromBetaActiveGatePool(pool))
+	}
+
+	dst.Spec.Routing.Enabled = src.Spec.Routing.Enabled
+	convertFromBetaCapabilityProperties(&dst.Spec.Routing.CapabilityProperties, &src.Spec.Routing.CapabilityProperties)
+	dst.Spec.KubernetesMonitoring.Enabled = src.Spec.KubernetesMonitoring.Enabled
+	convertFromBetaCapabilityProperties(&dst.Spec.KubernetesMonitoring.CapabilityProperties, &src.Spec.KubernetesMonitoring.CapabilityProperties)
+
+	// Feature flags, typed settings in v1beta2
+	dst.convertFromFeatureFlags()
+
+	// Status
+	dst.Status.Phase = src.Status.Phase
+	dst.Status.UpdatedTimestamp = src.Status.UpdatedTimestamp
+	dst.Status.LastTokenProbeTimestamp = src.Status.LastTokenProbeTimestamp
+	dst.Status.KubeSystemUUID = src.Status.KubeSystemUUID
+	dst.Status.Conditions = src.Status.Conditions
+	dst.Status.UpdatesDeferredUntil = src.Status.UpdatesDeferredUntil
+	dst.Status.Settings = src.Status.Settings
+
+	dst.Status.ActiveGate.VersionStatus = src.Status.ActiveGate.VersionStatus
+	dst.Status.ActiveGate.ConnectionInfoStatus.ConnectionInfoStatus = ConnectionInfoStatus(src.Status.ActiveGate.ConnectionInfoStatus.ConnectionInfoStatus)
+	dst.Status.ActiveGate.Pools = nil
+	for _, pool := range src.Status.ActiveGate.Pools {
+		dst.Status.ActiveGate.Pools = append(dst.Status.ActiveGate.Pools, ActiveGatePoolStatus(pool))
+	}
+
+	dst.Status.OneAgent.VersionStatus = src.Status.OneAgent.VersionStatus
+	dst.Status.OneAgent.Instances = nil
+	if src.Status.OneAgent.Instances != nil {
+		dst.Status.OneAgent.Instances = map[string]OneAgentInstance{}
+		for key, value := range src.Status.OneAgent.Instances {
+			dst.Status.OneAgent.Instances[key] = OneAgentInstance(value)
+		}
+	}
+	dst.Status.OneAgent.LastInstanceStatusUpdate = src.Status.OneAgent.LastInstanceStatusUpdate
+	dst.Status.OneAgent.ConnectionInfoStatus.ConnectionInfoStatus = ConnectionInfoStatus(src.Status.OneAgent.ConnectionInfoStatus.ConnectionInfoStatus)
+	dst.Status.OneAgent.ConnectionInfoStatus.CommunicationHosts = nil
+	for _, host := range src.Status.OneAgent.ConnectionInfoStatus.CommunicationHosts {
+		dst.Status.OneAgent.ConnectionInfoStatus.CommunicationHosts = append(dst.Status.OneAgent.ConnectionInfoStatus.CommunicationHosts, CommunicationHostStatus(host))
+	}
+	dst.Status.OneAgent.Healthcheck = src.Status.OneAgent.Healthcheck
+	dst.Status.OneAgent.Rollout = src.Status.OneAgent.Rollout
+
+	dst.Status.CodeModules.VersionStatus = src.Status.CodeModules.VersionStatus
+	dst.Status.Synthetic.VersionStatus = src.Status.Synthetic.VersionStatus
+	dst.Status.DynatraceApi = DynatraceApiStatus(src.Status.DynatraceApi)
+
+	return nil
+}
+
+func convertFromBetaCapabilityProperties(dst *CapabilityProperties, src *dynatracev1beta1.CapabilityProperties) {
+	dst.Replicas = src.Replicas
+	dst.Image = src.Image
+	dst.Group = src.Group
+	dst.CustomProperties = (*DynaKubeValueSource)(src.CustomProperties)
+	dst.Resources = src.Resources
+	dst.NodeSelector = src.NodeSelector
+	dst.Tolerations = src.Tolerations
+	dst.Labels = src.Labels
+	dst.Env = src.Env
+	dst.TopologySpreadConstraints = src.TopologySpreadConstraints
+}
+
+func convertFromBetaActiveGatePool(src dynatracev1beta1.ActiveGatePoolSpec) ActiveGatePoolSpec {
+	dst := ActiveGatePoolSpec{
+		Name:        src.Name,
+		NetworkZone: src.NetworkZone,
+	}
+	for _, capability := range src.Capabilities {
+		dst.Capabilities = append(dst.Capabilities, CapabilityDisplayName(capability))
+	}
+	convertFromBetaCapabilityProperties(&dst.CapabilityProperties, &src.CapabilityProperties)
+	return dst
+}
@@ -0,0 +1,354 @@
+package dynakube
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/maintenancewindow"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/rollout"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/settings"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/status"
+	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/versionpolicy"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/address"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	testName             = "test-name"
+	testNamespace        = "test-namespace"
+	testAPIURL           = "https://f.q.d.n/api"
+	testToken            = "test-token"
+	testCustomPullSecret = "test-custompullsecret"
+	testNetworkZone      = "test-networkzone"
+	testActiveGateImage  = "test-activegateimage"
+	testOtherAnnotation  = "other.annotation/key"
+)
+
+var testMinAvailable = intstr.FromString("50%")
+
+func TestConversion_ConvertTo(t *testing.T) {
+	t.Run("typed settings end up as feature flags", func(t *testing.T) {
+		dynakube := getTypedDynakube()
+		converted := &dynatracev1beta1.DynaKube{}
+
+		err := dynakube.ConvertTo(converted)
+		require.NoError(t, err)
+
+		assert.Equal(t, testName, converted.Name)
+		assert.Equal(t, testNamespace, converted.Namespace)
+		assert.Equal(t, testAPIURL, converted.Spec.APIURL)
+		assert.Equal(t, testToken, converted.Spec.Tokens)
+		assert.Equal(t, testCustomPullSecret, converted.Spec.CustomPullSecret)
+		assert.Equal(t, testNetworkZone, converted.Spec.NetworkZone)
+		assert.Equal(t, testActiveGateImage, converted.Spec.ActiveGate.Image)
+		assert.NotNil(t, converted.Spec.OneAgent.CloudNativeFullStack)
+		assert.Equal(t, ">=1.280", converted.OneAgentVersionPolicy().Constraint)
+		assert.Equal(t, 1, converted.CodeModulesVersionPolicy().GetMinorVersionsBehindLatest())
+		assert.Equal(t, "~1.281", converted.ActiveGateVersionPolicy().Constraint)
+		assert.Equal(t, int32(2), converted.ActiveGateAutoscaling().GetMinReplicas())
+		assert.Equal(t, int32(5), converted.ActiveGateAutoscaling().MaxReplicas)
+		assert.Equal(t, address.Of(int32(70)), converted.ActiveGateAutoscaling().TargetCPUUtilization)
+		assert.Equal(t, &testMinAvailable, converted.ActiveGatePodDisruptionBudget().MinAvailable)
+		assert.Equal(t, dynakube.Spec.MaintenanceWindows, converted.Spec.MaintenanceWindows)
+		assert.Equal(t, dynakube.Status.UpdatesDeferredUntil, converted.Status.UpdatesDeferredUntil)
+		assert.Equal(t, dynakube.Spec.Settings, converted.Spec.Settings)
+		assert.Equal(t, dynakube.Status.Settings, converted.Status.Settings)
+		assert.Equal(t, dynakube.Spec.OneAgent.CloudNativeFullStack.Rollout, converted.OneAgentRolloutStrategy())
+		assert.Equal(t, dynakube.Status.OneAgent.Rollout, converted.Status.OneAgent.Rollout)
+		require.Len(t, converted.Spec.ActiveGate.Pools, 1)
+		assert.Equal(t, "zone-a", converted.Spec.ActiveGate.Pools[0].Name)
+		assert.Equal(t, "zone-a", converted.Spec.ActiveGate.Pools[0].NetworkZone)
+		assert.Equal(t, address.Of(int32(2)), converted.Spec.ActiveGate.Pools[0].Replicas)
+		assert.Equal(t, "1.281.0", converted.ActiveGatePoolVersionStatus("zone-a").Version)
+
+		assert.Equal(t, "value", converted.Annotations[testOtherAnnotation])
+		assert.Equal(t, "true", converted.Annotations[dynatracev1beta1.AnnotationFeaturePublicRegistry])
+		assert.Equal(t, "20", converted.Annotations[dynatracev1beta1.AnnotationFeatureApiRequestThreshold])
+		assert.Equal(t, "2", converted.Annotations[dynatracev1beta1.AnnotationFeatureOneAgentMaxUnavailable])
+		assert.Equal(t, "false", converted.Annotations[dynatracev1beta1.AnnotationFeatureActiveGateUpdates])
+		assert.Equal(t, `["kube-.*","openshift-.*"]`, converted.Annotations[dynatracev1beta1.AnnotationFeatureIgnoredNamespaces])
+		assert.Equal(t, "fail", converted.Annotations[dynatracev1beta1.AnnotationInjectionFailurePolicy])
+		assert.Equal(t, "S", converted.Annotations[dynatracev1beta1.AnnotationFeatureSyntheticNodeType])
+		assert.Equal(t, "3", converted.Annotations[dynatracev1beta1.AnnotationFeatureSyntheticReplicas])
+		assert.Equal(t, "0", converted.Annotations[dynatracev1beta1.AnnotationFeatureApiRequestRetries])
+		assert.Equal(t, "10", converted.Annotations[dynatracev1beta1.AnnotationFeatureApiRequestMaxBackoff])
+		assert.Equal(t, "60", converted.Annotations[dynatracev1beta1.AnnotationFeatureApiRequestTimeout])
+		assert.Equal(t, "true", converted.Annotations[dynatracev1beta1.AnnotationFeatureOneAgentVersionEvents])
+		assert.Equal(t, "false", converted.Annotations[dynatracev1beta1.AnnotationFeatureInjectionFailureEvents])
+		assert.NotContains(t, converted.Annotations, dynatracev1beta1.AnnotationFeatureTokenScopeEvents)
+
+		assert.True(t, converted.FeaturePublicRegistry())
+		assert.Equal(t, 20*time.Minute, converted.FeatureApiRequestThreshold())
+		assert.Equal(t, 2, converted.FeatureOneAgentMaxUnavailable())
+		assert.True(t, converted.FeatureDisableActiveGateUpdates())
+		assert.Equal(t, "fail", converted.FeatureInjectionFailurePolicy())
+		assert.Equal(t, 0, converted.FeatureApiRequestRetries())
+		assert.Equal(t, 10*time.Second, converted.FeatureApiRequestMaxBackoff())
+		assert.Equal(t, time.Minute, converted.FeatureApiRequestTimeout())
+		assert.True(t, converted.FeatureOneAgentVersionEvents())
+		assert.False(t, converted.FeatureInjectionFailureEvents())
+	})
+	t.Run("typed setting wins over annotation", func(t *testing.T) {
+		dynakube := getTypedDynakube()
+		dynakube.Annotations[dynatracev1beta1.AnnotationFeaturePublicRegistry] = "false"
+		converted := &dynatracev1beta1.DynaKube{}
+
+		err := dynakube.ConvertTo(converted)
+		require.NoError(t, err)
+
+		assert.Equal(t, "true", converted.Annotations[dynatracev1beta1.AnnotationFeaturePublicRegistry])
+	})
+	t.Run("no annotations are added without typed settings", func(t *testing.T) {
+		dynakube := &DynaKube{
+			ObjectMeta: metav1.ObjectMeta{Name: testName, Namespace: testNamespace},
+			Spec:       DynaKubeSpec{APIURL: testAPIURL},
+		}
+		converted := &dynatracev1beta1.DynaKube{}
+
+		err := dynakube.ConvertTo(converted)
+		require.NoError(t, err)
+
+		assert.Nil(t, converted.Annotations)
+	})
+}
+
+func TestConversion_ConvertFrom(t *testing.T) {
+	t.Run("feature flags end up as typed settings", func(t *testing.T) {
+		oldDynakube := &dynatracev1beta1.DynaKube{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      testName,
+				Namespace: testNamespace,
+				Annotations: map[string]string{
+					testOtherAnnotation: "value",
+					dynatracev1beta1.AnnotationFeaturePublicRegistry:               "true",
+					dynatracev1beta1.AnnotationFeatureApiRequestThreshold:          "20",
+					dynatracev1beta1.AnnotationFeatureNoProxy:                      "some.host",
+					dynatracev1beta1.AnnotationFeatureIgnoredNamespaces:            `["kube-.*"]`,
+					dynatracev1beta1.AnnotationInjectionFailurePolicy:              "force",
+					dynatracev1beta1.AnnotationFeatureSyntheticNodeType:            "XS",
+					dynatracev1beta1.AnnotationFeatureActiveGateReadOnlyFilesystem: "false",
+					dynatracev1beta1.AnnotationFeatureApiRequestRetries:            "5",
+					dynatracev1beta1.AnnotationFeatureApiRequestTimeout:            "30",
+					dynatracev1beta1.AnnotationFeatureTokenScopeEvents:             "true",
+				},
+			},
+			Spec: dynatracev1beta1.DynaKubeSpec{APIURL: testAPIURL},
+		}
+		converted := &DynaKube{}
+
+		err := converted.ConvertFrom(oldDynakube)
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]string{testOtherAnnotation: "value"}, converted.Annotations)
+		assert.Equal(t, address.Of(true), converted.Spec.PublicRegistry)
+		assert.Equal(t, address.Of(20), converted.Spec.DynatraceApi.RequestThreshold)
+		assert.Equal(t, "some.host", converted.Spec.DynatraceApi.NoProxy)
+		assert.Equal(t, []string{"kube-.*"}, converted.Spec.Injection.IgnoredNamespaces)
+		assert.Equal(t, InjectionFailurePolicyForce, converted.Spec.Injection.FailurePolicy)
+		assert.Equal(t, SyntheticNodeXs, converted.Spec.Synthetic.NodeType)
+		assert.Equal(t, address.Of(false), converted.Spec.ActiveGate.ReadOnlyFilesystem)
+		assert.Equal(t, address.Of(5), converted.Spec.DynatraceApi.RequestRetries)
+		assert.Equal(t, address.Of(30), converted.Spec.DynatraceApi.RequestTimeoutSeconds)
+		assert.Equal(t, address.Of(true), converted.Spec.Events.TokenScope)
+	})
+	t.Run("invalid and deprecated feature flags are kept as annotations", func(t *testing.T) {
+		annotations := map[string]string{
+			dynatracev1beta1.AnnotationFeaturePublicRegistry:                   "yes",
+			dynatracev1beta1.AnnotationFeatureApiRequestThreshold:              "-5",
+			dynatracev1beta1.AnnotationFeatureApiRequestTimeout:                "0",
+			dynatracev1beta1.AnnotationFeatureOneAgentMaxUnavailable:           "02",
+			dynatracev1beta1.AnnotationInjectionFailurePolicy:                  "unknown",
+			dynatracev1beta1.AnnotationFeatureIgnoredNamespaces:                "kube-.*",
+			dynatracev1beta1.AnnotationFeatureDisableActiveGateUpdates:         "true",
+			dynatracev1beta1.AnnotationFeatureDisableWebhookReinvocationPolicy: "true",
+		}
+		oldDynakube := &dynatracev1beta1.DynaKube{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        testName,
+				Namespace:   testNamespace,
+				Annotations: copyAnnotations(annotations),
+			},
+		}
+		converted := &DynaKube{}
+
+		err := converted.ConvertFrom(oldDynakube)
+		require.NoError(t, err)
+
+		assert.Equal(t, annotations, converted.Annotations)
+		assert.Nil(t, converted.Spec.PublicRegistry)
+		assert.Nil(t, converted.Spec.DynatraceApi.RequestThreshold)
+		assert.Nil(t, converted.Spec.OneAgent.MaxUnavailable)
+		assert.Empty(t, converted.Spec.Injection.FailurePolicy)
+		assert.Empty(t, converted.Spec.Injection.IgnoredNamespaces)
+	})
+}
+
+func TestConversion_RoundTrip(t *testing.T) {
+	t.Run("v1beta2 -> v1beta1 -> v1beta2", func(t *testing.T) {
+		dynakube := getTypedDynakube()
+		hub := &dynatracev1beta1.DynaKube{}
+		restored := &DynaKube{}
+
+		require.NoError(t, dynakube.ConvertTo(hub))
+		require.NoError(t, restored.ConvertFrom(hub))
+
+		assert.Equal(t, dynakube, restored)
+	})
+	t.Run("v1beta1 -> v1beta2 -> v1beta1", func(t *testing.T) {
+		oldDynakube := &dynatracev1beta1.DynaKube{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      testName,
+				Namespace: testNamespace,
+				Annotations: map[string]string{
+					testOtherAnnotation: "value",
+					dynatracev1beta1.AnnotationFeatureAutomaticInjection:          "false",
+					dynatracev1beta1.AnnotationFeatureSyntheticReplicas:           "1",
+					dynatracev1beta1.AnnotationFeatureOneAgentInitialConnectRetry: "-1",
+					dynatracev1beta1.AnnotationFeaturePublicRegistry:              "invalid",
+					dynatracev1beta1.AnnotationFeatureNoProxy:                     "",
+					dynatracev1beta1.AnnotationFeatureApiRequestMaxBackoff:        "15",
+					dynatracev1beta1.AnnotationFeatureInstallFailureEvents:        "false",
+					dynatracev1beta1.AnnotationFeatureIgnoredNamespaces:           "null",
+				},
+			},
+			Spec: dynatracev1beta1.DynaKubeSpec{
+				APIURL:      testAPIURL,
+				Tokens:      testToken,
+				NetworkZone: testNetworkZone,
+				OneAgent: dynatracev1beta1.OneAgentSpec{
+					HostMonitoring: &dynatracev1beta1.HostInjectSpec{},
+				},
+				ActiveGate: dynatracev1beta1.ActiveGateSpec{
+					Capabilities: []dynatracev1beta1.CapabilityDisplayName{dynatracev1beta1.RoutingCapability.DisplayName},
+				},
+			},
+		}
+		intermediate := &DynaKube{}
+		restored := &dynatracev1beta1.DynaKube{}
+
+		require.NoError(t, intermediate.ConvertFrom(oldDynakube))
+		require.NoError(t, intermediate.ConvertTo(restored))
+
+		assert.Equal(t, oldDynakube, restored)
+	})
+}
+
+func getTypedDynakube() *DynaKube {
+	return &DynaKube{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testName,
+			Namespace: testNamespace,
+			Annotations: map[string]string{
+				testOtherAnnotation: "value",
+			},
+		},
+		Spec: DynaKubeSpec{
+			APIURL:           testAPIURL,
+			Tokens:           testToken,
+			CustomPullSecret: testCustomPullSecret,
+			NetworkZone:      testNetworkZone,
+			PublicRegistry:   address.Of(true),
+			MaintenanceWindows: []maintenancewindow.Window{
+				{Days: []string{"Sat"}, Start: "02:00", End: "04:00", TimeZone: "Europe/Vienna"},
+			},
+			Settings: []settings.Spec{
+				{
+					Name:     "workload-anomalies",
+					SchemaId: "builtin:anomaly-detection.kubernetes.workload",
+					Scope:    settings.ScopeKubernetesCluster,
+					Value:    apiextensionsv1.JSON{Raw: []byte(`{"crashLoopBackOff":{"enabled":true}}`)},
+				},
+			},
+			DynatraceApi: DynatraceApiSpec{
+				RequestThreshold:         address.Of(20),
+				HostsRequests:            address.Of(false),
+				RequestRetries:           address.Of(0),
+				RequestMaxBackoffSeconds: address.Of(10),
+				RequestTimeoutSeconds:    address.Of(60),
+			},
+			Events: EventsSpec{
+				OneAgentVersion:  address.Of(true),
+				InjectionFailure: address.Of(false),
+			},
+			KubernetesApiMonitoring: KubernetesApiMonitoringSpec{
+				Enabled:     address.Of(true),
+				ClusterName: "cluster",
+			},
+			Injection: InjectionSpec{
+				IgnoredNamespaces:         []string{"kube-.*", "openshift-.*"},
+				FailurePolicy:             InjectionFailurePolicyFail,
+				MaxFailedCsiMountAttempts: address.Of(5),
+			},
+			Synthetic: SyntheticSpec{
+				NodeType: SyntheticNodeS,
+				Replicas: address.Of[int32](3),
+			},
+			OneAgent: OneAgentSpec{
+				CloudNativeFullStack: &CloudNativeFullStackSpec{
+					HostInjectSpec: HostInjectSpec{
+						VersionPolicy: &versionpolicy.Spec{Constraint: ">=1.280"},
+						Rollout:       &rollout.Strategy{NodePoolLabel: "topology.kubernetes.io/zone"},
+					},
+					AppInjectionSpec: AppInjectionSpec{
+						CodeModulesVersionPolicy: &versionpolicy.Spec{MinorVersionsBehindLatest: address.Of(1)},
+					},
+				},
+				MaxUnavailable: address.Of(2),
+				SecCompProfile: "profile",
+			},
+			ActiveGate: ActiveGateSpec{
+				Capabilities: []CapabilityDisplayName{"routing"},
+				CapabilityProperties: CapabilityProperties{
+					Image: testActiveGateImage,
+				},
+				AutoUpdate:    address.Of(false),
+				VersionPolicy: &versionpolicy.Spec{Constraint: "~1.281"},
+				Autoscaling: &ActiveGateAutoscalingSpec{
+					MinReplicas:          address.Of(int32(2)),
+					MaxReplicas:          5,
+					TargetCPUUtilization: address.Of(int32(70)),
+				},
+				PodDisruptionBudget: &ActiveGatePodDisruptionBudgetSpec{
+					MinAvailable: &testMinAvailable,
+				},
+				Pools: []ActiveGatePoolSpec{
+					{
+						Name:         "zone-a",
+						Capabilities: []CapabilityDisplayName{"routing"},
+						NetworkZone:  "zone-a",
+						CapabilityProperties: CapabilityProperties{
+							Replicas: address.Of(int32(2)),
+						},
+					},
+				},
+			},
+		},
+		Status: DynaKubeStatus{
+			UpdatesDeferredUntil: &metav1.Time{Time: time.Date(2024, 1, 6, 1, 0, 0, 0, time.UTC)},
+			Settings: []settings.Status{
+				{Name: "workload-anomalies", SchemaId: "builtin:anomaly-detection.kubernetes.workload", Scope: "KUBERNETES_CLUSTER-1234", ObjectId: "object-id"},
+			},
+			ActiveGate: ActiveGateStatus{
+				Pools: []ActiveGatePoolStatus{
+					{Name: "zone-a", VersionStatus: status.VersionStatus{Version: "1.281.0"}},
+				},
+			},
+			OneAgent: OneAgentStatus{
+				Rollout: &rollout.Status{Phase: rollout.PhaseProgressing, Stage: "canary"},
+			},
+		},
+	}
+}
+
+func copyAnnotations(annotations map[string]string) map[string]string {
+	copied := make(map[string]string, len(annotations))
+	for key, value := range annotations {
+		copied[key] = value
+	}
+	return copied
+}
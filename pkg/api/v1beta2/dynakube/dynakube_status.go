@@ -0,0 +1,151 @@
+package dynakube
+
+import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/rollout"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/settings"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/status"
+	containerv1 "github.com/google/go-containerregistry/pkg/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DynaKubeStatus defines the observed state of DynaKube
+// +k8s:openapi-gen=true
+type DynaKubeStatus struct { // nolint:revive
+	// Defines the current state (Running, Updating, Error, ...)
+	Phase status.DeploymentPhase `json:"phase,omitempty"`
+
+	// UpdatedTimestamp indicates when the instance was last updated
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Last Updated"
+	// +operator-sdk:gen-csv:customresourcedefinitions.statusDescriptors.x-descriptors="urn:alm:descriptor:text"
+	UpdatedTimestamp metav1.Time `json:"updatedTimestamp,omitempty"`
+
+	// LastTokenProbeTimestamp tracks when the last request for the API token validity was sent
+	// +kubebuilder:deprecatedversion:warning="Use DynatraceApiStatus.LastTokenScopeRequest instead"
+	LastTokenProbeTimestamp *metav1.Time `json:"lastTokenProbeTimestamp,omitempty"`
+
+	// KubeSystemUUID contains the UUID of the current Kubernetes cluster
+	KubeSystemUUID string `json:"kubeSystemUUID,omitempty"`
+
+	// Conditions includes status about the current state of the instance
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Time the next maintenance window opens, only set while updates of deployed components are deferred
+	UpdatesDeferredUntil *metav1.Time `json:"updatesDeferredUntil,omitempty"`
+
+	// Settings objects created by the operator in Dynatrace
+	Settings []settings.Status `json:"settings,omitempty"`
+
+	// Observed state of ActiveGate
+	ActiveGate ActiveGateStatus `json:"activeGate,omitempty"`
+
+	// Observed state of OneAgent
+	OneAgent OneAgentStatus `json:"oneAgent,omitempty"`
+
+	// Observed state of Code Modules
+	CodeModules CodeModulesStatus `json:"codeModules,omitempty"`
+
+	// Observed state of Synthetic
+	Synthetic SyntheticStatus `json:"synthetic,omitempty"`
+
+	// Observed state of Dynatrace API
+	DynatraceApi DynatraceApiStatus `json:"dynatraceApi,omitempty"`
+}
+
+type DynatraceApiStatus struct {
+	// Time of the last token request
+	LastTokenScopeRequest metav1.Time `json:"lastTokenScopeRequest,omitempty"`
+
+	// Hash of the tokens of the last reconciliation, used to detect a rotation of the tokens
+	TokenHash string `json:"tokenHash,omitempty"`
+}
+
+type ConnectionInfoStatus struct {
+	// UUID of the tenant, received from the tenant
+	TenantUUID string `json:"tenantUUID,omitempty"`
+
+	// Available connection endpoints
+	Endpoints string `json:"endpoints,omitempty"`
+
+	// Time of the last connection request
+	LastRequest metav1.Time `json:"lastRequest,omitempty"`
+}
+
+type OneAgentConnectionInfoStatus struct {
+	// Information for communicating with the tenant
+	ConnectionInfoStatus `json:",inline"`
+
+	// List of communication hosts
+	CommunicationHosts []CommunicationHostStatus `json:"communicationHosts,omitempty"`
+}
+
+type ActiveGateConnectionInfoStatus struct {
+	// Information about Active Gate's connections
+	ConnectionInfoStatus `json:",inline"`
+}
+
+type CommunicationHostStatus struct {
+	// Connection protocol
+	Protocol string `json:"protocol,omitempty"`
+
+	// Host domain
+	Host string `json:"host,omitempty"`
+
+	// Connection port
+	Port uint32 `json:"port,omitempty"`
+}
+
+type ActiveGateStatus struct {
+	status.VersionStatus `json:",inline"`
+
+	// Information about Active Gate's connections
+	ConnectionInfoStatus ActiveGateConnectionInfoStatus `json:"connectionInfoStatus,omitempty"`
+
+	// Versions of the ActiveGate pools
+	Pools []ActiveGatePoolStatus `json:"pools,omitempty"`
+}
+
+type ActiveGatePoolStatus struct {
+	status.VersionStatus `json:",inline"`
+
+	// Name of the pool
+	Name string `json:"name"`
+}
+
+type CodeModulesStatus struct {
+	status.VersionStatus `json:",inline"`
+}
+
+type OneAgentStatus struct {
+	status.VersionStatus `json:",inline"`
+
+	// List of deployed OneAgent instances
+	Instances map[string]OneAgentInstance `json:"instances,omitempty"`
+
+	// Time of the last instance status update
+	LastInstanceStatusUpdate *metav1.Time `json:"lastInstanceStatusUpdate,omitempty"`
+
+	// Information about OneAgent's connections
+	ConnectionInfoStatus OneAgentConnectionInfoStatus `json:"connectionInfoStatus,omitempty"`
+
+	// Commands used for OneAgent's readiness probe
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Healthcheck *containerv1.HealthConfig `json:"healthcheck,omitempty"`
+
+	// Progress of the staged rollout of the OneAgent DaemonSet
+	Rollout *rollout.Status `json:"rollout,omitempty"`
+}
+
+type OneAgentInstance struct {
+	// Name of the OneAgent pod
+	PodName string `json:"podName,omitempty"`
+
+	// IP address of the pod
+	IPAddress string `json:"ipAddress,omitempty"`
+}
+
+type SyntheticStatus struct {
+	status.VersionStatus `json:",inline"`
+}
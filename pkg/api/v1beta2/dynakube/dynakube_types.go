@@ -0,0 +1,296 @@
+// +kubebuilder:object:generate=true
+// +groupName=dynatrace.com
+// +versionName=v1beta2
+package dynakube
+
+import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/maintenancewindow"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/mirror"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/oauth"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/settings"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/tokensource"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type DynaKubeProxy struct { // nolint:revive
+	// Proxy URL. It has preference over ValueFrom.
+	// +nullable
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Proxy value",order=32,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:text"}
+	Value string `json:"value,omitempty"`
+
+	// Secret containing proxy URL.
+	// +nullable
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Proxy secret",order=33,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:io.kubernetes:Secret"}
+	ValueFrom string `json:"valueFrom,omitempty"`
+}
+
+type DynaKubeValueSource struct { // nolint:revive
+	// Custom properties value.
+	// +nullable
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Custom properties value",order=32,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:text"}
+	Value string `json:"value,omitempty"`
+
+	// Custom properties secret.
+	// +nullable
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Custom properties secret",order=33,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:io.kubernetes:Secret"}
+	ValueFrom string `json:"valueFrom,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DynaKube is the Schema for the DynaKube API
+// +k8s:openapi-gen=true
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=dynakubes,scope=Namespaced,categories=dynatrace
+// +kubebuilder:printcolumn:name="ApiUrl",type=string,JSONPath=`.spec.apiUrl`
+// +kubebuilder:printcolumn:name="Status",type=string,JSONPath=`.status.phase`
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+// +operator-sdk:csv:customresourcedefinitions:displayName="Dynatrace DynaKube"
+// +operator-sdk:csv:customresourcedefinitions:resources={{StatefulSet,v1,},{DaemonSet,v1,},{Pod,v1,}}
+type DynaKube struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DynaKubeSpec   `json:"spec,omitempty"`
+	Status DynaKubeStatus `json:"status,omitempty"`
+}
+
+// DynaKubeSpec defines the desired state of DynaKube
+// +k8s:openapi-gen=true
+type DynaKubeSpec struct { // nolint:revive
+	// Add custom validation using kubebuilder tags: https://book-v1.book.kubebuilder.io/beyond_basics/generating_crd.html
+
+	// Dynatrace apiUrl, including the /api path at the end. For SaaS, set YOUR_ENVIRONMENT_ID to your environment ID. For Managed, change the apiUrl address.
+	// For instructions on how to determine the environment ID and how to configure the apiUrl address, see Environment ID (https://www.dynatrace.com/support/help/get-started/monitoring-environment/environment-id).
+	// +kubebuilder:validation:Required
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="API URL",order=1,xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	APIURL string `json:"apiUrl"`
+
+	// Name of the secret holding the tokens used for connecting to Dynatrace.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Tenant specific secrets",order=2,xDescriptors="urn:alm:descriptor:io.kubernetes:Secret"
+	Tokens string `json:"tokens,omitempty"`
+
+	// Where the tokens used for connecting to Dynatrace are read from. Defaults to the secret named in tokens.
+	// +optional
+	TokenSource *tokensource.Spec `json:"tokenSource,omitempty"`
+
+	// OAuth client used instead of the api and paas token to authenticate against the Dynatrace API.
+	// Its client id and secret are read from the token source with the keys oauthClientId and oauthClientSecret.
+	// A paas or api token is still required for pull secrets and code modules.
+	// +optional
+	OAuth *oauth.Spec `json:"oauth,omitempty"`
+
+	// Mirror of the Dynatrace deployment API, used in air-gapped clusters instead of the Dynatrace API for downloading agents and looking up versions and images.
+	// Connection info and token scopes are only verified while the Dynatrace API is reachable.
+	// +optional
+	Mirror *mirror.Spec `json:"mirror,omitempty"`
+
+	// Disable certificate check for the connection between Dynatrace Operator and the Dynatrace Cluster.
+	// Set to true if you want to skip certification validation checks.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Skip Certificate Check",order=3,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	SkipCertCheck bool `json:"skipCertCheck,omitempty"`
+
+	// Set custom proxy settings either directly or from a secret with the field proxy.
+	// Note: Applies to Dynatrace Operator, ActiveGate, and OneAgents.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Proxy",order=3,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	Proxy *DynaKubeProxy `json:"proxy,omitempty"`
+
+	// Adds custom RootCAs from a configmap. Put the certificate under certs within your configmap.
+	// Note: Applies only to Dynatrace Operator and OneAgent, not to ActiveGate.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Trusted CAs",order=6,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:io.kubernetes:ConfigMap"}
+	TrustedCAs string `json:"trustedCAs,omitempty"`
+
+	// Sets a network zone for the OneAgent and ActiveGate pods.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Network Zone",order=7,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:text"}
+	NetworkZone string `json:"networkZone,omitempty"`
+
+	// Defines a custom pull secret in case you use a private registry when pulling images from the Dynatrace environment.
+	// To define a custom pull secret and learn about the expected behavior, see Configure customPullSecret
+	// (https://www.dynatrace.com/support/help/setup-and-configuration/setup-on-container-platforms/kubernetes/get-started-with-kubernetes-monitoring/dto-config-options-k8s#custompullsecret).
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Custom PullSecret",order=8,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:io.kubernetes:Secret"}
+	CustomPullSecret string `json:"customPullSecret,omitempty"`
+
+	// When enabled, and if Istio is installed on the Kubernetes environment, Dynatrace Operator will create the corresponding
+	// VirtualService and ServiceEntry objects to allow access to the Dynatrace Cluster from the OneAgent or ActiveGate.
+	// Disabled by default.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Enable Istio automatic management",order=9,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	EnableIstio bool `json:"enableIstio,omitempty"`
+
+	// Applicable only for applicationMonitoring or cloudNativeFullStack configuration types. The namespaces where you want Dynatrace Operator to inject.
+	// For more information, see Configure monitoring for namespaces and pods (https://www.dynatrace.com/support/help/setup-and-configuration/setup-on-container-platforms/kubernetes/get-started-with-kubernetes-monitoring/dto-config-options-k8s#annotate).
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Namespace Selector",order=17,xDescriptors="urn:alm:descriptor:com.tectonic.ui:selector:core:v1:Namespace"
+	NamespaceSelector metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+
+	// Applicable only for applicationMonitoring or cloudNativeFullStack configuration types. The pods where you want Dynatrace Operator to inject.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Pod Selector",order=18,xDescriptors="urn:alm:descriptor:com.tectonic.ui:selector:core:v1:Pod"
+	PodSelector metav1.LabelSelector `json:"podSelector,omitempty"`
+
+	// Windows in which the operator is allowed to update already deployed components, e.g. to roll out a new version.
+	// Outside of them, changes of the version status and of the DaemonSets, StatefulSets and Deployments are deferred.
+	// Updates are allowed at any time if no window is defined.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Maintenance windows",xDescriptors="urn:alm:descriptor:com.tectonic.ui:advanced"
+	MaintenanceWindows []maintenancewindow.Window `json:"maintenanceWindows,omitempty"`
+
+	// Dynatrace settings objects managed by the operator via the Settings 2.0 API, e.g. anomaly detection for workloads or the Kubernetes event forwarding.
+	// Objects removed from this list are deleted in Dynatrace, changes made in Dynatrace are reverted.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Settings",xDescriptors="urn:alm:descriptor:com.tectonic.ui:advanced"
+	Settings []settings.Spec `json:"settings,omitempty"`
+
+	// Use the public registry for the images of all Dynatrace components instead of the registry of the Dynatrace environment.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Public registry",order=10,xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:booleanSwitch"}
+	PublicRegistry *bool `json:"publicRegistry,omitempty"`
+
+	// Configuration of the requests the Dynatrace Operator sends to the Dynatrace API.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Dynatrace API",xDescriptors="urn:alm:descriptor:com.tectonic.ui:advanced"
+	DynatraceApi DynatraceApiSpec `json:"dynatraceApi,omitempty"`
+
+	// Lifecycle events the Dynatrace Operator sends to the Dynatrace environment, they require the events ingest scope.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Events",xDescriptors="urn:alm:descriptor:com.tectonic.ui:advanced"
+	Events EventsSpec `json:"events,omitempty"`
+
+	// Configuration of the automatic Kubernetes API monitoring, which ensures that the settings for this Kubernetes cluster exist in Dynatrace.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Kubernetes API monitoring",xDescriptors="urn:alm:descriptor:com.tectonic.ui:advanced"
+	KubernetesApiMonitoring KubernetesApiMonitoringSpec `json:"kubernetesApiMonitoring,omitempty"`
+
+	// Configuration of the injection into application pods, applicable only for applicationMonitoring or cloudNativeFullStack configuration types.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Injection",xDescriptors="urn:alm:descriptor:com.tectonic.ui:advanced"
+	Injection InjectionSpec `json:"injection,omitempty"`
+
+	// Configuration of the synthetic monitoring, applicable only if the synthetic ActiveGate capability is enabled.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Synthetic",xDescriptors="urn:alm:descriptor:com.tectonic.ui:advanced"
+	Synthetic SyntheticSpec `json:"synthetic,omitempty"`
+
+	// General configuration about OneAgent instances.
+	// You can't enable more than one module (classicFullStack, cloudNativeFullStack, hostMonitoring, or applicationMonitoring).
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="OneAgent",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	OneAgent OneAgentSpec `json:"oneAgent,omitempty"`
+
+	// General configuration about ActiveGate instances.
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="ActiveGate",xDescriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	ActiveGate ActiveGateSpec `json:"activeGate,omitempty"`
+
+	// Configuration for Routing
+	// +kubebuilder:deprecatedversion:warning="Deprecated property"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Routing"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	Routing RoutingSpec `json:"routing,omitempty"`
+
+	// Configuration for Kubernetes Monitoring
+	// +kubebuilder:deprecatedversion:warning="Deprecated property"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors=true
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.displayName="Kubernetes Monitoring"
+	// +operator-sdk:gen-csv:customresourcedefinitions.specDescriptors.x-descriptors="urn:alm:descriptor:com.tectonic.ui:text"
+	KubernetesMonitoring KubernetesMonitoringSpec `json:"kubernetesMonitoring,omitempty"`
+}
+
+type DynatraceApiSpec struct {
+	// Minimum amount of minutes between two requests of the same kind to the Dynatrace API.
+	// Defaults to 15 minutes.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	RequestThreshold *int `json:"requestThreshold,omitempty"`
+
+	// Enables queries to the Hosts API, which are used to map OneAgent pods to hosts in Dynatrace.
+	// Enabled by default.
+	// +optional
+	HostsRequests *bool `json:"hostsRequests,omitempty"`
+
+	// The NO_PROXY value used by the Dynatrace Operator when it connects to the Dynatrace API.
+	// +optional
+	NoProxy string `json:"noProxy,omitempty"`
+
+	// How often requests which failed with a transient error, e.g. because of rate limiting, are retried.
+	// Defaults to 3, 0 disables retries.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	RequestRetries *int `json:"requestRetries,omitempty"`
+
+	// Longest time in seconds the Dynatrace Operator waits before retrying a request.
+	// Defaults to 30 seconds.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	RequestMaxBackoffSeconds *int `json:"requestMaxBackoffSeconds,omitempty"`
+
+	// How long in seconds a single attempt of a request to the Dynatrace API may take, downloads of agent packages aren't limited by it.
+	// Defaults to 120 seconds.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	RequestTimeoutSeconds *int `json:"requestTimeoutSeconds,omitempty"`
+}
+
+type EventsSpec struct {
+	// Sends an event to Dynatrace when the OneAgent version changes.
+	// Disabled by default.
+	// +optional
+	OneAgentVersion *bool `json:"oneAgentVersion,omitempty"`
+
+	// Sends an event to Dynatrace when the ActiveGate version changes.
+	// Disabled by default.
+	// +optional
+	ActiveGateVersion *bool `json:"activeGateVersion,omitempty"`
+
+	// Sends an event to Dynatrace when the CSI driver fails to install the code modules.
+	// Disabled by default.
+	// +optional
+	InstallFailure *bool `json:"installFailure,omitempty"`
+
+	// Sends an event to Dynatrace when the tokens are missing required scopes.
+	// Disabled by default.
+	// +optional
+	TokenScope *bool `json:"tokenScope,omitempty"`
+
+	// Sends an event to Dynatrace when the webhook fails to inject into a pod.
+	// Disabled by default.
+	// +optional
+	InjectionFailure *bool `json:"injectionFailure,omitempty"`
+}
+
+type KubernetesApiMonitoringSpec struct {
+	// Enables the automatic creation of the Kubernetes API monitoring settings in Dynatrace.
+	// Enabled by default.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Custom name of the Kubernetes cluster in Dynatrace. Defaults to the name of the DynaKube.
+	// +optional
+	ClusterName string `json:"clusterName,omitempty"`
+
+	// Enables the Kubernetes app for this Kubernetes cluster.
+	// Disabled by default.
+	// +optional
+	K8sAppEnabled *bool `json:"k8sAppEnabled,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DynaKubeList contains a list of DynaKube
+// +kubebuilder:object:root=true
+type DynaKubeList struct { // nolint:revive
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DynaKube `json:"items"`
+}
+
+func init() {
+	v1beta2.SchemeBuilder.Register(&DynaKube{}, &DynaKubeList{})
+}
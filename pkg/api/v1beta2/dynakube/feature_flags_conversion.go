@@ -0,0 +1,256 @@
+package dynakube
+
+import (
+	"encoding/json"
+	"strconv"
+
+	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+)
+
+// convertToFeatureFlags stores the typed settings of v1beta2 as the corresponding feature flag annotations of v1beta1.
+// A typed setting always wins over an annotation with the same key.
+func (src *DynaKube) convertToFeatureFlags(dst *dynatracev1beta1.DynaKube) {
+	if dst.Annotations == nil {
+		dst.Annotations = map[string]string{}
+	}
+	flags := dst.Annotations
+
+	setBoolFlag(flags, dynatracev1beta1.AnnotationFeaturePublicRegistry, src.Spec.PublicRegistry)
+
+	// dtClient
+	setIntFlag(flags, dynatracev1beta1.AnnotationFeatureApiRequestThreshold, src.Spec.DynatraceApi.RequestThreshold)
+	setBoolFlag(flags, dynatracev1beta1.AnnotationFeatureHostsRequests, src.Spec.DynatraceApi.HostsRequests)
+	setStringFlag(flags, dynatracev1beta1.AnnotationFeatureNoProxy, src.Spec.DynatraceApi.NoProxy)
+	setIntFlag(flags, dynatracev1beta1.AnnotationFeatureApiRequestRetries, src.Spec.DynatraceApi.RequestRetries)
+	setIntFlag(flags, dynatracev1beta1.AnnotationFeatureApiRequestMaxBackoff, src.Spec.DynatraceApi.RequestMaxBackoffSeconds)
+	setIntFlag(flags, dynatracev1beta1.AnnotationFeatureApiRequestTimeout, src.Spec.DynatraceApi.RequestTimeoutSeconds)
+
+	// events
+	setBoolFlag(flags, dynatracev1beta1.AnnotationFeatureOneAgentVersionEvents, src.Spec.Events.OneAgentVersion)
+	setBoolFlag(flags, dynatracev1beta1.AnnotationFeatureActiveGateVersionEvents, src.Spec.Events.ActiveGateVersion)
+	setBoolFlag(flags, dynatracev1beta1.AnnotationFeatureInstallFailureEvents, src.Spec.Events.InstallFailure)
+	setBoolFlag(flags, dynatracev1beta1.AnnotationFeatureTokenScopeEvents, src.Spec.Events.TokenScope)
+	setBoolFlag(flags, dynatracev1beta1.AnnotationFeatureInjectionFailureEvents, src.Spec.Events.InjectionFailure)
+
+	// kubernetes api monitoring
+	setBoolFlag(flags, dynatracev1beta1.AnnotationFeatureAutomaticK8sApiMonitoring, src.Spec.KubernetesApiMonitoring.Enabled)
+	setStringFlag(flags, dynatracev1beta1.AnnotationFeatureAutomaticK8sApiMonitoringClusterName, src.Spec.KubernetesApiMonitoring.ClusterName)
+	setBoolFlag(flags, dynatracev1beta1.AnnotationFeatureK8sAppEnabled, src.Spec.KubernetesApiMonitoring.K8sAppEnabled)
+
+	// oneAgent
+	setIntFlag(flags, dynatracev1beta1.AnnotationFeatureOneAgentMaxUnavailable, src.Spec.OneAgent.MaxUnavailable)
+	setIntFlag(flags, dynatracev1beta1.AnnotationFeatureOneAgentInitialConnectRetry, src.Spec.OneAgent.InitialConnectRetry)
+	setBoolFlag(flags, dynatracev1beta1.AnnotationFeatureOneAgentIgnoreProxy, src.Spec.OneAgent.IgnoreProxy)
+	setBoolFlag(flags, dynatracev1beta1.AnnotationFeatureRunOneAgentContainerPrivileged, src.Spec.OneAgent.Privileged)
+	setStringFlag(flags, dynatracev1beta1.AnnotationFeatureOneAgentSecCompProfile, src.Spec.OneAgent.SecCompProfile)
+	setBoolFlag(flags, dynatracev1beta1.AnnotationFeatureReadOnlyOneAgent, src.Spec.OneAgent.ReadOnlyHostFs)
+	setBoolFlag(flags, dynatracev1beta1.AnnotationFeatureMultipleOsAgentsOnNode, src.Spec.OneAgent.MultipleOsAgentsOnNode)
+
+	// activeGate
+	setBoolFlag(flags, dynatracev1beta1.AnnotationFeatureActiveGateUpdates, src.Spec.ActiveGate.AutoUpdate)
+	setBoolFlag(flags, dynatracev1beta1.AnnotationFeatureActiveGateRawImage, src.Spec.ActiveGate.RawImage)
+	setBoolFlag(flags, dynatracev1beta1.AnnotationFeatureActiveGateAuthToken, src.Spec.ActiveGate.AuthToken)
+	setBoolFlag(flags, dynatracev1beta1.AnnotationFeatureActiveGateAppArmor, src.Spec.ActiveGate.AppArmor)
+	setBoolFlag(flags, dynatracev1beta1.AnnotationFeatureActiveGateReadOnlyFilesystem, src.Spec.ActiveGate.ReadOnlyFilesystem)
+	setBoolFlag(flags, dynatracev1beta1.AnnotationFeatureActiveGateIgnoreProxy, src.Spec.ActiveGate.IgnoreProxy)
+
+	// injection (webhook)
+	setStringListFlag(flags, dynatracev1beta1.AnnotationFeatureIgnoredNamespaces, src.Spec.Injection.IgnoredNamespaces)
+	setStringFlag(flags, dynatracev1beta1.AnnotationInjectionFailurePolicy, string(src.Spec.Injection.FailurePolicy))
+	setBoolFlag(flags, dynatracev1beta1.AnnotationFeatureAutomaticInjection, src.Spec.Injection.AutomaticInjection)
+	setBoolFlag(flags, dynatracev1beta1.AnnotationFeatureWebhookReinvocationPolicy, src.Spec.Injection.WebhookReinvocationPolicy)
+	setBoolFlag(flags, dynatracev1beta1.AnnotationFeatureMetadataEnrichment, src.Spec.Injection.MetadataEnrichment)
+	setBoolFlag(flags, dynatracev1beta1.AnnotationFeatureIgnoreUnknownState, src.Spec.Injection.IgnoreUnknownState)
+	setBoolFlag(flags, dynatracev1beta1.AnnotationFeatureLabelVersionDetection, src.Spec.Injection.LabelVersionDetection)
+	setBoolFlag(flags, dynatracev1beta1.AnnotationFeatureInitContainerSeccomp, src.Spec.Injection.InitContainerSeccomp)
+	setIntFlag(flags, dynatracev1beta1.AnnotationFeatureMaxFailedCsiMountAttempts, src.Spec.Injection.MaxFailedCsiMountAttempts)
+	setBoolFlag(flags, dynatracev1beta1.AnnotationFeatureReadOnlyCsiVolume, src.Spec.Injection.ReadOnlyCsiVolume)
+
+	// synthetic
+	setStringFlag(flags, dynatracev1beta1.AnnotationFeatureSyntheticLocationEntityId, src.Spec.Synthetic.LocationEntityId)
+	setStringFlag(flags, dynatracev1beta1.AnnotationFeatureSyntheticNodeType, string(src.Spec.Synthetic.NodeType))
+	setInt32Flag(flags, dynatracev1beta1.AnnotationFeatureSyntheticReplicas, src.Spec.Synthetic.Replicas)
+	setStringFlag(flags, dynatracev1beta1.AnnotationFeatureCustomSyntheticImage, src.Spec.Synthetic.Image)
+
+	if len(flags) == 0 && src.Annotations == nil {
+		dst.Annotations = nil
+	}
+}
+
+// convertFromFeatureFlags moves the feature flag annotations of v1beta1 to the typed settings of v1beta2.
+// An annotation is only moved if its value can be restored exactly by convertToFeatureFlags,
+// every other annotation is kept as it is, so the conversion stays lossless.
+func (dst *DynaKube) convertFromFeatureFlags() {
+	flags := dst.Annotations
+
+	dst.Spec.PublicRegistry = takeBoolFlag(flags, dynatracev1beta1.AnnotationFeaturePublicRegistry)
+
+	// dtClient
+	dst.Spec.DynatraceApi.RequestThreshold = takeIntFlag(flags, dynatracev1beta1.AnnotationFeatureApiRequestThreshold, 0)
+	dst.Spec.DynatraceApi.HostsRequests = takeBoolFlag(flags, dynatracev1beta1.AnnotationFeatureHostsRequests)
+	dst.Spec.DynatraceApi.NoProxy = takeStringFlag(flags, dynatracev1beta1.AnnotationFeatureNoProxy)
+	dst.Spec.DynatraceApi.RequestRetries = takeIntFlag(flags, dynatracev1beta1.AnnotationFeatureApiRequestRetries, 0)
+	dst.Spec.DynatraceApi.RequestMaxBackoffSeconds = takeIntFlag(flags, dynatracev1beta1.AnnotationFeatureApiRequestMaxBackoff, 0)
+	dst.Spec.DynatraceApi.RequestTimeoutSeconds = takeIntFlag(flags, dynatracev1beta1.AnnotationFeatureApiRequestTimeout, 1)
+
+	// events
+	dst.Spec.Events.OneAgentVersion = takeBoolFlag(flags, dynatracev1beta1.AnnotationFeatureOneAgentVersionEvents)
+	dst.Spec.Events.ActiveGateVersion = takeBoolFlag(flags, dynatracev1beta1.AnnotationFeatureActiveGateVersionEvents)
+	dst.Spec.Events.InstallFailure = takeBoolFlag(flags, dynatracev1beta1.AnnotationFeatureInstallFailureEvents)
+	dst.Spec.Events.TokenScope = takeBoolFlag(flags, dynatracev1beta1.AnnotationFeatureTokenScopeEvents)
+	dst.Spec.Events.InjectionFailure = takeBoolFlag(flags, dynatracev1beta1.AnnotationFeatureInjectionFailureEvents)
+
+	// kubernetes api monitoring
+	dst.Spec.KubernetesApiMonitoring.Enabled = takeBoolFlag(flags, dynatracev1beta1.AnnotationFeatureAutomaticK8sApiMonitoring)
+	dst.Spec.KubernetesApiMonitoring.ClusterName = takeStringFlag(flags, dynatracev1beta1.AnnotationFeatureAutomaticK8sApiMonitoringClusterName)
+	dst.Spec.KubernetesApiMonitoring.K8sAppEnabled = takeBoolFlag(flags, dynatracev1beta1.AnnotationFeatureK8sAppEnabled)
+
+	// oneAgent
+	dst.Spec.OneAgent.MaxUnavailable = takeIntFlag(flags, dynatracev1beta1.AnnotationFeatureOneAgentMaxUnavailable, 1)
+	dst.Spec.OneAgent.InitialConnectRetry = takeIntFlag(flags, dynatracev1beta1.AnnotationFeatureOneAgentInitialConnectRetry, -1)
+	dst.Spec.OneAgent.IgnoreProxy = takeBoolFlag(flags, dynatracev1beta1.AnnotationFeatureOneAgentIgnoreProxy)
+	dst.Spec.OneAgent.Privileged = takeBoolFlag(flags, dynatracev1beta1.AnnotationFeatureRunOneAgentContainerPrivileged)
+	dst.Spec.OneAgent.SecCompProfile = takeStringFlag(flags, dynatracev1beta1.AnnotationFeatureOneAgentSecCompProfile)
+	dst.Spec.OneAgent.ReadOnlyHostFs = takeBoolFlag(flags, dynatracev1beta1.AnnotationFeatureReadOnlyOneAgent)
+	dst.Spec.OneAgent.MultipleOsAgentsOnNode = takeBoolFlag(flags, dynatracev1beta1.AnnotationFeatureMultipleOsAgentsOnNode)
+
+	// activeGate
+	dst.Spec.ActiveGate.AutoUpdate = takeBoolFlag(flags, dynatracev1beta1.AnnotationFeatureActiveGateUpdates)
+	dst.Spec.ActiveGate.RawImage = takeBoolFlag(flags, dynatracev1beta1.AnnotationFeatureActiveGateRawImage)
+	dst.Spec.ActiveGate.AuthToken = takeBoolFlag(flags, dynatracev1beta1.AnnotationFeatureActiveGateAuthToken)
+	dst.Spec.ActiveGate.AppArmor = takeBoolFlag(flags, dynatracev1beta1.AnnotationFeatureActiveGateAppArmor)
+	dst.Spec.ActiveGate.ReadOnlyFilesystem = takeBoolFlag(flags, dynatracev1beta1.AnnotationFeatureActiveGateReadOnlyFilesystem)
+	dst.Spec.ActiveGate.IgnoreProxy = takeBoolFlag(flags, dynatracev1beta1.AnnotationFeatureActiveGateIgnoreProxy)
+
+	// injection (webhook)
+	dst.Spec.Injection.IgnoredNamespaces = takeStringListFlag(flags, dynatracev1beta1.AnnotationFeatureIgnoredNamespaces)
+	dst.Spec.Injection.FailurePolicy = InjectionFailurePolicy(takeEnumFlag(flags, dynatracev1beta1.AnnotationInjectionFailurePolicy,
+		string(InjectionFailurePolicySilent), string(InjectionFailurePolicyFail), string(InjectionFailurePolicyForce)))
+	dst.Spec.Injection.AutomaticInjection = takeBoolFlag(flags, dynatracev1beta1.AnnotationFeatureAutomaticInjection)
+	dst.Spec.Injection.WebhookReinvocationPolicy = takeBoolFlag(flags, dynatracev1beta1.AnnotationFeatureWebhookReinvocationPolicy)
+	dst.Spec.Injection.MetadataEnrichment = takeBoolFlag(flags, dynatracev1beta1.AnnotationFeatureMetadataEnrichment)
+	dst.Spec.Injection.IgnoreUnknownState = takeBoolFlag(flags, dynatracev1beta1.AnnotationFeatureIgnoreUnknownState)
+	dst.Spec.Injection.LabelVersionDetection = takeBoolFlag(flags, dynatracev1beta1.AnnotationFeatureLabelVersionDetection)
+	dst.Spec.Injection.InitContainerSeccomp = takeBoolFlag(flags, dynatracev1beta1.AnnotationFeatureInitContainerSeccomp)
+	dst.Spec.Injection.MaxFailedCsiMountAttempts = takeIntFlag(flags, dynatracev1beta1.AnnotationFeatureMaxFailedCsiMountAttempts, 0)
+	dst.Spec.Injection.ReadOnlyCsiVolume = takeBoolFlag(flags, dynatracev1beta1.AnnotationFeatureReadOnlyCsiVolume)
+
+	// synthetic
+	dst.Spec.Synthetic.LocationEntityId = takeStringFlag(flags, dynatracev1beta1.AnnotationFeatureSyntheticLocationEntityId)
+	dst.Spec.Synthetic.NodeType = SyntheticNodeType(takeEnumFlag(flags, dynatracev1beta1.AnnotationFeatureSyntheticNodeType,
+		string(SyntheticNodeXs), string(SyntheticNodeS), string(SyntheticNodeM)))
+	dst.Spec.Synthetic.Replicas = takeInt32Flag(flags, dynatracev1beta1.AnnotationFeatureSyntheticReplicas, 0)
+	dst.Spec.Synthetic.Image = takeStringFlag(flags, dynatracev1beta1.AnnotationFeatureCustomSyntheticImage)
+}
+
+func setBoolFlag(flags map[string]string, annotation string, value *bool) {
+	if value != nil {
+		flags[annotation] = strconv.FormatBool(*value)
+	}
+}
+
+func setIntFlag(flags map[string]string, annotation string, value *int) {
+	if value != nil {
+		flags[annotation] = strconv.Itoa(*value)
+	}
+}
+
+func setInt32Flag(flags map[string]string, annotation string, value *int32) {
+	if value != nil {
+		flags[annotation] = strconv.FormatInt(int64(*value), 10)
+	}
+}
+
+func setStringFlag(flags map[string]string, annotation string, value string) {
+	if value != "" {
+		flags[annotation] = value
+	}
+}
+
+func setStringListFlag(flags map[string]string, annotation string, value []string) {
+	if value == nil {
+		return
+	}
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return
+	}
+	flags[annotation] = string(raw)
+}
+
+func takeBoolFlag(flags map[string]string, annotation string) *bool {
+	raw, ok := flags[annotation]
+	if !ok {
+		return nil
+	}
+	value, err := strconv.ParseBool(raw)
+	if err != nil || strconv.FormatBool(value) != raw {
+		return nil
+	}
+	delete(flags, annotation)
+	return &value
+}
+
+// takeIntFlag only takes values that are allowed by the schema of the typed setting, see minValue
+func takeIntFlag(flags map[string]string, annotation string, minValue int) *int {
+	raw, ok := flags[annotation]
+	if !ok {
+		return nil
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil || strconv.Itoa(value) != raw || value < minValue {
+		return nil
+	}
+	delete(flags, annotation)
+	return &value
+}
+
+func takeInt32Flag(flags map[string]string, annotation string, minValue int32) *int32 {
+	raw, ok := flags[annotation]
+	if !ok {
+		return nil
+	}
+	value, err := strconv.ParseInt(raw, 10, 32)
+	if err != nil || strconv.FormatInt(value, 10) != raw || value < int64(minValue) {
+		return nil
+	}
+	delete(flags, annotation)
+	result := int32(value)
+	return &result
+}
+
+func takeStringFlag(flags map[string]string, annotation string) string {
+	value := flags[annotation]
+	if value != "" {
+		delete(flags, annotation)
+	}
+	return value
+}
+
+func takeEnumFlag(flags map[string]string, annotation string, allowed ...string) string {
+	raw := flags[annotation]
+	for _, value := range allowed {
+		if raw == value {
+			delete(flags, annotation)
+			return value
+		}
+	}
+	return ""
+}
+
+func takeStringListFlag(flags map[string]string, annotation string) []string {
+	raw, ok := flags[annotation]
+	if !ok {
+		return nil
+	}
+	value := []string{}
+	// null unmarshals to a nil list, which can't be told apart from a missing flag when converting back
+	if err := json.Unmarshal([]byte(raw), &value); err != nil || value == nil {
+		return nil
+	}
+	if restored, err := json.Marshal(value); err != nil || string(restored) != raw {
+		return nil
+	}
+	delete(flags, annotation)
+	return value
+}
@@ -0,0 +1,64 @@
+package dynakube
+
+type InjectionFailurePolicy string
+
+const (
+	InjectionFailurePolicySilent InjectionFailurePolicy = "silent"
+	InjectionFailurePolicyFail   InjectionFailurePolicy = "fail"
+	InjectionFailurePolicyForce  InjectionFailurePolicy = "force"
+)
+
+type InjectionSpec struct {
+	// Namespaces the dynatrace-webhook never injects into, given as a list of regular expressions.
+	// Defaults to the namespace of the DynaKube and the well known system namespaces.
+	// +optional
+	IgnoredNamespaces []string `json:"ignoredNamespaces,omitempty"`
+
+	// Defines what happens if the injection into a pod fails. With silent, the pod starts without monitoring.
+	// With fail, the pod doesn't start. With force, the injection is retried even if the DynaKube isn't ready.
+	// Defaults to silent.
+	// +kubebuilder:validation:Enum=silent;fail;force
+	// +optional
+	FailurePolicy InjectionFailurePolicy `json:"failurePolicy,omitempty"`
+
+	// Injects into all pods of the selected namespaces. If disabled, pods have to opt in one by one.
+	// Enabled by default.
+	// +optional
+	AutomaticInjection *bool `json:"automaticInjection,omitempty"`
+
+	// Enables the reinvocation of the dynatrace-webhook, so containers added by other webhooks are instrumented as well.
+	// Enabled by default.
+	// +optional
+	WebhookReinvocationPolicy *bool `json:"webhookReinvocationPolicy,omitempty"`
+
+	// Enables the metadata enrichment of the injected pods.
+	// Enabled by default.
+	// +optional
+	MetadataEnrichment *bool `json:"metadataEnrichment,omitempty"`
+
+	// Injects into pods even if the DynaKube is in an unknown state. This may cause extra hosts to appear in Dynatrace.
+	// Disabled by default.
+	// +optional
+	IgnoreUnknownState *bool `json:"ignoreUnknownState,omitempty"`
+
+	// Adds environment variables for the version detection based on the labels of the injected pods.
+	// Disabled by default.
+	// +optional
+	LabelVersionDetection *bool `json:"labelVersionDetection,omitempty"`
+
+	// Sets the seccomp profile of the injected init container to RuntimeDefault.
+	// Disabled by default.
+	// +optional
+	InitContainerSeccomp *bool `json:"initContainerSeccomp,omitempty"`
+
+	// Amount of failed mount attempts of the CSI volume after which the pod starts without monitoring.
+	// Defaults to 10.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxFailedCsiMountAttempts *int `json:"maxFailedCsiMountAttempts,omitempty"`
+
+	// Mounts the CSI volume read-only into the injected pods.
+	// Disabled by default.
+	// +optional
+	ReadOnlyCsiVolume *bool `json:"readOnlyCsiVolume,omitempty"`
+}
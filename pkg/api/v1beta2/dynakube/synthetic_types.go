@@ -0,0 +1,31 @@
+package dynakube
+
+type SyntheticNodeType string
+
+const (
+	SyntheticNodeXs SyntheticNodeType = "XS"
+	SyntheticNodeS  SyntheticNodeType = "S"
+	SyntheticNodeM  SyntheticNodeType = "M"
+)
+
+type SyntheticSpec struct {
+	// The entity ID of the private synthetic location.
+	// +optional
+	LocationEntityId string `json:"locationEntityId,omitempty"`
+
+	// The size of the synthetic nodes, determines the resources of the synthetic pods.
+	// Defaults to S.
+	// +kubebuilder:validation:Enum=XS;S;M
+	// +optional
+	NodeType SyntheticNodeType `json:"nodeType,omitempty"`
+
+	// Amount of replicas of the synthetic pods.
+	// Defaults to 1.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Use a custom synthetic image instead of the one provided by the Dynatrace environment.
+	// +optional
+	Image string `json:"image,omitempty"`
+}
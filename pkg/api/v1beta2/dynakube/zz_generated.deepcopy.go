@@ -0,0 +1,1082 @@
+//go:build !ignore_autogenerated
+// +build !ignore_autogenerated
+
+/*
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by controller-gen. DO NOT EDIT.
+
+package dynakube
+
+import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/maintenancewindow"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/mirror"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/oauth"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/rollout"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/settings"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/tokensource"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/versionpolicy"
+	pkgv1 "github.com/google/go-containerregistry/pkg/v1"
+	"k8s.io/api/autoscaling/v2"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActiveGateAutoscalingSpec) DeepCopyInto(out *ActiveGateAutoscalingSpec) {
+	*out = *in
+	if in.MinReplicas != nil {
+		in, out := &in.MinReplicas, &out.MinReplicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TargetCPUUtilization != nil {
+		in, out := &in.TargetCPUUtilization, &out.TargetCPUUtilization
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TargetMemoryUtilization != nil {
+		in, out := &in.TargetMemoryUtilization, &out.TargetMemoryUtilization
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Metrics != nil {
+		in, out := &in.Metrics, &out.Metrics
+		*out = make([]v2.MetricSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActiveGateAutoscalingSpec.
+func (in *ActiveGateAutoscalingSpec) DeepCopy() *ActiveGateAutoscalingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ActiveGateAutoscalingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActiveGateConnectionInfoStatus) DeepCopyInto(out *ActiveGateConnectionInfoStatus) {
+	*out = *in
+	in.ConnectionInfoStatus.DeepCopyInto(&out.ConnectionInfoStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActiveGateConnectionInfoStatus.
+func (in *ActiveGateConnectionInfoStatus) DeepCopy() *ActiveGateConnectionInfoStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ActiveGateConnectionInfoStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActiveGatePodDisruptionBudgetSpec) DeepCopyInto(out *ActiveGatePodDisruptionBudgetSpec) {
+	*out = *in
+	if in.MinAvailable != nil {
+		in, out := &in.MinAvailable, &out.MinAvailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActiveGatePodDisruptionBudgetSpec.
+func (in *ActiveGatePodDisruptionBudgetSpec) DeepCopy() *ActiveGatePodDisruptionBudgetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ActiveGatePodDisruptionBudgetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActiveGatePoolSpec) DeepCopyInto(out *ActiveGatePoolSpec) {
+	*out = *in
+	if in.Capabilities != nil {
+		in, out := &in.Capabilities, &out.Capabilities
+		*out = make([]CapabilityDisplayName, len(*in))
+		copy(*out, *in)
+	}
+	in.CapabilityProperties.DeepCopyInto(&out.CapabilityProperties)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActiveGatePoolSpec.
+func (in *ActiveGatePoolSpec) DeepCopy() *ActiveGatePoolSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ActiveGatePoolSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActiveGatePoolStatus) DeepCopyInto(out *ActiveGatePoolStatus) {
+	*out = *in
+	in.VersionStatus.DeepCopyInto(&out.VersionStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActiveGatePoolStatus.
+func (in *ActiveGatePoolStatus) DeepCopy() *ActiveGatePoolStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ActiveGatePoolStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActiveGateSpec) DeepCopyInto(out *ActiveGateSpec) {
+	*out = *in
+	if in.Capabilities != nil {
+		in, out := &in.Capabilities, &out.Capabilities
+		*out = make([]CapabilityDisplayName, len(*in))
+		copy(*out, *in)
+	}
+	in.CapabilityProperties.DeepCopyInto(&out.CapabilityProperties)
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.AutoUpdate != nil {
+		in, out := &in.AutoUpdate, &out.AutoUpdate
+		*out = new(bool)
+		**out = **in
+	}
+	if in.VersionPolicy != nil {
+		in, out := &in.VersionPolicy, &out.VersionPolicy
+		*out = new(versionpolicy.Spec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RawImage != nil {
+		in, out := &in.RawImage, &out.RawImage
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AuthToken != nil {
+		in, out := &in.AuthToken, &out.AuthToken
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AppArmor != nil {
+		in, out := &in.AppArmor, &out.AppArmor
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ReadOnlyFilesystem != nil {
+		in, out := &in.ReadOnlyFilesystem, &out.ReadOnlyFilesystem
+		*out = new(bool)
+		**out = **in
+	}
+	if in.IgnoreProxy != nil {
+		in, out := &in.IgnoreProxy, &out.IgnoreProxy
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Autoscaling != nil {
+		in, out := &in.Autoscaling, &out.Autoscaling
+		*out = new(ActiveGateAutoscalingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(ActiveGatePodDisruptionBudgetSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Pools != nil {
+		in, out := &in.Pools, &out.Pools
+		*out = make([]ActiveGatePoolSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActiveGateSpec.
+func (in *ActiveGateSpec) DeepCopy() *ActiveGateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ActiveGateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActiveGateStatus) DeepCopyInto(out *ActiveGateStatus) {
+	*out = *in
+	in.VersionStatus.DeepCopyInto(&out.VersionStatus)
+	in.ConnectionInfoStatus.DeepCopyInto(&out.ConnectionInfoStatus)
+	if in.Pools != nil {
+		in, out := &in.Pools, &out.Pools
+		*out = make([]ActiveGatePoolStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActiveGateStatus.
+func (in *ActiveGateStatus) DeepCopy() *ActiveGateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ActiveGateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppInjectionSpec) DeepCopyInto(out *AppInjectionSpec) {
+	*out = *in
+	if in.CodeModulesVersionPolicy != nil {
+		in, out := &in.CodeModulesVersionPolicy, &out.CodeModulesVersionPolicy
+		*out = new(versionpolicy.Spec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.InitResources != nil {
+		in, out := &in.InitResources, &out.InitResources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppInjectionSpec.
+func (in *AppInjectionSpec) DeepCopy() *AppInjectionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AppInjectionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationMonitoringSpec) DeepCopyInto(out *ApplicationMonitoringSpec) {
+	*out = *in
+	in.AppInjectionSpec.DeepCopyInto(&out.AppInjectionSpec)
+	if in.UseCSIDriver != nil {
+		in, out := &in.UseCSIDriver, &out.UseCSIDriver
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationMonitoringSpec.
+func (in *ApplicationMonitoringSpec) DeepCopy() *ApplicationMonitoringSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationMonitoringSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CapabilityProperties) DeepCopyInto(out *CapabilityProperties) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CustomProperties != nil {
+		in, out := &in.CustomProperties, &out.CustomProperties
+		*out = new(DynaKubeValueSource)
+		**out = **in
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]v1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CapabilityProperties.
+func (in *CapabilityProperties) DeepCopy() *CapabilityProperties {
+	if in == nil {
+		return nil
+	}
+	out := new(CapabilityProperties)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudNativeFullStackSpec) DeepCopyInto(out *CloudNativeFullStackSpec) {
+	*out = *in
+	in.HostInjectSpec.DeepCopyInto(&out.HostInjectSpec)
+	in.AppInjectionSpec.DeepCopyInto(&out.AppInjectionSpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudNativeFullStackSpec.
+func (in *CloudNativeFullStackSpec) DeepCopy() *CloudNativeFullStackSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudNativeFullStackSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CodeModulesStatus) DeepCopyInto(out *CodeModulesStatus) {
+	*out = *in
+	in.VersionStatus.DeepCopyInto(&out.VersionStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CodeModulesStatus.
+func (in *CodeModulesStatus) DeepCopy() *CodeModulesStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CodeModulesStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CommunicationHostStatus) DeepCopyInto(out *CommunicationHostStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommunicationHostStatus.
+func (in *CommunicationHostStatus) DeepCopy() *CommunicationHostStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CommunicationHostStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectionInfoStatus) DeepCopyInto(out *ConnectionInfoStatus) {
+	*out = *in
+	in.LastRequest.DeepCopyInto(&out.LastRequest)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectionInfoStatus.
+func (in *ConnectionInfoStatus) DeepCopy() *ConnectionInfoStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectionInfoStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DynaKube) DeepCopyInto(out *DynaKube) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DynaKube.
+func (in *DynaKube) DeepCopy() *DynaKube {
+	if in == nil {
+		return nil
+	}
+	out := new(DynaKube)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DynaKube) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DynaKubeList) DeepCopyInto(out *DynaKubeList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DynaKube, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DynaKubeList.
+func (in *DynaKubeList) DeepCopy() *DynaKubeList {
+	if in == nil {
+		return nil
+	}
+	out := new(DynaKubeList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DynaKubeList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DynaKubeProxy) DeepCopyInto(out *DynaKubeProxy) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DynaKubeProxy.
+func (in *DynaKubeProxy) DeepCopy() *DynaKubeProxy {
+	if in == nil {
+		return nil
+	}
+	out := new(DynaKubeProxy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DynaKubeSpec) DeepCopyInto(out *DynaKubeSpec) {
+	*out = *in
+	if in.TokenSource != nil {
+		in, out := &in.TokenSource, &out.TokenSource
+		*out = new(tokensource.Spec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OAuth != nil {
+		in, out := &in.OAuth, &out.OAuth
+		*out = new(oauth.Spec)
+		**out = **in
+	}
+	if in.Mirror != nil {
+		in, out := &in.Mirror, &out.Mirror
+		*out = new(mirror.Spec)
+		**out = **in
+	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(DynaKubeProxy)
+		**out = **in
+	}
+	in.NamespaceSelector.DeepCopyInto(&out.NamespaceSelector)
+	in.PodSelector.DeepCopyInto(&out.PodSelector)
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]maintenancewindow.Window, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Settings != nil {
+		in, out := &in.Settings, &out.Settings
+		*out = make([]settings.Spec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PublicRegistry != nil {
+		in, out := &in.PublicRegistry, &out.PublicRegistry
+		*out = new(bool)
+		**out = **in
+	}
+	in.DynatraceApi.DeepCopyInto(&out.DynatraceApi)
+	in.Events.DeepCopyInto(&out.Events)
+	in.KubernetesApiMonitoring.DeepCopyInto(&out.KubernetesApiMonitoring)
+	in.Injection.DeepCopyInto(&out.Injection)
+	in.Synthetic.DeepCopyInto(&out.Synthetic)
+	in.OneAgent.DeepCopyInto(&out.OneAgent)
+	in.ActiveGate.DeepCopyInto(&out.ActiveGate)
+	in.Routing.DeepCopyInto(&out.Routing)
+	in.KubernetesMonitoring.DeepCopyInto(&out.KubernetesMonitoring)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DynaKubeSpec.
+func (in *DynaKubeSpec) DeepCopy() *DynaKubeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DynaKubeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DynaKubeStatus) DeepCopyInto(out *DynaKubeStatus) {
+	*out = *in
+	in.UpdatedTimestamp.DeepCopyInto(&out.UpdatedTimestamp)
+	if in.LastTokenProbeTimestamp != nil {
+		in, out := &in.LastTokenProbeTimestamp, &out.LastTokenProbeTimestamp
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.UpdatesDeferredUntil != nil {
+		in, out := &in.UpdatesDeferredUntil, &out.UpdatesDeferredUntil
+		*out = (*in).DeepCopy()
+	}
+	if in.Settings != nil {
+		in, out := &in.Settings, &out.Settings
+		*out = make([]settings.Status, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.ActiveGate.DeepCopyInto(&out.ActiveGate)
+	in.OneAgent.DeepCopyInto(&out.OneAgent)
+	in.CodeModules.DeepCopyInto(&out.CodeModules)
+	in.Synthetic.DeepCopyInto(&out.Synthetic)
+	in.DynatraceApi.DeepCopyInto(&out.DynatraceApi)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DynaKubeStatus.
+func (in *DynaKubeStatus) DeepCopy() *DynaKubeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DynaKubeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DynaKubeValueSource) DeepCopyInto(out *DynaKubeValueSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DynaKubeValueSource.
+func (in *DynaKubeValueSource) DeepCopy() *DynaKubeValueSource {
+	if in == nil {
+		return nil
+	}
+	out := new(DynaKubeValueSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DynatraceApiSpec) DeepCopyInto(out *DynatraceApiSpec) {
+	*out = *in
+	if in.RequestThreshold != nil {
+		in, out := &in.RequestThreshold, &out.RequestThreshold
+		*out = new(int)
+		**out = **in
+	}
+	if in.HostsRequests != nil {
+		in, out := &in.HostsRequests, &out.HostsRequests
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RequestRetries != nil {
+		in, out := &in.RequestRetries, &out.RequestRetries
+		*out = new(int)
+		**out = **in
+	}
+	if in.RequestMaxBackoffSeconds != nil {
+		in, out := &in.RequestMaxBackoffSeconds, &out.RequestMaxBackoffSeconds
+		*out = new(int)
+		**out = **in
+	}
+	if in.RequestTimeoutSeconds != nil {
+		in, out := &in.RequestTimeoutSeconds, &out.RequestTimeoutSeconds
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DynatraceApiSpec.
+func (in *DynatraceApiSpec) DeepCopy() *DynatraceApiSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DynatraceApiSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DynatraceApiStatus) DeepCopyInto(out *DynatraceApiStatus) {
+	*out = *in
+	in.LastTokenScopeRequest.DeepCopyInto(&out.LastTokenScopeRequest)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DynatraceApiStatus.
+func (in *DynatraceApiStatus) DeepCopy() *DynatraceApiStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DynatraceApiStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventsSpec) DeepCopyInto(out *EventsSpec) {
+	*out = *in
+	if in.OneAgentVersion != nil {
+		in, out := &in.OneAgentVersion, &out.OneAgentVersion
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ActiveGateVersion != nil {
+		in, out := &in.ActiveGateVersion, &out.ActiveGateVersion
+		*out = new(bool)
+		**out = **in
+	}
+	if in.InstallFailure != nil {
+		in, out := &in.InstallFailure, &out.InstallFailure
+		*out = new(bool)
+		**out = **in
+	}
+	if in.TokenScope != nil {
+		in, out := &in.TokenScope, &out.TokenScope
+		*out = new(bool)
+		**out = **in
+	}
+	if in.InjectionFailure != nil {
+		in, out := &in.InjectionFailure, &out.InjectionFailure
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventsSpec.
+func (in *EventsSpec) DeepCopy() *EventsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(EventsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostInjectSpec) DeepCopyInto(out *HostInjectSpec) {
+	*out = *in
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AutoUpdate != nil {
+		in, out := &in.AutoUpdate, &out.AutoUpdate
+		*out = new(bool)
+		**out = **in
+	}
+	if in.VersionPolicy != nil {
+		in, out := &in.VersionPolicy, &out.VersionPolicy
+		*out = new(versionpolicy.Spec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(rollout.Strategy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.OneAgentResources.DeepCopyInto(&out.OneAgentResources)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostInjectSpec.
+func (in *HostInjectSpec) DeepCopy() *HostInjectSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HostInjectSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InjectionSpec) DeepCopyInto(out *InjectionSpec) {
+	*out = *in
+	if in.IgnoredNamespaces != nil {
+		in, out := &in.IgnoredNamespaces, &out.IgnoredNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AutomaticInjection != nil {
+		in, out := &in.AutomaticInjection, &out.AutomaticInjection
+		*out = new(bool)
+		**out = **in
+	}
+	if in.WebhookReinvocationPolicy != nil {
+		in, out := &in.WebhookReinvocationPolicy, &out.WebhookReinvocationPolicy
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MetadataEnrichment != nil {
+		in, out := &in.MetadataEnrichment, &out.MetadataEnrichment
+		*out = new(bool)
+		**out = **in
+	}
+	if in.IgnoreUnknownState != nil {
+		in, out := &in.IgnoreUnknownState, &out.IgnoreUnknownState
+		*out = new(bool)
+		**out = **in
+	}
+	if in.LabelVersionDetection != nil {
+		in, out := &in.LabelVersionDetection, &out.LabelVersionDetection
+		*out = new(bool)
+		**out = **in
+	}
+	if in.InitContainerSeccomp != nil {
+		in, out := &in.InitContainerSeccomp, &out.InitContainerSeccomp
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaxFailedCsiMountAttempts != nil {
+		in, out := &in.MaxFailedCsiMountAttempts, &out.MaxFailedCsiMountAttempts
+		*out = new(int)
+		**out = **in
+	}
+	if in.ReadOnlyCsiVolume != nil {
+		in, out := &in.ReadOnlyCsiVolume, &out.ReadOnlyCsiVolume
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InjectionSpec.
+func (in *InjectionSpec) DeepCopy() *InjectionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InjectionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubernetesApiMonitoringSpec) DeepCopyInto(out *KubernetesApiMonitoringSpec) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.K8sAppEnabled != nil {
+		in, out := &in.K8sAppEnabled, &out.K8sAppEnabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesApiMonitoringSpec.
+func (in *KubernetesApiMonitoringSpec) DeepCopy() *KubernetesApiMonitoringSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KubernetesApiMonitoringSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubernetesMonitoringSpec) DeepCopyInto(out *KubernetesMonitoringSpec) {
+	*out = *in
+	in.CapabilityProperties.DeepCopyInto(&out.CapabilityProperties)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesMonitoringSpec.
+func (in *KubernetesMonitoringSpec) DeepCopy() *KubernetesMonitoringSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KubernetesMonitoringSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OneAgentConnectionInfoStatus) DeepCopyInto(out *OneAgentConnectionInfoStatus) {
+	*out = *in
+	in.ConnectionInfoStatus.DeepCopyInto(&out.ConnectionInfoStatus)
+	if in.CommunicationHosts != nil {
+		in, out := &in.CommunicationHosts, &out.CommunicationHosts
+		*out = make([]CommunicationHostStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OneAgentConnectionInfoStatus.
+func (in *OneAgentConnectionInfoStatus) DeepCopy() *OneAgentConnectionInfoStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OneAgentConnectionInfoStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OneAgentInstance) DeepCopyInto(out *OneAgentInstance) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OneAgentInstance.
+func (in *OneAgentInstance) DeepCopy() *OneAgentInstance {
+	if in == nil {
+		return nil
+	}
+	out := new(OneAgentInstance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OneAgentSpec) DeepCopyInto(out *OneAgentSpec) {
+	*out = *in
+	if in.ClassicFullStack != nil {
+		in, out := &in.ClassicFullStack, &out.ClassicFullStack
+		*out = new(HostInjectSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CloudNativeFullStack != nil {
+		in, out := &in.CloudNativeFullStack, &out.CloudNativeFullStack
+		*out = new(CloudNativeFullStackSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ApplicationMonitoring != nil {
+		in, out := &in.ApplicationMonitoring, &out.ApplicationMonitoring
+		*out = new(ApplicationMonitoringSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HostMonitoring != nil {
+		in, out := &in.HostMonitoring, &out.HostMonitoring
+		*out = new(HostInjectSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(int)
+		**out = **in
+	}
+	if in.InitialConnectRetry != nil {
+		in, out := &in.InitialConnectRetry, &out.InitialConnectRetry
+		*out = new(int)
+		**out = **in
+	}
+	if in.IgnoreProxy != nil {
+		in, out := &in.IgnoreProxy, &out.IgnoreProxy
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Privileged != nil {
+		in, out := &in.Privileged, &out.Privileged
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ReadOnlyHostFs != nil {
+		in, out := &in.ReadOnlyHostFs, &out.ReadOnlyHostFs
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MultipleOsAgentsOnNode != nil {
+		in, out := &in.MultipleOsAgentsOnNode, &out.MultipleOsAgentsOnNode
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OneAgentSpec.
+func (in *OneAgentSpec) DeepCopy() *OneAgentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OneAgentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OneAgentStatus) DeepCopyInto(out *OneAgentStatus) {
+	*out = *in
+	in.VersionStatus.DeepCopyInto(&out.VersionStatus)
+	if in.Instances != nil {
+		in, out := &in.Instances, &out.Instances
+		*out = make(map[string]OneAgentInstance, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.LastInstanceStatusUpdate != nil {
+		in, out := &in.LastInstanceStatusUpdate, &out.LastInstanceStatusUpdate
+		*out = (*in).DeepCopy()
+	}
+	in.ConnectionInfoStatus.DeepCopyInto(&out.ConnectionInfoStatus)
+	if in.Healthcheck != nil {
+		in, out := &in.Healthcheck, &out.Healthcheck
+		*out = new(pkgv1.HealthConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rollout != nil {
+		in, out := &in.Rollout, &out.Rollout
+		*out = new(rollout.Status)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OneAgentStatus.
+func (in *OneAgentStatus) DeepCopy() *OneAgentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OneAgentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoutingSpec) DeepCopyInto(out *RoutingSpec) {
+	*out = *in
+	in.CapabilityProperties.DeepCopyInto(&out.CapabilityProperties)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoutingSpec.
+func (in *RoutingSpec) DeepCopy() *RoutingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RoutingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyntheticSpec) DeepCopyInto(out *SyntheticSpec) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyntheticSpec.
+func (in *SyntheticSpec) DeepCopy() *SyntheticSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SyntheticSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyntheticStatus) DeepCopyInto(out *SyntheticStatus) {
+	*out = *in
+	in.VersionStatus.DeepCopyInto(&out.VersionStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyntheticStatus.
+func (in *SyntheticStatus) DeepCopy() *SyntheticStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SyntheticStatus)
+	in.DeepCopyInto(out)
+	return out
+}
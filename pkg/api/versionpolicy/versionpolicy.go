@@ -0,0 +1,28 @@
+// +kubebuilder:object:generate=true
+// +k8s:openapi-gen=true
+package versionpolicy
+
+// Spec restricts the versions the operator chooses from when it updates a component automatically.
+// It can't be combined with a fixed version or a custom image.
+type Spec struct {
+	// Semantic version constraint the chosen version has to satisfy, e.g. `>=1.280 <1.290`, `~1.285` or `1.285.x || 1.287.x`.
+	// Supported operators are =, !=, >, >=, <, <=, ~ and ^. Build dates of versions are ignored.
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Version constraint",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:text"}
+	Constraint string `json:"constraint,omitempty"`
+
+	// Stay the given number of minor versions behind the newest minor version satisfying the constraint (N-minus-k).
+	// The latest release of that minor version is chosen.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	// +operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Minor versions behind latest",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:advanced","urn:alm:descriptor:com.tectonic.ui:number"}
+	MinorVersionsBehindLatest *int `json:"minorVersionsBehindLatest,omitempty"`
+}
+
+// GetMinorVersionsBehindLatest returns the configured number of minor versions to stay behind, 0 if unset
+func (spec *Spec) GetMinorVersionsBehindLatest() int {
+	if spec == nil || spec.MinorVersionsBehindLatest == nil {
+		return 0
+	}
+	return *spec.MinorVersionsBehindLatest
+}
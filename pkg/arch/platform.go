@@ -0,0 +1,63 @@
+package arch
+
+// Operating systems in the notation of the Dynatrace deployment API
+const (
+	OsUnix    = "unix"
+	OsWindows = "windows"
+)
+
+// Values of the kubernetes.io/os and kubernetes.io/arch node labels
+const (
+	KubernetesOsLinux   = "linux"
+	KubernetesOsWindows = "windows"
+
+	KubernetesArchAMD64   = "amd64"
+	KubernetesArchARM64   = "arm64"
+	KubernetesArchPPC64LE = "ppc64le"
+	KubernetesArchS390X   = "s390x"
+)
+
+var kubernetesOses = map[string]string{
+	KubernetesOsLinux:   OsUnix,
+	KubernetesOsWindows: OsWindows,
+}
+
+var kubernetesArches = map[string]string{
+	KubernetesArchAMD64:   ArchX86,
+	KubernetesArchARM64:   ArchARM,
+	KubernetesArchPPC64LE: ArchPPCLE,
+	KubernetesArchS390X:   ArchS390,
+}
+
+// OsFromKubernetes maps the value of the kubernetes.io/os label to the os of the deployment API
+func OsFromKubernetes(kubernetesOs string) (string, bool) {
+	os, ok := kubernetesOses[kubernetesOs]
+	return os, ok
+}
+
+// ArchFromKubernetes maps the value of the kubernetes.io/arch label to the arch of the deployment API
+func ArchFromKubernetes(kubernetesArch string) (string, bool) {
+	arch, ok := kubernetesArches[kubernetesArch]
+	return arch, ok
+}
+
+// DefaultFlavor returns the flavor of the code modules for the os and arch, multidistro is only available for unix on x86
+func DefaultFlavor(os, arch string) string {
+	if os == OsUnix && arch == ArchX86 {
+		return FlavorMultidistro
+	}
+	return FlavorDefault
+}
+
+// Arches are the architectures code modules are available for
+var Arches = []string{ArchX86, ArchARM, ArchPPCLE, ArchS390}
+
+// KubernetesArchFromArch maps the arch of the deployment API to the value of the kubernetes.io/arch label, which is also the architecture of OCI platforms
+func KubernetesArchFromArch(arch string) (string, bool) {
+	for kubernetesArch, dynatraceArch := range kubernetesArches {
+		if dynatraceArch == arch {
+			return kubernetesArch, true
+		}
+	}
+	return "", false
+}
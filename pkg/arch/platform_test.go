@@ -0,0 +1,55 @@
+package arch
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOsFromKubernetes(t *testing.T) {
+	os, ok := OsFromKubernetes(KubernetesOsLinux)
+	assert.True(t, ok)
+	assert.Equal(t, OsUnix, os)
+
+	os, ok = OsFromKubernetes(KubernetesOsWindows)
+	assert.True(t, ok)
+	assert.Equal(t, OsWindows, os)
+
+	_, ok = OsFromKubernetes("darwin")
+	assert.False(t, ok)
+}
+
+func TestArchFromKubernetes(t *testing.T) {
+	for kubernetesArch, expectedArch := range map[string]string{
+		KubernetesArchAMD64:   ArchX86,
+		KubernetesArchARM64:   ArchARM,
+		KubernetesArchPPC64LE: ArchPPCLE,
+		KubernetesArchS390X:   ArchS390,
+	} {
+		arch, ok := ArchFromKubernetes(kubernetesArch)
+		assert.True(t, ok)
+		assert.Equal(t, expectedArch, arch)
+	}
+
+	_, ok := ArchFromKubernetes("riscv64")
+	assert.False(t, ok)
+}
+
+func TestKubernetesArchFromArch(t *testing.T) {
+	for _, arch := range Arches {
+		kubernetesArch, ok := KubernetesArchFromArch(arch)
+		assert.True(t, ok)
+
+		roundTrip, _ := ArchFromKubernetes(kubernetesArch)
+		assert.Equal(t, arch, roundTrip)
+	}
+
+	_, ok := KubernetesArchFromArch("sparc")
+	assert.False(t, ok)
+}
+
+func TestDefaultFlavor(t *testing.T) {
+	assert.Equal(t, FlavorMultidistro, DefaultFlavor(OsUnix, ArchX86))
+	assert.Equal(t, FlavorDefault, DefaultFlavor(OsUnix, ArchARM))
+	assert.Equal(t, FlavorDefault, DefaultFlavor(OsWindows, ArchX86))
+}
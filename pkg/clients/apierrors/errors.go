@@ -0,0 +1,173 @@
+package apierrors
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+)
+
+// The classes of errors returned by the clients of the Dynatrace APIs, use errors.Is to check for them.
+var (
+	// ErrUnauthorized is returned if the token or the oauth client is invalid or expired (401)
+	ErrUnauthorized = errors.New("unauthorized")
+
+	// ErrForbidden is returned if the token or the oauth client lacks the permissions for the request (403), this includes missing scopes
+	ErrForbidden = errors.New("forbidden")
+
+	// ErrNotFound is returned if the requested object doesn't exist (404)
+	ErrNotFound = errors.New("not found")
+
+	// ErrRateLimited is returned if the request limit of the environment is reached (429), see RetryAfter
+	ErrRateLimited = errors.New("rate limited")
+
+	// ErrServer is returned if the server failed to handle the request (5xx)
+	ErrServer = errors.New("server error")
+
+	// ErrNetwork is returned if no connection to the server could be established or the connection broke
+	ErrNetwork = errors.New("network error")
+
+	// ErrTLS is returned if the certificate of the server isn't trusted or the tls handshake failed
+	ErrTLS = errors.New("tls error")
+)
+
+// FromStatusCode returns the class of a failed request based on its http status code, nil if the status code isn't classified
+func FromStatusCode(statusCode int) error {
+	switch {
+	case statusCode == http.StatusUnauthorized:
+		return ErrUnauthorized
+	case statusCode == http.StatusForbidden:
+		return ErrForbidden
+	case statusCode == http.StatusNotFound:
+		return ErrNotFound
+	case statusCode == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case statusCode >= http.StatusInternalServerError && statusCode <= 599:
+		return ErrServer
+	}
+
+	return nil
+}
+
+// Class returns the class of the given error, nil if it isn't one of the classified errors
+func Class(err error) error {
+	for _, class := range []error{ErrUnauthorized, ErrForbidden, ErrNotFound, ErrRateLimited, ErrServer, ErrNetwork, ErrTLS} {
+		if errors.Is(err, class) {
+			return class
+		}
+	}
+
+	return nil
+}
+
+// IsTransient returns true if repeating the request later can succeed without changing the configuration
+func IsTransient(err error) bool {
+	return errors.Is(err, ErrRateLimited) || errors.Is(err, ErrServer) || errors.Is(err, ErrNetwork)
+}
+
+// RetryAfter returns the wait time the server requested for a rate limited request, false if the server didn't request one
+func RetryAfter(err error) (time.Duration, bool) {
+	var rateLimited interface{ RetryAfterDuration() time.Duration }
+	if !errors.As(err, &rateLimited) || rateLimited.RetryAfterDuration() <= 0 {
+		return 0, false
+	}
+
+	return rateLimited.RetryAfterDuration(), true
+}
+
+// MissingScopesError is returned if a token or an oauth client lacks scopes required by the DynaKube, it is a ErrForbidden
+type MissingScopesError struct {
+	// Subject describes what lacks the scopes, e.g. "token 'apiToken'"
+	Subject string
+	Scopes  []string
+}
+
+func (e MissingScopesError) Error() string {
+	return e.Subject + " is missing the following scopes: [ " + strings.Join(e.Scopes, ", ") + " ]"
+}
+
+func (e MissingScopesError) Is(target error) bool {
+	return target == ErrForbidden
+}
+
+// transportError keeps the original error of the http client, so it can still be inspected, and adds its class
+type transportError struct {
+	err   error
+	class error
+}
+
+func (e transportError) Error() string {
+	return e.err.Error()
+}
+
+func (e transportError) Unwrap() []error {
+	return []error{e.err, e.class}
+}
+
+// FromTransport classifies an error returned by http.Client.Do as ErrTLS or ErrNetwork.
+// Errors of an oauth token endpoint are classified like the responses of the Dynatrace API.
+// Canceled requests are returned unchanged, as they are neither caused by the network nor by the server.
+func FromTransport(err error) error {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return err
+	}
+
+	var retrieveErr *oauth2.RetrieveError
+	if errors.As(err, &retrieveErr) {
+		if class := fromOAuthError(retrieveErr); class != nil {
+			return transportError{err: err, class: class}
+		}
+		return err
+	}
+
+	if isTLSError(err) {
+		return transportError{err: err, class: ErrTLS}
+	}
+
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return transportError{err: err, class: ErrNetwork}
+	}
+
+	return err
+}
+
+// fromOAuthError uses the error code of RFC 6749 if present, as token endpoints respond with 400 to most errors
+func fromOAuthError(err *oauth2.RetrieveError) error {
+	switch err.ErrorCode {
+	case "invalid_client":
+		return ErrUnauthorized
+	case "unauthorized_client", "invalid_scope":
+		return ErrForbidden
+	}
+
+	if err.Response == nil {
+		return nil
+	}
+
+	return FromStatusCode(err.Response.StatusCode)
+}
+
+func isTLSError(err error) bool {
+	var (
+		unknownAuthorityErr   x509.UnknownAuthorityError
+		certificateInvalidErr x509.CertificateInvalidError
+		hostnameErr           x509.HostnameError
+		verificationErr       *tls.CertificateVerificationError
+		recordHeaderErr       tls.RecordHeaderError
+		alertErr              tls.AlertError
+	)
+
+	return errors.As(err, &unknownAuthorityErr) ||
+		errors.As(err, &certificateInvalidErr) ||
+		errors.As(err, &hostnameErr) ||
+		errors.As(err, &verificationErr) ||
+		errors.As(err, &recordHeaderErr) ||
+		errors.As(err, &alertErr)
+}
@@ -0,0 +1,117 @@
+package apierrors
+
+import (
+	"context"
+	"crypto/x509"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/oauth2"
+)
+
+type testRateLimitedError struct {
+	retryAfter time.Duration
+}
+
+func (e testRateLimitedError) Error() string {
+	return "rate limited"
+}
+
+func (e testRateLimitedError) RetryAfterDuration() time.Duration {
+	return e.retryAfter
+}
+
+func TestFromStatusCode(t *testing.T) {
+	assert.Equal(t, ErrUnauthorized, FromStatusCode(http.StatusUnauthorized))
+	assert.Equal(t, ErrForbidden, FromStatusCode(http.StatusForbidden))
+	assert.Equal(t, ErrNotFound, FromStatusCode(http.StatusNotFound))
+	assert.Equal(t, ErrRateLimited, FromStatusCode(http.StatusTooManyRequests))
+	assert.Equal(t, ErrServer, FromStatusCode(http.StatusInternalServerError))
+	assert.Equal(t, ErrServer, FromStatusCode(http.StatusServiceUnavailable))
+	assert.Nil(t, FromStatusCode(http.StatusOK))
+	assert.Nil(t, FromStatusCode(http.StatusBadRequest))
+	assert.Nil(t, FromStatusCode(0))
+}
+
+func TestClass(t *testing.T) {
+	assert.Equal(t, ErrNotFound, Class(errors.WithMessage(ErrNotFound, "wrapped")))
+	assert.Equal(t, ErrForbidden, Class(MissingScopesError{Subject: "token 'apiToken'", Scopes: []string{"a"}}))
+	assert.Nil(t, Class(errors.New("unclassified")))
+	assert.Nil(t, Class(nil))
+}
+
+func TestIsTransient(t *testing.T) {
+	assert.True(t, IsTransient(ErrRateLimited))
+	assert.True(t, IsTransient(ErrServer))
+	assert.True(t, IsTransient(ErrNetwork))
+	assert.False(t, IsTransient(ErrUnauthorized))
+	assert.False(t, IsTransient(ErrTLS))
+	assert.False(t, IsTransient(errors.New("unclassified")))
+}
+
+func TestRetryAfter(t *testing.T) {
+	retryAfter, ok := RetryAfter(errors.WithStack(testRateLimitedError{retryAfter: time.Minute}))
+	assert.True(t, ok)
+	assert.Equal(t, time.Minute, retryAfter)
+
+	_, ok = RetryAfter(testRateLimitedError{})
+	assert.False(t, ok)
+
+	_, ok = RetryAfter(ErrRateLimited)
+	assert.False(t, ok)
+}
+
+func TestMissingScopesError(t *testing.T) {
+	err := MissingScopesError{Subject: "token 'apiToken'", Scopes: []string{"a", "b"}}
+
+	assert.EqualError(t, err, "token 'apiToken' is missing the following scopes: [ a, b ]")
+	assert.ErrorIs(t, err, ErrForbidden)
+	assert.NotErrorIs(t, err, ErrUnauthorized)
+}
+
+func TestFromTransport(t *testing.T) {
+	t.Run("nil stays nil", func(t *testing.T) {
+		assert.NoError(t, FromTransport(nil))
+	})
+	t.Run("connection errors are network errors", func(t *testing.T) {
+		urlErr := &url.Error{Op: "Get", URL: "https://test", Err: errors.New("connection refused")}
+
+		err := FromTransport(urlErr)
+
+		assert.ErrorIs(t, err, ErrNetwork)
+		assert.NotErrorIs(t, err, ErrTLS)
+		assert.EqualError(t, err, urlErr.Error())
+
+		var unwrapped *url.Error
+		assert.ErrorAs(t, err, &unwrapped)
+	})
+	t.Run("certificate errors are tls errors", func(t *testing.T) {
+		err := FromTransport(&url.Error{Op: "Get", URL: "https://test", Err: x509.UnknownAuthorityError{}})
+
+		assert.ErrorIs(t, err, ErrTLS)
+		assert.NotErrorIs(t, err, ErrNetwork)
+	})
+	t.Run("canceled requests are not classified", func(t *testing.T) {
+		canceledErr := &url.Error{Op: "Get", URL: "https://test", Err: context.Canceled}
+
+		err := FromTransport(canceledErr)
+
+		assert.Equal(t, canceledErr, err)
+		assert.Nil(t, Class(err))
+	})
+	t.Run("other errors are not classified", func(t *testing.T) {
+		assert.Nil(t, Class(FromTransport(errors.New("unclassified"))))
+	})
+	t.Run("oauth errors are classified by error code or status code", func(t *testing.T) {
+		badRequest := &http.Response{StatusCode: http.StatusBadRequest}
+
+		assert.ErrorIs(t, FromTransport(&oauth2.RetrieveError{Response: badRequest, ErrorCode: "invalid_client"}), ErrUnauthorized)
+		assert.ErrorIs(t, FromTransport(&oauth2.RetrieveError{Response: badRequest, ErrorCode: "invalid_scope"}), ErrForbidden)
+		assert.ErrorIs(t, FromTransport(&oauth2.RetrieveError{Response: &http.Response{StatusCode: http.StatusServiceUnavailable}}), ErrServer)
+		assert.Nil(t, Class(FromTransport(&oauth2.RetrieveError{Response: badRequest, ErrorCode: "invalid_request"})))
+	})
+}
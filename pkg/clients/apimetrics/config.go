@@ -0,0 +1,48 @@
+package apimetrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	otelName = "dynatrace-operator-api-client"
+
+	otelRequestsName = "apiRequests"
+	otelErrorsName   = "apiRequestErrors"
+)
+
+var (
+	labels = []string{"client", "endpoint", "method", "status_code", "owner"}
+
+	requestsMetric = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "dynatrace",
+		Subsystem: "api_client",
+		Name:      "requests_total",
+		Help:      "Number of requests sent to the Dynatrace or EdgeConnect API, owner is the DynaKube or EdgeConnect the request was sent for",
+	}, labels)
+
+	errorsMetric = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "dynatrace",
+		Subsystem: "api_client",
+		Name:      "errors_total",
+		Help:      "Number of requests to the Dynatrace or EdgeConnect API which failed with a connection error or an error status code",
+	}, labels)
+
+	durationMetric = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "dynatrace",
+		Subsystem: "api_client",
+		Name:      "request_duration_seconds",
+		Help:      "Time until the response headers of a request to the Dynatrace or EdgeConnect API were received",
+		Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60},
+	}, []string{"client", "endpoint", "method", "owner"})
+
+	otelMeter = otel.Meter(otelName)
+)
+
+func init() {
+	metrics.Registry.MustRegister(requestsMetric)
+	metrics.Registry.MustRegister(errorsMetric)
+	metrics.Registry.MustRegister(durationMetric)
+}
@@ -0,0 +1,119 @@
+package apimetrics
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	dtotel "github.com/Dynatrace/dynatrace-operator/pkg/util/otel"
+)
+
+const (
+	// OtherEndpoint is used as endpoint label for requests which don't match any of the known endpoints
+	OtherEndpoint = "other"
+
+	statusConnectionError = "error"
+	segmentSeparator      = "/"
+)
+
+type roundTripper struct {
+	next      http.RoundTripper
+	client    string
+	owner     string
+	baseHost  string
+	basePath  string
+	endpoints [][]string
+}
+
+// NewRoundTripper records the count, latency and errors of all requests sent via next.
+// The requests are labelled by the endpoint template they match, so the labels don't contain ids, versions or query parameters.
+// Endpoint templates are paths relative to baseURL, segments in braces match any value, e.g. "/v1/deployment/installer/agent/{os}/{installerType}/latest".
+// client names the API (e.g. dynatrace), owner is the name of the DynaKube or EdgeConnect the requests are sent for.
+func NewRoundTripper(next http.RoundTripper, client, owner, baseURL string, endpoints ...string) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	rt := roundTripper{
+		next:      next,
+		client:    client,
+		owner:     owner,
+		endpoints: make([][]string, 0, len(endpoints)),
+	}
+
+	if parsedURL, err := url.Parse(baseURL); err == nil {
+		rt.baseHost = parsedURL.Host
+		rt.basePath = strings.TrimSuffix(parsedURL.Path, segmentSeparator)
+	}
+
+	for _, endpoint := range endpoints {
+		rt.endpoints = append(rt.endpoints, strings.Split(strings.Trim(endpoint, segmentSeparator), segmentSeparator))
+	}
+
+	return rt
+}
+
+func (rt roundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	start := time.Now()
+	response, err := rt.next.RoundTrip(request)
+	duration := time.Since(start)
+
+	endpoint := rt.endpoint(request.URL)
+	status := statusConnectionError
+	failed := err != nil
+	if response != nil {
+		status = strconv.Itoa(response.StatusCode)
+		failed = failed || response.StatusCode >= http.StatusBadRequest
+	}
+
+	requestsMetric.WithLabelValues(rt.client, endpoint, request.Method, status, rt.owner).Inc()
+	durationMetric.WithLabelValues(rt.client, endpoint, request.Method, rt.owner).Observe(duration.Seconds())
+	dtotel.Count(request.Context(), otelMeter, otelRequestsName, int64(1),
+		"client", rt.client, "endpoint", endpoint, "method", request.Method, "statusCode", status, "owner", rt.owner)
+
+	if failed {
+		errorsMetric.WithLabelValues(rt.client, endpoint, request.Method, status, rt.owner).Inc()
+		dtotel.Count(request.Context(), otelMeter, otelErrorsName, int64(1),
+			"client", rt.client, "endpoint", endpoint, "method", request.Method, "statusCode", status, "owner", rt.owner)
+	}
+
+	return response, err
+}
+
+// endpoint returns the template of the first endpoint matching the url, or OtherEndpoint
+func (rt roundTripper) endpoint(requestURL *url.URL) string {
+	if requestURL == nil || (rt.baseHost != "" && requestURL.Host != rt.baseHost) {
+		return OtherEndpoint
+	}
+
+	path, ok := strings.CutPrefix(requestURL.Path, rt.basePath)
+	if !ok {
+		return OtherEndpoint
+	}
+
+	segments := strings.Split(strings.Trim(path, segmentSeparator), segmentSeparator)
+	for _, endpoint := range rt.endpoints {
+		if matches(endpoint, segments) {
+			return segmentSeparator + strings.Join(endpoint, segmentSeparator)
+		}
+	}
+
+	return OtherEndpoint
+}
+
+func matches(endpoint, segments []string) bool {
+	if len(endpoint) != len(segments) {
+		return false
+	}
+
+	for i, segment := range endpoint {
+		isPlaceholder := strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}")
+		if !isPlaceholder && segment != segments[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,101 @@
+package apimetrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testClient = "test-client"
+	testOwner  = "test-dynakube"
+)
+
+var testEndpoints = []string{
+	"/v1/deployment/installer/agent/{os}/{installerType}/latest",
+	"/v1/deployment/installer/agent/versions/{os}/{installerType}",
+	"/v1/events",
+}
+
+func TestRoundTripper(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.URL.Path == "/api/v1/events" {
+			writer.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		writer.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	httpClient := &http.Client{
+		Transport: NewRoundTripper(server.Client().Transport, testClient, testOwner, server.URL+"/api", testEndpoints...),
+	}
+
+	t.Run("requests are counted per endpoint template", func(t *testing.T) {
+		requestsMetric.Reset()
+		errorsMetric.Reset()
+		durationMetric.Reset()
+
+		for _, os := range []string{"unix", "windows"} {
+			response, err := httpClient.Get(server.URL + "/api/v1/deployment/installer/agent/" + os + "/default/latest?flavor=default")
+			require.NoError(t, err)
+			_ = response.Body.Close()
+		}
+
+		endpoint := "/v1/deployment/installer/agent/{os}/{installerType}/latest"
+		assert.Equal(t, 1, testutil.CollectAndCount(requestsMetric))
+		assert.Equal(t, float64(2), testutil.ToFloat64(requestsMetric.WithLabelValues(testClient, endpoint, http.MethodGet, "200", testOwner)))
+		assert.Equal(t, 1, testutil.CollectAndCount(durationMetric))
+		assert.Equal(t, 0, testutil.CollectAndCount(errorsMetric))
+	})
+	t.Run("error status codes are counted as errors", func(t *testing.T) {
+		requestsMetric.Reset()
+		errorsMetric.Reset()
+
+		response, err := httpClient.Post(server.URL+"/api/v1/events", "application/json", nil)
+		require.NoError(t, err)
+		_ = response.Body.Close()
+
+		assert.Equal(t, float64(1), testutil.ToFloat64(requestsMetric.WithLabelValues(testClient, "/v1/events", http.MethodPost, "400", testOwner)))
+		assert.Equal(t, float64(1), testutil.ToFloat64(errorsMetric.WithLabelValues(testClient, "/v1/events", http.MethodPost, "400", testOwner)))
+	})
+	t.Run("connection errors are counted as errors", func(t *testing.T) {
+		requestsMetric.Reset()
+		errorsMetric.Reset()
+
+		closedServer := httptest.NewServer(http.NotFoundHandler())
+		closedServer.Close()
+		failingClient := &http.Client{
+			Transport: NewRoundTripper(nil, testClient, testOwner, closedServer.URL),
+		}
+
+		_, err := failingClient.Get(closedServer.URL + "/v1/events")
+		require.Error(t, err)
+
+		assert.Equal(t, float64(1), testutil.ToFloat64(errorsMetric.WithLabelValues(testClient, OtherEndpoint, http.MethodGet, statusConnectionError, testOwner)))
+	})
+}
+
+func TestEndpoint(t *testing.T) {
+	rt := NewRoundTripper(nil, testClient, testOwner, "https://test.live.dynatrace.com/e/env/api/", testEndpoints...).(roundTripper)
+
+	parse := func(rawURL string) *url.URL {
+		parsedURL, err := url.Parse(rawURL)
+		require.NoError(t, err)
+		return parsedURL
+	}
+
+	assert.Equal(t, "/v1/deployment/installer/agent/versions/{os}/{installerType}",
+		rt.endpoint(parse("https://test.live.dynatrace.com/e/env/api/v1/deployment/installer/agent/versions/unix/paas?arch=x86")))
+	assert.Equal(t, "/v1/deployment/installer/agent/{os}/{installerType}/latest",
+		rt.endpoint(parse("https://test.live.dynatrace.com/e/env/api/v1/deployment/installer/agent/unix/paas/latest")))
+	assert.Equal(t, OtherEndpoint, rt.endpoint(parse("https://test.live.dynatrace.com/e/env/api/v1/unknown")))
+	assert.Equal(t, OtherEndpoint, rt.endpoint(parse("https://sso.dynatrace.com/sso/oauth2/token")))
+	assert.Equal(t, OtherEndpoint, rt.endpoint(parse("https://test.live.dynatrace.com/other/v1/events")))
+	assert.Equal(t, OtherEndpoint, rt.endpoint(nil))
+}
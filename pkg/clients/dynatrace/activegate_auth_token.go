@@ -2,6 +2,7 @@ package dynatrace
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
 	"time"
@@ -27,13 +28,13 @@ type ActiveGateAuthTokenParams struct {
 	ExpirationDate string `json:"expirationDate"`
 }
 
-func (dtc *dynatraceClient) GetActiveGateAuthToken(dynakubeName string) (*ActiveGateAuthTokenInfo, error) {
-	request, err := dtc.createAuthTokenRequest(dynakubeName)
+func (dtc *dynatraceClient) GetActiveGateAuthToken(ctx context.Context, dynakubeName string) (*ActiveGateAuthTokenInfo, error) {
+	request, err := dtc.createAuthTokenRequest(ctx, dynakubeName)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
 
-	response, err := dtc.httpClient.Do(request)
+	response, err := dtc.doRequest(request)
 	defer utils.CloseBodyAfterRequest(response)
 
 	if err != nil {
@@ -50,7 +51,7 @@ func (dtc *dynatraceClient) GetActiveGateAuthToken(dynakubeName string) (*Active
 	return authTokenInfo, nil
 }
 
-func (dtc *dynatraceClient) createAuthTokenRequest(dynakubeName string) (*http.Request, error) {
+func (dtc *dynatraceClient) createAuthTokenRequest(ctx context.Context, dynakubeName string) (*http.Request, error) {
 	body := &ActiveGateAuthTokenParams{
 		Name:           dynakubeName,
 		SeedToken:      false,
@@ -62,7 +63,7 @@ func (dtc *dynatraceClient) createAuthTokenRequest(dynakubeName string) (*http.R
 		return nil, err
 	}
 
-	request, err := createBaseRequest(
+	request, err := dtc.createBaseRequest(ctx,
 		dtc.getActiveGateAuthTokenUrl(),
 		http.MethodPost,
 		dtc.apiToken,
@@ -77,7 +78,7 @@ func (dtc *dynatraceClient) createAuthTokenRequest(dynakubeName string) (*http.R
 func (dtc *dynatraceClient) handleAuthTokenResponse(response *http.Response) (*ActiveGateAuthTokenInfo, error) {
 	data, err := dtc.getServerResponseData(response)
 	if err != nil {
-		return nil, dtc.handleErrorResponseFromAPI(data, response.StatusCode)
+		return nil, err
 	}
 
 	authTokenInfo, err := dtc.readResponseForActiveGateAuthToken(data)
@@ -1,6 +1,8 @@
 package dynatrace
 
 import (
+	"context"
+
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -21,7 +23,7 @@ func TestGetActiveGateAuthTokenInfo(t *testing.T) {
 		dynatraceServer, dynatraceClient := createTestDynatraceServer(t, connectionInfoServerHandler(activeGateAuthTokenUrl, activeGateAuthTokenResponse), "")
 		defer dynatraceServer.Close()
 
-		agAuthTokenInfo, err := dynatraceClient.GetActiveGateAuthToken(dynakubeName)
+		agAuthTokenInfo, err := dynatraceClient.GetActiveGateAuthToken(context.Background(), dynakubeName)
 		assert.NoError(t, err)
 		assert.NotNil(t, agAuthTokenInfo)
 
@@ -31,7 +33,7 @@ func TestGetActiveGateAuthTokenInfo(t *testing.T) {
 		faultyDynatraceServer, faultyDynatraceClient := createTestDynatraceServer(t, tenantMalformedJson(activeGateAuthTokenUrl), "")
 		defer faultyDynatraceServer.Close()
 
-		tenantInfo, err := faultyDynatraceClient.GetActiveGateAuthToken(dynakubeName)
+		tenantInfo, err := faultyDynatraceClient.GetActiveGateAuthToken(context.Background(), dynakubeName)
 		assert.Error(t, err)
 		assert.Nil(t, tenantInfo)
 
@@ -41,7 +43,7 @@ func TestGetActiveGateAuthTokenInfo(t *testing.T) {
 		faultyDynatraceServer, faultyDynatraceClient := createTestDynatraceServer(t, tenantInternalServerError(activeGateAuthTokenUrl), "")
 		defer faultyDynatraceServer.Close()
 
-		tenantInfo, err := faultyDynatraceClient.GetActiveGateAuthToken(dynakubeName)
+		tenantInfo, err := faultyDynatraceClient.GetActiveGateAuthToken(context.Background(), dynakubeName)
 		assert.Error(t, err)
 		assert.Nil(t, tenantInfo)
 
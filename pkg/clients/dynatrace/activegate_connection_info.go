@@ -1,6 +1,7 @@
 package dynatrace
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/clients/utils"
@@ -23,8 +24,8 @@ type activeGateConnectionInfoJsonResponse struct {
 	CommunicationEndpoints string `json:"communicationEndpoints"`
 }
 
-func (dtc *dynatraceClient) GetActiveGateConnectionInfo() (ActiveGateConnectionInfo, error) {
-	response, err := dtc.makeRequest(
+func (dtc *dynatraceClient) GetActiveGateConnectionInfo(ctx context.Context) (ActiveGateConnectionInfo, error) {
+	response, err := dtc.makeRequest(ctx,
 		dtc.getActiveGateConnectionInfoUrl(),
 		dynatracePaaSToken,
 	)
@@ -36,7 +37,7 @@ func (dtc *dynatraceClient) GetActiveGateConnectionInfo() (ActiveGateConnectionI
 
 	data, err := dtc.getServerResponseData(response)
 	if err != nil {
-		return ActiveGateConnectionInfo{}, dtc.handleErrorResponseFromAPI(data, response.StatusCode)
+		return ActiveGateConnectionInfo{}, err
 	}
 
 	tenantInfo, err := dtc.readResponseForActiveGateTenantInfo(data)
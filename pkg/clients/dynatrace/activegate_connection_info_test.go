@@ -1,6 +1,8 @@
 package dynatrace
 
 import (
+	"context"
+
 	"encoding/json"
 	"net/http"
 	"testing"
@@ -33,7 +35,7 @@ func Test_GetActiveGateConnectionInfo(t *testing.T) {
 		dynatraceServer, dynatraceClient := createTestDynatraceServer(t, connectionInfoServerHandler(activeGateConnectionInfoEndpoint, activegateJsonResponse), "")
 		defer dynatraceServer.Close()
 
-		connectionInfo, err := dynatraceClient.GetActiveGateConnectionInfo()
+		connectionInfo, err := dynatraceClient.GetActiveGateConnectionInfo(context.Background())
 		assert.NoError(t, err)
 		assert.NotNil(t, connectionInfo)
 
@@ -43,7 +45,7 @@ func Test_GetActiveGateConnectionInfo(t *testing.T) {
 		dynatraceServer, dynatraceClient := createTestDynatraceServer(t, connectionInfoServerHandler(activeGateConnectionInfoEndpoint, activegateJsonResponse), "nz")
 		defer dynatraceServer.Close()
 
-		connectionInfo, err := dynatraceClient.GetActiveGateConnectionInfo()
+		connectionInfo, err := dynatraceClient.GetActiveGateConnectionInfo(context.Background())
 		assert.NoError(t, err)
 		assert.NotNil(t, connectionInfo)
 
@@ -53,7 +55,7 @@ func Test_GetActiveGateConnectionInfo(t *testing.T) {
 		dynatraceServer, dynatraceClient := createTestDynatraceServer(t, connectionInfoServerHandler(activeGateConnectionInfoEndpoint, activegateJsonResponse), "")
 		defer dynatraceServer.Close()
 
-		connectionInfo, err := dynatraceClient.GetActiveGateConnectionInfo()
+		connectionInfo, err := dynatraceClient.GetActiveGateConnectionInfo(context.Background())
 		assert.NoError(t, err)
 		assert.NotNil(t, connectionInfo)
 
@@ -63,7 +65,7 @@ func Test_GetActiveGateConnectionInfo(t *testing.T) {
 		faultyDynatraceServer, faultyDynatraceClient := createTestDynatraceServer(t, tenantMalformedJson(activeGateConnectionInfoEndpoint), "")
 		defer faultyDynatraceServer.Close()
 
-		connectionInfo, err := faultyDynatraceClient.GetActiveGateConnectionInfo()
+		connectionInfo, err := faultyDynatraceClient.GetActiveGateConnectionInfo(context.Background())
 		assert.Error(t, err)
 		assert.Equal(t, "invalid character 'h' in literal true (expecting 'r')", err.Error())
 
@@ -74,7 +76,7 @@ func Test_GetActiveGateConnectionInfo(t *testing.T) {
 		faultyDynatraceServer, faultyDynatraceClient := createTestDynatraceServer(t, tenantInternalServerError(activeGateConnectionInfoEndpoint), "")
 		defer faultyDynatraceServer.Close()
 
-		connectionInfo, err := faultyDynatraceClient.GetActiveGateConnectionInfo()
+		connectionInfo, err := faultyDynatraceClient.GetActiveGateConnectionInfo(context.Background())
 		assert.Error(t, err)
 		assert.NotNil(t, connectionInfo)
 		assert.Equal(t, ActiveGateConnectionInfo{}, connectionInfo)
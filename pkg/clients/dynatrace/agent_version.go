@@ -1,18 +1,19 @@
 package dynatrace
 
 import (
+	"context"
 	"io"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/arch"
 	"github.com/pkg/errors"
 )
 
-func (dtc *dynatraceClient) GetEntityIDForIP(ip string) (string, error) {
+func (dtc *dynatraceClient) GetEntityIDForIP(ctx context.Context, ip string) (string, error) {
 	if len(ip) == 0 {
 		return "", errors.New("ip is invalid")
 	}
 
-	hostInfo, err := dtc.getHostInfoForIP(ip)
+	hostInfo, err := dtc.getHostInfoForIP(ctx, ip)
 	if err != nil {
 		return "", err
 	}
@@ -24,13 +25,21 @@ func (dtc *dynatraceClient) GetEntityIDForIP(ip string) (string, error) {
 }
 
 // GetLatestAgent gets the latest agent package for the given OS and installer type.
-func (dtc *dynatraceClient) GetLatestAgent(os, installerType, flavor, arch string, technologies []string, skipMetadata bool, writer io.Writer) error {
+func (dtc *dynatraceClient) GetLatestAgent(ctx context.Context, os, installerType, flavor, arch string, technologies []string, skipMetadata bool, writer io.Writer) error {
 	if len(os) == 0 || len(installerType) == 0 {
 		return errors.New("os or installerType is empty")
 	}
 
+	if dtc.usesMirror() {
+		version, err := dtc.getLatestAgentVersionFromMirror(ctx, os, installerType, flavor, arch)
+		if err != nil {
+			return err
+		}
+		return dtc.getAgentFromMirror(ctx, os, installerType, flavor, arch, version, writer)
+	}
+
 	url := dtc.getLatestAgentUrl(os, installerType, flavor, arch, technologies, skipMetadata)
-	md5, err := dtc.makeRequestForBinary(url, dynatracePaaSToken, writer)
+	md5, err := dtc.makeRequestForBinary(ctx, url, dynatracePaaSToken, writer)
 	if err == nil {
 		log.Info("downloaded agent file", "os", os, "type", installerType, "flavor", flavor, "arch", arch, "technologies", technologies, "md5", md5)
 	}
@@ -38,7 +47,7 @@ func (dtc *dynatraceClient) GetLatestAgent(os, installerType, flavor, arch strin
 }
 
 // GetLatestAgentVersion gets the latest agent version for the given OS and installer type configured on the Tenant.
-func (dtc *dynatraceClient) GetLatestAgentVersion(os, installerType string) (string, error) {
+func (dtc *dynatraceClient) GetLatestAgentVersion(ctx context.Context, os, installerType string) (string, error) {
 	response := struct {
 		LatestAgentVersion string `json:"latestAgentVersion"`
 	}{}
@@ -53,16 +62,20 @@ func (dtc *dynatraceClient) GetLatestAgentVersion(os, installerType string) (str
 	if installerType == InstallerTypeDefault {
 		flavor = arch.FlavorDefault
 	} else {
-		flavor = arch.Flavor
+		flavor = arch.DefaultFlavor(os, arch.Arch)
+	}
+
+	if dtc.usesMirror() {
+		return dtc.getLatestAgentVersionFromMirror(ctx, os, installerType, flavor, arch.Arch)
 	}
 
 	url := dtc.getLatestAgentVersionUrl(os, installerType, flavor, arch.Arch)
-	err := dtc.makeRequestAndUnmarshal(url, dynatracePaaSToken, &response)
+	err := dtc.makeRequestAndUnmarshal(ctx, url, dynatracePaaSToken, &response)
 	return response.LatestAgentVersion, errors.WithStack(err)
 }
 
 // GetAgentVersions gets available agent versions for the given OS and installer type.
-func (dtc *dynatraceClient) GetAgentVersions(os, installerType, flavor, arch string) ([]string, error) {
+func (dtc *dynatraceClient) GetAgentVersions(ctx context.Context, os, installerType, flavor, arch string) ([]string, error) {
 	response := struct {
 		AvailableVersions []string `json:"availableVersions"`
 	}{}
@@ -71,26 +84,35 @@ func (dtc *dynatraceClient) GetAgentVersions(os, installerType, flavor, arch str
 		return nil, errors.New("os or installerType is empty")
 	}
 
+	if dtc.usesMirror() {
+		versions, err := dtc.getAgentVersionsFromMirror(ctx, os, installerType, flavor, arch)
+		return versions.AvailableVersions, err
+	}
+
 	url := dtc.getAgentVersionsUrl(os, installerType, flavor, arch)
-	err := dtc.makeRequestAndUnmarshal(url, dynatracePaaSToken, &response)
+	err := dtc.makeRequestAndUnmarshal(ctx, url, dynatracePaaSToken, &response)
 	return response.AvailableVersions, errors.WithStack(err)
 }
 
-func (dtc *dynatraceClient) GetAgent(os, installerType, flavor, arch, version string, technologies []string, skipMetadata bool, writer io.Writer) error {
+func (dtc *dynatraceClient) GetAgent(ctx context.Context, os, installerType, flavor, arch, version string, technologies []string, skipMetadata bool, writer io.Writer) error {
 	if len(os) == 0 || len(installerType) == 0 {
 		return errors.New("os or installerType is empty")
 	}
 
+	if dtc.usesMirror() {
+		return dtc.getAgentFromMirror(ctx, os, installerType, flavor, arch, version, writer)
+	}
+
 	url := dtc.getAgentUrl(os, installerType, flavor, arch, version, technologies, skipMetadata)
-	md5, err := dtc.makeRequestForBinary(url, dynatracePaaSToken, writer)
+	md5, err := dtc.makeRequestForBinary(ctx, url, dynatracePaaSToken, writer)
 	if err == nil {
 		log.Info("downloaded agent file", "os", os, "type", installerType, "flavor", flavor, "arch", arch, "technologies", technologies, "md5", md5)
 	}
 	return err
 }
 
-func (dtc *dynatraceClient) GetAgentViaInstallerUrl(url string, writer io.Writer) error {
-	md5, err := dtc.makeRequestForBinary(url, installerUrlToken, writer)
+func (dtc *dynatraceClient) GetAgentViaInstallerUrl(ctx context.Context, url string, writer io.Writer) error {
+	md5, err := dtc.makeRequestForBinary(ctx, url, installerUrlToken, writer)
 	if err == nil {
 		log.Info("downloaded agent file using given url", "url", url, "md5", md5)
 	}
@@ -1,6 +1,8 @@
 package dynatrace
 
 import (
+	"context"
+
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -83,12 +85,12 @@ func TestGetEntityIDForIP(t *testing.T) {
 		}
 	}
 ]`, time.Now().UTC().Unix()*1000))))
-	id, err := dtc.GetEntityIDForIP("1.1.1.1")
+	id, err := dtc.GetEntityIDForIP(context.Background(), "1.1.1.1")
 	assert.NoError(t, err)
 	assert.NotEmpty(t, id)
 	assert.Equal(t, "HOST-42", id)
 
-	id, err = dtc.GetEntityIDForIP("2.2.2.2")
+	id, err = dtc.GetEntityIDForIP(context.Background(), "2.2.2.2")
 
 	assert.Error(t, err)
 	assert.Empty(t, id)
@@ -115,7 +117,7 @@ func TestGetEntityIDForIP(t *testing.T) {
 	}
 ]`, time.Now().UTC().Unix()*1000))))
 
-	id, err = dtc.GetEntityIDForIP("1.1.1.1")
+	id, err = dtc.GetEntityIDForIP(context.Background(), "1.1.1.1")
 
 	assert.Error(t, err)
 	assert.Empty(t, id)
@@ -123,17 +125,17 @@ func TestGetEntityIDForIP(t *testing.T) {
 
 func testAgentVersionGetLatestAgentVersion(t *testing.T, dynatraceClient Client) {
 	{
-		_, err := dynatraceClient.GetLatestAgentVersion("", InstallerTypeDefault)
+		_, err := dynatraceClient.GetLatestAgentVersion(context.Background(), "", InstallerTypeDefault)
 
 		assert.Error(t, err, "empty OS")
 	}
 	{
-		_, err := dynatraceClient.GetLatestAgentVersion(OsUnix, "")
+		_, err := dynatraceClient.GetLatestAgentVersion(context.Background(), OsUnix, "")
 
 		assert.Error(t, err, "empty installer type")
 	}
 	{
-		latestAgentVersion, err := dynatraceClient.GetLatestAgentVersion(OsUnix, InstallerTypePaaS)
+		latestAgentVersion, err := dynatraceClient.GetLatestAgentVersion(context.Background(), OsUnix, InstallerTypePaaS)
 
 		assert.NoError(t, err)
 		assert.Equal(t, "1.242.0.20220429-180918", latestAgentVersion, "latest agent version equals expected version")
@@ -157,7 +159,7 @@ func TestGetLatestAgent(t *testing.T) {
 		file, err := afero.TempFile(fs, "client", "installer")
 		require.NoError(t, err)
 
-		err = dtc.GetLatestAgent(OsUnix, InstallerTypePaaS, arch.FlavorMultidistro, "arch", nil, false, file)
+		err = dtc.GetLatestAgent(context.Background(), OsUnix, InstallerTypePaaS, arch.FlavorMultidistro, "arch", nil, false, file)
 		require.NoError(t, err)
 
 		resp, err := afero.ReadFile(fs, file.Name())
@@ -169,7 +171,7 @@ func TestGetLatestAgent(t *testing.T) {
 		file, err := afero.TempFile(fs, "client", "installer")
 		require.NoError(t, err)
 
-		err = dtc.GetLatestAgent(OsUnix, InstallerTypePaaS, arch.FlavorMultidistro, "invalid", nil, false, file)
+		err = dtc.GetLatestAgent(context.Background(), OsUnix, InstallerTypePaaS, arch.FlavorMultidistro, "invalid", nil, false, file)
 		require.Error(t, err)
 	})
 }
@@ -180,7 +182,7 @@ func TestDynatraceClient_GetAgent(t *testing.T) {
 		defer dynatraceServer.Close()
 
 		readWriter := &memoryReadWriter{data: make([]byte, len(versionedAgentResponse))}
-		err := dtc.GetAgent(OsUnix, InstallerTypePaaS, "", "", "", nil, false, readWriter)
+		err := dtc.GetAgent(context.Background(), OsUnix, InstallerTypePaaS, "", "", "", nil, false, readWriter)
 
 		assert.NoError(t, err)
 		assert.Equal(t, versionedAgentResponse, string(readWriter.data))
@@ -190,7 +192,7 @@ func TestDynatraceClient_GetAgent(t *testing.T) {
 		defer dynatraceServer.Close()
 
 		readWriter := &memoryReadWriter{data: make([]byte, len(versionedAgentResponse))}
-		err := dtc.GetAgent(OsUnix, InstallerTypePaaS, "", "", "", nil, false, readWriter)
+		err := dtc.GetAgent(context.Background(), OsUnix, InstallerTypePaaS, "", "", "", nil, false, readWriter)
 
 		assert.EqualError(t, err, "dynatrace server error 400: test-error")
 	})
@@ -201,7 +203,7 @@ func TestDynatraceClient_GetAgentVersions(t *testing.T) {
 		dynatraceServer, dtc := createTestDynatraceClientWithFunc(t, versionsRequestHandler)
 		defer dynatraceServer.Close()
 
-		availableVersions, err := dtc.GetAgentVersions(OsUnix, InstallerTypePaaS, "", "")
+		availableVersions, err := dtc.GetAgentVersions(context.Background(), OsUnix, InstallerTypePaaS, "", "")
 
 		assert.NoError(t, err)
 		assert.Equal(t, 4, len(availableVersions))
@@ -214,7 +216,7 @@ func TestDynatraceClient_GetAgentVersions(t *testing.T) {
 		dynatraceServer, dtc := createTestDynatraceClientWithFunc(t, errorHandler)
 		defer dynatraceServer.Close()
 
-		availableVersions, err := dtc.GetAgentVersions(OsUnix, InstallerTypePaaS, "", "")
+		availableVersions, err := dtc.GetAgentVersions(context.Background(), OsUnix, InstallerTypePaaS, "", "")
 
 		assert.EqualError(t, err, "dynatrace server error 400: test-error")
 		assert.Equal(t, 0, len(availableVersions))
@@ -0,0 +1,138 @@
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// DefaultTTL is the longest time a response is served from the cache
+	DefaultTTL = 5 * time.Minute
+
+	// staleRetention is how long an expired response is kept, so it can be used to only request changes
+	staleRetention = time.Hour
+
+	// fetchTimeout limits a shared fetch, it's longer than a request with the default retries takes
+	fetchTimeout = 10 * time.Minute
+)
+
+var shared = New()
+
+// Shared returns the cache used by all Dynatrace clients of the current process
+func Shared() *Cache {
+	return shared
+}
+
+// Cache stores responses of the Dynatrace API, so different controllers don't request the same data over and over again.
+// Concurrent identical requests are merged into a single request.
+type Cache struct {
+	entries map[string]entry
+	group   singleflight.Group
+	mutex   sync.Mutex
+	now     func() time.Time
+}
+
+type entry struct {
+	value   any
+	expires time.Time
+}
+
+func New() *Cache {
+	return &Cache{
+		entries: map[string]entry{},
+		now:     time.Now,
+	}
+}
+
+// Identity derives a key from the values which determine the responses of a Dynatrace client, e.g. the url, the tokens and the network zone.
+// The values are hashed, so the key can be used in logs without leaking tokens.
+func Identity(values ...string) string {
+	hash := sha256.Sum256([]byte(strings.Join(values, "\x00")))
+	return hex.EncodeToString(hash[:])
+}
+
+func (cache *Cache) lookup(key string) (value any, fresh bool, found bool) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	cached, found := cache.entries[key]
+	if !found {
+		return nil, false, false
+	}
+	return cached.value, cache.now().Before(cached.expires), true
+}
+
+func (cache *Cache) store(key string, value any, ttl time.Duration) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	now := cache.now()
+	for otherKey, other := range cache.entries {
+		if now.After(other.expires.Add(staleRetention)) {
+			delete(cache.entries, otherKey)
+		}
+	}
+
+	if ttl > 0 {
+		cache.entries[key] = entry{value: value, expires: now.Add(ttl)}
+	}
+}
+
+// fetchFunc requests a value from the Dynatrace API.
+// If the cache holds an expired value, it's passed as stale, so the request can be limited to changes since then.
+type fetchFunc[T any] func(ctx context.Context, stale *T) (T, error)
+
+// get returns the cached value for the key, or fetches it if there is no fresh value.
+// Only one fetch per key runs at a time, concurrent callers wait for its result.
+// The fetch isn't canceled with the context of the caller which started it, as other callers may wait for it,
+// instead it's limited by the fetchTimeout. Every caller stops waiting when its own context is done.
+func get[T any](ctx context.Context, cache *Cache, method, key string, ttl time.Duration, fetch fetchFunc[T]) (T, error) {
+	var zero T
+
+	value, fresh, found := cache.lookup(key)
+	if fresh {
+		requestsMetric.WithLabelValues(method, resultHit).Inc()
+		return value.(T), nil
+	}
+
+	var stale *T
+	if found {
+		staleValue := value.(T)
+		stale = &staleValue
+	}
+
+	results := cache.group.DoChan(key, func() (any, error) {
+		fetchCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), fetchTimeout)
+		defer cancel()
+
+		fetched, err := fetch(fetchCtx, stale)
+		if err != nil {
+			return nil, err
+		}
+		cache.store(key, fetched, ttl)
+		return fetched, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		return zero, errors.WithStack(ctx.Err())
+	case result := <-results:
+		if result.Shared {
+			requestsMetric.WithLabelValues(method, resultShared).Inc()
+		} else {
+			requestsMetric.WithLabelValues(method, resultMiss).Inc()
+		}
+
+		if result.Err != nil {
+			return zero, result.Err
+		}
+		return result.Val.(T), nil
+	}
+}
@@ -0,0 +1,200 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testKey = "identity/method/"
+
+func TestGet(t *testing.T) {
+	t.Run("fresh values are served from the cache", func(t *testing.T) {
+		cache := New()
+		fetches := 0
+		fetch := func(_ context.Context, _ *string) (string, error) {
+			fetches++
+			return "value", nil
+		}
+
+		for i := 0; i < 3; i++ {
+			value, err := get(context.Background(), cache, "method", testKey, time.Minute, fetch)
+			require.NoError(t, err)
+			assert.Equal(t, "value", value)
+		}
+		assert.Equal(t, 1, fetches)
+	})
+	t.Run("expired values are fetched again and passed as stale value", func(t *testing.T) {
+		cache := New()
+		now := time.Now()
+		cache.now = func() time.Time { return now }
+
+		var staleValues []*string
+		fetch := func(_ context.Context, stale *string) (string, error) {
+			staleValues = append(staleValues, stale)
+			return "value", nil
+		}
+
+		_, err := get(context.Background(), cache, "method", testKey, time.Minute, fetch)
+		require.NoError(t, err)
+
+		now = now.Add(2 * time.Minute)
+		_, err = get(context.Background(), cache, "method", testKey, time.Minute, fetch)
+		require.NoError(t, err)
+
+		require.Len(t, staleValues, 2)
+		assert.Nil(t, staleValues[0])
+		require.NotNil(t, staleValues[1])
+		assert.Equal(t, "value", *staleValues[1])
+	})
+	t.Run("errors are not cached", func(t *testing.T) {
+		cache := New()
+		fetches := 0
+		fetch := func(_ context.Context, _ *string) (string, error) {
+			fetches++
+			return "", errors.New("failed")
+		}
+
+		_, err := get(context.Background(), cache, "method", testKey, time.Minute, fetch)
+		require.Error(t, err)
+		_, err = get(context.Background(), cache, "method", testKey, time.Minute, fetch)
+		require.Error(t, err)
+
+		assert.Equal(t, 2, fetches)
+	})
+	t.Run("nothing is stored without ttl", func(t *testing.T) {
+		cache := New()
+		fetches := 0
+		fetch := func(_ context.Context, _ *string) (string, error) {
+			fetches++
+			return "value", nil
+		}
+
+		_, err := get(context.Background(), cache, "method", testKey, 0, fetch)
+		require.NoError(t, err)
+		_, err = get(context.Background(), cache, "method", testKey, 0, fetch)
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, fetches)
+	})
+	t.Run("concurrent requests are merged", func(t *testing.T) {
+		cache := New()
+		fetches := 0
+		release := make(chan struct{})
+		fetch := func(_ context.Context, _ *string) (string, error) {
+			fetches++
+			<-release
+			return "value", nil
+		}
+
+		const callers = 5
+		var started, done sync.WaitGroup
+		started.Add(callers)
+		done.Add(callers)
+		values := make([]string, callers)
+		for i := 0; i < callers; i++ {
+			go func(i int) {
+				defer done.Done()
+				started.Done()
+				values[i], _ = get(context.Background(), cache, "method", testKey, 0, fetch)
+			}(i)
+		}
+
+		started.Wait()
+		time.Sleep(50 * time.Millisecond)
+		close(release)
+		done.Wait()
+
+		assert.Equal(t, 1, fetches)
+		for _, value := range values {
+			assert.Equal(t, "value", value)
+		}
+	})
+	t.Run("waiting stops when the context is done", func(t *testing.T) {
+		cache := New()
+		release := make(chan struct{})
+		defer close(release)
+		fetch := func(_ context.Context, _ *string) (string, error) {
+			<-release
+			return "value", nil
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := get(ctx, cache, "method", testKey, time.Minute, fetch)
+		require.ErrorIs(t, err, context.Canceled)
+	})
+	t.Run("fetch isn't canceled with the caller which started it", func(t *testing.T) {
+		cache := New()
+		release := make(chan struct{})
+		fetches := 0
+		fetch := func(ctx context.Context, _ *string) (string, error) {
+			fetches++
+			<-release
+			return "value", ctx.Err()
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		firstErr := make(chan error)
+
+		go func() {
+			_, err := get(ctx, cache, "method", testKey, time.Minute, fetch)
+			firstErr <- err
+		}()
+
+		time.Sleep(50 * time.Millisecond)
+
+		var value string
+		var err error
+		second := make(chan struct{})
+
+		go func() {
+			defer close(second)
+			value, err = get(context.Background(), cache, "method", testKey, time.Minute, fetch)
+		}()
+
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+		require.ErrorIs(t, <-firstErr, context.Canceled)
+		close(release)
+		<-second
+
+		require.NoError(t, err)
+		assert.Equal(t, "value", value)
+		assert.Equal(t, 1, fetches)
+	})
+}
+
+func TestStore(t *testing.T) {
+	cache := New()
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+
+	cache.store("expired", "value", time.Minute)
+	cache.store("stale", "value", time.Minute)
+
+	now = now.Add(time.Minute + staleRetention + time.Second)
+	cache.store("stale", "value", time.Minute)
+
+	_, _, found := cache.lookup("expired")
+	assert.False(t, found)
+
+	_, fresh, found := cache.lookup("stale")
+	assert.True(t, found)
+	assert.True(t, fresh)
+}
+
+func TestIdentity(t *testing.T) {
+	identity := Identity("https://test.live.dynatrace.com/api", "api-token")
+
+	assert.Equal(t, identity, Identity("https://test.live.dynatrace.com/api", "api-token"))
+	assert.NotEqual(t, identity, Identity("https://test.live.dynatrace.com/api", "other-token"))
+	assert.NotEqual(t, Identity("ab", "c"), Identity("a", "bc"))
+	assert.NotContains(t, identity, "api-token")
+}
@@ -0,0 +1,135 @@
+package cache
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
+)
+
+// client serves the read-only requests of the wrapped Dynatrace client from the cache.
+// Requests which change data in the tenant, download agents or verify tokens are passed through.
+type client struct {
+	dtclient.Client
+
+	cache    *Cache
+	identity string
+	ttl      time.Duration
+}
+
+var _ dtclient.Client = client{}
+
+// NewClient wraps the given Dynatrace client so its responses are shared via the cache with all other clients of the same identity.
+// With a ttl <= 0 responses aren't stored, but concurrent identical requests are still merged.
+func NewClient(dtc dtclient.Client, cache *Cache, identity string, ttl time.Duration) dtclient.Client {
+	return client{
+		Client:   dtc,
+		cache:    cache,
+		identity: identity,
+		ttl:      ttl,
+	}
+}
+
+func (c client) key(method string, args ...string) string {
+	return c.identity + "/" + method + "/" + strings.Join(args, "/")
+}
+
+func (c client) GetLatestAgentVersion(ctx context.Context, os, installerType string) (string, error) {
+	const method = "GetLatestAgentVersion"
+	return get(ctx, c.cache, method, c.key(method, os, installerType), c.ttl, func(ctx context.Context, _ *string) (string, error) {
+		return c.Client.GetLatestAgentVersion(ctx, os, installerType)
+	})
+}
+
+func (c client) GetAgentVersions(ctx context.Context, os, installerType, flavor, arch string) ([]string, error) {
+	const method = "GetAgentVersions"
+	versions, err := get(ctx, c.cache, method, c.key(method, os, installerType, flavor, arch), c.ttl, func(ctx context.Context, _ *[]string) ([]string, error) {
+		return c.Client.GetAgentVersions(ctx, os, installerType, flavor, arch)
+	})
+	return cloneSlice(versions), err
+}
+
+func (c client) GetOneAgentConnectionInfo(ctx context.Context) (dtclient.OneAgentConnectionInfo, error) {
+	const method = "GetOneAgentConnectionInfo"
+	connectionInfo, err := get(ctx, c.cache, method, c.key(method), c.ttl, func(ctx context.Context, _ *dtclient.OneAgentConnectionInfo) (dtclient.OneAgentConnectionInfo, error) {
+		return c.Client.GetOneAgentConnectionInfo(ctx)
+	})
+	connectionInfo.CommunicationHosts = cloneSlice(connectionInfo.CommunicationHosts)
+	return connectionInfo, err
+}
+
+func (c client) GetActiveGateConnectionInfo(ctx context.Context) (dtclient.ActiveGateConnectionInfo, error) {
+	const method = "GetActiveGateConnectionInfo"
+	return get(ctx, c.cache, method, c.key(method), c.ttl, func(ctx context.Context, _ *dtclient.ActiveGateConnectionInfo) (dtclient.ActiveGateConnectionInfo, error) {
+		return c.Client.GetActiveGateConnectionInfo(ctx)
+	})
+}
+
+// GetProcessModuleConfig caches the latest process module config independent of prevRevision.
+// When the cached config expires, only changes since its revision are requested.
+// As the API does, an empty config is returned if the latest revision is prevRevision.
+func (c client) GetProcessModuleConfig(ctx context.Context, prevRevision uint) (*dtclient.ProcessModuleConfig, error) {
+	const method = "GetProcessModuleConfig"
+	latest, err := get(ctx, c.cache, method, c.key(method), c.ttl, func(ctx context.Context, stale **dtclient.ProcessModuleConfig) (*dtclient.ProcessModuleConfig, error) {
+		if stale == nil || *stale == nil || (*stale).IsEmpty() {
+			return c.Client.GetProcessModuleConfig(ctx, 0)
+		}
+
+		changed, err := c.Client.GetProcessModuleConfig(ctx, (*stale).Revision)
+		if err != nil {
+			return nil, err
+		}
+		if changed == nil || changed.IsEmpty() {
+			return *stale, nil
+		}
+		return changed, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if latest == nil || (prevRevision != 0 && latest.Revision == prevRevision) {
+		return &dtclient.ProcessModuleConfig{}, nil
+	}
+
+	return &dtclient.ProcessModuleConfig{
+		Revision:   latest.Revision,
+		Properties: cloneSlice(latest.Properties),
+	}, nil
+}
+
+func (c client) GetLatestOneAgentImage(ctx context.Context) (*dtclient.LatestImageInfo, error) {
+	const method = "GetLatestOneAgentImage"
+	return getImage(ctx, c, method, c.Client.GetLatestOneAgentImage)
+}
+
+func (c client) GetLatestCodeModulesImage(ctx context.Context) (*dtclient.LatestImageInfo, error) {
+	const method = "GetLatestCodeModulesImage"
+	return getImage(ctx, c, method, c.Client.GetLatestCodeModulesImage)
+}
+
+func (c client) GetLatestActiveGateImage(ctx context.Context) (*dtclient.LatestImageInfo, error) {
+	const method = "GetLatestActiveGateImage"
+	return getImage(ctx, c, method, c.Client.GetLatestActiveGateImage)
+}
+
+func getImage(ctx context.Context, c client, method string, fetch func(ctx context.Context) (*dtclient.LatestImageInfo, error)) (*dtclient.LatestImageInfo, error) {
+	image, err := get(ctx, c.cache, method, c.key(method), c.ttl, func(ctx context.Context, _ **dtclient.LatestImageInfo) (*dtclient.LatestImageInfo, error) {
+		return fetch(ctx)
+	})
+	if err != nil || image == nil {
+		return image, err
+	}
+
+	imageCopy := *image
+	return &imageCopy, nil
+}
+
+// cloneSlice keeps callers from modifying the cached value
+func cloneSlice[T any](values []T) []T {
+	if values == nil {
+		return nil
+	}
+	return append(make([]T, 0, len(values)), values...)
+}
@@ -0,0 +1,138 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
+	dtclientmock "github.com/Dynatrace/dynatrace-operator/test/mocks/pkg/clients/dynatrace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testIdentity = "identity"
+	testTenant   = "abc123456"
+)
+
+func TestClient(t *testing.T) {
+	t.Run("clients of the same identity share responses", func(t *testing.T) {
+		cache := New()
+		mockClient := dtclientmock.NewClient(t)
+		mockClient.On("GetOneAgentConnectionInfo", mock.Anything).Return(dtclient.OneAgentConnectionInfo{
+			ConnectionInfo:     dtclient.ConnectionInfo{TenantUUID: testTenant},
+			CommunicationHosts: []dtclient.CommunicationHost{{Host: "host"}},
+		}, nil).Once()
+
+		first := NewClient(mockClient, cache, testIdentity, time.Minute)
+		second := NewClient(mockClient, cache, testIdentity, time.Minute)
+
+		connectionInfo, err := first.GetOneAgentConnectionInfo(context.Background())
+		require.NoError(t, err)
+		connectionInfo.CommunicationHosts[0].Host = "modified"
+
+		connectionInfo, err = second.GetOneAgentConnectionInfo(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, testTenant, connectionInfo.TenantUUID)
+		assert.Equal(t, "host", connectionInfo.CommunicationHosts[0].Host)
+	})
+	t.Run("clients of different identities don't share responses", func(t *testing.T) {
+		cache := New()
+		mockClient := dtclientmock.NewClient(t)
+		mockClient.On("GetLatestAgentVersion", mock.Anything, dtclient.OsUnix, dtclient.InstallerTypeDefault).Return("1.2.3", nil).Twice()
+
+		_, err := NewClient(mockClient, cache, "first", time.Minute).GetLatestAgentVersion(context.Background(), dtclient.OsUnix, dtclient.InstallerTypeDefault)
+		require.NoError(t, err)
+		_, err = NewClient(mockClient, cache, "second", time.Minute).GetLatestAgentVersion(context.Background(), dtclient.OsUnix, dtclient.InstallerTypeDefault)
+		require.NoError(t, err)
+	})
+	t.Run("arguments are part of the key", func(t *testing.T) {
+		cache := New()
+		mockClient := dtclientmock.NewClient(t)
+		mockClient.On("GetLatestAgentVersion", mock.Anything, dtclient.OsUnix, dtclient.InstallerTypeDefault).Return("1.2.3", nil).Once()
+		mockClient.On("GetLatestAgentVersion", mock.Anything, dtclient.OsUnix, dtclient.InstallerTypePaaS).Return("1.2.4", nil).Once()
+		dtc := NewClient(mockClient, cache, testIdentity, time.Minute)
+
+		version, err := dtc.GetLatestAgentVersion(context.Background(), dtclient.OsUnix, dtclient.InstallerTypeDefault)
+		require.NoError(t, err)
+		assert.Equal(t, "1.2.3", version)
+
+		version, err = dtc.GetLatestAgentVersion(context.Background(), dtclient.OsUnix, dtclient.InstallerTypePaaS)
+		require.NoError(t, err)
+		assert.Equal(t, "1.2.4", version)
+	})
+	t.Run("requests which change data are passed through", func(t *testing.T) {
+		cache := New()
+		mockClient := dtclientmock.NewClient(t)
+		mockClient.On("SendEvent", mock.Anything, mock.Anything).Return(nil).Twice()
+		dtc := NewClient(mockClient, cache, testIdentity, time.Minute)
+
+		require.NoError(t, dtc.SendEvent(context.Background(), &dtclient.EventData{}))
+		require.NoError(t, dtc.SendEvent(context.Background(), &dtclient.EventData{}))
+	})
+	t.Run("cached images can't be modified", func(t *testing.T) {
+		cache := New()
+		mockClient := dtclientmock.NewClient(t)
+		mockClient.On("GetLatestActiveGateImage", mock.Anything).Return(&dtclient.LatestImageInfo{Source: "source", Tag: "tag"}, nil).Once()
+		dtc := NewClient(mockClient, cache, testIdentity, time.Minute)
+
+		image, err := dtc.GetLatestActiveGateImage(context.Background())
+		require.NoError(t, err)
+		image.Tag = "modified"
+
+		image, err = dtc.GetLatestActiveGateImage(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "tag", image.Tag)
+	})
+}
+
+func TestClientProcessModuleConfig(t *testing.T) {
+	latest := &dtclient.ProcessModuleConfig{
+		Revision:   3,
+		Properties: []dtclient.ProcessModuleProperty{{Section: "general", Key: "key", Value: "value"}},
+	}
+
+	t.Run("latest config is shared independent of the previous revision", func(t *testing.T) {
+		cache := New()
+		mockClient := dtclientmock.NewClient(t)
+		mockClient.On("GetProcessModuleConfig", mock.Anything, uint(0)).Return(latest, nil).Once()
+		dtc := NewClient(mockClient, cache, testIdentity, time.Minute)
+
+		config, err := dtc.GetProcessModuleConfig(context.Background(), 0)
+		require.NoError(t, err)
+		assert.Equal(t, latest, config)
+		config.Add(dtclient.ProcessModuleProperty{Section: "general", Key: "other", Value: "value"})
+
+		config, err = dtc.GetProcessModuleConfig(context.Background(), 2)
+		require.NoError(t, err)
+		assert.Equal(t, latest, config)
+
+		config, err = dtc.GetProcessModuleConfig(context.Background(), 3)
+		require.NoError(t, err)
+		assert.True(t, config.IsEmpty(), "the config didn't change since the given revision")
+	})
+	t.Run("expired config is revalidated with its revision", func(t *testing.T) {
+		cache := New()
+		now := time.Now()
+		cache.now = func() time.Time { return now }
+
+		mockClient := dtclientmock.NewClient(t)
+		mockClient.On("GetProcessModuleConfig", mock.Anything, uint(0)).Return(latest, nil).Once()
+		mockClient.On("GetProcessModuleConfig", mock.Anything, uint(3)).Return(&dtclient.ProcessModuleConfig{}, nil).Once()
+		dtc := NewClient(mockClient, cache, testIdentity, time.Minute)
+
+		_, err := dtc.GetProcessModuleConfig(context.Background(), 0)
+		require.NoError(t, err)
+
+		now = now.Add(2 * time.Minute)
+		config, err := dtc.GetProcessModuleConfig(context.Background(), 0)
+		require.NoError(t, err)
+		assert.Equal(t, latest, config)
+
+		config, err = dtc.GetProcessModuleConfig(context.Background(), 0)
+		require.NoError(t, err)
+		assert.Equal(t, latest, config)
+	})
+}
@@ -0,0 +1,25 @@
+package cache
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	resultHit    = "hit"
+	resultMiss   = "miss"
+	resultShared = "shared"
+)
+
+var (
+	requestsMetric = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "dynatrace",
+		Subsystem: "api_cache",
+		Name:      "requests_total",
+		Help:      "Number of Dynatrace API calls served by the response cache, by method and result (hit, miss, shared)",
+	}, []string{"method", "result"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(requestsMetric)
+}
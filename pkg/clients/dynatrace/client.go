@@ -1,6 +1,7 @@
 package dynatrace
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"io"
@@ -9,6 +10,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Dynatrace/dynatrace-operator/pkg/arch"
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/apimetrics"
 	"github.com/pkg/errors"
 	"golang.org/x/net/http/httpproxy"
 )
@@ -17,6 +20,9 @@ const (
 	DynatracePaasToken       = "paasToken"
 	DynatraceApiToken        = "apiToken"
 	DynatraceDataIngestToken = "dataIngestToken"
+
+	DynatraceOAuthClientID     = "oauthClientId"
+	DynatraceOAuthClientSecret = "oauthClientSecret"
 )
 
 // Client is the interface for the Dynatrace REST API client.
@@ -29,72 +35,90 @@ type Client interface {
 	//  - IO error or unexpected response
 	//  - error response from the server (e.g. authentication failure)
 	//  - the agent version is not set or empty
-	GetLatestAgentVersion(os, installerType string) (string, error)
+	GetLatestAgentVersion(ctx context.Context, os, installerType string) (string, error)
 
 	// GetLatestAgent returns a reader with the contents of the download. Must be closed by caller.
-	GetLatestAgent(os, installerType, flavor, arch string, technologies []string, skipMetadata bool, writer io.Writer) error
+	GetLatestAgent(ctx context.Context, os, installerType, flavor, arch string, technologies []string, skipMetadata bool, writer io.Writer) error
 
 	// GetAgent downloads a specific agent version and writes it to the given io.Writer
-	GetAgent(os, installerType, flavor, arch, version string, technologies []string, skipMetadata bool, writer io.Writer) error
+	GetAgent(ctx context.Context, os, installerType, flavor, arch, version string, technologies []string, skipMetadata bool, writer io.Writer) error
 
 	// GetAgentViaInstallerUrl downloads the agent from the user specified URL and writes it to the given io.Writer
-	GetAgentViaInstallerUrl(url string, writer io.Writer) error
+	GetAgentViaInstallerUrl(ctx context.Context, url string, writer io.Writer) error
 
 	// GetAgentVersions on success returns an array of versions that can be used with GetAgent to
 	// download a specific agent version
-	GetAgentVersions(os, installerType, flavor, arch string) ([]string, error)
+	GetAgentVersions(ctx context.Context, os, installerType, flavor, arch string) ([]string, error)
 
-	GetOneAgentConnectionInfo() (OneAgentConnectionInfo, error)
+	GetOneAgentConnectionInfo(ctx context.Context) (OneAgentConnectionInfo, error)
 
-	GetProcessModuleConfig(prevRevision uint) (*ProcessModuleConfig, error)
+	GetProcessModuleConfig(ctx context.Context, prevRevision uint) (*ProcessModuleConfig, error)
 
 	// GetCommunicationHostForClient returns a CommunicationHost for the client's API URL. Or error, if failed to be parsed.
-	GetCommunicationHostForClient() (CommunicationHost, error)
+	GetCommunicationHostForClient(ctx context.Context) (CommunicationHost, error)
 
 	// SendEvent posts events to dynatrace API
-	SendEvent(eventData *EventData) error
+	SendEvent(ctx context.Context, eventData *EventData) error
+
+	// IngestEvent posts an event to the events API v2
+	IngestEvent(ctx context.Context, event *IngestEventData) error
 
 	// GetEntityIDForIP returns the entity id for a given IP address.
 	//
 	// Returns an error in case the lookup failed.
-	GetEntityIDForIP(ip string) (string, error)
+	GetEntityIDForIP(ctx context.Context, ip string) (string, error)
 
 	// GetTokenScopes returns the list of scopes assigned to a token if successful.
-	GetTokenScopes(token string) (TokenScopes, error)
+	GetTokenScopes(ctx context.Context, token string) (TokenScopes, error)
+
+	// GetOAuthScopes returns the list of scopes granted to the configured OAuth client if successful.
+	GetOAuthScopes(ctx context.Context) (TokenScopes, error)
 
 	// GetActiveGateConnectionInfo returns AgentTenantInfo for ActiveGate that holds UUID, Tenant Token and Endpoints
-	GetActiveGateConnectionInfo() (ActiveGateConnectionInfo, error)
+	GetActiveGateConnectionInfo(ctx context.Context) (ActiveGateConnectionInfo, error)
 
 	// CreateOrUpdateKubernetesSetting returns the object id of the created k8s settings if successful, or an api error otherwise
-	CreateOrUpdateKubernetesSetting(name, kubeSystemUUID, scope string) (string, error)
+	CreateOrUpdateKubernetesSetting(ctx context.Context, name, kubeSystemUUID, scope string) (string, error)
 
 	// CreateOrUpdateKubernetesAppSetting returns the object id of the created k8s app settings if successful, or an api error otherwise
-	CreateOrUpdateKubernetesAppSetting(scope string) (string, error)
+	CreateOrUpdateKubernetesAppSetting(ctx context.Context, scope string) (string, error)
 
 	// GetMonitoredEntitiesForKubeSystemUUID returns a (possibly empty) list of k8s monitored entities for the given uuid,
 	// or an api error otherwise
-	GetMonitoredEntitiesForKubeSystemUUID(kubeSystemUUID string) ([]MonitoredEntity, error)
+	GetMonitoredEntitiesForKubeSystemUUID(ctx context.Context, kubeSystemUUID string) ([]MonitoredEntity, error)
 
 	// GetSettingsForMonitoredEntities returns the settings response with the number of settings objects,
 	// or an api error otherwise
-	GetSettingsForMonitoredEntities(monitoredEntities []MonitoredEntity, schemaId string) (GetSettingsResponse, error)
+	GetSettingsForMonitoredEntities(ctx context.Context, monitoredEntities []MonitoredEntity, schemaId string) (GetSettingsResponse, error)
+
+	// GetSettingsObject returns the settings object with the given id, or nil if it doesn't exist
+	GetSettingsObject(ctx context.Context, objectId string) (*SettingsObject, error)
+
+	// CreateSettingsObject returns the object id of the created settings object if successful, or an api error otherwise
+	CreateSettingsObject(ctx context.Context, object SettingsObject) (string, error)
+
+	// UpdateSettingsObject replaces the schema version and value of the settings object with the given id
+	UpdateSettingsObject(ctx context.Context, objectId string, object SettingsObject) error
+
+	// DeleteSettingsObject deletes the settings object with the given id, an already deleted object is not an error
+	DeleteSettingsObject(ctx context.Context, objectId string) error
 
 	// GetSettingsForMonitoredEntities returns the settings response with the number of settings objects,
 	// or an api error otherwise
-	GetActiveGateAuthToken(dynakubeName string) (*ActiveGateAuthTokenInfo, error)
+	GetActiveGateAuthToken(ctx context.Context, dynakubeName string) (*ActiveGateAuthTokenInfo, error)
 
-	GetLatestOneAgentImage() (*LatestImageInfo, error)
+	GetLatestOneAgentImage(ctx context.Context) (*LatestImageInfo, error)
 
-	GetLatestCodeModulesImage() (*LatestImageInfo, error)
+	GetLatestCodeModulesImage(ctx context.Context) (*LatestImageInfo, error)
 
-	GetLatestActiveGateImage() (*LatestImageInfo, error)
+	GetLatestActiveGateImage(ctx context.Context) (*LatestImageInfo, error)
 }
 
 // Known OS values.
 const (
-	OsUnix = "unix"
+	OsUnix    = arch.OsUnix
+	OsWindows = arch.OsWindows
 	// Commented for linter, left for further reference
-	// OsWindows = "windows"
 	// OsAix     = "aix"
 	// OsSolaris = "solaris"
 )
@@ -117,10 +141,11 @@ const (
 	TokenScopeSettingsRead          = "settings.read"
 	TokenScopeSettingsWrite         = "settings.write"
 	TokenScopeActiveGateTokenCreate = "activeGateTokenManagement.create"
+	TokenScopeEventsIngest          = "events.ingest"
 )
 
 // NewClient creates a REST client for the given API base URL and authentication tokens.
-// Returns an error if a token or the URL is empty.
+// Returns an error if the URL is empty or if both tokens are empty and no OAuth client is configured.
 //
 // The API base URL is different for managed and SaaS environments:
 //   - SaaS: https://{environment-id}.live.dynatrace.com/api
@@ -131,9 +156,6 @@ func NewClient(url, apiToken, paasToken string, opts ...Option) (Client, error)
 	if len(url) == 0 {
 		return nil, errors.New("url is empty")
 	}
-	if len(apiToken) == 0 && len(paasToken) == 0 {
-		return nil, errors.New("tokens are empty")
-	}
 
 	url = strings.TrimSuffix(url, "/")
 
@@ -142,7 +164,8 @@ func NewClient(url, apiToken, paasToken string, opts ...Option) (Client, error)
 		apiToken:  apiToken,
 		paasToken: paasToken,
 
-		hostCache: make(map[string]hostInfo),
+		hostCache:   make(map[string]hostInfo),
+		retryPolicy: DefaultRetryPolicy(),
 		httpClient: &http.Client{
 			Transport: http.DefaultTransport.(*http.Transport).Clone(),
 			Timeout:   15 * time.Minute,
@@ -153,6 +176,20 @@ func NewClient(url, apiToken, paasToken string, opts ...Option) (Client, error)
 		opt(dc)
 	}
 
+	if len(apiToken) == 0 && len(paasToken) == 0 && !dc.usesOAuth() {
+		return nil, errors.New("tokens are empty")
+	}
+
+	if err := dc.validateMirrorURL(); err != nil {
+		return nil, err
+	}
+
+	dc.httpClient.Transport = apimetrics.NewRoundTripper(dc.httpClient.Transport, metricsClientName, dc.owner, dc.url, endpointTemplates...)
+
+	if err := dc.setupOAuth(); err != nil {
+		return nil, err
+	}
+
 	return dc, nil
 }
 
@@ -164,7 +201,7 @@ type Option func(*dynatraceClient)
 func SkipCertificateValidation(skip bool) Option {
 	return func(c *dynatraceClient) {
 		if skip {
-			t := c.httpClient.Transport.(*http.Transport)
+			t := c.ownTransport()
 			if t.TLSClientConfig == nil {
 				t.TLSClientConfig = &tls.Config{} //nolint:gosec // fix is expected to be delivered soon
 			}
@@ -173,6 +210,27 @@ func SkipCertificateValidation(skip bool) Option {
 	}
 }
 
+// Transport creates an Option that sends the requests via the given transport instead of a transport created for the client.
+// The transport can be shared with other clients, SkipCertificateValidation, Proxy and Certs modify a copy of it instead.
+func Transport(transport *http.Transport) Option {
+	return func(c *dynatraceClient) {
+		c.httpClient.Transport = transport
+		c.sharedTransport = true
+	}
+}
+
+// ownTransport returns the transport of the client for an Option to modify it, a shared transport is copied first.
+func (dtc *dynatraceClient) ownTransport() *http.Transport {
+	transport := dtc.httpClient.Transport.(*http.Transport)
+	if dtc.sharedTransport {
+		transport = transport.Clone()
+		dtc.httpClient.Transport = transport
+		dtc.sharedTransport = false
+	}
+
+	return transport
+}
+
 func Proxy(proxyURL string, noProxy string) Option {
 	return func(dtclient *dynatraceClient) {
 		parsedURL, err := url.Parse(proxyURL)
@@ -180,7 +238,7 @@ func Proxy(proxyURL string, noProxy string) Option {
 			log.Info("could not parse proxy URL!")
 			return
 		}
-		transport := dtclient.httpClient.Transport.(*http.Transport)
+		transport := dtclient.ownTransport()
 		proxyConfig := httpproxy.Config{
 			HTTPProxy:  parsedURL.String(),
 			HTTPSProxy: parsedURL.String(),
@@ -203,7 +261,7 @@ func Certs(certs []byte) Option {
 			log.Info("failed to append custom certs!")
 		}
 
-		t := c.httpClient.Transport.(*http.Transport)
+		t := c.ownTransport()
 		if t.TLSClientConfig == nil {
 			t.TLSClientConfig = &tls.Config{} //nolint:gosec // fix is expected to be delivered soon
 		}
@@ -217,6 +275,13 @@ func NetworkZone(networkZone string) Option {
 	}
 }
 
+// Owner creates an Option that sets the name of the DynaKube the client is used for, it's added as label to the metrics of all requests
+func Owner(dynakubeName string) Option {
+	return func(c *dynatraceClient) {
+		c.owner = dynakubeName
+	}
+}
+
 func DisableHostsRequests(disabledHostsRequests bool) Option {
 	return func(c *dynatraceClient) {
 		c.disableHostsRequests = disabledHostsRequests
@@ -95,6 +95,41 @@ func TestCerts(t *testing.T) {
 	assert.NotNil(t, transport.TLSClientConfig.RootCAs)
 }
 
+func TestTransport(t *testing.T) {
+	transport := &http.Transport{}
+
+	dtc, err := NewClient("https://test.dev.dynatracelabs.com/api", apiToken, paasToken, Transport(transport))
+	require.NoError(t, err)
+
+	roundTripper := dtc.(*dynatraceClient).httpClient.Transport
+	assert.NotSame(t, transport, roundTripper, "requests are still wrapped for the metrics")
+	assert.Nil(t, transport.TLSClientConfig, "the shared transport isn't modified")
+}
+
+func TestTransportWithOptions(t *testing.T) {
+	transport := &http.Transport{}
+
+	dtc, err := NewClient("https://test.dev.dynatracelabs.com/api", apiToken, paasToken,
+		Transport(transport),
+		SkipCertificateValidation(true),
+		Proxy("http://proxy.test:8080", ""),
+		Certs(nil),
+	)
+	require.NoError(t, err)
+
+	// cloning sets the HTTP/2 defaults of the TLS config, as the first request would
+	if transport.TLSClientConfig != nil {
+		assert.False(t, transport.TLSClientConfig.InsecureSkipVerify, "the shared transport isn't modified")
+		assert.Nil(t, transport.TLSClientConfig.RootCAs, "the shared transport isn't modified")
+	}
+
+	assert.Nil(t, transport.Proxy, "the shared transport isn't modified")
+
+	client := dtc.(*dynatraceClient)
+	assert.False(t, client.sharedTransport)
+	assert.NotSame(t, transport, client.httpClient.Transport)
+}
+
 func checkProxyForUrl(t *testing.T, transport http.Transport, proxyRawURL, targetRawURL string, noProxy bool) {
 	targetURL, err := url.Parse(targetRawURL)
 	require.NoError(t, err)
@@ -1,12 +1,13 @@
 package dynatrace
 
 import (
+	"context"
 	"errors"
 	"net/url"
 	"strconv"
 )
 
-func (dtc *dynatraceClient) GetCommunicationHostForClient() (CommunicationHost, error) {
+func (dtc *dynatraceClient) GetCommunicationHostForClient(_ context.Context) (CommunicationHost, error) {
 	return ParseEndpoint(dtc.url)
 }
 
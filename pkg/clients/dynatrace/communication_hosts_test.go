@@ -1,6 +1,8 @@
 package dynatrace
 
 import (
+	"context"
+
 	"net/http"
 	"sort"
 	"testing"
@@ -137,7 +139,7 @@ func TestParseEndpoints(t *testing.T) {
 }
 
 func testCommunicationHostsGetCommunicationHosts(t *testing.T, dynatraceClient Client) {
-	res, err := dynatraceClient.GetOneAgentConnectionInfo()
+	res, err := dynatraceClient.GetOneAgentConnectionInfo(context.Background())
 
 	assert.NoError(t, err)
 	assert.ObjectsAreEqualValues(res.CommunicationHosts, []CommunicationHost{
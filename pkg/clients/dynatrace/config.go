@@ -4,6 +4,9 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/logger"
 )
 
+// metricsClientName is the value of the client label of the request metrics
+const metricsClientName = "dynatrace"
+
 var (
 	log = logger.Factory.GetLogger("dtclient")
 )
@@ -1,6 +1,7 @@
 package dynatrace
 
 import (
+	"context"
 	"crypto/md5" //nolint:gosec
 	"encoding/hex"
 	"encoding/json"
@@ -10,8 +11,10 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/apierrors"
 	"github.com/Dynatrace/dynatrace-operator/pkg/clients/utils"
 	"github.com/pkg/errors"
+	"golang.org/x/oauth2/clientcredentials"
 )
 
 type HostNotFoundErr struct {
@@ -22,6 +25,10 @@ func (e HostNotFoundErr) Error() string {
 	return fmt.Sprintf("host not found for ip: %v", e.IP)
 }
 
+func (e HostNotFoundErr) Is(target error) bool {
+	return target == apierrors.ErrNotFound
+}
+
 type hostInfo struct {
 	version  string
 	entityID string
@@ -35,10 +42,24 @@ type dynatraceClient struct {
 
 	networkZone string
 
+	// owner is the name of the DynaKube the client is used for
+	owner string
+
+	// mirrorURL is the base URL of the deployment API mirror, empty if the deployment API is used
+	mirrorURL string
+
 	disableHostsRequests bool
 
 	httpClient *http.Client
 
+	// sharedTransport is set if the transport of the httpClient is shared with other clients and must not be modified
+	sharedTransport bool
+
+	oauthConfig *clientcredentials.Config
+	oauthToken  *oauthToken
+
+	retryPolicy RetryPolicy
+
 	hostCache map[string]hostInfo
 
 	// Set for testing purposes, leave the default zero value to use the current time.
@@ -55,43 +76,52 @@ const (
 
 // makeRequest does an HTTP request by formatting the URL from the given arguments and returns the response.
 // The response body must be closed by the caller when no longer used.
-func (dtc *dynatraceClient) makeRequest(url string, tokenType tokenType) (*http.Response, error) {
-	req, err := http.NewRequest(http.MethodGet, url, nil)
+func (dtc *dynatraceClient) makeRequest(ctx context.Context, url string, tokenType tokenType) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, errors.WithMessage(err, "error initializing http request")
 	}
 
-	var authHeader string
+	var token string
 
 	switch tokenType {
 	case dynatraceApiToken:
-		if dtc.apiToken == "" {
+		if dtc.apiToken == "" && !dtc.usesOAuth() {
 			return nil, errors.Errorf("not able to set token since api token is empty for request: %s", url)
 		}
-		authHeader = fmt.Sprintf("Api-Token %s", dtc.apiToken)
+		token = dtc.apiToken
 	case dynatracePaaSToken:
-		if dtc.paasToken == "" {
+		if dtc.paasToken == "" && !dtc.usesOAuth() {
 			return nil, errors.Errorf("not able to set token since paas token is empty for request: %s", url)
 		}
-		authHeader = fmt.Sprintf("Api-Token %s", dtc.paasToken)
+		token = dtc.paasToken
 	case installerUrlToken:
-		return dtc.httpClient.Do(req)
+		return dtc.doRequest(req)
 	default:
 		return nil, errors.Errorf("unknown token type (%d), unable to determine token to set in headers", tokenType)
 	}
 
+	authHeader, err := dtc.authorizationHeader(ctx, token)
+	if err != nil {
+		return nil, err
+	}
 	req.Header.Add("Authorization", authHeader)
 
-	return dtc.httpClient.Do(req)
+	return dtc.doRequest(req)
 }
 
-func createBaseRequest(url, method, apiToken string, body io.Reader) (*http.Request, error) {
-	req, err := http.NewRequest(method, url, body)
+func (dtc *dynatraceClient) createBaseRequest(ctx context.Context, url, method, apiToken string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
 		return nil, errors.WithMessage(err, "error initializing http request")
 	}
+
+	authHeader, err := dtc.authorizationHeader(ctx, apiToken)
+	if err != nil {
+		return nil, err
+	}
 	req.Header.Add("Accept", "application/json")
-	req.Header.Add("Authorization", fmt.Sprintf("Api-Token %s", apiToken))
+	req.Header.Add("Authorization", authHeader)
 
 	if method == http.MethodPost {
 		req.Header.Add("Content-Type", "application/json")
@@ -108,14 +138,16 @@ func (dtc *dynatraceClient) getServerResponseData(response *http.Response) ([]by
 
 	if response.StatusCode != http.StatusOK &&
 		response.StatusCode != http.StatusCreated {
-		return responseData, dtc.handleErrorResponseFromAPI(responseData, response.StatusCode)
+		serverErr := parseServerError(responseData, response.StatusCode)
+		serverErr.RetryAfter, _ = waitRequestedByServer(response, dtc.now)
+		return responseData, serverErr
 	}
 
 	return responseData, nil
 }
 
-func (dtc *dynatraceClient) makeRequestAndUnmarshal(url string, token tokenType, response interface{}) error {
-	resp, err := dtc.makeRequest(url, token)
+func (dtc *dynatraceClient) makeRequestAndUnmarshal(ctx context.Context, url string, token tokenType, response interface{}) error {
+	resp, err := dtc.makeRequest(ctx, url, token)
 	if err != nil {
 		return err
 	}
@@ -129,20 +161,19 @@ func (dtc *dynatraceClient) makeRequestAndUnmarshal(url string, token tokenType,
 	return json.Unmarshal(responseData, &response)
 }
 
-func (dtc *dynatraceClient) makeRequestForBinary(url string, token tokenType, writer io.Writer) (string, error) {
-	resp, err := dtc.makeRequest(url, token)
+func (dtc *dynatraceClient) makeRequestForBinary(ctx context.Context, url string, token tokenType, writer io.Writer) (string, error) {
+	resp, err := dtc.makeRequest(download(ctx), url, token)
 	if err != nil {
 		return "", err
 	}
 	defer utils.CloseBodyAfterRequest(resp)
 
 	if resp.StatusCode != http.StatusOK {
-		var errorResponse serverErrorResponse
-		err = json.NewDecoder(resp.Body).Decode(&errorResponse)
+		responseData, err := io.ReadAll(resp.Body)
 		if err != nil {
-			return "", err
+			return "", errors.WithMessage(err, "error reading response")
 		}
-		return "", errors.Errorf("dynatrace server error %d: %s", errorResponse.ErrorMessage.Code, errorResponse.ErrorMessage.Message)
+		return "", parseServerError(responseData, resp.StatusCode)
 	}
 
 	hash := md5.New() //nolint:gosec
@@ -150,18 +181,23 @@ func (dtc *dynatraceClient) makeRequestForBinary(url string, token tokenType, wr
 	return hex.EncodeToString(hash.Sum(nil)), err
 }
 
-func (dtc *dynatraceClient) handleErrorResponseFromAPI(response []byte, statusCode int) error {
+// parseServerError reads the error from the response body, the status code of the response is used if the body doesn't contain one
+func parseServerError(response []byte, statusCode int) ServerError {
 	se := serverErrorResponse{}
 	if err := json.Unmarshal(response, &se); err != nil {
-		return errors.WithMessagef(err, "response error, can't unmarshal json response %d", statusCode)
+		return ServerError{Code: statusCode, Message: fmt.Sprintf("response error, can't unmarshal json response: %s", err)}
+	}
+
+	if se.ErrorMessage.Code == 0 {
+		se.ErrorMessage.Code = statusCode
 	}
 
 	return se.ErrorMessage
 }
 
-func (dtc *dynatraceClient) getHostInfoForIP(ip string) (*hostInfo, error) {
+func (dtc *dynatraceClient) getHostInfoForIP(ctx context.Context, ip string) (*hostInfo, error) {
 	if len(dtc.hostCache) == 0 {
-		err := dtc.buildHostCache()
+		err := dtc.buildHostCache(ctx)
 		if err != nil {
 			return nil, errors.WithMessage(err, "error building host-cache from dynatrace cluster")
 		}
@@ -175,12 +211,12 @@ func (dtc *dynatraceClient) getHostInfoForIP(ip string) (*hostInfo, error) {
 	}
 }
 
-func (dtc *dynatraceClient) buildHostCache() error {
+func (dtc *dynatraceClient) buildHostCache(ctx context.Context) error {
 	if dtc.disableHostsRequests {
 		return nil
 	}
 
-	resp, err := dtc.makeRequest(dtc.getHostsUrl(), dynatraceApiToken)
+	resp, err := dtc.makeRequest(ctx, dtc.getHostsUrl(), dynatraceApiToken)
 	if err != nil {
 		return errors.WithStack(err)
 	}
@@ -278,9 +314,13 @@ type serverErrorResponse struct {
 }
 
 // ServerError represents an error returned from the server (e.g. authentication failure).
+// It is classified by its code, so errors.Is can be used with the errors of the apierrors package.
 type ServerError struct {
 	Code    int
 	Message string
+
+	// RetryAfter is the wait time the server requested before the next request, if any
+	RetryAfter time.Duration `json:"-"`
 }
 
 // Error formats the server error code and message.
@@ -291,3 +331,14 @@ func (e ServerError) Error() string {
 
 	return fmt.Sprintf("dynatrace server error %d: %s", int64(e.Code), e.Message)
 }
+
+// Is returns true if the target is the class of the status code of the error
+func (e ServerError) Is(target error) bool {
+	class := apierrors.FromStatusCode(e.Code)
+	return class != nil && target == class
+}
+
+// RetryAfterDuration returns the wait time requested by the server, see apierrors.RetryAfter
+func (e ServerError) RetryAfterDuration() time.Duration {
+	return e.RetryAfter
+}
@@ -1,6 +1,8 @@
 package dynatrace
 
 import (
+	"context"
+
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -8,7 +10,9 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/apierrors"
 	"github.com/Dynatrace/dynatrace-operator/pkg/clients/utils"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -30,13 +34,13 @@ func TestMakeRequest(t *testing.T) {
 
 	{
 		url := fmt.Sprintf("%s/v1/deployment/installer/agent/connectioninfo", dc.url)
-		resp, err := dc.makeRequest(url, dynatraceApiToken)
+		resp, err := dc.makeRequest(context.Background(), url, dynatraceApiToken)
 		assert.NoError(t, err)
 		assert.NotNil(t, resp)
 		defer utils.CloseBodyAfterRequest(resp)
 	}
 	{
-		resp, err := dc.makeRequest("%s/v1/deployment/installer/agent/connectioninfo", dynatraceApiToken) //nolint:bodyclose
+		resp, err := dc.makeRequest(context.Background(), "%s/v1/deployment/installer/agent/connectioninfo", dynatraceApiToken) //nolint:bodyclose
 		assert.Error(t, err, "unsupported protocol scheme")
 		assert.Nil(t, resp)
 	}
@@ -59,7 +63,7 @@ func TestGetResponseOrServerError(t *testing.T) {
 
 	reqURL := fmt.Sprintf("%s/v1/deployment/installer/agent/connectioninfo", dc.url)
 	{
-		resp, err := dc.makeRequest(reqURL, dynatraceApiToken) //nolint:bodyclose
+		resp, err := dc.makeRequest(context.Background(), reqURL, dynatraceApiToken) //nolint:bodyclose
 		assert.NoError(t, err)
 		assert.NotNil(t, resp)
 
@@ -85,13 +89,13 @@ func TestBuildHostCache(t *testing.T) {
 	require.NotNil(t, dc)
 
 	{
-		err := dc.buildHostCache()
+		err := dc.buildHostCache(context.Background())
 		assert.Error(t, err, "error querying dynatrace server")
 		assert.Empty(t, dc.hostCache)
 	}
 	{
 		dc.apiToken = apiToken
-		err := dc.buildHostCache()
+		err := dc.buildHostCache(context.Background())
 		assert.NoError(t, err)
 		assert.NotZero(t, len(dc.hostCache))
 		assert.ObjectsAreEqualValues(dc.hostCache, map[string]hostInfo{
@@ -120,6 +124,39 @@ func TestServerError(t *testing.T) {
 	}
 }
 
+func TestServerErrorClasses(t *testing.T) {
+	assert.ErrorIs(t, ServerError{Code: http.StatusUnauthorized}, apierrors.ErrUnauthorized)
+	assert.ErrorIs(t, ServerError{Code: http.StatusForbidden}, apierrors.ErrForbidden)
+	assert.ErrorIs(t, errors.WithStack(ServerError{Code: http.StatusNotFound}), apierrors.ErrNotFound)
+	assert.ErrorIs(t, ServerError{Code: http.StatusTooManyRequests}, apierrors.ErrRateLimited)
+	assert.ErrorIs(t, ServerError{Code: http.StatusBadGateway}, apierrors.ErrServer)
+	assert.Nil(t, apierrors.Class(ServerError{Code: http.StatusBadRequest}))
+	assert.ErrorIs(t, HostNotFoundErr{IP: "1.2.3.4"}, apierrors.ErrNotFound)
+
+	retryAfter, ok := apierrors.RetryAfter(ServerError{Code: http.StatusTooManyRequests, RetryAfter: time.Minute})
+	assert.True(t, ok)
+	assert.Equal(t, time.Minute, retryAfter)
+}
+
+func TestParseServerError(t *testing.T) {
+	t.Run("code of the body is used", func(t *testing.T) {
+		err := parseServerError([]byte(`{"error": {"code": 401, "message": "Token Authentication failed"}}`), http.StatusUnauthorized)
+
+		assert.Equal(t, ServerError{Code: http.StatusUnauthorized, Message: "Token Authentication failed"}, err)
+	})
+	t.Run("status code is used if the body has no code", func(t *testing.T) {
+		err := parseServerError([]byte(`{"error": {"message": "not found"}}`), http.StatusNotFound)
+
+		assert.Equal(t, ServerError{Code: http.StatusNotFound, Message: "not found"}, err)
+	})
+	t.Run("body which isn't json is still classified", func(t *testing.T) {
+		err := parseServerError([]byte(`<html>Bad Gateway</html>`), http.StatusBadGateway)
+
+		assert.ErrorIs(t, err, apierrors.ErrServer)
+		assert.Contains(t, err.Error(), "can't unmarshal json response")
+	})
+}
+
 func TestDynatraceClientWithServer(t *testing.T) {
 	dynatraceServer := httptest.NewServer(dynatraceServerHandler())
 	defer dynatraceServer.Close()
@@ -225,7 +262,7 @@ func TestIgnoreNonCurrentlySeenHosts(t *testing.T) {
 	}
 ]`)))
 
-	info, err := c.getHostInfoForIP("1.1.1.1")
+	info, err := c.getHostInfoForIP(context.Background(), "1.1.1.1")
 	require.NoError(t, err)
 	require.Equal(t, "HOST-42", info.entityID)
 	require.Equal(t, "1.195.0.20200515-045253", info.version)
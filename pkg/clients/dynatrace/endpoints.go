@@ -2,6 +2,28 @@ package dynatrace
 
 import "fmt"
 
+// endpointTemplates are the endpoints used by the client, used as labels for the metrics of the requests
+var endpointTemplates = []string{
+	"/v1/deployment/installer/agent/{os}/{installerType}/version/{version}",
+	"/v1/deployment/installer/agent/{os}/{installerType}/latest",
+	"/v1/deployment/installer/agent/{os}/{installerType}/latest/metainfo",
+	"/v1/deployment/installer/agent/versions/{os}/{installerType}",
+	"/v1/deployment/installer/agent/connectioninfo",
+	"/v1/deployment/installer/agent/processmoduleconfig",
+	"/v1/deployment/installer/gateway/connectioninfo",
+	"/v1/entity/infrastructure/hosts",
+	"/v2/entities",
+	"/v2/settings/objects",
+	"/v2/settings/objects/{objectId}",
+	"/v1/events",
+	"/v2/events/ingest",
+	"/v1/tokens/lookup",
+	"/v2/activeGateTokens",
+	"/v1/deployment/image/agent/oneAgent/latest",
+	"/v1/deployment/image/agent/codeModules/latest",
+	"/v1/deployment/image/gateway/latest",
+}
+
 func (dtc *dynatraceClient) getAgentUrl(os, installerType, flavor, arch, version string, technologies []string, skipMetadata bool) string {
 	url := fmt.Sprintf("%s/v1/deployment/installer/agent/%s/%s/version/%s?flavor=%s&arch=%s&bitness=64&skipMetadata=%t",
 		dtc.url, os, installerType, version, flavor, arch, skipMetadata)
@@ -51,6 +73,10 @@ func (dtc *dynatraceClient) getSettingsUrl(validate bool) string {
 	return fmt.Sprintf("%s/v2/settings/objects%s", dtc.url, validationQuery)
 }
 
+func (dtc *dynatraceClient) getSettingsObjectUrl(objectId string) string {
+	return fmt.Sprintf("%s/v2/settings/objects/%s", dtc.url, objectId)
+}
+
 func (dtc *dynatraceClient) getProcessModuleConfigUrl() string {
 	return fmt.Sprintf("%s/v1/deployment/installer/agent/processmoduleconfig", dtc.url)
 }
@@ -59,6 +85,10 @@ func (dtc *dynatraceClient) getEventsUrl() string {
 	return fmt.Sprintf("%s/v1/events", dtc.url)
 }
 
+func (dtc *dynatraceClient) getEventsIngestUrl() string {
+	return fmt.Sprintf("%s/v2/events/ingest", dtc.url)
+}
+
 func (dtc *dynatraceClient) getTokensLookupUrl() string {
 	return fmt.Sprintf("%s/v1/tokens/lookup", dtc.url)
 }
@@ -2,6 +2,7 @@ package dynatrace
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
 
@@ -18,8 +19,12 @@ func (image LatestImageInfo) String() string {
 	return image.Source + ":" + image.Tag
 }
 
-func (dtc *dynatraceClient) GetLatestOneAgentImage() (*LatestImageInfo, error) {
-	latestImageInfo, err := dtc.processLatestImageRequest(dtc.getLatestOneAgentImageUrl())
+func (dtc *dynatraceClient) GetLatestOneAgentImage(ctx context.Context) (*LatestImageInfo, error) {
+	if dtc.usesMirror() {
+		return dtc.getLatestImageFromMirror(ctx, func(images MirrorImages) *LatestImageInfo { return images.OneAgent })
+	}
+
+	latestImageInfo, err := dtc.processLatestImageRequest(ctx, dtc.getLatestOneAgentImageUrl())
 
 	if err != nil {
 		log.Info("failed to process latest image response")
@@ -29,8 +34,12 @@ func (dtc *dynatraceClient) GetLatestOneAgentImage() (*LatestImageInfo, error) {
 	return latestImageInfo, nil
 }
 
-func (dtc *dynatraceClient) GetLatestCodeModulesImage() (*LatestImageInfo, error) {
-	latestImageInfo, err := dtc.processLatestImageRequest(dtc.getLatestCodeModulesImageUrl())
+func (dtc *dynatraceClient) GetLatestCodeModulesImage(ctx context.Context) (*LatestImageInfo, error) {
+	if dtc.usesMirror() {
+		return dtc.getLatestImageFromMirror(ctx, func(images MirrorImages) *LatestImageInfo { return images.CodeModules })
+	}
+
+	latestImageInfo, err := dtc.processLatestImageRequest(ctx, dtc.getLatestCodeModulesImageUrl())
 
 	if err != nil {
 		log.Info("failed to process latest image response")
@@ -40,8 +49,12 @@ func (dtc *dynatraceClient) GetLatestCodeModulesImage() (*LatestImageInfo, error
 	return latestImageInfo, nil
 }
 
-func (dtc *dynatraceClient) GetLatestActiveGateImage() (*LatestImageInfo, error) {
-	latestImageInfo, err := dtc.processLatestImageRequest(dtc.getLatestActiveGateImageUrl())
+func (dtc *dynatraceClient) GetLatestActiveGateImage(ctx context.Context) (*LatestImageInfo, error) {
+	if dtc.usesMirror() {
+		return dtc.getLatestImageFromMirror(ctx, func(images MirrorImages) *LatestImageInfo { return images.ActiveGate })
+	}
+
+	latestImageInfo, err := dtc.processLatestImageRequest(ctx, dtc.getLatestActiveGateImageUrl())
 
 	if err != nil {
 		log.Info("failed to process latest image response")
@@ -51,13 +64,13 @@ func (dtc *dynatraceClient) GetLatestActiveGateImage() (*LatestImageInfo, error)
 	return latestImageInfo, nil
 }
 
-func (dtc *dynatraceClient) processLatestImageRequest(url string) (*LatestImageInfo, error) {
-	request, err := dtc.createLatestImageRequest(url)
+func (dtc *dynatraceClient) processLatestImageRequest(ctx context.Context, url string) (*LatestImageInfo, error) {
+	request, err := dtc.createLatestImageRequest(ctx, url)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
 
-	response, err := dtc.httpClient.Do(request)
+	response, err := dtc.doRequest(request)
 	if err != nil {
 		log.Info("failed to retrieve latest image")
 		return nil, err
@@ -84,7 +97,7 @@ func (dtc *dynatraceClient) handleLatestImageResponse(response *http.Response) (
 
 	data, err := dtc.getServerResponseData(response)
 	if err != nil {
-		return nil, dtc.handleErrorResponseFromAPI(data, response.StatusCode)
+		return nil, err
 	}
 
 	latestImageInfo, err := dtc.readResponseForLatestImage(data)
@@ -94,7 +107,7 @@ func (dtc *dynatraceClient) handleLatestImageResponse(response *http.Response) (
 	return latestImageInfo, err
 }
 
-func (dtc *dynatraceClient) createLatestImageRequest(url string) (*http.Request, error) {
+func (dtc *dynatraceClient) createLatestImageRequest(ctx context.Context, url string) (*http.Request, error) {
 	body := &LatestImageInfo{}
 
 	bodyData, err := json.Marshal(body)
@@ -102,7 +115,7 @@ func (dtc *dynatraceClient) createLatestImageRequest(url string) (*http.Request,
 		return nil, err
 	}
 
-	request, err := createBaseRequest(
+	request, err := dtc.createBaseRequest(ctx,
 		url,
 		http.MethodGet,
 		dtc.apiToken,
@@ -1,6 +1,8 @@
 package dynatrace
 
 import (
+	"context"
+
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -32,7 +34,7 @@ func TestGetLatestImage(t *testing.T) {
 		dynatraceServer, dynatraceClient := createTestDynatraceServer(t, connectionInfoServerHandler(oneAgentImageUrl, latestOneAgentImageResponse), "")
 		defer dynatraceServer.Close()
 
-		latestImageInfo, err := dynatraceClient.GetLatestOneAgentImage()
+		latestImageInfo, err := dynatraceClient.GetLatestOneAgentImage(context.Background())
 		assert.NoError(t, err)
 		assert.NotNil(t, latestImageInfo)
 
@@ -43,7 +45,7 @@ func TestGetLatestImage(t *testing.T) {
 		dynatraceServer, dynatraceClient := createTestDynatraceServer(t, connectionInfoServerHandler(activeGateImageUrl, latestActiveGateImageResponse), "")
 		defer dynatraceServer.Close()
 
-		latestImageInfo, err := dynatraceClient.GetLatestActiveGateImage()
+		latestImageInfo, err := dynatraceClient.GetLatestActiveGateImage(context.Background())
 		assert.NoError(t, err)
 		assert.NotNil(t, latestImageInfo)
 
@@ -54,7 +56,7 @@ func TestGetLatestImage(t *testing.T) {
 		dynatraceServer, dynatraceClient := createTestDynatraceServer(t, connectionInfoServerHandler(codeModulesImageUrl, latestCodeModulesImageResponse), "")
 		defer dynatraceServer.Close()
 
-		latestImageInfo, err := dynatraceClient.GetLatestCodeModulesImage()
+		latestImageInfo, err := dynatraceClient.GetLatestCodeModulesImage(context.Background())
 		assert.NoError(t, err)
 		assert.NotNil(t, latestImageInfo)
 
@@ -68,7 +70,7 @@ func TestGetLatestImageFailure(t *testing.T) {
 		faultyDynatraceServer, faultyDynatraceClient := createTestDynatraceServer(t, tenantInternalServerError(oneAgentImageUrl), "")
 		defer faultyDynatraceServer.Close()
 
-		latestImageInfo, err := faultyDynatraceClient.GetLatestOneAgentImage()
+		latestImageInfo, err := faultyDynatraceClient.GetLatestOneAgentImage(context.Background())
 		assert.Error(t, err)
 		assert.Nil(t, latestImageInfo)
 
@@ -78,7 +80,7 @@ func TestGetLatestImageFailure(t *testing.T) {
 		faultyDynatraceServer, faultyDynatraceClient := createTestDynatraceServer(t, tenantInternalServerError(activeGateImageUrl), "")
 		defer faultyDynatraceServer.Close()
 
-		latestImageInfo, err := faultyDynatraceClient.GetLatestActiveGateImage()
+		latestImageInfo, err := faultyDynatraceClient.GetLatestActiveGateImage(context.Background())
 		assert.Error(t, err)
 		assert.Nil(t, latestImageInfo)
 
@@ -88,7 +90,7 @@ func TestGetLatestImageFailure(t *testing.T) {
 		faultyDynatraceServer, faultyDynatraceClient := createTestDynatraceServer(t, tenantInternalServerError(codeModulesImageUrl), "")
 		defer faultyDynatraceServer.Close()
 
-		latestImageInfo, err := faultyDynatraceClient.GetLatestCodeModulesImage()
+		latestImageInfo, err := faultyDynatraceClient.GetLatestCodeModulesImage(context.Background())
 		assert.Error(t, err)
 		assert.Nil(t, latestImageInfo)
 
@@ -98,7 +100,7 @@ func TestGetLatestImageFailure(t *testing.T) {
 		faultyDynatraceServer, faultyDynatraceClient := createTestDynatraceServer(t, tenantMalformedJson(oneAgentImageUrl), "")
 		defer faultyDynatraceServer.Close()
 
-		latestImageInfo, err := faultyDynatraceClient.GetLatestOneAgentImage()
+		latestImageInfo, err := faultyDynatraceClient.GetLatestOneAgentImage(context.Background())
 		assert.Error(t, err)
 		assert.Nil(t, latestImageInfo)
 
@@ -108,7 +110,7 @@ func TestGetLatestImageFailure(t *testing.T) {
 		faultyDynatraceServer, faultyDynatraceClient := createTestDynatraceServer(t, tenantMalformedJson(activeGateImageUrl), "")
 		defer faultyDynatraceServer.Close()
 
-		latestImageInfo, err := faultyDynatraceClient.GetLatestActiveGateImage()
+		latestImageInfo, err := faultyDynatraceClient.GetLatestActiveGateImage(context.Background())
 		assert.Error(t, err)
 		assert.Nil(t, latestImageInfo)
 
@@ -118,7 +120,7 @@ func TestGetLatestImageFailure(t *testing.T) {
 		faultyDynatraceServer, faultyDynatraceClient := createTestDynatraceServer(t, tenantMalformedJson(codeModulesImageUrl), "")
 		defer faultyDynatraceServer.Close()
 
-		latestImageInfo, err := faultyDynatraceClient.GetLatestCodeModulesImage()
+		latestImageInfo, err := faultyDynatraceClient.GetLatestCodeModulesImage(context.Background())
 		assert.Error(t, err)
 		assert.Nil(t, latestImageInfo)
 
@@ -0,0 +1,61 @@
+package dynatrace
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/utils"
+	"github.com/pkg/errors"
+)
+
+// Event types of the events API v2
+const (
+	IngestEventTypeCustomInfo       = "CUSTOM_INFO"
+	IngestEventTypeCustomDeployment = "CUSTOM_DEPLOYMENT"
+)
+
+// IngestEventData is the payload of the events API v2
+type IngestEventData struct {
+	EventType string `json:"eventType"`
+	Title     string `json:"title"`
+	// EntitySelector defines the entities the event is attached to, the event is attached to the environment if empty
+	EntitySelector string            `json:"entitySelector,omitempty"`
+	Properties     map[string]string `json:"properties,omitempty"`
+}
+
+// KubernetesClusterEntitySelector returns the selector of the Kubernetes cluster entity with the given kube-system namespace UUID
+func KubernetesClusterEntitySelector(kubeSystemUUID string) string {
+	return "type(KUBERNETES_CLUSTER),kubernetesClusterId(" + kubeSystemUUID + ")"
+}
+
+func (dtc *dynatraceClient) IngestEvent(ctx context.Context, event *IngestEventData) error {
+	if event == nil {
+		return errors.New("no event data given")
+	}
+
+	if event.EventType == "" || event.Title == "" {
+		return errors.New("eventType and title are required for an event")
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	req, err := dtc.createBaseRequest(ctx, dtc.getEventsIngestUrl(), http.MethodPost, dtc.apiToken, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	response, err := dtc.doRequest(req)
+	if err != nil {
+		return errors.WithMessage(err, "error making post request to dynatrace api")
+	}
+
+	defer utils.CloseBodyAfterRequest(response)
+
+	_, err = dtc.getServerResponseData(response)
+	return errors.WithStack(err)
+}
@@ -0,0 +1,54 @@
+package dynatrace
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIngestEvent(t *testing.T) {
+	testEvent := IngestEventData{
+		EventType:      IngestEventTypeCustomInfo,
+		Title:          "test event",
+		EntitySelector: KubernetesClusterEntitySelector("kube-system-uuid"),
+		Properties:     map[string]string{"dynakube.name": "dynakube"},
+	}
+
+	t.Run("event is posted", func(t *testing.T) {
+		var received IngestEventData
+		dynatraceServer, dynatraceClient := createTestDynatraceClientWithFunc(t, func(writer http.ResponseWriter, request *http.Request) {
+			assert.Equal(t, http.MethodPost, request.Method)
+			assert.Equal(t, "/v2/events/ingest", request.URL.Path)
+			assert.Equal(t, "Api-Token "+apiToken, request.Header.Get("Authorization"))
+			assert.NoError(t, json.NewDecoder(request.Body).Decode(&received))
+			writer.WriteHeader(http.StatusCreated)
+		})
+		defer dynatraceServer.Close()
+
+		err := dynatraceClient.IngestEvent(context.Background(), &testEvent)
+		require.NoError(t, err)
+		assert.Equal(t, testEvent, received)
+		assert.Equal(t, "type(KUBERNETES_CLUSTER),kubernetesClusterId(kube-system-uuid)", received.EntitySelector)
+	})
+	t.Run("incomplete event data", func(t *testing.T) {
+		dynatraceServer, dynatraceClient := createTestDynatraceClientWithFunc(t, func(writer http.ResponseWriter, request *http.Request) {
+			t.Error("no request expected")
+		})
+		defer dynatraceServer.Close()
+
+		require.Error(t, dynatraceClient.IngestEvent(context.Background(), nil))
+		require.Error(t, dynatraceClient.IngestEvent(context.Background(), &IngestEventData{EventType: IngestEventTypeCustomInfo}))
+	})
+	t.Run("server error", func(t *testing.T) {
+		dynatraceServer, dynatraceClient := createTestDynatraceClientWithFunc(t, func(writer http.ResponseWriter, request *http.Request) {
+			writeError(writer, http.StatusBadRequest)
+		})
+		defer dynatraceServer.Close()
+
+		require.Error(t, dynatraceClient.IngestEvent(context.Background(), &testEvent))
+	})
+}
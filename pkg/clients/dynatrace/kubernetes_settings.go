@@ -2,13 +2,14 @@ package dynatrace
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
-	"strconv"
 	"strings"
 
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/apierrors"
 	"github.com/Dynatrace/dynatrace-operator/pkg/clients/utils"
 	"github.com/pkg/errors"
 )
@@ -88,18 +89,18 @@ const (
 	appTransitionSchemaVersion                  = "1.0.1"
 )
 
-func (dtc *dynatraceClient) performCreateOrUpdateKubernetesSetting(body []postKubernetesSettingsBody) (string, error) {
+func (dtc *dynatraceClient) performCreateOrUpdateKubernetesSetting(ctx context.Context, body []postKubernetesSettingsBody) (string, error) {
 	bodyData, err := json.Marshal(body)
 	if err != nil {
 		return "", err
 	}
 
-	req, err := createBaseRequest(dtc.getSettingsUrl(false), http.MethodPost, dtc.apiToken, bytes.NewReader(bodyData))
+	req, err := dtc.createBaseRequest(ctx, dtc.getSettingsUrl(false), http.MethodPost, dtc.apiToken, bytes.NewReader(bodyData))
 	if err != nil {
 		return "", err
 	}
 
-	res, err := dtc.httpClient.Do(req)
+	res, err := dtc.doRequest(req)
 	if err != nil {
 		return "", fmt.Errorf("error making post request to dynatrace api: %w", err)
 	}
@@ -175,16 +176,16 @@ func createV3KubernetesSettingsBody(clusterLabel, kubeSystemUUID, scope string)
 	return []postKubernetesSettingsBody{settings}
 }
 
-func (dtc *dynatraceClient) CreateOrUpdateKubernetesSetting(clusterLabel, kubeSystemUUID, scope string) (string, error) {
+func (dtc *dynatraceClient) CreateOrUpdateKubernetesSetting(ctx context.Context, clusterLabel, kubeSystemUUID, scope string) (string, error) {
 	if kubeSystemUUID == "" {
 		return "", errors.New("no kube-system namespace UUID given")
 	}
 	body := createV3KubernetesSettingsBody(clusterLabel, kubeSystemUUID, scope)
-	objectId, err := dtc.performCreateOrUpdateKubernetesSetting(body)
+	objectId, err := dtc.performCreateOrUpdateKubernetesSetting(ctx, body)
 	if err != nil {
-		if strings.Contains(err.Error(), strconv.Itoa(http.StatusNotFound)) {
+		if errors.Is(err, apierrors.ErrNotFound) {
 			body = createV1KubernetesSettingsBody(clusterLabel, kubeSystemUUID, scope)
-			return dtc.performCreateOrUpdateKubernetesSetting(body)
+			return dtc.performCreateOrUpdateKubernetesSetting(ctx, body)
 		} else {
 			return "", err
 		}
@@ -192,12 +193,12 @@ func (dtc *dynatraceClient) CreateOrUpdateKubernetesSetting(clusterLabel, kubeSy
 	return objectId, nil
 }
 
-func (dtc *dynatraceClient) GetMonitoredEntitiesForKubeSystemUUID(kubeSystemUUID string) ([]MonitoredEntity, error) {
+func (dtc *dynatraceClient) GetMonitoredEntitiesForKubeSystemUUID(ctx context.Context, kubeSystemUUID string) ([]MonitoredEntity, error) {
 	if kubeSystemUUID == "" {
 		return nil, errors.New("no kube-system namespace UUID given")
 	}
 
-	req, err := createBaseRequest(dtc.getEntitiesUrl(), http.MethodGet, dtc.apiToken, nil)
+	req, err := dtc.createBaseRequest(ctx, dtc.getEntitiesUrl(), http.MethodGet, dtc.apiToken, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -209,7 +210,7 @@ func (dtc *dynatraceClient) GetMonitoredEntitiesForKubeSystemUUID(kubeSystemUUID
 	q.Add("fields", "+lastSeenTms")
 	req.URL.RawQuery = q.Encode()
 
-	res, err := dtc.httpClient.Do(req)
+	res, err := dtc.doRequest(req)
 
 	if err != nil {
 		log.Info("check if ME exists failed")
@@ -227,7 +228,7 @@ func (dtc *dynatraceClient) GetMonitoredEntitiesForKubeSystemUUID(kubeSystemUUID
 	return resDataJson.Entities, nil
 }
 
-func (dtc *dynatraceClient) GetSettingsForMonitoredEntities(monitoredEntities []MonitoredEntity, schemaId string) (GetSettingsResponse, error) {
+func (dtc *dynatraceClient) GetSettingsForMonitoredEntities(ctx context.Context, monitoredEntities []MonitoredEntity, schemaId string) (GetSettingsResponse, error) {
 	if len(monitoredEntities) < 1 {
 		return GetSettingsResponse{TotalCount: 0}, nil
 	}
@@ -237,7 +238,7 @@ func (dtc *dynatraceClient) GetSettingsForMonitoredEntities(monitoredEntities []
 		scopes = append(scopes, entity.EntityId)
 	}
 
-	req, err := createBaseRequest(dtc.getSettingsUrl(true), http.MethodGet, dtc.apiToken, nil)
+	req, err := dtc.createBaseRequest(ctx, dtc.getSettingsUrl(true), http.MethodGet, dtc.apiToken, nil)
 	if err != nil {
 		return GetSettingsResponse{}, err
 	}
@@ -247,7 +248,7 @@ func (dtc *dynatraceClient) GetSettingsForMonitoredEntities(monitoredEntities []
 	q.Add("scopes", strings.Join(scopes, ","))
 	req.URL.RawQuery = q.Encode()
 
-	res, err := dtc.httpClient.Do(req)
+	res, err := dtc.doRequest(req)
 
 	if err != nil {
 		log.Info("failed to retrieve MEs")
@@ -284,13 +285,13 @@ func handleErrorArrayResponseFromAPI(response []byte, statusCode int) error {
 	if statusCode == http.StatusForbidden || statusCode == http.StatusUnauthorized {
 		var se getSettingsErrorResponse
 		if err := json.Unmarshal(response, &se); err != nil {
-			return fmt.Errorf("response error: %d, can't unmarshal json response", statusCode)
+			return ServerError{Code: statusCode, Message: "can't unmarshal json response"}
 		}
-		return fmt.Errorf("response error: %d, %s", statusCode, se.ErrorMessage.Message)
+		return ServerError{Code: statusCode, Message: se.ErrorMessage.Message}
 	} else {
 		var se []getSettingsErrorResponse
 		if err := json.Unmarshal(response, &se); err != nil {
-			return fmt.Errorf("response error: %d, can't unmarshal json response", statusCode)
+			return ServerError{Code: statusCode, Message: "can't unmarshal json response"}
 		}
 
 		var sb strings.Builder
@@ -304,17 +305,17 @@ func handleErrorArrayResponseFromAPI(response []byte, statusCode int) error {
 			sb.WriteString("]\n")
 		}
 
-		return fmt.Errorf(sb.String())
+		return ServerError{Code: statusCode, Message: sb.String()}
 	}
 }
 
-func (dtc *dynatraceClient) CreateOrUpdateKubernetesAppSetting(scope string) (string, error) {
+func (dtc *dynatraceClient) CreateOrUpdateKubernetesAppSetting(ctx context.Context, scope string) (string, error) {
 	settings := createBaseKubernetesSettings(postKubernetesAppSettings{
 		kubernetesAppOptionsSettings{
 			EnableKubernetesApp: true,
 		},
 	}, AppTransitionSchemaId, appTransitionSchemaVersion, scope)
-	objectId, err := dtc.performCreateOrUpdateKubernetesSetting([]postKubernetesSettingsBody{settings})
+	objectId, err := dtc.performCreateOrUpdateKubernetesSetting(ctx, []postKubernetesSettingsBody{settings})
 	if err != nil {
 		return "", err
 	}
@@ -1,6 +1,8 @@
 package dynatrace
 
 import (
+	"context"
+
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -35,7 +37,7 @@ func TestDynatraceClient_GetMonitoredEntitiesForKubeSystemUUID(t *testing.T) {
 		require.NotNil(t, dtc)
 
 		// act
-		actual, err := dtc.(*dynatraceClient).GetMonitoredEntitiesForKubeSystemUUID(testUID)
+		actual, err := dtc.(*dynatraceClient).GetMonitoredEntitiesForKubeSystemUUID(context.Background(), testUID)
 
 		// assert
 		require.NotNil(t, actual)
@@ -57,7 +59,7 @@ func TestDynatraceClient_GetMonitoredEntitiesForKubeSystemUUID(t *testing.T) {
 		require.NotNil(t, dtc)
 
 		// act
-		actual, err := dtc.(*dynatraceClient).GetMonitoredEntitiesForKubeSystemUUID(testUID)
+		actual, err := dtc.(*dynatraceClient).GetMonitoredEntitiesForKubeSystemUUID(context.Background(), testUID)
 
 		// assert
 		require.NotNil(t, actual)
@@ -79,7 +81,7 @@ func TestDynatraceClient_GetMonitoredEntitiesForKubeSystemUUID(t *testing.T) {
 		require.NotNil(t, dtc)
 
 		// act
-		actual, err := dtc.(*dynatraceClient).GetMonitoredEntitiesForKubeSystemUUID("")
+		actual, err := dtc.(*dynatraceClient).GetMonitoredEntitiesForKubeSystemUUID(context.Background(), "")
 
 		// assert
 		require.Nil(t, actual)
@@ -100,7 +102,7 @@ func TestDynatraceClient_GetMonitoredEntitiesForKubeSystemUUID(t *testing.T) {
 		require.NotNil(t, dtc)
 
 		// act
-		actual, err := dtc.(*dynatraceClient).GetMonitoredEntitiesForKubeSystemUUID(testUID)
+		actual, err := dtc.(*dynatraceClient).GetMonitoredEntitiesForKubeSystemUUID(context.Background(), testUID)
 
 		// assert
 		require.Nil(t, actual)
@@ -124,7 +126,7 @@ func TestDynatraceClient_GetSettingsForMonitoredEntities(t *testing.T) {
 		require.NotNil(t, dtc)
 
 		// act
-		actual, err := dtc.(*dynatraceClient).GetSettingsForMonitoredEntities(expected, SettingsSchemaId)
+		actual, err := dtc.(*dynatraceClient).GetSettingsForMonitoredEntities(context.Background(), expected, SettingsSchemaId)
 
 		// assert
 		assert.NoError(t, err)
@@ -147,7 +149,7 @@ func TestDynatraceClient_GetSettingsForMonitoredEntities(t *testing.T) {
 		require.NotNil(t, dtc)
 
 		// act
-		actual, err := dtc.(*dynatraceClient).GetSettingsForMonitoredEntities(expected, SettingsSchemaId)
+		actual, err := dtc.(*dynatraceClient).GetSettingsForMonitoredEntities(context.Background(), expected, SettingsSchemaId)
 
 		// assert
 		assert.NoError(t, err)
@@ -171,7 +173,7 @@ func TestDynatraceClient_GetSettingsForMonitoredEntities(t *testing.T) {
 		require.NotNil(t, dtc)
 
 		// act
-		actual, err := dtc.(*dynatraceClient).GetSettingsForMonitoredEntities(entities, SettingsSchemaId)
+		actual, err := dtc.(*dynatraceClient).GetSettingsForMonitoredEntities(context.Background(), entities, SettingsSchemaId)
 
 		// assert
 		assert.NoError(t, err)
@@ -194,7 +196,7 @@ func TestDynatraceClient_GetSettingsForMonitoredEntities(t *testing.T) {
 		require.NotNil(t, dtc)
 
 		// act
-		actual, err := dtc.(*dynatraceClient).GetSettingsForMonitoredEntities(entities, SettingsSchemaId)
+		actual, err := dtc.(*dynatraceClient).GetSettingsForMonitoredEntities(context.Background(), entities, SettingsSchemaId)
 
 		// assert
 		assert.Error(t, err)
@@ -214,7 +216,7 @@ func TestDynatraceClient_CreateOrUpdateKubernetesSetting(t *testing.T) {
 		require.NotNil(t, dtc)
 
 		// act
-		actual, err := dtc.(*dynatraceClient).CreateOrUpdateKubernetesSetting(testName, testUID, testScope)
+		actual, err := dtc.(*dynatraceClient).CreateOrUpdateKubernetesSetting(context.Background(), testName, testUID, testScope)
 
 		// assert
 		require.NotNil(t, actual)
@@ -234,7 +236,7 @@ func TestDynatraceClient_CreateOrUpdateKubernetesSetting(t *testing.T) {
 		require.NotNil(t, dtc)
 
 		// act
-		actual, err := dtc.(*dynatraceClient).CreateOrUpdateKubernetesSetting(testName, testUID, testScope)
+		actual, err := dtc.(*dynatraceClient).CreateOrUpdateKubernetesSetting(context.Background(), testName, testUID, testScope)
 
 		// assert
 		require.NotNil(t, actual)
@@ -254,7 +256,7 @@ func TestDynatraceClient_CreateOrUpdateKubernetesSetting(t *testing.T) {
 		require.NotNil(t, dtc)
 
 		// act
-		actual, err := dtc.(*dynatraceClient).CreateOrUpdateKubernetesSetting(testName, "", testScope)
+		actual, err := dtc.(*dynatraceClient).CreateOrUpdateKubernetesSetting(context.Background(), testName, "", testScope)
 
 		// assert
 		assert.Error(t, err)
@@ -272,7 +274,7 @@ func TestDynatraceClient_CreateOrUpdateKubernetesSetting(t *testing.T) {
 		require.NotNil(t, dtc)
 
 		// act
-		actual, err := dtc.(*dynatraceClient).CreateOrUpdateKubernetesSetting(testName, testUID, testScope)
+		actual, err := dtc.(*dynatraceClient).CreateOrUpdateKubernetesSetting(context.Background(), testName, testUID, testScope)
 
 		// assert
 		assert.Error(t, err)
@@ -291,7 +293,7 @@ func TestDynatraceClient_CreateOrUpdateKubernetesSetting(t *testing.T) {
 		require.NotNil(t, dtc)
 
 		// act
-		actual, err := dtc.(*dynatraceClient).CreateOrUpdateKubernetesSetting(testName, testUID, testScope)
+		actual, err := dtc.(*dynatraceClient).CreateOrUpdateKubernetesSetting(context.Background(), testName, testUID, testScope)
 
 		// assert
 		assert.Error(t, err)
@@ -312,7 +314,7 @@ func TestDynatraceClient_CreateOrUpdateAppKubernetesSetting(t *testing.T) {
 		require.NotNil(t, dtc)
 
 		// act
-		actual, err := dtc.(*dynatraceClient).CreateOrUpdateKubernetesAppSetting(testScope)
+		actual, err := dtc.(*dynatraceClient).CreateOrUpdateKubernetesAppSetting(context.Background(), testScope)
 
 		// assert
 		require.NotNil(t, actual)
@@ -332,7 +334,7 @@ func TestDynatraceClient_CreateOrUpdateAppKubernetesSetting(t *testing.T) {
 		require.NotNil(t, dtc)
 
 		// act
-		actual, err := dtc.(*dynatraceClient).CreateOrUpdateKubernetesAppSetting(testScope)
+		actual, err := dtc.(*dynatraceClient).CreateOrUpdateKubernetesAppSetting(context.Background(), testScope)
 
 		// assert
 		assert.Error(t, err)
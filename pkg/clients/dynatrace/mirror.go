@@ -0,0 +1,194 @@
+package dynatrace
+
+import (
+	"context"
+	"crypto/md5" //nolint:gosec
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/utils"
+	"github.com/pkg/errors"
+)
+
+// Layout of a mirror of the deployment API, relative to the mirror URL.
+// Agent packages are stored per os, installer type, flavor and architecture:
+//
+//	agent/{os}/{installerType}/{flavor}/{arch}/versions.json
+//	agent/{os}/{installerType}/{flavor}/{arch}/{version}.zip
+//	processmoduleconfig.json
+//	images.json
+const (
+	MirrorProcessModuleConfigPath = "processmoduleconfig.json"
+	MirrorImagesPath              = "images.json"
+
+	mirrorAgentDir          = "agent"
+	mirrorAgentVersionsFile = "versions.json"
+	mirrorAgentExtension    = ".zip"
+
+	mirrorSchemeFile = "file"
+)
+
+// MirrorAgentVersions is the content of the versions.json file of a mirror
+type MirrorAgentVersions struct {
+	LatestAgentVersion string   `json:"latestAgentVersion"`
+	AvailableVersions  []string `json:"availableVersions"`
+}
+
+// MirrorImages is the content of the images.json file of a mirror, images which weren't exported are nil
+type MirrorImages struct {
+	OneAgent    *LatestImageInfo `json:"oneAgent,omitempty"`
+	CodeModules *LatestImageInfo `json:"codeModules,omitempty"`
+	ActiveGate  *LatestImageInfo `json:"activeGate,omitempty"`
+}
+
+// MirrorAgentVersionsPath returns the path of the version list of the given agent package type
+func MirrorAgentVersionsPath(os, installerType, flavor, arch string) string {
+	return path.Join(mirrorAgentDir, os, installerType, flavor, arch, mirrorAgentVersionsFile)
+}
+
+// MirrorAgentPath returns the path of the agent package of the given type and version
+func MirrorAgentPath(os, installerType, flavor, arch, version string) string {
+	return path.Join(mirrorAgentDir, os, installerType, flavor, arch, version+mirrorAgentExtension)
+}
+
+// Mirror creates an Option that makes the client read agent packages, versions, the process module config and images
+// from a mirror created by the `dynatrace-operator mirror` command instead of the deployment API.
+// mirrorURL is either the URL of a web server or a file:// URL of a mounted directory.
+// Requests to other endpoints, like the connection info, are still sent to the Dynatrace API.
+func Mirror(mirrorURL string) Option {
+	return func(c *dynatraceClient) {
+		c.mirrorURL = strings.TrimSuffix(mirrorURL, "/")
+	}
+}
+
+func (dtc *dynatraceClient) usesMirror() bool {
+	return dtc.mirrorURL != ""
+}
+
+func (dtc *dynatraceClient) validateMirrorURL() error {
+	if !dtc.usesMirror() {
+		return nil
+	}
+
+	parsedURL, err := url.Parse(dtc.mirrorURL)
+	if err != nil {
+		return errors.WithMessagef(err, "invalid mirror url %s", dtc.mirrorURL)
+	}
+
+	switch parsedURL.Scheme {
+	case "http", "https", mirrorSchemeFile:
+		return nil
+	default:
+		return errors.Errorf("unsupported scheme of mirror url %s, must be http, https or file", dtc.mirrorURL)
+	}
+}
+
+// openFromMirror returns the content of the file at the given path of the mirror, it must be closed by the caller
+func (dtc *dynatraceClient) openFromMirror(ctx context.Context, filePath string) (io.ReadCloser, error) {
+	if strings.HasPrefix(dtc.mirrorURL, mirrorSchemeFile+"://") {
+		parsedURL, err := url.Parse(dtc.mirrorURL)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		file, err := os.Open(filepath.Join(parsedURL.Path, filepath.FromSlash(filePath)))
+		return file, errors.WithMessage(err, "failed to read from mirror")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, dtc.mirrorURL+"/"+filePath, nil)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error initializing http request")
+	}
+
+	resp, err := dtc.doRequest(req)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to read from mirror")
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		utils.CloseBodyAfterRequest(resp)
+		return nil, errors.Errorf("failed to read %s from mirror, status code %d", filePath, resp.StatusCode)
+	}
+
+	return resp.Body, nil
+}
+
+func (dtc *dynatraceClient) readFromMirror(ctx context.Context, filePath string, value any) error {
+	content, err := dtc.openFromMirror(ctx, filePath)
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+
+	return errors.WithMessagef(json.NewDecoder(content).Decode(value), "invalid content of %s in mirror", filePath)
+}
+
+func (dtc *dynatraceClient) getAgentVersionsFromMirror(ctx context.Context, os, installerType, flavor, arch string) (MirrorAgentVersions, error) {
+	var versions MirrorAgentVersions
+	err := dtc.readFromMirror(ctx, MirrorAgentVersionsPath(os, installerType, flavor, arch), &versions)
+	return versions, err
+}
+
+func (dtc *dynatraceClient) getLatestAgentVersionFromMirror(ctx context.Context, os, installerType, flavor, arch string) (string, error) {
+	versions, err := dtc.getAgentVersionsFromMirror(ctx, os, installerType, flavor, arch)
+	if err != nil {
+		return "", err
+	}
+
+	if versions.LatestAgentVersion == "" {
+		return "", errors.Errorf("no latest version of %s/%s/%s/%s in mirror", os, installerType, flavor, arch)
+	}
+
+	return versions.LatestAgentVersion, nil
+}
+
+// getAgentFromMirror writes the mirrored agent package, the package contains the technologies it was exported with
+func (dtc *dynatraceClient) getAgentFromMirror(ctx context.Context, os, installerType, flavor, arch, version string, writer io.Writer) error {
+	content, err := dtc.openFromMirror(download(ctx), MirrorAgentPath(os, installerType, flavor, arch, version))
+	if err != nil {
+		return err
+	}
+	defer content.Close()
+
+	hash := md5.New() //nolint:gosec
+	if _, err := io.Copy(writer, io.TeeReader(content, hash)); err != nil {
+		return errors.WithStack(err)
+	}
+
+	log.Info("read agent file from mirror", "os", os, "type", installerType, "flavor", flavor, "arch", arch, "version", version, "md5", hex.EncodeToString(hash.Sum(nil)))
+	return nil
+}
+
+func (dtc *dynatraceClient) getProcessModuleConfigFromMirror(ctx context.Context, prevRevision uint) (*ProcessModuleConfig, error) {
+	var processModuleConfig ProcessModuleConfig
+	if err := dtc.readFromMirror(ctx, MirrorProcessModuleConfigPath, &processModuleConfig); err != nil {
+		return nil, err
+	}
+
+	if prevRevision != 0 && processModuleConfig.Revision <= prevRevision {
+		return &ProcessModuleConfig{}, nil
+	}
+
+	return &processModuleConfig, nil
+}
+
+func (dtc *dynatraceClient) getLatestImageFromMirror(ctx context.Context, image func(MirrorImages) *LatestImageInfo) (*LatestImageInfo, error) {
+	var images MirrorImages
+	if err := dtc.readFromMirror(ctx, MirrorImagesPath, &images); err != nil {
+		return nil, err
+	}
+
+	latestImageInfo := image(images)
+	if latestImageInfo == nil {
+		return nil, errors.New("image is not contained in mirror")
+	}
+
+	return latestImageInfo, nil
+}
@@ -0,0 +1,147 @@
+package dynatrace
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/arch"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testMirrorVersion    = "1.281.0.20231116-123456"
+	testMirrorOldVersion = "1.279.0.20231018-123456"
+	testMirrorAgent      = "mirrored-zip-content"
+)
+
+func TestMirror(t *testing.T) {
+	mirrorDir := createTestMirror(t)
+
+	fileServer := httptest.NewServer(http.FileServer(http.Dir(mirrorDir)))
+	defer fileServer.Close()
+
+	apiServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		t.Errorf("unexpected request to the Dynatrace API: %s", request.URL.Path)
+		writer.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer apiServer.Close()
+
+	for mode, mirrorURL := range map[string]string{
+		"directory":  "file://" + mirrorDir,
+		"web server": fileServer.URL + "/",
+	} {
+		t.Run(mode, func(t *testing.T) {
+			dtc, err := NewClient(apiServer.URL, apiToken, paasToken, Mirror(mirrorURL))
+			require.NoError(t, err)
+
+			testMirrorAgentVersions(t, dtc)
+			testMirrorAgents(t, dtc)
+			testMirrorProcessModuleConfig(t, dtc)
+			testMirrorImages(t, dtc)
+		})
+	}
+}
+
+func TestMirrorURL(t *testing.T) {
+	t.Run("unsupported scheme", func(t *testing.T) {
+		_, err := NewClient("https://test.live.dynatrace.com/api", apiToken, paasToken, Mirror("ftp://mirror.example.com"))
+		require.Error(t, err)
+	})
+	t.Run("missing files", func(t *testing.T) {
+		dtc, err := NewClient("https://test.live.dynatrace.com/api", apiToken, paasToken, Mirror("file://"+t.TempDir()))
+		require.NoError(t, err)
+
+		_, err = dtc.GetAgentVersions(context.Background(), OsUnix, InstallerTypePaaS, arch.FlavorDefault, arch.ArchX86)
+		require.Error(t, err)
+		_, err = dtc.GetLatestOneAgentImage(context.Background())
+		require.Error(t, err)
+	})
+}
+
+func testMirrorAgentVersions(t *testing.T, dtc Client) {
+	versions, err := dtc.GetAgentVersions(context.Background(), OsUnix, InstallerTypePaaS, arch.FlavorDefault, arch.ArchX86)
+	require.NoError(t, err)
+	assert.Equal(t, []string{testMirrorVersion, testMirrorOldVersion}, versions)
+
+	latestVersion, err := dtc.GetLatestAgentVersion(context.Background(), OsUnix, InstallerTypeDefault)
+	require.NoError(t, err)
+	assert.Equal(t, testMirrorVersion, latestVersion)
+}
+
+func testMirrorAgents(t *testing.T, dtc Client) {
+	var agent bytes.Buffer
+	err := dtc.GetAgent(context.Background(), OsUnix, InstallerTypePaaS, arch.FlavorDefault, arch.ArchX86, testMirrorOldVersion, nil, false, &agent)
+	require.NoError(t, err)
+	assert.Equal(t, testMirrorAgent+testMirrorOldVersion, agent.String())
+
+	agent.Reset()
+	err = dtc.GetLatestAgent(context.Background(), OsUnix, InstallerTypePaaS, arch.FlavorDefault, arch.ArchX86, nil, false, &agent)
+	require.NoError(t, err)
+	assert.Equal(t, testMirrorAgent+testMirrorVersion, agent.String())
+
+	err = dtc.GetAgent(context.Background(), OsUnix, InstallerTypePaaS, arch.FlavorDefault, arch.ArchX86, "1.200.0.20200101-000000", nil, false, &agent)
+	require.Error(t, err)
+}
+
+func testMirrorProcessModuleConfig(t *testing.T, dtc Client) {
+	processModuleConfig, err := dtc.GetProcessModuleConfig(context.Background(), 0)
+	require.NoError(t, err)
+	assert.Equal(t, uint(3), processModuleConfig.Revision)
+	assert.Len(t, processModuleConfig.Properties, 1)
+
+	processModuleConfig, err = dtc.GetProcessModuleConfig(context.Background(), 3)
+	require.NoError(t, err)
+	assert.True(t, processModuleConfig.IsEmpty())
+}
+
+func testMirrorImages(t *testing.T, dtc Client) {
+	image, err := dtc.GetLatestOneAgentImage(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "registry.example.com/linux/oneagent:1.281.0", image.String())
+
+	_, err = dtc.GetLatestActiveGateImage(context.Background())
+	require.Error(t, err, "the activegate image wasn't exported")
+}
+
+func createTestMirror(t *testing.T) string {
+	mirrorDir := t.TempDir()
+
+	writeFile := func(filePath string, content []byte) {
+		target := filepath.Join(mirrorDir, filepath.FromSlash(filePath))
+		require.NoError(t, os.MkdirAll(filepath.Dir(target), 0755))
+		require.NoError(t, os.WriteFile(target, content, 0644))
+	}
+	writeJSON := func(filePath string, value any) {
+		content, err := json.Marshal(value)
+		require.NoError(t, err)
+		writeFile(filePath, content)
+	}
+
+	versions := MirrorAgentVersions{
+		LatestAgentVersion: testMirrorVersion,
+		AvailableVersions:  []string{testMirrorVersion, testMirrorOldVersion},
+	}
+	writeJSON(MirrorAgentVersionsPath(OsUnix, InstallerTypePaaS, arch.FlavorDefault, arch.ArchX86), versions)
+	writeJSON(MirrorAgentVersionsPath(OsUnix, InstallerTypeDefault, arch.FlavorDefault, arch.Arch), versions)
+
+	for _, version := range versions.AvailableVersions {
+		writeFile(MirrorAgentPath(OsUnix, InstallerTypePaaS, arch.FlavorDefault, arch.ArchX86, version), []byte(testMirrorAgent+version))
+	}
+
+	writeJSON(MirrorProcessModuleConfigPath, ProcessModuleConfig{
+		Revision:   3,
+		Properties: []ProcessModuleProperty{{Section: "general", Key: "key", Value: "value"}},
+	})
+	writeJSON(MirrorImagesPath, MirrorImages{
+		OneAgent: &LatestImageInfo{Source: "registry.example.com/linux/oneagent", Tag: "1.281.0"},
+	})
+
+	return mirrorDir
+}
@@ -0,0 +1,177 @@
+package dynatrace
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/apierrors"
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+const (
+	// DefaultOAuthTokenURL is the token endpoint of Dynatrace SSO
+	DefaultOAuthTokenURL = "https://sso.dynatrace.com/sso/oauth2/token"
+
+	oauthResourceParam = "resource"
+	oauthScopeField    = "scope"
+)
+
+// Known OAuth scopes
+const (
+	OAuthScopeInstallerDownload     = "environment-api:deployment:download"
+	OAuthScopeMetricsIngest         = "environment-api:metrics:write"
+	OAuthScopeEntitiesRead          = "environment-api:entities:read"
+	OAuthScopeSettingsRead          = "settings:objects:read"
+	OAuthScopeSettingsWrite         = "settings:objects:write"
+	OAuthScopeActiveGateTokenCreate = "environment-api:activegate-tokens:create"
+	OAuthScopeEventsIngest          = "environment-api:events:write"
+)
+
+var tokenScopesToOAuthScopes = map[string]string{
+	TokenScopeInstallerDownload:     OAuthScopeInstallerDownload,
+	TokenScopeDataExport:            OAuthScopeEntitiesRead,
+	TokenScopeMetricsIngest:         OAuthScopeMetricsIngest,
+	TokenScopeEntitiesRead:          OAuthScopeEntitiesRead,
+	TokenScopeSettingsRead:          OAuthScopeSettingsRead,
+	TokenScopeSettingsWrite:         OAuthScopeSettingsWrite,
+	TokenScopeActiveGateTokenCreate: OAuthScopeActiveGateTokenCreate,
+	TokenScopeEventsIngest:          OAuthScopeEventsIngest,
+}
+
+// OAuthScope returns the OAuth scope which grants the same permissions as the given api token scope.
+// Unknown scopes are returned unchanged.
+func OAuthScope(tokenScope string) string {
+	if oauthScope, ok := tokenScopesToOAuthScopes[tokenScope]; ok {
+		return oauthScope
+	}
+	return tokenScope
+}
+
+// OAuthScopes maps the given api token scopes to OAuth scopes, every OAuth scope is contained only once
+func OAuthScopes(tokenScopes []string) []string {
+	oauthScopes := make([]string, 0, len(tokenScopes))
+	for _, tokenScope := range tokenScopes {
+		oauthScope := OAuthScope(tokenScope)
+		if !TokenScopes(oauthScopes).Contains(oauthScope) {
+			oauthScopes = append(oauthScopes, oauthScope)
+		}
+	}
+	return oauthScopes
+}
+
+// OAuthClientCredentials creates an Option that authenticates all requests against the Dynatrace API
+// with an access token fetched via the OAuth client credentials flow instead of the api and paas token.
+// The access token is refreshed when it expires.
+// If tokenURL is empty, Dynatrace SSO is used; resource is the URN of the account or environment and may be empty.
+func OAuthClientCredentials(clientID, clientSecret, tokenURL, resource string, scopes []string) Option {
+	return func(c *dynatraceClient) {
+		if tokenURL == "" {
+			tokenURL = DefaultOAuthTokenURL
+		}
+
+		c.oauthConfig = &clientcredentials.Config{
+			ClientID:     clientID,
+			ClientSecret: clientSecret,
+			TokenURL:     tokenURL,
+			Scopes:       scopes,
+			AuthStyle:    oauth2.AuthStyleInParams,
+		}
+
+		if resource != "" {
+			c.oauthConfig.EndpointParams = url.Values{oauthResourceParam: []string{resource}}
+		}
+	}
+}
+
+func (dtc *dynatraceClient) usesOAuth() bool {
+	return dtc.oauthConfig != nil
+}
+
+// setupOAuth has to run after all other options, so the token requests use the same proxy and certificates as the api requests
+func (dtc *dynatraceClient) setupOAuth() error {
+	if !dtc.usesOAuth() {
+		return nil
+	}
+
+	if dtc.oauthConfig.ClientID == "" || dtc.oauthConfig.ClientSecret == "" {
+		return errors.New("oauth client id or secret is empty")
+	}
+
+	dtc.oauthToken = &oauthToken{
+		config: dtc.oauthConfig,
+		httpClient: &http.Client{
+			Transport: dtc.httpClient.Transport,
+			Timeout:   dtc.httpClient.Timeout,
+		},
+	}
+
+	return nil
+}
+
+// oauthToken caches the access token of the OAuth client until it expires.
+// A new access token is fetched with the context of the request that needs it, so it can be canceled with the request.
+type oauthToken struct {
+	config     *clientcredentials.Config
+	httpClient *http.Client
+	token      *oauth2.Token
+	mutex      sync.Mutex
+}
+
+func (cached *oauthToken) get(ctx context.Context) (*oauth2.Token, error) {
+	cached.mutex.Lock()
+	defer cached.mutex.Unlock()
+
+	if cached.token.Valid() {
+		return cached.token, nil
+	}
+
+	token, err := cached.config.Token(context.WithValue(ctx, oauth2.HTTPClient, cached.httpClient))
+	if err != nil {
+		return nil, errors.WithMessage(apierrors.FromTransport(err), "failed to fetch oauth access token")
+	}
+
+	cached.token = token
+
+	return token, nil
+}
+
+// authorizationHeader returns the value of the Authorization header for a request which would be authenticated by the given token.
+// If an OAuth client is configured, the access token of the OAuth client is used instead.
+func (dtc *dynatraceClient) authorizationHeader(ctx context.Context, token string) (string, error) {
+	if !dtc.usesOAuth() {
+		return fmt.Sprintf("Api-Token %s", token), nil
+	}
+
+	oauthToken, err := dtc.oauthToken.get(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s %s", oauthToken.Type(), oauthToken.AccessToken), nil
+}
+
+// GetOAuthScopes returns the scopes granted to the OAuth client.
+// If the token endpoint doesn't list the granted scopes, they are identical to the requested ones.
+func (dtc *dynatraceClient) GetOAuthScopes(ctx context.Context) (TokenScopes, error) {
+	if !dtc.usesOAuth() {
+		return nil, errors.New("no oauth client configured")
+	}
+
+	oauthToken, err := dtc.oauthToken.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	grantedScopes, ok := oauthToken.Extra(oauthScopeField).(string)
+	if !ok || grantedScopes == "" {
+		return dtc.oauthConfig.Scopes, nil
+	}
+
+	return strings.Fields(grantedScopes), nil
+}
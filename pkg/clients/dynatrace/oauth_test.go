@@ -0,0 +1,140 @@
+package dynatrace
+
+import (
+	"context"
+
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testOAuthClientID     = "client-id"
+	testOAuthClientSecret = "client-secret"
+	testOAuthResource     = "urn:dtaccount:test"
+	testOAuthAccessToken  = "access-token"
+)
+
+func oauthServerHandler(t *testing.T, grantedScopes string, tokenRequests *int) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		switch request.URL.Path {
+		case "/sso/oauth2/token":
+			*tokenRequests++
+			require.NoError(t, request.ParseForm())
+			assert.Equal(t, "client_credentials", request.PostForm.Get("grant_type"))
+			assert.Equal(t, testOAuthClientID, request.PostForm.Get("client_id"))
+			assert.Equal(t, testOAuthClientSecret, request.PostForm.Get("client_secret"))
+			assert.Equal(t, testOAuthResource, request.PostForm.Get("resource"))
+
+			response := map[string]any{
+				"access_token": testOAuthAccessToken,
+				"token_type":   "Bearer",
+				"expires_in":   300,
+			}
+			if grantedScopes != "" {
+				response["scope"] = grantedScopes
+			}
+
+			writer.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(writer).Encode(response)
+		case "/v1/deployment/installer/agent/connectioninfo":
+			if request.Header.Get("Authorization") != "Bearer "+testOAuthAccessToken {
+				writeError(writer, http.StatusUnauthorized)
+				return
+			}
+			_, _ = writer.Write([]byte(`{"tenantUUID": "abc123456", "tenantToken": "token"}`))
+		default:
+			writeError(writer, http.StatusNotFound)
+		}
+	}
+}
+
+func TestOAuthClientCredentials(t *testing.T) {
+	t.Run("api requests use the access token of the oauth client", func(t *testing.T) {
+		tokenRequests := 0
+		server := httptest.NewServer(oauthServerHandler(t, "", &tokenRequests))
+		defer server.Close()
+
+		dtc, err := NewClient(server.URL, "", "",
+			OAuthClientCredentials(testOAuthClientID, testOAuthClientSecret, server.URL+"/sso/oauth2/token", testOAuthResource, []string{OAuthScopeInstallerDownload}))
+		require.NoError(t, err)
+
+		connectionInfo, err := dtc.GetOneAgentConnectionInfo(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "abc123456", connectionInfo.TenantUUID)
+
+		_, err = dtc.GetOneAgentConnectionInfo(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 1, tokenRequests, "access token is reused until it expires")
+	})
+	t.Run("granted scopes are read from the token response", func(t *testing.T) {
+		tokenRequests := 0
+		server := httptest.NewServer(oauthServerHandler(t, OAuthScopeInstallerDownload+" "+OAuthScopeSettingsRead, &tokenRequests))
+		defer server.Close()
+
+		dtc, err := NewClient(server.URL, "", "",
+			OAuthClientCredentials(testOAuthClientID, testOAuthClientSecret, server.URL+"/sso/oauth2/token", testOAuthResource, []string{OAuthScopeInstallerDownload}))
+		require.NoError(t, err)
+
+		scopes, err := dtc.GetOAuthScopes(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, TokenScopes{OAuthScopeInstallerDownload, OAuthScopeSettingsRead}, scopes)
+	})
+	t.Run("requested scopes are granted if the token response doesn't list scopes", func(t *testing.T) {
+		tokenRequests := 0
+		server := httptest.NewServer(oauthServerHandler(t, "", &tokenRequests))
+		defer server.Close()
+
+		dtc, err := NewClient(server.URL, "", "",
+			OAuthClientCredentials(testOAuthClientID, testOAuthClientSecret, server.URL+"/sso/oauth2/token", testOAuthResource, []string{OAuthScopeInstallerDownload}))
+		require.NoError(t, err)
+
+		scopes, err := dtc.GetOAuthScopes(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, TokenScopes{OAuthScopeInstallerDownload}, scopes)
+	})
+	t.Run("access token is fetched with the context of the caller", func(t *testing.T) {
+		tokenRequests := 0
+		server := httptest.NewServer(oauthServerHandler(t, "", &tokenRequests))
+		defer server.Close()
+
+		dtc, err := NewClient(server.URL, "", "",
+			OAuthClientCredentials(testOAuthClientID, testOAuthClientSecret, server.URL+"/sso/oauth2/token", testOAuthResource, []string{OAuthScopeInstallerDownload}))
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err = dtc.GetOAuthScopes(ctx)
+		require.Error(t, err)
+		assert.Equal(t, 0, tokenRequests)
+
+		_, err = dtc.GetOAuthScopes(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 1, tokenRequests)
+	})
+	t.Run("empty client credentials", func(t *testing.T) {
+		_, err := NewClient("https://aabb.live.dynatrace.com/api", "", "",
+			OAuthClientCredentials("", "", "", "", nil))
+		assert.Error(t, err)
+	})
+	t.Run("no oauth client configured", func(t *testing.T) {
+		dtc, err := NewClient("https://aabb.live.dynatrace.com/api", "foo", "bar")
+		require.NoError(t, err)
+
+		_, err = dtc.GetOAuthScopes(context.Background())
+		assert.Error(t, err)
+	})
+}
+
+func TestOAuthScopes(t *testing.T) {
+	assert.Equal(t, OAuthScopeSettingsWrite, OAuthScope(TokenScopeSettingsWrite))
+	assert.Equal(t, "unknown", OAuthScope("unknown"))
+	assert.Equal(t,
+		[]string{OAuthScopeEntitiesRead, OAuthScopeSettingsRead},
+		OAuthScopes([]string{TokenScopeDataExport, TokenScopeEntitiesRead, TokenScopeSettingsRead}))
+}
@@ -1,6 +1,8 @@
 package dynatrace
 
 import (
+	"context"
+
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -45,7 +47,7 @@ func Test_GetOneAgentConnectionInfo(t *testing.T) {
 		dynatraceServer, dynatraceClient := createTestDynatraceServer(t, connectionInfoServerHandler(oneAgentConnectionInfoEndpoint, oneAgentJsonResponse), "")
 		defer dynatraceServer.Close()
 
-		connectionInfo, err := dynatraceClient.GetOneAgentConnectionInfo()
+		connectionInfo, err := dynatraceClient.GetOneAgentConnectionInfo(context.Background())
 		assert.NoError(t, err)
 		assert.NotNil(t, connectionInfo)
 
@@ -56,7 +58,7 @@ func Test_GetOneAgentConnectionInfo(t *testing.T) {
 		dynatraceServer, dynatraceClient := createTestDynatraceServer(t, connectionInfoServerHandler(oneAgentConnectionInfoEndpoint, oneAgentJsonResponseWithDups), "")
 		defer dynatraceServer.Close()
 
-		connectionInfo, err := dynatraceClient.GetOneAgentConnectionInfo()
+		connectionInfo, err := dynatraceClient.GetOneAgentConnectionInfo(context.Background())
 		assert.NoError(t, err)
 		assert.NotNil(t, connectionInfo)
 
@@ -66,7 +68,7 @@ func Test_GetOneAgentConnectionInfo(t *testing.T) {
 		dynatraceServer, dynatraceClient := createTestDynatraceServer(t, connectionInfoServerHandler(oneAgentConnectionInfoEndpoint, oneAgentJsonResponse), "nz")
 		defer dynatraceServer.Close()
 
-		connectionInfo, err := dynatraceClient.GetOneAgentConnectionInfo()
+		connectionInfo, err := dynatraceClient.GetOneAgentConnectionInfo(context.Background())
 		assert.NoError(t, err)
 		assert.NotNil(t, connectionInfo)
 
@@ -82,7 +84,7 @@ func Test_GetOneAgentConnectionInfo(t *testing.T) {
 		dynatraceServer, dynatraceClient := createTestDynatraceServer(t, connectionInfoServerHandler(oneAgentConnectionInfoEndpoint, oneAgentJsonResponse), "")
 		defer dynatraceServer.Close()
 
-		connectionInfo, err := dynatraceClient.GetOneAgentConnectionInfo()
+		connectionInfo, err := dynatraceClient.GetOneAgentConnectionInfo(context.Background())
 		assert.NoError(t, err)
 		assert.NotNil(t, connectionInfo)
 
@@ -92,7 +94,7 @@ func Test_GetOneAgentConnectionInfo(t *testing.T) {
 		dynatraceServer, dynatraceClient := createTestDynatraceServer(t, connectionInfoServerHandler(oneAgentConnectionInfoEndpoint, oneAgentJsonResponse), "")
 		defer dynatraceServer.Close()
 
-		connectionInfo, err := dynatraceClient.GetOneAgentConnectionInfo()
+		connectionInfo, err := dynatraceClient.GetOneAgentConnectionInfo(context.Background())
 		assert.NoError(t, err)
 		assert.NotNil(t, connectionInfo)
 
@@ -102,7 +104,7 @@ func Test_GetOneAgentConnectionInfo(t *testing.T) {
 		faultyDynatraceServer, faultyDynatraceClient := createTestDynatraceServer(t, tenantMalformedJson(oneAgentConnectionInfoEndpoint), "")
 		defer faultyDynatraceServer.Close()
 
-		connectionInfo, err := faultyDynatraceClient.GetOneAgentConnectionInfo()
+		connectionInfo, err := faultyDynatraceClient.GetOneAgentConnectionInfo(context.Background())
 		assert.Error(t, err)
 		assert.Equal(t, "invalid character 'h' in literal true (expecting 'r')", err.Error())
 
@@ -113,7 +115,7 @@ func Test_GetOneAgentConnectionInfo(t *testing.T) {
 		faultyDynatraceServer, faultyDynatraceClient := createTestDynatraceServer(t, tenantInternalServerError(oneAgentConnectionInfoEndpoint), "")
 		defer faultyDynatraceServer.Close()
 
-		connectionInfo, err := faultyDynatraceClient.GetOneAgentConnectionInfo()
+		connectionInfo, err := faultyDynatraceClient.GetOneAgentConnectionInfo(context.Background())
 		assert.Error(t, err)
 		assert.NotNil(t, connectionInfo)
 		assert.Equal(t, OneAgentConnectionInfo{}, connectionInfo)
@@ -1,6 +1,7 @@
 package dynatrace
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -135,13 +136,17 @@ func (pmc ProcessModuleConfig) IsEmpty() bool {
 	return len(pmc.Properties) == 0
 }
 
-func (dtc *dynatraceClient) GetProcessModuleConfig(prevRevision uint) (*ProcessModuleConfig, error) {
-	req, err := dtc.createProcessModuleConfigRequest(prevRevision)
+func (dtc *dynatraceClient) GetProcessModuleConfig(ctx context.Context, prevRevision uint) (*ProcessModuleConfig, error) {
+	if dtc.usesMirror() {
+		return dtc.getProcessModuleConfigFromMirror(ctx, prevRevision)
+	}
+
+	req, err := dtc.createProcessModuleConfigRequest(ctx, prevRevision)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := dtc.httpClient.Do(req)
+	resp, err := dtc.doRequest(req)
 
 	if dtc.checkProcessModuleConfigRequestStatus(resp) {
 		return &ProcessModuleConfig{}, nil
@@ -159,16 +164,20 @@ func (dtc *dynatraceClient) GetProcessModuleConfig(prevRevision uint) (*ProcessM
 	return dtc.readResponseForProcessModuleConfig(responseData)
 }
 
-func (dtc *dynatraceClient) createProcessModuleConfigRequest(prevRevision uint) (*http.Request, error) {
-	req, err := http.NewRequest(http.MethodGet, dtc.getProcessModuleConfigUrl(), nil)
+func (dtc *dynatraceClient) createProcessModuleConfigRequest(ctx context.Context, prevRevision uint) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, dtc.getProcessModuleConfigUrl(), nil)
 	if err != nil {
 		return nil, fmt.Errorf("error initializing http request: %w", err)
 	}
 	query := req.URL.Query()
 	query.Add("revision", strconv.FormatUint(uint64(prevRevision), 10))
 	req.URL.RawQuery = query.Encode()
+	authHeader, err := dtc.authorizationHeader(ctx, dtc.paasToken)
+	if err != nil {
+		return nil, err
+	}
 	req.Header.Add("Content-Type", "application/json")
-	req.Header.Add("Authorization", fmt.Sprintf("Api-Token %s", dtc.paasToken))
+	req.Header.Add("Authorization", authHeader)
 
 	return req, nil
 }
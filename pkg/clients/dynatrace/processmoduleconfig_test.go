@@ -1,6 +1,8 @@
 package dynatrace
 
 import (
+	"context"
+
 	"fmt"
 	"net/http"
 	"testing"
@@ -33,7 +35,7 @@ func TestCreateProcessModuleConfigRequest(t *testing.T) {
 	}
 	require.NotNil(t, dc)
 
-	req, err := dc.createProcessModuleConfigRequest(0)
+	req, err := dc.createProcessModuleConfigRequest(context.Background(), 0)
 	require.Nil(t, err)
 	assert.Equal(t, "0", req.URL.Query().Get("revision"))
 	assert.Contains(t, req.Header.Get("Authorization"), dc.paasToken)
@@ -0,0 +1,267 @@
+package dynatrace
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/apierrors"
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/utils"
+	"github.com/pkg/errors"
+)
+
+const (
+	DefaultMaxRetries     = 3
+	DefaultInitialBackoff = time.Second
+	DefaultMaxBackoff     = 30 * time.Second
+	DefaultRequestTimeout = 2 * time.Minute
+
+	retryAfterHeader     = "Retry-After"
+	rateLimitResetHeader = "X-RateLimit-Reset"
+)
+
+// RetryPolicy defines how often and how long the client waits before repeating a request which failed with a transient error.
+// Transient errors are timeouts, refused or reset connections and the status codes 429, 502, 503 and 504.
+// Only GET and HEAD requests and requests marked as idempotent are repeated.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt, 0 disables retries
+	MaxRetries int
+
+	// InitialBackoff is the upper bound of the wait time before the first retry, it doubles with every further retry
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the wait time between two attempts, including the waits requested by the server
+	MaxBackoff time.Duration
+
+	// RequestTimeout limits every attempt of a request, including reading the response, 0 disables the limit.
+	// Downloads of agent packages aren't limited by it.
+	RequestTimeout time.Duration
+}
+
+// DefaultRetryPolicy returns the retry policy the client uses if none is configured
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries:     DefaultMaxRetries,
+		InitialBackoff: DefaultInitialBackoff,
+		MaxBackoff:     DefaultMaxBackoff,
+		RequestTimeout: DefaultRequestTimeout,
+	}
+}
+
+// Retries creates an Option that sets the retry policy of the client
+func Retries(policy RetryPolicy) Option {
+	return func(c *dynatraceClient) {
+		c.retryPolicy = policy
+	}
+}
+
+// backoff returns the wait time before the given retry (starting with 1) using exponential backoff with full jitter.
+func (policy RetryPolicy) backoff(retry int) time.Duration {
+	ceiling := policy.InitialBackoff << (retry - 1)
+	if ceiling <= 0 || ceiling > policy.MaxBackoff {
+		ceiling = policy.MaxBackoff
+	}
+
+	if ceiling <= 0 {
+		return 0
+	}
+
+	return time.Duration(rand.Int63n(int64(ceiling) + 1)) //nolint:gosec // jitter doesn't need a secure random number
+}
+
+type requestMark int
+
+const (
+	idempotentMark requestMark = iota
+	downloadMark
+)
+
+// idempotent marks the requests created with the returned context as safe to repeat, even if their method is neither GET nor HEAD
+func idempotent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, idempotentMark, true)
+}
+
+// download marks the requests created with the returned context as downloads, which aren't limited by the request timeout of the retry policy
+func download(ctx context.Context) context.Context {
+	return context.WithValue(ctx, downloadMark, true)
+}
+
+func isMarked(req *http.Request, mark requestMark) bool {
+	marked, _ := req.Context().Value(mark).(bool)
+	return marked
+}
+
+func isRetryable(req *http.Request) bool {
+	return req.Method == http.MethodGet || req.Method == http.MethodHead || isMarked(req, idempotentMark)
+}
+
+// doRequest sends the request and repeats it on transient errors according to the retry policy of the client.
+// Every attempt gets its own deadline derived from the context of the request, it ends when the body of the response is closed.
+// The context of the request cancels the request as well as the waits between attempts.
+// Errors of the transport are classified as apierrors.ErrNetwork or apierrors.ErrTLS.
+func (dtc *dynatraceClient) doRequest(req *http.Request) (*http.Response, error) {
+	maxRetries := dtc.retryPolicy.MaxRetries
+	if !isRetryable(req) {
+		maxRetries = 0
+	}
+
+	for retry := 0; ; retry++ {
+		if retry > 0 {
+			if err := dtc.rewindBody(req); err != nil {
+				return nil, err
+			}
+		}
+
+		response, err := dtc.doAttempt(req)
+		if retry >= maxRetries || !isTransient(req.Context(), response, err) {
+			return response, apierrors.FromTransport(err)
+		}
+
+		wait := dtc.retryPolicy.backoff(retry + 1)
+		if serverWait, ok := waitRequestedByServer(response, dtc.now); ok {
+			wait = min(serverWait, dtc.retryPolicy.MaxBackoff)
+		}
+
+		log.Info("transient error on request to the dynatrace api, retrying",
+			"url", req.URL.Redacted(), "status", statusOf(response), "error", err, "retry", retry+1, "wait", wait)
+
+		utils.CloseBodyAfterRequest(response)
+
+		if err := sleep(req.Context(), wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// doAttempt sends the request once, limited by the request timeout of the retry policy
+func (dtc *dynatraceClient) doAttempt(req *http.Request) (*http.Response, error) {
+	if dtc.retryPolicy.RequestTimeout <= 0 || isMarked(req, downloadMark) {
+		return dtc.httpClient.Do(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), dtc.retryPolicy.RequestTimeout)
+
+	response, err := dtc.httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	response.Body = cancelOnClose{ReadCloser: response.Body, cancel: cancel}
+
+	return response, nil
+}
+
+// cancelOnClose releases the deadline of an attempt once its response is read
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (body cancelOnClose) Close() error {
+	defer body.cancel()
+	return body.ReadCloser.Close()
+}
+
+func (dtc *dynatraceClient) rewindBody(req *http.Request) error {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil
+	}
+
+	if req.GetBody == nil {
+		return errors.New("can't retry request, its body can't be read again")
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	req.Body = body
+
+	return nil
+}
+
+func isTransient(ctx context.Context, response *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	if err != nil {
+		return isTransientTransportError(err)
+	}
+
+	switch response.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+
+	return false
+}
+
+// isTransientTransportError returns true for timeouts and refused or reset connections, tls and certificate errors are never transient
+func isTransientTransportError(err error) bool {
+	if errors.Is(apierrors.FromTransport(err), apierrors.ErrTLS) {
+		return false
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED)
+}
+
+// waitRequestedByServer reads the Retry-After header (seconds or http date) or, if missing, the X-RateLimit-Reset header (unix timestamp in microseconds)
+func waitRequestedByServer(response *http.Response, now time.Time) (time.Duration, bool) {
+	if response == nil {
+		return 0, false
+	}
+
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	if retryAfter := response.Header.Get(retryAfterHeader); retryAfter != "" {
+		if seconds, err := strconv.ParseInt(retryAfter, 10, 64); err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second, true
+		}
+
+		if date, err := http.ParseTime(retryAfter); err == nil {
+			return max(date.Sub(now), 0), true
+		}
+	}
+
+	if reset := response.Header.Get(rateLimitResetHeader); reset != "" {
+		if microseconds, err := strconv.ParseInt(reset, 10, 64); err == nil && microseconds > 0 {
+			return max(time.UnixMicro(microseconds).Sub(now), 0), true
+		}
+	}
+
+	return 0, false
+}
+
+func sleep(ctx context.Context, wait time.Duration) error {
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return errors.WithStack(ctx.Err())
+	case <-timer.C:
+		return nil
+	}
+}
+
+func statusOf(response *http.Response) int {
+	if response == nil {
+		return 0
+	}
+	return response.StatusCode
+}
@@ -0,0 +1,291 @@
+package dynatrace
+
+import (
+	"context"
+	"crypto/x509"
+	"io"
+	stdlog "log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/apierrors"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testConnectionInfoResponse = `{"tenantUUID": "abc123456", "tenantToken": "token"}`
+
+func newRetryTestClient(server *httptest.Server, policy RetryPolicy) *dynatraceClient {
+	return &dynatraceClient{
+		url:         server.URL,
+		apiToken:    apiToken,
+		paasToken:   paasToken,
+		httpClient:  server.Client(),
+		retryPolicy: policy,
+	}
+}
+
+func failingHandler(failures int, status int, header http.Header, requests *int) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		*requests++
+		if *requests <= failures {
+			for key, values := range header {
+				writer.Header()[key] = values
+			}
+			writeError(writer, status)
+			return
+		}
+		_, _ = writer.Write([]byte(testConnectionInfoResponse))
+	}
+}
+
+func testRetryPolicy(maxRetries int) RetryPolicy {
+	return RetryPolicy{
+		MaxRetries:     maxRetries,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     10 * time.Millisecond,
+	}
+}
+
+func TestRetries(t *testing.T) {
+	t.Run("transient errors are retried", func(t *testing.T) {
+		for _, status := range []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout} {
+			requests := 0
+			server := httptest.NewServer(failingHandler(2, status, nil, &requests))
+
+			connectionInfo, err := newRetryTestClient(server, testRetryPolicy(3)).GetOneAgentConnectionInfo(context.Background())
+
+			server.Close()
+			require.NoError(t, err, "status %d", status)
+			assert.Equal(t, "abc123456", connectionInfo.TenantUUID)
+			assert.Equal(t, 3, requests)
+		}
+	})
+	t.Run("give up after max retries", func(t *testing.T) {
+		requests := 0
+		server := httptest.NewServer(failingHandler(10, http.StatusServiceUnavailable, nil, &requests))
+		defer server.Close()
+
+		_, err := newRetryTestClient(server, testRetryPolicy(2)).GetOneAgentConnectionInfo(context.Background())
+
+		require.Error(t, err)
+		assert.Equal(t, 3, requests)
+	})
+	t.Run("no retries if disabled", func(t *testing.T) {
+		requests := 0
+		server := httptest.NewServer(failingHandler(10, http.StatusServiceUnavailable, nil, &requests))
+		defer server.Close()
+
+		_, err := newRetryTestClient(server, testRetryPolicy(0)).GetOneAgentConnectionInfo(context.Background())
+
+		require.Error(t, err)
+		assert.Equal(t, 1, requests)
+	})
+	t.Run("other errors are not retried", func(t *testing.T) {
+		requests := 0
+		server := httptest.NewServer(failingHandler(10, http.StatusInternalServerError, nil, &requests))
+		defer server.Close()
+
+		_, err := newRetryTestClient(server, testRetryPolicy(3)).GetOneAgentConnectionInfo(context.Background())
+
+		require.Error(t, err)
+		assert.Equal(t, 1, requests)
+	})
+	t.Run("canceled context stops waiting for the next retry", func(t *testing.T) {
+		requests := 0
+		header := http.Header{}
+		header.Set(retryAfterHeader, "60")
+		server := httptest.NewServer(failingHandler(10, http.StatusTooManyRequests, header, &requests))
+		defer server.Close()
+
+		policy := testRetryPolicy(3)
+		policy.MaxBackoff = time.Minute
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		_, err := newRetryTestClient(server, policy).GetOneAgentConnectionInfo(ctx)
+
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, 1, requests)
+		assert.Less(t, time.Since(start), 10*time.Second)
+	})
+	t.Run("request body of idempotent requests is sent again", func(t *testing.T) {
+		requests := 0
+		var bodies []string
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			requests++
+			body, _ := io.ReadAll(request.Body)
+			bodies = append(bodies, string(body))
+			if requests == 1 {
+				writeError(writer, http.StatusServiceUnavailable)
+				return
+			}
+			_, _ = writer.Write([]byte(`{"scopes": ["DataExport"]}`))
+		}))
+		defer server.Close()
+
+		scopes, err := newRetryTestClient(server, testRetryPolicy(3)).GetTokenScopes(context.Background(), apiToken)
+
+		require.NoError(t, err)
+		assert.Equal(t, TokenScopes{TokenScopeDataExport}, scopes)
+		require.Len(t, bodies, 2)
+		assert.NotEmpty(t, bodies[0])
+		assert.Equal(t, bodies[0], bodies[1])
+	})
+	t.Run("requests which aren't idempotent are not retried", func(t *testing.T) {
+		requests := 0
+		server := httptest.NewServer(failingHandler(10, http.StatusServiceUnavailable, nil, &requests))
+		defer server.Close()
+
+		err := newRetryTestClient(server, testRetryPolicy(3)).SendEvent(context.Background(), &EventData{
+			EventType: MarkedForTerminationEvent,
+			Source:    "test",
+		})
+
+		require.Error(t, err)
+		assert.Equal(t, 1, requests)
+	})
+	t.Run("every attempt has its own deadline", func(t *testing.T) {
+		requests := 0
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			requests++
+			if requests == 1 {
+				<-request.Context().Done()
+				return
+			}
+			_, _ = writer.Write([]byte(testConnectionInfoResponse))
+		}))
+		defer server.Close()
+
+		policy := testRetryPolicy(3)
+		policy.RequestTimeout = 50 * time.Millisecond
+
+		connectionInfo, err := newRetryTestClient(server, policy).GetOneAgentConnectionInfo(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, "abc123456", connectionInfo.TenantUUID)
+		assert.Equal(t, 2, requests)
+	})
+}
+
+func TestIsTransientTransportError(t *testing.T) {
+	t.Run("timeouts and broken connections are transient", func(t *testing.T) {
+		assert.True(t, isTransientTransportError(&url.Error{Op: "Get", Err: context.DeadlineExceeded}))
+		assert.True(t, isTransientTransportError(&url.Error{Op: "Get", Err: syscall.ECONNREFUSED}))
+		assert.True(t, isTransientTransportError(&url.Error{Op: "Get", Err: syscall.ECONNRESET}))
+	})
+	t.Run("certificate errors are not transient", func(t *testing.T) {
+		assert.False(t, isTransientTransportError(&url.Error{Op: "Get", Err: x509.UnknownAuthorityError{}}))
+	})
+	t.Run("other errors are not transient", func(t *testing.T) {
+		assert.False(t, isTransientTransportError(&url.Error{Op: "Get", Err: errors.New("unsupported protocol scheme")}))
+	})
+}
+
+func TestRetriesClassifyErrors(t *testing.T) {
+	t.Run("rate limit keeps the wait time requested by the server", func(t *testing.T) {
+		requests := 0
+		header := http.Header{}
+		header.Set(retryAfterHeader, "120")
+		server := httptest.NewServer(failingHandler(10, http.StatusTooManyRequests, header, &requests))
+		defer server.Close()
+
+		_, err := newRetryTestClient(server, testRetryPolicy(0)).GetOneAgentConnectionInfo(context.Background())
+
+		require.ErrorIs(t, err, apierrors.ErrRateLimited)
+		retryAfter, ok := apierrors.RetryAfter(err)
+		assert.True(t, ok)
+		assert.Equal(t, 2*time.Minute, retryAfter)
+	})
+	t.Run("connection errors are network errors", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		dtc := newRetryTestClient(server, testRetryPolicy(0))
+		server.Close()
+
+		_, err := dtc.GetOneAgentConnectionInfo(context.Background())
+
+		require.ErrorIs(t, err, apierrors.ErrNetwork)
+	})
+	t.Run("untrusted certificates are tls errors", func(t *testing.T) {
+		server := httptest.NewUnstartedServer(http.NotFoundHandler())
+		server.Config.ErrorLog = stdlog.New(io.Discard, "", 0)
+		server.StartTLS()
+		defer server.Close()
+
+		dtc := newRetryTestClient(server, testRetryPolicy(0))
+		dtc.httpClient = &http.Client{}
+
+		_, err := dtc.GetOneAgentConnectionInfo(context.Background())
+
+		require.ErrorIs(t, err, apierrors.ErrTLS)
+	})
+}
+
+func TestWaitRequestedByServer(t *testing.T) {
+	now := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("retry after in seconds", func(t *testing.T) {
+		response := &http.Response{Header: http.Header{retryAfterHeader: []string{"5"}}}
+
+		wait, ok := waitRequestedByServer(response, now)
+
+		assert.True(t, ok)
+		assert.Equal(t, 5*time.Second, wait)
+	})
+	t.Run("retry after as http date", func(t *testing.T) {
+		response := &http.Response{Header: http.Header{retryAfterHeader: []string{now.Add(7 * time.Second).Format(http.TimeFormat)}}}
+
+		wait, ok := waitRequestedByServer(response, now)
+
+		assert.True(t, ok)
+		assert.Equal(t, 7*time.Second, wait)
+	})
+	t.Run("rate limit reset in microseconds", func(t *testing.T) {
+		response := &http.Response{Header: http.Header{}}
+		response.Header.Set(rateLimitResetHeader, strconv.FormatInt(now.Add(3*time.Second).UnixMicro(), 10))
+
+		wait, ok := waitRequestedByServer(response, now)
+
+		assert.True(t, ok)
+		assert.Equal(t, 3*time.Second, wait)
+	})
+	t.Run("reset in the past", func(t *testing.T) {
+		response := &http.Response{Header: http.Header{}}
+		response.Header.Set(rateLimitResetHeader, strconv.FormatInt(now.Add(-3*time.Second).UnixMicro(), 10))
+
+		wait, ok := waitRequestedByServer(response, now)
+
+		assert.True(t, ok)
+		assert.Zero(t, wait)
+	})
+	t.Run("no or invalid headers", func(t *testing.T) {
+		_, ok := waitRequestedByServer(nil, now)
+		assert.False(t, ok)
+
+		_, ok = waitRequestedByServer(&http.Response{Header: http.Header{retryAfterHeader: []string{"soon"}}}, now)
+		assert.False(t, ok)
+	})
+}
+
+func TestBackoff(t *testing.T) {
+	policy := RetryPolicy{
+		MaxRetries:     10,
+		InitialBackoff: time.Second,
+		MaxBackoff:     5 * time.Second,
+	}
+
+	for retry := 1; retry <= 10; retry++ {
+		wait := policy.backoff(retry)
+		assert.GreaterOrEqual(t, wait, time.Duration(0))
+		assert.LessOrEqual(t, wait, min(time.Second<<(retry-1), 5*time.Second))
+	}
+
+	assert.Zero(t, RetryPolicy{}.backoff(1))
+}
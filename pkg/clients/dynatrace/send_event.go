@@ -2,6 +2,7 @@ package dynatrace
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -28,7 +29,7 @@ type EventDataAttachRules struct {
 	EntityIDs []string `json:"entityIds"`
 }
 
-func (dtc *dynatraceClient) SendEvent(eventData *EventData) error {
+func (dtc *dynatraceClient) SendEvent(ctx context.Context, eventData *EventData) error {
 	if eventData == nil {
 		return errors.New("no data found in eventData payload")
 	}
@@ -42,14 +43,18 @@ func (dtc *dynatraceClient) SendEvent(eventData *EventData) error {
 		return errors.WithStack(err)
 	}
 
-	req, err := http.NewRequest("POST", dtc.getEventsUrl(), bytes.NewBuffer(jsonStr))
+	req, err := http.NewRequestWithContext(ctx, "POST", dtc.getEventsUrl(), bytes.NewBuffer(jsonStr))
 	if err != nil {
 		return fmt.Errorf("error initializing http request: %w", err)
 	}
+	authHeader, err := dtc.authorizationHeader(ctx, dtc.apiToken)
+	if err != nil {
+		return err
+	}
 	req.Header.Add("Content-Type", "application/json")
-	req.Header.Add("Authorization", fmt.Sprintf("Api-Token %s", dtc.apiToken))
+	req.Header.Add("Authorization", authHeader)
 
-	response, err := dtc.httpClient.Do(req)
+	response, err := dtc.doRequest(req)
 
 	if err != nil {
 		return fmt.Errorf("error making post request to dynatrace api: %w", err)
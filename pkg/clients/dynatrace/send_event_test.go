@@ -1,6 +1,8 @@
 package dynatrace
 
 import (
+	"context"
+
 	"encoding/json"
 	"net/http"
 	"strings"
@@ -43,7 +45,7 @@ func TestSendEvent(t *testing.T) {
 		dynatraceServer, dynatraceClient := createTestDynatraceServer(t, sendEventHandlerStub(), "")
 		defer dynatraceServer.Close()
 
-		err := dynatraceClient.SendEvent(nil)
+		err := dynatraceClient.SendEvent(context.Background(), nil)
 		assert.Error(t, err)
 		assert.Equal(t, "no data found in eventData payload", err.Error())
 	})
@@ -51,27 +53,27 @@ func TestSendEvent(t *testing.T) {
 		dynatraceServer, dynatraceClient := createTestDynatraceServer(t, sendEventHandlerStub(), "")
 		defer dynatraceServer.Close()
 
-		err := dynatraceClient.SendEvent(&empty)
+		err := dynatraceClient.SendEvent(context.Background(), &empty)
 		assert.Error(t, err)
 		assert.Equal(t, "no key set for eventType in eventData payload", err.Error())
 
-		err = dynatraceClient.SendEvent(&eventTypeOnly)
+		err = dynatraceClient.SendEvent(context.Background(), &eventTypeOnly)
 		assert.NoError(t, err)
 	})
 	t.Run("SendEvent request error", func(t *testing.T) {
 		dynatraceServer, dynatraceClient := createTestDynatraceServer(t, sendEventHandlerError(), "")
 
-		err := dynatraceClient.SendEvent(&empty)
+		err := dynatraceClient.SendEvent(context.Background(), &empty)
 		assert.Error(t, err)
 		assert.Equal(t, "no key set for eventType in eventData payload", err.Error())
 
-		err = dynatraceClient.SendEvent(&eventTypeOnly)
+		err = dynatraceClient.SendEvent(context.Background(), &eventTypeOnly)
 		assert.Error(t, err)
 		assert.Equal(t, "dynatrace server error 500: error received from server", err.Error())
 
 		dynatraceServer.Close()
 
-		err = dynatraceClient.SendEvent(&eventTypeOnly)
+		err = dynatraceClient.SendEvent(context.Background(), &eventTypeOnly)
 		assert.Error(t, err)
 		assert.True(t,
 			// Reason differs between local tests and travis test, so only check main error message
@@ -108,7 +110,7 @@ func testSendEvent(t *testing.T, dynatraceClient Client) {
 		err := json.Unmarshal(testValidEventData, &testEventData)
 		assert.NoError(t, err)
 
-		err = dynatraceClient.SendEvent(&testEventData)
+		err = dynatraceClient.SendEvent(context.Background(), &testEventData)
 		assert.NoError(t, err)
 	}
 	{
@@ -125,7 +127,7 @@ func testSendEvent(t *testing.T, dynatraceClient Client) {
 		err := json.Unmarshal(testInvalidEventData, &testEventData)
 		assert.NoError(t, err)
 
-		err = dynatraceClient.SendEvent(&testEventData)
+		err = dynatraceClient.SendEvent(context.Background(), &testEventData)
 		assert.Error(t, err, "no eventType set")
 	}
 	{
@@ -144,7 +146,7 @@ func testSendEvent(t *testing.T, dynatraceClient Client) {
 		err := json.Unmarshal(testExtraKeysEventData, &testEventData)
 		assert.NoError(t, err)
 
-		err = dynatraceClient.SendEvent(&testEventData)
+		err = dynatraceClient.SendEvent(context.Background(), &testEventData)
 		assert.NoError(t, err)
 	}
 }
@@ -0,0 +1,140 @@
+package dynatrace
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/utils"
+	"github.com/pkg/errors"
+)
+
+// SettingsObject is an object of the Settings 2.0 API with an arbitrary schema
+type SettingsObject struct {
+	ObjectId      string          `json:"objectId,omitempty"`
+	SchemaId      string          `json:"schemaId"`
+	SchemaVersion string          `json:"schemaVersion,omitempty"`
+	Scope         string          `json:"scope,omitempty"`
+	Value         json.RawMessage `json:"value"`
+}
+
+type putSettingsObjectBody struct {
+	SchemaVersion string          `json:"schemaVersion,omitempty"`
+	Value         json.RawMessage `json:"value"`
+}
+
+// GetSettingsObject returns the settings object with the given id, or nil if it doesn't exist
+func (dtc *dynatraceClient) GetSettingsObject(ctx context.Context, objectId string) (*SettingsObject, error) {
+	req, err := dtc.createBaseRequest(ctx, dtc.getSettingsObjectUrl(objectId), http.MethodGet, dtc.apiToken, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := dtc.doRequest(req)
+	if err != nil {
+		return nil, errors.WithMessage(err, "error making get request to dynatrace api")
+	}
+	defer utils.CloseBodyAfterRequest(res)
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+
+	var object SettingsObject
+	err = dtc.unmarshalToJson(res, &object)
+	if err != nil {
+		return nil, err
+	}
+
+	return &object, nil
+}
+
+// CreateSettingsObject creates the given settings object and returns the id of the created object
+func (dtc *dynatraceClient) CreateSettingsObject(ctx context.Context, object SettingsObject) (string, error) {
+	object.ObjectId = ""
+	bodyData, err := json.Marshal([]SettingsObject{object})
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	req, err := dtc.createBaseRequest(ctx, dtc.getSettingsUrl(false), http.MethodPost, dtc.apiToken, bytes.NewReader(bodyData))
+	if err != nil {
+		return "", err
+	}
+
+	res, err := dtc.doRequest(req)
+	if err != nil {
+		return "", errors.WithMessage(err, "error making post request to dynatrace api")
+	}
+	defer utils.CloseBodyAfterRequest(res)
+
+	resData, err := io.ReadAll(res.Body)
+	if err != nil {
+		return "", errors.WithMessage(err, "error reading response")
+	}
+
+	if res.StatusCode != http.StatusOK &&
+		res.StatusCode != http.StatusCreated {
+		return "", handleErrorArrayResponseFromAPI(resData, res.StatusCode)
+	}
+
+	var resDataJson []postSettingsResponse
+	err = json.Unmarshal(resData, &resDataJson)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	if len(resDataJson) != 1 {
+		return "", errors.Errorf("response is not containing exactly one entry %s", resData)
+	}
+
+	return resDataJson[0].ObjectId, nil
+}
+
+// UpdateSettingsObject replaces the value of the settings object with the given id
+func (dtc *dynatraceClient) UpdateSettingsObject(ctx context.Context, objectId string, object SettingsObject) error {
+	bodyData, err := json.Marshal(putSettingsObjectBody{
+		SchemaVersion: object.SchemaVersion,
+		Value:         object.Value,
+	})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	req, err := dtc.createBaseRequest(idempotent(ctx), dtc.getSettingsObjectUrl(objectId), http.MethodPut, dtc.apiToken, bytes.NewReader(bodyData))
+	if err != nil {
+		return err
+	}
+
+	res, err := dtc.doRequest(req)
+	if err != nil {
+		return errors.WithMessage(err, "error making put request to dynatrace api")
+	}
+	defer utils.CloseBodyAfterRequest(res)
+
+	_, err = dtc.getServerResponseData(res)
+	return err
+}
+
+// DeleteSettingsObject deletes the settings object with the given id, an object which doesn't exist anymore is not an error
+func (dtc *dynatraceClient) DeleteSettingsObject(ctx context.Context, objectId string) error {
+	req, err := dtc.createBaseRequest(idempotent(ctx), dtc.getSettingsObjectUrl(objectId), http.MethodDelete, dtc.apiToken, nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := dtc.doRequest(req)
+	if err != nil {
+		return errors.WithMessage(err, "error making delete request to dynatrace api")
+	}
+	defer utils.CloseBodyAfterRequest(res)
+
+	if res.StatusCode == http.StatusNoContent || res.StatusCode == http.StatusNotFound {
+		return nil
+	}
+
+	_, err = dtc.getServerResponseData(res)
+	return err
+}
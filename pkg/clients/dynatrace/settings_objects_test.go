@@ -0,0 +1,152 @@
+package dynatrace
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSettingsSchemaId = "builtin:anomaly-detection.kubernetes.workload"
+
+func TestGetSettingsObject(t *testing.T) {
+	t.Run("object exists", func(t *testing.T) {
+		dynatraceServer, dynatraceClient := createTestDynatraceClientWithFunc(t, func(writer http.ResponseWriter, request *http.Request) {
+			assert.Equal(t, http.MethodGet, request.Method)
+			assert.Equal(t, "/v2/settings/objects/"+testObjectID, request.URL.Path)
+			writer.WriteHeader(http.StatusOK)
+			_, _ = writer.Write([]byte(`{"objectId":"` + testObjectID + `","schemaId":"` + testSettingsSchemaId + `","schemaVersion":"1.0.0","scope":"environment","value":{"enabled":true}}`))
+		})
+		defer dynatraceServer.Close()
+
+		object, err := dynatraceClient.GetSettingsObject(context.Background(), testObjectID)
+		require.NoError(t, err)
+		require.NotNil(t, object)
+		assert.Equal(t, testObjectID, object.ObjectId)
+		assert.Equal(t, testSettingsSchemaId, object.SchemaId)
+		assert.Equal(t, "environment", object.Scope)
+		assert.JSONEq(t, `{"enabled":true}`, string(object.Value))
+	})
+	t.Run("object doesn't exist", func(t *testing.T) {
+		dynatraceServer, dynatraceClient := createTestDynatraceClientWithFunc(t, func(writer http.ResponseWriter, request *http.Request) {
+			writeError(writer, http.StatusNotFound)
+		})
+		defer dynatraceServer.Close()
+
+		object, err := dynatraceClient.GetSettingsObject(context.Background(), testObjectID)
+		require.NoError(t, err)
+		assert.Nil(t, object)
+	})
+	t.Run("server error", func(t *testing.T) {
+		dynatraceServer, dynatraceClient := createTestDynatraceClientWithFunc(t, func(writer http.ResponseWriter, request *http.Request) {
+			writeError(writer, http.StatusInternalServerError)
+		})
+		defer dynatraceServer.Close()
+
+		_, err := dynatraceClient.GetSettingsObject(context.Background(), testObjectID)
+		require.Error(t, err)
+	})
+}
+
+func TestCreateSettingsObject(t *testing.T) {
+	testObject := SettingsObject{
+		ObjectId: "ignored",
+		SchemaId: testSettingsSchemaId,
+		Scope:    "environment",
+		Value:    json.RawMessage(`{"enabled":true}`),
+	}
+
+	t.Run("object is created", func(t *testing.T) {
+		var received []SettingsObject
+		dynatraceServer, dynatraceClient := createTestDynatraceClientWithFunc(t, func(writer http.ResponseWriter, request *http.Request) {
+			assert.Equal(t, http.MethodPost, request.Method)
+			assert.Equal(t, "/v2/settings/objects", request.URL.Path)
+			assert.Equal(t, "false", request.URL.Query().Get("validateOnly"))
+			assert.NoError(t, json.NewDecoder(request.Body).Decode(&received))
+			writer.WriteHeader(http.StatusOK)
+			_, _ = writer.Write([]byte(`[{"objectId":"` + testObjectID + `"}]`))
+		})
+		defer dynatraceServer.Close()
+
+		objectId, err := dynatraceClient.CreateSettingsObject(context.Background(), testObject)
+		require.NoError(t, err)
+		assert.Equal(t, testObjectID, objectId)
+		require.Len(t, received, 1)
+		assert.Empty(t, received[0].ObjectId)
+		assert.Equal(t, testSettingsSchemaId, received[0].SchemaId)
+		assert.JSONEq(t, `{"enabled":true}`, string(received[0].Value))
+	})
+	t.Run("constraint violation", func(t *testing.T) {
+		dynatraceServer, dynatraceClient := createTestDynatraceClientWithFunc(t, func(writer http.ResponseWriter, request *http.Request) {
+			writer.WriteHeader(http.StatusBadRequest)
+			_, _ = writer.Write([]byte(`[{"error":{"code":400,"message":"Validation failed","constraintViolations":[{"message":"unknown property"}]}}]`))
+		})
+		defer dynatraceServer.Close()
+
+		_, err := dynatraceClient.CreateSettingsObject(context.Background(), testObject)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown property")
+	})
+}
+
+func TestUpdateSettingsObject(t *testing.T) {
+	t.Run("object is updated", func(t *testing.T) {
+		var received putSettingsObjectBody
+		dynatraceServer, dynatraceClient := createTestDynatraceClientWithFunc(t, func(writer http.ResponseWriter, request *http.Request) {
+			assert.Equal(t, http.MethodPut, request.Method)
+			assert.Equal(t, "/v2/settings/objects/"+testObjectID, request.URL.Path)
+			assert.NoError(t, json.NewDecoder(request.Body).Decode(&received))
+			writer.WriteHeader(http.StatusOK)
+			_, _ = writer.Write([]byte(`{"code":200}`))
+		})
+		defer dynatraceServer.Close()
+
+		err := dynatraceClient.UpdateSettingsObject(context.Background(), testObjectID, SettingsObject{
+			SchemaVersion: "1.0.0",
+			Value:         json.RawMessage(`{"enabled":false}`),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "1.0.0", received.SchemaVersion)
+		assert.JSONEq(t, `{"enabled":false}`, string(received.Value))
+	})
+	t.Run("server error", func(t *testing.T) {
+		dynatraceServer, dynatraceClient := createTestDynatraceClientWithFunc(t, func(writer http.ResponseWriter, request *http.Request) {
+			writeError(writer, http.StatusBadRequest)
+		})
+		defer dynatraceServer.Close()
+
+		require.Error(t, dynatraceClient.UpdateSettingsObject(context.Background(), testObjectID, SettingsObject{}))
+	})
+}
+
+func TestDeleteSettingsObject(t *testing.T) {
+	t.Run("object is deleted", func(t *testing.T) {
+		dynatraceServer, dynatraceClient := createTestDynatraceClientWithFunc(t, func(writer http.ResponseWriter, request *http.Request) {
+			assert.Equal(t, http.MethodDelete, request.Method)
+			assert.Equal(t, "/v2/settings/objects/"+testObjectID, request.URL.Path)
+			writer.WriteHeader(http.StatusNoContent)
+		})
+		defer dynatraceServer.Close()
+
+		require.NoError(t, dynatraceClient.DeleteSettingsObject(context.Background(), testObjectID))
+	})
+	t.Run("object is already deleted", func(t *testing.T) {
+		dynatraceServer, dynatraceClient := createTestDynatraceClientWithFunc(t, func(writer http.ResponseWriter, request *http.Request) {
+			writeError(writer, http.StatusNotFound)
+		})
+		defer dynatraceServer.Close()
+
+		require.NoError(t, dynatraceClient.DeleteSettingsObject(context.Background(), testObjectID))
+	})
+	t.Run("server error", func(t *testing.T) {
+		dynatraceServer, dynatraceClient := createTestDynatraceClientWithFunc(t, func(writer http.ResponseWriter, request *http.Request) {
+			writeError(writer, http.StatusForbidden)
+		})
+		defer dynatraceServer.Close()
+
+		require.Error(t, dynatraceClient.DeleteSettingsObject(context.Background(), testObjectID))
+	})
+}
@@ -2,6 +2,7 @@ package dynatrace
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -23,7 +24,7 @@ func (s TokenScopes) Contains(scope string) bool {
 	return false
 }
 
-func (dtc *dynatraceClient) GetTokenScopes(token string) (TokenScopes, error) {
+func (dtc *dynatraceClient) GetTokenScopes(ctx context.Context, token string) (TokenScopes, error) {
 	var model struct {
 		Token string `json:"token"`
 	}
@@ -34,14 +35,14 @@ func (dtc *dynatraceClient) GetTokenScopes(token string) (TokenScopes, error) {
 		return nil, errors.WithStack(err)
 	}
 
-	req, err := http.NewRequest("POST", dtc.getTokensLookupUrl(), bytes.NewBuffer(jsonStr))
+	req, err := http.NewRequestWithContext(idempotent(ctx), "POST", dtc.getTokensLookupUrl(), bytes.NewBuffer(jsonStr))
 	if err != nil {
 		return nil, fmt.Errorf("error initializing http request: %w", err)
 	}
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Add("Authorization", fmt.Sprintf("Api-Token %s", token))
 
-	resp, err := dtc.httpClient.Do(req)
+	resp, err := dtc.doRequest(req)
 	if err != nil {
 		return nil, fmt.Errorf("error making post request to dynatrace api: %w", err)
 	}
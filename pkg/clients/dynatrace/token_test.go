@@ -1,6 +1,8 @@
 package dynatrace
 
 import (
+	"context"
+
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
@@ -22,12 +24,12 @@ func TestToken_Contains(t *testing.T) {
 
 func testGetTokenScopes(t *testing.T, dynatraceClient Client) {
 	{
-		scopes, err := dynatraceClient.GetTokenScopes("good-token")
+		scopes, err := dynatraceClient.GetTokenScopes(context.Background(), "good-token")
 		assert.NoError(t, err)
 		assert.ElementsMatch(t, []string{"DataExport", "LogExport"}, scopes)
 	}
 	{
-		scopes, err := dynatraceClient.GetTokenScopes("bad-token")
+		scopes, err := dynatraceClient.GetTokenScopes(context.Background(), "bad-token")
 		assert.Nil(t, scopes)
 		assert.Error(t, err)
 		assert.Exactly(t, ServerError{Code: 401, Message: "error received from server"}, errors.Cause(err))
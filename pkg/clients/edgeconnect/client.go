@@ -9,6 +9,8 @@ import (
 	"net/http"
 	"net/url"
 
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/apierrors"
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/apimetrics"
 	"github.com/Dynatrace/dynatrace-operator/pkg/clients/utils"
 	"github.com/pkg/errors"
 	"golang.org/x/oauth2"
@@ -17,11 +19,15 @@ import (
 
 const (
 	contentTypeJSON = "application/json"
+
+	// metricsClientName is the value of the client label of the request metrics
+	metricsClientName = "edgeconnect"
 )
 
 type client struct {
 	ctx        context.Context
 	baseURL    string
+	owner      string
 	httpClient *http.Client
 	clientcredentials.Config
 }
@@ -47,6 +53,7 @@ func NewClient(clientID, clientSecret string, options ...Option) (Client, error)
 	if httpClient == nil {
 		return nil, errors.New("can't create http client for edge connect")
 	}
+	httpClient.Transport = apimetrics.NewRoundTripper(httpClient.Transport, metricsClientName, c.owner, c.baseURL, endpointTemplates...)
 	c.httpClient = httpClient
 
 	return c, nil
@@ -76,6 +83,13 @@ func WithBaseURL(url string) func(*client) {
 	}
 }
 
+// WithOwner sets the name of the EdgeConnect the client is used for, it's added as label to the metrics of all requests
+func WithOwner(edgeConnectName string) func(*client) {
+	return func(c *client) {
+		c.owner = edgeConnectName
+	}
+}
+
 func WithTokenURL(url string) func(*client) {
 	return func(c *client) {
 		c.TokenURL = url
@@ -97,6 +111,7 @@ func WithContext(ctx context.Context) func(*client) {
 }
 
 // ServerError represents an error returned from the server (e.g. authentication failure).
+// It is classified by its code, so errors.Is can be used with the errors of the apierrors package.
 type ServerError struct {
 	Code    int          `json:"code,omitempty"`
 	Message string       `json:"message,omitempty"`
@@ -122,9 +137,23 @@ func (e ServerError) Error() string {
 		return "unknown server error"
 	}
 
+	if len(e.Details.ConstraintViolations.Message) > 0 || len(e.Details.MissingScopes) > 0 {
+		return fmt.Sprintf("edgeconnect server error %d: %s: details %s", int64(e.Code), e.Message, e.Details)
+	}
+
 	return fmt.Sprintf("edgeconnect server error %d: %s", int64(e.Code), e.Message)
 }
 
+// Is returns true if the target is the class of the status code of the error, missing scopes are always forbidden
+func (e ServerError) Is(target error) bool {
+	if len(e.Details.MissingScopes) > 0 && target == apierrors.ErrForbidden {
+		return true
+	}
+
+	class := apierrors.FromStatusCode(e.Code)
+	return class != nil && target == class
+}
+
 type serverErrorResponse struct {
 	ErrorMessage ServerError `json:"error"`
 }
@@ -132,7 +161,11 @@ type serverErrorResponse struct {
 func (c *client) handleErrorResponseFromAPI(response []byte, statusCode int) error {
 	se := serverErrorResponse{}
 	if err := json.Unmarshal(response, &se); err != nil {
-		return errors.WithStack(errors.WithMessagef(err, "response error, can't unmarshal json response %d", statusCode))
+		return ServerError{Code: statusCode, Message: fmt.Sprintf("response error, can't unmarshal json response: %s", err)}
+	}
+
+	if se.ErrorMessage.Code == 0 {
+		se.ErrorMessage.Code = statusCode
 	}
 
 	return se.ErrorMessage
@@ -160,7 +193,7 @@ func (c *client) GetEdgeConnect(edgeConnectId string) (GetResponse, error) {
 	defer utils.CloseBodyAfterRequest(resp)
 
 	if err != nil {
-		return GetResponse{}, err
+		return GetResponse{}, apierrors.FromTransport(err)
 	}
 
 	responseData, err := c.getServerResponseData(resp)
@@ -199,16 +232,15 @@ func (c *client) UpdateEdgeConnect(edgeConnectId, name string, hostPatterns []st
 	defer utils.CloseBodyAfterRequest(resp)
 
 	if err != nil {
-		return err
+		return apierrors.FromTransport(err)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		var errorResponse serverErrorResponse
-		err = json.NewDecoder(resp.Body).Decode(&errorResponse)
+		responseData, err := io.ReadAll(resp.Body)
 		if err != nil {
-			return err
+			return errors.WithMessage(err, "error reading response")
 		}
-		return errors.Errorf("edgeconnect server error %d: %s: details %s", errorResponse.ErrorMessage.Code, errorResponse.ErrorMessage.Message, errorResponse.ErrorMessage.Details)
+		return c.handleErrorResponseFromAPI(responseData, resp.StatusCode)
 	}
 
 	return nil
@@ -227,16 +259,15 @@ func (c *client) DeleteEdgeConnect(edgeConnectId string) error {
 	defer utils.CloseBodyAfterRequest(resp)
 
 	if err != nil {
-		return err
+		return apierrors.FromTransport(err)
 	}
 
 	if resp.StatusCode != http.StatusNoContent {
-		var errorResponse serverErrorResponse
-		err = json.NewDecoder(resp.Body).Decode(&errorResponse)
+		responseData, err := io.ReadAll(resp.Body)
 		if err != nil {
-			return err
+			return errors.WithMessage(err, "error reading response")
 		}
-		return errors.Errorf("edgeconnect server error %d: %s", errorResponse.ErrorMessage.Code, errorResponse.ErrorMessage.Message)
+		return c.handleErrorResponseFromAPI(responseData, resp.StatusCode)
 	}
 	return nil
 }
@@ -256,7 +287,7 @@ func (c *client) CreateEdgeConnect(name string, hostPatterns []string, oauthClie
 	defer utils.CloseBodyAfterRequest(resp)
 
 	if err != nil {
-		return CreateResponse{}, err
+		return CreateResponse{}, apierrors.FromTransport(err)
 	}
 
 	responseData, err := c.getServerResponseData(resp)
@@ -290,7 +321,7 @@ func (c *client) GetEdgeConnects(name string) (ListResponse, error) {
 	defer utils.CloseBodyAfterRequest(resp)
 
 	if err != nil {
-		return ListResponse{}, err
+		return ListResponse{}, apierrors.FromTransport(err)
 	}
 
 	responseData, err := c.getServerResponseData(resp)
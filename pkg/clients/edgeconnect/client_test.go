@@ -8,6 +8,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/apierrors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/oauth2"
@@ -104,6 +105,45 @@ func TestUpdateEdgeConnect(t *testing.T) {
 	})
 }
 
+func TestErrorClasses(t *testing.T) {
+	t.Run("server errors are classified by their code", func(t *testing.T) {
+		assert.ErrorIs(t, ServerError{Code: http.StatusNotFound}, apierrors.ErrNotFound)
+		assert.ErrorIs(t, ServerError{Code: http.StatusServiceUnavailable}, apierrors.ErrServer)
+		assert.ErrorIs(t, ServerError{Code: http.StatusBadRequest, Details: DetailsError{MissingScopes: []string{"app-engine:edge-connects:read"}}}, apierrors.ErrForbidden)
+		assert.Nil(t, apierrors.Class(ServerError{Code: http.StatusBadRequest}))
+	})
+	t.Run("responses of the api are classified", func(t *testing.T) {
+		for status, class := range map[int]error{
+			http.StatusForbidden:       apierrors.ErrForbidden,
+			http.StatusNotFound:        apierrors.ErrNotFound,
+			http.StatusTooManyRequests: apierrors.ErrRateLimited,
+		} {
+			edgeConnectServer, edgeConnectClient := createTestEdgeConnectServer(t, edgeConnectErrorServerHandler(status))
+
+			_, getErr := edgeConnectClient.GetEdgeConnect(EdgeConnectID)
+			deleteErr := edgeConnectClient.DeleteEdgeConnect(EdgeConnectID)
+			updateErr := edgeConnectClient.UpdateEdgeConnect(EdgeConnectID, "test_name", []string{""}, "")
+
+			edgeConnectServer.Close()
+			assert.ErrorIs(t, getErr, class, "status %d", status)
+			assert.ErrorIs(t, deleteErr, class, "status %d", status)
+			assert.ErrorIs(t, updateErr, class, "status %d", status)
+		}
+	})
+	t.Run("invalid oauth client is unauthorized", func(t *testing.T) {
+		edgeConnectServer, edgeConnectClient := createTestEdgeConnectServer(t, http.HandlerFunc(func(writer http.ResponseWriter, _ *http.Request) {
+			writer.Header().Set("Content-Type", "application/json")
+			writer.WriteHeader(http.StatusBadRequest)
+			_, _ = writer.Write([]byte(`{"error": "invalid_client"}`))
+		}))
+		defer edgeConnectServer.Close()
+
+		_, err := edgeConnectClient.GetEdgeConnect(EdgeConnectID)
+
+		assert.ErrorIs(t, err, apierrors.ErrUnauthorized)
+	})
+}
+
 func createTestEdgeConnectServer(t *testing.T, handler http.Handler) (*httptest.Server, Client) {
 	edgeConnectServer := httptest.NewServer(handler)
 
@@ -245,6 +285,17 @@ func edgeConnectUpdateServerHandler() http.HandlerFunc {
 	}
 }
 
+func edgeConnectErrorServerHandler(status int) http.HandlerFunc {
+	return func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("Content-Type", "application/json")
+		if request.URL.Path == "/sso/oauth2/token" {
+			writeOauthTokenResponse(writer)
+			return
+		}
+		writeError(writer, status)
+	}
+}
+
 func isManagedByOperator(request *http.Request) bool {
 	edgeConnect := Request{}
 	err := json.NewDecoder(request.Body).Decode(&edgeConnect)
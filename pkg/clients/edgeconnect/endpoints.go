@@ -2,6 +2,12 @@ package edgeconnect
 
 import "fmt"
 
+// endpointTemplates are the endpoints used by the client, used as labels for the metrics of the requests
+var endpointTemplates = []string{
+	"/edge-connects",
+	"/edge-connects/{id}",
+}
+
 func (c *client) getEdgeConnectsUrl() string {
 	return fmt.Sprintf("%s/edge-connects", c.baseURL)
 }
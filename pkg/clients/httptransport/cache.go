@@ -0,0 +1,118 @@
+package httptransport
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/hasher"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// idleTimeout is how long a transport is kept without being used, so the transports of deleted DynaKubes are dropped
+// by processes that don't reconcile the DynaKube, e.g. the CSI driver and the webhook.
+const idleTimeout = time.Hour
+
+var shared = NewCache()
+
+// Shared returns the cache used by all clients of the current process
+func Shared() *Cache {
+	return shared
+}
+
+// Cache keeps one transport per DynaKube, so the connection pools survive between reconciliations
+// and the certificates are only parsed again if they changed.
+type Cache struct {
+	entries map[string]entry
+	mutex   sync.Mutex
+	now     func() time.Time
+}
+
+type entry struct {
+	hash      string
+	transport *http.Transport
+	lastUsed  time.Time
+}
+
+func NewCache() *Cache {
+	return &Cache{
+		entries: map[string]entry{},
+		now:     time.Now,
+	}
+}
+
+// ForDynaKube returns the transport of the DynaKube, it's only rebuilt if the network settings of the DynaKube changed
+func (cache *Cache) ForDynaKube(ctx context.Context, apiReader client.Reader, dynakube *dynatracev1beta1.DynaKube) (*http.Transport, error) {
+	settings, err := NewSettings(ctx, apiReader, dynakube)
+	if err != nil {
+		return nil, err
+	}
+
+	return cache.Get(Key(dynakube), settings)
+}
+
+// Get returns the transport stored for the key if it was created with the same settings, otherwise a new one replaces it.
+// The idle connections of a replaced transport are closed, requests that are still in flight are finished.
+func (cache *Cache) Get(key string, settings Settings) (*http.Transport, error) {
+	hash, err := hasher.GenerateHash(settings)
+	if err != nil {
+		return nil, err
+	}
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	now := cache.now()
+	cache.removeIdle(key, now)
+
+	cached, found := cache.entries[key]
+	if found && cached.hash == hash {
+		cached.lastUsed = now
+		cache.entries[key] = cached
+
+		return cached.transport, nil
+	}
+
+	transport, err := newTransport(settings)
+	if err != nil {
+		return nil, err
+	}
+
+	if found {
+		log.Info("network settings changed, replacing transport", "key", key)
+		cached.transport.CloseIdleConnections()
+	}
+
+	cache.entries[key] = entry{hash: hash, transport: transport, lastUsed: now}
+
+	return transport, nil
+}
+
+// removeIdle forgets the transports of the other keys that weren't used within the idleTimeout, the caller must hold the mutex
+func (cache *Cache) removeIdle(key string, now time.Time) {
+	for otherKey, other := range cache.entries {
+		if otherKey != key && now.Sub(other.lastUsed) > idleTimeout {
+			log.Info("removing idle transport", "key", otherKey)
+			other.transport.CloseIdleConnections()
+			delete(cache.entries, otherKey)
+		}
+	}
+}
+
+// Remove closes the idle connections of the transport stored for the key and forgets it, e.g. when the DynaKube is deleted
+func (cache *Cache) Remove(key string) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	if cached, found := cache.entries[key]; found {
+		cached.transport.CloseIdleConnections()
+		delete(cache.entries, key)
+	}
+}
+
+// Key returns the key of the transport of the DynaKube
+func Key(dynakube *dynatracev1beta1.DynaKube) string {
+	return dynakube.Namespace + "/" + dynakube.Name
+}
@@ -0,0 +1,153 @@
+package httptransport
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
+	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCacheGet(t *testing.T) {
+	t.Run("same settings reuse the transport", func(t *testing.T) {
+		cache := NewCache()
+
+		transport, err := cache.Get(testName, Settings{Proxy: testProxy})
+		require.NoError(t, err)
+
+		cached, err := cache.Get(testName, Settings{Proxy: testProxy})
+		require.NoError(t, err)
+
+		assert.Same(t, transport, cached)
+		assert.True(t, transport.ForceAttemptHTTP2)
+	})
+	t.Run("changed settings replace the transport", func(t *testing.T) {
+		cache := NewCache()
+
+		transport, err := cache.Get(testName, Settings{})
+		require.NoError(t, err)
+
+		replaced, err := cache.Get(testName, Settings{SkipCertCheck: true})
+		require.NoError(t, err)
+
+		assert.NotSame(t, transport, replaced)
+		assert.False(t, transport.TLSClientConfig.InsecureSkipVerify, "the replaced transport isn't modified")
+		assert.True(t, replaced.TLSClientConfig.InsecureSkipVerify)
+
+		cached, err := cache.Get(testName, Settings{SkipCertCheck: true})
+		require.NoError(t, err)
+		assert.Same(t, replaced, cached)
+	})
+	t.Run("keys don't share transports", func(t *testing.T) {
+		cache := NewCache()
+
+		transport, err := cache.Get(testName, Settings{})
+		require.NoError(t, err)
+
+		other, err := cache.Get(testNamespace, Settings{})
+		require.NoError(t, err)
+
+		assert.NotSame(t, transport, other)
+	})
+	t.Run("invalid settings keep the previous transport", func(t *testing.T) {
+		cache := NewCache()
+
+		transport, err := cache.Get(testName, Settings{})
+		require.NoError(t, err)
+
+		_, err = cache.Get(testName, Settings{TrustedCAs: []byte("certs")})
+		require.Error(t, err)
+
+		cached, err := cache.Get(testName, Settings{})
+		require.NoError(t, err)
+		assert.Same(t, transport, cached)
+	})
+}
+
+func TestCacheRemoveIdle(t *testing.T) {
+	now := time.Now()
+	cache := NewCache()
+	cache.now = func() time.Time { return now }
+
+	idle, err := cache.Get(testName, Settings{})
+	require.NoError(t, err)
+
+	used, err := cache.Get(testNamespace, Settings{})
+	require.NoError(t, err)
+
+	now = now.Add(idleTimeout)
+	_, err = cache.Get(testNamespace, Settings{})
+	require.NoError(t, err)
+	assert.Len(t, cache.entries, 2, "transports are kept within the idle timeout")
+
+	now = now.Add(time.Minute)
+	cached, err := cache.Get(testNamespace, Settings{})
+	require.NoError(t, err)
+
+	assert.Same(t, used, cached)
+	assert.Len(t, cache.entries, 1)
+
+	recreated, err := cache.Get(testName, Settings{})
+	require.NoError(t, err)
+	assert.NotSame(t, idle, recreated)
+}
+
+func TestCacheRemove(t *testing.T) {
+	cache := NewCache()
+
+	transport, err := cache.Get(testName, Settings{})
+	require.NoError(t, err)
+
+	cache.Remove(testName)
+	cache.Remove(testName)
+
+	recreated, err := cache.Get(testName, Settings{})
+	require.NoError(t, err)
+	assert.NotSame(t, transport, recreated)
+}
+
+func TestCacheForDynaKube(t *testing.T) {
+	ctx := context.Background()
+	server := httptest.NewTLSServer(http.NotFoundHandler())
+	defer server.Close()
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: testName, Namespace: testNamespace},
+		Data:       map[string]string{dynatracev1beta1.TrustedCAKey: string(createTestServerCA(t, server))},
+	}
+	fakeClient := fake.NewClient(configMap)
+	dynakube := createTestDynakube(dynatracev1beta1.DynaKubeSpec{TrustedCAs: testName})
+	cache := NewCache()
+
+	transport, err := cache.ForDynaKube(ctx, fakeClient, dynakube)
+	require.NoError(t, err)
+
+	response, err := (&http.Client{Transport: transport}).Get(server.URL)
+	require.NoError(t, err)
+	response.Body.Close()
+
+	cached, err := cache.ForDynaKube(ctx, fakeClient, dynakube)
+	require.NoError(t, err)
+	assert.Same(t, transport, cached, "the transport is reused while the config map is unchanged")
+
+	configMap.Data[dynatracev1beta1.TrustedCAKey] += "\n"
+	require.NoError(t, fakeClient.Update(ctx, configMap))
+
+	replaced, err := cache.ForDynaKube(ctx, fakeClient, dynakube)
+	require.NoError(t, err)
+	assert.NotSame(t, transport, replaced, "the transport is rebuilt after the config map changed")
+
+	_, err = cache.ForDynaKube(ctx, fakeClient, createTestDynakube(dynatracev1beta1.DynaKubeSpec{TrustedCAs: "missing"}))
+	require.Error(t, err)
+}
+
+func TestKey(t *testing.T) {
+	assert.Equal(t, testNamespace+"/"+testName, Key(createTestDynakube(dynatracev1beta1.DynaKubeSpec{})))
+}
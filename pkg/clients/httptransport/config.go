@@ -0,0 +1,9 @@
+package httptransport
+
+import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/logger"
+)
+
+var (
+	log = logger.Factory.GetLogger("http-transport")
+)
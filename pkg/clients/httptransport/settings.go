@@ -0,0 +1,94 @@
+package httptransport
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/url"
+
+	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+	"github.com/pkg/errors"
+	"golang.org/x/net/http/httpproxy"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Settings are the network settings of a DynaKube which determine how its transport connects to the Dynatrace API and the registries
+type Settings struct {
+	Proxy         string `json:"proxy,omitempty"`
+	NoProxy       string `json:"noProxy,omitempty"`
+	TrustedCAs    []byte `json:"trustedCAs,omitempty"`
+	SkipCertCheck bool   `json:"skipCertCheck,omitempty"`
+}
+
+// NewSettings reads the proxy and the trusted certificates of the DynaKube from its secret and config map
+func NewSettings(ctx context.Context, apiReader client.Reader, dynakube *dynatracev1beta1.DynaKube) (Settings, error) {
+	settings := Settings{
+		NoProxy:       dynakube.FeatureNoProxy(),
+		SkipCertCheck: dynakube.Spec.SkipCertCheck,
+	}
+
+	if dynakube.HasProxy() {
+		proxy, err := dynakube.Proxy(ctx, apiReader)
+		if err != nil {
+			return Settings{}, err
+		}
+		settings.Proxy = proxy
+	}
+
+	if dynakube.Spec.TrustedCAs != "" {
+		trustedCAs, err := dynakube.TrustedCAs(ctx, apiReader)
+		if err != nil {
+			return Settings{}, err
+		}
+		if len(trustedCAs) == 0 {
+			return Settings{}, errors.New("failed to extract certificate configmap field: missing field certs")
+		}
+		settings.TrustedCAs = trustedCAs
+	}
+
+	return settings, nil
+}
+
+// Apply configures the proxy and the tls settings on the given transport
+func (settings Settings) Apply(transport *http.Transport) (*http.Transport, error) {
+	if settings.Proxy != "" {
+		proxyURL, err := url.Parse(settings.Proxy)
+		if err != nil {
+			return nil, errors.WithMessage(err, "failed to parse proxy url")
+		}
+
+		proxyConfig := httpproxy.Config{
+			HTTPProxy:  proxyURL.String(),
+			HTTPSProxy: proxyURL.String(),
+			NoProxy:    settings.NoProxy,
+		}
+		transport.Proxy = func(req *http.Request) (*url.URL, error) {
+			return proxyConfig.ProxyFunc()(req.URL)
+		}
+	}
+
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{} // nolint:gosec
+	}
+
+	if len(settings.TrustedCAs) > 0 {
+		rootCAs := x509.NewCertPool()
+		if ok := rootCAs.AppendCertsFromPEM(settings.TrustedCAs); !ok {
+			return nil, errors.New("failed to append custom certs")
+		}
+		transport.TLSClientConfig.RootCAs = rootCAs
+	}
+
+	transport.TLSClientConfig.InsecureSkipVerify = settings.SkipCertCheck
+
+	return transport, nil
+}
+
+// newTransport creates a transport based on the default transport, which keeps using http/2 even though its tls settings are customized
+func newTransport(settings Settings) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.ForceAttemptHTTP2 = true
+
+	return settings.Apply(transport)
+}
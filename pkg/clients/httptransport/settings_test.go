@@ -0,0 +1,162 @@
+package httptransport
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
+	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	testName      = "test-name"
+	testNamespace = "test-namespace"
+	testProxy     = "http://proxy.test:3128"
+)
+
+func createTestDynakube(spec dynatracev1beta1.DynaKubeSpec) *dynatracev1beta1.DynaKube {
+	return &dynatracev1beta1.DynaKube{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testName,
+			Namespace: testNamespace,
+		},
+		Spec: spec,
+	}
+}
+
+func createTestServerCA(t *testing.T, server *httptest.Server) []byte {
+	t.Helper()
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+}
+
+func TestNewSettings(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("no network settings", func(t *testing.T) {
+		settings, err := NewSettings(ctx, fake.NewClient(), createTestDynakube(dynatracev1beta1.DynaKubeSpec{}))
+
+		require.NoError(t, err)
+		assert.Equal(t, Settings{}, settings)
+	})
+	t.Run("proxy from value", func(t *testing.T) {
+		dynakube := createTestDynakube(dynatracev1beta1.DynaKubeSpec{
+			Proxy:         &dynatracev1beta1.DynaKubeProxy{Value: testProxy},
+			SkipCertCheck: true,
+		})
+
+		settings, err := NewSettings(ctx, fake.NewClient(), dynakube)
+
+		require.NoError(t, err)
+		assert.Equal(t, Settings{Proxy: testProxy, SkipCertCheck: true}, settings)
+	})
+	t.Run("proxy from secret", func(t *testing.T) {
+		fakeClient := fake.NewClient(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: testName, Namespace: testNamespace},
+			Data:       map[string][]byte{dynatracev1beta1.ProxyKey: []byte(testProxy)},
+		})
+		dynakube := createTestDynakube(dynatracev1beta1.DynaKubeSpec{
+			Proxy: &dynatracev1beta1.DynaKubeProxy{ValueFrom: testName},
+		})
+
+		settings, err := NewSettings(ctx, fakeClient, dynakube)
+
+		require.NoError(t, err)
+		assert.Equal(t, testProxy, settings.Proxy)
+	})
+	t.Run("missing or malformed proxy secret", func(t *testing.T) {
+		dynakube := createTestDynakube(dynatracev1beta1.DynaKubeSpec{
+			Proxy: &dynatracev1beta1.DynaKubeProxy{ValueFrom: testName},
+		})
+
+		_, err := NewSettings(ctx, fake.NewClient(), dynakube)
+		require.Error(t, err)
+
+		fakeClient := fake.NewClient(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: testName, Namespace: testNamespace},
+			Data:       map[string][]byte{},
+		})
+
+		_, err = NewSettings(ctx, fakeClient, dynakube)
+		require.Error(t, err)
+	})
+	t.Run("trusted certificates from config map", func(t *testing.T) {
+		fakeClient := fake.NewClient(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: testName, Namespace: testNamespace},
+			Data:       map[string]string{dynatracev1beta1.TrustedCAKey: "certs"},
+		})
+
+		settings, err := NewSettings(ctx, fakeClient, createTestDynakube(dynatracev1beta1.DynaKubeSpec{TrustedCAs: testName}))
+
+		require.NoError(t, err)
+		assert.Equal(t, []byte("certs"), settings.TrustedCAs)
+	})
+	t.Run("missing or malformed trusted certificates config map", func(t *testing.T) {
+		dynakube := createTestDynakube(dynatracev1beta1.DynaKubeSpec{TrustedCAs: testName})
+
+		_, err := NewSettings(ctx, fake.NewClient(), dynakube)
+		require.Error(t, err)
+
+		fakeClient := fake.NewClient(&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: testName, Namespace: testNamespace},
+			Data:       map[string]string{},
+		})
+
+		_, err = NewSettings(ctx, fakeClient, dynakube)
+		require.EqualError(t, err, "failed to extract certificate configmap field: missing field certs")
+	})
+}
+
+func TestApply(t *testing.T) {
+	t.Run("proxy respects no proxy", func(t *testing.T) {
+		transport, err := Settings{Proxy: testProxy, NoProxy: "internal.test"}.Apply(&http.Transport{})
+		require.NoError(t, err)
+
+		proxyURL, err := transport.Proxy(&http.Request{URL: &url.URL{Scheme: "https", Host: "tenant.test"}})
+		require.NoError(t, err)
+		assert.Equal(t, testProxy, proxyURL.String())
+
+		proxyURL, err = transport.Proxy(&http.Request{URL: &url.URL{Scheme: "https", Host: "internal.test"}})
+		require.NoError(t, err)
+		assert.Nil(t, proxyURL)
+	})
+	t.Run("skip cert check", func(t *testing.T) {
+		transport, err := Settings{SkipCertCheck: true}.Apply(&http.Transport{})
+		require.NoError(t, err)
+		assert.True(t, transport.TLSClientConfig.InsecureSkipVerify)
+
+		transport, err = Settings{}.Apply(transport)
+		require.NoError(t, err)
+		assert.False(t, transport.TLSClientConfig.InsecureSkipVerify)
+	})
+	t.Run("trusted certificates", func(t *testing.T) {
+		server := httptest.NewTLSServer(http.NotFoundHandler())
+		defer server.Close()
+
+		transport, err := Settings{TrustedCAs: createTestServerCA(t, server)}.Apply(&http.Transport{})
+		require.NoError(t, err)
+
+		response, err := (&http.Client{Transport: transport}).Get(server.URL)
+		require.NoError(t, err)
+		defer response.Body.Close()
+		assert.Equal(t, http.StatusNotFound, response.StatusCode)
+	})
+	t.Run("invalid trusted certificates", func(t *testing.T) {
+		_, err := Settings{TrustedCAs: []byte("certs")}.Apply(&http.Transport{})
+
+		require.EqualError(t, err, "failed to append custom certs")
+	})
+	t.Run("invalid proxy", func(t *testing.T) {
+		_, err := Settings{Proxy: "http://proxy.test:port"}.Apply(&http.Transport{})
+
+		require.Error(t, err)
+	})
+}
@@ -21,6 +21,8 @@ const (
 	AgentInstallerFlavorEnv  = "FLAVOR"
 	AgentInstallerTechEnv    = "TECHNOLOGIES"
 	AgentInstallerVersionEnv = "VERSION"
+	AgentInstallerOsEnv      = "OS"
+	AgentInstallerArchEnv    = "ARCH"
 
 	AgentInstallPathEnv            = "INSTALLPATH"
 	AgentContainerCountEnv         = "CONTAINERS_COUNT"
@@ -0,0 +1,30 @@
+package blobstore
+
+import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/logger"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	log = logger.Factory.GetLogger("csi-blobstore")
+
+	deduplicatedBytesMetric = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "dynatrace",
+		Subsystem: "csi_driver",
+		Name:      "blobs_deduplicated_bytes",
+		Help:      "Bytes of code module files that were replaced by a hardlink to an existing blob",
+	})
+
+	removedBlobsMetric = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "dynatrace",
+		Subsystem: "csi_driver",
+		Name:      "blobs_removed",
+		Help:      "Number of blobs removed because no code module references them anymore",
+	})
+)
+
+func init() {
+	metrics.Registry.MustRegister(deduplicatedBytesMetric)
+	metrics.Registry.MustRegister(removedBlobsMetric)
+}
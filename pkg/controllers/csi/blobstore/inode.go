@@ -0,0 +1,28 @@
+//go:build linux
+
+package blobstore
+
+import (
+	"os"
+	"syscall"
+)
+
+// SharedInode returns the inode of a file that has more than one hardlink, so the size of the file is only counted once
+func SharedInode(info os.FileInfo) (uint64, bool) {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok || stat.Nlink <= 1 {
+		return 0, false
+	}
+
+	return stat.Ino, true
+}
+
+// HardLinks returns the number of hardlinks of the file
+func HardLinks(info os.FileInfo) uint64 {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 1
+	}
+
+	return uint64(stat.Nlink) //nolint:unconvert // the type of Nlink depends on the architecture
+}
@@ -0,0 +1,15 @@
+//go:build !linux
+
+package blobstore
+
+import "os"
+
+// SharedInode is only implemented for linux, which is the only os the csi driver runs on
+func SharedInode(_ os.FileInfo) (uint64, bool) {
+	return 0, false
+}
+
+// HardLinks is only implemented for linux, every file counts as a single link
+func HardLinks(_ os.FileInfo) uint64 {
+	return 1
+}
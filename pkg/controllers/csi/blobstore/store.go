@@ -0,0 +1,220 @@
+package blobstore
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/csi/metadata"
+	"github.com/Dynatrace/dynatrace-operator/pkg/injection/codemodule/installer/common"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+)
+
+const tmpLinkSuffix = ".blob"
+
+// Store keeps a single copy of the files the code modules have in common, e.g. the same version installed from a zip and from an image,
+// or the files that didn't change between two versions.
+// The files of the code modules are hardlinks to blobs in the blob dir, which are identified by their content and mode.
+// The metadata database counts the code modules that reference a blob, a blob is removed once no code module references it.
+//
+// As the code modules are hardlinks, removing a blob or a code module never breaks the other one,
+// the space is only freed once both are removed.
+type Store struct {
+	fs   afero.Fs
+	db   metadata.Access
+	path metadata.PathResolver
+}
+
+func NewStore(fs afero.Fs, db metadata.Access, path metadata.PathResolver) *Store {
+	return &Store{
+		fs:   fs,
+		db:   db,
+		path: path,
+	}
+}
+
+// Deduplicate replaces the files of the code modules in dir with hardlinks to their blobs and references the blobs for the agentBin.
+// It must only run for code modules that aren't mounted yet, as the lower dir of an overlay must not change while it is mounted.
+func (store *Store) Deduplicate(ctx context.Context, agentBin metadata.AgentBin, dir string) error {
+	if !store.supportsHardlinks() {
+		log.Info("deduplication not possible, the filesystem doesn't support hardlinks", "dir", dir)
+		return nil
+	}
+
+	files, err := store.hashFiles(dir)
+	if err != nil {
+		return err
+	}
+
+	// the blobs are referenced before the files are linked, so a crash in between leaves no blob behind that isn't counted
+	if err := store.db.InsertCodeModuleBlobs(ctx, agentBin, uniqueBlobs(files)); err != nil {
+		return err
+	}
+
+	var deduplicatedBytes int64
+
+	for path, blob := range files {
+		replaced, err := store.linkToBlob(path, blob)
+		if err != nil {
+			return err
+		}
+
+		if replaced {
+			deduplicatedBytes += blob.Size
+		}
+	}
+
+	deduplicatedBytesMetric.Add(float64(deduplicatedBytes))
+	log.Info("deduplicated code modules", "dir", dir, "files", len(files), "deduplicatedBytes", deduplicatedBytes)
+
+	return nil
+}
+
+// Release drops the references of the agentBin to its blobs, it has to be called when its code modules are removed
+func (store *Store) Release(ctx context.Context, agentBin metadata.AgentBin) error {
+	return store.db.DeleteCodeModuleBlobs(ctx, agentBin)
+}
+
+// RemoveUnreferenced removes the blobs that no code module references anymore and returns the number of bytes that were freed
+func (store *Store) RemoveUnreferenced(ctx context.Context) (int64, error) {
+	blobs, err := store.db.GetUnreferencedBlobs(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var reclaimed int64
+
+	for _, blob := range blobs {
+		blobPath := store.path.BlobPath(blob.ID)
+		if err := store.fs.Remove(blobPath); err != nil && !os.IsNotExist(err) {
+			return reclaimed, errors.WithMessagef(err, "failed to remove blob %s", blobPath)
+		}
+
+		if err := store.db.DeleteBlob(ctx, blob.ID); err != nil {
+			return reclaimed, err
+		}
+
+		reclaimed += blob.Size
+		removedBlobsMetric.Inc()
+	}
+
+	if len(blobs) > 0 {
+		log.Info("removed unreferenced blobs", "blobs", len(blobs), "reclaimedBytes", reclaimed)
+	}
+
+	return reclaimed, nil
+}
+
+// hashFiles returns the blob of every regular file in dir, symlinks and directories aren't deduplicated
+func (store *Store) hashFiles(dir string) (map[string]*metadata.Blob, error) {
+	files := map[string]*metadata.Blob{}
+
+	err := afero.Walk(store.fs, dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		blob, err := store.hashFile(path, info)
+		if err != nil {
+			return err
+		}
+
+		files[path] = blob
+
+		return nil
+	})
+
+	return files, errors.WithStack(err)
+}
+
+func (store *Store) hashFile(path string, info os.FileInfo) (*metadata.Blob, error) {
+	file, err := store.fs.Open(path)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer func() { _ = file.Close() }()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return nil, errors.WithMessagef(err, "failed to hash %s", path)
+	}
+
+	return &metadata.Blob{
+		ID:   fmt.Sprintf("%x-%o", hash.Sum(nil), info.Mode().Perm()),
+		Size: info.Size(),
+	}, nil
+}
+
+// linkToBlob makes the file a hardlink to its blob, the file becomes the blob if there is none yet.
+// It returns true if the file was replaced by an existing blob.
+func (store *Store) linkToBlob(path string, blob *metadata.Blob) (bool, error) {
+	blobPath := store.path.BlobPath(blob.ID)
+
+	blobInfo, err := store.fs.Stat(blobPath)
+	if os.IsNotExist(err) {
+		if err := store.fs.MkdirAll(filepath.Dir(blobPath), common.MkDirFileMode); err != nil {
+			return false, errors.WithStack(err)
+		}
+
+		return false, errors.WithStack(os.Link(path, blobPath))
+	} else if err != nil {
+		return false, errors.WithStack(err)
+	}
+
+	fileInfo, err := store.fs.Stat(path)
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+
+	if os.SameFile(fileInfo, blobInfo) {
+		return false, nil
+	}
+
+	// the file is replaced atomically, so it exists at any time
+	tmpPath := path + tmpLinkSuffix
+	_ = store.fs.Remove(tmpPath)
+
+	if err := os.Link(blobPath, tmpPath); err != nil {
+		return false, errors.WithStack(err)
+	}
+
+	if err := store.fs.Rename(tmpPath, path); err != nil {
+		_ = store.fs.Remove(tmpPath)
+		return false, errors.WithStack(err)
+	}
+
+	return true, nil
+}
+
+// supportsHardlinks is false for the MemMapFs used for testing, afero has no abstraction for hardlinks
+func (store *Store) supportsHardlinks() bool {
+	_, ok := store.fs.(*afero.OsFs)
+	return ok
+}
+
+func uniqueBlobs(files map[string]*metadata.Blob) []*metadata.Blob {
+	blobs := map[string]*metadata.Blob{}
+	for _, blob := range files {
+		blobs[blob.ID] = blob
+	}
+
+	result := make([]*metadata.Blob, 0, len(blobs))
+	for _, blob := range blobs {
+		result = append(result, blob)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+
+	return result
+}
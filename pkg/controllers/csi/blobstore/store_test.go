@@ -0,0 +1,204 @@
+package blobstore
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/csi/metadata"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testVersion     = "1.2.3"
+	testImageDigest = "abcdef"
+	testArch        = "x86"
+)
+
+var (
+	testZipBin   = metadata.AgentBin{Name: testVersion, Arch: testArch}
+	testImageBin = metadata.AgentBin{Name: testImageDigest, Arch: testArch}
+)
+
+func TestDeduplicate(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("identical files of code modules share their blob", func(t *testing.T) {
+		store, path := newTestStore(t)
+		zipDir := path.AgentSharedBinaryDirForAgentArch(testVersion, testArch)
+		imageDir := path.AgentSharedBinaryDirForAgentArch(testImageDigest, testArch)
+		writeTestFile(t, filepath.Join(zipDir, "agent", "lib", "liboneagent.so"), "binary", 0755)
+		writeTestFile(t, filepath.Join(zipDir, "manifest.json"), "zip", 0644)
+		writeTestFile(t, filepath.Join(imageDir, "agent", "lib", "liboneagent.so"), "binary", 0755)
+		writeTestFile(t, filepath.Join(imageDir, "manifest.json"), "image", 0644)
+
+		require.NoError(t, store.Deduplicate(ctx, testZipBin, zipDir))
+		require.NoError(t, store.Deduplicate(ctx, testImageBin, imageDir))
+
+		assertSameFile(t, true, filepath.Join(zipDir, "agent", "lib", "liboneagent.so"), filepath.Join(imageDir, "agent", "lib", "liboneagent.so"))
+		assertSameFile(t, false, filepath.Join(zipDir, "manifest.json"), filepath.Join(imageDir, "manifest.json"))
+		assertFileContent(t, filepath.Join(imageDir, "manifest.json"), "image")
+
+		assertBlobCount(t, path, 3)
+	})
+	t.Run("files that only differ in their mode aren't linked", func(t *testing.T) {
+		store, path := newTestStore(t)
+		dir := path.AgentSharedBinaryDirForAgentArch(testVersion, testArch)
+		writeTestFile(t, filepath.Join(dir, "executable"), "content", 0755)
+		writeTestFile(t, filepath.Join(dir, "config"), "content", 0644)
+
+		require.NoError(t, store.Deduplicate(ctx, testZipBin, dir))
+
+		assertSameFile(t, false, filepath.Join(dir, "executable"), filepath.Join(dir, "config"))
+	})
+	t.Run("symlinks are kept", func(t *testing.T) {
+		store, path := newTestStore(t)
+		dir := path.AgentSharedBinaryDirForAgentArch(testVersion, testArch)
+		writeTestFile(t, filepath.Join(dir, "agent", "bin", testVersion, "oneagent"), "binary", 0755)
+		require.NoError(t, os.Symlink(testVersion, filepath.Join(dir, "agent", "bin", "current")))
+
+		require.NoError(t, store.Deduplicate(ctx, testZipBin, dir))
+
+		target, err := os.Readlink(filepath.Join(dir, "agent", "bin", "current"))
+		require.NoError(t, err)
+		assert.Equal(t, testVersion, target)
+	})
+	t.Run("nothing is deduplicated without hardlinks", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		path := metadata.PathResolver{RootDir: "/data"}
+		store := NewStore(fs, metadata.FakeMemoryDB(), path)
+		dir := path.AgentSharedBinaryDirForAgentArch(testVersion, testArch)
+		require.NoError(t, afero.WriteFile(fs, filepath.Join(dir, "file"), []byte("content"), 0644))
+
+		require.NoError(t, store.Deduplicate(ctx, testZipBin, dir))
+
+		exists, err := afero.DirExists(fs, path.BlobDir())
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+	t.Run("bad database", func(t *testing.T) {
+		store, path := newTestStore(t)
+		store.db = &metadata.FakeFailDB{}
+		dir := path.AgentSharedBinaryDirForAgentArch(testVersion, testArch)
+		writeTestFile(t, filepath.Join(dir, "file"), "content", 0644)
+
+		require.Error(t, store.Deduplicate(ctx, testZipBin, dir))
+	})
+}
+
+func TestRemoveUnreferenced(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("blobs are removed once no code module references them", func(t *testing.T) {
+		store, path := newTestStore(t)
+		zipDir := path.AgentSharedBinaryDirForAgentArch(testVersion, testArch)
+		imageDir := path.AgentSharedBinaryDirForAgentArch(testImageDigest, testArch)
+		writeTestFile(t, filepath.Join(zipDir, "shared"), "shared", 0644)
+		writeTestFile(t, filepath.Join(zipDir, "zip-only"), "zip", 0644)
+		writeTestFile(t, filepath.Join(imageDir, "shared"), "shared", 0644)
+
+		require.NoError(t, store.Deduplicate(ctx, testZipBin, zipDir))
+		require.NoError(t, store.Deduplicate(ctx, testImageBin, imageDir))
+
+		reclaimed, err := store.RemoveUnreferenced(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, reclaimed)
+
+		require.NoError(t, store.Release(ctx, testZipBin))
+		require.NoError(t, os.RemoveAll(zipDir))
+
+		reclaimed, err = store.RemoveUnreferenced(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(len("zip")), reclaimed)
+		assertBlobCount(t, path, 1)
+		assertFileContent(t, filepath.Join(imageDir, "shared"), "shared")
+
+		require.NoError(t, store.Release(ctx, testImageBin))
+
+		reclaimed, err = store.RemoveUnreferenced(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(len("shared")), reclaimed)
+		assertBlobCount(t, path, 0)
+		// the code modules are hardlinks, so they outlive their blobs
+		assertFileContent(t, filepath.Join(imageDir, "shared"), "shared")
+	})
+	t.Run("bad database", func(t *testing.T) {
+		store, _ := newTestStore(t)
+		store.db = &metadata.FakeFailDB{}
+
+		_, err := store.RemoveUnreferenced(ctx)
+		require.Error(t, err)
+	})
+}
+
+func TestSharedInode(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file")
+	link := filepath.Join(dir, "link")
+	writeTestFile(t, file, "content", 0644)
+
+	info, err := os.Stat(file)
+	require.NoError(t, err)
+
+	_, ok := SharedInode(info)
+	assert.False(t, ok)
+	assert.Equal(t, uint64(1), HardLinks(info))
+
+	require.NoError(t, os.Link(file, link))
+
+	fileInfo, err := os.Stat(file)
+	require.NoError(t, err)
+	linkInfo, err := os.Stat(link)
+	require.NoError(t, err)
+
+	fileInode, ok := SharedInode(fileInfo)
+	assert.True(t, ok)
+	linkInode, ok := SharedInode(linkInfo)
+	assert.True(t, ok)
+	assert.Equal(t, fileInode, linkInode)
+	assert.Equal(t, uint64(2), HardLinks(linkInfo))
+}
+
+func newTestStore(t *testing.T) (*Store, metadata.PathResolver) {
+	path := metadata.PathResolver{RootDir: t.TempDir()}
+
+	return NewStore(afero.NewOsFs(), metadata.FakeMemoryDB(), path), path
+}
+
+func writeTestFile(t *testing.T, path, content string, mode os.FileMode) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, []byte(content), mode))
+	// the mode of WriteFile is reduced by the umask
+	require.NoError(t, os.Chmod(path, mode))
+}
+
+func assertSameFile(t *testing.T, expected bool, path1, path2 string) {
+	info1, err := os.Stat(path1)
+	require.NoError(t, err)
+	info2, err := os.Stat(path2)
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, os.SameFile(info1, info2), "%s and %s", path1, path2)
+}
+
+func assertFileContent(t *testing.T, path, expected string) {
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, expected, string(content))
+}
+
+func assertBlobCount(t *testing.T, path metadata.PathResolver, expected int) {
+	count := 0
+	err := filepath.WalkDir(path.BlobDir(), func(_ string, entry os.DirEntry, err error) error {
+		if err == nil && !entry.IsDir() {
+			count++
+		}
+
+		return err
+	})
+	require.NoError(t, err)
+	assert.Equal(t, expected, count)
+}
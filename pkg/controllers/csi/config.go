@@ -31,6 +31,8 @@ const (
 	OverlayWorkDirPath   = "work"
 	SharedAgentBinDir    = "codemodules"
 	SharedAgentConfigDir = "config"
+	ImageCacheDir        = "cache"
+	BlobDir              = "blobs"
 
 	DaemonSetName = "dynatrace-oneagent-csi-driver"
 
@@ -87,6 +87,7 @@ func (svr *Server) Start(ctx context.Context) error {
 		appvolumes.Mode:  appvolumes.NewAppVolumePublisher(svr.client, svr.fs, svr.mounter, svr.db, svr.path),
 		hostvolumes.Mode: hostvolumes.NewHostVolumePublisher(svr.client, svr.fs, svr.mounter, svr.db, svr.path),
 	}
+	svr.reconcilePublishers(ctx)
 
 	log.Info("starting listener", "protocol", proto, "address", addr)
 
@@ -124,6 +125,20 @@ func (svr *Server) Start(ctx context.Context) error {
 	return err
 }
 
+// reconcilePublishers repairs volumes left behind by a previous run of the server, a failure doesn't prevent the server from starting
+func (svr *Server) reconcilePublishers(ctx context.Context) {
+	for mode, publisher := range svr.publishers {
+		reconciler, ok := publisher.(csivolumes.Reconciler)
+		if !ok {
+			continue
+		}
+
+		if err := reconciler.Reconcile(ctx); err != nil {
+			log.Error(err, "failed to reconcile volumes", "mode", mode)
+		}
+	}
+}
+
 func (svr *Server) GetPluginInfo(context.Context, *csi.GetPluginInfoRequest) (*csi.GetPluginInfoResponse, error) {
 	return &csi.GetPluginInfoResponse{Name: dtcsi.DriverName, VendorVersion: version.Version}, nil
 }
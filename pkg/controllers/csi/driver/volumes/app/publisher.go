@@ -22,6 +22,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	dtcsi "github.com/Dynatrace/dynatrace-operator/pkg/controllers/csi"
 	csivolumes "github.com/Dynatrace/dynatrace-operator/pkg/controllers/csi/driver/volumes"
@@ -99,10 +100,15 @@ func (publisher *AppVolumePublisher) UnpublishVolume(ctx context.Context, volume
 		return &csi.NodeUnpublishVolumeResponse{}, publisher.db.DeleteVolume(ctx, volume.VolumeID)
 	}
 
+	intent := metadata.NewVolumeIntent(metadata.VolumeOperationUnpublish, volume.VolumeID, volume.TenantUUID, volumeInfo.TargetPath)
+	if err = publisher.db.InsertVolumeIntent(ctx, intent); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
 	overlayFSPath := publisher.path.AgentRunDirForVolume(volume.TenantUUID, volumeInfo.VolumeID)
 	publisher.umountOneAgent(volumeInfo.TargetPath, overlayFSPath)
 
-	if err = publisher.db.DeleteVolume(ctx, volume.VolumeID); err != nil {
+	if err = publisher.deleteVolume(ctx, volume.VolumeID); err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 	log.Info("deleted volume info", "ID", volume.VolumeID, "PodUID", volume.PodName, "Version", volume.Version, "TenantUUID", volume.TenantUUID)
@@ -140,17 +146,31 @@ func (publisher *AppVolumePublisher) fireVolumeUnpublishedMetric(volume metadata
 }
 
 func (publisher *AppVolumePublisher) buildLowerDir(bindCfg *csivolumes.BindConfig) string {
+	directories := []string{
+		publisher.path.AgentConfigDir(bindCfg.TenantUUID),
+		publisher.agentBinaryDir(bindCfg),
+	}
+	return strings.Join(directories, ":")
+}
+
+func (publisher *AppVolumePublisher) agentBinaryDir(bindCfg *csivolumes.BindConfig) string {
 	var binFolderName string
 	if bindCfg.ImageDigest == "" {
 		binFolderName = bindCfg.Version
 	} else {
 		binFolderName = bindCfg.ImageDigest
 	}
-	directories := []string{
-		publisher.path.AgentConfigDir(bindCfg.TenantUUID),
-		publisher.path.AgentSharedBinaryDirForAgent(binFolderName),
+	return publisher.path.AgentSharedBinaryDirForAgentArch(binFolderName, bindCfg.Arch)
+}
+
+// markAgentBinaryUsed updates the modification time of the mounted binaries, the garbage collector evicts the least recently used binaries first
+func (publisher *AppVolumePublisher) markAgentBinaryUsed(bindCfg *csivolumes.BindConfig) {
+	now := time.Now()
+	binaryDir := publisher.agentBinaryDir(bindCfg)
+
+	if err := publisher.fs.Chtimes(binaryDir, now, now); err != nil {
+		log.Info("failed to update the modification time of the agent binaries", "path", binaryDir, "error", err.Error())
 	}
-	return strings.Join(directories, ":")
 }
 
 func (publisher *AppVolumePublisher) mountOneAgent(bindCfg *csivolumes.BindConfig, volumeCfg *csivolumes.VolumeConfig) error {
@@ -197,8 +217,20 @@ func (publisher *AppVolumePublisher) umountOneAgent(targetPath string, overlayFS
 	}
 }
 
+// ensureMountSteps records the intent to publish the volume before mounting it, so a mount that isn't stored in the volumes table
+// because of a crash is found by the reconciliation on the next start of the csi server
 func (publisher *AppVolumePublisher) ensureMountSteps(ctx context.Context, bindCfg *csivolumes.BindConfig, volumeCfg *csivolumes.VolumeConfig) error {
+	intent := metadata.NewVolumeIntent(metadata.VolumeOperationPublish, volumeCfg.VolumeID, bindCfg.TenantUUID, volumeCfg.TargetPath)
+	if err := publisher.db.InsertVolumeIntent(ctx, intent); err != nil {
+		return status.Error(codes.Internal, fmt.Sprintf("failed to store volume intent: %s", err))
+	}
+
 	if err := publisher.mountOneAgent(bindCfg, volumeCfg); err != nil {
+		// mountOneAgent doesn't leave a mount behind, so the intent is completed
+		if err := publisher.db.DeleteVolumeIntent(ctx, volumeCfg.VolumeID); err != nil {
+			log.Info("failed to delete volume intent", "error", err.Error())
+		}
+
 		return status.Error(codes.Internal, fmt.Sprintf("failed to mount oneagent volume: %s", err))
 	}
 
@@ -208,28 +240,52 @@ func (publisher *AppVolumePublisher) ensureMountSteps(ctx context.Context, bindC
 
 		return status.Error(codes.Internal, fmt.Sprintf("Failed to store volume info: %s", err))
 	}
+
+	publisher.markAgentBinaryUsed(bindCfg)
+
 	return nil
 }
 
 func (publisher *AppVolumePublisher) hasTooManyMountAttempts(ctx context.Context, bindCfg *csivolumes.BindConfig, volumeCfg *csivolumes.VolumeConfig) (bool, error) {
-	volume, err := publisher.loadVolume(ctx, volumeCfg.VolumeID)
-	if err != nil {
-		return false, err
-	}
-	if volume == nil {
-		volume = createNewVolume(bindCfg, volumeCfg)
-	}
-	if volume.MountAttempts > bindCfg.MaxMountAttempts {
-		return true, nil
-	}
-	volume.MountAttempts += 1
-	return false, publisher.db.InsertVolume(ctx, volume)
+	hasTooManyAttempts := false
+	err := publisher.db.WithTx(ctx, func(access metadata.Access) error {
+		volume, err := access.GetVolume(ctx, volumeCfg.VolumeID)
+		if err != nil {
+			return err
+		}
+		if volume == nil {
+			volume = createNewVolume(bindCfg, volumeCfg)
+		}
+		if volume.MountAttempts > bindCfg.MaxMountAttempts {
+			hasTooManyAttempts = true
+			return nil
+		}
+		volume.MountAttempts += 1
+		return access.InsertVolume(ctx, volume)
+	})
+	return hasTooManyAttempts, err
 }
 
+// storeVolume stores the mounted volume and completes its publish intent
 func (publisher *AppVolumePublisher) storeVolume(ctx context.Context, bindCfg *csivolumes.BindConfig, volumeCfg *csivolumes.VolumeConfig) error {
 	volume := createNewVolume(bindCfg, volumeCfg)
 	log.Info("inserting volume info", "ID", volume.VolumeID, "PodUID", volume.PodName, "Version", volume.Version, "TenantUUID", volume.TenantUUID)
-	return publisher.db.InsertVolume(ctx, volume)
+	return publisher.db.WithTx(ctx, func(access metadata.Access) error {
+		if err := access.InsertVolume(ctx, volume); err != nil {
+			return err
+		}
+		return access.DeleteVolumeIntent(ctx, volume.VolumeID)
+	})
+}
+
+// deleteVolume removes the unmounted volume and completes its unpublish intent
+func (publisher *AppVolumePublisher) deleteVolume(ctx context.Context, volumeID string) error {
+	return publisher.db.WithTx(ctx, func(access metadata.Access) error {
+		if err := access.DeleteVolume(ctx, volumeID); err != nil {
+			return err
+		}
+		return access.DeleteVolumeIntent(ctx, volumeID)
+	})
 }
 
 func (publisher *AppVolumePublisher) loadVolume(ctx context.Context, volumeID string) (*metadata.Volume, error) {
@@ -245,5 +301,5 @@ func createNewVolume(bindCfg *csivolumes.BindConfig, volumeCfg *csivolumes.Volum
 	if bindCfg.ImageDigest != "" {
 		version = bindCfg.ImageDigest
 	}
-	return metadata.NewVolume(volumeCfg.VolumeID, volumeCfg.PodName, version, bindCfg.TenantUUID, 0)
+	return metadata.NewVolume(volumeCfg.VolumeID, volumeCfg.PodName, version, bindCfg.TenantUUID, 0, bindCfg.Arch)
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
 	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
@@ -28,6 +29,7 @@ const (
 	testAgentVersion = "1.2-3"
 	testDynakubeName = "a-dynakube"
 	testImageDigest  = "sha256:123456789"
+	testArch         = "arm"
 )
 
 func TestPublishVolume(t *testing.T) {
@@ -87,6 +89,47 @@ func TestPublishVolume(t *testing.T) {
 		assertReferencesForPublishedVolumeWithCodeModulesImage(t, &publisher, mounter)
 	})
 
+	t.Run("using binaries stored per arch", func(t *testing.T) {
+		mounter := mount.NewFakeMounter([]mount.MountPoint{})
+		publisher := newPublisherForTesting(mounter)
+		err := publisher.db.InsertDynakube(context.TODO(), metadata.NewDynakube(testDynakubeName, testTenantUUID, testAgentVersion, "", 0, testArch))
+		require.NoError(t, err)
+
+		response, err := publisher.PublishVolume(context.TODO(), createTestVolumeConfig())
+		require.NoError(t, err)
+		assert.NotNil(t, response)
+
+		require.NotEmpty(t, mounter.MountPoints)
+		assert.Equal(t, []string{
+			"lowerdir=/a-tenant-uuid/config:/codemodules/" + testArch + "/1.2-3",
+			"upperdir=/a-tenant-uuid/run/a-volume/var",
+			"workdir=/a-tenant-uuid/run/a-volume/work"},
+			mounter.MountPoints[0].Opts)
+
+		volume, err := publisher.loadVolume(context.TODO(), testVolumeId)
+		require.NoError(t, err)
+		require.NotNil(t, volume)
+		assert.Equal(t, testArch, volume.Arch)
+	})
+
+	t.Run("marks binaries as used", func(t *testing.T) {
+		mounter := mount.NewFakeMounter([]mount.MountPoint{})
+		publisher := newPublisherForTesting(mounter)
+		mockUrlDynakubeMetadata(t, &publisher)
+
+		binaryDir := publisher.path.AgentSharedBinaryDirForAgent(testAgentVersion)
+		lastUsed := time.Now().Add(-time.Hour)
+		require.NoError(t, publisher.fs.MkdirAll(binaryDir, 0770))
+		require.NoError(t, publisher.fs.Chtimes(binaryDir, lastUsed, lastUsed))
+
+		_, err := publisher.PublishVolume(context.TODO(), createTestVolumeConfig())
+		require.NoError(t, err)
+
+		info, err := publisher.fs.Stat(binaryDir)
+		require.NoError(t, err)
+		assert.True(t, info.ModTime().After(lastUsed))
+	})
+
 	t.Run("too many mount attempts", func(t *testing.T) {
 		mounter := mount.NewFakeMounter([]mount.MountPoint{})
 		publisher := newPublisherForTesting(mounter)
@@ -211,6 +254,25 @@ func TestNodePublishAndUnpublishVolume(t *testing.T) {
 	require.NotNil(t, unpublishResponse)
 	require.Empty(t, mounter.MountPoints)
 	assertNoReferencesForUnpublishedVolume(t, &publisher)
+	assertNoVolumeIntents(t, &publisher)
+}
+
+func TestPublishVolumeWithFailingMount(t *testing.T) {
+	mounter := &failingBindMounter{FakeMounter: mount.NewFakeMounter([]mount.MountPoint{})}
+	publisher := newPublisherForTesting(mounter.FakeMounter)
+	publisher.mounter = mounter
+	mockUrlDynakubeMetadata(t, &publisher)
+
+	_, err := publisher.PublishVolume(context.TODO(), createTestVolumeConfig())
+	require.Error(t, err)
+
+	assert.Empty(t, mounter.MountPoints)
+	assertNoVolumeIntents(t, &publisher)
+
+	volume, err := publisher.loadVolume(context.TODO(), testVolumeId)
+	require.NoError(t, err)
+	require.NotNil(t, volume)
+	assert.Equal(t, 1, volume.MountAttempts)
 }
 
 func TestStoreAndLoadPodInfo(t *testing.T) {
@@ -293,24 +355,24 @@ func newPublisherForTesting(mounter *mount.FakeMounter) AppVolumePublisher {
 
 func mockPublishedVolume(t *testing.T, publisher *AppVolumePublisher) {
 	mockUrlDynakubeMetadata(t, publisher)
-	err := publisher.db.InsertVolume(context.TODO(), metadata.NewVolume(testVolumeId, testPodUID, testAgentVersion, testTenantUUID, 0))
+	err := publisher.db.InsertVolume(context.TODO(), metadata.NewVolume(testVolumeId, testPodUID, testAgentVersion, testTenantUUID, 0, ""))
 	require.NoError(t, err)
 	agentsVersionsMetric.WithLabelValues(testAgentVersion).Inc()
 }
 
 func mockFailedPublishedVolume(t *testing.T, publisher *AppVolumePublisher) {
 	mockUrlDynakubeMetadata(t, publisher)
-	err := publisher.db.InsertVolume(context.TODO(), metadata.NewVolume(testVolumeId, testPodUID, testAgentVersion, testTenantUUID, dynatracev1beta1.DefaultMaxFailedCsiMountAttempts+1))
+	err := publisher.db.InsertVolume(context.TODO(), metadata.NewVolume(testVolumeId, testPodUID, testAgentVersion, testTenantUUID, dynatracev1beta1.DefaultMaxFailedCsiMountAttempts+1, ""))
 	require.NoError(t, err)
 }
 
 func mockUrlDynakubeMetadata(t *testing.T, publisher *AppVolumePublisher) {
-	err := publisher.db.InsertDynakube(context.TODO(), metadata.NewDynakube(testDynakubeName, testTenantUUID, testAgentVersion, "", 0))
+	err := publisher.db.InsertDynakube(context.TODO(), metadata.NewDynakube(testDynakubeName, testTenantUUID, testAgentVersion, "", 0, ""))
 	require.NoError(t, err)
 }
 
 func mockImageDynakubeMetadata(t *testing.T, publisher *AppVolumePublisher) {
-	err := publisher.db.InsertDynakube(context.TODO(), metadata.NewDynakube(testDynakubeName, testTenantUUID, "", testImageDigest, dynatracev1beta1.DefaultMaxFailedCsiMountAttempts))
+	err := publisher.db.InsertDynakube(context.TODO(), metadata.NewDynakube(testDynakubeName, testTenantUUID, "", testImageDigest, dynatracev1beta1.DefaultMaxFailedCsiMountAttempts, ""))
 	require.NoError(t, err)
 }
 
@@ -340,6 +402,25 @@ func assertNoReferencesForUnpublishedVolume(t *testing.T, publisher *AppVolumePu
 	require.Nil(t, volume)
 }
 
+func assertNoVolumeIntents(t *testing.T, publisher *AppVolumePublisher) {
+	intents, err := publisher.db.GetAllVolumeIntents(context.TODO())
+	require.NoError(t, err)
+	assert.Empty(t, intents)
+}
+
+// failingBindMounter fails to bind mount the overlay into the target path
+type failingBindMounter struct {
+	*mount.FakeMounter
+}
+
+func (mounter *failingBindMounter) Mount(source string, target string, fstype string, options []string) error {
+	if fstype == "" {
+		return fmt.Errorf("failed to mount %s", target)
+	}
+
+	return mounter.FakeMounter.Mount(source, target, fstype, options)
+}
+
 func resetMetrics() {
 	agentsVersionsMetric.DeleteLabelValues(testAgentVersion)
 	agentsVersionsMetric.DeleteLabelValues(testImageDigest)
@@ -0,0 +1,142 @@
+package appvolumes
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	dtcsi "github.com/Dynatrace/dynatrace-operator/pkg/controllers/csi"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/csi/metadata"
+	"github.com/pkg/errors"
+)
+
+// Reconcile repairs the state left behind by a csi server that stopped while publishing or unpublishing a volume.
+// It has to run before the server accepts requests, it compares the mounts of the node with the database:
+//   - unfinished publishes are unmounted, kubelet retries them
+//   - unfinished unpublishes are completed
+//   - volumes that aren't mounted anymore, e.g. after a reboot of the node, are removed from the database, kubelet publishes them again if needed
+//   - overlays that aren't in the database are unmounted
+func (publisher *AppVolumePublisher) Reconcile(ctx context.Context) error {
+	mountPoints, err := publisher.mounter.List()
+	if err != nil {
+		return errors.WithMessage(err, "failed to list mount points")
+	}
+
+	mounted := make(map[string]bool, len(mountPoints))
+	for _, mountPoint := range mountPoints {
+		mounted[mountPoint.Path] = true
+	}
+
+	if err := publisher.finishVolumeIntents(ctx, mounted); err != nil {
+		return err
+	}
+
+	if err := publisher.removeUnmountedVolumes(ctx, mounted); err != nil {
+		return err
+	}
+
+	return publisher.unmountOrphanedOverlays(ctx, mounted)
+}
+
+func (publisher *AppVolumePublisher) finishVolumeIntents(ctx context.Context, mounted map[string]bool) error {
+	intents, err := publisher.db.GetAllVolumeIntents(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, intent := range intents {
+		log.Info("finishing interrupted volume operation", "operation", intent.Operation, "volumeID", intent.VolumeID, "targetPath", intent.TargetPath)
+
+		publisher.unmountIfMounted(mounted, intent.TargetPath)
+		publisher.unmountIfMounted(mounted, publisher.path.OverlayMappedDir(intent.TenantUUID, intent.VolumeID))
+
+		switch intent.Operation {
+		case metadata.VolumeOperationUnpublish:
+			if err := publisher.deleteVolume(ctx, intent.VolumeID); err != nil {
+				return err
+			}
+
+			if err := publisher.fs.RemoveAll(intent.TargetPath); err != nil {
+				log.Info("failed to remove target path of unpublished volume", "targetPath", intent.TargetPath, "error", err.Error())
+			}
+		default:
+			if err := publisher.db.DeleteVolumeIntent(ctx, intent.VolumeID); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (publisher *AppVolumePublisher) removeUnmountedVolumes(ctx context.Context, mounted map[string]bool) error {
+	volumes, err := publisher.db.GetAllVolumes(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, volume := range volumes {
+		if volume.Version == "" || mounted[publisher.path.OverlayMappedDir(volume.TenantUUID, volume.VolumeID)] {
+			continue
+		}
+
+		log.Info("removing volume that isn't mounted anymore", "volumeID", volume.VolumeID, "pod", volume.PodName)
+
+		if err := publisher.db.DeleteVolume(ctx, volume.VolumeID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (publisher *AppVolumePublisher) unmountOrphanedOverlays(ctx context.Context, mounted map[string]bool) error {
+	for path := range mounted {
+		tenantUUID, volumeID, ok := publisher.parseOverlayMappedDir(path)
+		if !ok {
+			continue
+		}
+
+		volume, err := publisher.db.GetVolume(ctx, volumeID)
+		if err != nil {
+			return err
+		}
+
+		if volume != nil && volume.TenantUUID == tenantUUID {
+			continue
+		}
+
+		log.Info("unmounting overlay of unknown volume", "path", path)
+		publisher.unmountIfMounted(mounted, path)
+	}
+
+	return nil
+}
+
+// parseOverlayMappedDir returns the tenant and volume of a path created by PathResolver.OverlayMappedDir
+func (publisher *AppVolumePublisher) parseOverlayMappedDir(path string) (tenantUUID string, volumeID string, ok bool) {
+	relativePath, err := filepath.Rel(publisher.path.RootDir, path)
+	if err != nil {
+		return "", "", false
+	}
+
+	parts := strings.Split(relativePath, string(filepath.Separator))
+	if len(parts) != 4 || parts[0] == ".." || parts[1] != dtcsi.AgentRunDir || parts[3] != dtcsi.OverlayMappedDirPath {
+		return "", "", false
+	}
+
+	return parts[0], parts[2], true
+}
+
+func (publisher *AppVolumePublisher) unmountIfMounted(mounted map[string]bool, path string) {
+	if !mounted[path] {
+		return
+	}
+
+	if err := publisher.mounter.Unmount(path); err != nil {
+		log.Error(err, "Unmount failed", "path", path)
+		return
+	}
+
+	delete(mounted, path)
+}
@@ -0,0 +1,108 @@
+package appvolumes
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/csi/metadata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/mount"
+)
+
+var testMappedDir = fmt.Sprintf("/%s/run/%s/mapped", testTenantUUID, testVolumeId)
+
+func TestReconcile(t *testing.T) {
+	ctx := context.TODO()
+
+	t.Run("consistent volumes are left unchanged", func(t *testing.T) {
+		mounter := mount.NewFakeMounter([]mount.MountPoint{{Path: testTargetPath}, {Path: testMappedDir}})
+		publisher := newPublisherForTesting(mounter)
+		mockPublishedVolume(t, &publisher)
+
+		require.NoError(t, publisher.Reconcile(ctx))
+
+		assert.Len(t, mounter.MountPoints, 2)
+		assertReferencesForPublishedVolume(t, &publisher, mounter)
+	})
+	t.Run("interrupted publish is unmounted", func(t *testing.T) {
+		mounter := mount.NewFakeMounter([]mount.MountPoint{{Path: testTargetPath}, {Path: testMappedDir}})
+		publisher := newPublisherForTesting(mounter)
+		mockPublishedVolume(t, &publisher)
+		mockVolumeIntent(t, &publisher, metadata.VolumeOperationPublish)
+
+		require.NoError(t, publisher.Reconcile(ctx))
+
+		assert.Empty(t, mounter.MountPoints)
+		assertNoVolumeIntents(t, &publisher)
+		assertNoReferencesForUnpublishedVolume(t, &publisher)
+	})
+	t.Run("interrupted unpublish is completed", func(t *testing.T) {
+		mounter := mount.NewFakeMounter([]mount.MountPoint{{Path: testMappedDir}})
+		publisher := newPublisherForTesting(mounter)
+		mockPublishedVolume(t, &publisher)
+		mockVolumeIntent(t, &publisher, metadata.VolumeOperationUnpublish)
+		require.NoError(t, publisher.fs.MkdirAll(testTargetPath, 0755))
+
+		require.NoError(t, publisher.Reconcile(ctx))
+
+		assert.Empty(t, mounter.MountPoints)
+		assertNoVolumeIntents(t, &publisher)
+		assertNoReferencesForUnpublishedVolume(t, &publisher)
+
+		exists, err := publisher.fs.DirExists(testTargetPath)
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+	t.Run("volume that isn't mounted is removed", func(t *testing.T) {
+		mounter := mount.NewFakeMounter([]mount.MountPoint{})
+		publisher := newPublisherForTesting(mounter)
+		mockPublishedVolume(t, &publisher)
+
+		require.NoError(t, publisher.Reconcile(ctx))
+
+		assertNoReferencesForUnpublishedVolume(t, &publisher)
+	})
+	t.Run("overlay of unknown volume is unmounted", func(t *testing.T) {
+		otherMount := "/var/lib/kubelet/pods/other/mount"
+		mounter := mount.NewFakeMounter([]mount.MountPoint{{Path: testMappedDir}, {Path: otherMount}})
+		publisher := newPublisherForTesting(mounter)
+
+		require.NoError(t, publisher.Reconcile(ctx))
+
+		require.Len(t, mounter.MountPoints, 1)
+		assert.Equal(t, otherMount, mounter.MountPoints[0].Path)
+	})
+	t.Run("database error", func(t *testing.T) {
+		mounter := mount.NewFakeMounter([]mount.MountPoint{{Path: testMappedDir}})
+		publisher := newPublisherForTesting(mounter)
+		publisher.db = &metadata.FakeFailDB{}
+
+		require.Error(t, publisher.Reconcile(ctx))
+		assert.Len(t, mounter.MountPoints, 1)
+	})
+}
+
+func TestParseOverlayMappedDir(t *testing.T) {
+	publisher := newPublisherForTesting(mount.NewFakeMounter(nil))
+
+	tenantUUID, volumeID, ok := publisher.parseOverlayMappedDir(publisher.path.OverlayMappedDir(testTenantUUID, testVolumeId))
+	require.True(t, ok)
+	assert.Equal(t, testTenantUUID, tenantUUID)
+	assert.Equal(t, testVolumeId, volumeID)
+
+	for _, path := range []string{
+		testTargetPath,
+		publisher.path.OverlayVarDir(testTenantUUID, testVolumeId),
+		publisher.path.AgentRunDirForVolume(testTenantUUID, testVolumeId),
+	} {
+		_, _, ok := publisher.parseOverlayMappedDir(path)
+		assert.False(t, ok, path)
+	}
+}
+
+func mockVolumeIntent(t *testing.T, publisher *AppVolumePublisher, operation metadata.VolumeOperation) {
+	err := publisher.db.InsertVolumeIntent(context.TODO(), metadata.NewVolumeIntent(operation, testVolumeId, testTenantUUID, testTargetPath))
+	require.NoError(t, err)
+}
@@ -12,6 +12,7 @@ const (
 	testDynakubeName = "a-dynakube"
 	testTenantUUID   = "a-tenant-uuid"
 	testAgentVersion = "1.2-3"
+	testArch         = "arm"
 )
 
 func TestNewBindConfig(t *testing.T) {
@@ -32,13 +33,14 @@ func TestNewBindConfig(t *testing.T) {
 
 		db := metadata.FakeMemoryDB()
 
-		db.InsertDynakube(context.TODO(), metadata.NewDynakube(testDynakubeName, testTenantUUID, testAgentVersion, "", 0))
+		db.InsertDynakube(context.TODO(), metadata.NewDynakube(testDynakubeName, testTenantUUID, testAgentVersion, "", 0, testArch))
 
 		bindCfg, err := NewBindConfig(context.TODO(), db, volumeCfg)
 
 		expected := BindConfig{
 			TenantUUID: testTenantUUID,
 			Version:    testAgentVersion,
+			Arch:       testArch,
 		}
 		assert.NoError(t, err)
 		assert.NotNil(t, bindCfg)
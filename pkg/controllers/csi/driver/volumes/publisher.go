@@ -11,3 +11,8 @@ type Publisher interface {
 	UnpublishVolume(ctx context.Context, volumeInfo *VolumeInfo) (*csi.NodeUnpublishVolumeResponse, error)
 	CanUnpublishVolume(ctx context.Context, volumeInfo *VolumeInfo) (bool, error)
 }
+
+// Reconciler is implemented by publishers that need to repair their volumes before the csi server accepts requests
+type Reconciler interface {
+	Reconcile(ctx context.Context) error
+}
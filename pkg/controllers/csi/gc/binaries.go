@@ -4,6 +4,8 @@ import (
 	"context"
 	"os"
 
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/csi/blobstore"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/csi/metadata"
 	"github.com/pkg/errors"
 	"github.com/spf13/afero"
 )
@@ -45,7 +47,12 @@ func (gc *CSIGarbageCollector) runBinaryGarbageCollection(ctx context.Context, t
 }
 
 func (gc *CSIGarbageCollector) getStoredVersions(fs *afero.Afero, tenantUUID string) ([]string, error) {
-	bins, err := fs.ReadDir(gc.path.AgentBinaryDir(tenantUUID))
+	return GetStoredVersions(fs, gc.path, tenantUUID)
+}
+
+// GetStoredVersions lists the versions of a tenant stored in the deprecated location
+func GetStoredVersions(fs *afero.Afero, path metadata.PathResolver, tenantUUID string) ([]string, error) {
+	bins, err := fs.ReadDir(path.AgentBinaryDir(tenantUUID))
 	versions := make([]string, 0, len(bins))
 
 	if os.IsNotExist(err) {
@@ -66,7 +73,7 @@ func shouldDeleteVersion(version string, usedVersions map[string]bool) bool {
 }
 
 func removeUnusedVersion(fs *afero.Afero, binaryPath string) {
-	size, _ := dirSize(fs, binaryPath)
+	size, _ := DirSize(fs, binaryPath)
 	err := fs.RemoveAll(binaryPath)
 	if err != nil {
 		log.Info("delete failed", "path", binaryPath)
@@ -76,7 +83,8 @@ func removeUnusedVersion(fs *afero.Afero, binaryPath string) {
 	}
 }
 
-func dirSize(fs *afero.Afero, path string) (int64, error) {
+// DirSize returns the size of the files in the directory and its subdirectories
+func DirSize(fs *afero.Afero, path string) (int64, error) {
 	var size int64
 	err := fs.Walk(path, func(_ string, info os.FileInfo, err error) error {
 		if err != nil {
@@ -89,3 +97,31 @@ func dirSize(fs *afero.Afero, path string) (int64, error) {
 	})
 	return size, err
 }
+
+// DiskUsage returns the space the paths take up together, files that are hardlinked more than once, like the deduplicated code modules, are counted once
+func DiskUsage(fs *afero.Afero, paths ...string) (int64, error) {
+	var size int64
+	countedInodes := map[uint64]bool{}
+	for _, path := range paths {
+		err := fs.Walk(path, func(_ string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() {
+				return nil
+			}
+			if inode, ok := blobstore.SharedInode(info); ok {
+				if countedInodes[inode] {
+					return nil
+				}
+				countedInodes[inode] = true
+			}
+			size += info.Size()
+			return nil
+		})
+		if err != nil {
+			return size, err
+		}
+	}
+	return size, nil
+}
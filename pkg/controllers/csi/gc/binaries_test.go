@@ -3,6 +3,7 @@ package csigc
 import (
 	"context"
 	"fmt"
+	"os"
 	"path/filepath"
 	"testing"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const (
@@ -86,6 +88,26 @@ func TestBinaryGarbageCollector_getUsedVersions(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestDiskUsage(t *testing.T) {
+	dir := t.TempDir()
+	fs := &afero.Afero{Fs: afero.NewOsFs()}
+	dir1 := filepath.Join(dir, testVersion1)
+	dir2 := filepath.Join(dir, testVersion2)
+	require.NoError(t, fs.MkdirAll(dir1, 0755))
+	require.NoError(t, fs.MkdirAll(dir2, 0755))
+	require.NoError(t, fs.WriteFile(filepath.Join(dir1, "shared"), []byte("shared"), 0644))
+	require.NoError(t, fs.WriteFile(filepath.Join(dir2, "other"), []byte("other"), 0644))
+	require.NoError(t, os.Link(filepath.Join(dir1, "shared"), filepath.Join(dir2, "shared")))
+
+	size, err := DirSize(fs, dir2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len("shared")+len("other")), size)
+
+	usage, err := DiskUsage(fs, dir1, dir2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len("shared")+len("other")), usage)
+}
+
 func NewMockGarbageCollector() *CSIGarbageCollector {
 	return &CSIGarbageCollector{
 		fs:                    afero.NewMemMapFs(),
@@ -105,7 +127,7 @@ func (gc *CSIGarbageCollector) mockUsedVersions(versions ...string) {
 	_ = gc.fs.Mkdir(testBinaryDir, 0770)
 	for i, version := range versions {
 		_, _ = gc.fs.Create(filepath.Join(testBinaryDir, version))
-		_ = gc.db.InsertVolume(context.TODO(), metadata.NewVolume(fmt.Sprintf("pod%b", i), fmt.Sprintf("volume%b", i), version, testTenantUUID, 0))
+		_ = gc.db.InsertVolume(context.TODO(), metadata.NewVolume(fmt.Sprintf("pod%b", i), fmt.Sprintf("volume%b", i), version, testTenantUUID, 0, ""))
 	}
 }
 
@@ -29,10 +29,47 @@ var (
 		Name:      "gc_runs",
 		Help:      "Number of GC runs",
 	})
+
+	storageUsageMetric = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "dynatrace",
+		Subsystem: "csi_driver",
+		Name:      "gc_storage_usage",
+		Help:      "Size of the CSI data directory in bytes, measured by the GC if a storage budget is set",
+	})
+
+	storageBudgetMetric = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "dynatrace",
+		Subsystem: "csi_driver",
+		Name:      "gc_storage_budget",
+		Help:      "Storage budget of the CSI data directory in bytes",
+	})
+
+	evictionsMetric = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "dynatrace",
+		Subsystem: "csi_driver",
+		Name:      "gc_evictions",
+		Help:      "Number of directories evicted by the GC to stay within the storage budget",
+	}, []string{"kind"})
+
+	evictedBytesMetric = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "dynatrace",
+		Subsystem: "csi_driver",
+		Name:      "gc_evicted_bytes",
+		Help:      "Amount of memory reclaimed by the GC to stay within the storage budget",
+	}, []string{"kind"})
+)
+
+const (
+	storageBudgetEvictionEvent = "StorageBudgetEviction"
+	storageBudgetExceededEvent = "StorageBudgetExceeded"
 )
 
 func init() {
 	metrics.Registry.MustRegister(reclaimedMemoryMetric)
 	metrics.Registry.MustRegister(foldersRemovedMetric)
 	metrics.Registry.MustRegister(gcRunsMetric)
+	metrics.Registry.MustRegister(storageUsageMetric)
+	metrics.Registry.MustRegister(storageBudgetMetric)
+	metrics.Registry.MustRegister(evictionsMetric)
+	metrics.Registry.MustRegister(evictedBytesMetric)
 }
@@ -3,22 +3,26 @@ package csigc
 import (
 	"context"
 	"os"
+	"slices"
 
+	"github.com/Dynatrace/dynatrace-operator/pkg/arch"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/csi/blobstore"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/csi/metadata"
 	"github.com/pkg/errors"
 	"github.com/spf13/afero"
 )
 
 func (gc *CSIGarbageCollector) runSharedBinaryGarbageCollection(ctx context.Context) error {
-	imageDirs, err := gc.getSharedBinDirs()
+	agentBins, err := gc.getSharedAgentBins()
 	if err != nil {
 		return err
 	}
-	if len(imageDirs) == 0 {
+	if len(agentBins) == 0 {
 		log.Info("no shared binary dirs on node")
 		return nil
 	}
 
-	binsToDelete, err := gc.collectUnusedAgentBins(ctx, imageDirs)
+	binsToDelete, err := gc.collectUnusedAgentBins(ctx, agentBins)
 	if err != nil {
 		return err
 	}
@@ -27,46 +31,74 @@ func (gc *CSIGarbageCollector) runSharedBinaryGarbageCollection(ctx context.Cont
 		return nil
 	}
 
-	return deleteSharedBinDirs(gc.fs, binsToDelete)
-}
-
-func (gc *CSIGarbageCollector) getSharedBinDirs() ([]os.FileInfo, error) {
-	imageDirs, err := afero.Afero{Fs: gc.fs}.ReadDir(gc.path.AgentSharedBinaryDirBase())
-	if os.IsNotExist(err) {
-		return nil, nil
+	// the blobs are released first, a crash before the dirs are deleted only leaves dirs behind that are deleted by the next run
+	store := gc.blobStore()
+	dirsToDelete := make([]string, 0, len(binsToDelete))
+	for _, agentBin := range binsToDelete {
+		if err := store.Release(ctx, agentBin); err != nil {
+			return err
+		}
+		dirsToDelete = append(dirsToDelete, gc.path.AgentSharedBinaryDirForAgentArch(agentBin.Name, agentBin.Arch))
 	}
-	if err != nil {
-		log.Info("failed to read shared image directory")
-		return nil, errors.WithStack(err)
+
+	if err := deleteSharedBinDirs(gc.fs, dirsToDelete); err != nil {
+		return err
 	}
-	return imageDirs, nil
+
+	return gc.removeUnreferencedBlobs(ctx)
 }
 
-func (gc *CSIGarbageCollector) collectUnusedAgentBins(ctx context.Context, imageDirs []os.FileInfo) ([]string, error) {
-	var toDelete []string
-	usedAgentVersions, err := gc.db.GetLatestVersions(ctx)
-	if err != nil {
-		log.Info("failed to get the used image versions")
-		return nil, err
-	}
-	usedAgentDigest, err := gc.db.GetUsedImageDigests(ctx)
-	if err != nil {
-		log.Info("failed to get the used image digests")
-		return nil, err
+func (gc *CSIGarbageCollector) blobStore() *blobstore.Store {
+	return blobstore.NewStore(gc.fs, gc.db, gc.path)
+}
+
+func (gc *CSIGarbageCollector) removeUnreferencedBlobs(ctx context.Context) error {
+	reclaimed, err := gc.blobStore().RemoveUnreferenced(ctx)
+	reclaimedMemoryMetric.Add(float64(reclaimed))
+	return err
+}
+
+func (gc *CSIGarbageCollector) getSharedAgentBins() ([]metadata.AgentBin, error) {
+	return GetSharedAgentBins(gc.fs, gc.path)
+}
+
+// GetSharedAgentBins lists the shared binaries on the node, both the ones stored per arch and the ones installed before that
+func GetSharedAgentBins(fs afero.Fs, path metadata.PathResolver) ([]metadata.AgentBin, error) {
+	var agentBins []metadata.AgentBin
+	for _, agentArch := range append([]string{""}, arch.Arches...) {
+		binDirs, err := afero.Afero{Fs: fs}.ReadDir(path.AgentSharedBinaryDirBaseForArch(agentArch))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			log.Info("failed to read shared image directory", "arch", agentArch)
+			return nil, errors.WithStack(err)
+		}
+		for _, binDir := range binDirs {
+			// the arch dirs are next to the binaries installed before they were stored per arch
+			if agentArch == "" && slices.Contains(arch.Arches, binDir.Name()) {
+				continue
+			}
+			agentBins = append(agentBins, metadata.AgentBin{Name: binDir.Name(), Arch: agentArch})
+		}
 	}
+	return agentBins, nil
+}
+
+func (gc *CSIGarbageCollector) collectUnusedAgentBins(ctx context.Context, agentBins []metadata.AgentBin) ([]metadata.AgentBin, error) {
+	var toDelete []metadata.AgentBin
 
 	// If a shared image was used during mount, the version of a Volume is the imageDigest.
 	// A Volume can still reference versions that are not imageDigests.
 	// However, this shouldn't cause issues as those versions don't matter in this context.
-	mountedAgentBins, err := gc.db.GetAllUsedVersions(ctx)
+	usedAgentBins, err := gc.db.GetUsedAgentBins(ctx)
 	if err != nil {
-		log.Info("failed to get all mounted versions")
+		log.Info("failed to get the used agent binaries")
 		return nil, err
 	}
-	for _, imageDir := range imageDirs {
-		agentBin := imageDir.Name()
-		if !mountedAgentBins[agentBin] && !usedAgentVersions[agentBin] && !usedAgentDigest[agentBin] {
-			toDelete = append(toDelete, gc.path.AgentSharedBinaryDirForAgent(agentBin))
+	for _, agentBin := range agentBins {
+		if !usedAgentBins[agentBin] {
+			toDelete = append(toDelete, agentBin)
 		}
 	}
 	return toDelete, nil
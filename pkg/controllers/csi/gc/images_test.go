@@ -3,8 +3,11 @@ package csigc
 import (
 	"context"
 	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/Dynatrace/dynatrace-operator/pkg/arch"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/csi/blobstore"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/csi/metadata"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
@@ -97,6 +100,84 @@ func TestRunSharedImagesGarbageCollection(t *testing.T) {
 	})
 }
 
+func TestRunSharedImagesGarbageCollectionWithBlobs(t *testing.T) {
+	ctx := context.TODO()
+	path := metadata.PathResolver{RootDir: t.TempDir()}
+	fs := afero.NewOsFs()
+	db := metadata.FakeMemoryDB()
+	gc := CSIGarbageCollector{
+		fs:   fs,
+		db:   db,
+		path: path,
+	}
+	store := blobstore.NewStore(fs, db, path)
+
+	unusedDir := path.AgentSharedBinaryDirForAgentArch(testVersion1, arch.ArchX86)
+	usedDir := path.AgentSharedBinaryDirForAgentArch(testVersion2, arch.ArchX86)
+	for _, dir := range []string{unusedDir, usedDir} {
+		require.NoError(t, fs.MkdirAll(dir, 0755))
+		require.NoError(t, afero.WriteFile(fs, filepath.Join(dir, "shared"), []byte("shared"), 0644))
+	}
+	require.NoError(t, afero.WriteFile(fs, filepath.Join(unusedDir, "unused"), []byte("unused"), 0644))
+
+	require.NoError(t, store.Deduplicate(ctx, metadata.AgentBin{Name: testVersion1, Arch: arch.ArchX86}, unusedDir))
+	require.NoError(t, store.Deduplicate(ctx, metadata.AgentBin{Name: testVersion2, Arch: arch.ArchX86}, usedDir))
+	require.NoError(t, db.InsertVolume(ctx, metadata.NewVolume("volume", "pod", testVersion2, testTenantUUID, 0, arch.ArchX86)))
+
+	err := gc.runSharedBinaryGarbageCollection(ctx)
+	require.NoError(t, err)
+
+	_, err = fs.Stat(unusedDir)
+	assert.True(t, os.IsNotExist(err))
+
+	var blobs []string
+	require.NoError(t, afero.Walk(fs, path.BlobDir(), func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			blobs = append(blobs, path)
+		}
+		return err
+	}))
+	require.Len(t, blobs, 1)
+
+	content, err := afero.ReadFile(fs, filepath.Join(usedDir, "shared"))
+	require.NoError(t, err)
+	assert.Equal(t, "shared", string(content))
+
+	unreferenced, err := db.GetUnreferencedBlobs(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, unreferenced)
+}
+
+func TestRunSharedImagesGarbageCollectionPerArch(t *testing.T) {
+	ctx := context.TODO()
+	usedDir := testPathResolver.AgentSharedBinaryDirForAgentArch(testImageDigest, arch.ArchARM)
+	unusedDir := testPathResolver.AgentSharedBinaryDirForAgentArch(testImageDigest, arch.ArchX86)
+	legacyDir := testPathResolver.AgentSharedBinaryDirForAgent(testImageDigest)
+	fs := createTestDirs(t, usedDir, unusedDir, legacyDir)
+	gc := CSIGarbageCollector{
+		fs:   fs,
+		db:   metadata.FakeMemoryDB(),
+		path: testPathResolver,
+	}
+	gc.db.InsertDynakube(ctx, &metadata.Dynakube{
+		Name:          "test",
+		TenantUUID:    "test",
+		LatestVersion: "test",
+		ImageDigest:   testImageDigest,
+		Arch:          arch.ArchARM,
+	})
+
+	err := gc.runSharedBinaryGarbageCollection(ctx)
+	require.NoError(t, err)
+
+	_, err = fs.Stat(usedDir)
+	require.NoError(t, err)
+	_, err = fs.Stat(unusedDir)
+	assert.True(t, os.IsNotExist(err))
+	_, err = fs.Stat(legacyDir)
+	assert.True(t, os.IsNotExist(err))
+}
+
 func TestGetSharedImageDirs(t *testing.T) {
 	t.Run("no error on empty fs", func(t *testing.T) {
 		fs := afero.NewMemMapFs()
@@ -104,7 +185,7 @@ func TestGetSharedImageDirs(t *testing.T) {
 			fs:   fs,
 			path: testPathResolver,
 		}
-		dirs, err := gc.getSharedBinDirs()
+		dirs, err := gc.getSharedAgentBins()
 		require.NoError(t, err)
 		assert.Nil(t, dirs)
 	})
@@ -115,10 +196,27 @@ func TestGetSharedImageDirs(t *testing.T) {
 			fs:   fs,
 			path: testPathResolver,
 		}
-		dirs, err := gc.getSharedBinDirs()
+		dirs, err := gc.getSharedAgentBins()
 		require.NoError(t, err)
 		assert.Len(t, dirs, 1)
 	})
+	t.Run("get image cache dirs per arch", func(t *testing.T) {
+		fs := createTestDirs(t,
+			testPathResolver.AgentSharedBinaryDirForAgent(testImageDigest),
+			testPathResolver.AgentSharedBinaryDirForAgentArch(testImageDigest, arch.ArchARM),
+			testPathResolver.AgentSharedBinaryDirForAgentArch(testVersion1, arch.ArchX86))
+		gc := CSIGarbageCollector{
+			fs:   fs,
+			path: testPathResolver,
+		}
+		agentBins, err := gc.getSharedAgentBins()
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []metadata.AgentBin{
+			{Name: testImageDigest},
+			{Name: testImageDigest, Arch: arch.ArchARM},
+			{Name: testVersion1, Arch: arch.ArchX86},
+		}, agentBins)
+	})
 }
 
 func TestCollectUnusedAgentBins(t *testing.T) {
@@ -145,18 +243,10 @@ func TestCollectUnusedAgentBins(t *testing.T) {
 			db:   metadata.FakeMemoryDB(),
 			path: testPathResolver,
 		}
-		testImageDir := testPathResolver.AgentSharedBinaryDirForAgent(testImageDigest)
-		testZipDir := testPathResolver.AgentSharedBinaryDirForAgent(testVersion1)
-		fs := createTestDirs(t, testImageDir, testZipDir)
-		imageDirInfo, err := fs.Stat(testImageDir)
+		agentBins, err := gc.collectUnusedAgentBins(ctx, []metadata.AgentBin{{Name: testImageDigest}, {Name: testVersion1}})
 		require.NoError(t, err)
-		versionDirInfo, err := fs.Stat(testZipDir)
-		require.NoError(t, err)
-
-		dirs, err := gc.collectUnusedAgentBins(ctx, []os.FileInfo{imageDirInfo, versionDirInfo})
-		require.NoError(t, err)
-		assert.Len(t, dirs, 2)
-		assert.Equal(t, testImageDir, dirs[0])
+		assert.Len(t, agentBins, 2)
+		assert.Equal(t, metadata.AgentBin{Name: testImageDigest}, agentBins[0])
 	})
 	t.Run("gets nothing, image bin is set in dk, zip version is mounted in volume", func(t *testing.T) {
 		gc := CSIGarbageCollector{
@@ -175,15 +265,7 @@ func TestCollectUnusedAgentBins(t *testing.T) {
 			Version:    testVersion1,
 			PodName:    "test",
 		})
-		testImageDir := testPathResolver.AgentSharedBinaryDirForAgent(testImageDigest)
-		testZipDir := testPathResolver.AgentSharedBinaryDirForAgent(testVersion1)
-		fs := createTestDirs(t, testImageDir, testZipDir)
-		imageDirInfo, err := fs.Stat(testImageDir)
-		require.NoError(t, err)
-		versionDirInfo, err := fs.Stat(testZipDir)
-		require.NoError(t, err)
-
-		dirs, err := gc.collectUnusedAgentBins(ctx, []os.FileInfo{imageDirInfo, versionDirInfo})
+		dirs, err := gc.collectUnusedAgentBins(ctx, []metadata.AgentBin{{Name: testImageDigest}, {Name: testVersion1}})
 		require.NoError(t, err)
 		assert.Len(t, dirs, 0)
 	})
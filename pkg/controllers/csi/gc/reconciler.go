@@ -11,6 +11,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/spf13/afero"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
@@ -21,20 +22,27 @@ type CSIGarbageCollector struct {
 	fs        afero.Fs
 	db        metadata.Access
 	path      metadata.PathResolver
+	recorder  record.EventRecorder
+	nodeName  string
 
 	maxUnmountedVolumeAge time.Duration
+	// storageBudget is the maximum size of the data directory in bytes, 0 if there is no budget
+	storageBudget int64
 }
 
 var _ reconcile.Reconciler = (*CSIGarbageCollector)(nil)
 
 // NewCSIGarbageCollector returns a new CSIGarbageCollector
-func NewCSIGarbageCollector(apiReader client.Reader, opts dtcsi.CSIOptions, db metadata.Access) *CSIGarbageCollector {
+func NewCSIGarbageCollector(apiReader client.Reader, recorder record.EventRecorder, opts dtcsi.CSIOptions, db metadata.Access) *CSIGarbageCollector {
 	return &CSIGarbageCollector{
 		apiReader:             apiReader,
 		fs:                    afero.NewOsFs(),
 		db:                    db,
 		path:                  metadata.PathResolver{RootDir: opts.RootDir},
+		recorder:              recorder,
+		nodeName:              opts.NodeId,
 		maxUnmountedVolumeAge: determineMaxUnmountedVolumeAge(os.Getenv(maxUnmountedCsiVolumeAgeEnv)),
+		storageBudget:         determineStorageBudget(os.Getenv(storageBudgetEnv)),
 	}
 }
 
@@ -81,6 +89,12 @@ func (gc *CSIGarbageCollector) Reconcile(ctx context.Context, request reconcile.
 		return defaultReconcileResult, err
 	}
 
+	log.Info("running storage budget garbage collection")
+	if err := gc.runStorageBudgetGarbageCollection(ctx, dynakube); err != nil {
+		log.Info("failed to enforce the storage budget")
+		return defaultReconcileResult, err
+	}
+
 	return defaultReconcileResult, nil
 }
 
@@ -0,0 +1,581 @@
+package csigc
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+	dtcsi "github.com/Dynatrace/dynatrace-operator/pkg/controllers/csi"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/csi/blobstore"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/csi/metadata"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const (
+	storageBudgetEnv = "STORAGE_BUDGET"
+
+	evictionKindBinary     = "binary"
+	evictionKindImageCache = "image-cache"
+	evictionKindVolume     = "volume"
+	evictionKindOsAgent    = "osagent"
+)
+
+// evictionCandidate is a directory in the csi data directory that isn't mounted and can be recreated if needed
+type evictionCandidate struct {
+	lastUsed time.Time
+	// agentBin is set for shared binaries, which reference blobs
+	agentBin *metadata.AgentBin
+	// volumeID is set for the directories of app volumes
+	volumeID string
+	kind     string
+	path     string
+	// files are the files of the directory, they are collected once to know how much space evicting the directory frees
+	files []candidateFile
+}
+
+// candidateFile is a file of an eviction candidate, a file with several hardlinks is only freed once all of its links are removed
+type candidateFile struct {
+	inode  uint64
+	shared bool
+	size   int64
+}
+
+// hardlinks tracks the links of the shared files of the eviction candidates that remain after the evictions so far
+type hardlinks struct {
+	remaining map[uint64]uint64
+	// blobs are the inodes of the blobs, which are removed together with the last code module that links them
+	blobs map[uint64]bool
+}
+
+// remove returns the space that is freed by removing the files, files whose only remaining link is their blob are freed as well
+func (links *hardlinks) remove(files []candidateFile) int64 {
+	var freed int64
+
+	for _, file := range files {
+		if !file.shared {
+			freed += file.size
+			continue
+		}
+
+		links.remaining[file.inode]--
+		remaining := links.remaining[file.inode]
+
+		if remaining == 0 || (remaining == 1 && links.blobs[file.inode]) {
+			freed += file.size
+		}
+	}
+
+	return freed
+}
+
+// runStorageBudgetGarbageCollection evicts data that isn't mounted, least recently used first, until the data directory fits into the storage budget
+func (gc *CSIGarbageCollector) runStorageBudgetGarbageCollection(ctx context.Context, dynakube *dynatracev1beta1.DynaKube) error {
+	if gc.storageBudget <= 0 {
+		return nil
+	}
+
+	usage, err := gc.getStorageUsage()
+	if err != nil {
+		return err
+	}
+
+	storageUsageMetric.Set(float64(usage))
+	storageBudgetMetric.Set(float64(gc.storageBudget))
+
+	if usage <= gc.storageBudget {
+		log.Info("storage usage is within budget", "usage", usage, "budget", gc.storageBudget)
+		return nil
+	}
+
+	log.Info("storage usage exceeds budget, evicting unused data", "usage", usage, "budget", gc.storageBudget)
+
+	candidates, err := gc.getEvictionCandidates(ctx)
+	if err != nil {
+		return err
+	}
+
+	links, err := gc.collectCandidateFiles(candidates)
+	if err != nil {
+		return err
+	}
+
+	evicted := 0
+	reclaimed := int64(0)
+
+	for _, candidate := range candidates {
+		if usage <= gc.storageBudget {
+			break
+		}
+
+		log.Info("evicting unused data", "kind", candidate.kind, "path", candidate.path, "lastUsed", candidate.lastUsed)
+
+		if err := gc.evict(ctx, candidate); err != nil {
+			log.Info("failed to evict unused data", "path", candidate.path, "error", err)
+			continue
+		}
+
+		size := links.remove(candidate.files)
+		usage -= size
+		reclaimed += size
+		evicted++
+
+		evictionsMetric.WithLabelValues(candidate.kind).Inc()
+		evictedBytesMetric.WithLabelValues(candidate.kind).Add(float64(size))
+		foldersRemovedMetric.Inc()
+		reclaimedMemoryMetric.Add(float64(size))
+	}
+
+	storageUsageMetric.Set(float64(usage))
+
+	if evicted > 0 {
+		gc.recorder.Eventf(dynakube,
+			corev1.EventTypeNormal,
+			storageBudgetEvictionEvent,
+			"Evicted %d unused directories and reclaimed %s on node %s to stay within the storage budget of %s",
+			evicted, formatBytes(reclaimed), gc.nodeName, formatBytes(gc.storageBudget))
+	}
+
+	if usage > gc.storageBudget {
+		gc.recorder.Eventf(dynakube,
+			corev1.EventTypeWarning,
+			storageBudgetExceededEvent,
+			"Storage usage of %s on node %s exceeds the budget of %s, the remaining data is in use",
+			formatBytes(usage), gc.nodeName, formatBytes(gc.storageBudget))
+	}
+
+	return nil
+}
+
+// evict removes the directory of the candidate, the blobs that only shared binaries referenced are removed as well
+func (gc *CSIGarbageCollector) evict(ctx context.Context, candidate evictionCandidate) error {
+	if candidate.volumeID != "" {
+		return gc.evictVolume(ctx, candidate)
+	}
+
+	if candidate.agentBin == nil {
+		return errors.WithStack(gc.fs.RemoveAll(candidate.path))
+	}
+
+	store := gc.blobStore()
+	if err := store.Release(ctx, *candidate.agentBin); err != nil {
+		return err
+	}
+
+	if err := gc.fs.RemoveAll(candidate.path); err != nil {
+		return errors.WithStack(err)
+	}
+
+	_, err := store.RemoveUnreferenced(ctx)
+
+	return err
+}
+
+// evictVolume removes the directory of an app volume, unless the volume was published since the candidates were collected.
+// The app volume publisher records its intent before it creates the directory, so the transaction keeps it from publishing the volume meanwhile.
+func (gc *CSIGarbageCollector) evictVolume(ctx context.Context, candidate evictionCandidate) error {
+	return gc.db.WithTx(ctx, func(access metadata.Access) error {
+		volumeIDsInUse, err := getVolumeIDsInUse(ctx, access)
+		if err != nil {
+			return err
+		}
+
+		if volumeIDsInUse[candidate.volumeID] {
+			return errors.Errorf("volume %s is in use", candidate.volumeID)
+		}
+
+		return errors.WithStack(gc.fs.RemoveAll(candidate.path))
+	})
+}
+
+// collectCandidateFiles lists the files of every candidate and the hardlinks of the data directory, so the storage usage only has to be measured once
+func (gc *CSIGarbageCollector) collectCandidateFiles(candidates []evictionCandidate) (*hardlinks, error) {
+	links := &hardlinks{
+		remaining: map[uint64]uint64{},
+		blobs:     map[uint64]bool{},
+	}
+
+	err := afero.Walk(gc.fs, gc.path.BlobDir(), func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+
+			return err
+		}
+
+		if inode, ok := blobstore.SharedInode(info); ok && !info.IsDir() {
+			links.blobs[inode] = true
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	for i := range candidates {
+		err := afero.Walk(gc.fs, candidates[i].path, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) {
+					return nil
+				}
+
+				return err
+			}
+
+			if info.IsDir() && isOverlayMappedDir(path) {
+				return filepath.SkipDir
+			}
+
+			if info.IsDir() {
+				return nil
+			}
+
+			file := candidateFile{size: info.Size()}
+			if inode, ok := blobstore.SharedInode(info); ok {
+				file.inode = inode
+				file.shared = true
+
+				if _, ok := links.remaining[inode]; !ok {
+					links.remaining[inode] = blobstore.HardLinks(info)
+				}
+			}
+
+			candidates[i].files = append(candidates[i].files, file)
+
+			return nil
+		})
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+
+	return links, nil
+}
+
+// getStorageUsage returns the size of the data directory, the overlays mounted into it are skipped, as they would count the binaries twice,
+// and files with several hardlinks, like the deduplicated code modules and their blobs, are only counted once
+func (gc *CSIGarbageCollector) getStorageUsage() (int64, error) {
+	var size int64
+
+	countedInodes := map[uint64]bool{}
+
+	err := afero.Walk(gc.fs, gc.path.RootDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+
+			return err
+		}
+
+		if info.IsDir() && isOverlayMappedDir(path) {
+			return filepath.SkipDir
+		}
+
+		if info.IsDir() {
+			return nil
+		}
+
+		if inode, ok := blobstore.SharedInode(info); ok {
+			if countedInodes[inode] {
+				return nil
+			}
+
+			countedInodes[inode] = true
+		}
+
+		size += info.Size()
+
+		return nil
+	})
+
+	return size, errors.WithStack(err)
+}
+
+func isOverlayMappedDir(path string) bool {
+	return filepath.Base(path) == dtcsi.OverlayMappedDirPath && filepath.Base(filepath.Dir(filepath.Dir(path))) == dtcsi.AgentRunDir
+}
+
+// getEvictionCandidates lists the data that isn't mounted, sorted by the time it was used last
+func (gc *CSIGarbageCollector) getEvictionCandidates(ctx context.Context) ([]evictionCandidate, error) {
+	var candidates []evictionCandidate
+
+	collectors := []func(ctx context.Context) ([]evictionCandidate, error){
+		gc.getUnusedSharedBinaries,
+		gc.getUnusedTenantBinaries,
+		gc.getImageCaches,
+		gc.getUnmountedVolumeDirs,
+		gc.getUnmountedOsAgentDirs,
+	}
+
+	for _, collect := range collectors {
+		collected, err := collect(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		candidates = append(candidates, collected...)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].lastUsed.Before(candidates[j].lastUsed)
+	})
+
+	return candidates, nil
+}
+
+// getUnusedSharedBinaries lists the shared binaries that neither a volume nor a dynakube uses
+func (gc *CSIGarbageCollector) getUnusedSharedBinaries(ctx context.Context) ([]evictionCandidate, error) {
+	agentBins, err := gc.getSharedAgentBins()
+	if err != nil {
+		return nil, err
+	}
+
+	usedAgentBins, err := gc.db.GetUsedAgentBins(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []evictionCandidate
+
+	for _, agentBin := range agentBins {
+		if usedAgentBins[agentBin] {
+			continue
+		}
+
+		path := gc.path.AgentSharedBinaryDirForAgentArch(agentBin.Name, agentBin.Arch)
+
+		info, err := gc.fs.Stat(path)
+		if err != nil {
+			continue
+		}
+
+		evictedAgentBin := agentBin
+		candidates = append(candidates, evictionCandidate{
+			lastUsed: info.ModTime(),
+			agentBin: &evictedAgentBin,
+			kind:     evictionKindBinary,
+			path:     path,
+		})
+	}
+
+	return candidates, nil
+}
+
+// getUnusedTenantBinaries lists the binaries in the deprecated location that neither a volume nor a dynakube uses,
+// including the ones of tenants that have no dynakube anymore
+func (gc *CSIGarbageCollector) getUnusedTenantBinaries(ctx context.Context) ([]evictionCandidate, error) {
+	tenantUUIDs, err := gc.getTenantUUIDs()
+	if err != nil {
+		return nil, err
+	}
+
+	latestVersions, err := gc.db.GetLatestVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	fs := &afero.Afero{Fs: gc.fs}
+
+	var candidates []evictionCandidate
+
+	for _, tenantUUID := range tenantUUIDs {
+		usedVersions, err := gc.db.GetUsedVersions(ctx, tenantUUID)
+		if err != nil {
+			return nil, err
+		}
+
+		storedVersions, err := GetStoredVersions(fs, gc.path, tenantUUID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, version := range storedVersions {
+			if usedVersions[version] || latestVersions[version] {
+				continue
+			}
+
+			candidates = gc.appendCandidate(candidates, evictionKindBinary, gc.path.AgentBinaryDirForVersion(tenantUUID, version))
+		}
+	}
+
+	return candidates, nil
+}
+
+// getImageCaches lists the leftovers of interrupted installations, the provisioner only uses them while it installs the code modules
+func (gc *CSIGarbageCollector) getImageCaches(_ context.Context) ([]evictionCandidate, error) {
+	candidates := gc.appendCandidate(nil, evictionKindImageCache, gc.path.AgentTempUnzipRootDir())
+
+	imageCaches, err := afero.ReadDir(gc.fs, gc.path.ImageCacheDir())
+	if os.IsNotExist(err) {
+		return candidates, nil
+	} else if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	for _, imageCache := range imageCaches {
+		candidates = gc.appendCandidate(candidates, evictionKindImageCache, filepath.Join(gc.path.ImageCacheDir(), imageCache.Name()))
+	}
+
+	return candidates, nil
+}
+
+// getUnmountedVolumeDirs lists the directories of the app volumes that aren't mounted anymore, regardless of their age.
+// Volumes that are stored in the database or have a pending intent are skipped, as they are being published or unpublished.
+func (gc *CSIGarbageCollector) getUnmountedVolumeDirs(ctx context.Context) ([]evictionCandidate, error) {
+	tenantUUIDs, err := gc.getTenantUUIDs()
+	if err != nil {
+		return nil, err
+	}
+
+	volumeIDsInUse, err := getVolumeIDsInUse(ctx, gc.db)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []evictionCandidate
+
+	for _, tenantUUID := range tenantUUIDs {
+		unmountedVolumes, err := gc.getUnmountedVolumes(tenantUUID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, volume := range unmountedVolumes {
+			if volumeIDsInUse[volume.Name()] {
+				continue
+			}
+
+			candidates = append(candidates, evictionCandidate{
+				lastUsed: volume.ModTime(),
+				volumeID: volume.Name(),
+				kind:     evictionKindVolume,
+				path:     gc.path.AgentRunDirForVolume(tenantUUID, volume.Name()),
+			})
+		}
+	}
+
+	return candidates, nil
+}
+
+// getVolumeIDsInUse returns the ids of the app volumes that are published or have a pending intent
+func getVolumeIDsInUse(ctx context.Context, access metadata.Access) (map[string]bool, error) {
+	volumes, err := access.GetAllVolumes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	intents, err := access.GetAllVolumeIntents(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	volumeIDs := make(map[string]bool, len(volumes)+len(intents))
+	for _, volume := range volumes {
+		volumeIDs[volume.VolumeID] = true
+	}
+
+	for _, intent := range intents {
+		volumeIDs[intent.VolumeID] = true
+	}
+
+	return volumeIDs, nil
+}
+
+// getUnmountedOsAgentDirs lists the data of the osagent volumes that aren't mounted, the host volume publisher recreates the directory when it is mounted again
+func (gc *CSIGarbageCollector) getUnmountedOsAgentDirs(ctx context.Context) ([]evictionCandidate, error) {
+	osAgentVolumes, err := gc.db.GetAllOsAgentVolumes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []evictionCandidate
+
+	for _, volume := range osAgentVolumes {
+		if volume.Mounted || volume.LastModified == nil {
+			continue
+		}
+
+		path := gc.path.OsAgentDir(volume.TenantUUID)
+		if exists, _ := afero.DirExists(gc.fs, path); !exists {
+			continue
+		}
+
+		candidates = append(candidates, evictionCandidate{
+			lastUsed: *volume.LastModified,
+			kind:     evictionKindOsAgent,
+			path:     path,
+		})
+	}
+
+	return candidates, nil
+}
+
+// getTenantUUIDs lists the tenant directories in the data directory
+func (gc *CSIGarbageCollector) getTenantUUIDs() ([]string, error) {
+	entries, err := afero.ReadDir(gc.fs, gc.path.RootDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	notTenants := map[string]bool{
+		filepath.Base(gc.path.AgentSharedBinaryDirBase()): true,
+		filepath.Base(gc.path.AgentTempUnzipRootDir()):    true,
+		filepath.Base(gc.path.ImageCacheDir()):            true,
+		filepath.Base(gc.path.BlobDir()):                  true,
+	}
+
+	var tenantUUIDs []string
+
+	for _, entry := range entries {
+		if entry.IsDir() && !notTenants[entry.Name()] {
+			tenantUUIDs = append(tenantUUIDs, entry.Name())
+		}
+	}
+
+	return tenantUUIDs, nil
+}
+
+// appendCandidate adds the directory if it exists, the time the directory was modified last is the time it was used last,
+// the app volume publisher updates it for the binaries it mounts
+func (gc *CSIGarbageCollector) appendCandidate(candidates []evictionCandidate, kind, path string) []evictionCandidate {
+	info, err := gc.fs.Stat(path)
+	if err != nil {
+		return candidates
+	}
+
+	return append(candidates, evictionCandidate{
+		lastUsed: info.ModTime(),
+		kind:     kind,
+		path:     path,
+	})
+}
+
+func determineStorageBudget(storageBudgetEnvValue string) int64 {
+	if storageBudgetEnvValue == "" {
+		return 0
+	}
+
+	budget, err := resource.ParseQuantity(storageBudgetEnvValue)
+	if err != nil {
+		log.Error(err, "failed to parse the storage budget, no budget is enforced", "env", storageBudgetEnv, "value", storageBudgetEnvValue)
+		return 0
+	}
+
+	log.Info("storage budget used", "bytes", budget.Value())
+
+	return budget.Value()
+}
+
+func formatBytes(size int64) string {
+	return resource.NewQuantity(size, resource.BinarySI).String() + "B"
+}
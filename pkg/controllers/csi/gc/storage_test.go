@@ -0,0 +1,257 @@
+package csigc
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/arch"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/csi/metadata"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+const (
+	testFileSize = 100
+)
+
+var (
+	testDynakube = &dynatracev1beta1.DynaKube{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "dynakube",
+			Namespace: "dynatrace",
+		},
+	}
+)
+
+func TestDetermineStorageBudget(t *testing.T) {
+	t.Run("no env set ==> no budget", func(t *testing.T) {
+		assert.Equal(t, int64(0), determineStorageBudget(""))
+	})
+	t.Run("use quantity from env", func(t *testing.T) {
+		assert.Equal(t, int64(10*1024*1024*1024), determineStorageBudget("10Gi"))
+		assert.Equal(t, int64(500000000), determineStorageBudget("500M"))
+	})
+	t.Run("invalid quantity in env ==> no budget", func(t *testing.T) {
+		assert.Equal(t, int64(0), determineStorageBudget("a lot"))
+	})
+}
+
+func TestGetStorageUsage(t *testing.T) {
+	t.Run("no error if data directory is missing", func(t *testing.T) {
+		gc := NewMockGarbageCollector()
+
+		usage, err := gc.getStorageUsage()
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), usage)
+	})
+	t.Run("mapped directories of the volumes are skipped", func(t *testing.T) {
+		gc := NewMockGarbageCollector()
+		gc.mockFile(t, filepath.Join(gc.path.AgentSharedBinaryDirForAgent(testImageDigest), "agent.so"), time.Now())
+		gc.mockFile(t, filepath.Join(gc.path.OverlayMappedDir(testTenantUUID, testVersion1), "agent.so"), time.Now())
+		gc.mockFile(t, filepath.Join(gc.path.AgentRunDirForVolume(testTenantUUID, testVersion1), "var", "log"), time.Now())
+
+		usage, err := gc.getStorageUsage()
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(2*testFileSize), usage)
+	})
+}
+
+func TestRunStorageBudgetGarbageCollection(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	t.Run("nothing is evicted without budget", func(t *testing.T) {
+		gc := NewMockGarbageCollector()
+		recorder := gc.mockRecorder()
+		oldBinary := gc.mockFile(t, filepath.Join(gc.path.AgentSharedBinaryDirForAgent(testVersion1), "agent.so"), now)
+
+		err := gc.runStorageBudgetGarbageCollection(ctx, testDynakube)
+
+		require.NoError(t, err)
+		assertDirExists(t, gc.fs, oldBinary)
+		assert.Empty(t, recorder.Events)
+	})
+	t.Run("nothing is evicted within budget", func(t *testing.T) {
+		gc := NewMockGarbageCollector()
+		gc.storageBudget = testFileSize
+		recorder := gc.mockRecorder()
+		binary := gc.mockFile(t, filepath.Join(gc.path.AgentSharedBinaryDirForAgent(testVersion1), "agent.so"), now)
+
+		err := gc.runStorageBudgetGarbageCollection(ctx, testDynakube)
+
+		require.NoError(t, err)
+		assertDirExists(t, gc.fs, binary)
+		assert.Empty(t, recorder.Events)
+		assert.Equal(t, float64(testFileSize), testutil.ToFloat64(storageUsageMetric))
+		assert.Equal(t, float64(testFileSize), testutil.ToFloat64(storageBudgetMetric))
+	})
+	t.Run("least recently used data is evicted first", func(t *testing.T) {
+		gc := NewMockGarbageCollector()
+		gc.storageBudget = testFileSize
+		recorder := gc.mockRecorder()
+		oldestBinary := gc.mockFile(t, filepath.Join(gc.path.AgentSharedBinaryDirForAgentArch(testVersion1, arch.ArchARM), "agent.so"), now.Add(-3*time.Hour))
+		imageCache := gc.mockFile(t, filepath.Join(gc.path.ImageCacheDir(), testImageDigest, "layer"), now.Add(-2*time.Hour))
+		unmountedVolume := gc.mockUnmountedVolume(t, testVersion1, now.Add(-time.Hour))
+		newestBinary := gc.mockFile(t, filepath.Join(gc.path.AgentSharedBinaryDirForAgent(testVersion2), "agent.so"), now)
+		evictionsBefore := testutil.ToFloat64(evictionsMetric.WithLabelValues(evictionKindBinary))
+
+		err := gc.runStorageBudgetGarbageCollection(ctx, testDynakube)
+
+		require.NoError(t, err)
+		assertDirNotExists(t, gc.fs, oldestBinary, imageCache, unmountedVolume)
+		assertDirExists(t, gc.fs, newestBinary)
+		assert.Equal(t, float64(testFileSize), testutil.ToFloat64(storageUsageMetric))
+		assert.Equal(t, evictionsBefore+1, testutil.ToFloat64(evictionsMetric.WithLabelValues(evictionKindBinary)))
+		require.Len(t, recorder.Events, 1)
+		assert.Contains(t, <-recorder.Events, storageBudgetEvictionEvent)
+	})
+	t.Run("data in use is not evicted", func(t *testing.T) {
+		gc := NewMockGarbageCollector()
+		gc.storageBudget = 1
+		recorder := gc.mockRecorder()
+		usedBinary := gc.mockFile(t, filepath.Join(gc.path.AgentSharedBinaryDirForAgent(testVersion1), "agent.so"), now.Add(-time.Hour))
+		latestBinary := gc.mockFile(t, filepath.Join(gc.path.AgentBinaryDirForVersion(testTenantUUID, testVersion2), "agent.so"), now.Add(-time.Hour))
+		mountedVolume := gc.mockFile(t, filepath.Join(gc.path.OverlayMappedDir(testTenantUUID, testVersion1), "agent.so"), now.Add(-time.Hour))
+		mountedOsAgent := gc.mockFile(t, filepath.Join(gc.path.OsAgentDir(testTenantUUID), "data"), now.Add(-time.Hour))
+
+		require.NoError(t, gc.db.InsertVolume(ctx, metadata.NewVolume(testVersion1, "pod", testVersion1, testTenantUUID, 0, "")))
+		require.NoError(t, gc.db.InsertDynakube(ctx, metadata.NewDynakube("dynakube", testTenantUUID, testVersion2, "", 0, "")))
+		require.NoError(t, gc.db.InsertOsAgentVolume(ctx, metadata.NewOsAgentVolume("osagent", testTenantUUID, true, &now)))
+
+		err := gc.runStorageBudgetGarbageCollection(ctx, testDynakube)
+
+		require.NoError(t, err)
+		assertDirExists(t, gc.fs, usedBinary, latestBinary, mountedVolume, mountedOsAgent)
+		require.Len(t, recorder.Events, 1)
+		assert.Contains(t, <-recorder.Events, storageBudgetExceededEvent)
+	})
+	t.Run("volumes that are being published are not evicted", func(t *testing.T) {
+		gc := NewMockGarbageCollector()
+		gc.storageBudget = 1
+		gc.mockRecorder()
+		publishingVolume := gc.mockUnmountedVolume(t, testVersion1, now.Add(-time.Hour))
+		unpublishedVolume := gc.mockUnmountedVolume(t, testVersion2, now.Add(-time.Hour))
+
+		require.NoError(t, gc.db.InsertVolumeIntent(ctx, metadata.NewVolumeIntent(metadata.VolumeOperationPublish, testVersion1, testTenantUUID, "/target")))
+
+		err := gc.runStorageBudgetGarbageCollection(ctx, testDynakube)
+
+		require.NoError(t, err)
+		assertDirExists(t, gc.fs, publishingVolume)
+		assertDirNotExists(t, gc.fs, unpublishedVolume)
+	})
+	t.Run("volume published after the candidates were collected is not evicted", func(t *testing.T) {
+		gc := NewMockGarbageCollector()
+		volume := gc.mockUnmountedVolume(t, testVersion1, now.Add(-time.Hour))
+
+		candidates, err := gc.getEvictionCandidates(ctx)
+		require.NoError(t, err)
+		require.Len(t, candidates, 1)
+
+		require.NoError(t, gc.db.InsertVolumeIntent(ctx, metadata.NewVolumeIntent(metadata.VolumeOperationPublish, testVersion1, testTenantUUID, "/target")))
+
+		require.Error(t, gc.evict(ctx, candidates[0]))
+		assertDirExists(t, gc.fs, volume)
+	})
+	t.Run("unmounted osagent data is evicted", func(t *testing.T) {
+		gc := NewMockGarbageCollector()
+		gc.storageBudget = 1
+		recorder := gc.mockRecorder()
+		unmountedOsAgent := gc.mockFile(t, filepath.Join(gc.path.OsAgentDir(testTenantUUID), "data"), now)
+		lastMounted := now.Add(-time.Hour)
+
+		require.NoError(t, gc.db.InsertOsAgentVolume(ctx, metadata.NewOsAgentVolume("osagent", testTenantUUID, false, &lastMounted)))
+
+		err := gc.runStorageBudgetGarbageCollection(ctx, testDynakube)
+
+		require.NoError(t, err)
+		assertDirNotExists(t, gc.fs, unmountedOsAgent)
+		require.Len(t, recorder.Events, 1)
+		assert.Contains(t, <-recorder.Events, storageBudgetEvictionEvent)
+	})
+	t.Run("bad database", func(t *testing.T) {
+		gc := NewMockGarbageCollector()
+		gc.db = &metadata.FakeFailDB{}
+		gc.storageBudget = 1
+		gc.mockRecorder()
+		gc.mockFile(t, filepath.Join(gc.path.AgentSharedBinaryDirForAgent(testVersion1), "agent.so"), now)
+
+		err := gc.runStorageBudgetGarbageCollection(ctx, testDynakube)
+
+		require.Error(t, err)
+	})
+}
+
+func TestHardlinksRemove(t *testing.T) {
+	t.Run("files without hardlinks are freed at once", func(t *testing.T) {
+		links := &hardlinks{remaining: map[uint64]uint64{}, blobs: map[uint64]bool{}}
+
+		assert.Equal(t, int64(2*testFileSize), links.remove([]candidateFile{{size: testFileSize}, {size: testFileSize}}))
+	})
+	t.Run("hardlinked files are freed with their last link", func(t *testing.T) {
+		links := &hardlinks{remaining: map[uint64]uint64{1: 2}, blobs: map[uint64]bool{}}
+		file := candidateFile{inode: 1, shared: true, size: testFileSize}
+
+		assert.Zero(t, links.remove([]candidateFile{file}))
+		assert.Equal(t, int64(testFileSize), links.remove([]candidateFile{file}))
+	})
+	t.Run("deduplicated files are freed once only their blob links them", func(t *testing.T) {
+		links := &hardlinks{remaining: map[uint64]uint64{1: 3}, blobs: map[uint64]bool{1: true}}
+		file := candidateFile{inode: 1, shared: true, size: testFileSize}
+
+		assert.Zero(t, links.remove([]candidateFile{file}))
+		assert.Equal(t, int64(testFileSize), links.remove([]candidateFile{file}))
+	})
+}
+
+func (gc *CSIGarbageCollector) mockRecorder() *record.FakeRecorder {
+	recorder := record.NewFakeRecorder(10)
+	gc.recorder = recorder
+
+	return recorder
+}
+
+// mockFile creates a file of testFileSize bytes and returns its directory, which was last used at lastUsed
+func (gc *CSIGarbageCollector) mockFile(t *testing.T, path string, lastUsed time.Time) string {
+	dir := filepath.Dir(path)
+	require.NoError(t, gc.fs.MkdirAll(dir, 0770))
+	require.NoError(t, afero.WriteFile(gc.fs, path, make([]byte, testFileSize), 0770))
+	require.NoError(t, gc.fs.Chtimes(dir, lastUsed, lastUsed))
+
+	return dir
+}
+
+// mockUnmountedVolume creates the directory of a volume with an empty mapped directory and a log file of testFileSize bytes
+func (gc *CSIGarbageCollector) mockUnmountedVolume(t *testing.T, volumeID string, lastUsed time.Time) string {
+	dir := gc.path.AgentRunDirForVolume(testTenantUUID, volumeID)
+	gc.mockFile(t, filepath.Join(dir, "var", "log"), lastUsed)
+	require.NoError(t, gc.fs.MkdirAll(gc.path.OverlayMappedDir(testTenantUUID, volumeID), 0770))
+	require.NoError(t, gc.fs.Chtimes(dir, lastUsed, lastUsed))
+
+	return dir
+}
+
+func assertDirExists(t *testing.T, fs afero.Fs, dirs ...string) {
+	for _, dir := range dirs {
+		exists, err := afero.DirExists(fs, dir)
+		require.NoError(t, err)
+		assert.True(t, exists, dir)
+	}
+}
+
+func assertDirNotExists(t *testing.T, fs afero.Fs, dirs ...string) {
+	for _, dir := range dirs {
+		exists, err := afero.DirExists(fs, dir)
+		require.NoError(t, err)
+		assert.False(t, exists, dir)
+	}
+}
@@ -0,0 +1,62 @@
+package inventory
+
+import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/logger"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	DefaultPort = "10081"
+	Endpoint    = "/inventory"
+
+	// DefaultBindAddress keeps the endpoint off the pod network, it is only reachable via port-forwarding.
+	DefaultBindAddress = "127.0.0.1:" + DefaultPort
+)
+
+var (
+	log = logger.Factory.GetLogger("csi-inventory")
+
+	codeModuleSizeMetric = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "dynatrace",
+		Subsystem: "csi_driver",
+		Name:      "inventory_code_module_bytes",
+		Help:      "Disk usage of a code module version stored by the CSI driver in bytes",
+	}, []string{"version", "arch", "tenant_uuid"})
+
+	codeModuleVolumesMetric = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "dynatrace",
+		Subsystem: "csi_driver",
+		Name:      "inventory_code_module_volumes",
+		Help:      "Number of volumes that mount a code module version",
+	}, []string{"version", "arch", "tenant_uuid"})
+
+	tenantVolumesMetric = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "dynatrace",
+		Subsystem: "csi_driver",
+		Name:      "inventory_mounted_volumes",
+		Help:      "Number of volumes of a tenant mounted by the CSI driver",
+	}, []string{"tenant_uuid"})
+
+	tenantFailedMountAttemptsMetric = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "dynatrace",
+		Subsystem: "csi_driver",
+		Name:      "inventory_failed_mount_attempts",
+		Help:      "Number of failed mount attempts of the volumes of a tenant that aren't mounted",
+	}, []string{"tenant_uuid"})
+
+	diskUsageMetric = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "dynatrace",
+		Subsystem: "csi_driver",
+		Name:      "inventory_disk_usage_bytes",
+		Help:      "Disk usage of all code modules stored by the CSI driver in bytes",
+	})
+)
+
+func init() {
+	metrics.Registry.MustRegister(codeModuleSizeMetric)
+	metrics.Registry.MustRegister(codeModuleVolumesMetric)
+	metrics.Registry.MustRegister(tenantVolumesMetric)
+	metrics.Registry.MustRegister(tenantFailedMountAttemptsMetric)
+	metrics.Registry.MustRegister(diskUsageMetric)
+}
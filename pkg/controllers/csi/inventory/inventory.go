@@ -0,0 +1,244 @@
+package inventory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	csigc "github.com/Dynatrace/dynatrace-operator/pkg/controllers/csi/gc"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/csi/metadata"
+	"github.com/spf13/afero"
+)
+
+// Inventory describes what the CSI driver holds on a node
+type Inventory struct {
+	CollectedAt    time.Time            `json:"collectedAt"`
+	NodeName       string               `json:"nodeName"`
+	Dynakubes      []*metadata.Dynakube `json:"dynakubes"`
+	Tenants        []Tenant             `json:"tenants"`
+	CodeModules    []CodeModule         `json:"codeModules"`
+	DiskUsageBytes int64                `json:"diskUsageBytes"`
+}
+
+// Tenant summarizes the volumes of a tenant
+type Tenant struct {
+	TenantUUID     string   `json:"tenantUUID"`
+	UsedVersions   []string `json:"usedVersions"`
+	MountedVolumes int      `json:"mountedVolumes"`
+	// FailingVolumes are the volumes that couldn't be mounted (yet), they have FailedMountAttempts in total
+	FailingVolumes      int `json:"failingVolumes"`
+	FailedMountAttempts int `json:"failedMountAttempts"`
+}
+
+// CodeModule is a version or image digest of the code modules stored on the node
+type CodeModule struct {
+	// Name is the version or image digest
+	Name        string `json:"name"`
+	Arch        string `json:"arch,omitempty"`
+	ImageDigest bool   `json:"imageDigest"`
+	// TenantUUID is only set for the code modules in the deprecated location, which isn't shared between tenants
+	TenantUUID     string `json:"tenantUUID,omitempty"`
+	Path           string `json:"path"`
+	SizeBytes      int64  `json:"sizeBytes"`
+	MountedVolumes int    `json:"mountedVolumes"`
+}
+
+// Collector collects the Inventory of the node from the metadata database and the filesystem
+type Collector struct {
+	db       metadata.Access
+	fs       afero.Afero
+	path     metadata.PathResolver
+	nodeName string
+}
+
+func NewCollector(db metadata.Access, fs afero.Fs, path metadata.PathResolver, nodeName string) *Collector {
+	return &Collector{
+		db:       db,
+		fs:       afero.Afero{Fs: fs},
+		path:     path,
+		nodeName: nodeName,
+	}
+}
+
+func (collector *Collector) Collect(ctx context.Context) (*Inventory, error) {
+	dynakubes, err := collector.db.GetAllDynakubes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	volumes, err := collector.db.GetAllVolumes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tenants, err := collector.collectTenants(ctx, dynakubes, volumes)
+	if err != nil {
+		return nil, err
+	}
+
+	codeModules, err := collector.collectCodeModules(ctx, tenants, volumes)
+	if err != nil {
+		return nil, err
+	}
+
+	codeModulePaths := make([]string, 0, len(codeModules))
+	for _, codeModule := range codeModules {
+		codeModulePaths = append(codeModulePaths, codeModule.Path)
+	}
+
+	// the code modules share their deduplicated files, so their sizes can't be summed up
+	diskUsage, err := csigc.DiskUsage(&collector.fs, codeModulePaths...)
+	if err != nil {
+		log.Info("failed to determine the disk usage of the code modules", "error", err.Error())
+	}
+
+	return &Inventory{
+		CollectedAt:    time.Now(),
+		NodeName:       collector.nodeName,
+		Dynakubes:      dynakubes,
+		Tenants:        tenants,
+		CodeModules:    codeModules,
+		DiskUsageBytes: diskUsage,
+	}, nil
+}
+
+func (collector *Collector) collectTenants(ctx context.Context, dynakubes []*metadata.Dynakube, volumes []*metadata.Volume) ([]Tenant, error) {
+	tenants := map[string]*Tenant{}
+	getTenant := func(tenantUUID string) *Tenant {
+		if tenants[tenantUUID] == nil {
+			tenants[tenantUUID] = &Tenant{TenantUUID: tenantUUID, UsedVersions: []string{}}
+		}
+
+		return tenants[tenantUUID]
+	}
+
+	for _, dynakube := range dynakubes {
+		getTenant(dynakube.TenantUUID)
+	}
+
+	for _, volume := range volumes {
+		tenant := getTenant(volume.TenantUUID)
+
+		// the mount attempts are reset once the volume is mounted
+		if volume.MountAttempts > 0 {
+			tenant.FailingVolumes++
+			tenant.FailedMountAttempts += volume.MountAttempts
+		} else if volume.Version != "" {
+			tenant.MountedVolumes++
+		}
+	}
+
+	result := make([]Tenant, 0, len(tenants))
+
+	for tenantUUID, tenant := range tenants {
+		usedVersions, err := collector.db.GetUsedVersions(ctx, tenantUUID)
+		if err != nil {
+			return nil, err
+		}
+
+		for version := range usedVersions {
+			if version != "" {
+				tenant.UsedVersions = append(tenant.UsedVersions, version)
+			}
+		}
+
+		sort.Strings(tenant.UsedVersions)
+		result = append(result, *tenant)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].TenantUUID < result[j].TenantUUID
+	})
+
+	return result, nil
+}
+
+func (collector *Collector) collectCodeModules(ctx context.Context, tenants []Tenant, volumes []*metadata.Volume) ([]CodeModule, error) {
+	imageDigests, err := collector.db.GetUsedImageDigests(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	agentBins, err := csigc.GetSharedAgentBins(collector.fs.Fs, collector.path)
+	if err != nil {
+		return nil, err
+	}
+
+	mountedVolumes := map[metadata.AgentBin]int{}
+
+	for _, volume := range volumes {
+		if volume.MountAttempts == 0 && volume.Version != "" {
+			mountedVolumes[metadata.AgentBin{Name: volume.Version, Arch: volume.Arch}]++
+		}
+	}
+
+	codeModules := make([]CodeModule, 0, len(agentBins))
+
+	for _, agentBin := range agentBins {
+		codeModules = append(codeModules, collector.newCodeModule(
+			agentBin.Name,
+			agentBin.Arch,
+			"",
+			collector.path.AgentSharedBinaryDirForAgentArch(agentBin.Name, agentBin.Arch),
+			imageDigests[agentBin.Name],
+			mountedVolumes[agentBin],
+		))
+	}
+
+	for _, tenant := range tenants {
+		versions, err := csigc.GetStoredVersions(&collector.fs, collector.path, tenant.TenantUUID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, version := range versions {
+			codeModules = append(codeModules, collector.newCodeModule(
+				version,
+				"",
+				tenant.TenantUUID,
+				collector.path.AgentBinaryDirForVersion(tenant.TenantUUID, version),
+				false,
+				0,
+			))
+		}
+	}
+
+	return codeModules, nil
+}
+
+func (collector *Collector) newCodeModule(name, arch, tenantUUID, path string, imageDigest bool, mountedVolumes int) CodeModule { //nolint:revive // argument-limit doesn't apply to constructors
+	size, err := csigc.DirSize(&collector.fs, path)
+	if err != nil {
+		log.Info("failed to determine the size of the code modules", "path", path, "error", err.Error())
+	}
+
+	return CodeModule{
+		Name:           name,
+		Arch:           arch,
+		ImageDigest:    imageDigest,
+		TenantUUID:     tenantUUID,
+		Path:           path,
+		SizeBytes:      size,
+		MountedVolumes: mountedVolumes,
+	}
+}
+
+// updateMetrics replaces the values of the inventory metrics, so code modules and tenants that are gone aren't reported anymore
+func updateMetrics(inventory *Inventory) {
+	codeModuleSizeMetric.Reset()
+	codeModuleVolumesMetric.Reset()
+	tenantVolumesMetric.Reset()
+	tenantFailedMountAttemptsMetric.Reset()
+
+	for _, codeModule := range inventory.CodeModules {
+		codeModuleSizeMetric.WithLabelValues(codeModule.Name, codeModule.Arch, codeModule.TenantUUID).Set(float64(codeModule.SizeBytes))
+		codeModuleVolumesMetric.WithLabelValues(codeModule.Name, codeModule.Arch, codeModule.TenantUUID).Set(float64(codeModule.MountedVolumes))
+	}
+
+	for _, tenant := range inventory.Tenants {
+		tenantVolumesMetric.WithLabelValues(tenant.TenantUUID).Set(float64(tenant.MountedVolumes))
+		tenantFailedMountAttemptsMetric.WithLabelValues(tenant.TenantUUID).Set(float64(tenant.FailedMountAttempts))
+	}
+
+	diskUsageMetric.Set(float64(inventory.DiskUsageBytes))
+}
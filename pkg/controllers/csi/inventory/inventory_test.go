@@ -0,0 +1,118 @@
+package inventory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/arch"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/csi/metadata"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testNodeName    = "node"
+	testTenantUUID  = "tenant"
+	testVersion     = "1.2.3"
+	testOldVersion  = "1.0.0"
+	testImageDigest = "sha256:123"
+)
+
+var testPath = metadata.PathResolver{RootDir: "/data"}
+
+func createTestCollector(t *testing.T) *Collector {
+	ctx := context.TODO()
+	db := metadata.FakeMemoryDB()
+	fs := afero.NewMemMapFs()
+
+	require.NoError(t, db.InsertDynakube(ctx, metadata.NewDynakube("dk", testTenantUUID, testVersion, testImageDigest, 5, arch.ArchARM)))
+	require.NoError(t, db.InsertVolume(ctx, metadata.NewVolume("vol-1", "pod-1", testImageDigest, testTenantUUID, 0, arch.ArchARM)))
+	require.NoError(t, db.InsertVolume(ctx, metadata.NewVolume("vol-2", "pod-2", testImageDigest, testTenantUUID, 0, arch.ArchARM)))
+	require.NoError(t, db.InsertVolume(ctx, metadata.NewVolume("vol-3", "pod-3", testVersion, testTenantUUID, 3, "")))
+
+	writeFile(t, fs, testPath.AgentSharedBinaryDirForAgentArch(testImageDigest, arch.ArchARM), 100)
+	writeFile(t, fs, testPath.AgentSharedBinaryDirForAgent(testVersion), 20)
+	writeFile(t, fs, testPath.AgentBinaryDirForVersion(testTenantUUID, testOldVersion), 3)
+
+	return NewCollector(db, fs, testPath, testNodeName)
+}
+
+func writeFile(t *testing.T, fs afero.Fs, dir string, size int) {
+	require.NoError(t, afero.WriteFile(fs, dir+"/agent/file", make([]byte, size), 0644))
+}
+
+func TestCollect(t *testing.T) {
+	t.Run("collects volumes, versions and disk usage", func(t *testing.T) {
+		inventory, err := createTestCollector(t).Collect(context.TODO())
+		require.NoError(t, err)
+
+		assert.Equal(t, testNodeName, inventory.NodeName)
+		require.Len(t, inventory.Dynakubes, 1)
+		assert.Equal(t, "dk", inventory.Dynakubes[0].Name)
+
+		require.Len(t, inventory.Tenants, 1)
+		assert.Equal(t, Tenant{
+			TenantUUID:          testTenantUUID,
+			UsedVersions:        []string{testVersion, testImageDigest},
+			MountedVolumes:      2,
+			FailingVolumes:      1,
+			FailedMountAttempts: 3,
+		}, inventory.Tenants[0])
+
+		assert.ElementsMatch(t, []CodeModule{
+			{
+				Name:           testVersion,
+				Path:           testPath.AgentSharedBinaryDirForAgent(testVersion),
+				SizeBytes:      20,
+				MountedVolumes: 0,
+			},
+			{
+				Name:           testImageDigest,
+				Arch:           arch.ArchARM,
+				ImageDigest:    true,
+				Path:           testPath.AgentSharedBinaryDirForAgentArch(testImageDigest, arch.ArchARM),
+				SizeBytes:      100,
+				MountedVolumes: 2,
+			},
+			{
+				Name:       testOldVersion,
+				TenantUUID: testTenantUUID,
+				Path:       testPath.AgentBinaryDirForVersion(testTenantUUID, testOldVersion),
+				SizeBytes:  3,
+			},
+		}, inventory.CodeModules)
+		assert.Equal(t, int64(123), inventory.DiskUsageBytes)
+	})
+	t.Run("empty node", func(t *testing.T) {
+		inventory, err := NewCollector(metadata.FakeMemoryDB(), afero.NewMemMapFs(), testPath, testNodeName).Collect(context.TODO())
+		require.NoError(t, err)
+
+		assert.Empty(t, inventory.Tenants)
+		assert.Empty(t, inventory.CodeModules)
+		assert.Zero(t, inventory.DiskUsageBytes)
+	})
+	t.Run("database error", func(t *testing.T) {
+		_, err := NewCollector(&metadata.FakeFailDB{}, afero.NewMemMapFs(), testPath, testNodeName).Collect(context.TODO())
+		require.Error(t, err)
+	})
+}
+
+func TestUpdateMetrics(t *testing.T) {
+	inventory, err := createTestCollector(t).Collect(context.TODO())
+	require.NoError(t, err)
+
+	updateMetrics(inventory)
+
+	assert.Equal(t, float64(123), testutil.ToFloat64(diskUsageMetric))
+	assert.Equal(t, float64(100), testutil.ToFloat64(codeModuleSizeMetric.WithLabelValues(testImageDigest, arch.ArchARM, "")))
+	assert.Equal(t, float64(2), testutil.ToFloat64(codeModuleVolumesMetric.WithLabelValues(testImageDigest, arch.ArchARM, "")))
+	assert.Equal(t, float64(2), testutil.ToFloat64(tenantVolumesMetric.WithLabelValues(testTenantUUID)))
+	assert.Equal(t, float64(3), testutil.ToFloat64(tenantFailedMountAttemptsMetric.WithLabelValues(testTenantUUID)))
+
+	updateMetrics(&Inventory{})
+
+	assert.Equal(t, 0, testutil.CollectAndCount(codeModuleSizeMetric))
+	assert.Equal(t, 0, testutil.CollectAndCount(tenantVolumesMetric))
+}
@@ -0,0 +1,108 @@
+package inventory
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+const (
+	metricsRefreshInterval = 5 * time.Minute
+	readHeaderTimeout      = 10 * time.Second
+)
+
+// Server serves the Inventory of the node read-only via http and refreshes it, together with the inventory metrics, periodically.
+// Requests are answered from the last refresh, so they never hit the database or walk the filesystem.
+type Server struct {
+	collector *Collector
+	inventory atomic.Pointer[Inventory]
+	address   string
+}
+
+func NewServer(address string, collector *Collector) *Server {
+	return &Server{
+		collector: collector,
+		address:   address,
+	}
+}
+
+func (srv *Server) SetupWithManager(mgr ctrl.Manager) error {
+	return mgr.Add(srv)
+}
+
+func (srv *Server) Start(ctx context.Context) error {
+	mux := http.NewServeMux()
+	mux.Handle(Endpoint, srv)
+
+	server := &http.Server{
+		Addr:              srv.address,
+		Handler:           mux,
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+
+	go srv.refreshMetrics(ctx)
+	go func() {
+		<-ctx.Done()
+		log.Info("stopping inventory server")
+		_ = server.Shutdown(context.WithoutCancel(ctx))
+	}()
+
+	log.Info("serving inventory", "address", srv.address, "endpoint", Endpoint)
+
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (srv *Server) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodGet {
+		http.Error(writer, "only GET is supported", http.StatusMethodNotAllowed)
+		return
+	}
+
+	inventory := srv.inventory.Load()
+	if inventory == nil {
+		http.Error(writer, "inventory is not yet collected", http.StatusServiceUnavailable)
+		return
+	}
+
+	writer.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(writer).Encode(inventory); err != nil {
+		log.Info("failed to write inventory", "error", err.Error())
+	}
+}
+
+func (srv *Server) refreshMetrics(ctx context.Context) {
+	ticker := time.NewTicker(metricsRefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		srv.refresh(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// refresh keeps the last inventory if collecting fails, so a temporary error doesn't make the endpoint unavailable.
+func (srv *Server) refresh(ctx context.Context) {
+	inventory, err := srv.collector.Collect(ctx)
+	if err != nil {
+		log.Info("failed to collect inventory", "error", err.Error())
+		return
+	}
+
+	updateMetrics(inventory)
+	srv.inventory.Store(inventory)
+}
@@ -0,0 +1,64 @@
+package inventory
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/csi/metadata"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServeHTTP(t *testing.T) {
+	t.Run("serves the inventory as json", func(t *testing.T) {
+		server := NewServer(":0", createTestCollector(t))
+		server.refresh(context.Background())
+		recorder := httptest.NewRecorder()
+
+		server.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, Endpoint, nil))
+
+		require.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+
+		var inventory Inventory
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &inventory))
+		assert.Equal(t, testNodeName, inventory.NodeName)
+		assert.Len(t, inventory.CodeModules, 3)
+	})
+	t.Run("is read-only", func(t *testing.T) {
+		server := NewServer(":0", createTestCollector(t))
+		recorder := httptest.NewRecorder()
+
+		server.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, Endpoint, nil))
+
+		assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+	})
+	t.Run("serves the last inventory", func(t *testing.T) {
+		server := NewServer(":0", createTestCollector(t))
+		server.refresh(context.Background())
+		server.collector = NewCollector(&metadata.FakeFailDB{}, afero.NewMemMapFs(), testPath, testNodeName)
+		server.refresh(context.Background())
+		recorder := httptest.NewRecorder()
+
+		server.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, Endpoint, nil))
+
+		require.Equal(t, http.StatusOK, recorder.Code)
+
+		var inventory Inventory
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &inventory))
+		assert.Len(t, inventory.CodeModules, 3)
+	})
+	t.Run("not yet collected", func(t *testing.T) {
+		server := NewServer(":0", NewCollector(&metadata.FakeFailDB{}, afero.NewMemMapFs(), testPath, testNodeName))
+		server.refresh(context.Background())
+		recorder := httptest.NewRecorder()
+
+		server.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, Endpoint, nil))
+
+		assert.Equal(t, http.StatusServiceUnavailable, recorder.Code)
+	})
+}
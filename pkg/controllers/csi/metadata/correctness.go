@@ -110,7 +110,7 @@ func (checker *CorrectnessChecker) copyCodeModulesFromDeprecatedBin(ctx context.
 			continue
 		}
 		deprecatedBin := checker.path.AgentBinaryDirForVersion(dynakube.TenantUUID, dynakube.LatestVersion)
-		currentBin := checker.path.AgentSharedBinaryDirForAgent(dynakube.LatestVersion)
+		currentBin := checker.path.AgentSharedBinaryDirForAgentArch(dynakube.LatestVersion, dynakube.Arch)
 
 		linked, err := checker.safelyLinkCodeModule(deprecatedBin, currentBin)
 		if err != nil {
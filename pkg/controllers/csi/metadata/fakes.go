@@ -99,6 +99,34 @@ func (f *FakeFailDB) GetUsedImageDigests(ctx context.Context) (map[string]bool,
 	return nil, sql.ErrTxDone
 }
 
+func (f *FakeFailDB) GetUsedAgentBins(ctx context.Context) (map[AgentBin]bool, error) {
+	return nil, sql.ErrTxDone
+}
+
 func (f *FakeFailDB) IsImageDigestUsed(ctx context.Context, imageDigest string) (bool, error) {
 	return false, sql.ErrTxDone
 }
+func (f *FakeFailDB) WithTx(ctx context.Context, fn func(access Access) error) error {
+	return sql.ErrTxDone
+}
+func (f *FakeFailDB) InsertVolumeIntent(ctx context.Context, intent *VolumeIntent) error {
+	return sql.ErrTxDone
+}
+func (f *FakeFailDB) DeleteVolumeIntent(ctx context.Context, volumeID string) error {
+	return sql.ErrTxDone
+}
+func (f *FakeFailDB) GetAllVolumeIntents(ctx context.Context) ([]*VolumeIntent, error) {
+	return nil, sql.ErrTxDone
+}
+func (f *FakeFailDB) InsertCodeModuleBlobs(ctx context.Context, agentBin AgentBin, blobs []*Blob) error {
+	return sql.ErrTxDone
+}
+func (f *FakeFailDB) DeleteCodeModuleBlobs(ctx context.Context, agentBin AgentBin) error {
+	return sql.ErrTxDone
+}
+func (f *FakeFailDB) GetUnreferencedBlobs(ctx context.Context) ([]*Blob, error) {
+	return nil, sql.ErrTxDone
+}
+func (f *FakeFailDB) DeleteBlob(ctx context.Context, id string) error {
+	return sql.ErrTxDone
+}
@@ -12,10 +12,12 @@ type Dynakube struct {
 	LatestVersion          string `json:"latestVersion"`
 	ImageDigest            string `json:"imageDigest"`
 	MaxFailedMountAttempts int    `json:"maxFailedMountAttempts"`
+	// Arch of the installed LatestVersion or ImageDigest, empty for binaries installed before they were stored per arch
+	Arch string `json:"arch"`
 }
 
-// NewDynakube returns a new metadata.Dynakube if all fields are set.
-func NewDynakube(dynakubeName, tenantUUID, latestVersion, imageDigest string, maxFailedMountAttempts int) *Dynakube { //nolint:revive // argument-limit doesn't apply to constructors
+// NewDynakube returns a new metadata.Dynakube if all fields (except arch) are set.
+func NewDynakube(dynakubeName, tenantUUID, latestVersion, imageDigest string, maxFailedMountAttempts int, arch string) *Dynakube { //nolint:revive // argument-limit doesn't apply to constructors
 	if tenantUUID == "" || dynakubeName == "" {
 		return nil
 	}
@@ -26,6 +28,7 @@ func NewDynakube(dynakubeName, tenantUUID, latestVersion, imageDigest string, ma
 		LatestVersion:          latestVersion,
 		ImageDigest:            imageDigest,
 		MaxFailedMountAttempts: maxFailedMountAttempts,
+		Arch:                   arch,
 	}
 }
 
@@ -35,10 +38,12 @@ type Volume struct {
 	Version       string `json:"version"`
 	TenantUUID    string `json:"tenantUUID"`
 	MountAttempts int    `json:"mountAttempts"`
+	// Arch of the mounted binaries, empty for binaries installed before they were stored per arch
+	Arch string `json:"arch"`
 }
 
-// NewVolume returns a new Volume if all fields (except version) are set.
-func NewVolume(id, podName, version, tenantUUID string, mountAttempts int) *Volume { //nolint:revive // argument-limit doesn't apply to constructors
+// NewVolume returns a new Volume if all fields (except version and arch) are set.
+func NewVolume(id, podName, version, tenantUUID string, mountAttempts int, arch string) *Volume { //nolint:revive // argument-limit doesn't apply to constructors
 	if id == "" || podName == "" || tenantUUID == "" {
 		return nil
 	}
@@ -53,9 +58,18 @@ func NewVolume(id, podName, version, tenantUUID string, mountAttempts int) *Volu
 		Version:       version,
 		TenantUUID:    tenantUUID,
 		MountAttempts: mountAttempts,
+		Arch:          arch,
 	}
 }
 
+// AgentBin identifies the shared binaries of a version or image digest for an arch
+type AgentBin struct {
+	// Name is the version or image digest
+	Name string
+	// Arch is empty for binaries installed before they were stored per arch
+	Arch string
+}
+
 type OsAgentVolume struct {
 	VolumeID     string     `json:"volumeID"`
 	TenantUUID   string     `json:"tenantUUID"`
@@ -71,9 +85,57 @@ func NewOsAgentVolume(volumeID, tenantUUID string, mounted bool, timeStamp *time
 	return &OsAgentVolume{volumeID, tenantUUID, mounted, timeStamp}
 }
 
+// VolumeOperation is the operation a VolumeIntent was recorded for
+type VolumeOperation string
+
+const (
+	VolumeOperationPublish   VolumeOperation = "publish"
+	VolumeOperationUnpublish VolumeOperation = "unpublish"
+)
+
+// VolumeIntent is recorded before a volume is mounted or unmounted and removed once the volumes table reflects the result.
+// An intent that is left over after a crash tells which mounts have to be checked when the csi server starts again.
+type VolumeIntent struct {
+	CreatedAt  *time.Time      `json:"createdAt"`
+	VolumeID   string          `json:"volumeID"`
+	TenantUUID string          `json:"tenantUUID"`
+	TargetPath string          `json:"targetPath"`
+	Operation  VolumeOperation `json:"operation"`
+}
+
+// NewVolumeIntent returns a new VolumeIntent if all fields are set.
+func NewVolumeIntent(operation VolumeOperation, volumeID, tenantUUID, targetPath string) *VolumeIntent {
+	if operation == "" || volumeID == "" || tenantUUID == "" || targetPath == "" {
+		return nil
+	}
+
+	now := time.Now()
+
+	return &VolumeIntent{
+		CreatedAt:  &now,
+		VolumeID:   volumeID,
+		TenantUUID: tenantUUID,
+		TargetPath: targetPath,
+		Operation:  operation,
+	}
+}
+
+// Blob is a file of the code modules that is stored only once, the files of the code modules with the same content are hardlinks to it.
+// References is the number of code modules that contain the blob, it can be removed once no code module references it.
+type Blob struct {
+	// ID is the sha256 hash of the content and the file mode, as files that only differ in their mode can't share an inode
+	ID         string `json:"id"`
+	Size       int64  `json:"size"`
+	References int    `json:"references"`
+}
+
 type Access interface {
 	Setup(ctx context.Context, path string) error
 
+	// WithTx runs fn in a transaction, the changes made via the given Access are committed if fn returns nil and rolled back otherwise.
+	// The Access WithTx is called on must not be used within fn.
+	WithTx(ctx context.Context, fn func(access Access) error) error
+
 	InsertDynakube(ctx context.Context, dynakube *Dynakube) error
 	UpdateDynakube(ctx context.Context, dynakube *Dynakube) error
 	DeleteDynakube(ctx context.Context, dynakubeName string) error
@@ -96,7 +158,17 @@ type Access interface {
 	GetAllUsedVersions(ctx context.Context) (map[string]bool, error)
 	GetLatestVersions(ctx context.Context) (map[string]bool, error)
 	GetUsedImageDigests(ctx context.Context) (map[string]bool, error)
+	GetUsedAgentBins(ctx context.Context) (map[AgentBin]bool, error)
 	IsImageDigestUsed(ctx context.Context, imageDigest string) (bool, error)
+
+	InsertVolumeIntent(ctx context.Context, intent *VolumeIntent) error
+	DeleteVolumeIntent(ctx context.Context, volumeID string) error
+	GetAllVolumeIntents(ctx context.Context) ([]*VolumeIntent, error)
+
+	InsertCodeModuleBlobs(ctx context.Context, agentBin AgentBin, blobs []*Blob) error
+	DeleteCodeModuleBlobs(ctx context.Context, agentBin AgentBin) error
+	GetUnreferencedBlobs(ctx context.Context) ([]*Blob, error)
+	DeleteBlob(ctx context.Context, id string) error
 }
 
 type AccessOverview struct {
@@ -18,7 +18,7 @@ const (
 
 func TestNewDynakube(t *testing.T) {
 	t.Run("initializes correctly", func(t *testing.T) {
-		dynakube := NewDynakube(testName, testUUID, testVersion, testDigest, testMaxFailedMountAttempts)
+		dynakube := NewDynakube(testName, testUUID, testVersion, testDigest, testMaxFailedMountAttempts, "")
 
 		assert.Equal(t, testName, dynakube.Name)
 		assert.Equal(t, testUUID, dynakube.TenantUUID)
@@ -27,11 +27,11 @@ func TestNewDynakube(t *testing.T) {
 		assert.Equal(t, testMaxFailedMountAttempts, dynakube.MaxFailedMountAttempts)
 	})
 	t.Run("returns nil if name or uuid is empty", func(t *testing.T) {
-		dynakube := NewDynakube("", testUUID, testVersion, testDigest, testMaxFailedMountAttempts)
+		dynakube := NewDynakube("", testUUID, testVersion, testDigest, testMaxFailedMountAttempts, "")
 
 		assert.Nil(t, dynakube)
 
-		dynakube = NewDynakube(testName, "", testVersion, testDigest, testMaxFailedMountAttempts)
+		dynakube = NewDynakube(testName, "", testVersion, testDigest, testMaxFailedMountAttempts, "")
 
 		assert.Nil(t, dynakube)
 	})
@@ -39,7 +39,7 @@ func TestNewDynakube(t *testing.T) {
 
 func TestNewVolume(t *testing.T) {
 	t.Run("initializes correctly", func(t *testing.T) {
-		volume := NewVolume(testID, testName, testVersion, testUUID, testMountAttempts)
+		volume := NewVolume(testID, testName, testVersion, testUUID, testMountAttempts, "")
 
 		assert.Equal(t, testID, volume.VolumeID)
 		assert.Equal(t, testName, volume.PodName)
@@ -48,30 +48,30 @@ func TestNewVolume(t *testing.T) {
 		assert.Equal(t, testMountAttempts, volume.MountAttempts)
 	})
 	t.Run("returns nil if id, name, or uuid is unset", func(t *testing.T) {
-		volume := NewVolume("", testName, testVersion, testUUID, testMountAttempts)
+		volume := NewVolume("", testName, testVersion, testUUID, testMountAttempts, "")
 
 		assert.Nil(t, volume)
 
-		volume = NewVolume(testID, "", testVersion, testUUID, testMountAttempts)
+		volume = NewVolume(testID, "", testVersion, testUUID, testMountAttempts, "")
 
 		assert.Nil(t, volume)
 
-		volume = NewVolume(testID, testName, testVersion, "", testMountAttempts)
+		volume = NewVolume(testID, testName, testVersion, "", testMountAttempts, "")
 
 		assert.Nil(t, volume)
 
-		volume = NewVolume(testID, testName, testVersion, testUUID, 0)
+		volume = NewVolume(testID, testName, testVersion, testUUID, 0, "")
 
 		assert.NotNil(t, volume)
 		assert.Equal(t, 0, volume.MountAttempts)
 	})
 	t.Run("sets default value for mount attempts if less than 0", func(t *testing.T) {
-		volume := NewVolume(testID, testName, testVersion, testUUID, -1)
+		volume := NewVolume(testID, testName, testVersion, testUUID, -1, "")
 
 		assert.NotNil(t, volume)
 		assert.Equal(t, 0, volume.MountAttempts)
 
-		volume = NewVolume(testID, testName, testVersion, testUUID, -2)
+		volume = NewVolume(testID, testName, testVersion, testUUID, -2, "")
 
 		assert.NotNil(t, volume)
 		assert.Equal(t, 0, volume.MountAttempts)
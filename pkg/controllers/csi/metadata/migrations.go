@@ -0,0 +1,460 @@
+package metadata
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+	"github.com/pkg/errors"
+)
+
+const (
+	dynakubesCreateStatement = `
+	CREATE TABLE IF NOT EXISTS dynakubes (
+		Name VARCHAR NOT NULL,
+		TenantUUID VARCHAR NOT NULL,
+		LatestVersion VARCHAR NOT NULL,
+		PRIMARY KEY (Name)
+	); `
+
+	volumesCreateStatement = `
+	CREATE TABLE IF NOT EXISTS volumes (
+		ID VARCHAR NOT NULL,
+		PodName VARCHAR NOT NULL,
+		Version VARCHAR NOT NULL,
+		TenantUUID VARCHAR NOT NULL,
+		PRIMARY KEY (ID)
+	);`
+
+	osAgentVolumesCreateStatement = `
+	CREATE TABLE IF NOT EXISTS osagent_volumes (
+		TenantUUID VARCHAR NOT NULL,
+		VolumeID VARCHAR NOT NULL,
+		Mounted BOOLEAN NOT NULL,
+		LastModified DATETIME NOT NULL,
+		PRIMARY KEY (TenantUUID)
+	);`
+
+	volumeIntentsCreateStatement = `
+	CREATE TABLE IF NOT EXISTS volume_intents (
+		VolumeID VARCHAR NOT NULL,
+		Operation VARCHAR NOT NULL,
+		TenantUUID VARCHAR NOT NULL,
+		TargetPath VARCHAR NOT NULL,
+		CreatedAt DATETIME NOT NULL,
+		PRIMARY KEY (VolumeID)
+	);`
+
+	blobsCreateStatement = `
+	CREATE TABLE IF NOT EXISTS blobs (
+		ID VARCHAR NOT NULL,
+		Size INT NOT NULL,
+		RefCount INT NOT NULL DEFAULT 0,
+		PRIMARY KEY (ID)
+	);`
+
+	codeModuleBlobsCreateStatement = `
+	CREATE TABLE IF NOT EXISTS code_module_blobs (
+		Name VARCHAR NOT NULL,
+		Arch VARCHAR NOT NULL,
+		BlobID VARCHAR NOT NULL,
+		PRIMARY KEY (Name, Arch, BlobID)
+	);`
+
+	schemaVersionTableName       = "schema_version"
+	schemaVersionCreateStatement = `
+	CREATE TABLE IF NOT EXISTS schema_version (
+		Version INT NOT NULL,
+		Description VARCHAR NOT NULL,
+		AppliedAt DATETIME NOT NULL,
+		PRIMARY KEY (Version)
+	);`
+
+	getSchemaVersionStatement = `
+	SELECT COALESCE(MAX(Version), 0)
+	FROM schema_version;
+	`
+
+	insertSchemaVersionStatement = `
+	INSERT INTO schema_version (Version, Description, AppliedAt)
+	VALUES (?,?,?);
+	`
+
+	deleteSchemaVersionStatement = "DELETE FROM schema_version WHERE Version = ?;"
+
+	countTablesStatement = `
+	SELECT COUNT(*)
+	FROM sqlite_master
+	WHERE type = 'table' AND name = ?;
+	`
+
+	countAllTablesStatement = `
+	SELECT COUNT(*)
+	FROM sqlite_master
+	WHERE type = 'table';
+	`
+
+	countColumnsStatement = `
+	SELECT COUNT(*)
+	FROM pragma_table_info(?)
+	WHERE name = ?;
+	`
+
+	integrityCheckStatement = "PRAGMA integrity_check;"
+
+	backupStatement = "VACUUM INTO ?;"
+)
+
+// Migration changes the schema of the database from the previous version to Version, its down statements revert it
+type Migration struct {
+	Description string
+	Version     int
+
+	up   []string
+	down []string
+
+	// table and column are set by migrations that only add a column.
+	// Such a migration is recorded without running it if the column exists already,
+	// which is the case for databases set up before the schema was versioned.
+	table  string
+	column string
+}
+
+// migrations must be ordered by their version, which starts at 1 and has no gaps.
+// Released migrations must never be changed, a change of the schema always needs a new migration.
+var migrations = []Migration{
+	{
+		Version:     1,
+		Description: "create the dynakubes, volumes and osagent_volumes tables",
+		up:          []string{dynakubesCreateStatement, volumesCreateStatement, osAgentVolumesCreateStatement},
+		down:        []string{"DROP TABLE IF EXISTS osagent_volumes;", "DROP TABLE IF EXISTS volumes;", "DROP TABLE IF EXISTS dynakubes;"},
+	},
+	addColumn(2, dynakubesTableName, "ImageDigest", "VARCHAR NOT NULL DEFAULT ''"),
+	addColumn(3, volumesTableName, "MountAttempts", "INT NOT NULL DEFAULT 0"),
+	// "Not null"-columns need a default value set
+	addColumn(4, dynakubesTableName, "MaxFailedMountAttempts", "INT NOT NULL DEFAULT "+strconv.Itoa(dynatracev1beta1.DefaultMaxFailedCsiMountAttempts)),
+	addColumn(5, dynakubesTableName, "Arch", "VARCHAR NOT NULL DEFAULT ''"),
+	addColumn(6, volumesTableName, "Arch", "VARCHAR NOT NULL DEFAULT ''"),
+	{
+		Version:     7,
+		Description: "create the volume_intents table",
+		up:          []string{volumeIntentsCreateStatement},
+		down:        []string{"DROP TABLE IF EXISTS volume_intents;"},
+	},
+	{
+		Version:     8,
+		Description: "create the blobs and code_module_blobs tables",
+		up:          []string{blobsCreateStatement, codeModuleBlobsCreateStatement},
+		down:        []string{"DROP TABLE IF EXISTS code_module_blobs;", "DROP TABLE IF EXISTS blobs;"},
+	},
+}
+
+// LatestSchemaVersion is the schema version the operator works with
+var LatestSchemaVersion = migrations[len(migrations)-1].Version
+
+func addColumn(version int, table, column, definition string) Migration {
+	return Migration{
+		Version:     version,
+		Description: fmt.Sprintf("add column %s to %s", column, table),
+		up:          []string{fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s;", table, column, definition)},
+		down:        []string{fmt.Sprintf("ALTER TABLE %s DROP COLUMN %s;", table, column)},
+		table:       table,
+		column:      column,
+	}
+}
+
+// MigrationPlan lists the migrations between two schema versions in the order they are run.
+// If To is lower than From, the down statements of the migrations are run.
+type MigrationPlan struct {
+	From       int
+	To         int
+	Migrations []Migration
+}
+
+func (plan MigrationPlan) IsDowngrade() bool {
+	return plan.To < plan.From
+}
+
+// errDryRun rolls back the transaction of a dry run
+var errDryRun = errors.New("dry run")
+
+// Migrator migrates the schema of the database to a given version.
+// All migrations of a run are applied in a single transaction, so the schema is either fully migrated or not changed at all.
+type Migrator struct {
+	conn   *sql.DB
+	path   string
+	dryRun bool
+}
+
+func newMigrator(conn *sql.DB, path string) *Migrator {
+	return &Migrator{
+		conn: conn,
+		path: path,
+	}
+}
+
+// OpenMigrator connects to the database without changing its schema
+func OpenMigrator(path string) (*Migrator, error) {
+	access := SqliteAccess{}
+	if err := access.connect(sqliteDriverName, path); err != nil {
+		return nil, err
+	}
+
+	return newMigrator(access.conn, path), nil
+}
+
+// SetDryRun makes Migrate roll back the migrations after they were applied successfully, no backup is created
+func (migrator *Migrator) SetDryRun(dryRun bool) *Migrator {
+	migrator.dryRun = dryRun
+	return migrator
+}
+
+func (migrator *Migrator) Close() error {
+	return errors.WithStack(migrator.conn.Close())
+}
+
+// Version returns the current schema version of the database, 0 if it wasn't migrated yet
+func (migrator *Migrator) Version(ctx context.Context) (int, error) {
+	return schemaVersion(ctx, migrator.conn)
+}
+
+// Migrate brings the schema of the database to the target version.
+// A database that was already migrated by a newer operator is left unchanged, as the down migrations are only known to the newer operator.
+func (migrator *Migrator) Migrate(ctx context.Context, target int) (MigrationPlan, error) {
+	if target < 0 || target > LatestSchemaVersion {
+		return MigrationPlan{}, errors.Errorf("unknown schema version %d, the latest schema version is %d", target, LatestSchemaVersion)
+	}
+
+	plan, err := planMigrations(ctx, migrator.conn, target)
+	if err != nil || len(plan.Migrations) == 0 {
+		return plan, err
+	}
+
+	if !migrator.dryRun {
+		if err := migrator.backup(ctx, plan.From); err != nil {
+			return plan, err
+		}
+	}
+
+	err = runImmediateTransaction(ctx, migrator.conn, func(conn *sql.Conn) error {
+		// another pod could have migrated the database in the meantime
+		plan, err = planMigrations(ctx, conn, target)
+		if err != nil {
+			return err
+		}
+
+		if err := applyMigrations(ctx, conn, plan); err != nil {
+			return err
+		}
+
+		if err := checkIntegrity(ctx, conn); err != nil {
+			return err
+		}
+
+		if migrator.dryRun {
+			return errDryRun
+		}
+
+		return nil
+	})
+
+	switch {
+	case errors.Is(err, errDryRun):
+		log.Info("dry run of the database migration succeeded, rolled it back", "from", plan.From, "to", plan.To)
+	case err != nil:
+		return plan, errors.WithMessage(err, "failed to migrate the database schema")
+	default:
+		log.Info("migrated the database schema", "from", plan.From, "to", plan.To)
+	}
+
+	return plan, nil
+}
+
+// CheckIntegrity fails if the database file is corrupted
+func (migrator *Migrator) CheckIntegrity(ctx context.Context) error {
+	return checkIntegrity(ctx, migrator.conn)
+}
+
+// backup copies the database next to the original file before it's migrated, an older backup of the same version is replaced
+func (migrator *Migrator) backup(ctx context.Context, version int) error {
+	if isInMemory(migrator.path) {
+		return nil
+	}
+
+	var tableCount int
+	if err := migrator.conn.QueryRowContext(ctx, countAllTablesStatement).Scan(&tableCount); err != nil {
+		return errors.WithStack(err)
+	}
+
+	if tableCount == 0 {
+		return nil
+	}
+
+	backupPath := BackupPath(migrator.path, version)
+	if err := os.Remove(backupPath); err != nil && !os.IsNotExist(err) {
+		return errors.WithMessagef(err, "failed to remove the old backup %s", backupPath)
+	}
+
+	if _, err := migrator.conn.ExecContext(ctx, backupStatement, backupPath); err != nil {
+		return errors.WithMessagef(err, "failed to create the backup %s", backupPath)
+	}
+
+	log.Info("created a backup of the database before the migration", "path", backupPath)
+
+	return nil
+}
+
+// BackupPath returns the path of the backup that is created before a database at the given schema version is migrated
+func BackupPath(path string, version int) string {
+	return fmt.Sprintf("%s.v%d.bak", path, version)
+}
+
+func isInMemory(path string) bool {
+	return path == ":memory:" || strings.HasPrefix(path, "file::memory:") || strings.Contains(path, "mode=memory")
+}
+
+func planMigrations(ctx context.Context, q querier, target int) (MigrationPlan, error) {
+	from, err := schemaVersion(ctx, q)
+	if err != nil {
+		return MigrationPlan{}, err
+	}
+
+	plan := MigrationPlan{From: from, To: target}
+
+	if from > LatestSchemaVersion {
+		log.Info("the database schema is newer than the latest schema known to this operator, it's left unchanged",
+			"version", from, "latestVersion", LatestSchemaVersion)
+
+		plan.To = from
+
+		return plan, nil
+	}
+
+	if plan.IsDowngrade() {
+		for i := len(migrations) - 1; i >= 0; i-- {
+			if migrations[i].Version <= from && migrations[i].Version > target {
+				plan.Migrations = append(plan.Migrations, migrations[i])
+			}
+		}
+	} else {
+		for _, migration := range migrations {
+			if migration.Version > from && migration.Version <= target {
+				plan.Migrations = append(plan.Migrations, migration)
+			}
+		}
+	}
+
+	return plan, nil
+}
+
+func applyMigrations(ctx context.Context, q querier, plan MigrationPlan) error {
+	if _, err := q.ExecContext(ctx, schemaVersionCreateStatement); err != nil {
+		return errors.WithMessagef(err, "couldn't create the table %s", schemaVersionTableName)
+	}
+
+	for _, migration := range plan.Migrations {
+		var err error
+		if plan.IsDowngrade() {
+			err = revertMigration(ctx, q, migration)
+		} else {
+			err = applyMigration(ctx, q, migration)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func applyMigration(ctx context.Context, q querier, migration Migration) error {
+	exists, err := columnExists(ctx, q, migration.table, migration.column)
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		log.Info("column of the migration exists already, only recording it", "version", migration.Version, "column", migration.column)
+	} else if err := executeMigrationStatements(ctx, q, migration.up); err != nil {
+		return errors.WithMessagef(err, "failed to apply migration %d (%s)", migration.Version, migration.Description)
+	}
+
+	_, err = q.ExecContext(ctx, insertSchemaVersionStatement, migration.Version, migration.Description, time.Now())
+
+	return errors.WithStack(err)
+}
+
+func revertMigration(ctx context.Context, q querier, migration Migration) error {
+	if err := executeMigrationStatements(ctx, q, migration.down); err != nil {
+		return errors.WithMessagef(err, "failed to revert migration %d (%s)", migration.Version, migration.Description)
+	}
+
+	_, err := q.ExecContext(ctx, deleteSchemaVersionStatement, migration.Version)
+
+	return errors.WithStack(err)
+}
+
+func executeMigrationStatements(ctx context.Context, q querier, statements []string) error {
+	for _, statement := range statements {
+		if _, err := q.ExecContext(ctx, statement); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	return nil
+}
+
+func schemaVersion(ctx context.Context, q querier) (int, error) {
+	exists, err := tableExists(ctx, q, schemaVersionTableName)
+	if err != nil || !exists {
+		return 0, err
+	}
+
+	var version int
+	if err := q.QueryRowContext(ctx, getSchemaVersionStatement).Scan(&version); err != nil {
+		return 0, errors.WithMessage(err, "failed to read the schema version")
+	}
+
+	return version, nil
+}
+
+func tableExists(ctx context.Context, q querier, table string) (bool, error) {
+	var count int
+	if err := q.QueryRowContext(ctx, countTablesStatement, table).Scan(&count); err != nil {
+		return false, errors.WithStack(err)
+	}
+
+	return count > 0, nil
+}
+
+func columnExists(ctx context.Context, q querier, table, column string) (bool, error) {
+	if table == "" || column == "" {
+		return false, nil
+	}
+
+	var count int
+	if err := q.QueryRowContext(ctx, countColumnsStatement, table, column).Scan(&count); err != nil {
+		return false, errors.WithStack(err)
+	}
+
+	return count > 0, nil
+}
+
+func checkIntegrity(ctx context.Context, q querier) error {
+	var result string
+	if err := q.QueryRowContext(ctx, integrityCheckStatement).Scan(&result); err != nil {
+		return errors.WithMessage(err, "failed to check the integrity of the database")
+	}
+
+	if result != "ok" {
+		return errors.Errorf("the integrity check of the database failed: %s", result)
+	}
+
+	return nil
+}
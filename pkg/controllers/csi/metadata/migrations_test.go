@@ -0,0 +1,288 @@
+package metadata
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// legacyMemoryDB creates a database like the operator did before the schema was versioned,
+// the tables exist with the columns of the given migrations, but there is no schema_version table
+func legacyMemoryDB(t *testing.T, version int) *SqliteAccess {
+	db := emptyMemoryDB()
+
+	for _, migration := range migrations[:version] {
+		require.NoError(t, executeMigrationStatements(context.Background(), db.conn, migration.up))
+	}
+
+	return db
+}
+
+func fileDB(t *testing.T, version int) (*SqliteAccess, string) {
+	path := filepath.Join(t.TempDir(), "csi.db")
+	db := SqliteAccess{}
+	require.NoError(t, db.connect(sqliteDriverName, path))
+
+	_, err := newMigrator(db.conn, path).Migrate(context.Background(), version)
+	require.NoError(t, err)
+
+	return &db, path
+}
+
+func getColumns(t *testing.T, db *SqliteAccess, table string) []string {
+	rows, err := db.conn.Query("SELECT name FROM pragma_table_info(?);", table)
+	require.NoError(t, err)
+
+	defer rows.Close()
+
+	var columns []string
+
+	for rows.Next() {
+		var column string
+		require.NoError(t, rows.Scan(&column))
+		columns = append(columns, column)
+	}
+
+	require.NoError(t, rows.Err())
+
+	return columns
+}
+
+func getSchemaVersion(t *testing.T, db *SqliteAccess) int {
+	version, err := newMigrator(db.conn, ":memory:").Version(context.Background())
+	require.NoError(t, err)
+
+	return version
+}
+
+func TestMigrations(t *testing.T) {
+	for i, migration := range migrations {
+		assert.Equal(t, i+1, migration.Version, "versions start at 1 and have no gaps")
+		assert.NotEmpty(t, migration.Description)
+		assert.NotEmpty(t, migration.up)
+		assert.NotEmpty(t, migration.down)
+	}
+
+	assert.Equal(t, len(migrations), LatestSchemaVersion)
+}
+
+func TestMigrateHistoricSchemas(t *testing.T) {
+	ctx := context.Background()
+
+	for version := 0; version <= LatestSchemaVersion; version++ {
+		t.Run("legacy schema of version "+migrationName(version), func(t *testing.T) {
+			db := legacyMemoryDB(t, version)
+
+			if version > 0 {
+				_, err := db.conn.Exec("INSERT INTO dynakubes (Name, TenantUUID, LatestVersion) VALUES ('dk', 'tenant', '1.2.3');")
+				require.NoError(t, err)
+				_, err = db.conn.Exec("INSERT INTO volumes (ID, PodName, Version, TenantUUID) VALUES ('vol', 'pod', '1.2.3', 'tenant');")
+				require.NoError(t, err)
+			}
+
+			require.NoError(t, db.createTables(ctx))
+
+			assert.Equal(t, LatestSchemaVersion, getSchemaVersion(t, db))
+			assert.Equal(t, []string{"Name", "TenantUUID", "LatestVersion", "ImageDigest", "MaxFailedMountAttempts", "Arch"}, getColumns(t, db, dynakubesTableName))
+			assert.Equal(t, []string{"ID", "PodName", "Version", "TenantUUID", "MountAttempts", "Arch"}, getColumns(t, db, volumesTableName))
+			assert.True(t, checkIfTablesExist(db))
+
+			if version > 0 {
+				dynakube, err := db.GetDynakube(ctx, "dk")
+				require.NoError(t, err)
+				require.NotNil(t, dynakube)
+				assert.Equal(t, "1.2.3", dynakube.LatestVersion)
+				assert.Equal(t, dynatracev1beta1.DefaultMaxFailedCsiMountAttempts, dynakube.MaxFailedMountAttempts)
+
+				volume, err := db.GetVolume(ctx, "vol")
+				require.NoError(t, err)
+				require.NotNil(t, volume)
+				assert.Equal(t, "pod", volume.PodName)
+				assert.Equal(t, 0, volume.MountAttempts)
+			}
+
+			dynakube := createTestDynakube(1)
+			require.NoError(t, db.InsertDynakube(ctx, &dynakube))
+		})
+	}
+}
+
+func TestMigrateUpAndDown(t *testing.T) {
+	ctx := context.Background()
+
+	for version := 0; version < LatestSchemaVersion; version++ {
+		t.Run("down to version "+migrationName(version), func(t *testing.T) {
+			db := FakeMemoryDB()
+			migrator := newMigrator(db.conn, ":memory:")
+
+			plan, err := migrator.Migrate(ctx, version)
+			require.NoError(t, err)
+
+			assert.True(t, plan.IsDowngrade())
+			assert.Equal(t, LatestSchemaVersion, plan.From)
+			assert.Len(t, plan.Migrations, LatestSchemaVersion-version)
+			assert.Equal(t, LatestSchemaVersion, plan.Migrations[0].Version, "the newest migration is reverted first")
+			assert.Equal(t, version, getSchemaVersion(t, db))
+
+			legacy := legacyMemoryDB(t, version)
+			for _, table := range []string{dynakubesTableName, volumesTableName, osAgentVolumesTableName} {
+				assert.Equal(t, getColumns(t, legacy, table), getColumns(t, db, table))
+			}
+
+			plan, err = migrator.Migrate(ctx, LatestSchemaVersion)
+			require.NoError(t, err)
+
+			assert.Len(t, plan.Migrations, LatestSchemaVersion-version)
+			assert.Equal(t, LatestSchemaVersion, getSchemaVersion(t, db))
+		})
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("nothing to do at the latest version", func(t *testing.T) {
+		db := FakeMemoryDB()
+
+		plan, err := newMigrator(db.conn, ":memory:").Migrate(ctx, LatestSchemaVersion)
+		require.NoError(t, err)
+
+		assert.Empty(t, plan.Migrations)
+		assert.Equal(t, LatestSchemaVersion, plan.From)
+	})
+	t.Run("unknown target version", func(t *testing.T) {
+		db := FakeMemoryDB()
+
+		_, err := newMigrator(db.conn, ":memory:").Migrate(ctx, LatestSchemaVersion+1)
+		require.Error(t, err)
+
+		_, err = newMigrator(db.conn, ":memory:").Migrate(ctx, -1)
+		require.Error(t, err)
+	})
+	t.Run("dry run doesn't change the database", func(t *testing.T) {
+		db := legacyMemoryDB(t, 2)
+
+		plan, err := newMigrator(db.conn, ":memory:").SetDryRun(true).Migrate(ctx, LatestSchemaVersion)
+		require.NoError(t, err)
+
+		assert.Len(t, plan.Migrations, LatestSchemaVersion)
+		assert.Equal(t, 0, getSchemaVersion(t, db))
+		assert.Equal(t, []string{"Name", "TenantUUID", "LatestVersion", "ImageDigest"}, getColumns(t, db, dynakubesTableName))
+	})
+	t.Run("failed migration is rolled back", func(t *testing.T) {
+		db := legacyMemoryDB(t, 1)
+		// recording the applied migrations fails, as the table lacks columns
+		_, err := db.conn.Exec("CREATE TABLE schema_version (Version INT NOT NULL);")
+		require.NoError(t, err)
+
+		_, err = newMigrator(db.conn, ":memory:").Migrate(ctx, LatestSchemaVersion)
+		require.Error(t, err)
+
+		assert.Equal(t, []string{"Name", "TenantUUID", "LatestVersion"}, getColumns(t, db, dynakubesTableName))
+	})
+	t.Run("columns added by an older operator after a downgrade are recorded", func(t *testing.T) {
+		db := FakeMemoryDB()
+		migrator := newMigrator(db.conn, ":memory:")
+
+		_, err := migrator.Migrate(ctx, 2)
+		require.NoError(t, err)
+
+		// the older operator adds the columns it knows about without looking at the schema version
+		require.NoError(t, executeMigrationStatements(ctx, db.conn, migrations[2].up))
+		require.NoError(t, executeMigrationStatements(ctx, db.conn, migrations[3].up))
+
+		plan, err := migrator.Migrate(ctx, LatestSchemaVersion)
+		require.NoError(t, err)
+
+		assert.Len(t, plan.Migrations, LatestSchemaVersion-2)
+		assert.Equal(t, LatestSchemaVersion, getSchemaVersion(t, db))
+	})
+	t.Run("newer schema is left unchanged", func(t *testing.T) {
+		db := FakeMemoryDB()
+		_, err := db.conn.Exec(insertSchemaVersionStatement, LatestSchemaVersion+1, "from the future", "2030-01-01")
+		require.NoError(t, err)
+
+		plan, err := newMigrator(db.conn, ":memory:").Migrate(ctx, LatestSchemaVersion)
+		require.NoError(t, err)
+
+		assert.Empty(t, plan.Migrations)
+		assert.Equal(t, LatestSchemaVersion+1, getSchemaVersion(t, db))
+	})
+}
+
+func TestMigrateBackup(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("backup is created before migrating", func(t *testing.T) {
+		db, path := fileDB(t, 4)
+		_, err := db.conn.Exec("INSERT INTO dynakubes (Name, TenantUUID, LatestVersion) VALUES ('dk', 'tenant', '1.2.3');")
+		require.NoError(t, err)
+
+		_, err = newMigrator(db.conn, path).Migrate(ctx, LatestSchemaVersion)
+		require.NoError(t, err)
+
+		backup := SqliteAccess{}
+		require.NoError(t, backup.connect(sqliteDriverName, BackupPath(path, 4)))
+		assert.Equal(t, 4, getSchemaVersion(t, &backup))
+
+		var name string
+		require.NoError(t, backup.conn.QueryRow("SELECT Name FROM dynakubes;").Scan(&name))
+		assert.Equal(t, "dk", name)
+	})
+	t.Run("old backup is replaced", func(t *testing.T) {
+		db, path := fileDB(t, 4)
+		require.NoError(t, os.WriteFile(BackupPath(path, 4), []byte("outdated"), 0600))
+
+		_, err := newMigrator(db.conn, path).Migrate(ctx, LatestSchemaVersion)
+		require.NoError(t, err)
+
+		content, err := os.ReadFile(BackupPath(path, 4))
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(string(content), "SQLite format 3"))
+	})
+	t.Run("no backup of an empty database or a dry run", func(t *testing.T) {
+		db, path := fileDB(t, 0)
+
+		_, err := newMigrator(db.conn, path).SetDryRun(true).Migrate(ctx, 1)
+		require.NoError(t, err)
+
+		_, err = newMigrator(db.conn, path).Migrate(ctx, 1)
+		require.NoError(t, err)
+
+		_, err = newMigrator(db.conn, path).SetDryRun(true).Migrate(ctx, LatestSchemaVersion)
+		require.NoError(t, err)
+
+		matches, err := filepath.Glob(path + ".*.bak")
+		require.NoError(t, err)
+		assert.Empty(t, matches)
+	})
+}
+
+func TestOpenMigrator(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "csi.db")
+
+	migrator, err := OpenMigrator(path)
+	require.NoError(t, err)
+
+	defer migrator.Close()
+
+	version, err := migrator.Version(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, version)
+
+	require.NoError(t, migrator.CheckIntegrity(context.Background()))
+}
+
+func migrationName(version int) string {
+	if version == 0 {
+		return "0 (empty)"
+	}
+
+	return migrations[version-1].Description
+}
@@ -49,6 +49,26 @@ func (pr PathResolver) AgentTempUnzipRootDir() string {
 	return filepath.Join(pr.RootDir, "tmp_zip")
 }
 
+// ImageCacheDir is where the code modules images are pulled to, before they are unpacked into the shared binary dir
+func (pr PathResolver) ImageCacheDir() string {
+	return filepath.Join(pr.RootDir, dtcsi.ImageCacheDir)
+}
+
+// BlobDir holds the deduplicated files of the code modules, the files in the shared binary dirs are hardlinks to them
+func (pr PathResolver) BlobDir() string {
+	return filepath.Join(pr.RootDir, dtcsi.BlobDir)
+}
+
+// BlobPath spreads the blobs over subdirectories named after the first characters of their id, to keep the directories small
+func (pr PathResolver) BlobPath(blobID string) string {
+	prefix := blobID
+	if len(prefix) > 2 {
+		prefix = prefix[:2]
+	}
+
+	return filepath.Join(pr.BlobDir(), prefix, blobID)
+}
+
 func (pr PathResolver) AgentTempUnzipDir() string {
 	return filepath.Join(pr.AgentTempUnzipRootDir(), "opt", "dynatrace", "oneagent")
 }
@@ -57,6 +77,16 @@ func (pr PathResolver) AgentSharedBinaryDirForAgent(versionOrDigest string) stri
 	return filepath.Join(pr.AgentSharedBinaryDirBase(), versionOrDigest)
 }
 
+// AgentSharedBinaryDirBaseForArch returns the directory of the shared binaries of the arch.
+// Binaries without arch were installed before they were stored per arch and are located directly in the base directory.
+func (pr PathResolver) AgentSharedBinaryDirBaseForArch(arch string) string {
+	return filepath.Join(pr.AgentSharedBinaryDirBase(), arch)
+}
+
+func (pr PathResolver) AgentSharedBinaryDirForAgentArch(versionOrDigest, arch string) string {
+	return filepath.Join(pr.AgentSharedBinaryDirBaseForArch(arch), versionOrDigest)
+}
+
 func (pr PathResolver) AgentConfigDir(tenantUUID string) string {
 	return filepath.Join(pr.TenantDir(tenantUUID), dtcsi.SharedAgentConfigDir)
 }
@@ -24,4 +24,13 @@ func TestPathResolver(t *testing.T) {
 	assert.Equal(t, filepath.Join(agentRunDirForVolume, "mapped"), pathResolver.OverlayMappedDir(tenantUUID, fakeVolume))
 	assert.Equal(t, filepath.Join(agentRunDirForVolume, "var"), pathResolver.OverlayVarDir(tenantUUID, fakeVolume))
 	assert.Equal(t, filepath.Join(agentRunDirForVolume, "work"), pathResolver.OverlayWorkDir(tenantUUID, fakeVolume))
+
+	sharedBinaryDir := filepath.Join(rootDir, "codemodules")
+	assert.Equal(t, filepath.Join(sharedBinaryDir, "v1"), pathResolver.AgentSharedBinaryDirForAgent("v1"))
+	assert.Equal(t, filepath.Join(sharedBinaryDir, "v1"), pathResolver.AgentSharedBinaryDirForAgentArch("v1", ""))
+	assert.Equal(t, filepath.Join(sharedBinaryDir, "arm"), pathResolver.AgentSharedBinaryDirBaseForArch("arm"))
+	assert.Equal(t, filepath.Join(sharedBinaryDir, "arm", "v1"), pathResolver.AgentSharedBinaryDirForAgentArch("v1", "arm"))
+
+	assert.Equal(t, filepath.Join(rootDir, "blobs"), pathResolver.BlobDir())
+	assert.Equal(t, filepath.Join(rootDir, "blobs", "ab", "abcdef"), pathResolver.BlobPath("abcdef"))
 }
@@ -3,78 +3,34 @@ package metadata
 import (
 	"context"
 	"database/sql"
-	"strconv"
 	"time"
 
-	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
-	"github.com/mattn/go-sqlite3"
+	_ "github.com/mattn/go-sqlite3" // registers the sqlite3 driver
 	"github.com/pkg/errors"
 )
 
-var (
-	dynakubesAlterStatementMaxFailedMountAttempts = `
-	ALTER TABLE dynakubes
-	ADD COLUMN MaxFailedMountAttempts INT NOT NULL DEFAULT ` + strconv.FormatInt(dynatracev1beta1.DefaultMaxFailedCsiMountAttempts, 10) + ";"
-	// "Not null"-columns need a default value set
-)
-
 const (
 	sqliteDriverName = "sqlite3"
 
-	// CREATE
-	dynakubesTableName       = "dynakubes"
-	dynakubesCreateStatement = `
-	CREATE TABLE IF NOT EXISTS dynakubes (
-		Name VARCHAR NOT NULL,
-		TenantUUID VARCHAR NOT NULL,
-		LatestVersion VARCHAR NOT NULL,
-		PRIMARY KEY (Name)
-	); `
-
-	volumesTableName       = "volumes"
-	volumesCreateStatement = `
-	CREATE TABLE IF NOT EXISTS volumes (
-		ID VARCHAR NOT NULL,
-		PodName VARCHAR NOT NULL,
-		Version VARCHAR NOT NULL,
-		TenantUUID VARCHAR NOT NULL,
-		PRIMARY KEY (ID)
-	);`
-
-	osAgentVolumesTableName       = "osagent_volumes"
-	osAgentVolumesCreateStatement = `
-	CREATE TABLE IF NOT EXISTS osagent_volumes (
-		TenantUUID VARCHAR NOT NULL,
-		VolumeID VARCHAR NOT NULL,
-		Mounted BOOLEAN NOT NULL,
-		LastModified DATETIME NOT NULL,
-		PRIMARY KEY (TenantUUID)
-	);`
-
-	// ALTER
-	dynakubesAlterStatementImageDigestColumn = `
-	ALTER TABLE dynakubes
-	ADD COLUMN ImageDigest VARCHAR NOT NULL DEFAULT '';
-	`
-
-	volumesAlterStatementMountAttempts = `
-	ALTER TABLE volumes
-	ADD COLUMN MountAttempts INT NOT NULL DEFAULT 0;`
+	dynakubesTableName      = "dynakubes"
+	volumesTableName        = "volumes"
+	osAgentVolumesTableName = "osagent_volumes"
 
 	// INSERT
 	insertDynakubeStatement = `
-	INSERT INTO dynakubes (Name, TenantUUID, LatestVersion, ImageDigest, MaxFailedMountAttempts)
-	VALUES (?,?,?,?, ?);
+	INSERT INTO dynakubes (Name, TenantUUID, LatestVersion, ImageDigest, MaxFailedMountAttempts, Arch)
+	VALUES (?,?,?,?,?,?);
 	`
 
 	insertVolumeStatement = `
-	INSERT INTO volumes (ID, PodName, Version, TenantUUID, MountAttempts)
-	VALUES (?,?,?,?,?)
+	INSERT INTO volumes (ID, PodName, Version, TenantUUID, MountAttempts, Arch)
+	VALUES (?,?,?,?,?,?)
 	ON CONFLICT(ID) DO UPDATE SET
 	  PodName=excluded.PodName,
 	  Version=excluded.Version,
 	  TenantUUID=excluded.TenantUUID,
-  	  MountAttempts=excluded.MountAttempts;
+  	  MountAttempts=excluded.MountAttempts,
+	  Arch=excluded.Arch;
 	`
 
 	insertOsAgentVolumeStatement = `
@@ -82,10 +38,31 @@ const (
 	VALUES (?,?,?,?);
 	`
 
+	insertVolumeIntentStatement = `
+	INSERT INTO volume_intents (VolumeID, Operation, TenantUUID, TargetPath, CreatedAt)
+	VALUES (?,?,?,?,?)
+	ON CONFLICT(VolumeID) DO UPDATE SET
+	  Operation=excluded.Operation,
+	  TenantUUID=excluded.TenantUUID,
+	  TargetPath=excluded.TargetPath,
+	  CreatedAt=excluded.CreatedAt;
+	`
+
+	insertBlobStatement = `
+	INSERT INTO blobs (ID, Size, RefCount)
+	VALUES (?,?,0)
+	ON CONFLICT(ID) DO NOTHING;
+	`
+
+	insertCodeModuleBlobStatement = `
+	INSERT OR IGNORE INTO code_module_blobs (Name, Arch, BlobID)
+	VALUES (?,?,?);
+	`
+
 	// UPDATE
 	updateDynakubeStatement = `
 	UPDATE dynakubes
-	SET LatestVersion = ?, TenantUUID = ?, ImageDigest = ?, MaxFailedMountAttempts = ?
+	SET LatestVersion = ?, TenantUUID = ?, ImageDigest = ?, MaxFailedMountAttempts = ?, Arch = ?
 	WHERE Name = ?;
 	`
 
@@ -95,15 +72,23 @@ const (
 	WHERE TenantUUID = ?;
 	`
 
+	incrementBlobRefCountStatement = "UPDATE blobs SET RefCount = RefCount + 1 WHERE ID = ?;"
+
+	decrementCodeModuleBlobRefCountsStatement = `
+	UPDATE blobs
+	SET RefCount = RefCount - 1
+	WHERE ID IN (SELECT BlobID FROM code_module_blobs WHERE Name = ? AND Arch = ?);
+	`
+
 	// GET
 	getDynakubeStatement = `
-	SELECT TenantUUID, LatestVersion, ImageDigest, MaxFailedMountAttempts
+	SELECT TenantUUID, LatestVersion, ImageDigest, MaxFailedMountAttempts, Arch
 	FROM dynakubes
 	WHERE Name = ?;
 	`
 
 	getVolumeStatement = `
-	SELECT PodName, Version, TenantUUID, MountAttempts
+	SELECT PodName, Version, TenantUUID, MountAttempts, Arch
 	FROM volumes
 	WHERE ID = ?;
 	`
@@ -122,12 +107,12 @@ const (
 
 	// GET ALL
 	getAllDynakubesStatement = `
-		SELECT Name, TenantUUID, LatestVersion, ImageDigest, MaxFailedMountAttempts
+		SELECT Name, TenantUUID, LatestVersion, ImageDigest, MaxFailedMountAttempts, Arch
 		FROM dynakubes;
 		`
 
 	getAllVolumesStatement = `
-		SELECT ID, PodName, Version, TenantUUID, MountAttempts
+		SELECT ID, PodName, Version, TenantUUID, MountAttempts, Arch
 		FROM volumes;
 		`
 
@@ -136,11 +121,28 @@ const (
 		FROM osagent_volumes;
 		`
 
+	getAllVolumeIntentsStatement = `
+		SELECT VolumeID, Operation, TenantUUID, TargetPath, CreatedAt
+		FROM volume_intents;
+		`
+
+	getUnreferencedBlobsStatement = `
+		SELECT ID, Size, RefCount
+		FROM blobs
+		WHERE RefCount <= 0;
+		`
+
 	// DELETE
 	deleteVolumeStatement = "DELETE FROM volumes WHERE ID = ?;"
 
+	deleteVolumeIntentStatement = "DELETE FROM volume_intents WHERE VolumeID = ?;"
+
 	deleteDynakubeStatement = "DELETE FROM dynakubes WHERE Name = ?;"
 
+	deleteCodeModuleBlobsStatement = "DELETE FROM code_module_blobs WHERE Name = ? AND Arch = ?;"
+
+	deleteUnreferencedBlobStatement = "DELETE FROM blobs WHERE ID = ? AND RefCount <= 0;"
+
 	// SPECIAL
 	getUsedVersionsStatement = `
 	SELECT DISTINCT Version
@@ -164,6 +166,20 @@ const (
 	FROM dynakubes;
 	`
 
+	getUsedAgentBinsStatement = `
+	SELECT LatestVersion, Arch
+	FROM dynakubes
+	WHERE LatestVersion != ""
+	UNION
+	SELECT ImageDigest, Arch
+	FROM dynakubes
+	WHERE ImageDigest != ""
+	UNION
+	SELECT Version, Arch
+	FROM volumes
+	WHERE Version != "";
+	`
+
 	getPodNamesStatement = `
 	SELECT ID, PodName
 	FROM volumes;
@@ -183,6 +199,9 @@ const (
 
 type SqliteAccess struct {
 	conn *sql.DB
+	// tx is set for the Access passed to the function run by WithTx
+	tx   querier
+	path string
 }
 
 // NewAccess creates a new SqliteAccess, connects to the database.
@@ -204,85 +223,51 @@ func (access *SqliteAccess) connect(driver, path string) error {
 		return err
 	}
 	access.conn = db
+	access.path = path
 	return nil
 }
 
+// createTables brings the schema of the database to the latest version
 func (access *SqliteAccess) createTables(ctx context.Context) error {
-	err := access.setupDynakubeTable(ctx)
-	if err != nil {
-		return err
-	}
+	_, err := newMigrator(access.conn, access.path).Migrate(ctx, LatestSchemaVersion)
 
-	err = access.setupVolumeTable(ctx)
-	if err != nil {
-		return err
-	}
-
-	if _, err := access.conn.ExecContext(ctx, osAgentVolumesCreateStatement); err != nil {
-		return errors.WithStack(errors.WithMessagef(err, "couldn't create the table %s", osAgentVolumesTableName))
-	}
-	return nil
-}
-
-func (access *SqliteAccess) setupVolumeTable(ctx context.Context) error {
-	_, err := access.conn.Exec(volumesCreateStatement)
-	if err != nil {
-		return errors.WithMessagef(err, "couldn't create the table %s", volumesTableName)
-	}
-
-	err = access.executeAlterStatement(ctx, volumesAlterStatementMountAttempts)
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return err
 }
 
-// setupDynakubeTable creates the dynakubes table if it doesn't exist and tries to add additional columns
-func (access *SqliteAccess) setupDynakubeTable(ctx context.Context) error {
-	if _, err := access.conn.Exec(dynakubesCreateStatement); err != nil {
-		return errors.WithStack(errors.WithMessagef(err, "couldn't create the table %s", dynakubesTableName))
-	}
-
-	err := access.executeAlterStatement(ctx, dynakubesAlterStatementImageDigestColumn)
-	if err != nil {
+// Setup connects to the database and migrates its schema to the latest version
+func (access *SqliteAccess) Setup(ctx context.Context, path string) error {
+	if err := access.connect(sqliteDriverName, path); err != nil {
 		return err
 	}
-
-	err = access.executeAlterStatement(ctx, dynakubesAlterStatementMaxFailedMountAttempts)
-	if err != nil {
+	if err := access.createTables(ctx); err != nil {
 		return err
 	}
-
 	return nil
 }
 
-func (access *SqliteAccess) executeAlterStatement(ctx context.Context, statement string) error {
-	if _, err := access.conn.ExecContext(ctx, statement); err != nil {
-		sqliteErr := sqlite3.Error{}
-		isSqliteErr := errors.As(err, &sqliteErr)
-		if isSqliteErr && sqliteErr.Code != sqlite3.ErrError {
-			return errors.WithStack(err)
-		}
+// WithTx runs fn in a transaction, which is committed if fn returns nil. Calls of WithTx within fn join the transaction.
+func (access *SqliteAccess) WithTx(ctx context.Context, fn func(access Access) error) error {
+	if access.tx != nil {
+		return fn(access)
 	}
 
-	return nil
+	return runImmediateTransaction(ctx, access.conn, func(conn *sql.Conn) error {
+		return fn(&SqliteAccess{conn: access.conn, tx: conn, path: access.path})
+	})
 }
 
-// Setup connects to the database and creates the necessary tables if they don't exist
-func (access *SqliteAccess) Setup(ctx context.Context, path string) error {
-	if err := access.connect(sqliteDriverName, path); err != nil {
-		return err
+// executor returns the connection of the transaction if the Access runs in one, otherwise the connection pool
+func (access *SqliteAccess) executor() querier {
+	if access.tx != nil {
+		return access.tx
 	}
-	if err := access.createTables(ctx); err != nil {
-		return err
-	}
-	return nil
+
+	return access.conn
 }
 
 // InsertDynakube inserts a new Dynakube
 func (access *SqliteAccess) InsertDynakube(ctx context.Context, dynakube *Dynakube) error {
-	err := access.executeStatement(ctx, insertDynakubeStatement, dynakube.Name, dynakube.TenantUUID, dynakube.LatestVersion, dynakube.ImageDigest, dynakube.MaxFailedMountAttempts)
+	err := access.executeStatement(ctx, insertDynakubeStatement, dynakube.Name, dynakube.TenantUUID, dynakube.LatestVersion, dynakube.ImageDigest, dynakube.MaxFailedMountAttempts, dynakube.Arch)
 	if err != nil {
 		err = errors.WithMessagef(err, "couldn't insert dynakube entry, tenantUUID '%s', latest version '%s', name '%s', image digest '%s'",
 			dynakube.TenantUUID,
@@ -295,7 +280,7 @@ func (access *SqliteAccess) InsertDynakube(ctx context.Context, dynakube *Dynaku
 
 // UpdateDynakube updates an existing Dynakube by matching the name
 func (access *SqliteAccess) UpdateDynakube(ctx context.Context, dynakube *Dynakube) error {
-	err := access.executeStatement(ctx, updateDynakubeStatement, dynakube.LatestVersion, dynakube.TenantUUID, dynakube.ImageDigest, dynakube.MaxFailedMountAttempts, dynakube.Name)
+	err := access.executeStatement(ctx, updateDynakubeStatement, dynakube.LatestVersion, dynakube.TenantUUID, dynakube.ImageDigest, dynakube.MaxFailedMountAttempts, dynakube.Arch, dynakube.Name)
 	if err != nil {
 		err = errors.WithMessagef(err, "couldn't update dynakube, tenantUUID '%s', latest version '%s', name '%s', image digest '%s'",
 			dynakube.TenantUUID,
@@ -321,18 +306,19 @@ func (access *SqliteAccess) GetDynakube(ctx context.Context, dynakubeName string
 	var latestVersion string
 	var imageDigest string
 	var maxFailedMountAttempts int
+	var arch string
 
-	err := access.querySimpleStatement(ctx, getDynakubeStatement, dynakubeName, &tenantUUID, &latestVersion, &imageDigest, &maxFailedMountAttempts)
+	err := access.querySimpleStatement(ctx, getDynakubeStatement, dynakubeName, &tenantUUID, &latestVersion, &imageDigest, &maxFailedMountAttempts, &arch)
 	if err != nil {
 		err = errors.WithMessagef(err, "couldn't get dynakube, name '%s'", dynakubeName)
 	}
 
-	return NewDynakube(dynakubeName, tenantUUID, latestVersion, imageDigest, maxFailedMountAttempts), err
+	return NewDynakube(dynakubeName, tenantUUID, latestVersion, imageDigest, maxFailedMountAttempts, arch), err
 }
 
 // InsertVolume inserts a new Volume
 func (access *SqliteAccess) InsertVolume(ctx context.Context, volume *Volume) error {
-	err := access.executeStatement(ctx, insertVolumeStatement, volume.VolumeID, volume.PodName, volume.Version, volume.TenantUUID, volume.MountAttempts)
+	err := access.executeStatement(ctx, insertVolumeStatement, volume.VolumeID, volume.PodName, volume.Version, volume.TenantUUID, volume.MountAttempts, volume.Arch)
 	if err != nil {
 		err = errors.WithMessagef(err, "couldn't insert volume info, volume id '%s', pod '%s', version '%s', dynakube '%s'",
 			volume.VolumeID,
@@ -349,13 +335,14 @@ func (access *SqliteAccess) GetVolume(ctx context.Context, volumeID string) (*Vo
 	var version string
 	var tenantUUID string
 	var mountAttempts int
+	var arch string
 
-	err := access.querySimpleStatement(ctx, getVolumeStatement, volumeID, &podName, &version, &tenantUUID, &mountAttempts)
+	err := access.querySimpleStatement(ctx, getVolumeStatement, volumeID, &podName, &version, &tenantUUID, &mountAttempts, &arch)
 	if err != nil {
 		err = errors.WithMessagef(err, "couldn't get volume field for volume id '%s'", volumeID)
 	}
 
-	return NewVolume(volumeID, podName, version, tenantUUID, mountAttempts), err
+	return NewVolume(volumeID, podName, version, tenantUUID, mountAttempts, arch), err
 }
 
 // DeleteVolume deletes a Volume by its ID
@@ -419,7 +406,7 @@ func (access *SqliteAccess) GetOsAgentVolumeViaTenantUUID(ctx context.Context, t
 
 // GetAllVolumes gets all the Volumes from the database
 func (access *SqliteAccess) GetAllVolumes(ctx context.Context) ([]*Volume, error) {
-	rows, err := access.conn.QueryContext(ctx, getAllVolumesStatement)
+	rows, err := access.executor().QueryContext(ctx, getAllVolumesStatement)
 	if err != nil {
 		return nil, errors.WithStack(errors.WithMessage(err, "couldn't get all the volumes"))
 	}
@@ -431,20 +418,21 @@ func (access *SqliteAccess) GetAllVolumes(ctx context.Context) ([]*Volume, error
 		var version string
 		var tenantUUID string
 		var mountAttempts int
+		var arch string
 
-		err := rows.Scan(&id, &podName, &version, &tenantUUID, &mountAttempts)
+		err := rows.Scan(&id, &podName, &version, &tenantUUID, &mountAttempts, &arch)
 		if err != nil {
 			return nil, errors.WithStack(errors.WithMessage(err, "couldn't scan volume from database"))
 		}
 
-		volumes = append(volumes, NewVolume(id, podName, version, tenantUUID, mountAttempts))
+		volumes = append(volumes, NewVolume(id, podName, version, tenantUUID, mountAttempts, arch))
 	}
 	return volumes, nil
 }
 
 // GetAllDynakubes gets all the Dynakubes from the database
 func (access *SqliteAccess) GetAllDynakubes(ctx context.Context) ([]*Dynakube, error) {
-	rows, err := access.conn.QueryContext(ctx, getAllDynakubesStatement)
+	rows, err := access.executor().QueryContext(ctx, getAllDynakubesStatement)
 	if err != nil {
 		return nil, errors.WithStack(errors.WithMessage(err, "couldn't get all the dynakubes"))
 	}
@@ -456,20 +444,21 @@ func (access *SqliteAccess) GetAllDynakubes(ctx context.Context) ([]*Dynakube, e
 		var tenantUUID string
 		var imageDigest string
 		var maxFailedMountAttempts int
+		var arch string
 
-		err := rows.Scan(&name, &tenantUUID, &version, &imageDigest, &maxFailedMountAttempts)
+		err := rows.Scan(&name, &tenantUUID, &version, &imageDigest, &maxFailedMountAttempts, &arch)
 		if err != nil {
 			return nil, errors.WithStack(errors.WithMessage(err, "couldn't scan dynakube from database"))
 		}
 
-		dynakubes = append(dynakubes, NewDynakube(name, tenantUUID, version, imageDigest, maxFailedMountAttempts))
+		dynakubes = append(dynakubes, NewDynakube(name, tenantUUID, version, imageDigest, maxFailedMountAttempts, arch))
 	}
 	return dynakubes, nil
 }
 
 // GetAllOsAgentVolumes gets all the OsAgentVolume from the database
 func (access *SqliteAccess) GetAllOsAgentVolumes(ctx context.Context) ([]*OsAgentVolume, error) {
-	rows, err := access.conn.QueryContext(ctx, getAllOsAgentVolumes)
+	rows, err := access.executor().QueryContext(ctx, getAllOsAgentVolumes)
 	if err != nil {
 		return nil, errors.WithStack(errors.WithMessage(err, "couldn't get all the osagent volumes"))
 	}
@@ -493,7 +482,7 @@ func (access *SqliteAccess) GetAllOsAgentVolumes(ctx context.Context) ([]*OsAgen
 // Map is used to make sure we don't return the same version multiple time,
 // it's also easier to check if a version is in it or not. (a Set in style of Golang)
 func (access *SqliteAccess) GetUsedVersions(ctx context.Context, tenantUUID string) (map[string]bool, error) {
-	rows, err := access.conn.QueryContext(ctx, getUsedVersionsStatement, tenantUUID)
+	rows, err := access.executor().QueryContext(ctx, getUsedVersionsStatement, tenantUUID)
 	if err != nil {
 		return nil, errors.WithStack(errors.WithMessagef(err, "couldn't get used version info for tenant uuid '%s'", tenantUUID))
 	}
@@ -514,7 +503,7 @@ func (access *SqliteAccess) GetUsedVersions(ctx context.Context, tenantUUID stri
 // Map is used to make sure we don't return the same version multiple time,
 // it's also easier to check if a version is in it or not. (a Set in style of Golang)
 func (access *SqliteAccess) GetAllUsedVersions(ctx context.Context) (map[string]bool, error) {
-	rows, err := access.conn.QueryContext(ctx, getAllUsedVersionsStatement)
+	rows, err := access.executor().QueryContext(ctx, getAllUsedVersionsStatement)
 	if err != nil {
 		return nil, errors.WithStack(errors.WithMessagef(err, "couldn't get all used version info"))
 	}
@@ -537,7 +526,7 @@ func (access *SqliteAccess) GetAllUsedVersions(ctx context.Context) (map[string]
 // Map is used to make sure we don't return the same version multiple time,
 // it's also easier to check if a version is in it or not. (a Set in style of Golang)
 func (access *SqliteAccess) GetLatestVersions(ctx context.Context) (map[string]bool, error) {
-	rows, err := access.conn.QueryContext(ctx, getLatestVersionsStatement)
+	rows, err := access.executor().QueryContext(ctx, getLatestVersionsStatement)
 	if err != nil {
 		return nil, errors.WithStack(errors.WithMessage(err, "couldn't get all the latests version info for tenant uuid"))
 	}
@@ -558,7 +547,7 @@ func (access *SqliteAccess) GetLatestVersions(ctx context.Context) (map[string]b
 // Map is used to make sure we don't return the same digest multiple time,
 // it's also easier to check if a digest is in it or not. (a Set in style of Golang)
 func (access *SqliteAccess) GetUsedImageDigests(ctx context.Context) (map[string]bool, error) {
-	rows, err := access.conn.QueryContext(ctx, getUsedImageDigestStatement)
+	rows, err := access.executor().QueryContext(ctx, getUsedImageDigestStatement)
 	if err != nil {
 		return nil, errors.WithStack(errors.WithMessage(err, "couldn't get used image digests from database"))
 	}
@@ -577,6 +566,25 @@ func (access *SqliteAccess) GetUsedImageDigests(ctx context.Context) (map[string
 	return imageDigests, nil
 }
 
+// GetUsedAgentBins gets all UNIQUE shared binaries referenced by the `dynakubes` or mounted by the `volumes`, together with their arch.
+func (access *SqliteAccess) GetUsedAgentBins(ctx context.Context) (map[AgentBin]bool, error) {
+	rows, err := access.executor().QueryContext(ctx, getUsedAgentBinsStatement)
+	if err != nil {
+		return nil, errors.WithStack(errors.WithMessage(err, "couldn't get used agent binaries from database"))
+	}
+	agentBins := map[AgentBin]bool{}
+	defer func() { _ = rows.Close() }()
+	for rows.Next() {
+		var agentBin AgentBin
+		err := rows.Scan(&agentBin.Name, &agentBin.Arch)
+		if err != nil {
+			return nil, errors.WithStack(errors.WithMessage(err, "couldn't scan used agent binary from database"))
+		}
+		agentBins[agentBin] = true
+	}
+	return agentBins, nil
+}
+
 // IsImageDigestUsed checks if the specified image digest is present in the database.
 func (access *SqliteAccess) IsImageDigestUsed(ctx context.Context, imageDigest string) (bool, error) {
 	var count int
@@ -589,7 +597,7 @@ func (access *SqliteAccess) IsImageDigestUsed(ctx context.Context, imageDigest s
 
 // GetPodNames gets all PodNames present in the `volumes` database in map with their corresponding volumeIDs.
 func (access *SqliteAccess) GetPodNames(ctx context.Context) (map[string]string, error) {
-	rows, err := access.conn.QueryContext(ctx, getPodNamesStatement)
+	rows, err := access.executor().QueryContext(ctx, getPodNamesStatement)
 	if err != nil {
 		return nil, errors.WithStack(errors.WithMessage(err, "couldn't get all pod names"))
 	}
@@ -609,7 +617,7 @@ func (access *SqliteAccess) GetPodNames(ctx context.Context) (map[string]string,
 
 // GetTenantsToDynakubes gets all Dynakubes and maps their name to the corresponding TenantUUID.
 func (access *SqliteAccess) GetTenantsToDynakubes(ctx context.Context) (map[string]string, error) {
-	rows, err := access.conn.QueryContext(ctx, getTenantsToDynakubesStatement)
+	rows, err := access.executor().QueryContext(ctx, getTenantsToDynakubesStatement)
 	if err != nil {
 		return nil, errors.WithStack(errors.WithMessage(err, "couldn't get all tenants to dynakube metadata"))
 	}
@@ -627,10 +635,130 @@ func (access *SqliteAccess) GetTenantsToDynakubes(ctx context.Context) (map[stri
 	return dynakubes, nil
 }
 
+// InsertVolumeIntent records the intent to mount or unmount a volume, it replaces an older intent for the same volume
+func (access *SqliteAccess) InsertVolumeIntent(ctx context.Context, intent *VolumeIntent) error {
+	if intent == nil {
+		return errors.New("couldn't insert volume intent, fields are missing")
+	}
+
+	err := access.executeStatement(ctx, insertVolumeIntentStatement, intent.VolumeID, intent.Operation, intent.TenantUUID, intent.TargetPath, intent.CreatedAt)
+	if err != nil {
+		err = errors.WithMessagef(err, "couldn't insert volume intent, id '%s', operation '%s'", intent.VolumeID, intent.Operation)
+	}
+
+	return err
+}
+
+// DeleteVolumeIntent removes the intent of a volume once the mount and the volumes table are consistent again
+func (access *SqliteAccess) DeleteVolumeIntent(ctx context.Context, volumeID string) error {
+	err := access.executeStatement(ctx, deleteVolumeIntentStatement, volumeID)
+	if err != nil {
+		err = errors.WithMessagef(err, "couldn't delete volume intent, id '%s'", volumeID)
+	}
+
+	return err
+}
+
+// GetAllVolumeIntents returns the intents that weren't completed yet
+func (access *SqliteAccess) GetAllVolumeIntents(ctx context.Context) ([]*VolumeIntent, error) {
+	rows, err := access.executor().QueryContext(ctx, getAllVolumeIntentsStatement)
+	if err != nil {
+		return nil, errors.WithStack(errors.WithMessage(err, "couldn't get all the volume intents"))
+	}
+	intents := []*VolumeIntent{}
+	defer func() { _ = rows.Close() }()
+	for rows.Next() {
+		var intent VolumeIntent
+		var createdAt time.Time
+		err := rows.Scan(&intent.VolumeID, &intent.Operation, &intent.TenantUUID, &intent.TargetPath, &createdAt)
+		if err != nil {
+			return nil, errors.WithStack(errors.WithMessage(err, "couldn't scan volume intent from database"))
+		}
+		intent.CreatedAt = &createdAt
+		intents = append(intents, &intent)
+	}
+	return intents, nil
+}
+
+// InsertCodeModuleBlobs records that the code modules of the agentBin contain the blobs,
+// the reference count of a blob is only increased once per code module
+func (access *SqliteAccess) InsertCodeModuleBlobs(ctx context.Context, agentBin AgentBin, blobs []*Blob) error {
+	return access.WithTx(ctx, func(txAccess Access) error {
+		executor := txAccess.(*SqliteAccess).executor()
+
+		for _, blob := range blobs {
+			if _, err := executor.ExecContext(ctx, insertBlobStatement, blob.ID, blob.Size); err != nil {
+				return errors.WithMessagef(err, "couldn't insert blob, id '%s'", blob.ID)
+			}
+
+			result, err := executor.ExecContext(ctx, insertCodeModuleBlobStatement, agentBin.Name, agentBin.Arch, blob.ID)
+			if err != nil {
+				return errors.WithMessagef(err, "couldn't insert blob of code module, name '%s', arch '%s', id '%s'", agentBin.Name, agentBin.Arch, blob.ID)
+			}
+
+			if inserted, _ := result.RowsAffected(); inserted == 0 {
+				continue
+			}
+
+			if _, err := executor.ExecContext(ctx, incrementBlobRefCountStatement, blob.ID); err != nil {
+				return errors.WithMessagef(err, "couldn't increment reference count of blob, id '%s'", blob.ID)
+			}
+		}
+
+		return nil
+	})
+}
+
+// DeleteCodeModuleBlobs releases the blobs of the code modules of the agentBin, the blobs that aren't referenced anymore are returned by GetUnreferencedBlobs
+func (access *SqliteAccess) DeleteCodeModuleBlobs(ctx context.Context, agentBin AgentBin) error {
+	return access.WithTx(ctx, func(txAccess Access) error {
+		executor := txAccess.(*SqliteAccess).executor()
+
+		if _, err := executor.ExecContext(ctx, decrementCodeModuleBlobRefCountsStatement, agentBin.Name, agentBin.Arch); err != nil {
+			return errors.WithMessagef(err, "couldn't decrement reference counts of the blobs of code module, name '%s', arch '%s'", agentBin.Name, agentBin.Arch)
+		}
+
+		if _, err := executor.ExecContext(ctx, deleteCodeModuleBlobsStatement, agentBin.Name, agentBin.Arch); err != nil {
+			return errors.WithMessagef(err, "couldn't delete blobs of code module, name '%s', arch '%s'", agentBin.Name, agentBin.Arch)
+		}
+
+		return nil
+	})
+}
+
+// GetUnreferencedBlobs returns the blobs that no code module contains anymore
+func (access *SqliteAccess) GetUnreferencedBlobs(ctx context.Context) ([]*Blob, error) {
+	rows, err := access.executor().QueryContext(ctx, getUnreferencedBlobsStatement)
+	if err != nil {
+		return nil, errors.WithStack(errors.WithMessage(err, "couldn't get the unreferenced blobs"))
+	}
+	blobs := []*Blob{}
+	defer func() { _ = rows.Close() }()
+	for rows.Next() {
+		var blob Blob
+		err := rows.Scan(&blob.ID, &blob.Size, &blob.References)
+		if err != nil {
+			return nil, errors.WithStack(errors.WithMessage(err, "couldn't scan blob from database"))
+		}
+		blobs = append(blobs, &blob)
+	}
+	return blobs, nil
+}
+
+// DeleteBlob removes the blob if no code module references it, a blob that was referenced again in the meantime is kept
+func (access *SqliteAccess) DeleteBlob(ctx context.Context, id string) error {
+	err := access.executeStatement(ctx, deleteUnreferencedBlobStatement, id)
+	if err != nil {
+		err = errors.WithMessagef(err, "couldn't delete blob, id '%s'", id)
+	}
+
+	return err
+}
+
 // Executes the provided SQL statement on the database.
 // The `vars` are passed to the SQL statement (in-order), to fill in the SQL wildcards.
 func (access *SqliteAccess) executeStatement(ctx context.Context, statement string, vars ...any) error {
-	_, err := access.conn.ExecContext(ctx, statement, vars...)
+	_, err := access.executor().ExecContext(ctx, statement, vars...)
 	return errors.WithStack(err)
 }
 
@@ -639,7 +767,7 @@ func (access *SqliteAccess) executeStatement(ctx context.Context, statement stri
 // The `id` is passed to the SQL query to fill in an SQL wildcard
 // The `vars` are filled with the values of the return of the SELECT statement, so the `vars` need to be pointers.
 func (access *SqliteAccess) querySimpleStatement(ctx context.Context, statement, id string, vars ...any) error {
-	row := access.conn.QueryRowContext(ctx, statement, id)
+	row := access.executor().QueryRowContext(ctx, statement, id)
 	err := row.Scan(vars...)
 	if err != nil && err != sql.ErrNoRows {
 		return errors.WithStack(err)
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/arch"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -73,6 +74,7 @@ func TestCreateTables(t *testing.T) {
 			"Version",
 			"TenantUUID",
 			"MountAttempts",
+			"Arch",
 		}
 
 		for _, column := range columns {
@@ -90,6 +92,10 @@ func TestCreateTables(t *testing.T) {
 				assert.Equal(t, "0", *defaultValue)
 				assert.Equal(t, "1", notNull)
 			}
+			if column == "Arch" {
+				assert.Equal(t, "''", *defaultValue)
+				assert.Equal(t, "1", notNull)
+			}
 		}
 	})
 	t.Run("dynakube table is created correctly", func(t *testing.T) {
@@ -114,6 +120,7 @@ func TestCreateTables(t *testing.T) {
 			"LatestVersion",
 			"ImageDigest",
 			"MaxFailedMountAttempts",
+			"Arch",
 		}
 
 		for _, column := range columns {
@@ -140,6 +147,7 @@ func TestCreateTables(t *testing.T) {
 
 func TestInsertDynakube(t *testing.T) {
 	testDynakube1 := createTestDynakube(1)
+	testDynakube1.Arch = arch.ArchARM
 
 	db := FakeMemoryDB()
 
@@ -149,14 +157,16 @@ func TestInsertDynakube(t *testing.T) {
 	var uuid, lv, name string
 	var imageDigest string
 	var maxMountAttempts int
+	var dynakubeArch string
 	row := db.conn.QueryRow(fmt.Sprintf("SELECT * FROM %s WHERE TenantUUID = ?;", dynakubesTableName), testDynakube1.TenantUUID)
-	err = row.Scan(&name, &uuid, &lv, &imageDigest, &maxMountAttempts)
+	err = row.Scan(&name, &uuid, &lv, &imageDigest, &maxMountAttempts, &dynakubeArch)
 	require.NoError(t, err)
 	assert.Equal(t, testDynakube1.TenantUUID, uuid)
 	assert.Equal(t, testDynakube1.LatestVersion, lv)
 	assert.Equal(t, testDynakube1.Name, name)
 	assert.Equal(t, testDynakube1.ImageDigest, imageDigest)
 	assert.Equal(t, testDynakube1.MaxFailedMountAttempts, maxMountAttempts)
+	assert.Equal(t, testDynakube1.Arch, dynakubeArch)
 }
 
 func TestGetDynakube_Empty(t *testing.T) {
@@ -326,6 +336,7 @@ func TestGetVolume_Empty(t *testing.T) {
 func TestInsertVolume(t *testing.T) {
 	ctx := context.TODO()
 	testVolume1 := createTestVolume(1)
+	testVolume1.Arch = arch.ArchARM
 	db := FakeMemoryDB()
 
 	err := db.InsertVolume(ctx, &testVolume1)
@@ -336,7 +347,8 @@ func TestInsertVolume(t *testing.T) {
 	var ver string
 	var tuid string
 	var mountAttempts int
-	err = row.Scan(&id, &puid, &ver, &tuid, &mountAttempts)
+	var volumeArch string
+	err = row.Scan(&id, &puid, &ver, &tuid, &mountAttempts, &volumeArch)
 
 	require.NoError(t, err)
 	assert.Equal(t, testVolume1.VolumeID, id)
@@ -344,13 +356,14 @@ func TestInsertVolume(t *testing.T) {
 	assert.Equal(t, testVolume1.Version, ver)
 	assert.Equal(t, testVolume1.TenantUUID, tuid)
 	assert.Equal(t, testVolume1.MountAttempts, mountAttempts)
+	assert.Equal(t, testVolume1.Arch, volumeArch)
 
 	newPodName := "something-else"
 	testVolume1.PodName = newPodName
 	err = db.InsertVolume(ctx, &testVolume1)
 	require.NoError(t, err)
 	row = db.conn.QueryRow(fmt.Sprintf("SELECT * FROM %s WHERE ID = ?;", volumesTableName), testVolume1.VolumeID)
-	err = row.Scan(&id, &puid, &ver, &tuid, &mountAttempts)
+	err = row.Scan(&id, &puid, &ver, &tuid, &mountAttempts, &volumeArch)
 
 	require.NoError(t, err)
 	assert.Equal(t, testVolume1.VolumeID, id)
@@ -562,6 +575,36 @@ func TestGetUsedImageDigests(t *testing.T) {
 	assert.True(t, digests[testDynakube2.ImageDigest])
 }
 
+func TestGetUsedAgentBins(t *testing.T) {
+	ctx := context.TODO()
+	db := FakeMemoryDB()
+
+	testDynakube1 := createTestDynakube(1)
+	testDynakube1.Arch = arch.ArchARM
+	err := db.InsertDynakube(ctx, &testDynakube1)
+	require.NoError(t, err)
+
+	testDynakube2 := createTestDynakube(2)
+	testDynakube2.ImageDigest = ""
+	err = db.InsertDynakube(ctx, &testDynakube2)
+	require.NoError(t, err)
+
+	testVolume1 := createTestVolume(1)
+	testVolume1.Version = "mounted"
+	testVolume1.Arch = arch.ArchARM
+	err = db.InsertVolume(ctx, &testVolume1)
+	require.NoError(t, err)
+
+	agentBins, err := db.GetUsedAgentBins(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, map[AgentBin]bool{
+		{Name: testDynakube1.LatestVersion, Arch: arch.ArchARM}: true,
+		{Name: testDynakube1.ImageDigest, Arch: arch.ArchARM}:   true,
+		{Name: testDynakube2.LatestVersion}:                     true,
+		{Name: "mounted", Arch: arch.ArchARM}:                   true,
+	}, agentBins)
+}
+
 func TestIsImageDigestUsed(t *testing.T) {
 	ctx := context.TODO()
 	db := FakeMemoryDB()
@@ -615,3 +658,128 @@ func TestDeleteVolume(t *testing.T) {
 	assert.Equal(t, len(podNames), 1)
 	assert.Equal(t, testVolume1.VolumeID, podNames[testVolume1.PodName])
 }
+
+func TestWithTx(t *testing.T) {
+	ctx := context.TODO()
+
+	t.Run("commits if the function succeeds", func(t *testing.T) {
+		db := FakeMemoryDB()
+		testVolume := createTestVolume(1)
+
+		err := db.WithTx(ctx, func(access Access) error {
+			return access.InsertVolume(ctx, &testVolume)
+		})
+		require.NoError(t, err)
+
+		volume, err := db.GetVolume(ctx, testVolume.VolumeID)
+		require.NoError(t, err)
+		assert.Equal(t, testVolume, *volume)
+	})
+	t.Run("rolls back if the function fails", func(t *testing.T) {
+		db := FakeMemoryDB()
+		testVolume := createTestVolume(1)
+
+		err := db.WithTx(ctx, func(access Access) error {
+			require.NoError(t, access.InsertVolume(ctx, &testVolume))
+			return fmt.Errorf("failed")
+		})
+		require.Error(t, err)
+
+		volume, err := db.GetVolume(ctx, testVolume.VolumeID)
+		require.NoError(t, err)
+		assert.Nil(t, volume)
+	})
+	t.Run("nested calls join the transaction", func(t *testing.T) {
+		db := FakeMemoryDB()
+		testVolume1 := createTestVolume(1)
+		testVolume2 := createTestVolume(2)
+
+		err := db.WithTx(ctx, func(access Access) error {
+			require.NoError(t, access.InsertVolume(ctx, &testVolume1))
+
+			return access.WithTx(ctx, func(access Access) error {
+				require.NoError(t, access.InsertVolume(ctx, &testVolume2))
+				return fmt.Errorf("failed")
+			})
+		})
+		require.Error(t, err)
+
+		volumes, err := db.GetAllVolumes(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, volumes)
+	})
+}
+
+func TestVolumeIntents(t *testing.T) {
+	ctx := context.TODO()
+	db := FakeMemoryDB()
+
+	intent := NewVolumeIntent(VolumeOperationPublish, "vol-1", "tenant-1", "/target")
+	require.NotNil(t, intent)
+	require.NoError(t, db.InsertVolumeIntent(ctx, intent))
+
+	intents, err := db.GetAllVolumeIntents(ctx)
+	require.NoError(t, err)
+	require.Len(t, intents, 1)
+	assert.Equal(t, VolumeOperationPublish, intents[0].Operation)
+	assert.Equal(t, "tenant-1", intents[0].TenantUUID)
+	assert.Equal(t, "/target", intents[0].TargetPath)
+	assert.WithinDuration(t, *intent.CreatedAt, *intents[0].CreatedAt, time.Second)
+
+	// the newer intent replaces the older one
+	require.NoError(t, db.InsertVolumeIntent(ctx, NewVolumeIntent(VolumeOperationUnpublish, "vol-1", "tenant-1", "/target")))
+
+	intents, err = db.GetAllVolumeIntents(ctx)
+	require.NoError(t, err)
+	require.Len(t, intents, 1)
+	assert.Equal(t, VolumeOperationUnpublish, intents[0].Operation)
+
+	require.NoError(t, db.DeleteVolumeIntent(ctx, "vol-1"))
+
+	intents, err = db.GetAllVolumeIntents(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, intents)
+
+	require.Error(t, db.InsertVolumeIntent(ctx, NewVolumeIntent(VolumeOperationPublish, "vol-1", "", "/target")))
+}
+
+func TestCodeModuleBlobs(t *testing.T) {
+	ctx := context.TODO()
+	db := FakeMemoryDB()
+
+	codeModule1 := AgentBin{Name: "1.2.3", Arch: "x86"}
+	codeModule2 := AgentBin{Name: "sha256-digest", Arch: "x86"}
+	shared := &Blob{ID: "shared", Size: 10}
+	only1 := &Blob{ID: "only-1", Size: 20}
+
+	require.NoError(t, db.InsertCodeModuleBlobs(ctx, codeModule1, []*Blob{shared, only1}))
+	require.NoError(t, db.InsertCodeModuleBlobs(ctx, codeModule2, []*Blob{shared}))
+	// a code module references a blob only once
+	require.NoError(t, db.InsertCodeModuleBlobs(ctx, codeModule2, []*Blob{shared}))
+
+	unreferenced, err := db.GetUnreferencedBlobs(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, unreferenced)
+
+	require.NoError(t, db.DeleteCodeModuleBlobs(ctx, codeModule1))
+
+	unreferenced, err = db.GetUnreferencedBlobs(ctx)
+	require.NoError(t, err)
+	require.Len(t, unreferenced, 1)
+	assert.Equal(t, Blob{ID: "only-1", Size: 20, References: 0}, *unreferenced[0])
+
+	// a referenced blob isn't deleted
+	require.NoError(t, db.DeleteBlob(ctx, "shared"))
+	require.NoError(t, db.DeleteBlob(ctx, "only-1"))
+
+	unreferenced, err = db.GetUnreferencedBlobs(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, unreferenced)
+
+	require.NoError(t, db.DeleteCodeModuleBlobs(ctx, codeModule2))
+
+	unreferenced, err = db.GetUnreferencedBlobs(ctx)
+	require.NoError(t, err)
+	require.Len(t, unreferenced, 1)
+	assert.Equal(t, "shared", unreferenced[0].ID)
+}
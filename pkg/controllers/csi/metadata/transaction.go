@@ -0,0 +1,46 @@
+package metadata
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pkg/errors"
+)
+
+// querier is implemented by the connection pool and by a single connection that runs a transaction
+type querier interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// runImmediateTransaction runs fn on a single connection in a transaction, which is committed if fn returns nil.
+// An immediate transaction takes the write lock right away, instead of upgrading a read lock later,
+// which would fail right away if another process holds the write lock in the meantime.
+// The csi pods on a node share the database, so this way they wait for each other.
+func runImmediateTransaction(ctx context.Context, db *sql.DB, fn func(conn *sql.Conn) error) error {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "BEGIN IMMEDIATE;"); err != nil {
+		return errors.WithMessage(err, "failed to begin transaction")
+	}
+
+	if err := fn(conn); err != nil {
+		if _, rollbackErr := conn.ExecContext(context.WithoutCancel(ctx), "ROLLBACK;"); rollbackErr != nil {
+			log.Error(rollbackErr, "failed to roll back transaction")
+		}
+
+		return err
+	}
+
+	if _, err := conn.ExecContext(ctx, "COMMIT;"); err != nil {
+		_, _ = conn.ExecContext(context.WithoutCancel(ctx), "ROLLBACK;")
+		return errors.WithMessage(err, "failed to commit transaction")
+	}
+
+	return nil
+}
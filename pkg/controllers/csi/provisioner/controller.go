@@ -28,6 +28,7 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/csi/metadata"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/connectioninfo"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/dynatraceclient"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/dynatraceevents"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/token"
 	"github.com/Dynatrace/dynatrace-operator/pkg/injection/codemodule/installer"
 	"github.com/Dynatrace/dynatrace-operator/pkg/injection/codemodule/installer/image"
@@ -64,6 +65,9 @@ type OneAgentProvisioner struct {
 	path      metadata.PathResolver
 	gc        reconcile.Reconciler
 
+	// nodeArch is resolved once, as the arch of a node doesn't change
+	nodeArch string
+
 	dynatraceClientBuilder dynatraceclient.Builder
 	urlInstallerBuilder    urlInstallerBuilder
 	imageInstallerBuilder  imageInstallerBuilder
@@ -79,7 +83,7 @@ func NewOneAgentProvisioner(mgr manager.Manager, opts dtcsi.CSIOptions, db metad
 		recorder:               mgr.GetEventRecorderFor("OneAgentProvisioner"),
 		db:                     db,
 		path:                   metadata.PathResolver{RootDir: opts.RootDir},
-		gc:                     csigc.NewCSIGarbageCollector(mgr.GetAPIReader(), opts, db),
+		gc:                     csigc.NewCSIGarbageCollector(mgr.GetAPIReader(), mgr.GetEventRecorderFor("CSIGarbageCollector"), opts, db),
 		dynatraceClientBuilder: dynatraceclient.NewBuilder(mgr.GetAPIReader()),
 		urlInstallerBuilder:    url.NewUrlInstaller,
 		imageInstallerBuilder:  image.NewImageInstaller,
@@ -201,7 +205,7 @@ func (provisioner *OneAgentProvisioner) updateAgentInstallation(ctx context.Cont
 	requeue bool,
 	err error,
 ) {
-	latestProcessModuleConfig, _, err := provisioner.getProcessModuleConfig(dtc, dynakubeMetadata.TenantUUID)
+	latestProcessModuleConfig, _, err := provisioner.getProcessModuleConfig(ctx, dtc, dynakubeMetadata.TenantUUID)
 	if err != nil {
 		log.Error(err, "error when getting the latest ruxitagentproc.conf")
 		return nil, false, err
@@ -228,24 +232,28 @@ func (provisioner *OneAgentProvisioner) updateAgentInstallation(ctx context.Cont
 	}
 
 	if dk.CodeModulesImage() != "" {
-		updatedDigest, err := provisioner.installAgentImage(*dk, latestProcessModuleConfigCache)
+		updatedDigest, err := provisioner.installAgentImage(ctx, *dk, latestProcessModuleConfigCache)
 		if err != nil {
 			log.Info("error when updating agent from image", "error", err.Error())
+			dynatraceevents.NewSender(dtc, dk).SendInstallFailure(ctx, dk.CodeModulesImage(), err)
 			// reporting error but not returning it to avoid immediate requeue and subsequently calling the API every few seconds
 			return nil, true, nil
 		} else if updatedDigest != "" {
 			dynakubeMetadata.LatestVersion = ""
 			dynakubeMetadata.ImageDigest = updatedDigest
+			dynakubeMetadata.Arch = provisioner.getNodeArch(ctx)
 		}
 	} else {
-		updateVersion, err := provisioner.installAgentZip(*dk, dtc, latestProcessModuleConfigCache)
+		updateVersion, err := provisioner.installAgentZip(ctx, *dk, dtc, latestProcessModuleConfigCache)
 		if err != nil {
 			log.Info("error when updating agent from zip", "error", err.Error())
+			dynatraceevents.NewSender(dtc, dk).SendInstallFailure(ctx, dk.CodeModulesVersion(), err)
 			// reporting error but not returning it to avoid immediate requeue and subsequently calling the API every few seconds
 			return nil, true, nil
 		} else if updateVersion != "" {
 			dynakubeMetadata.LatestVersion = updateVersion
 			dynakubeMetadata.ImageDigest = ""
+			dynakubeMetadata.Arch = provisioner.getNodeArch(ctx)
 		}
 	}
 	return latestProcessModuleConfigCache, false, nil
@@ -288,7 +296,8 @@ func (provisioner *OneAgentProvisioner) handleMetadata(ctx context.Context, dk *
 		tenantUUID,
 		oldDynakubeMetadata.LatestVersion,
 		oldDynakubeMetadata.ImageDigest,
-		dk.FeatureMaxFailedCsiMountAttempts())
+		dk.FeatureMaxFailedCsiMountAttempts(),
+		oldDynakubeMetadata.Arch)
 
 	return dynakubeMetadata, oldDynakubeMetadata, nil
 }
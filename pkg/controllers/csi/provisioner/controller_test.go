@@ -528,14 +528,14 @@ func buildValidApplicationMonitoringSpec(_ *testing.T) *dynatracev1beta1.Applica
 func TestProvisioner_CreateDynakube(t *testing.T) {
 	ctx := context.TODO()
 	db := metadata.FakeMemoryDB()
-	expectedOtherDynakube := metadata.NewDynakube(otherDkName, tenantUUID, "v1", "", 0)
+	expectedOtherDynakube := metadata.NewDynakube(otherDkName, tenantUUID, "v1", "", 0, "")
 	_ = db.InsertDynakube(ctx, expectedOtherDynakube)
 	provisioner := &OneAgentProvisioner{
 		db: db,
 	}
 
 	oldDynakube := metadata.Dynakube{}
-	newDynakube := metadata.NewDynakube(dkName, tenantUUID, "v1", "", 0)
+	newDynakube := metadata.NewDynakube(dkName, tenantUUID, "v1", "", 0, "")
 
 	err := provisioner.createOrUpdateDynakubeMetadata(ctx, oldDynakube, newDynakube)
 	require.NoError(t, err)
@@ -554,15 +554,15 @@ func TestProvisioner_CreateDynakube(t *testing.T) {
 func TestProvisioner_UpdateDynakube(t *testing.T) {
 	ctx := context.TODO()
 	db := metadata.FakeMemoryDB()
-	oldDynakube := metadata.NewDynakube(dkName, tenantUUID, "v1", "", 0)
+	oldDynakube := metadata.NewDynakube(dkName, tenantUUID, "v1", "", 0, "")
 	_ = db.InsertDynakube(ctx, oldDynakube)
-	expectedOtherDynakube := metadata.NewDynakube(otherDkName, tenantUUID, "v1", "", 0)
+	expectedOtherDynakube := metadata.NewDynakube(otherDkName, tenantUUID, "v1", "", 0, "")
 	_ = db.InsertDynakube(ctx, expectedOtherDynakube)
 
 	provisioner := &OneAgentProvisioner{
 		db: db,
 	}
-	newDynakube := metadata.NewDynakube(dkName, "new-uuid", "v2", "", 0)
+	newDynakube := metadata.NewDynakube(dkName, "new-uuid", "v2", "", 0, "")
 
 	err := provisioner.createOrUpdateDynakubeMetadata(ctx, *oldDynakube, newDynakube)
 	require.NoError(t, err)
@@ -1,17 +1,22 @@
 package csiprovisioner
 
 import (
+	"context"
+
 	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
 	"github.com/Dynatrace/dynatrace-operator/pkg/arch"
 	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/csi/blobstore"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/csi/metadata"
 	"github.com/Dynatrace/dynatrace-operator/pkg/injection/codemodule/installer"
 	"github.com/Dynatrace/dynatrace-operator/pkg/injection/codemodule/installer/image"
 	"github.com/Dynatrace/dynatrace-operator/pkg/injection/codemodule/installer/url"
 	"github.com/Dynatrace/dynatrace-operator/pkg/injection/codemodule/processmoduleconfig"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func (provisioner *OneAgentProvisioner) installAgentImage(dynakube dynatracev1beta1.DynaKube, latestProcessModuleConfigCache *processModuleConfigCache) (string, error) {
+func (provisioner *OneAgentProvisioner) installAgentImage(ctx context.Context, dynakube dynatracev1beta1.DynaKube, latestProcessModuleConfigCache *processModuleConfigCache) (string, error) {
 	tenantUUID, err := dynakube.TenantUUIDFromApiUrl()
 	if err != nil {
 		return "", err
@@ -27,6 +32,7 @@ func (provisioner *OneAgentProvisioner) installAgentImage(dynakube dynatracev1be
 		return "", err
 	}
 
+	nodeArch := provisioner.getNodeArch(ctx)
 	imageInstaller, err := provisioner.imageInstallerBuilder(provisioner.fs, &image.Properties{
 		ImageUri:     targetImage,
 		ApiReader:    provisioner.apiReader,
@@ -34,14 +40,16 @@ func (provisioner *OneAgentProvisioner) installAgentImage(dynakube dynatracev1be
 		PathResolver: provisioner.path,
 		Metadata:     provisioner.db,
 		ImageDigest:  imageDigest,
+		Arch:         nodeArch,
 	})
 	if err != nil {
 		return "", err
 	}
 
-	targetDir := provisioner.path.AgentSharedBinaryDirForAgent(imageDigest)
+	agentBin := metadata.AgentBin{Name: imageDigest, Arch: nodeArch}
+	targetDir := provisioner.path.AgentSharedBinaryDirForAgentArch(agentBin.Name, agentBin.Arch)
 	targetConfigDir := provisioner.path.AgentConfigDir(tenantUUID)
-	err = provisioner.installAgent(imageInstaller, dynakube, targetDir, targetImage, tenantUUID)
+	err = provisioner.installAgent(ctx, imageInstaller, dynakube, agentBin, targetImage, tenantUUID)
 	if err != nil {
 		return "", err
 	}
@@ -53,17 +61,20 @@ func (provisioner *OneAgentProvisioner) installAgentImage(dynakube dynatracev1be
 	return imageDigest, err
 }
 
-func (provisioner *OneAgentProvisioner) installAgentZip(dynakube dynatracev1beta1.DynaKube, dtc dtclient.Client, latestProcessModuleConfigCache *processModuleConfigCache) (string, error) {
+func (provisioner *OneAgentProvisioner) installAgentZip(ctx context.Context, dynakube dynatracev1beta1.DynaKube, dtc dtclient.Client, latestProcessModuleConfigCache *processModuleConfigCache) (string, error) {
 	tenantUUID, err := dynakube.TenantUUIDFromApiUrl()
 	if err != nil {
 		return "", err
 	}
 	targetVersion := dynakube.CodeModulesVersion()
-	urlInstaller := provisioner.urlInstallerBuilder(provisioner.fs, dtc, getUrlProperties(targetVersion, provisioner.path))
+	nodeArch := provisioner.getNodeArch(ctx)
+	// the csi driver only runs on linux nodes
+	urlInstaller := provisioner.urlInstallerBuilder(provisioner.fs, dtc, getUrlProperties(targetVersion, dtclient.OsUnix, nodeArch, provisioner.path))
 
-	targetDir := provisioner.path.AgentSharedBinaryDirForAgent(targetVersion)
+	agentBin := metadata.AgentBin{Name: targetVersion, Arch: nodeArch}
+	targetDir := provisioner.path.AgentSharedBinaryDirForAgentArch(agentBin.Name, agentBin.Arch)
 	targetConfigDir := provisioner.path.AgentConfigDir(tenantUUID)
-	err = provisioner.installAgent(urlInstaller, dynakube, targetDir, targetVersion, tenantUUID)
+	err = provisioner.installAgent(ctx, urlInstaller, dynakube, agentBin, targetVersion, tenantUUID)
 	if err != nil {
 		return "", err
 	}
@@ -75,28 +86,68 @@ func (provisioner *OneAgentProvisioner) installAgentZip(dynakube dynatracev1beta
 	return targetVersion, nil
 }
 
-func (provisioner *OneAgentProvisioner) installAgent(agentInstaller installer.Installer, dynakube dynatracev1beta1.DynaKube, targetDir, targetVersion, tenantUUID string) error {
+func (provisioner *OneAgentProvisioner) installAgent(ctx context.Context, agentInstaller installer.Installer, dynakube dynatracev1beta1.DynaKube, agentBin metadata.AgentBin, targetVersion, tenantUUID string) error {
 	eventRecorder := updaterEventRecorder{
 		recorder: provisioner.recorder,
 		dynakube: &dynakube,
 	}
-	isNewlyInstalled, err := agentInstaller.InstallAgent(targetDir)
+	targetDir := provisioner.path.AgentSharedBinaryDirForAgentArch(agentBin.Name, agentBin.Arch)
+	isNewlyInstalled, err := agentInstaller.InstallAgent(ctx, targetDir)
 	if err != nil {
 		eventRecorder.sendFailedInstallAgentVersionEvent(targetVersion, tenantUUID)
 		return err
 	}
 	if isNewlyInstalled {
 		eventRecorder.sendInstalledAgentVersionEvent(targetVersion, tenantUUID)
+		provisioner.deduplicateAgent(ctx, agentBin, targetDir)
 	}
 	return nil
 }
 
-func getUrlProperties(targetVersion string, pathResolver metadata.PathResolver) *url.Properties {
+// deduplicateAgent shares the files of newly installed code modules with the ones installed before,
+// the code modules work without it, so a failure is only logged
+func (provisioner *OneAgentProvisioner) deduplicateAgent(ctx context.Context, agentBin metadata.AgentBin, targetDir string) {
+	store := blobstore.NewStore(provisioner.fs, provisioner.db, provisioner.path)
+	if err := store.Deduplicate(ctx, agentBin, targetDir); err != nil {
+		log.Info("failed to deduplicate the code modules", "targetDir", targetDir, "error", err.Error())
+	}
+}
+
+// getNodeArch resolves the arch of the node the provisioner runs on from its kubernetes.io/arch label,
+// so the installed binaries match the node even if the provisioner itself runs emulated.
+// If the node can't be read, the arch the provisioner was built for is used.
+func (provisioner *OneAgentProvisioner) getNodeArch(ctx context.Context) string {
+	if provisioner.nodeArch != "" {
+		return provisioner.nodeArch
+	}
+
+	if provisioner.opts.NodeId == "" {
+		return arch.Arch
+	}
+
+	var node corev1.Node
+	err := provisioner.apiReader.Get(ctx, client.ObjectKey{Name: provisioner.opts.NodeId}, &node)
+	if err != nil {
+		log.Info("failed to get node, using the arch of the provisioner", "node", provisioner.opts.NodeId, "error", err.Error())
+		return arch.Arch
+	}
+
+	nodeArch, ok := arch.ArchFromKubernetes(node.Labels[corev1.LabelArchStable])
+	if !ok {
+		log.Info("unsupported node arch, using the arch of the provisioner", "node", provisioner.opts.NodeId, "arch", node.Labels[corev1.LabelArchStable])
+		return arch.Arch
+	}
+
+	provisioner.nodeArch = nodeArch
+	return nodeArch
+}
+
+func getUrlProperties(targetVersion, os, agentArch string, pathResolver metadata.PathResolver) *url.Properties {
 	return &url.Properties{
-		Os:            dtclient.OsUnix,
+		Os:            os,
 		Type:          dtclient.InstallerTypePaaS,
-		Arch:          arch.Arch,
-		Flavor:        arch.Flavor,
+		Arch:          agentArch,
+		Flavor:        arch.DefaultFlavor(os, agentArch),
 		Technologies:  []string{"all"},
 		TargetVersion: targetVersion,
 		SkipMetadata:  true,
@@ -1,15 +1,21 @@
 package csiprovisioner
 
 import (
+	"context"
+
 	"fmt"
+	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/status"
 	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/arch"
 	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/csi/metadata"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/connectioninfo"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/dynatraceevents"
 	"github.com/Dynatrace/dynatrace-operator/pkg/injection/codemodule/installer"
 	"github.com/Dynatrace/dynatrace-operator/pkg/injection/codemodule/installer/image"
 	"github.com/Dynatrace/dynatrace-operator/pkg/injection/codemodule/installer/url"
@@ -33,16 +39,16 @@ func TestUpdateAgent(t *testing.T) {
 	t.Run("zip install", func(t *testing.T) {
 		dk := createTestDynaKubeWithZip(testVersion)
 		provisioner := createTestProvisioner()
-		targetDir := provisioner.path.AgentSharedBinaryDirForAgent(dk.CodeModulesVersion())
+		targetDir := provisioner.path.AgentSharedBinaryDirForAgentArch(dk.CodeModulesVersion(), arch.Arch)
 		var revision uint = 3
 		processModuleCache := createTestProcessModuleConfigCache(revision)
 		installerMock := mockedinstaller.NewInstaller(t)
 		installerMock.
-			On("InstallAgent", targetDir).
+			On("InstallAgent", mock.Anything, targetDir).
 			Return(true, nil).Run(mockFsAfterInstall(provisioner, testVersion))
 		provisioner.urlInstallerBuilder = mockUrlInstallerBuilder(installerMock)
 
-		currentVersion, err := provisioner.installAgentZip(dk, mockedclient.NewClient(t), &processModuleCache)
+		currentVersion, err := provisioner.installAgentZip(context.Background(), dk, mockedclient.NewClient(t), &processModuleCache)
 		require.NoError(t, err)
 		assert.Equal(t, testVersion, currentVersion)
 		t_utils.AssertEvents(t,
@@ -55,34 +61,65 @@ func TestUpdateAgent(t *testing.T) {
 			},
 		)
 	})
+	t.Run("zip and image install share their files", func(t *testing.T) {
+		dockerconfigjsonContent := `{"auths":{}}`
+		imageDk := createTestDynaKubeWithImage(testImageDigest)
+		zipDk := createTestDynaKubeWithZip(testVersion)
+		provisioner := createTestProvisioner(createMockedPullSecret(imageDk, dockerconfigjsonContent))
+		provisioner.fs = afero.NewOsFs()
+		provisioner.path = metadata.PathResolver{RootDir: t.TempDir()}
+		processModuleCache := createTestProcessModuleConfigCache(3)
+
+		zipInstallerMock := mockedinstaller.NewInstaller(t)
+		zipInstallerMock.
+			On("InstallAgent", mock.Anything, mock.Anything).
+			Return(true, nil).Run(mockFsAfterInstall(provisioner, testVersion))
+		provisioner.urlInstallerBuilder = mockUrlInstallerBuilder(zipInstallerMock)
+		imageInstallerMock := mockedinstaller.NewInstaller(t)
+		imageInstallerMock.
+			On("InstallAgent", mock.Anything, mock.Anything).
+			Return(true, nil).Run(mockFsAfterInstall(provisioner, testImageDigest))
+		provisioner.imageInstallerBuilder = mockImageInstallerBuilder(imageInstallerMock)
+
+		_, err := provisioner.installAgentZip(context.Background(), zipDk, mockedclient.NewClient(t), &processModuleCache)
+		require.NoError(t, err)
+		_, err = provisioner.installAgentImage(context.Background(), imageDk, &processModuleCache)
+		require.NoError(t, err)
+
+		zipInfo, err := provisioner.fs.Stat(filepath.Join(provisioner.path.AgentSharedBinaryDirForAgentArch(testVersion, arch.Arch), processmoduleconfig.RuxitAgentProcPath))
+		require.NoError(t, err)
+		imageInfo, err := provisioner.fs.Stat(filepath.Join(provisioner.path.AgentSharedBinaryDirForAgentArch(testImageDigest, arch.Arch), processmoduleconfig.RuxitAgentProcPath))
+		require.NoError(t, err)
+		assert.True(t, os.SameFile(zipInfo, imageInfo))
+	})
 	t.Run("zip update", func(t *testing.T) {
 		dk := createTestDynaKubeWithZip(testVersion)
 		provisioner := createTestProvisioner()
-		previousTargetDir := provisioner.path.AgentSharedBinaryDirForAgent(dk.CodeModulesVersion())
+		previousTargetDir := provisioner.path.AgentSharedBinaryDirForAgentArch(dk.CodeModulesVersion(), arch.Arch)
 		previousSourceConfigPath := filepath.Join(previousTargetDir, processmoduleconfig.RuxitAgentProcPath)
 		_ = provisioner.fs.MkdirAll(previousTargetDir, 0755)
 		_, _ = provisioner.fs.Create(previousSourceConfigPath)
 
 		newVersion := "new"
 		dk.Status.CodeModules.Version = newVersion
-		newTargetDir := provisioner.path.AgentSharedBinaryDirForAgent(dk.CodeModulesVersion())
+		newTargetDir := provisioner.path.AgentSharedBinaryDirForAgentArch(dk.CodeModulesVersion(), arch.Arch)
 
 		var revision uint = 3
 		processModuleCache := createTestProcessModuleConfigCache(revision)
 		installerMock := mockedinstaller.NewInstaller(t)
 		installerMock.
-			On("InstallAgent", newTargetDir).
+			On("InstallAgent", mock.Anything, newTargetDir).
 			Return(true, nil).Run(mockFsAfterInstall(provisioner, newVersion))
 		provisioner.urlInstallerBuilder = mockUrlInstallerBuilder(installerMock)
 
-		currentVersion, err := provisioner.installAgentZip(dk, mockedclient.NewClient(t), &processModuleCache)
+		currentVersion, err := provisioner.installAgentZip(context.Background(), dk, mockedclient.NewClient(t), &processModuleCache)
 		require.NoError(t, err)
 		assert.Equal(t, newVersion, currentVersion)
 	})
 	t.Run("only process module config update", func(t *testing.T) {
 		dk := createTestDynaKubeWithZip(testVersion)
 		provisioner := createTestProvisioner()
-		targetDir := provisioner.path.AgentSharedBinaryDirForAgent(dk.CodeModulesVersion())
+		targetDir := provisioner.path.AgentSharedBinaryDirForAgentArch(dk.CodeModulesVersion(), arch.Arch)
 		sourceConfigPath := filepath.Join(targetDir, processmoduleconfig.RuxitAgentProcPath)
 		_ = provisioner.fs.MkdirAll(targetDir, 0755)
 		_, _ = provisioner.fs.Create(sourceConfigPath)
@@ -90,11 +127,11 @@ func TestUpdateAgent(t *testing.T) {
 		processModuleCache := createTestProcessModuleConfigCache(revision)
 		installerMock := mockedinstaller.NewInstaller(t)
 		installerMock.
-			On("InstallAgent", targetDir).
+			On("InstallAgent", mock.Anything, targetDir).
 			Return(false, nil)
 
 		provisioner.urlInstallerBuilder = mockUrlInstallerBuilder(installerMock)
-		currentVersion, err := provisioner.installAgentZip(dk, mockedclient.NewClient(t), &processModuleCache)
+		currentVersion, err := provisioner.installAgentZip(context.Background(), dk, mockedclient.NewClient(t), &processModuleCache)
 
 		require.NoError(t, err)
 		assert.Equal(t, testVersion, currentVersion)
@@ -105,14 +142,14 @@ func TestUpdateAgent(t *testing.T) {
 		provisioner := createTestProvisioner(createMockedPullSecret(dk, dockerconfigjsonContent))
 		var revision uint = 3
 		processModuleCache := createTestProcessModuleConfigCache(revision)
-		targetDir := provisioner.path.AgentSharedBinaryDirForAgent(testImageDigest)
+		targetDir := provisioner.path.AgentSharedBinaryDirForAgentArch(testImageDigest, arch.Arch)
 		installerMock := mockedinstaller.NewInstaller(t)
 		installerMock.
-			On("InstallAgent", targetDir).
+			On("InstallAgent", mock.Anything, targetDir).
 			Return(false, fmt.Errorf("BOOM"))
 		provisioner.imageInstallerBuilder = mockImageInstallerBuilder(installerMock)
 
-		currentVersion, err := provisioner.installAgentImage(dk, &processModuleCache)
+		currentVersion, err := provisioner.installAgentImage(context.Background(), dk, &processModuleCache)
 
 		require.Error(t, err)
 		assert.Equal(t, "", currentVersion)
@@ -133,14 +170,14 @@ func TestUpdateAgent(t *testing.T) {
 
 		dk := createTestDynaKubeWithImage(testImageDigest)
 		provisioner := createTestProvisioner(createMockedPullSecret(dk, dockerconfigjsonContent))
-		targetDir := provisioner.path.AgentSharedBinaryDirForAgent(testImageDigest)
+		targetDir := provisioner.path.AgentSharedBinaryDirForAgentArch(testImageDigest, arch.Arch)
 		installerMock := mockedinstaller.NewInstaller(t)
 		installerMock.
-			On("InstallAgent", targetDir).
+			On("InstallAgent", mock.Anything, targetDir).
 			Return(true, nil).Run(mockFsAfterInstall(provisioner, testImageDigest))
 		provisioner.imageInstallerBuilder = mockImageInstallerBuilder(installerMock)
 
-		currentVersion, err := provisioner.installAgentImage(dk, &processModuleCache)
+		currentVersion, err := provisioner.installAgentImage(context.Background(), dk, &processModuleCache)
 		require.NoError(t, err)
 		assert.Equal(t, testImageDigest, currentVersion)
 	})
@@ -154,14 +191,14 @@ func TestUpdateAgent(t *testing.T) {
 		dk.Spec.CustomPullSecret = pullSecretName
 
 		provisioner := createTestProvisioner(createMockedPullSecret(dk, dockerconfigjsonContent))
-		targetDir := provisioner.path.AgentSharedBinaryDirForAgent(testImageDigest)
+		targetDir := provisioner.path.AgentSharedBinaryDirForAgentArch(testImageDigest, arch.Arch)
 		installerMock := mockedinstaller.NewInstaller(t)
 		installerMock.
-			On("InstallAgent", targetDir).
+			On("InstallAgent", mock.Anything, targetDir).
 			Return(true, nil).Run(mockFsAfterInstall(provisioner, testImageDigest))
 		provisioner.imageInstallerBuilder = mockImageInstallerBuilder(installerMock)
 
-		currentVersion, err := provisioner.installAgentImage(dk, &processModuleCache)
+		currentVersion, err := provisioner.installAgentImage(context.Background(), dk, &processModuleCache)
 		require.NoError(t, err)
 		assert.Equal(t, testImageDigest, currentVersion)
 	})
@@ -178,14 +215,14 @@ func TestUpdateAgent(t *testing.T) {
 		dk.Spec.TrustedCAs = trustedCAName
 
 		provisioner := createTestProvisioner(createMockedPullSecret(dk, dockerconfigjsonContent), createMockedCAConfigMap(dk, customCertContent))
-		targetDir := provisioner.path.AgentSharedBinaryDirForAgent(testImageDigest)
+		targetDir := provisioner.path.AgentSharedBinaryDirForAgentArch(testImageDigest, arch.Arch)
 		installerMock := mockedinstaller.NewInstaller(t)
 		installerMock.
-			On("InstallAgent", targetDir).
+			On("InstallAgent", mock.Anything, targetDir).
 			Return(true, nil).Run(mockFsAfterInstall(provisioner, testImageDigest))
 		provisioner.imageInstallerBuilder = mockImageInstallerBuilder(installerMock)
 
-		currentVersion, err := provisioner.installAgentImage(dk, &processModuleCache)
+		currentVersion, err := provisioner.installAgentImage(context.Background(), dk, &processModuleCache)
 		require.NoError(t, err)
 		assert.Equal(t, testImageDigest, currentVersion)
 	})
@@ -193,7 +230,7 @@ func TestUpdateAgent(t *testing.T) {
 
 func mockFsAfterInstall(provisioner *OneAgentProvisioner, version string) func(mock.Arguments) {
 	return func(mock.Arguments) {
-		targetDir := provisioner.path.AgentSharedBinaryDirForAgent(version)
+		targetDir := provisioner.path.AgentSharedBinaryDirForAgentArch(version, arch.Arch)
 		sourceConfigPath := filepath.Join(targetDir, processmoduleconfig.RuxitAgentProcPath)
 		_ = provisioner.fs.MkdirAll(targetDir, 0755)
 		_ = provisioner.fs.MkdirAll(filepath.Dir(sourceConfigPath), 0755)
@@ -264,6 +301,105 @@ func createTestDynaKubeWithZip(version string) dynatracev1beta1.DynaKube {
 	}
 }
 
+func TestGetUrlProperties(t *testing.T) {
+	path := metadata.PathResolver{RootDir: "test"}
+
+	props := getUrlProperties(agentVersion, dtclient.OsUnix, arch.ArchX86, path)
+	assert.Equal(t, dtclient.OsUnix, props.Os)
+	assert.Equal(t, dtclient.InstallerTypePaaS, props.Type)
+	assert.Equal(t, arch.ArchX86, props.Arch)
+	assert.Equal(t, arch.FlavorMultidistro, props.Flavor)
+	assert.Equal(t, agentVersion, props.TargetVersion)
+
+	props = getUrlProperties(agentVersion, dtclient.OsWindows, arch.ArchX86, path)
+	assert.Equal(t, dtclient.OsWindows, props.Os)
+	assert.Equal(t, arch.FlavorDefault, props.Flavor)
+}
+
+func TestUpdateAgentInstallationFailureEvent(t *testing.T) {
+	dk := createTestDynaKubeWithZip("1.2.3")
+	dk.Annotations = map[string]string{dynatracev1beta1.AnnotationFeatureInstallFailureEvents: "true"}
+	provisioner := createTestProvisioner(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: dk.OneagentTenantSecret(), Namespace: dk.Namespace},
+		Data:       map[string][]byte{connectioninfo.TenantTokenName: []byte("tenant-token")},
+	})
+	installerMock := mockedinstaller.NewInstaller(t)
+	installerMock.On("InstallAgent", mock.Anything, mock.Anything).Return(false, fmt.Errorf("BOOM"))
+	provisioner.urlInstallerBuilder = mockUrlInstallerBuilder(installerMock)
+
+	dtc := mockedclient.NewClient(t)
+	dtc.On("GetProcessModuleConfig", mock.Anything, uint(0)).Return(&dtclient.ProcessModuleConfig{}, nil)
+	dtc.On("IngestEvent", mock.Anything, mock.MatchedBy(func(event *dtclient.IngestEventData) bool {
+		return event.Properties[dynatraceevents.PropertyVersion] == "1.2.3" && event.Properties[dynatraceevents.PropertyError] == "BOOM"
+	})).Return(nil).Once()
+
+	_, requeue, err := provisioner.updateAgentInstallation(context.Background(), dtc, &metadata.Dynakube{TenantUUID: testTenantUUID}, &dk)
+	require.NoError(t, err)
+	assert.True(t, requeue)
+}
+
+func TestGetNodeArch(t *testing.T) {
+	const testNodeName = "test-node"
+	createTestNode := func(kubernetesArch string) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   testNodeName,
+				Labels: map[string]string{corev1.LabelArchStable: kubernetesArch},
+			},
+		}
+	}
+
+	t.Run("arch from node label", func(t *testing.T) {
+		provisioner := createTestProvisioner(createTestNode(arch.KubernetesArchARM64))
+		provisioner.opts.NodeId = testNodeName
+
+		assert.Equal(t, arch.ArchARM, provisioner.getNodeArch(context.Background()))
+		assert.Equal(t, arch.ArchARM, provisioner.nodeArch)
+	})
+	t.Run("arch of the provisioner, if node id is not set", func(t *testing.T) {
+		provisioner := createTestProvisioner(createTestNode(arch.KubernetesArchARM64))
+
+		assert.Equal(t, arch.Arch, provisioner.getNodeArch(context.Background()))
+	})
+	t.Run("arch of the provisioner, if node is missing", func(t *testing.T) {
+		provisioner := createTestProvisioner()
+		provisioner.opts.NodeId = testNodeName
+
+		assert.Equal(t, arch.Arch, provisioner.getNodeArch(context.Background()))
+		assert.Empty(t, provisioner.nodeArch)
+	})
+	t.Run("arch of the provisioner, if node arch is unsupported", func(t *testing.T) {
+		provisioner := createTestProvisioner(createTestNode("riscv64"))
+		provisioner.opts.NodeId = testNodeName
+
+		assert.Equal(t, arch.Arch, provisioner.getNodeArch(context.Background()))
+	})
+	t.Run("image installer and target dir use node arch", func(t *testing.T) {
+		testImageDigest := "7ece13a07a20c77a31cc36906a10ebc90bd47970905ee61e8ed491b7f4c5d62f"
+		dk := createTestDynaKubeWithImage(testImageDigest)
+		provisioner := createTestProvisioner(createMockedPullSecret(dk, `{"auths":{}}`), createTestNode(arch.KubernetesArchS390X))
+		provisioner.opts.NodeId = testNodeName
+		processModuleCache := createTestProcessModuleConfigCache(3)
+		targetDir := provisioner.path.AgentSharedBinaryDirForAgentArch(testImageDigest, arch.ArchS390)
+		installerMock := mockedinstaller.NewInstaller(t)
+		installerMock.
+			On("InstallAgent", mock.Anything, targetDir).
+			Return(true, nil).Run(func(mock.Arguments) {
+			_ = provisioner.fs.MkdirAll(filepath.Join(targetDir, filepath.Dir(processmoduleconfig.RuxitAgentProcPath)), 0755)
+			_, _ = provisioner.fs.Create(filepath.Join(targetDir, processmoduleconfig.RuxitAgentProcPath))
+		})
+		var imageProps *image.Properties
+		provisioner.imageInstallerBuilder = func(_ afero.Fs, props *image.Properties) (installer.Installer, error) {
+			imageProps = props
+			return installerMock, nil
+		}
+
+		_, err := provisioner.installAgentImage(context.Background(), dk, &processModuleCache)
+		require.NoError(t, err)
+		assert.Equal(t, arch.ArchS390, imageProps.Arch)
+	})
+}
+
 func createTestProvisioner(obj ...client.Object) *OneAgentProvisioner {
 	path := metadata.PathResolver{RootDir: "test"}
 	fs := afero.NewMemMapFs()
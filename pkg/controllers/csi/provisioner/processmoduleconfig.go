@@ -1,6 +1,7 @@
 package csiprovisioner
 
 import (
+	"context"
 	"encoding/json"
 	"io"
 	"os"
@@ -31,11 +32,11 @@ func newProcessModuleConfigCache(pmc *dtclient.ProcessModuleConfig) *processModu
 
 // getProcessModuleConfig gets the latest `RuxitProcResponse`, it can come from the tenant if we don't have the latest revision saved locally,
 // otherwise we use the locally cached response
-func (provisioner *OneAgentProvisioner) getProcessModuleConfig(dtc dtclient.Client, tenantUUID string) (*dtclient.ProcessModuleConfig, string, error) {
+func (provisioner *OneAgentProvisioner) getProcessModuleConfig(ctx context.Context, dtc dtclient.Client, tenantUUID string) (*dtclient.ProcessModuleConfig, string, error) {
 	var storedHash string
 	storedProcessModuleConfig, err := provisioner.readProcessModuleConfigCache(tenantUUID)
 	if os.IsNotExist(err) {
-		latestProcessModuleConfig, err := dtc.GetProcessModuleConfig(0)
+		latestProcessModuleConfig, err := dtc.GetProcessModuleConfig(ctx, 0)
 		if err != nil {
 			return nil, storedHash, err
 		}
@@ -44,7 +45,7 @@ func (provisioner *OneAgentProvisioner) getProcessModuleConfig(dtc dtclient.Clie
 		return nil, storedHash, err
 	}
 	storedHash = storedProcessModuleConfig.Hash
-	latestProcessModuleConfig, err := dtc.GetProcessModuleConfig(storedProcessModuleConfig.Revision)
+	latestProcessModuleConfig, err := dtc.GetProcessModuleConfig(ctx, storedProcessModuleConfig.Revision)
 	if err != nil {
 		return nil, storedHash, err
 	}
@@ -1,6 +1,8 @@
 package csiprovisioner
 
 import (
+	"context"
+
 	"encoding/json"
 	"os"
 	"strconv"
@@ -11,6 +13,7 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/test/mocks/pkg/clients/dynatrace"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -57,13 +60,13 @@ func TestGetProcessModuleConfig(t *testing.T) {
 		testProcessModuleConfig := createTestProcessModuleConfig(3)
 		memFs := afero.NewMemMapFs()
 		mockClient := mocks.NewClient(t)
-		mockClient.On("GetProcessModuleConfig", uint(0)).
+		mockClient.On("GetProcessModuleConfig", mock.Anything, uint(0)).
 			Return(testProcessModuleConfig, nil)
 		provisioner := &OneAgentProvisioner{
 			fs: memFs,
 		}
 
-		response, storedHash, err := provisioner.getProcessModuleConfig(mockClient, testTenantUUID)
+		response, storedHash, err := provisioner.getProcessModuleConfig(context.Background(), mockClient, testTenantUUID)
 
 		require.Nil(t, err)
 		assert.Equal(t, *testProcessModuleConfig, *response)
@@ -76,13 +79,13 @@ func TestGetProcessModuleConfig(t *testing.T) {
 		content, _ := json.Marshal(testProcessModuleConfigCache)
 		prepTestFsCache(memFs, content)
 		mockClient := mocks.NewClient(t)
-		mockClient.On("GetProcessModuleConfig", testProcessModuleConfigCache.Revision).
+		mockClient.On("GetProcessModuleConfig", mock.Anything, testProcessModuleConfigCache.Revision).
 			Return(emptyResponse, nil)
 		provisioner := &OneAgentProvisioner{
 			fs: memFs,
 		}
 
-		response, storedHash, err := provisioner.getProcessModuleConfig(mockClient, testTenantUUID)
+		response, storedHash, err := provisioner.getProcessModuleConfig(context.Background(), mockClient, testTenantUUID)
 
 		require.Nil(t, err)
 		assert.Equal(t, testProcessModuleConfigCache.ProcessModuleConfig, response)
@@ -97,13 +100,13 @@ func TestGetProcessModuleConfig(t *testing.T) {
 		content, _ := json.Marshal(testProcessModuleConfigCache)
 		prepTestFsCache(memFs, content)
 		mockClient := mocks.NewClient(t)
-		mockClient.On("GetProcessModuleConfig", testProcessModuleConfigCache.Revision).
+		mockClient.On("GetProcessModuleConfig", mock.Anything, testProcessModuleConfigCache.Revision).
 			Return(testProcessModuleConfig, nil)
 		provisioner := &OneAgentProvisioner{
 			fs: memFs,
 		}
 
-		response, storedHash, err := provisioner.getProcessModuleConfig(mockClient, testTenantUUID)
+		response, storedHash, err := provisioner.getProcessModuleConfig(context.Background(), mockClient, testTenantUUID)
 
 		require.Nil(t, err)
 		assert.Equal(t, *testProcessModuleConfig, *response)
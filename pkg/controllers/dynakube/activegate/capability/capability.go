@@ -36,13 +36,17 @@ type Capability interface {
 	ShortName() string
 	ArgName() string
 	Properties() *dynatracev1beta1.CapabilityProperties
+	Autoscaling() *dynatracev1beta1.ActiveGateAutoscalingSpec
+	PodDisruptionBudget() *dynatracev1beta1.ActiveGatePodDisruptionBudgetSpec
 }
 
 type capabilityBase struct {
-	enabled    bool
-	shortName  string
-	argName    string
-	properties *dynatracev1beta1.CapabilityProperties
+	enabled             bool
+	shortName           string
+	argName             string
+	properties          *dynatracev1beta1.CapabilityProperties
+	autoscaling         *dynatracev1beta1.ActiveGateAutoscalingSpec
+	podDisruptionBudget *dynatracev1beta1.ActiveGatePodDisruptionBudgetSpec
 }
 
 func (capability *capabilityBase) Enabled() bool {
@@ -53,6 +57,16 @@ func (capability *capabilityBase) Properties() *dynatracev1beta1.CapabilityPrope
 	return capability.properties
 }
 
+// Autoscaling is only available for capabilities configured in the ActiveGate section, nil otherwise
+func (capability *capabilityBase) Autoscaling() *dynatracev1beta1.ActiveGateAutoscalingSpec {
+	return capability.autoscaling
+}
+
+// PodDisruptionBudget is only available for capabilities configured in the ActiveGate section, nil otherwise
+func (capability *capabilityBase) PodDisruptionBudget() *dynatracev1beta1.ActiveGatePodDisruptionBudgetSpec {
+	return capability.podDisruptionBudget
+}
+
 func (capability *capabilityBase) ShortName() string {
 	return capability.shortName
 }
@@ -83,6 +97,17 @@ type SyntheticCapability struct {
 	capabilityBase
 }
 
+// PoolCapability is an additional group of ActiveGate pods, defined in the pools of the ActiveGate section
+type PoolCapability struct {
+	capabilityBase
+	poolName string
+}
+
+// PoolName returns the name of the pool as set in the ActiveGate section
+func (capability *PoolCapability) PoolName() string {
+	return capability.poolName
+}
+
 func NewMultiCapability(dk *dynatracev1beta1.DynaKube) *MultiCapability {
 	mc := MultiCapability{
 		capabilityBase{
@@ -94,6 +119,8 @@ func NewMultiCapability(dk *dynatracev1beta1.DynaKube) *MultiCapability {
 	}
 	mc.enabled = true
 	mc.properties = &dk.Spec.ActiveGate.CapabilityProperties
+	mc.autoscaling = dk.ActiveGateAutoscaling()
+	mc.podDisruptionBudget = dk.ActiveGatePodDisruptionBudget()
 	capabilityNames := []string{}
 	for _, capName := range dk.Spec.ActiveGate.Capabilities {
 		capabilityGenerator, ok := activeGateCapabilities[capName]
@@ -142,6 +169,8 @@ func NewSyntheticCapability(dk *dynatracev1beta1.DynaKube) *SyntheticCapability
 	}
 	capability.enabled = dk.IsSyntheticMonitoringEnabled()
 	capability.properties = &dk.Spec.ActiveGate.CapabilityProperties
+	capability.autoscaling = dk.ActiveGateAutoscaling()
+	capability.podDisruptionBudget = dk.ActiveGatePodDisruptionBudget()
 	if capability.enabled {
 		capability.properties.Replicas = address.Of(dk.FeatureSyntheticReplicas())
 		capability.properties.Resources = SyntheticActiveGateResourceRequirements
@@ -150,6 +179,20 @@ func NewSyntheticCapability(dk *dynatracev1beta1.DynaKube) *SyntheticCapability
 	return capability
 }
 
+func NewPoolCapability(dk *dynatracev1beta1.DynaKube, pool dynatracev1beta1.ActiveGatePoolSpec) *PoolCapability {
+	capability := &PoolCapability{
+		capabilityBase: capabilityBase{
+			enabled:   true,
+			shortName: dynatracev1beta1.ActiveGatePoolShortName(pool.Name),
+		},
+		poolName: pool.Name,
+	}
+	poolCapability := NewMultiCapability(dk.ForActiveGatePool(pool))
+	capability.argName = poolCapability.argName
+	capability.properties = &pool.CapabilityProperties
+	return capability
+}
+
 func kubeMonBase() *capabilityBase {
 	c := capabilityBase{
 		shortName: dynatracev1beta1.KubeMonCapability.ShortName,
@@ -199,6 +242,45 @@ func GenerateActiveGateCapabilities(dk *dynatracev1beta1.DynaKube) []Capability
 	}
 }
 
+// GenerateActiveGatePoolCapabilities returns a capability for each ActiveGate pool
+func GenerateActiveGatePoolCapabilities(dk *dynatracev1beta1.DynaKube) []Capability {
+	capabilities := []Capability{}
+	for _, pool := range dk.Spec.ActiveGate.Pools {
+		capabilities = append(capabilities, NewPoolCapability(dk, pool))
+	}
+	return capabilities
+}
+
+// DynaKubeFor returns the DynaKube the resources of the capability are rendered from, for a pool its ActiveGate section is replaced by the pool
+func DynaKubeFor(dk *dynatracev1beta1.DynaKube, capability Capability) *dynatracev1beta1.DynaKube {
+	poolCapability, ok := capability.(*PoolCapability)
+	if !ok {
+		return dk
+	}
+	for _, pool := range dk.Spec.ActiveGate.Pools {
+		if pool.Name == poolCapability.PoolName() {
+			return dk.ForActiveGatePool(pool)
+		}
+	}
+	return dk
+}
+
+// BuildTenantSecretName returns the name of the tenant secret used by the ActiveGates of the capability
+func BuildTenantSecretName(dk *dynatracev1beta1.DynaKube, capability Capability) string {
+	if poolCapability, ok := capability.(*PoolCapability); ok {
+		return dk.ActiveGatePoolTenantSecret(poolCapability.PoolName())
+	}
+	return dk.ActivegateTenantSecret()
+}
+
+// BuildCustomPropertiesOwnerName returns the name the custom properties secret of the capability is named after
+func BuildCustomPropertiesOwnerName(dk *dynatracev1beta1.DynaKube, capability Capability) string {
+	if _, ok := capability.(*PoolCapability); ok {
+		return capability.ShortName()
+	}
+	return dk.ActiveGateServiceAccountOwner()
+}
+
 func BuildProxySecretName(dynakubeName string) string {
 	return dynakubeName + "-" + consts.MultiActiveGateName + "-" + consts.ProxySecretSuffix
 }
@@ -75,3 +75,43 @@ func TestNewMultiCapability(t *testing.T) {
 		assert.Equal(t, "", mc.ArgName())
 	})
 }
+
+func TestNewPoolCapability(t *testing.T) {
+	pool := dynatracev1beta1.ActiveGatePoolSpec{
+		Name:         "zone-a",
+		Capabilities: []dynatracev1beta1.CapabilityDisplayName{dynatracev1beta1.RoutingCapability.DisplayName},
+		NetworkZone:  "zone-a",
+	}
+	dynakube := buildDynakube(capabilities)
+	dynakube.Spec.ActiveGate.Pools = []dynatracev1beta1.ActiveGatePoolSpec{pool}
+
+	t.Run(`creates new pool capability`, func(t *testing.T) {
+		poolCapability := NewPoolCapability(dynakube, pool)
+		require.NotNil(t, poolCapability)
+		assert.True(t, poolCapability.Enabled())
+		assert.Equal(t, "zone-a", poolCapability.PoolName())
+		assert.Equal(t, "activegate-zone-a", poolCapability.ShortName())
+		assert.Equal(t, "MSGrouter", poolCapability.ArgName())
+		assert.Nil(t, poolCapability.Autoscaling())
+		assert.Nil(t, poolCapability.PodDisruptionBudget())
+		assert.Equal(t, testName+"-activegate-zone-a", CalculateStatefulSetName(poolCapability, testName))
+	})
+	t.Run(`names and dynakube of the pool`, func(t *testing.T) {
+		poolCapability := NewPoolCapability(dynakube, pool)
+
+		assert.Equal(t, testName+"-activegate-zone-a-tenant-secret", BuildTenantSecretName(dynakube, poolCapability))
+		assert.Equal(t, "activegate-zone-a", BuildCustomPropertiesOwnerName(dynakube, poolCapability))
+
+		poolDynakube := DynaKubeFor(dynakube, poolCapability)
+		assert.Equal(t, pool.Capabilities, poolDynakube.Spec.ActiveGate.Capabilities)
+		assert.Equal(t, "zone-a", poolDynakube.Spec.NetworkZone)
+		assert.Empty(t, poolDynakube.Spec.ActiveGate.Pools)
+	})
+	t.Run(`names and dynakube of the multi capability are unchanged`, func(t *testing.T) {
+		multiCapability := NewMultiCapability(dynakube)
+
+		assert.Equal(t, dynakube.ActivegateTenantSecret(), BuildTenantSecretName(dynakube, multiCapability))
+		assert.Equal(t, dynakube.ActiveGateServiceAccountOwner(), BuildCustomPropertiesOwnerName(dynakube, multiCapability))
+		assert.Same(t, dynakube, DynaKubeFor(dynakube, multiCapability))
+	})
+}
@@ -0,0 +1,66 @@
+package activegate
+
+import (
+	"context"
+	"fmt"
+
+	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/capability"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// setRolloutCondition sets the ActiveGate condition depending on the readiness of the pods of all enabled capabilities
+func (r *Reconciler) setRolloutCondition(ctx context.Context) error {
+	var desiredReplicas, readyReplicas int32
+
+	capabilities := append(capability.GenerateActiveGateCapabilities(r.dynakube), capability.GenerateActiveGatePoolCapabilities(r.dynakube)...)
+	for _, agCapability := range capabilities {
+		if !agCapability.Enabled() {
+			continue
+		}
+
+		statefulSet := &appsv1.StatefulSet{}
+		name := capability.CalculateStatefulSetName(agCapability, r.dynakube.Name)
+		err := r.client.Get(ctx, types.NamespacedName{Name: name, Namespace: r.dynakube.Namespace}, statefulSet)
+		if k8serrors.IsNotFound(err) {
+			r.dynakube.SetCondition(dynatracev1beta1.ActiveGateConditionType, metav1.ConditionFalse,
+				dynatracev1beta1.ReasonRolloutInProgress, fmt.Sprintf("StatefulSet %s is not yet available", name))
+			return nil
+		} else if err != nil {
+			r.dynakube.SetConditionError(dynatracev1beta1.ActiveGateConditionType, err)
+			return errors.WithStack(err)
+		}
+
+		if statefulSet.Status.ObservedGeneration < statefulSet.Generation {
+			r.dynakube.SetCondition(dynatracev1beta1.ActiveGateConditionType, metav1.ConditionFalse,
+				dynatracev1beta1.ReasonRolloutInProgress, fmt.Sprintf("latest changes of StatefulSet %s are not yet observed", name))
+			return nil
+		}
+
+		var replicas int32 = 1
+		if statefulSet.Spec.Replicas != nil {
+			replicas = *statefulSet.Spec.Replicas
+		}
+		if statefulSet.Status.UpdatedReplicas < replicas {
+			r.dynakube.SetCondition(dynatracev1beta1.ActiveGateConditionType, metav1.ConditionFalse,
+				dynatracev1beta1.ReasonRolloutInProgress, fmt.Sprintf("%d of %d pods of StatefulSet %s are updated", statefulSet.Status.UpdatedReplicas, replicas, name))
+			return nil
+		}
+
+		desiredReplicas += replicas
+		readyReplicas += statefulSet.Status.ReadyReplicas
+	}
+
+	if readyReplicas < desiredReplicas {
+		r.dynakube.SetCondition(dynatracev1beta1.ActiveGateConditionType, metav1.ConditionFalse,
+			dynatracev1beta1.ReasonRolloutInProgress, fmt.Sprintf("%d of %d ActiveGate pods are ready", readyReplicas, desiredReplicas))
+		return nil
+	}
+
+	r.dynakube.SetConditionReconciled(dynatracev1beta1.ActiveGateConditionType)
+	return nil
+}
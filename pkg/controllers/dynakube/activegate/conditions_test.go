@@ -0,0 +1,71 @@
+package activegate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
+	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/capability"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/address"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSetRolloutCondition(t *testing.T) {
+	dynakube := func() *dynatracev1beta1.DynaKube {
+		return &dynatracev1beta1.DynaKube{
+			ObjectMeta: metav1.ObjectMeta{Namespace: testNamespace, Name: testName},
+			Spec: dynatracev1beta1.DynaKubeSpec{
+				ActiveGate: dynatracev1beta1.ActiveGateSpec{
+					Capabilities: []dynatracev1beta1.CapabilityDisplayName{dynatracev1beta1.RoutingCapability.DisplayName},
+				},
+			},
+		}
+	}
+	statefulSet := func(dk *dynatracev1beta1.DynaKube, generation int64, status appsv1.StatefulSetStatus) *appsv1.StatefulSet {
+		return &appsv1.StatefulSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:  testNamespace,
+				Name:       capability.CalculateStatefulSetName(capability.NewMultiCapability(dk), dk.Name),
+				Generation: generation,
+			},
+			Spec:   appsv1.StatefulSetSpec{Replicas: address.Of(int32(2))},
+			Status: status,
+		}
+	}
+	assertCondition := func(t *testing.T, dk *dynatracev1beta1.DynaKube, status metav1.ConditionStatus, message string) {
+		condition := meta.FindStatusCondition(dk.Status.Conditions, dynatracev1beta1.ActiveGateConditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, status, condition.Status)
+		assert.Equal(t, message, condition.Message)
+	}
+
+	t.Run("updated and ready", func(t *testing.T) {
+		dk := dynakube()
+		fakeClient := fake.NewClient(statefulSet(dk, 2, appsv1.StatefulSetStatus{ObservedGeneration: 2, UpdatedReplicas: 2, ReadyReplicas: 2}))
+		r := &Reconciler{client: fakeClient, dynakube: dk}
+
+		require.NoError(t, r.setRolloutCondition(context.Background()))
+		assertCondition(t, dk, metav1.ConditionTrue, "")
+	})
+	t.Run("latest spec is not yet observed", func(t *testing.T) {
+		dk := dynakube()
+		sts := statefulSet(dk, 3, appsv1.StatefulSetStatus{ObservedGeneration: 2, UpdatedReplicas: 2, ReadyReplicas: 2})
+		r := &Reconciler{client: fake.NewClient(sts), dynakube: dk}
+
+		require.NoError(t, r.setRolloutCondition(context.Background()))
+		assertCondition(t, dk, metav1.ConditionFalse, "latest changes of StatefulSet "+sts.Name+" are not yet observed")
+	})
+	t.Run("ready pods aren't updated yet", func(t *testing.T) {
+		dk := dynakube()
+		sts := statefulSet(dk, 2, appsv1.StatefulSetStatus{ObservedGeneration: 2, UpdatedReplicas: 1, ReadyReplicas: 2})
+		r := &Reconciler{client: fake.NewClient(sts), dynakube: dk}
+
+		require.NoError(t, r.setRolloutCondition(context.Background()))
+		assertCondition(t, dk, metav1.ConditionFalse, "1 of 2 pods of StatefulSet "+sts.Name+" are updated")
+	})
+}
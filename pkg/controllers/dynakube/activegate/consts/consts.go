@@ -31,6 +31,8 @@ const (
 	AnnotationActiveGateConfigurationHash = dynatracev1beta1.InternalFlagPrefix + "activegate-configuration-hash"
 	AnnotationActiveGateContainerAppArmor = "container.apparmor.security.beta.kubernetes.io/" + ActiveGateContainerName
 
+	LabelActiveGatePool = dynatracev1beta1.InternalFlagPrefix + "activegate-pool"
+
 	InternalProxySecretMountPath = "/var/lib/dynatrace/secrets/internal-proxy"
 
 	InternalProxySecretVolumeName = "internal-proxy-secret-volume"
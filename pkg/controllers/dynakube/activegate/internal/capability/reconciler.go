@@ -42,7 +42,7 @@ func (r *Reconciler) Reconcile(ctx context.Context) error {
 	if err != nil {
 		return errors.WithStack(err)
 	}
-	if r.dynakube.NeedsActiveGateService() {
+	if capability.DynaKubeFor(r.dynakube, r.capability).NeedsActiveGateService() {
 		err = r.createOrUpdateService(ctx)
 		if err != nil {
 			return errors.WithStack(err)
@@ -54,7 +54,7 @@ func (r *Reconciler) Reconcile(ctx context.Context) error {
 }
 
 func (r *Reconciler) createOrUpdateService(ctx context.Context) error {
-	desired := CreateService(r.dynakube, r.capability.ShortName())
+	desired := r.buildService()
 	installed := &corev1.Service{}
 	err := r.client.Get(ctx, object.Key(desired), installed)
 
@@ -86,6 +86,14 @@ func (r *Reconciler) createOrUpdateService(ctx context.Context) error {
 	return nil
 }
 
+func (r *Reconciler) buildService() *corev1.Service {
+	dynakube := capability.DynaKubeFor(r.dynakube, r.capability)
+	if _, ok := r.capability.(*capability.PoolCapability); ok {
+		return CreatePoolService(dynakube, r.capability.ShortName())
+	}
+	return CreateService(dynakube, r.capability.ShortName())
+}
+
 func (r *Reconciler) portsAreOutdated(installedService, desiredService *corev1.Service) bool {
 	return !reflect.DeepEqual(installedService.Spec.Ports, desiredService.Spec.Ports)
 }
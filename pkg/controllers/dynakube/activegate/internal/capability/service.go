@@ -47,6 +47,14 @@ func CreateService(dynakube *dynatracev1beta1.DynaKube, feature string) *corev1.
 	}
 }
 
+// CreatePoolService creates the service of an ActiveGate pool, which only selects the pods of that pool
+func CreatePoolService(dynakube *dynatracev1beta1.DynaKube, feature string) *corev1.Service {
+	service := CreateService(dynakube, feature)
+	appLabels := labels.NewAppLabels(labels.ActiveGateComponentLabel, dynakube.Name, feature, "")
+	service.Spec.Selector[labels.AppComponentLabel] = appLabels.Component
+	return service
+}
+
 func buildSelectorLabels(dynakubeName string) map[string]string {
 	appLabels := labels.NewAppLabels(labels.ActiveGateComponentLabel, dynakubeName, "", "")
 	return appLabels.BuildMatchLabels()
@@ -92,4 +92,17 @@ func TestCreateService(t *testing.T) {
 		assert.Contains(t, ports, agHttpsPort)
 		assert.Contains(t, ports, agHttpPort)
 	})
+	t.Run("pool service selects only the pods of the pool", func(t *testing.T) {
+		instance := testCreateInstance()
+
+		service := CreatePoolService(instance, "activegate-zone-a")
+
+		assert.Equal(t, instance.Name+"-activegate-zone-a", service.Name)
+		assert.Equal(t, map[string]string{
+			labels.AppCreatedByLabel: testName,
+			labels.AppManagedByLabel: version.AppName,
+			labels.AppNameLabel:      labels.ActiveGateComponentLabel,
+			labels.AppComponentLabel: "activegate-zone-a",
+		}, service.Spec.Selector)
+	})
 }
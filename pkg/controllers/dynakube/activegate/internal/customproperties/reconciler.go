@@ -114,7 +114,12 @@ func (r *Reconciler) createCustomProperties() error {
 }
 
 func (r *Reconciler) buildCustomPropertiesName(name string) string {
-	return fmt.Sprintf("%s-%s-%s", name, r.customPropertiesOwnerName, Suffix)
+	return BuildSecretName(name, r.customPropertiesOwnerName)
+}
+
+// BuildSecretName returns the name of the custom properties secret of the given owner
+func BuildSecretName(dynakubeName string, customPropertiesOwnerName string) string {
+	return fmt.Sprintf("%s-%s-%s", dynakubeName, customPropertiesOwnerName, Suffix)
 }
 
 func (r *Reconciler) hasCustomPropertiesValueOnly() bool {
@@ -0,0 +1,7 @@
+package scaling
+
+import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/logger"
+)
+
+var log = logger.Factory.GetLogger("dynakube-activegate-scaling")
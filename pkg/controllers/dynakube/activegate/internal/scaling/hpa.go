@@ -0,0 +1,67 @@
+package scaling
+
+import (
+	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/capability"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/address"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const defaultTargetCPUUtilization = 80
+
+func buildHorizontalPodAutoscaler(dynakube *dynatracev1beta1.DynaKube, agCapability capability.Capability) *autoscalingv2.HorizontalPodAutoscaler {
+	autoscaling := agCapability.Autoscaling()
+	statefulSetName := capability.CalculateStatefulSetName(agCapability, dynakube.Name)
+
+	return &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      statefulSetName,
+			Namespace: dynakube.Namespace,
+			Labels:    buildLabels(dynakube, agCapability),
+		},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: appsv1.SchemeGroupVersion.String(),
+				Kind:       "StatefulSet",
+				Name:       statefulSetName,
+			},
+			MinReplicas: address.Of(autoscaling.GetMinReplicas()),
+			MaxReplicas: autoscaling.MaxReplicas,
+			Metrics:     buildMetrics(autoscaling),
+		},
+	}
+}
+
+// buildMetrics scales on the configured resource utilizations and custom metrics, or on 80% CPU utilization if none is configured
+func buildMetrics(autoscaling *dynatracev1beta1.ActiveGateAutoscalingSpec) []autoscalingv2.MetricSpec {
+	metrics := []autoscalingv2.MetricSpec{}
+
+	if autoscaling.TargetCPUUtilization != nil {
+		metrics = append(metrics, resourceUtilizationMetric(corev1.ResourceCPU, *autoscaling.TargetCPUUtilization))
+	}
+	if autoscaling.TargetMemoryUtilization != nil {
+		metrics = append(metrics, resourceUtilizationMetric(corev1.ResourceMemory, *autoscaling.TargetMemoryUtilization))
+	}
+	metrics = append(metrics, autoscaling.Metrics...)
+
+	if len(metrics) == 0 {
+		metrics = append(metrics, resourceUtilizationMetric(corev1.ResourceCPU, defaultTargetCPUUtilization))
+	}
+	return metrics
+}
+
+func resourceUtilizationMetric(resource corev1.ResourceName, utilization int32) autoscalingv2.MetricSpec {
+	return autoscalingv2.MetricSpec{
+		Type: autoscalingv2.ResourceMetricSourceType,
+		Resource: &autoscalingv2.ResourceMetricSource{
+			Name: resource,
+			Target: autoscalingv2.MetricTarget{
+				Type:               autoscalingv2.UtilizationMetricType,
+				AverageUtilization: address.Of(utilization),
+			},
+		},
+	}
+}
@@ -0,0 +1,51 @@
+package scaling
+
+import (
+	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/capability"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/labels"
+	policyv1 "k8s.io/api/policy/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func buildPodDisruptionBudget(dynakube *dynatracev1beta1.DynaKube, agCapability capability.Capability) *policyv1.PodDisruptionBudget {
+	podDisruptionBudget := agCapability.PodDisruptionBudget()
+
+	spec := policyv1.PodDisruptionBudgetSpec{
+		Selector:       &metav1.LabelSelector{MatchLabels: buildMatchLabels(dynakube, agCapability)},
+		MinAvailable:   podDisruptionBudget.MinAvailable,
+		MaxUnavailable: podDisruptionBudget.MaxUnavailable,
+	}
+	if spec.MinAvailable == nil && spec.MaxUnavailable == nil {
+		maxUnavailable := intstr.FromInt(1)
+		spec.MaxUnavailable = &maxUnavailable
+	}
+
+	return &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      capability.CalculateStatefulSetName(agCapability, dynakube.Name),
+			Namespace: dynakube.Namespace,
+			Labels:    buildLabels(dynakube, agCapability),
+		},
+		Spec: spec,
+	}
+}
+
+func buildLabels(dynakube *dynatracev1beta1.DynaKube, agCapability capability.Capability) map[string]string {
+	return newAppLabels(dynakube, agCapability).BuildLabels()
+}
+
+// buildMatchLabels has to select the same pods as the selector of the ActiveGate StatefulSet
+func buildMatchLabels(dynakube *dynatracev1beta1.DynaKube, agCapability capability.Capability) map[string]string {
+	appLabels := newAppLabels(dynakube, agCapability)
+	matchLabels := appLabels.BuildMatchLabels()
+	if _, ok := agCapability.(*capability.PoolCapability); ok {
+		matchLabels[labels.AppComponentLabel] = appLabels.Component
+	}
+	return matchLabels
+}
+
+func newAppLabels(dynakube *dynatracev1beta1.DynaKube, agCapability capability.Capability) *labels.AppLabels {
+	return labels.NewAppLabels(labels.ActiveGateComponentLabel, dynakube.Name, agCapability.ShortName(), "")
+}
@@ -0,0 +1,102 @@
+package scaling
+
+import (
+	"context"
+
+	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/capability"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/hasher"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/object"
+	"github.com/pkg/errors"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	policyv1 "k8s.io/api/policy/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+var _ controllers.Reconciler = &Reconciler{}
+
+// Reconciler manages the HorizontalPodAutoscaler and the PodDisruptionBudget of an ActiveGate StatefulSet
+type Reconciler struct {
+	client     client.Client
+	dynakube   *dynatracev1beta1.DynaKube
+	capability capability.Capability
+}
+
+func NewReconciler(clt client.Client, dynakube *dynatracev1beta1.DynaKube, capability capability.Capability) *Reconciler {
+	return &Reconciler{
+		client:     clt,
+		dynakube:   dynakube,
+		capability: capability,
+	}
+}
+
+func (r *Reconciler) Reconcile(ctx context.Context) error {
+	if r.capability.Autoscaling() != nil {
+		if err := r.createOrUpdate(ctx, "HorizontalPodAutoscaler", buildHorizontalPodAutoscaler(r.dynakube, r.capability), &autoscalingv2.HorizontalPodAutoscaler{}); err != nil {
+			return errors.WithMessage(err, "could not reconcile HorizontalPodAutoscaler of the ActiveGate")
+		}
+	} else if err := object.Delete(ctx, r.client, r.emptyHorizontalPodAutoscaler()); err != nil {
+		return errors.WithMessage(err, "could not delete HorizontalPodAutoscaler of the ActiveGate")
+	}
+
+	if r.capability.PodDisruptionBudget() != nil {
+		if err := r.createOrUpdate(ctx, "PodDisruptionBudget", buildPodDisruptionBudget(r.dynakube, r.capability), &policyv1.PodDisruptionBudget{}); err != nil {
+			return errors.WithMessage(err, "could not reconcile PodDisruptionBudget of the ActiveGate")
+		}
+	} else if err := object.Delete(ctx, r.client, r.emptyPodDisruptionBudget()); err != nil {
+		return errors.WithMessage(err, "could not delete PodDisruptionBudget of the ActiveGate")
+	}
+	return nil
+}
+
+// Delete removes the HorizontalPodAutoscaler and the PodDisruptionBudget, e.g. when the capability got disabled
+func (r *Reconciler) Delete(ctx context.Context) error {
+	if err := object.Delete(ctx, r.client, r.emptyHorizontalPodAutoscaler()); err != nil {
+		return errors.WithStack(err)
+	}
+	return errors.WithStack(object.Delete(ctx, r.client, r.emptyPodDisruptionBudget()))
+}
+
+func (r *Reconciler) createOrUpdate(ctx context.Context, kind string, desired client.Object, current client.Object) error {
+	if err := controllerutil.SetControllerReference(r.dynakube, desired, r.client.Scheme()); err != nil {
+		return errors.WithStack(err)
+	}
+	if err := hasher.AddAnnotation(desired); err != nil {
+		return errors.WithStack(err)
+	}
+
+	err := r.client.Get(ctx, object.Key(desired), current)
+	if k8serrors.IsNotFound(err) {
+		log.Info("creating", "kind", kind, "name", desired.GetName())
+		return errors.WithStack(r.client.Create(ctx, desired))
+	} else if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if !hasher.IsAnnotationDifferent(current, desired) {
+		return nil
+	}
+
+	log.Info("updating", "kind", kind, "name", desired.GetName())
+	desired.SetResourceVersion(current.GetResourceVersion())
+	return errors.WithStack(r.client.Update(ctx, desired))
+}
+
+func (r *Reconciler) emptyHorizontalPodAutoscaler() *autoscalingv2.HorizontalPodAutoscaler {
+	return &autoscalingv2.HorizontalPodAutoscaler{ObjectMeta: r.objectMeta()}
+}
+
+func (r *Reconciler) emptyPodDisruptionBudget() *policyv1.PodDisruptionBudget {
+	return &policyv1.PodDisruptionBudget{ObjectMeta: r.objectMeta()}
+}
+
+func (r *Reconciler) objectMeta() metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:      capability.CalculateStatefulSetName(r.capability, r.dynakube.Name),
+		Namespace: r.dynakube.Namespace,
+	}
+}
@@ -0,0 +1,144 @@
+package scaling
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
+	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/capability"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/address"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/labels"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	testName      = "test-dynakube"
+	testNamespace = "test-namespace"
+)
+
+func buildDynakube(autoscaling *dynatracev1beta1.ActiveGateAutoscalingSpec, podDisruptionBudget *dynatracev1beta1.ActiveGatePodDisruptionBudgetSpec) *dynatracev1beta1.DynaKube {
+	return &dynatracev1beta1.DynaKube{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testName,
+			Namespace: testNamespace,
+		},
+		Spec: dynatracev1beta1.DynaKubeSpec{
+			ActiveGate: dynatracev1beta1.ActiveGateSpec{
+				Capabilities: []dynatracev1beta1.CapabilityDisplayName{
+					dynatracev1beta1.RoutingCapability.DisplayName,
+				},
+				Autoscaling:         autoscaling,
+				PodDisruptionBudget: podDisruptionBudget,
+			},
+		},
+	}
+}
+
+func objectKey(dynakube *dynatracev1beta1.DynaKube) client.ObjectKey {
+	return client.ObjectKey{Name: capability.CalculateStatefulSetName(capability.NewMultiCapability(dynakube), dynakube.Name), Namespace: testNamespace}
+}
+
+func TestReconcile(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("creates horizontal pod autoscaler and pod disruption budget", func(t *testing.T) {
+		dynakube := buildDynakube(&dynatracev1beta1.ActiveGateAutoscalingSpec{MaxReplicas: 5}, &dynatracev1beta1.ActiveGatePodDisruptionBudgetSpec{})
+		clt := fake.NewClient()
+
+		err := NewReconciler(clt, dynakube, capability.NewMultiCapability(dynakube)).Reconcile(ctx)
+		require.NoError(t, err)
+
+		var hpa autoscalingv2.HorizontalPodAutoscaler
+		require.NoError(t, clt.Get(ctx, objectKey(dynakube), &hpa))
+		assert.Equal(t, "StatefulSet", hpa.Spec.ScaleTargetRef.Kind)
+		assert.Equal(t, objectKey(dynakube).Name, hpa.Spec.ScaleTargetRef.Name)
+		assert.Equal(t, int32(1), *hpa.Spec.MinReplicas)
+		assert.Equal(t, int32(5), hpa.Spec.MaxReplicas)
+		require.Len(t, hpa.Spec.Metrics, 1)
+		assert.Equal(t, corev1.ResourceCPU, hpa.Spec.Metrics[0].Resource.Name)
+		assert.Equal(t, int32(defaultTargetCPUUtilization), *hpa.Spec.Metrics[0].Resource.Target.AverageUtilization)
+		require.Len(t, hpa.OwnerReferences, 1)
+		assert.Equal(t, testName, hpa.OwnerReferences[0].Name)
+
+		var pdb policyv1.PodDisruptionBudget
+		require.NoError(t, clt.Get(ctx, objectKey(dynakube), &pdb))
+		assert.Equal(t, intstr.FromInt(1), *pdb.Spec.MaxUnavailable)
+		assert.Nil(t, pdb.Spec.MinAvailable)
+		assert.Equal(t, testName, pdb.Spec.Selector.MatchLabels[labels.AppCreatedByLabel])
+		require.Len(t, pdb.OwnerReferences, 1)
+	})
+	t.Run("updates changed configuration", func(t *testing.T) {
+		dynakube := buildDynakube(&dynatracev1beta1.ActiveGateAutoscalingSpec{MaxReplicas: 5}, nil)
+		clt := fake.NewClient()
+		require.NoError(t, NewReconciler(clt, dynakube, capability.NewMultiCapability(dynakube)).Reconcile(ctx))
+
+		dynakube.Spec.ActiveGate.Autoscaling.MaxReplicas = 10
+		dynakube.Spec.ActiveGate.Autoscaling.TargetMemoryUtilization = address.Of(int32(70))
+		require.NoError(t, NewReconciler(clt, dynakube, capability.NewMultiCapability(dynakube)).Reconcile(ctx))
+
+		var hpa autoscalingv2.HorizontalPodAutoscaler
+		require.NoError(t, clt.Get(ctx, objectKey(dynakube), &hpa))
+		assert.Equal(t, int32(10), hpa.Spec.MaxReplicas)
+		require.Len(t, hpa.Spec.Metrics, 1)
+		assert.Equal(t, corev1.ResourceMemory, hpa.Spec.Metrics[0].Resource.Name)
+	})
+	t.Run("deletes horizontal pod autoscaler and pod disruption budget when disabled", func(t *testing.T) {
+		dynakube := buildDynakube(&dynatracev1beta1.ActiveGateAutoscalingSpec{MaxReplicas: 5}, &dynatracev1beta1.ActiveGatePodDisruptionBudgetSpec{})
+		clt := fake.NewClient()
+		require.NoError(t, NewReconciler(clt, dynakube, capability.NewMultiCapability(dynakube)).Reconcile(ctx))
+
+		dynakube.Spec.ActiveGate.Autoscaling = nil
+		dynakube.Spec.ActiveGate.PodDisruptionBudget = nil
+		require.NoError(t, NewReconciler(clt, dynakube, capability.NewMultiCapability(dynakube)).Reconcile(ctx))
+
+		err := clt.Get(ctx, objectKey(dynakube), &autoscalingv2.HorizontalPodAutoscaler{})
+		assert.True(t, k8serrors.IsNotFound(err))
+		err = clt.Get(ctx, objectKey(dynakube), &policyv1.PodDisruptionBudget{})
+		assert.True(t, k8serrors.IsNotFound(err))
+	})
+}
+
+func TestDelete(t *testing.T) {
+	ctx := context.Background()
+	dynakube := buildDynakube(&dynatracev1beta1.ActiveGateAutoscalingSpec{MaxReplicas: 5}, &dynatracev1beta1.ActiveGatePodDisruptionBudgetSpec{})
+	clt := fake.NewClient()
+	reconciler := NewReconciler(clt, dynakube, capability.NewMultiCapability(dynakube))
+	require.NoError(t, reconciler.Reconcile(ctx))
+
+	require.NoError(t, reconciler.Delete(ctx))
+	require.NoError(t, reconciler.Delete(ctx))
+
+	err := clt.Get(ctx, objectKey(dynakube), &autoscalingv2.HorizontalPodAutoscaler{})
+	assert.True(t, k8serrors.IsNotFound(err))
+	err = clt.Get(ctx, objectKey(dynakube), &policyv1.PodDisruptionBudget{})
+	assert.True(t, k8serrors.IsNotFound(err))
+}
+
+func TestBuildMetrics(t *testing.T) {
+	customMetric := autoscalingv2.MetricSpec{
+		Type: autoscalingv2.PodsMetricSourceType,
+		Pods: &autoscalingv2.PodsMetricSource{
+			Metric: autoscalingv2.MetricIdentifier{Name: "requests_per_second"},
+			Target: autoscalingv2.MetricTarget{Type: autoscalingv2.AverageValueMetricType},
+		},
+	}
+
+	metrics := buildMetrics(&dynatracev1beta1.ActiveGateAutoscalingSpec{
+		TargetCPUUtilization: address.Of(int32(60)),
+		Metrics:              []autoscalingv2.MetricSpec{customMetric},
+	})
+
+	require.Len(t, metrics, 2)
+	assert.Equal(t, corev1.ResourceCPU, metrics[0].Resource.Name)
+	assert.Equal(t, int32(60), *metrics[0].Resource.Target.AverageUtilization)
+	assert.Equal(t, customMetric, metrics[1])
+}
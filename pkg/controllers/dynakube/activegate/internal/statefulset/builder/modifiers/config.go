@@ -32,7 +32,7 @@ func GenerateAllModifiers(dynakube dynatracev1beta1.DynaKube, capability capabil
 		NewCertificatesModifier(dynakube),
 		NewCustomPropertiesModifier(dynakube, capability),
 		NewProxyModifier(dynakube),
-		NewRawImageModifier(dynakube, agBaseContainerEnvMap),
+		NewRawImageModifier(dynakube, capability, agBaseContainerEnvMap),
 		NewReadOnlyModifier(dynakube),
 		newSyntheticModifier(dynakube, capability, agBaseContainerEnvMap),
 		NewServicePortModifier(dynakube, capability, agBaseContainerEnvMap),
@@ -2,6 +2,7 @@ package modifiers
 
 import (
 	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/capability"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/consts"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/internal/statefulset/builder"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/connectioninfo"
@@ -17,16 +18,18 @@ var _ volumeModifier = RawImageModifier{}
 var _ volumeMountModifier = RawImageModifier{}
 var _ builder.Modifier = RawImageModifier{}
 
-func NewRawImageModifier(dynakube dynatracev1beta1.DynaKube, envMap *prioritymap.Map) RawImageModifier {
+func NewRawImageModifier(dynakube dynatracev1beta1.DynaKube, capability capability.Capability, envMap *prioritymap.Map) RawImageModifier {
 	return RawImageModifier{
-		dynakube: dynakube,
-		envMap:   envMap,
+		dynakube:   dynakube,
+		capability: capability,
+		envMap:     envMap,
 	}
 }
 
 type RawImageModifier struct {
-	dynakube dynatracev1beta1.DynaKube
-	envMap   *prioritymap.Map
+	dynakube   dynatracev1beta1.DynaKube
+	capability capability.Capability
+	envMap     *prioritymap.Map
 }
 
 func (mod RawImageModifier) Enabled() bool {
@@ -47,7 +50,7 @@ func (mod RawImageModifier) getVolumes() []corev1.Volume {
 			Name: connectioninfo.TenantSecretVolumeName,
 			VolumeSource: corev1.VolumeSource{
 				Secret: &corev1.SecretVolumeSource{
-					SecretName: mod.dynakube.ActivegateTenantSecret(),
+					SecretName: capability.BuildTenantSecretName(&mod.dynakube, mod.capability),
 				},
 			},
 		},
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/capability"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/prioritymap"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -20,7 +21,7 @@ func TestRawImageEnabled(t *testing.T) {
 		enableKubeMonCapability(&dynakube)
 		setRawImageUsage(&dynakube, true)
 
-		mod := NewRawImageModifier(dynakube, prioritymap.New())
+		mod := NewRawImageModifier(dynakube, capability.NewMultiCapability(&dynakube), prioritymap.New())
 
 		assert.True(t, mod.Enabled())
 	})
@@ -30,7 +31,7 @@ func TestRawImageEnabled(t *testing.T) {
 		enableKubeMonCapability(&dynakube)
 		setRawImageUsage(&dynakube, false)
 
-		mod := NewRawImageModifier(dynakube, prioritymap.New())
+		mod := NewRawImageModifier(dynakube, capability.NewMultiCapability(&dynakube), prioritymap.New())
 
 		assert.False(t, mod.Enabled())
 	})
@@ -41,7 +42,7 @@ func TestRawImageModify(t *testing.T) {
 		dynakube := getBaseDynakube()
 		enableKubeMonCapability(&dynakube)
 		setRawImageUsage(&dynakube, true)
-		mod := NewRawImageModifier(dynakube, prioritymap.New())
+		mod := NewRawImageModifier(dynakube, capability.NewMultiCapability(&dynakube), prioritymap.New())
 		builder := createBuilderForTesting()
 
 		sts, _ := builder.AddModifier(mod).Build()
@@ -52,3 +53,18 @@ func TestRawImageModify(t *testing.T) {
 		isSubset(t, mod.getEnvs(), sts.Spec.Template.Spec.Containers[0].Env)
 	})
 }
+
+func TestRawImagePoolTenantSecret(t *testing.T) {
+	dynakube := getBaseDynakube()
+	pool := dynatracev1beta1.ActiveGatePoolSpec{
+		Name:         "zone-a",
+		Capabilities: []dynatracev1beta1.CapabilityDisplayName{dynatracev1beta1.RoutingCapability.DisplayName},
+	}
+	dynakube.Spec.ActiveGate.Pools = []dynatracev1beta1.ActiveGatePoolSpec{pool}
+	mod := NewRawImageModifier(dynakube, capability.NewPoolCapability(&dynakube, pool), prioritymap.New())
+
+	volumes := mod.getVolumes()
+
+	require.Len(t, volumes, 1)
+	assert.Equal(t, dynakube.ActiveGatePoolTenantSecret("zone-a"), volumes[0].Secret.SecretName)
+}
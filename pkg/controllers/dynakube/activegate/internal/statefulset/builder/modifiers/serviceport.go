@@ -73,7 +73,8 @@ func (mod ServicePortModifier) getEnvs() []corev1.EnvVar {
 }
 
 func (mod ServicePortModifier) buildDNSEntryPoint() string {
-	if mod.capability.ShortName() == consts.MultiActiveGateName && strings.Contains(mod.capability.ArgName(), dynatracev1beta1.RoutingCapability.ArgumentName) ||
+	_, isPool := mod.capability.(*capability.PoolCapability)
+	if (mod.capability.ShortName() == consts.MultiActiveGateName || isPool) && strings.Contains(mod.capability.ArgName(), dynatracev1beta1.RoutingCapability.ArgumentName) ||
 		mod.capability.ShortName() == dynatracev1beta1.RoutingCapability.ShortName {
 		return fmt.Sprintf("https://%s/communication,https://%s/communication", buildServiceHostName(mod.dynakube.Name, mod.capability.ShortName()), buildServiceDomainName(mod.dynakube.Name, mod.dynakube.Namespace, mod.capability.ShortName()))
 	}
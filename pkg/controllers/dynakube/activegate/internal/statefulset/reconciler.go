@@ -83,6 +83,11 @@ func (r *Reconciler) manageStatefulSet(ctx context.Context) error {
 		return errors.WithStack(err)
 	}
 
+	deferred, err := r.deferUpdateIfOutsideMaintenanceWindow(ctx, desiredSts)
+	if deferred || err != nil {
+		return errors.WithStack(err)
+	}
+
 	deleted, err := r.deleteStatefulSetIfSelectorChanged(ctx, desiredSts)
 	if deleted || err != nil {
 		return errors.WithStack(err)
@@ -107,7 +112,7 @@ func (r *Reconciler) buildDesiredStatefulSet(ctx context.Context) (*appsv1.State
 		return nil, errors.WithStack(err)
 	}
 
-	statefulSetBuilder := NewStatefulSetBuilder(kubeUID, activeGateConfigurationHash, *r.dynakube, r.capability)
+	statefulSetBuilder := NewStatefulSetBuilder(kubeUID, activeGateConfigurationHash, *capability.DynaKubeFor(r.dynakube, r.capability), r.capability)
 
 	desiredSts, err := statefulSetBuilder.CreateStatefulSet(r.modifiers)
 	return desiredSts, errors.WithStack(err)
@@ -131,6 +136,25 @@ func (r *Reconciler) createStatefulSetIfNotExists(ctx context.Context, desiredSt
 	return false, err
 }
 
+// deferUpdateIfOutsideMaintenanceWindow keeps an outdated stateful set as it is until the next maintenance window opens
+func (r *Reconciler) deferUpdateIfOutsideMaintenanceWindow(ctx context.Context, desiredSts *appsv1.StatefulSet) (bool, error) {
+	if !r.dynakube.IsUpdateDeferred() {
+		return false, nil
+	}
+
+	currentSts, err := r.getStatefulSet(ctx, desiredSts)
+	if err != nil {
+		return false, err
+	}
+	if !hasher.IsAnnotationDifferent(currentSts, desiredSts) {
+		return false, nil
+	}
+
+	log.Info("update of stateful set deferred until the next maintenance window", "name", desiredSts.Name, "until", r.dynakube.Status.UpdatesDeferredUntil)
+	r.dynakube.SetConditionUpdatePending()
+	return true, nil
+}
+
 func (r *Reconciler) updateStatefulSetIfOutdated(ctx context.Context, desiredSts *appsv1.StatefulSet) (bool, error) {
 	currentSts, err := r.getStatefulSet(ctx, desiredSts)
 	if err != nil {
@@ -144,6 +168,11 @@ func (r *Reconciler) updateStatefulSetIfOutdated(ctx context.Context, desiredSts
 		return r.recreateStatefulSet(ctx, currentSts, desiredSts)
 	}
 
+	if r.capability.Autoscaling() != nil {
+		// the replicas are managed by the HorizontalPodAutoscaler
+		desiredSts.Spec.Replicas = currentSts.Spec.Replicas
+	}
+
 	log.Info("updating existing stateful set")
 	if err = r.client.Update(ctx, desiredSts); err != nil {
 		return false, err
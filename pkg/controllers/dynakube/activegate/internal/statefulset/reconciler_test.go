@@ -3,12 +3,14 @@ package statefulset
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme"
 	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/capability"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/internal/authtoken"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/internal/customproperties"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/address"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubesystem"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
@@ -16,6 +18,7 @@ import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -183,6 +186,65 @@ func TestReconcile_UpdateStatefulSetIfOutdated(t *testing.T) {
 	assert.True(t, updated)
 }
 
+func TestReconcile_UpdateStatefulSetKeepsAutoscaledReplicas(t *testing.T) {
+	ctx := context.Background()
+	r := createDefaultReconciler(t)
+	r.dynakube.Spec.ActiveGate.Autoscaling = &dynatracev1beta1.ActiveGateAutoscalingSpec{MaxReplicas: 5}
+	r.capability = capability.NewMultiCapability(r.dynakube)
+
+	desiredSts, err := r.buildDesiredStatefulSet(ctx)
+	require.NoError(t, err)
+	created, err := r.createStatefulSetIfNotExists(ctx, desiredSts)
+	require.True(t, created)
+	require.NoError(t, err)
+
+	scaledSts, err := r.getStatefulSet(ctx, desiredSts)
+	require.NoError(t, err)
+	scaledSts.Spec.Replicas = address.Of(int32(4))
+	require.NoError(t, r.client.Update(ctx, scaledSts))
+
+	r.dynakube.Spec.Proxy = &dynatracev1beta1.DynaKubeProxy{Value: testValue}
+	desiredSts, err = r.buildDesiredStatefulSet(ctx)
+	require.NoError(t, err)
+
+	updated, err := r.updateStatefulSetIfOutdated(ctx, desiredSts)
+	require.NoError(t, err)
+	assert.True(t, updated)
+
+	currentSts, err := r.getStatefulSet(ctx, desiredSts)
+	require.NoError(t, err)
+	assert.Equal(t, int32(4), *currentSts.Spec.Replicas)
+}
+
+func TestReconcile_DeferUpdateIfOutsideMaintenanceWindow(t *testing.T) {
+	r := createDefaultReconciler(t)
+	r.dynakube.Status.UpdatesDeferredUntil = &metav1.Time{Time: time.Now().Add(time.Hour)}
+	desiredSts, err := r.buildDesiredStatefulSet(context.Background())
+	require.NoError(t, err)
+
+	created, err := r.createStatefulSetIfNotExists(context.Background(), desiredSts)
+	require.True(t, created)
+	require.NoError(t, err)
+
+	deferred, err := r.deferUpdateIfOutsideMaintenanceWindow(context.Background(), desiredSts)
+	assert.NoError(t, err)
+	assert.False(t, deferred)
+
+	r.dynakube.Spec.Proxy = &dynatracev1beta1.DynaKubeProxy{Value: testValue}
+	desiredSts, err = r.buildDesiredStatefulSet(context.Background())
+	require.NoError(t, err)
+
+	deferred, err = r.deferUpdateIfOutsideMaintenanceWindow(context.Background(), desiredSts)
+	assert.NoError(t, err)
+	assert.True(t, deferred)
+	assert.NotNil(t, meta.FindStatusCondition(r.dynakube.Status.Conditions, dynatracev1beta1.UpdatePendingConditionType))
+
+	r.dynakube.Status.UpdatesDeferredUntil = nil
+	deferred, err = r.deferUpdateIfOutsideMaintenanceWindow(context.Background(), desiredSts)
+	assert.NoError(t, err)
+	assert.False(t, deferred)
+}
+
 func TestReconcile_DeleteStatefulSetIfOldLabelsAreUsed(t *testing.T) {
 	t.Run("statefulset is deleted when old labels are used", func(t *testing.T) {
 		r := createDefaultReconciler(t)
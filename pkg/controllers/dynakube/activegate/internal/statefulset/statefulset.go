@@ -82,7 +82,7 @@ func (statefulSetBuilder Builder) getBaseObjectMeta() metav1.ObjectMeta {
 
 func (statefulSetBuilder Builder) getBaseSpec() appsv1.StatefulSetSpec {
 	return appsv1.StatefulSetSpec{
-		Replicas:            statefulSetBuilder.capability.Properties().Replicas,
+		Replicas:            statefulSetBuilder.getReplicas(),
 		PodManagementPolicy: appsv1.ParallelPodManagement,
 		Template: corev1.PodTemplateSpec{
 			ObjectMeta: metav1.ObjectMeta{
@@ -94,11 +94,35 @@ func (statefulSetBuilder Builder) getBaseSpec() appsv1.StatefulSetSpec {
 	}
 }
 
+// getReplicas returns the configured replicas, with autoscaling only the initial ones, the HorizontalPodAutoscaler takes care of the rest
+func (statefulSetBuilder Builder) getReplicas() *int32 {
+	replicas := statefulSetBuilder.capability.Properties().Replicas
+	if autoscaling := statefulSetBuilder.capability.Autoscaling(); autoscaling != nil {
+		return address.Of(autoscaling.GetInitialReplicas(replicas))
+	}
+	return replicas
+}
+
 func (statefulSetBuilder Builder) addLabels(sts *appsv1.StatefulSet) {
 	appLabels := statefulSetBuilder.buildAppLabels()
 	sts.ObjectMeta.Labels = appLabels.BuildLabels()
-	sts.Spec.Selector = &metav1.LabelSelector{MatchLabels: appLabels.BuildMatchLabels()}
+	sts.Spec.Selector = &metav1.LabelSelector{MatchLabels: statefulSetBuilder.buildMatchLabels()}
 	sts.Spec.Template.ObjectMeta.Labels = maputils.MergeMap(statefulSetBuilder.capability.Properties().Labels, appLabels.BuildLabels())
+
+	if poolCapability, ok := statefulSetBuilder.capability.(*capability.PoolCapability); ok {
+		sts.ObjectMeta.Labels[consts.LabelActiveGatePool] = poolCapability.PoolName()
+	}
+}
+
+// buildMatchLabels narrows the match labels down to the component for ActiveGate pools,
+// so the pods of one pool are not selected by the StatefulSets of the other pools
+func (statefulSetBuilder Builder) buildMatchLabels() map[string]string {
+	appLabels := statefulSetBuilder.buildAppLabels()
+	matchLabels := appLabels.BuildMatchLabels()
+	if _, ok := statefulSetBuilder.capability.(*capability.PoolCapability); ok {
+		matchLabels[labels.AppComponentLabel] = appLabels.Component
+	}
+	return matchLabels
 }
 
 func (statefulSetBuilder Builder) buildAppLabels() *labels.AppLabels {
@@ -145,19 +169,18 @@ func (statefulSetBuilder Builder) buildTopologySpreadConstraints(capability capa
 }
 
 func (statefulSetBuilder Builder) defaultTopologyConstraints() []corev1.TopologySpreadConstraint {
-	appLabels := statefulSetBuilder.buildAppLabels()
 	return []corev1.TopologySpreadConstraint{
 		{
 			MaxSkew:           1,
 			TopologyKey:       "topology.kubernetes.io/zone",
 			WhenUnsatisfiable: "ScheduleAnyway",
-			LabelSelector:     &metav1.LabelSelector{MatchLabels: appLabels.BuildMatchLabels()},
+			LabelSelector:     &metav1.LabelSelector{MatchLabels: statefulSetBuilder.buildMatchLabels()},
 		},
 		{
 			MaxSkew:           1,
 			TopologyKey:       "kubernetes.io/hostname",
 			WhenUnsatisfiable: "DoNotSchedule",
-			LabelSelector:     &metav1.LabelSelector{MatchLabels: appLabels.BuildMatchLabels()},
+			LabelSelector:     &metav1.LabelSelector{MatchLabels: statefulSetBuilder.buildMatchLabels()},
 		},
 	}
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/internal/statefulset/builder/modifiers"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/deploymentmetadata"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/activegate"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/address"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/env"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/labels"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/resources"
@@ -139,6 +140,19 @@ func TestGetBaseSpec(t *testing.T) {
 		require.NotNil(t, stsSpec.Template.Annotations)
 		assert.Equal(t, testConfigHash, stsSpec.Template.Annotations[consts.AnnotationActiveGateConfigurationHash])
 	})
+	t.Run("initial replicas are limited by autoscaling", func(t *testing.T) {
+		dynakube := getTestDynakube()
+		dynakube.Spec.ActiveGate.Autoscaling = &dynatracev1beta1.ActiveGateAutoscalingSpec{MaxReplicas: 5}
+		builder := NewStatefulSetBuilder(testKubeUID, testConfigHash, dynakube, capability.NewMultiCapability(&dynakube))
+
+		assert.Equal(t, int32(5), *builder.getBaseSpec().Replicas)
+
+		dynakube.Spec.ActiveGate.Replicas = nil
+		dynakube.Spec.ActiveGate.Autoscaling.MinReplicas = address.Of(int32(2))
+		builder = NewStatefulSetBuilder(testKubeUID, testConfigHash, dynakube, capability.NewMultiCapability(&dynakube))
+
+		assert.Equal(t, int32(2), *builder.getBaseSpec().Replicas)
+	})
 }
 
 func TestAddLabels(t *testing.T) {
@@ -176,6 +190,22 @@ func TestAddLabels(t *testing.T) {
 		require.NotEmpty(t, sts.Spec.Template.Labels)
 		assert.Equal(t, expectedTemplateLabels, sts.Spec.Template.Labels)
 	})
+
+	t.Run("pool selects only its own pods", func(t *testing.T) {
+		dynakube := getTestDynakube()
+		pool := dynatracev1beta1.ActiveGatePoolSpec{Name: "zone-a", Capabilities: dynakube.Spec.ActiveGate.Capabilities}
+		dynakube.Spec.ActiveGate.Pools = []dynatracev1beta1.ActiveGatePoolSpec{pool}
+		poolCapability := capability.NewPoolCapability(&dynakube, pool)
+		builder := NewStatefulSetBuilder(testKubeUID, testConfigHash, *capability.DynaKubeFor(&dynakube, poolCapability), poolCapability)
+		sts := appsv1.StatefulSet{}
+
+		builder.addLabels(&sts)
+
+		assert.Equal(t, "zone-a", sts.ObjectMeta.Labels[consts.LabelActiveGatePool])
+		assert.Equal(t, "activegate-zone-a", sts.Spec.Selector.MatchLabels[labels.AppComponentLabel])
+		assert.Equal(t, "activegate-zone-a", sts.Spec.Template.Labels[labels.AppComponentLabel])
+		assert.NotContains(t, sts.Spec.Template.Labels, consts.LabelActiveGatePool)
+	})
 }
 
 func TestAddTemplateSpec(t *testing.T) {
@@ -5,13 +5,16 @@ import (
 	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/capability"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/consts"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/internal/authtoken"
 	capabilityInternal "github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/internal/capability"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/internal/customproperties"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/internal/proxy"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/internal/scaling"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/internal/statefulset"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/connectioninfo"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/configmap"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/labels"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/object"
 	"github.com/pkg/errors"
 	"golang.org/x/net/context"
@@ -57,6 +60,20 @@ func NewReconciler(clt client.Client, apiReader client.Reader, scheme *runtime.S
 }
 
 func (r *Reconciler) Reconcile(ctx context.Context) error {
+	err := r.reconcile(ctx)
+	if err != nil {
+		r.dynakube.SetConditionError(dynatracev1beta1.ActiveGateConditionType, err)
+		return err
+	}
+
+	if !r.dynakube.NeedsActiveGate() {
+		r.dynakube.RemoveCondition(dynatracev1beta1.ActiveGateConditionType)
+		return nil
+	}
+	return r.setRolloutCondition(ctx)
+}
+
+func (r *Reconciler) reconcile(ctx context.Context) error {
 	err := r.createActiveGateTenantConnectionInfoConfigMap(ctx)
 	if err != nil {
 		return err
@@ -74,6 +91,15 @@ func (r *Reconciler) Reconcile(ctx context.Context) error {
 		return err
 	}
 
+	err = r.reconcileCapabilities(ctx)
+	if err != nil {
+		return err
+	}
+
+	return r.reconcilePools(ctx)
+}
+
+func (r *Reconciler) reconcileCapabilities(ctx context.Context) error {
 	caps := capability.GenerateActiveGateCapabilities(r.dynakube)
 
 	if r.dynakube.IsSyntheticMonitoringEnabled() {
@@ -87,15 +113,89 @@ func (r *Reconciler) Reconcile(ctx context.Context) error {
 	for _, agCapability := range caps {
 		if agCapability.Enabled() {
 			return r.createCapability(ctx, agCapability)
-		} else {
-			err = r.deleteCapability(ctx, agCapability)
-			if err != nil {
-				return err
-			}
 		}
+
+		err := r.deleteCapability(ctx, agCapability)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *Reconciler) reconcilePools(ctx context.Context) error {
+	for _, poolCapability := range capability.GenerateActiveGatePoolCapabilities(r.dynakube) {
+		err := r.createCapability(ctx, poolCapability)
+		if err != nil {
+			return errors.WithMessagef(err, "could not reconcile ActiveGate pool %s", poolCapability.ShortName())
+		}
+	}
+	return r.deleteRemovedPools(ctx)
+}
+
+// deleteRemovedPools deletes the resources of ActiveGate pools which were removed from the DynaKube
+func (r *Reconciler) deleteRemovedPools(ctx context.Context) error {
+	poolNames := map[string]bool{}
+	for _, pool := range r.dynakube.Spec.ActiveGate.Pools {
+		poolNames[pool.Name] = true
+	}
+
+	appLabels := labels.NewAppLabels(labels.ActiveGateComponentLabel, r.dynakube.Name, "", "")
+	statefulSets := &appsv1.StatefulSetList{}
+	err := r.client.List(ctx, statefulSets,
+		client.InNamespace(r.dynakube.Namespace),
+		client.MatchingLabels(appLabels.BuildMatchLabels()),
+		client.HasLabels{consts.LabelActiveGatePool})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	for _, statefulSet := range statefulSets.Items {
+		poolName := statefulSet.Labels[consts.LabelActiveGatePool]
+		if poolNames[poolName] {
+			continue
+		}
+
+		log.Info("deleting removed ActiveGate pool", "pool", poolName)
+		err = r.deletePool(ctx, capability.NewPoolCapability(r.dynakube, dynatracev1beta1.ActiveGatePoolSpec{Name: poolName}))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *Reconciler) deletePool(ctx context.Context, poolCapability capability.Capability) error {
+	objects := []client.Object{
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      capability.BuildServiceName(r.dynakube.Name, poolCapability.ShortName()),
+				Namespace: r.dynakube.Namespace,
+			},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      capability.BuildTenantSecretName(r.dynakube, poolCapability),
+				Namespace: r.dynakube.Namespace,
+			},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      customproperties.BuildSecretName(r.dynakube.Name, capability.BuildCustomPropertiesOwnerName(r.dynakube, poolCapability)),
+				Namespace: r.dynakube.Namespace,
+			},
+		},
 	}
 
-	return err
+	if err := r.deleteStatefulset(ctx, poolCapability); err != nil {
+		return err
+	}
+	for _, obj := range objects {
+		if err := object.Delete(ctx, r.client, obj); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return scaling.NewReconciler(r.client, r.dynakube, poolCapability).Delete(ctx)
 }
 
 func (r *Reconciler) createActiveGateTenantConnectionInfoConfigMap(ctx context.Context) error {
@@ -131,11 +231,15 @@ func extractPublicData(dynakube *dynatracev1beta1.DynaKube) map[string]string {
 }
 
 func (r *Reconciler) createCapability(ctx context.Context, agCapability capability.Capability) error {
-	customPropertiesReconciler := r.newCustomPropertiesReconcilerFunc(r.dynakube.ActiveGateServiceAccountOwner(), agCapability.Properties().CustomProperties) // nolint:typeCheck
-	statefulsetReconciler := r.newStatefulsetReconcilerFunc(r.client, r.apiReader, r.scheme, r.dynakube, agCapability)                                        // nolint:typeCheck
+	customPropertiesReconciler := r.newCustomPropertiesReconcilerFunc(capability.BuildCustomPropertiesOwnerName(r.dynakube, agCapability), agCapability.Properties().CustomProperties) // nolint:typeCheck
+	statefulsetReconciler := r.newStatefulsetReconcilerFunc(r.client, r.apiReader, r.scheme, r.dynakube, agCapability)                                                                 // nolint:typeCheck
 
 	capabilityReconciler := r.newCapabilityReconcilerFunc(r.client, agCapability, r.dynakube, statefulsetReconciler, customPropertiesReconciler)
-	return capabilityReconciler.Reconcile(ctx)
+	if err := capabilityReconciler.Reconcile(ctx); err != nil {
+		return err
+	}
+
+	return scaling.NewReconciler(r.client, r.dynakube, agCapability).Reconcile(ctx)
 }
 
 func (r *Reconciler) deleteCapability(ctx context.Context, agCapability capability.Capability) error {
@@ -147,7 +251,7 @@ func (r *Reconciler) deleteCapability(ctx context.Context, agCapability capabili
 		return err
 	}
 
-	return nil
+	return scaling.NewReconciler(r.client, r.dynakube, agCapability).Delete(ctx)
 }
 
 func (r *Reconciler) deleteService(ctx context.Context, agCapability capability.Capability) error {
@@ -13,6 +13,7 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/connectioninfo"
 	"github.com/Dynatrace/dynatrace-operator/test/mocks/pkg/clients/dynatrace"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -49,7 +50,7 @@ var (
 
 func TestReconciler_Reconcile(t *testing.T) {
 	dtc := mocks.NewClient(t)
-	dtc.On("GetActiveGateAuthToken", testName).Return(&dtclient.ActiveGateAuthTokenInfo{}, nil)
+	dtc.On("GetActiveGateAuthToken", mock.Anything, testName).Return(&dtclient.ActiveGateAuthTokenInfo{}, nil)
 
 	t.Run(`Create works with minimal setup`, func(t *testing.T) {
 		instance := &dynatracev1beta1.DynaKube{
@@ -110,6 +111,57 @@ func TestReconciler_Reconcile(t *testing.T) {
 		err = fakeClient.Get(context.Background(), types.NamespacedName{Name: testServiceName, Namespace: testNamespace}, &service)
 		assert.True(t, k8serrors.IsNotFound(err))
 	})
+	t.Run(`Create AG pools (creation and deletion)`, func(t *testing.T) {
+		routing := []dynatracev1beta1.CapabilityDisplayName{dynatracev1beta1.RoutingCapability.DisplayName}
+		instance := &dynatracev1beta1.DynaKube{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testNamespace,
+				Name:      testName,
+			},
+			Spec: dynatracev1beta1.DynaKubeSpec{
+				ActiveGate: dynatracev1beta1.ActiveGateSpec{
+					Pools: []dynatracev1beta1.ActiveGatePoolSpec{
+						{Name: "zone-a", Capabilities: routing, NetworkZone: "zone-a"},
+						{Name: "zone-b", Capabilities: routing, NetworkZone: "zone-b"},
+					},
+				},
+			},
+		}
+		fakeClient := fake.NewClient(testKubeSystemNamespace)
+		r := NewReconciler(fakeClient, fakeClient, scheme.Scheme, instance, dtc)
+		err := r.Reconcile(context.Background())
+		require.NoError(t, err)
+
+		for _, name := range []string{testName + "-activegate-zone-a", testName + "-activegate-zone-b"} {
+			var statefulSet appsv1.StatefulSet
+			err = fakeClient.Get(context.Background(), types.NamespacedName{Name: name, Namespace: testNamespace}, &statefulSet)
+			require.NoError(t, err)
+
+			var service corev1.Service
+			err = fakeClient.Get(context.Background(), types.NamespacedName{Name: name, Namespace: testNamespace}, &service)
+			require.NoError(t, err)
+		}
+
+		var serviceList corev1.ServiceList
+		err = fakeClient.List(context.Background(), &serviceList)
+		require.NoError(t, err)
+		assert.Len(t, serviceList.Items, 2)
+
+		// remove a pool from spec
+		instance.Spec.ActiveGate.Pools = instance.Spec.ActiveGate.Pools[:1]
+		err = r.Reconcile(context.Background())
+		require.NoError(t, err)
+
+		var statefulSet appsv1.StatefulSet
+		err = fakeClient.Get(context.Background(), types.NamespacedName{Name: testName + "-activegate-zone-a", Namespace: testNamespace}, &statefulSet)
+		require.NoError(t, err)
+		err = fakeClient.Get(context.Background(), types.NamespacedName{Name: testName + "-activegate-zone-b", Namespace: testNamespace}, &statefulSet)
+		assert.True(t, k8serrors.IsNotFound(err))
+
+		var service corev1.Service
+		err = fakeClient.Get(context.Background(), types.NamespacedName{Name: testName + "-activegate-zone-b", Namespace: testNamespace}, &service)
+		assert.True(t, k8serrors.IsNotFound(err))
+	})
 	t.Run("Reconcile DynaKube without Proxy after a DynaKube with proxy must not interfere with the second DKs Proxy Secret", func(t *testing.T) {
 		dynaKubeWithProxy := &dynatracev1beta1.DynaKube{
 			ObjectMeta: metav1.ObjectMeta{
@@ -235,7 +287,7 @@ func getTestActiveGateService(t *testing.T, fakeClient client.Client) corev1.Ser
 
 func TestExclusiveSynMonitoring(t *testing.T) {
 	mockDtClient := mocks.NewClient(t)
-	mockDtClient.On("GetActiveGateAuthToken", testName).
+	mockDtClient.On("GetActiveGateAuthToken", mock.Anything, testName).
 		Return(&dtclient.ActiveGateAuthTokenInfo{}, nil)
 
 	dynakube := &dynatracev1beta1.DynaKube{
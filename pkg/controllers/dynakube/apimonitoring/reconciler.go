@@ -1,6 +1,8 @@
 package apimonitoring
 
 import (
+	"context"
+
 	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
 	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
 	"github.com/pkg/errors"
@@ -20,8 +22,8 @@ func NewReconciler(dtc dtclient.Client, clusterLabel, kubeSystemUUID string) *Re
 	}
 }
 
-func (r *Reconciler) Reconcile(dynakube *dynatracev1beta1.DynaKube) error {
-	objectID, err := r.createObjectIdIfNotExists(dynakube)
+func (r *Reconciler) Reconcile(ctx context.Context, dynakube *dynatracev1beta1.DynaKube) error {
+	objectID, err := r.createObjectIdIfNotExists(ctx, dynakube)
 
 	if err != nil {
 		return err
@@ -36,25 +38,25 @@ func (r *Reconciler) Reconcile(dynakube *dynatracev1beta1.DynaKube) error {
 	return nil
 }
 
-func (r *Reconciler) createObjectIdIfNotExists(dynakube *dynatracev1beta1.DynaKube) (string, error) {
+func (r *Reconciler) createObjectIdIfNotExists(ctx context.Context, dynakube *dynatracev1beta1.DynaKube) (string, error) {
 	if r.kubeSystemUUID == "" {
 		return "", errors.New("no kube-system namespace UUID given")
 	}
 
 	// check if ME with UID exists
-	var monitoredEntities, err = r.dtc.GetMonitoredEntitiesForKubeSystemUUID(r.kubeSystemUUID)
+	var monitoredEntities, err = r.dtc.GetMonitoredEntitiesForKubeSystemUUID(ctx, r.kubeSystemUUID)
 	if err != nil {
 		return "", errors.WithMessage(err, "error while loading MEs")
 	}
 
 	// check if Setting for ME exists
-	settings, err := r.dtc.GetSettingsForMonitoredEntities(monitoredEntities, dtclient.SettingsSchemaId)
+	settings, err := r.dtc.GetSettingsForMonitoredEntities(ctx, monitoredEntities, dtclient.SettingsSchemaId)
 	if err != nil {
 		return "", errors.WithMessage(err, "error trying to check if setting exists")
 	}
 
 	if settings.TotalCount > 0 {
-		_, err = r.handleKubernetesAppEnabled(dynakube, monitoredEntities)
+		_, err = r.handleKubernetesAppEnabled(ctx, dynakube, monitoredEntities)
 		if err != nil {
 			return "", err
 		}
@@ -62,24 +64,24 @@ func (r *Reconciler) createObjectIdIfNotExists(dynakube *dynatracev1beta1.DynaKu
 	}
 
 	// determine newest ME (can be empty string), and create or update a settings object accordingly
-	meID := determineNewestMonitoredEntity(monitoredEntities)
-	objectID, err := r.dtc.CreateOrUpdateKubernetesSetting(r.clusterLabel, r.kubeSystemUUID, meID)
+	meID := DetermineNewestMonitoredEntity(monitoredEntities)
+	objectID, err := r.dtc.CreateOrUpdateKubernetesSetting(ctx, r.clusterLabel, r.kubeSystemUUID, meID)
 	if err != nil {
 		return "", errors.WithMessage(err, "error creating dynatrace settings object")
 	}
 	return objectID, nil
 }
 
-func (r *Reconciler) handleKubernetesAppEnabled(dynakube *dynatracev1beta1.DynaKube, monitoredEntities []dtclient.MonitoredEntity) (string, error) {
+func (r *Reconciler) handleKubernetesAppEnabled(ctx context.Context, dynakube *dynatracev1beta1.DynaKube, monitoredEntities []dtclient.MonitoredEntity) (string, error) {
 	if dynakube.FeatureEnableK8sAppEnabled() {
-		appSettings, err := r.dtc.GetSettingsForMonitoredEntities(monitoredEntities, dtclient.AppTransitionSchemaId)
+		appSettings, err := r.dtc.GetSettingsForMonitoredEntities(ctx, monitoredEntities, dtclient.AppTransitionSchemaId)
 		if err != nil {
 			return "", errors.WithMessage(err, "error trying to check if app setting exists")
 		}
 		if appSettings.TotalCount == 0 {
-			meID := determineNewestMonitoredEntity(monitoredEntities)
+			meID := DetermineNewestMonitoredEntity(monitoredEntities)
 			if meID != "" {
-				transitionSchemaObjectID, err := r.dtc.CreateOrUpdateKubernetesAppSetting(meID)
+				transitionSchemaObjectID, err := r.dtc.CreateOrUpdateKubernetesAppSetting(ctx, meID)
 				if err != nil {
 					log.Info("schema app-transition.kubernetes failed to set", "meID", meID, "err", err)
 					return "", err
@@ -93,8 +95,8 @@ func (r *Reconciler) handleKubernetesAppEnabled(dynakube *dynatracev1beta1.DynaK
 	return "", nil
 }
 
-// determineNewestMonitoredEntity returns the UUID of the newest entities; or empty string if the slice of entities is empty
-func determineNewestMonitoredEntity(entities []dtclient.MonitoredEntity) string {
+// DetermineNewestMonitoredEntity returns the UUID of the newest entities; or empty string if the slice of entities is empty
+func DetermineNewestMonitoredEntity(entities []dtclient.MonitoredEntity) string {
 	if len(entities) == 0 {
 		return ""
 	}
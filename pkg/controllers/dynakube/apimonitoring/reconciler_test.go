@@ -1,6 +1,8 @@
 package apimonitoring
 
 import (
+	"context"
+
 	"testing"
 
 	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
@@ -29,13 +31,13 @@ func createDefaultReconciler(t *testing.T) *Reconciler {
 
 func createReconciler(t *testing.T, uid string, monitoredEntities []dtclient.MonitoredEntity, getSettingsResponse dtclient.GetSettingsResponse, objectID string, meID interface{}) *Reconciler { //nolint:revive // argument-limit doesn't apply to constructors
 	mockClient := mocks.NewClient(t)
-	mockClient.On("GetMonitoredEntitiesForKubeSystemUUID", mock.AnythingOfType("string")).
+	mockClient.On("GetMonitoredEntitiesForKubeSystemUUID", mock.Anything, mock.AnythingOfType("string")).
 		Return(monitoredEntities, nil)
-	mockClient.On("GetSettingsForMonitoredEntities", monitoredEntities, mock.AnythingOfType("string")).
+	mockClient.On("GetSettingsForMonitoredEntities", mock.Anything, monitoredEntities, mock.AnythingOfType("string")).
 		Return(getSettingsResponse, nil)
-	mockClient.On("CreateOrUpdateKubernetesSetting", testName, testUID, mock.AnythingOfType("string")).
+	mockClient.On("CreateOrUpdateKubernetesSetting", mock.Anything, testName, testUID, mock.AnythingOfType("string")).
 		Return(objectID, nil)
-	mockClient.On("CreateOrUpdateKubernetesAppSetting", meID).
+	mockClient.On("CreateOrUpdateKubernetesAppSetting", mock.Anything, meID).
 		Return("transitionSchemaObjectID", nil)
 
 	for _, call := range mockClient.ExpectedCalls {
@@ -51,15 +53,15 @@ func createReconciler(t *testing.T, uid string, monitoredEntities []dtclient.Mon
 
 func createReconcilerWithError(t *testing.T, monitoredEntitiesError error, getSettingsResponseError error, createSettingsResponseError error, createAppSettingsResponseError error) *Reconciler {
 	mockClient := mocks.NewClient(t)
-	mockClient.On("GetMonitoredEntitiesForKubeSystemUUID", mock.AnythingOfType("string")).
+	mockClient.On("GetMonitoredEntitiesForKubeSystemUUID", mock.Anything, mock.AnythingOfType("string")).
 		Return([]dtclient.MonitoredEntity{}, monitoredEntitiesError)
-	mockClient.On("GetSettingsForMonitoredEntities",
+	mockClient.On("GetSettingsForMonitoredEntities", mock.Anything,
 		mock.AnythingOfType("[]dynatrace.MonitoredEntity"),
 		mock.AnythingOfType("string")).
 		Return(dtclient.GetSettingsResponse{}, getSettingsResponseError)
-	mockClient.On("CreateOrUpdateKubernetesSetting", testName, testUID, mock.AnythingOfType("string")).
+	mockClient.On("CreateOrUpdateKubernetesSetting", mock.Anything, testName, testUID, mock.AnythingOfType("string")).
 		Return("", createSettingsResponseError)
-	mockClient.On("CreateOrUpdateKubernetesAppSetting", mock.AnythingOfType("string")).
+	mockClient.On("CreateOrUpdateKubernetesAppSetting", mock.Anything, mock.AnythingOfType("string")).
 		Return("", createAppSettingsResponseError)
 
 	for _, call := range mockClient.ExpectedCalls {
@@ -87,7 +89,7 @@ func TestReconcile(t *testing.T) {
 		r := createDefaultReconciler(t)
 
 		// act
-		err := r.Reconcile(dynakube)
+		err := r.Reconcile(context.Background(), dynakube)
 
 		// assert
 		assert.NoError(t, err)
@@ -98,7 +100,7 @@ func TestReconcile(t *testing.T) {
 		r := createReconciler(t, testUID, []dtclient.MonitoredEntity{}, dtclient.GetSettingsResponse{}, testObjectID, "")
 
 		// act
-		actual, err := r.createObjectIdIfNotExists(dynakube)
+		actual, err := r.createObjectIdIfNotExists(context.Background(), dynakube)
 
 		// assert
 		assert.NoError(t, err)
@@ -111,7 +113,7 @@ func TestReconcile(t *testing.T) {
 		r := createReconciler(t, testUID, entities, dtclient.GetSettingsResponse{}, testObjectID, "")
 
 		// act
-		actual, err := r.createObjectIdIfNotExists(dynakube)
+		actual, err := r.createObjectIdIfNotExists(context.Background(), dynakube)
 
 		// assert
 		assert.NoError(t, err)
@@ -124,7 +126,7 @@ func TestReconcile(t *testing.T) {
 		r := createReconciler(t, testUID, entities, dtclient.GetSettingsResponse{TotalCount: 1}, testObjectID, "")
 
 		// act
-		actual, err := r.createObjectIdIfNotExists(dynakube)
+		actual, err := r.createObjectIdIfNotExists(context.Background(), dynakube)
 
 		// assert
 		assert.NoError(t, err)
@@ -139,7 +141,7 @@ func TestReconcileErrors(t *testing.T) {
 		r := createReconciler(t, "", []dtclient.MonitoredEntity{}, dtclient.GetSettingsResponse{}, testObjectID, "")
 
 		// act
-		actual, err := r.createObjectIdIfNotExists(dynakube)
+		actual, err := r.createObjectIdIfNotExists(context.Background(), dynakube)
 
 		// assert
 		assert.Error(t, err)
@@ -151,7 +153,7 @@ func TestReconcileErrors(t *testing.T) {
 		r := createReconcilerWithError(t, errors.New("could not get monitored entities"), nil, nil, nil)
 
 		// act
-		actual, err := r.createObjectIdIfNotExists(dynakube)
+		actual, err := r.createObjectIdIfNotExists(context.Background(), dynakube)
 
 		// assert
 		assert.Error(t, err)
@@ -163,7 +165,7 @@ func TestReconcileErrors(t *testing.T) {
 		r := createReconcilerWithError(t, nil, errors.New("could not get settings for monitored entities"), nil, nil)
 
 		// act
-		actual, err := r.createObjectIdIfNotExists(dynakube)
+		actual, err := r.createObjectIdIfNotExists(context.Background(), dynakube)
 
 		// assert
 		assert.Error(t, err)
@@ -175,7 +177,7 @@ func TestReconcileErrors(t *testing.T) {
 		r := createReconcilerWithError(t, nil, nil, errors.New("could not create monitored entity"), nil)
 
 		// act
-		actual, err := r.createObjectIdIfNotExists(dynakube)
+		actual, err := r.createObjectIdIfNotExists(context.Background(), dynakube)
 
 		// assert
 		assert.Error(t, err)
@@ -187,7 +189,7 @@ func TestReconcileErrors(t *testing.T) {
 		r := createReconcilerWithError(t, nil, nil, nil, errors.New("could not create monitored entity"))
 
 		// act
-		_, err := r.createObjectIdIfNotExists(dynakube)
+		_, err := r.createObjectIdIfNotExists(context.Background(), dynakube)
 
 		// assert
 		assert.NoError(t, err)
@@ -201,7 +203,7 @@ func TestHandleKubernetesAppEnabled(t *testing.T) {
 		r := createReconciler(t, "", []dtclient.MonitoredEntity{}, dtclient.GetSettingsResponse{}, "", "")
 
 		// act
-		_, err := r.handleKubernetesAppEnabled(dynakube, []dtclient.MonitoredEntity{})
+		_, err := r.handleKubernetesAppEnabled(context.Background(), dynakube, []dtclient.MonitoredEntity{})
 
 		// assert
 		assert.NoError(t, err)
@@ -216,7 +218,7 @@ func TestHandleKubernetesAppEnabled(t *testing.T) {
 		r := createReconciler(t, "", entities, dtclient.GetSettingsResponse{TotalCount: 1}, "", "")
 
 		// act
-		_, err := r.handleKubernetesAppEnabled(dynakube, entities)
+		_, err := r.handleKubernetesAppEnabled(context.Background(), dynakube, entities)
 
 		// assert
 		assert.NoError(t, err)
@@ -227,7 +229,7 @@ func TestHandleKubernetesAppEnabled(t *testing.T) {
 		r := createReconcilerWithError(t, nil, errors.New("could not get monitored entities"), nil, nil)
 
 		// act
-		_, err := r.handleKubernetesAppEnabled(dynakube, []dtclient.MonitoredEntity{})
+		_, err := r.handleKubernetesAppEnabled(context.Background(), dynakube, []dtclient.MonitoredEntity{})
 
 		// assert
 		assert.Error(t, err)
@@ -241,7 +243,7 @@ func TestHandleKubernetesAppEnabled(t *testing.T) {
 			{EntityId: meID, DisplayName: "operator test entity newest", LastSeenTms: 1639483869085},
 		}
 		// act
-		_, err := r.handleKubernetesAppEnabled(dynakube, entities)
+		_, err := r.handleKubernetesAppEnabled(context.Background(), dynakube, entities)
 
 		// assert
 		assert.Error(t, err)
@@ -255,7 +257,7 @@ func TestHandleKubernetesAppEnabled(t *testing.T) {
 		}
 		r := createReconciler(t, "", entities, dtclient.GetSettingsResponse{}, "", meID)
 		// act
-		id, err := r.handleKubernetesAppEnabled(dynakube, entities)
+		id, err := r.handleKubernetesAppEnabled(context.Background(), dynakube, entities)
 		// assert
 		assert.NoError(t, err)
 		assert.Equal(t, "transitionSchemaObjectID", id)
@@ -275,7 +277,7 @@ func TestDetermineNewestMonitoredEntity(t *testing.T) {
 		}
 
 		// act
-		newestEntity := determineNewestMonitoredEntity(entities)
+		newestEntity := DetermineNewestMonitoredEntity(entities)
 
 		// assert
 		assert.NotNil(t, newestEntity)
@@ -1,17 +1,23 @@
 package dynakube
 
 import (
+	"fmt"
+	"strings"
+
 	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/apierrors"
+	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func (controller *Controller) setConditionTokenError(dynakube *dynatracev1beta1.DynaKube, err error) {
 	tokenErrorCondition := metav1.Condition{
-		Type:    dynatracev1beta1.TokenConditionType,
-		Status:  metav1.ConditionFalse,
-		Reason:  dynatracev1beta1.ReasonTokenError,
-		Message: err.Error(),
+		Type:               dynatracev1beta1.TokenConditionType,
+		Status:             metav1.ConditionFalse,
+		Reason:             reasonForError(err, dynatracev1beta1.ReasonTokenError),
+		Message:            err.Error(),
+		ObservedGeneration: dynakube.Generation,
 	}
 
 	controller.setAndLogCondition(dynakube, tokenErrorCondition)
@@ -19,14 +25,77 @@ func (controller *Controller) setConditionTokenError(dynakube *dynatracev1beta1.
 
 func (controller *Controller) setConditionTokenReady(dynakube *dynatracev1beta1.DynaKube) {
 	tokenErrorCondition := metav1.Condition{
-		Type:   dynatracev1beta1.TokenConditionType,
-		Status: metav1.ConditionTrue,
-		Reason: dynatracev1beta1.ReasonTokenReady,
+		Type:               dynatracev1beta1.TokenConditionType,
+		Status:             metav1.ConditionTrue,
+		Reason:             dynatracev1beta1.ReasonTokenReady,
+		ObservedGeneration: dynakube.Generation,
 	}
 
 	controller.setAndLogCondition(dynakube, tokenErrorCondition)
 }
 
+func (controller *Controller) setConditionTokenUnverified(dynakube *dynatracev1beta1.DynaKube, err error) {
+	tokenUnverifiedCondition := metav1.Condition{
+		Type:               dynatracev1beta1.TokenConditionType,
+		Status:             metav1.ConditionTrue,
+		Reason:             dynatracev1beta1.ReasonTokenUnverified,
+		Message:            "Dynatrace API is not reachable, the token scopes are verified once it is reachable again: " + err.Error(),
+		ObservedGeneration: dynakube.Generation,
+	}
+
+	controller.setAndLogCondition(dynakube, tokenUnverifiedCondition)
+}
+
+// setConditionReady summarizes the conditions of all components in the Ready condition,
+// an error of the reconciliation always marks the DynaKube as not ready
+func (controller *Controller) setConditionReady(dynakube *dynatracev1beta1.DynaKube, reconcileErr error) {
+	if reconcileErr != nil {
+		dynakube.SetCondition(dynatracev1beta1.ReadyConditionType, metav1.ConditionFalse, reasonForError(reconcileErr, dynatracev1beta1.ReasonReconcileError), reconcileErr.Error())
+		return
+	}
+
+	var notReadyComponents []string
+	for _, conditionType := range dynatracev1beta1.ComponentConditionTypes {
+		condition := meta.FindStatusCondition(dynakube.Status.Conditions, conditionType)
+		if condition == nil || condition.Status == metav1.ConditionTrue {
+			continue
+		}
+		notReadyComponents = append(notReadyComponents, fmt.Sprintf("%s (%s): %s", condition.Type, condition.Reason, condition.Message))
+	}
+
+	if len(notReadyComponents) > 0 {
+		dynakube.SetCondition(dynatracev1beta1.ReadyConditionType, metav1.ConditionFalse, dynatracev1beta1.ReasonComponentsNotReady, strings.Join(notReadyComponents, "; "))
+		return
+	}
+	dynakube.SetCondition(dynatracev1beta1.ReadyConditionType, metav1.ConditionTrue, dynatracev1beta1.ReasonAllComponentsReady, "")
+}
+
+// reasonForError returns the condition reason for a failed request to the Dynatrace API, or the fallback for any other error
+func reasonForError(err error, fallback string) string {
+	if errors.As(err, &apierrors.MissingScopesError{}) {
+		return dynatracev1beta1.ReasonMissingScopes
+	}
+
+	switch apierrors.Class(err) {
+	case apierrors.ErrUnauthorized:
+		return dynatracev1beta1.ReasonUnauthorized
+	case apierrors.ErrForbidden:
+		return dynatracev1beta1.ReasonForbidden
+	case apierrors.ErrNotFound:
+		return dynatracev1beta1.ReasonNotFound
+	case apierrors.ErrRateLimited:
+		return dynatracev1beta1.ReasonRateLimited
+	case apierrors.ErrServer:
+		return dynatracev1beta1.ReasonServerError
+	case apierrors.ErrNetwork:
+		return dynatracev1beta1.ReasonNetworkError
+	case apierrors.ErrTLS:
+		return dynatracev1beta1.ReasonTLSError
+	}
+
+	return fallback
+}
+
 func (controller *Controller) setAndLogCondition(dynakube *dynatracev1beta1.DynaKube, newCondition metav1.Condition) {
 	controller.removeDeprecatedConditionTypes(dynakube)
 	statusCondition := meta.FindStatusCondition(dynakube.Status.Conditions, newCondition.Type)
@@ -50,7 +119,8 @@ func areStatusesEqual(statusCondition *metav1.Condition, newCondition metav1.Con
 	return statusCondition != nil &&
 		statusCondition.Reason == newCondition.Reason &&
 		statusCondition.Message == newCondition.Message &&
-		statusCondition.Status == newCondition.Status
+		statusCondition.Status == newCondition.Status &&
+		statusCondition.ObservedGeneration == newCondition.ObservedGeneration
 }
 
 func (controller *Controller) removeDeprecatedConditionTypes(dynakube *dynatracev1beta1.DynaKube) {
@@ -64,3 +134,13 @@ func (controller *Controller) removeDeprecatedConditionTypes(dynakube *dynatrace
 		meta.RemoveStatusCondition(&dynakube.Status.Conditions, dynatracev1beta1.DataIngestTokenConditionType)
 	}
 }
+
+// keepLastTransitionTime restores the lastTransitionTime of a condition that was reset and set again with the same status during the reconciliation,
+// so the status of the DynaKube isn't updated on every reconciliation
+func keepLastTransitionTime(oldConditions, conditions []metav1.Condition, conditionType string) {
+	oldCondition := meta.FindStatusCondition(oldConditions, conditionType)
+	condition := meta.FindStatusCondition(conditions, conditionType)
+	if oldCondition != nil && condition != nil && oldCondition.Status == condition.Status {
+		condition.LastTransitionTime = oldCondition.LastTransitionTime
+	}
+}
@@ -0,0 +1,132 @@
+package dynakube
+
+import (
+	"crypto/x509"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/apierrors"
+	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSetConditionReady(t *testing.T) {
+	controller := &Controller{}
+
+	t.Run("all components ready", func(t *testing.T) {
+		dynakube := &dynatracev1beta1.DynaKube{ObjectMeta: metav1.ObjectMeta{Generation: 3}}
+		dynakube.SetConditionReconciled(dynatracev1beta1.ConnectionInfoConditionType)
+		dynakube.SetConditionReconciled(dynatracev1beta1.ActiveGateConditionType)
+
+		controller.setConditionReady(dynakube, nil)
+
+		condition := meta.FindStatusCondition(dynakube.Status.Conditions, dynatracev1beta1.ReadyConditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionTrue, condition.Status)
+		assert.Equal(t, dynatracev1beta1.ReasonAllComponentsReady, condition.Reason)
+		assert.Equal(t, int64(3), condition.ObservedGeneration)
+	})
+	t.Run("component not ready", func(t *testing.T) {
+		dynakube := &dynatracev1beta1.DynaKube{}
+		dynakube.SetConditionReconciled(dynatracev1beta1.ConnectionInfoConditionType)
+		dynakube.SetCondition(dynatracev1beta1.OneAgentConditionType, metav1.ConditionFalse, dynatracev1beta1.ReasonRolloutInProgress, "1 of 2 OneAgent pods are ready")
+
+		controller.setConditionReady(dynakube, nil)
+
+		condition := meta.FindStatusCondition(dynakube.Status.Conditions, dynatracev1beta1.ReadyConditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionFalse, condition.Status)
+		assert.Equal(t, dynatracev1beta1.ReasonComponentsNotReady, condition.Reason)
+		assert.Equal(t, "OneAgent (RolloutInProgress): 1 of 2 OneAgent pods are ready", condition.Message)
+	})
+	t.Run("reconcile error", func(t *testing.T) {
+		dynakube := &dynatracev1beta1.DynaKube{}
+		dynakube.SetConditionReconciled(dynatracev1beta1.ConnectionInfoConditionType)
+
+		controller.setConditionReady(dynakube, errors.New("istio not installed"))
+
+		condition := meta.FindStatusCondition(dynakube.Status.Conditions, dynatracev1beta1.ReadyConditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionFalse, condition.Status)
+		assert.Equal(t, dynatracev1beta1.ReasonReconcileError, condition.Reason)
+		assert.Equal(t, "istio not installed", condition.Message)
+	})
+	t.Run("failed request to the Dynatrace API", func(t *testing.T) {
+		dynakube := &dynatracev1beta1.DynaKube{}
+
+		controller.setConditionReady(dynakube, errors.WithMessage(dtclient.ServerError{Code: http.StatusUnauthorized, Message: "Token Authentication failed"}, "failed to get connection info"))
+
+		condition := meta.FindStatusCondition(dynakube.Status.Conditions, dynatracev1beta1.ReadyConditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionFalse, condition.Status)
+		assert.Equal(t, dynatracev1beta1.ReasonUnauthorized, condition.Reason)
+	})
+	t.Run("lastTransitionTime only changes with the status", func(t *testing.T) {
+		dynakube := &dynatracev1beta1.DynaKube{}
+		controller.setConditionReady(dynakube, nil)
+		transitionTime := metav1.NewTime(metav1.Now().Add(-time.Hour))
+		meta.FindStatusCondition(dynakube.Status.Conditions, dynatracev1beta1.ReadyConditionType).LastTransitionTime = transitionTime
+
+		dynakube.Generation = 2
+		controller.setConditionReady(dynakube, nil)
+
+		condition := meta.FindStatusCondition(dynakube.Status.Conditions, dynatracev1beta1.ReadyConditionType)
+		assert.Equal(t, transitionTime, condition.LastTransitionTime)
+		assert.Equal(t, int64(2), condition.ObservedGeneration)
+	})
+}
+
+func TestKeepLastTransitionTime(t *testing.T) {
+	lastTransitionTime := metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+	oldConditions := []metav1.Condition{{
+		Type:               dynatracev1beta1.UpdatePendingConditionType,
+		Status:             metav1.ConditionTrue,
+		LastTransitionTime: lastTransitionTime,
+	}}
+
+	t.Run("condition set again keeps its lastTransitionTime", func(t *testing.T) {
+		dynakube := &dynatracev1beta1.DynaKube{}
+		dynakube.SetConditionUpdatePending()
+
+		keepLastTransitionTime(oldConditions, dynakube.Status.Conditions, dynatracev1beta1.UpdatePendingConditionType)
+
+		condition := meta.FindStatusCondition(dynakube.Status.Conditions, dynatracev1beta1.UpdatePendingConditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, lastTransitionTime, condition.LastTransitionTime)
+	})
+	t.Run("condition not set again stays removed", func(t *testing.T) {
+		dynakube := &dynatracev1beta1.DynaKube{}
+
+		keepLastTransitionTime(oldConditions, dynakube.Status.Conditions, dynatracev1beta1.UpdatePendingConditionType)
+
+		assert.Empty(t, dynakube.Status.Conditions)
+	})
+}
+
+func TestReasonForError(t *testing.T) {
+	testCases := []struct {
+		err            error
+		expectedReason string
+	}{
+		{dtclient.ServerError{Code: http.StatusUnauthorized}, dynatracev1beta1.ReasonUnauthorized},
+		{dtclient.ServerError{Code: http.StatusForbidden}, dynatracev1beta1.ReasonForbidden},
+		{apierrors.MissingScopesError{Subject: "oauth client", Scopes: []string{"a"}}, dynatracev1beta1.ReasonMissingScopes},
+		{dtclient.HostNotFoundErr{IP: "1.2.3.4"}, dynatracev1beta1.ReasonNotFound},
+		{dtclient.ServerError{Code: http.StatusTooManyRequests}, dynatracev1beta1.ReasonRateLimited},
+		{dtclient.ServerError{Code: http.StatusServiceUnavailable}, dynatracev1beta1.ReasonServerError},
+		{apierrors.FromTransport(&url.Error{Op: "Get", Err: errors.New("connection refused")}), dynatracev1beta1.ReasonNetworkError},
+		{apierrors.FromTransport(&url.Error{Op: "Get", Err: x509.UnknownAuthorityError{}}), dynatracev1beta1.ReasonTLSError},
+		{errors.New("istio not installed"), dynatracev1beta1.ReasonTokenError},
+	}
+
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expectedReason, reasonForError(testCase.err, dynatracev1beta1.ReasonTokenError), testCase.err.Error())
+	}
+}
@@ -3,6 +3,7 @@ package connectioninfo
 import (
 	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
 	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/dynatraceapi"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/hasher"
 	k8ssecret "github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/secret"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/timeprovider"
@@ -48,6 +49,30 @@ func (r Reconciler) updateDynakubeStatus(ctx context.Context) error {
 }
 
 func (r *Reconciler) Reconcile(ctx context.Context) error {
+	err := r.reconcileConnectionInfo(ctx)
+	if err != nil && r.canUseLastConnectionInfo(err) {
+		log.Info("Dynatrace API is not reachable, using the last known connection info", "error", err.Error())
+		r.dynakube.SetCondition(dynatracev1beta1.ConnectionInfoConditionType, metav1.ConditionTrue, dynatracev1beta1.ReasonOffline,
+			"Dynatrace API is not reachable, the last known connection info is used: "+err.Error())
+		return nil
+	}
+	if err != nil {
+		r.dynakube.SetConditionError(dynatracev1beta1.ConnectionInfoConditionType, err)
+		return err
+	}
+	r.dynakube.SetConditionReconciled(dynatracev1beta1.ConnectionInfoConditionType)
+	return nil
+}
+
+// canUseLastConnectionInfo is true if the connection info couldn't be updated because the Dynatrace API isn't reachable,
+// but a deployment API mirror is used and the connection info was received before
+func (r *Reconciler) canUseLastConnectionInfo(err error) bool {
+	return r.dynakube.UsesMirror() &&
+		dynatraceapi.IsOffline(err) &&
+		r.dynakube.Status.OneAgent.ConnectionInfoStatus.TenantUUID != ""
+}
+
+func (r *Reconciler) reconcileConnectionInfo(ctx context.Context) error {
 	oldStatus := r.dynakube.Status.DeepCopy()
 
 	if !r.dynakube.FeatureDisableActivegateRawImage() {
@@ -98,7 +123,7 @@ func (r *Reconciler) reconcileOneAgentConnectionInfo(ctx context.Context) error
 		return nil
 	}
 
-	connectionInfo, err := r.dtc.GetOneAgentConnectionInfo()
+	connectionInfo, err := r.dtc.GetOneAgentConnectionInfo(ctx)
 	if err != nil {
 		return errors.WithMessage(err, "failed to get OneAgent connection info")
 	}
@@ -144,10 +169,22 @@ func copyCommunicationHosts(dest *dynatracev1beta1.OneAgentConnectionInfoStatus,
 	}
 }
 
+func (r *Reconciler) activeGateTenantSecretNames() []string {
+	secretNames := []string{r.dynakube.ActivegateTenantSecret()}
+	for _, pool := range r.dynakube.Spec.ActiveGate.Pools {
+		secretNames = append(secretNames, r.dynakube.ActiveGatePoolTenantSecret(pool.Name))
+	}
+	return secretNames
+}
+
 func (r *Reconciler) reconcileActiveGateConnectionInfo(ctx context.Context) error {
-	needsUpdate, err := r.needsUpdate(ctx, r.dynakube.ActivegateTenantSecret(), r.dynakube.IsActiveGateConnectionInfoUpdateAllowed)
-	if err != nil {
-		return err
+	needsUpdate := false
+	for _, secretName := range r.activeGateTenantSecretNames() {
+		secretNeedsUpdate, err := r.needsUpdate(ctx, secretName, r.dynakube.IsActiveGateConnectionInfoUpdateAllowed)
+		if err != nil {
+			return err
+		}
+		needsUpdate = needsUpdate || secretNeedsUpdate
 	}
 	if !needsUpdate {
 		log.Info(dynatracev1beta1.GetCacheValidMessage(
@@ -157,7 +194,7 @@ func (r *Reconciler) reconcileActiveGateConnectionInfo(ctx context.Context) erro
 		return nil
 	}
 
-	connectionInfo, err := r.dtc.GetActiveGateConnectionInfo()
+	connectionInfo, err := r.dtc.GetActiveGateConnectionInfo(ctx)
 	if err != nil {
 		log.Info("failed to get activegate connection info")
 		return err
@@ -165,9 +202,11 @@ func (r *Reconciler) reconcileActiveGateConnectionInfo(ctx context.Context) erro
 
 	r.updateDynakubeActiveGateStatus(connectionInfo)
 
-	err = r.createTenantTokenSecret(ctx, r.dynakube.ActivegateTenantSecret(), connectionInfo.ConnectionInfo)
-	if err != nil {
-		return err
+	for _, secretName := range r.activeGateTenantSecretNames() {
+		err = r.createTenantTokenSecret(ctx, secretName, connectionInfo.ConnectionInfo)
+		if err != nil {
+			return err
+		}
 	}
 
 	log.Info("activegate connection info updated")
@@ -2,17 +2,22 @@ package connectioninfo
 
 import (
 	"context"
+	"errors"
+	"net/url"
 	"testing"
 	"time"
 
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/mirror"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
 	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
 	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
 	"github.com/Dynatrace/dynatrace-operator/test/mocks/pkg/clients/dynatrace"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -48,8 +53,8 @@ func TestReconcile_ConnectionInfo(t *testing.T) {
 		}}
 
 	dtc := mocks.NewClient(t)
-	dtc.On("GetActiveGateConnectionInfo").Return(getTestActiveGateConnectionInfo(), nil).Maybe()
-	dtc.On("GetOneAgentConnectionInfo").Return(getTestOneAgentConnectionInfo(), nil).Maybe()
+	dtc.On("GetActiveGateConnectionInfo", mock.Anything).Return(getTestActiveGateConnectionInfo(), nil).Maybe()
+	dtc.On("GetOneAgentConnectionInfo", mock.Anything).Return(getTestOneAgentConnectionInfo(), nil).Maybe()
 
 	t.Run(`store OneAgent connection info to DynaKube status`, func(t *testing.T) {
 		fakeClient := fake.NewClient(&dynakube)
@@ -178,8 +183,8 @@ func TestReconcile_NoOneAgentCommunicationHosts(t *testing.T) {
 		}}
 
 	dtc := mocks.NewClient(t)
-	dtc.On("GetActiveGateConnectionInfo").Return(getTestActiveGateConnectionInfo(), nil)
-	dtc.On("GetOneAgentConnectionInfo").Return(dtclient.OneAgentConnectionInfo{
+	dtc.On("GetActiveGateConnectionInfo", mock.Anything).Return(getTestActiveGateConnectionInfo(), nil)
+	dtc.On("GetOneAgentConnectionInfo", mock.Anything).Return(dtclient.OneAgentConnectionInfo{
 		ConnectionInfo: dtclient.ConnectionInfo{
 			TenantUUID:  testTenantUUID,
 			TenantToken: testTenantToken,
@@ -199,6 +204,69 @@ func TestReconcile_NoOneAgentCommunicationHosts(t *testing.T) {
 	assert.Empty(t, dynakube.Status.OneAgent.ConnectionInfoStatus.CommunicationHosts)
 }
 
+func TestReconcile_Offline(t *testing.T) {
+	offlineErr := &url.Error{Op: "Get", URL: "https://test.live.dynatrace.com/api", Err: errors.New("connection refused")}
+	knownConnectionInfo := dynatracev1beta1.OneAgentConnectionInfoStatus{
+		ConnectionInfoStatus: dynatracev1beta1.ConnectionInfoStatus{
+			TenantUUID: testOutdated,
+			Endpoints:  testOutdated,
+		},
+	}
+
+	newDynakube := func(mirrorURL string) *dynatracev1beta1.DynaKube {
+		dynakube := &dynatracev1beta1.DynaKube{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testNamespace,
+				Name:      testName,
+			},
+			Spec: dynatracev1beta1.DynaKubeSpec{
+				Mirror: &mirror.Spec{URL: mirrorURL},
+			},
+		}
+		dynakube.Status.OneAgent.ConnectionInfoStatus = knownConnectionInfo
+		return dynakube
+	}
+
+	newClient := func(t *testing.T) *mocks.Client {
+		dtc := mocks.NewClient(t)
+		dtc.On("GetActiveGateConnectionInfo", mock.Anything).Return(dtclient.ActiveGateConnectionInfo{}, offlineErr)
+		return dtc
+	}
+
+	t.Run(`last known connection info is kept when using a mirror`, func(t *testing.T) {
+		dynakube := newDynakube("file:///mirror")
+		fakeClient := fake.NewClient(dynakube)
+
+		err := NewReconciler(fakeClient, fakeClient, scheme.Scheme, dynakube, newClient(t)).Reconcile(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, knownConnectionInfo, dynakube.Status.OneAgent.ConnectionInfoStatus)
+		condition := meta.FindStatusCondition(dynakube.Status.Conditions, dynatracev1beta1.ConnectionInfoConditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionTrue, condition.Status)
+		assert.Equal(t, dynatracev1beta1.ReasonOffline, condition.Reason)
+	})
+	t.Run(`fails without mirror`, func(t *testing.T) {
+		dynakube := newDynakube("")
+		fakeClient := fake.NewClient(dynakube)
+
+		err := NewReconciler(fakeClient, fakeClient, scheme.Scheme, dynakube, newClient(t)).Reconcile(context.Background())
+		require.Error(t, err)
+
+		condition := meta.FindStatusCondition(dynakube.Status.Conditions, dynatracev1beta1.ConnectionInfoConditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, dynatracev1beta1.ReasonReconcileError, condition.Reason)
+	})
+	t.Run(`fails if connection info was never received`, func(t *testing.T) {
+		dynakube := newDynakube("file:///mirror")
+		dynakube.Status.OneAgent.ConnectionInfoStatus = dynatracev1beta1.OneAgentConnectionInfoStatus{}
+		fakeClient := fake.NewClient(dynakube)
+
+		err := NewReconciler(fakeClient, fakeClient, scheme.Scheme, dynakube, newClient(t)).Reconcile(context.Background())
+		require.Error(t, err)
+	})
+}
+
 func getTestOneAgentConnectionInfo() dtclient.OneAgentConnectionInfo {
 	return dtclient.OneAgentConnectionInfo{
 		ConnectionInfo: dtclient.ConnectionInfo{
@@ -244,8 +312,8 @@ func TestReconcile_ActivegateSecret(t *testing.T) {
 			Name:      testName,
 		}}
 	dtc := mocks.NewClient(t)
-	dtc.On("GetActiveGateConnectionInfo").Return(getTestActiveGateConnectionInfo(), nil)
-	dtc.On("GetOneAgentConnectionInfo").Return(getTestOneAgentConnectionInfo(), nil)
+	dtc.On("GetActiveGateConnectionInfo", mock.Anything).Return(getTestActiveGateConnectionInfo(), nil)
+	dtc.On("GetOneAgentConnectionInfo", mock.Anything).Return(getTestOneAgentConnectionInfo(), nil)
 
 	t.Run(`create activegate secret`, func(t *testing.T) {
 		fakeClient := fake.NewClient(dynakube)
@@ -292,6 +360,51 @@ func TestReconcile_ActivegateSecret(t *testing.T) {
 	})
 }
 
+func TestReconcile_ActivegatePoolSecrets(t *testing.T) {
+	dynakube := &dynatracev1beta1.DynaKube{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Name:      testName,
+		},
+		Spec: dynatracev1beta1.DynaKubeSpec{
+			ActiveGate: dynatracev1beta1.ActiveGateSpec{
+				Pools: []dynatracev1beta1.ActiveGatePoolSpec{
+					{Name: "zone-a", Capabilities: []dynatracev1beta1.CapabilityDisplayName{dynatracev1beta1.RoutingCapability.DisplayName}},
+				},
+			},
+		},
+	}
+	dtc := mocks.NewClient(t)
+	dtc.On("GetActiveGateConnectionInfo", mock.Anything).Return(getTestActiveGateConnectionInfo(), nil)
+	dtc.On("GetOneAgentConnectionInfo", mock.Anything).Return(getTestOneAgentConnectionInfo(), nil)
+
+	t.Run(`create activegate pool secret`, func(t *testing.T) {
+		fakeClient := fake.NewClient(dynakube)
+
+		r := NewReconciler(fakeClient, fakeClient, scheme.Scheme, dynakube, dtc)
+		err := r.Reconcile(context.Background())
+		require.NoError(t, err)
+
+		var actualSecret corev1.Secret
+		err = fakeClient.Get(context.Background(), client.ObjectKey{Name: dynakube.ActiveGatePoolTenantSecret("zone-a"), Namespace: testNamespace}, &actualSecret)
+		require.NoError(t, err)
+		assert.Equal(t, []byte(testTenantToken), actualSecret.Data[TenantTokenName])
+	})
+	t.Run(`create missing activegate pool secret, ignore timestamp`, func(t *testing.T) {
+		fakeClient := fake.NewClient(dynakube, buildActiveGateSecret(*dynakube, testOutdated))
+		dynakube.Status.ActiveGate.ConnectionInfoStatus.LastRequest = metav1.Now()
+
+		r := NewReconciler(fakeClient, fakeClient, scheme.Scheme, dynakube, dtc)
+		err := r.Reconcile(context.Background())
+		require.NoError(t, err)
+
+		var actualSecret corev1.Secret
+		err = fakeClient.Get(context.Background(), client.ObjectKey{Name: dynakube.ActiveGatePoolTenantSecret("zone-a"), Namespace: testNamespace}, &actualSecret)
+		require.NoError(t, err)
+		assert.Equal(t, []byte(testTenantToken), actualSecret.Data[TenantTokenName])
+	})
+}
+
 func buildActiveGateSecret(dynakube dynatracev1beta1.DynaKube, token string) *corev1.Secret {
 	return &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
@@ -315,7 +428,7 @@ func TestReconcile_OneagentSecret(t *testing.T) {
 		}}
 
 	dtc := mocks.NewClient(t)
-	dtc.On("GetOneAgentConnectionInfo").Return(getTestOneAgentConnectionInfo(), nil)
+	dtc.On("GetOneAgentConnectionInfo", mock.Anything).Return(getTestOneAgentConnectionInfo(), nil)
 
 	t.Run(`create oneagent secret`, func(t *testing.T) {
 		fakeClient := fake.NewClient(dynakube)
@@ -37,14 +37,18 @@ func NewReconciler(clt client.Client, apiReader client.Reader, scheme *runtime.S
 }
 
 func (r *Reconciler) Reconcile(ctx context.Context) error {
-	if r.dynakube.Spec.CustomPullSecret == "" {
-		err := r.reconcilePullSecret(ctx)
-		if err != nil {
-			log.Info("could not reconcile pull secret")
-			return errors.WithStack(err)
-		}
+	if r.dynakube.Spec.CustomPullSecret != "" {
+		r.dynakube.RemoveCondition(dynatracev1beta1.PullSecretConditionType)
+		return nil
 	}
 
+	err := r.reconcilePullSecret(ctx)
+	if err != nil {
+		log.Info("could not reconcile pull secret")
+		r.dynakube.SetConditionError(dynatracev1beta1.PullSecretConditionType, err)
+		return errors.WithStack(err)
+	}
+	r.dynakube.SetConditionReconciled(dynatracev1beta1.PullSecretConditionType)
 	return nil
 }
 
@@ -0,0 +1,281 @@
+package dtsettings
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/settings"
+	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/apimonitoring"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/hasher"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/timeprovider"
+	"github.com/pkg/errors"
+)
+
+// Reconciler creates, updates and deletes the settings objects defined in the DynaKube via the Settings 2.0 API.
+// Settings objects which were changed in Dynatrace are reverted, deleted ones are recreated.
+type Reconciler struct {
+	dtc          dtclient.Client
+	dynakube     *dynatracev1beta1.DynaKube
+	timeProvider *timeprovider.Provider
+
+	kubernetesClusterScope string
+}
+
+func NewReconciler(dtc dtclient.Client, dynakube *dynatracev1beta1.DynaKube, timeProvider *timeprovider.Provider) *Reconciler {
+	return &Reconciler{
+		dtc:          dtc,
+		dynakube:     dynakube,
+		timeProvider: timeProvider,
+	}
+}
+
+// Reconcile reconciles all settings objects, a failing object doesn't stop the reconciliation of the other ones.
+// The objects are only compared with Dynatrace if their spec changed or the api request threshold has passed.
+func (r *Reconciler) Reconcile(ctx context.Context) error {
+	var failures []string
+
+	statuses := make([]settings.Status, 0, len(r.dynakube.Spec.Settings))
+	for _, previous := range r.removedObjects() {
+		err := r.deleteObject(ctx, previous)
+		if err != nil {
+			// kept in the status, so deleting is retried
+			statuses = append(statuses, previous)
+			failures = append(failures, fmt.Sprintf("%s: %s", previous.Name, err.Error()))
+		}
+	}
+
+	for _, spec := range r.dynakube.Spec.Settings {
+		status, err := r.reconcileObject(ctx, spec, r.findStatus(spec.Name))
+		if status != nil {
+			statuses = append(statuses, *status)
+		}
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %s", spec.Name, err.Error()))
+		}
+	}
+
+	r.dynakube.Status.Settings = statuses
+	if len(r.dynakube.Status.Settings) == 0 {
+		r.dynakube.Status.Settings = nil
+	}
+
+	if len(failures) > 0 {
+		return errors.Errorf("failed to reconcile settings objects: %s", strings.Join(failures, "; "))
+	}
+	return nil
+}
+
+// reconcileObject returns the status of the settings object after the reconciliation,
+// or nil if there is no settings object in Dynatrace
+func (r *Reconciler) reconcileObject(ctx context.Context, spec settings.Spec, previous *settings.Status) (*settings.Status, error) {
+	hash, err := hasher.GenerateHash(spec)
+	if err != nil {
+		return previous, err
+	}
+
+	specChanged := previous == nil || previous.Hash != hash
+	if !specChanged && !r.timeProvider.IsOutdated(&previous.LastRequest, r.dynakube.FeatureApiRequestThreshold()) {
+		log.Info(dynatracev1beta1.GetCacheValidMessage("settings object comparison", previous.LastRequest, r.dynakube.FeatureApiRequestThreshold()), "name", spec.Name)
+		return previous, nil
+	}
+
+	scope := ""
+	if specChanged {
+		scope, err = r.resolveScope(ctx, spec)
+		if err != nil {
+			return previous, err
+		}
+	} else {
+		scope = previous.Scope
+	}
+
+	if previous != nil && (previous.SchemaId != spec.SchemaId || previous.Scope != scope) {
+		// the schema and scope of a settings object can't be changed, so it is replaced
+		err = r.deleteObject(ctx, *previous)
+		if err != nil {
+			return previous, err
+		}
+		previous = nil
+	}
+
+	status := &settings.Status{
+		Name:        spec.Name,
+		SchemaId:    spec.SchemaId,
+		Scope:       scope,
+		Hash:        hash,
+		LastRequest: *r.timeProvider.Now(),
+	}
+	object := dtclient.SettingsObject{
+		SchemaId:      spec.SchemaId,
+		SchemaVersion: spec.SchemaVersion,
+		Scope:         scope,
+		Value:         spec.Value.Raw,
+	}
+
+	if previous != nil && previous.ObjectId != "" {
+		found, err := r.updateObject(ctx, spec, previous.ObjectId, object, specChanged)
+		if err != nil {
+			return previous, err
+		}
+		if found {
+			status.ObjectId = previous.ObjectId
+			return status, nil
+		}
+		log.Info("settings object was deleted in Dynatrace, recreating it", "name", spec.Name, "objectId", previous.ObjectId)
+	}
+
+	objectId, err := r.dtc.CreateSettingsObject(ctx, object)
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to create settings object")
+	}
+	log.Info("created settings object", "name", spec.Name, "schemaId", spec.SchemaId, "scope", scope, "objectId", objectId)
+
+	status.ObjectId = objectId
+	return status, nil
+}
+
+// updateObject updates the settings object if its spec changed or its value was changed in Dynatrace.
+// Returns false if the settings object doesn't exist anymore.
+func (r *Reconciler) updateObject(ctx context.Context, spec settings.Spec, objectId string, object dtclient.SettingsObject, specChanged bool) (bool, error) {
+	current, err := r.dtc.GetSettingsObject(ctx, objectId)
+	if err != nil {
+		return false, errors.WithMessage(err, "failed to get settings object")
+	}
+	if current == nil {
+		return false, nil
+	}
+
+	if !specChanged {
+		drifted, err := isDrifted(object.Value, current.Value)
+		if err != nil {
+			return true, err
+		}
+		if !drifted {
+			return true, nil
+		}
+		log.Info("settings object was changed in Dynatrace, reverting it", "name", spec.Name, "objectId", objectId)
+	}
+
+	err = r.dtc.UpdateSettingsObject(ctx, objectId, object)
+	if err != nil {
+		return true, errors.WithMessage(err, "failed to update settings object")
+	}
+	log.Info("updated settings object", "name", spec.Name, "objectId", objectId)
+	return true, nil
+}
+
+func (r *Reconciler) deleteObject(ctx context.Context, status settings.Status) error {
+	if status.ObjectId == "" {
+		return nil
+	}
+
+	err := r.dtc.DeleteSettingsObject(ctx, status.ObjectId)
+	if err != nil {
+		return errors.WithMessage(err, "failed to delete settings object")
+	}
+	log.Info("deleted settings object", "name", status.Name, "objectId", status.ObjectId)
+	return nil
+}
+
+// resolveScope replaces the Kubernetes cluster scope with the id of the newest monitored entity of the cluster
+func (r *Reconciler) resolveScope(ctx context.Context, spec settings.Spec) (string, error) {
+	if spec.GetScope() != settings.ScopeKubernetesCluster {
+		return spec.GetScope(), nil
+	}
+	if r.kubernetesClusterScope != "" {
+		return r.kubernetesClusterScope, nil
+	}
+
+	kubeSystemUUID := r.dynakube.Status.KubeSystemUUID
+	if kubeSystemUUID == "" {
+		return "", errors.New("no kube-system namespace UUID given")
+	}
+
+	monitoredEntities, err := r.dtc.GetMonitoredEntitiesForKubeSystemUUID(ctx, kubeSystemUUID)
+	if err != nil {
+		return "", errors.WithMessage(err, "error while loading MEs")
+	}
+
+	r.kubernetesClusterScope = apimonitoring.DetermineNewestMonitoredEntity(monitoredEntities)
+	if r.kubernetesClusterScope == "" {
+		return "", errors.Errorf("no monitored entity found for the Kubernetes cluster %s yet", kubeSystemUUID)
+	}
+	return r.kubernetesClusterScope, nil
+}
+
+// removedObjects returns the status of the settings objects which are not defined in the DynaKube anymore
+func (r *Reconciler) removedObjects() []settings.Status {
+	var removed []settings.Status
+	for _, status := range r.dynakube.Status.Settings {
+		if !r.isDefined(status.Name) {
+			removed = append(removed, status)
+		}
+	}
+	return removed
+}
+
+func (r *Reconciler) isDefined(name string) bool {
+	for _, spec := range r.dynakube.Spec.Settings {
+		if spec.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Reconciler) findStatus(name string) *settings.Status {
+	for i := range r.dynakube.Status.Settings {
+		if r.dynakube.Status.Settings[i].Name == name {
+			status := r.dynakube.Status.Settings[i]
+			return &status
+		}
+	}
+	return nil
+}
+
+// isDrifted returns true if a property of the desired value differs from the current value in Dynatrace.
+// Properties which aren't part of the desired value are filled with defaults by Dynatrace, so they are ignored.
+func isDrifted(desired, current json.RawMessage) (bool, error) {
+	var desiredValue, currentValue any
+	if err := json.Unmarshal(desired, &desiredValue); err != nil {
+		return false, errors.WithStack(err)
+	}
+	if err := json.Unmarshal(current, &currentValue); err != nil {
+		return false, errors.WithStack(err)
+	}
+	return !contains(currentValue, desiredValue), nil
+}
+
+func contains(current, desired any) bool {
+	switch desiredValue := desired.(type) {
+	case map[string]any:
+		currentValue, ok := current.(map[string]any)
+		if !ok {
+			return false
+		}
+		for key, value := range desiredValue {
+			if !contains(currentValue[key], value) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		currentValue, ok := current.([]any)
+		if !ok || len(currentValue) != len(desiredValue) {
+			return false
+		}
+		for i := range desiredValue {
+			if !contains(currentValue[i], desiredValue[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(current, desired)
+	}
+}
@@ -0,0 +1,268 @@
+package dtsettings
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/settings"
+	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/hasher"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/timeprovider"
+	dtclientmock "github.com/Dynatrace/dynatrace-operator/test/mocks/pkg/clients/dynatrace"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	testName           = "workload-anomalies"
+	testSchemaId       = "builtin:anomaly-detection.kubernetes.workload"
+	testObjectId       = "test-object-id"
+	testKubeSystemUUID = "test-kube-system-uuid"
+	testEntityId       = "KUBERNETES_CLUSTER-1234"
+)
+
+func TestReconcile(t *testing.T) {
+	ctx := context.Background()
+	timeProvider := timeprovider.New().Freeze()
+
+	t.Run("create settings object", func(t *testing.T) {
+		dynakube := newDynakube(newSpec(`{"enabled":true}`))
+		dtc := dtclientmock.NewClient(t)
+		dtc.On("CreateSettingsObject", mock.Anything, dtclient.SettingsObject{
+			SchemaId: testSchemaId,
+			Scope:    settings.ScopeEnvironment,
+			Value:    json.RawMessage(`{"enabled":true}`),
+		}).Return(testObjectId, nil).Once()
+
+		err := NewReconciler(dtc, dynakube, timeProvider).Reconcile(ctx)
+		require.NoError(t, err)
+
+		require.Len(t, dynakube.Status.Settings, 1)
+		status := dynakube.Status.Settings[0]
+		assert.Equal(t, testName, status.Name)
+		assert.Equal(t, testSchemaId, status.SchemaId)
+		assert.Equal(t, settings.ScopeEnvironment, status.Scope)
+		assert.Equal(t, testObjectId, status.ObjectId)
+		assert.Equal(t, hashOf(t, dynakube.Spec.Settings[0]), status.Hash)
+		assert.Equal(t, *timeProvider.Now(), status.LastRequest)
+	})
+	t.Run("resolve kubernetes cluster scope", func(t *testing.T) {
+		spec := newSpec(`{"enabled":true}`)
+		spec.Scope = settings.ScopeKubernetesCluster
+		dynakube := newDynakube(spec)
+		dynakube.Status.KubeSystemUUID = testKubeSystemUUID
+		dtc := dtclientmock.NewClient(t)
+		dtc.On("GetMonitoredEntitiesForKubeSystemUUID", mock.Anything, testKubeSystemUUID).
+			Return([]dtclient.MonitoredEntity{{EntityId: "KUBERNETES_CLUSTER-old", LastSeenTms: 1}, {EntityId: testEntityId, LastSeenTms: 2}}, nil).Once()
+		dtc.On("CreateSettingsObject", mock.Anything, mock.MatchedBy(func(object dtclient.SettingsObject) bool {
+			return object.Scope == testEntityId
+		})).Return(testObjectId, nil).Once()
+
+		err := NewReconciler(dtc, dynakube, timeProvider).Reconcile(ctx)
+		require.NoError(t, err)
+
+		require.Len(t, dynakube.Status.Settings, 1)
+		assert.Equal(t, testEntityId, dynakube.Status.Settings[0].Scope)
+	})
+	t.Run("no monitored entity for kubernetes cluster scope yet", func(t *testing.T) {
+		spec := newSpec(`{"enabled":true}`)
+		spec.Scope = settings.ScopeKubernetesCluster
+		dynakube := newDynakube(spec)
+		dynakube.Status.KubeSystemUUID = testKubeSystemUUID
+		dtc := dtclientmock.NewClient(t)
+		dtc.On("GetMonitoredEntitiesForKubeSystemUUID", mock.Anything, testKubeSystemUUID).
+			Return([]dtclient.MonitoredEntity{}, nil).Once()
+
+		err := NewReconciler(dtc, dynakube, timeProvider).Reconcile(ctx)
+		require.Error(t, err)
+		assert.Empty(t, dynakube.Status.Settings)
+	})
+	t.Run("unchanged settings object isn't requested before the threshold passed", func(t *testing.T) {
+		dynakube := newDynakube(newSpec(`{"enabled":true}`))
+		dynakube.Status.Settings = []settings.Status{newStatus(t, dynakube.Spec.Settings[0], *timeProvider.Now())}
+		dtc := dtclientmock.NewClient(t)
+
+		err := NewReconciler(dtc, dynakube, timeProvider).Reconcile(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, testObjectId, dynakube.Status.Settings[0].ObjectId)
+	})
+	t.Run("unchanged settings object without drift isn't updated", func(t *testing.T) {
+		dynakube := newDynakube(newSpec(`{"enabled":true}`))
+		dynakube.Status.Settings = []settings.Status{newStatus(t, dynakube.Spec.Settings[0], outdated(timeProvider))}
+		dtc := dtclientmock.NewClient(t)
+		dtc.On("GetSettingsObject", mock.Anything, testObjectId).
+			Return(&dtclient.SettingsObject{ObjectId: testObjectId, Value: json.RawMessage(`{"enabled":true,"defaulted":5}`)}, nil).Once()
+
+		err := NewReconciler(dtc, dynakube, timeProvider).Reconcile(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, *timeProvider.Now(), dynakube.Status.Settings[0].LastRequest)
+	})
+	t.Run("drifted settings object is reverted", func(t *testing.T) {
+		dynakube := newDynakube(newSpec(`{"enabled":true}`))
+		dynakube.Status.Settings = []settings.Status{newStatus(t, dynakube.Spec.Settings[0], outdated(timeProvider))}
+		dtc := dtclientmock.NewClient(t)
+		dtc.On("GetSettingsObject", mock.Anything, testObjectId).
+			Return(&dtclient.SettingsObject{ObjectId: testObjectId, Value: json.RawMessage(`{"enabled":false}`)}, nil).Once()
+		dtc.On("UpdateSettingsObject", mock.Anything, testObjectId, mock.Anything).Return(nil).Once()
+
+		err := NewReconciler(dtc, dynakube, timeProvider).Reconcile(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, testObjectId, dynakube.Status.Settings[0].ObjectId)
+	})
+	t.Run("settings object deleted in Dynatrace is recreated", func(t *testing.T) {
+		dynakube := newDynakube(newSpec(`{"enabled":true}`))
+		dynakube.Status.Settings = []settings.Status{newStatus(t, dynakube.Spec.Settings[0], outdated(timeProvider))}
+		dtc := dtclientmock.NewClient(t)
+		dtc.On("GetSettingsObject", mock.Anything, testObjectId).Return(nil, nil).Once()
+		dtc.On("CreateSettingsObject", mock.Anything, mock.Anything).Return("new-object-id", nil).Once()
+
+		err := NewReconciler(dtc, dynakube, timeProvider).Reconcile(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, "new-object-id", dynakube.Status.Settings[0].ObjectId)
+	})
+	t.Run("changed value is updated immediately", func(t *testing.T) {
+		previousSpec := newSpec(`{"enabled":true}`)
+		dynakube := newDynakube(newSpec(`{"enabled":false}`))
+		dynakube.Status.Settings = []settings.Status{newStatus(t, previousSpec, *timeProvider.Now())}
+		dtc := dtclientmock.NewClient(t)
+		dtc.On("GetSettingsObject", mock.Anything, testObjectId).
+			Return(&dtclient.SettingsObject{ObjectId: testObjectId, Value: json.RawMessage(`{"enabled":true}`)}, nil).Once()
+		dtc.On("UpdateSettingsObject", mock.Anything, testObjectId, mock.MatchedBy(func(object dtclient.SettingsObject) bool {
+			return string(object.Value) == `{"enabled":false}`
+		})).Return(nil).Once()
+
+		err := NewReconciler(dtc, dynakube, timeProvider).Reconcile(ctx)
+		require.NoError(t, err)
+
+		assert.Equal(t, hashOf(t, dynakube.Spec.Settings[0]), dynakube.Status.Settings[0].Hash)
+	})
+	t.Run("changed scope replaces settings object", func(t *testing.T) {
+		previousSpec := newSpec(`{"enabled":true}`)
+		spec := newSpec(`{"enabled":true}`)
+		spec.Scope = "HOST-1234"
+		dynakube := newDynakube(spec)
+		dynakube.Status.Settings = []settings.Status{newStatus(t, previousSpec, *timeProvider.Now())}
+		dtc := dtclientmock.NewClient(t)
+		dtc.On("DeleteSettingsObject", mock.Anything, testObjectId).Return(nil).Once()
+		dtc.On("CreateSettingsObject", mock.Anything, mock.MatchedBy(func(object dtclient.SettingsObject) bool {
+			return object.Scope == "HOST-1234"
+		})).Return("new-object-id", nil).Once()
+
+		err := NewReconciler(dtc, dynakube, timeProvider).Reconcile(ctx)
+		require.NoError(t, err)
+
+		require.Len(t, dynakube.Status.Settings, 1)
+		assert.Equal(t, "new-object-id", dynakube.Status.Settings[0].ObjectId)
+		assert.Equal(t, "HOST-1234", dynakube.Status.Settings[0].Scope)
+	})
+	t.Run("removed settings object is deleted", func(t *testing.T) {
+		dynakube := newDynakube()
+		dynakube.Status.Settings = []settings.Status{newStatus(t, newSpec(`{"enabled":true}`), *timeProvider.Now())}
+		dtc := dtclientmock.NewClient(t)
+		dtc.On("DeleteSettingsObject", mock.Anything, testObjectId).Return(nil).Once()
+
+		err := NewReconciler(dtc, dynakube, timeProvider).Reconcile(ctx)
+		require.NoError(t, err)
+
+		assert.Nil(t, dynakube.Status.Settings)
+	})
+	t.Run("failed deletion is retried", func(t *testing.T) {
+		dynakube := newDynakube()
+		dynakube.Status.Settings = []settings.Status{newStatus(t, newSpec(`{"enabled":true}`), *timeProvider.Now())}
+		dtc := dtclientmock.NewClient(t)
+		dtc.On("DeleteSettingsObject", mock.Anything, testObjectId).Return(errors.New("forbidden")).Once()
+
+		err := NewReconciler(dtc, dynakube, timeProvider).Reconcile(ctx)
+		require.Error(t, err)
+
+		require.Len(t, dynakube.Status.Settings, 1)
+		assert.Equal(t, testObjectId, dynakube.Status.Settings[0].ObjectId)
+	})
+	t.Run("failing settings object doesn't block other ones", func(t *testing.T) {
+		failing := newSpec(`{"enabled":true}`)
+		failing.Name = "failing"
+		dynakube := newDynakube(failing, newSpec(`{"enabled":true}`))
+		dtc := dtclientmock.NewClient(t)
+		dtc.On("CreateSettingsObject", mock.Anything, mock.Anything).Return("", errors.New("constraint violation")).Once()
+		dtc.On("CreateSettingsObject", mock.Anything, mock.Anything).Return(testObjectId, nil).Once()
+
+		err := NewReconciler(dtc, dynakube, timeProvider).Reconcile(ctx)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failing: ")
+
+		require.Len(t, dynakube.Status.Settings, 1)
+		assert.Equal(t, testName, dynakube.Status.Settings[0].Name)
+	})
+}
+
+func TestIsDrifted(t *testing.T) {
+	testCases := []struct {
+		name    string
+		desired string
+		current string
+		drifted bool
+	}{
+		{"equal", `{"enabled":true}`, `{"enabled":true}`, false},
+		{"defaults are ignored", `{"enabled":true}`, `{"enabled":true,"threshold":5}`, false},
+		{"nested defaults are ignored", `{"rule":{"enabled":true}}`, `{"rule":{"enabled":true,"samples":3}}`, false},
+		{"changed value", `{"enabled":true}`, `{"enabled":false}`, true},
+		{"missing value", `{"enabled":true}`, `{}`, true},
+		{"changed list", `{"names":["a","b"]}`, `{"names":["a"]}`, true},
+		{"equal numbers", `{"threshold":5}`, `{"threshold":5.0}`, false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			drifted, err := isDrifted(json.RawMessage(testCase.desired), json.RawMessage(testCase.current))
+			require.NoError(t, err)
+			assert.Equal(t, testCase.drifted, drifted)
+		})
+	}
+}
+
+func newDynakube(specs ...settings.Spec) *dynatracev1beta1.DynaKube {
+	return &dynatracev1beta1.DynaKube{
+		ObjectMeta: metav1.ObjectMeta{Name: "dynakube", Namespace: "dynatrace"},
+		Spec:       dynatracev1beta1.DynaKubeSpec{Settings: specs},
+	}
+}
+
+func newSpec(value string) settings.Spec {
+	return settings.Spec{
+		Name:     testName,
+		SchemaId: testSchemaId,
+		Value:    apiextensionsv1.JSON{Raw: []byte(value)},
+	}
+}
+
+func newStatus(t *testing.T, spec settings.Spec, lastRequest metav1.Time) settings.Status {
+	return settings.Status{
+		Name:        spec.Name,
+		SchemaId:    spec.SchemaId,
+		Scope:       spec.GetScope(),
+		ObjectId:    testObjectId,
+		Hash:        hashOf(t, spec),
+		LastRequest: lastRequest,
+	}
+}
+
+func hashOf(t *testing.T, spec settings.Spec) string {
+	hash, err := hasher.GenerateHash(spec)
+	require.NoError(t, err)
+	return hash
+}
+
+func outdated(timeProvider *timeprovider.Provider) metav1.Time {
+	return metav1.NewTime(timeProvider.Now().Add(-time.Hour))
+}
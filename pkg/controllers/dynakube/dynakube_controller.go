@@ -2,18 +2,22 @@ package dynakube
 
 import (
 	"context"
-	"net/http"
 	"os"
 	"time"
 
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/maintenancewindow"
 	dynatracestatus "github.com/Dynatrace/dynatrace-operator/pkg/api/status"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/tokensource"
 	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/apierrors"
 	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/httptransport"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/apimonitoring"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/connectioninfo"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/deploymentmetadata"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/dtpullsecret"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/dtsettings"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/dynatraceapi"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/dynatraceclient"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/istio"
@@ -33,13 +37,16 @@ import (
 	"github.com/pkg/errors"
 	"github.com/spf13/afero"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
@@ -76,6 +83,7 @@ func NewDynaKubeController(kubeClient client.Client, apiReader client.Reader, sc
 		config:                 config,
 		operatorNamespace:      os.Getenv(env.PodNamespace),
 		clusterID:              clusterID,
+		timeProvider:           timeprovider.New(),
 	}
 }
 
@@ -84,8 +92,12 @@ func (controller *Controller) SetupWithManager(mgr ctrl.Manager) error {
 		For(&dynatracev1beta1.DynaKube{}).
 		Owns(&appsv1.StatefulSet{}).
 		Owns(&appsv1.DaemonSet{}).
+		Owns(&autoscalingv2.HorizontalPodAutoscaler{}).
+		Owns(&policyv1.PodDisruptionBudget{}).
 		Owns(&corev1.ConfigMap{}).
 		Owns(&corev1.Secret{}).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(controller.mapSecretToDynakubes)).
+		Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(controller.mapConfigMapToDynakubes)).
 		Complete(controller)
 }
 
@@ -104,6 +116,7 @@ type Controller struct {
 	config            *rest.Config
 	operatorNamespace string
 	clusterID         string
+	timeProvider      *timeprovider.Provider
 
 	requeueAfter time.Duration
 }
@@ -142,13 +155,34 @@ func (controller *Controller) reconcile(ctx context.Context, dynaKube *dynatrace
 	controller.requeueAfter = defaultUpdateInterval
 
 	err := controller.reconcileDynaKube(ctx, dynaKube)
+	keepLastTransitionTime(oldStatus.Conditions, dynaKube.Status.Conditions, dynatracev1beta1.UpdatePendingConditionType)
+	controller.setConditionReady(dynaKube, err)
+
+	// errors of the Dynatrace API are handled by requeueing, as retrying immediately won't help
+	returnErr := err
 
 	switch {
-	case dynatraceapi.IsUnreachable(err):
-		log.Info("dynaTrace API server is unavailable or request limit reached! trying again in one minute",
-			"errorCode", dynatraceapi.StatusCode(err), "errorMessage", dynatraceapi.Message(err))
-		// should we set the phase to error ?
-		return reconcile.Result{RequeueAfter: fastUpdateInterval}, nil
+	case errors.Is(err, apierrors.ErrRateLimited):
+		retryAfter, ok := apierrors.RetryAfter(err)
+		if !ok {
+			retryAfter = fastUpdateInterval
+		}
+		log.Info("request limit of the Dynatrace API reached, trying again later",
+			"retryAfter", retryAfter, "errorCode", dynatraceapi.StatusCode(err), "errorMessage", dynatraceapi.Message(err))
+		controller.requeueAfter = retryAfter
+		returnErr = nil
+
+	case errors.Is(err, apierrors.ErrServer), errors.Is(err, apierrors.ErrNetwork):
+		log.Info("Dynatrace API is unavailable, trying again in one minute", "error", err.Error())
+		controller.requeueAfter = fastUpdateInterval
+		returnErr = nil
+
+	case errors.Is(err, apierrors.ErrUnauthorized), errors.Is(err, apierrors.ErrForbidden), errors.Is(err, apierrors.ErrTLS):
+		// retrying won't help until the tokens, the permissions or the trusted certificates are changed
+		controller.setRequeueAfterIfNewIsShorter(changesUpdateInterval)
+		dynaKube.Status.SetPhase(dynatracestatus.Error)
+		log.Error(err, "request to the Dynatrace API was rejected, check the configuration of the DynaKube", "namespace", dynaKube.Namespace, "name", dynaKube.Name)
+		returnErr = nil
 
 	case err != nil:
 		controller.setRequeueAfterIfNewIsShorter(fastUpdateInterval)
@@ -169,8 +203,8 @@ func (controller *Controller) reconcile(ctx context.Context, dynaKube *dynatrace
 		}
 	}
 
-	if err != nil {
-		return reconcile.Result{}, err
+	if returnErr != nil {
+		return reconcile.Result{}, returnErr
 	}
 	return reconcile.Result{RequeueAfter: controller.requeueAfter}, nil
 }
@@ -184,6 +218,8 @@ func (controller *Controller) getDynakubeOrUnmap(ctx context.Context, dkName, dk
 	}
 	err := controller.apiReader.Get(ctx, client.ObjectKey{Name: dynakube.Name, Namespace: dynakube.Namespace}, dynakube)
 	if k8serrors.IsNotFound(err) {
+		httptransport.Shared().Remove(httptransport.Key(dynakube))
+		httptransport.Shared().Remove(token.TransportKey(dynakube))
 		return nil, controller.createDynakubeMapper(ctx, dynakube).UnmapFromDynaKube()
 	} else if err != nil {
 		return nil, errors.WithStack(err)
@@ -222,9 +258,8 @@ func (controller *Controller) setupIstio(ctx context.Context, dynakube *dynatrac
 
 func (controller *Controller) createDynatraceRegistryClient(ctx context.Context, dynakube *dynatracev1beta1.DynaKube) (registry.ImageGetter, error) {
 	pullSecret := dynakube.PullSecretWithoutData()
-	transport := http.DefaultTransport.(*http.Transport).Clone()
 
-	transport, err := registry.PrepareTransportForDynaKube(ctx, controller.apiReader, transport, dynakube)
+	transport, err := httptransport.Shared().ForDynaKube(ctx, controller.apiReader, dynakube)
 	if err != nil {
 		return nil, err
 	}
@@ -243,6 +278,11 @@ func (controller *Controller) createDynatraceRegistryClient(ctx context.Context,
 }
 
 func (controller *Controller) reconcileDynaKube(ctx context.Context, dynakube *dynatracev1beta1.DynaKube) error {
+	err := controller.reconcileMaintenanceWindows(dynakube)
+	if err != nil {
+		return err
+	}
+
 	istioReconciler, err := controller.setupIstio(ctx, dynakube)
 	if err != nil {
 		return err
@@ -255,17 +295,34 @@ func (controller *Controller) reconcileDynaKube(ctx context.Context, dynakube *d
 		return err
 	}
 
+	if dynakube.TokenSourceType() != tokensource.SecretType {
+		// changes of token files or of the secret store can't be watched, so they are polled
+		controller.setRequeueAfterIfNewIsShorter(changesUpdateInterval)
+	}
+
+	err = controller.handleTokenRotation(dynakube, tokens)
+	if err != nil {
+		return err
+	}
+
 	dynatraceClientBuilder := controller.dynatraceClientBuilder.
 		SetContext(ctx).
 		SetDynakube(*dynakube).
 		SetTokens(tokens)
 	dynatraceClient, err := dynatraceClientBuilder.BuildWithTokenVerification(&dynakube.Status)
+	if err != nil && dynakube.UsesMirror() && dynatraceapi.IsOffline(err) {
+		// the mirror doesn't need the tokens, they are verified once the Dynatrace API is reachable again
+		controller.setConditionTokenUnverified(dynakube, err)
+		dynatraceClient, err = dynatraceClientBuilder.Build()
+	} else if err == nil {
+		controller.setConditionTokenReady(dynakube)
+	}
+
 	if err != nil {
 		controller.setConditionTokenError(dynakube, err)
 		return err
 	}
 
-	controller.setConditionTokenReady(dynakube)
 	err = status.SetDynakubeStatus(ctx, dynakube, controller.apiReader)
 	if err != nil {
 		log.Info("could not update Dynakube status")
@@ -327,6 +384,32 @@ func (controller *Controller) reconcileDynaKube(ctx context.Context, dynakube *d
 	return controller.reconcileComponents(ctx, dynatraceClient, dynakube)
 }
 
+// reconcileMaintenanceWindows records until when updates of deployed components are deferred.
+// The UpdatePending condition is reset, the components set it again as long as one of their updates is deferred.
+func (controller *Controller) reconcileMaintenanceWindows(dynakube *dynatracev1beta1.DynaKube) error {
+	dynakube.RemoveCondition(dynatracev1beta1.UpdatePendingConditionType)
+
+	if len(dynakube.Spec.MaintenanceWindows) == 0 {
+		dynakube.Status.UpdatesDeferredUntil = nil
+		return nil
+	}
+
+	now := controller.timeProvider.Now()
+	deferredUntil, err := maintenancewindow.DeferUpdatesUntil(dynakube.Spec.MaintenanceWindows, now.Time)
+	if err != nil {
+		return err
+	}
+
+	dynakube.Status.UpdatesDeferredUntil = deferredUntil
+	if deferredUntil == nil {
+		return nil
+	}
+
+	log.Info("outside of maintenance windows, updates of deployed components are deferred", "until", deferredUntil)
+	controller.setRequeueAfterIfNewIsShorter(deferredUntil.Sub(now.Time))
+	return nil
+}
+
 func (controller *Controller) reconcileConnectionInfo(ctx context.Context, dynakube *dynatracev1beta1.DynaKube, dynatraceClient dtclient.Client) error {
 	err := connectioninfo.NewReconciler(controller.client, controller.apiReader, controller.scheme, dynakube, dynatraceClient).Reconcile(ctx)
 
@@ -363,15 +446,42 @@ func (controller *Controller) reconcileComponents(ctx context.Context, dynatrace
 		return err
 	}
 
+	log.Info("start reconciling settings objects")
+	controller.reconcileSettings(ctx, dynakube, dynatraceClient)
+
 	return nil
 }
 
+// reconcileSettings reconciles the settings objects of the DynaKube.
+// Failing settings objects are reported in the Settings condition, but don't stop the reconciliation of the DynaKube.
+func (controller *Controller) reconcileSettings(ctx context.Context, dynakube *dynatracev1beta1.DynaKube, dynatraceClient dtclient.Client) {
+	if !dynakube.ManagesSettings() {
+		dynakube.RemoveCondition(dynatracev1beta1.SettingsConditionType)
+		return
+	}
+
+	err := dtsettings.NewReconciler(dynatraceClient, dynakube, controller.timeProvider).Reconcile(ctx)
+	if err != nil {
+		log.Info("could not reconcile settings objects", "error", err.Error())
+		dynakube.SetConditionError(dynatracev1beta1.SettingsConditionType, err)
+		return
+	}
+	dynakube.SetConditionReconciled(dynatracev1beta1.SettingsConditionType)
+}
+
 func (controller *Controller) reconcileAppInjection(ctx context.Context, dynakube *dynatracev1beta1.DynaKube) error {
-	if dynakube.NeedAppInjection() {
-		return controller.setupAppInjection(ctx, dynakube)
+	if !dynakube.NeedAppInjection() {
+		dynakube.RemoveCondition(dynatracev1beta1.AppInjectionConditionType)
+		return controller.removeAppInjection(ctx, dynakube)
 	}
 
-	return controller.removeAppInjection(ctx, dynakube)
+	err := controller.setupAppInjection(ctx, dynakube)
+	if err != nil {
+		dynakube.SetConditionError(dynatracev1beta1.AppInjectionConditionType, err)
+		return err
+	}
+	dynakube.SetConditionReconciled(dynatracev1beta1.AppInjectionConditionType)
+	return nil
 }
 
 func (controller *Controller) setupAppInjection(ctx context.Context, dynakube *dynatracev1beta1.DynaKube) (err error) {
@@ -421,12 +531,20 @@ func (controller *Controller) removeAppInjection(ctx context.Context, dynakube *
 
 func (controller *Controller) reconcileOneAgent(ctx context.Context, dynakube *dynatracev1beta1.DynaKube) error {
 	if !dynakube.NeedsOneAgent() {
+		dynakube.RemoveCondition(dynatracev1beta1.OneAgentConditionType)
+		dynakube.Status.OneAgent.Rollout = nil
 		return controller.removeOneAgentDaemonSet(ctx, dynakube)
 	}
 
-	return oneagent.NewOneAgentReconciler(
+	err := oneagent.NewOneAgentReconciler(
 		controller.client, controller.apiReader, controller.scheme, controller.clusterID,
 	).Reconcile(ctx, dynakube)
+
+	// the health gate of a staged rollout has to be checked before the next stage can start
+	if dynakube.Status.OneAgent.Rollout.IsInProgress() {
+		controller.setRequeueAfterIfNewIsShorter(fastUpdateInterval)
+	}
+	return err
 }
 
 func (controller *Controller) removeOneAgentDaemonSet(ctx context.Context, dynakube *dynatracev1beta1.DynaKube) error {
@@ -441,12 +559,12 @@ func (controller *Controller) reconcileActiveGate(ctx context.Context, dynakube
 	if err != nil {
 		return errors.WithMessage(err, "failed to reconcile ActiveGate")
 	}
-	controller.setupAutomaticApiMonitoring(dynakube, dtc)
+	controller.setupAutomaticApiMonitoring(ctx, dynakube, dtc)
 
 	return nil
 }
 
-func (controller *Controller) setupAutomaticApiMonitoring(dynakube *dynatracev1beta1.DynaKube, dtc dtclient.Client) {
+func (controller *Controller) setupAutomaticApiMonitoring(ctx context.Context, dynakube *dynatracev1beta1.DynaKube, dtc dtclient.Client) {
 	if dynakube.Status.KubeSystemUUID != "" &&
 		dynakube.FeatureAutomaticKubernetesApiMonitoring() &&
 		dynakube.IsKubernetesMonitoringActiveGateEnabled() {
@@ -456,7 +574,7 @@ func (controller *Controller) setupAutomaticApiMonitoring(dynakube *dynatracev1b
 		}
 
 		err := apimonitoring.NewReconciler(dtc, clusterLabel, dynakube.Status.KubeSystemUUID).
-			Reconcile(dynakube)
+			Reconcile(ctx, dynakube)
 		if err != nil {
 			log.Error(err, "could not create setting")
 		}
@@ -4,11 +4,15 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/url"
 	"testing"
 	"time"
 
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/maintenancewindow"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/mirror"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/settings"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/status"
 	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
 	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
@@ -21,6 +25,7 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/address"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/labels"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubesystem"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/timeprovider"
 	"github.com/Dynatrace/dynatrace-operator/pkg/version"
 	dtwebhook "github.com/Dynatrace/dynatrace-operator/pkg/webhook"
 	mockedclient "github.com/Dynatrace/dynatrace-operator/test/mocks/pkg/clients/dynatrace"
@@ -34,6 +39,7 @@ import (
 	fakeistio "istio.io/client-go/pkg/clientset/versioned/fake"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -144,7 +150,7 @@ func TestReconcileActiveGate_Reconcile(t *testing.T) {
 	t.Run(`Create reconciles Kubernetes Monitoring if enabled`, func(t *testing.T) {
 		mockClient := createDTMockClient(t, dtclient.TokenScopes{dtclient.TokenScopeInstallerDownload}, dtclient.TokenScopes{dtclient.TokenScopeDataExport, dtclient.TokenScopeActiveGateTokenCreate})
 
-		mockClient.On("GetActiveGateAuthToken", testName).Return(&dtclient.ActiveGateAuthTokenInfo{}, nil)
+		mockClient.On("GetActiveGateAuthToken", mock.Anything, testName).Return(&dtclient.ActiveGateAuthTokenInfo{}, nil)
 
 		instance := &dynatracev1beta1.DynaKube{
 			ObjectMeta: metav1.ObjectMeta{
@@ -180,7 +186,7 @@ func TestReconcileActiveGate_Reconcile(t *testing.T) {
 		mockClient := createDTMockClient(t, dtclient.TokenScopes{dtclient.TokenScopeInstallerDownload}, dtclient.TokenScopes{dtclient.TokenScopeDataExport, dtclient.TokenScopeEntitiesRead, dtclient.TokenScopeSettingsRead, dtclient.TokenScopeSettingsWrite,
 			dtclient.TokenScopeActiveGateTokenCreate})
 
-		mockClient.On("GetActiveGateAuthToken", testName).Return(&dtclient.ActiveGateAuthTokenInfo{}, nil)
+		mockClient.On("GetActiveGateAuthToken", mock.Anything, testName).Return(&dtclient.ActiveGateAuthTokenInfo{}, nil)
 
 		instance := &dynatracev1beta1.DynaKube{
 			ObjectMeta: metav1.ObjectMeta{
@@ -207,6 +213,7 @@ func TestReconcileActiveGate_Reconcile(t *testing.T) {
 		})
 
 		mockClient.AssertCalled(t, "CreateOrUpdateKubernetesSetting",
+			mock.Anything,
 			testName,
 			testUID,
 			mock.AnythingOfType("string"))
@@ -218,11 +225,11 @@ func TestReconcileActiveGate_Reconcile(t *testing.T) {
 
 		mockClient := createDTMockClient(t, dtclient.TokenScopes{dtclient.TokenScopeInstallerDownload}, dtclient.TokenScopes{dtclient.TokenScopeDataExport, dtclient.TokenScopeEntitiesRead, dtclient.TokenScopeSettingsRead, dtclient.TokenScopeSettingsWrite,
 			dtclient.TokenScopeActiveGateTokenCreate})
-		mockClient.On("CreateOrUpdateKubernetesSetting",
+		mockClient.On("CreateOrUpdateKubernetesSetting", mock.Anything,
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string"),
 			mock.AnythingOfType("string")).Return(testUID, nil)
-		mockClient.On("GetActiveGateAuthToken", testName).Return(&dtclient.ActiveGateAuthTokenInfo{}, nil)
+		mockClient.On("GetActiveGateAuthToken", mock.Anything, testName).Return(&dtclient.ActiveGateAuthTokenInfo{}, nil)
 
 		instance := &dynatracev1beta1.DynaKube{
 			ObjectMeta: metav1.ObjectMeta{
@@ -250,6 +257,7 @@ func TestReconcileActiveGate_Reconcile(t *testing.T) {
 		})
 
 		mockClient.AssertCalled(t, "CreateOrUpdateKubernetesSetting",
+			mock.Anything,
 			clusterLabel,
 			testUID,
 			mock.AnythingOfType("string"))
@@ -323,7 +331,7 @@ func TestReconcileActiveGate_Reconcile(t *testing.T) {
 	t.Run(`reconciles phase change correctly`, func(t *testing.T) {
 		mockClient := createDTMockClient(t, dtclient.TokenScopes{dtclient.TokenScopeInstallerDownload}, dtclient.TokenScopes{dtclient.TokenScopeDataExport, dtclient.TokenScopeEntitiesRead, dtclient.TokenScopeSettingsRead, dtclient.TokenScopeSettingsWrite, dtclient.TokenScopeActiveGateTokenCreate})
 
-		mockClient.On("GetActiveGateAuthToken", testName).Return(&dtclient.ActiveGateAuthTokenInfo{}, nil)
+		mockClient.On("GetActiveGateAuthToken", mock.Anything, testName).Return(&dtclient.ActiveGateAuthTokenInfo{}, nil)
 
 		instance := &dynatracev1beta1.DynaKube{
 			ObjectMeta: metav1.ObjectMeta{
@@ -352,6 +360,7 @@ func TestReconcileActiveGate_Reconcile(t *testing.T) {
 		})
 
 		mockClient.AssertCalled(t, "CreateOrUpdateKubernetesSetting",
+			mock.Anything,
 			testName,
 			testUID,
 			mock.AnythingOfType("string"))
@@ -475,7 +484,7 @@ func TestRemoveOneAgentDaemonset(t *testing.T) {
 func TestReconcile_RemoveRoutingIfDisabled(t *testing.T) {
 	mockClient := createDTMockClient(t, dtclient.TokenScopes{dtclient.TokenScopeInstallerDownload}, dtclient.TokenScopes{dtclient.TokenScopeDataExport, dtclient.TokenScopeActiveGateTokenCreate})
 
-	mockClient.On("GetActiveGateAuthToken", testName).Return(&dtclient.ActiveGateAuthTokenInfo{}, nil)
+	mockClient.On("GetActiveGateAuthToken", mock.Anything, testName).Return(&dtclient.ActiveGateAuthTokenInfo{}, nil)
 
 	instance := &dynatracev1beta1.DynaKube{
 		ObjectMeta: metav1.ObjectMeta{
@@ -550,7 +559,7 @@ func TestReconcile_ActiveGateMultiCapability(t *testing.T) {
 		dtclient.TokenScopeActiveGateTokenCreate,
 	})
 
-	mockClient.On("GetActiveGateAuthToken", testName).Return(&dtclient.ActiveGateAuthTokenInfo{}, nil)
+	mockClient.On("GetActiveGateAuthToken", mock.Anything, testName).Return(&dtclient.ActiveGateAuthTokenInfo{}, nil)
 
 	instance := &dynatracev1beta1.DynaKube{
 		ObjectMeta: metav1.ObjectMeta{
@@ -627,12 +636,12 @@ func TestReconcile_ActiveGateMultiCapability(t *testing.T) {
 func createDTMockClient(t *testing.T, paasTokenScopes, apiTokenScopes dtclient.TokenScopes) *mockedclient.Client {
 	mockClient := mockedclient.NewClient(t)
 
-	mockClient.On("GetCommunicationHostForClient").Return(dtclient.CommunicationHost{
+	mockClient.On("GetCommunicationHostForClient", mock.Anything).Return(dtclient.CommunicationHost{
 		Protocol: testProtocol,
 		Host:     testHost,
 		Port:     testPort,
 	}, nil).Maybe()
-	mockClient.On("GetOneAgentConnectionInfo").Return(dtclient.OneAgentConnectionInfo{
+	mockClient.On("GetOneAgentConnectionInfo", mock.Anything).Return(dtclient.OneAgentConnectionInfo{
 		CommunicationHosts: []dtclient.CommunicationHost{
 			{
 				Protocol: testProtocol,
@@ -649,22 +658,22 @@ func createDTMockClient(t *testing.T, paasTokenScopes, apiTokenScopes dtclient.T
 			TenantUUID: testUUID,
 		},
 	}, nil).Maybe()
-	mockClient.On("GetTokenScopes", testPaasToken).Return(paasTokenScopes, nil).Maybe()
-	mockClient.On("GetTokenScopes", testAPIToken).Return(apiTokenScopes, nil).Maybe()
-	mockClient.On("GetOneAgentConnectionInfo").Return(
+	mockClient.On("GetTokenScopes", mock.Anything, testPaasToken).Return(paasTokenScopes, nil).Maybe()
+	mockClient.On("GetTokenScopes", mock.Anything, testAPIToken).Return(apiTokenScopes, nil).Maybe()
+	mockClient.On("GetOneAgentConnectionInfo", mock.Anything).Return(
 		dtclient.OneAgentConnectionInfo{
 			ConnectionInfo: dtclient.ConnectionInfo{
 				TenantUUID: "abc123456",
 			},
 		}, nil).Maybe()
-	mockClient.On("GetLatestAgentVersion", mock.Anything, mock.Anything).Return(testVersion, nil).Maybe()
-	mockClient.On("GetMonitoredEntitiesForKubeSystemUUID", mock.AnythingOfType("string")).
+	mockClient.On("GetLatestAgentVersion", mock.Anything, mock.Anything, mock.Anything).Return(testVersion, nil).Maybe()
+	mockClient.On("GetMonitoredEntitiesForKubeSystemUUID", mock.Anything, mock.AnythingOfType("string")).
 		Return([]dtclient.MonitoredEntity{}, nil).Maybe()
-	mockClient.On("GetSettingsForMonitoredEntities", []dtclient.MonitoredEntity{}, mock.AnythingOfType("string")).
+	mockClient.On("GetSettingsForMonitoredEntities", mock.Anything, []dtclient.MonitoredEntity{}, mock.AnythingOfType("string")).
 		Return(dtclient.GetSettingsResponse{}, nil).Maybe()
-	mockClient.On("CreateOrUpdateKubernetesSetting", testName, testUID, mock.AnythingOfType("string")).
+	mockClient.On("CreateOrUpdateKubernetesSetting", mock.Anything, testName, testUID, mock.AnythingOfType("string")).
 		Return(testObjectID, nil).Maybe()
-	mockClient.On("GetActiveGateConnectionInfo").Return(dtclient.ActiveGateConnectionInfo{}, nil).Maybe()
+	mockClient.On("GetActiveGateConnectionInfo", mock.Anything).Return(dtclient.ActiveGateConnectionInfo{}, nil).Maybe()
 
 	return mockClient
 }
@@ -1000,6 +1009,51 @@ func TestTokenConditions(t *testing.T) {
 		assert.Error(t, err, "status update will fail")
 		assertCondition(t, dynakube, dynatracev1beta1.TokenConditionType, metav1.ConditionTrue, dynatracev1beta1.ReasonTokenReady, "")
 	})
+	t.Run("token condition is unverified if the Dynatrace API isn't reachable and a mirror is used", func(t *testing.T) {
+		offlineErr := &url.Error{Op: "Get", URL: testApiUrl, Err: errors.New("connection refused")}
+
+		for mirrorURL, expectedReason := range map[string]string{
+			"file:///mirror": dynatracev1beta1.ReasonTokenUnverified,
+			"":               dynatracev1beta1.ReasonTokenError,
+		} {
+			dynakube := &dynatracev1beta1.DynaKube{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testName,
+					Namespace: testNamespace,
+				},
+				Spec: dynatracev1beta1.DynaKubeSpec{
+					Mirror: &mirror.Spec{URL: mirrorURL},
+				},
+			}
+			fakeClient := fake.NewClient(&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      testName,
+					Namespace: testNamespace,
+				},
+				Data: map[string][]byte{
+					dtclient.DynatraceApiToken: []byte(testAPIToken),
+				},
+			})
+			mockDtcBuilder := &dynatraceclient.StubBuilder{
+				DynatraceClient:      mockedclient.NewClient(t),
+				TokenVerificationErr: offlineErr,
+			}
+
+			controller := &Controller{
+				client:                 fakeClient,
+				apiReader:              fakeClient,
+				dynatraceClientBuilder: mockDtcBuilder,
+				registryClientBuilder:  createFakeRegistryClientBuilder(),
+			}
+
+			err := controller.reconcileDynaKube(context.TODO(), dynakube)
+
+			assert.Error(t, err)
+			condition := meta.FindStatusCondition(dynakube.Status.Conditions, dynatracev1beta1.TokenConditionType)
+			require.NotNil(t, condition)
+			assert.Equal(t, expectedReason, condition.Reason)
+		}
+	})
 }
 
 func TestAPIError(t *testing.T) {
@@ -1022,7 +1076,7 @@ func TestAPIError(t *testing.T) {
 	}
 
 	t.Run("should return error result on 503", func(t *testing.T) {
-		mockClient.On("GetActiveGateAuthToken", testName).Return(&dtclient.ActiveGateAuthTokenInfo{}, dtclient.ServerError{Code: http.StatusServiceUnavailable, Message: "Service unavailable"})
+		mockClient.On("GetActiveGateAuthToken", mock.Anything, testName).Return(&dtclient.ActiveGateAuthTokenInfo{}, dtclient.ServerError{Code: http.StatusServiceUnavailable, Message: "Service unavailable"})
 		controller := createFakeClientAndReconciler(mockClient, instance, testPaasToken, testAPIToken)
 
 		result, err := controller.Reconcile(context.Background(), reconcile.Request{
@@ -1033,7 +1087,7 @@ func TestAPIError(t *testing.T) {
 		assert.Equal(t, fastUpdateInterval, result.RequeueAfter)
 	})
 	t.Run("should return error result on 429", func(t *testing.T) {
-		mockClient.On("GetActiveGateAuthToken", testName).Return(&dtclient.ActiveGateAuthTokenInfo{}, dtclient.ServerError{Code: http.StatusTooManyRequests, Message: "Too many requests"})
+		mockClient.On("GetActiveGateAuthToken", mock.Anything, testName).Return(&dtclient.ActiveGateAuthTokenInfo{}, dtclient.ServerError{Code: http.StatusTooManyRequests, Message: "Too many requests"})
 		controller := createFakeClientAndReconciler(mockClient, instance, testPaasToken, testAPIToken)
 
 		result, err := controller.Reconcile(context.Background(), reconcile.Request{
@@ -1043,6 +1097,37 @@ func TestAPIError(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Equal(t, fastUpdateInterval, result.RequeueAfter)
 	})
+	t.Run("should requeue after the wait time requested by the server on 429", func(t *testing.T) {
+		mockClient := createDTMockClient(t, dtclient.TokenScopes{dtclient.TokenScopeInstallerDownload}, dtclient.TokenScopes{dtclient.TokenScopeDataExport, dtclient.TokenScopeActiveGateTokenCreate})
+		mockClient.On("GetActiveGateAuthToken", mock.Anything, testName).Return(&dtclient.ActiveGateAuthTokenInfo{}, dtclient.ServerError{Code: http.StatusTooManyRequests, Message: "Too many requests", RetryAfter: 3 * time.Minute})
+		controller := createFakeClientAndReconciler(mockClient, instance, testPaasToken, testAPIToken)
+
+		result, err := controller.Reconcile(context.Background(), reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testName},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3*time.Minute, result.RequeueAfter)
+	})
+	t.Run("should set error phase and requeue slowly on 401", func(t *testing.T) {
+		mockClient := createDTMockClient(t, dtclient.TokenScopes{dtclient.TokenScopeInstallerDownload}, dtclient.TokenScopes{dtclient.TokenScopeDataExport, dtclient.TokenScopeActiveGateTokenCreate})
+		mockClient.On("GetActiveGateAuthToken", mock.Anything, testName).Return(&dtclient.ActiveGateAuthTokenInfo{}, dtclient.ServerError{Code: http.StatusUnauthorized, Message: "Token Authentication failed"})
+		controller := createFakeClientAndReconciler(mockClient, instance, testPaasToken, testAPIToken)
+
+		result, err := controller.Reconcile(context.Background(), reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: testNamespace, Name: testName},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, changesUpdateInterval, result.RequeueAfter)
+
+		dynakube, err := controller.getDynakubeOrUnmap(context.Background(), testName, testNamespace)
+		require.NoError(t, err)
+		assert.Equal(t, status.Error, dynakube.Status.Phase)
+		condition := meta.FindStatusCondition(dynakube.Status.Conditions, dynatracev1beta1.ReadyConditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, dynatracev1beta1.ReasonUnauthorized, condition.Reason)
+	})
 }
 
 func TestSetupIstio(t *testing.T) {
@@ -1178,3 +1263,99 @@ func createTenantSecrets(dynakube *dynatracev1beta1.DynaKube) []client.Object {
 		},
 	}
 }
+
+func TestReconcileMaintenanceWindows(t *testing.T) {
+	// 2024-01-06 is a saturday
+	now := metav1.NewTime(time.Date(2024, 1, 6, 9, 30, 0, 0, time.UTC))
+	timeProvider := timeprovider.New()
+	timeProvider.Set(&now)
+
+	newDynakube := func(windows ...maintenancewindow.Window) *dynatracev1beta1.DynaKube {
+		dynakube := &dynatracev1beta1.DynaKube{
+			Spec: dynatracev1beta1.DynaKubeSpec{MaintenanceWindows: windows},
+		}
+		dynakube.Status.UpdatesDeferredUntil = &metav1.Time{Time: now.Add(-time.Hour)}
+		dynakube.SetConditionUpdatePending()
+		return dynakube
+	}
+
+	t.Run("updates are allowed without maintenance windows", func(t *testing.T) {
+		controller := &Controller{timeProvider: timeProvider, requeueAfter: defaultUpdateInterval}
+		dynakube := newDynakube()
+
+		require.NoError(t, controller.reconcileMaintenanceWindows(dynakube))
+		assert.False(t, dynakube.IsUpdateDeferred())
+		assert.Nil(t, meta.FindStatusCondition(dynakube.Status.Conditions, dynatracev1beta1.UpdatePendingConditionType))
+	})
+	t.Run("updates are allowed inside of maintenance window", func(t *testing.T) {
+		controller := &Controller{timeProvider: timeProvider, requeueAfter: defaultUpdateInterval}
+		dynakube := newDynakube(maintenancewindow.Window{Days: []string{"Sat"}, Start: "09:00", End: "10:00"})
+
+		require.NoError(t, controller.reconcileMaintenanceWindows(dynakube))
+		assert.False(t, dynakube.IsUpdateDeferred())
+		assert.Nil(t, meta.FindStatusCondition(dynakube.Status.Conditions, dynatracev1beta1.UpdatePendingConditionType))
+	})
+	t.Run("updates are deferred outside of maintenance window", func(t *testing.T) {
+		controller := &Controller{timeProvider: timeProvider, requeueAfter: defaultUpdateInterval}
+		dynakube := newDynakube(maintenancewindow.Window{Days: []string{"Sat"}, Start: "09:45", End: "10:00"})
+
+		require.NoError(t, controller.reconcileMaintenanceWindows(dynakube))
+		require.True(t, dynakube.IsUpdateDeferred())
+		// the components set the condition again while their update is still deferred
+		assert.Nil(t, meta.FindStatusCondition(dynakube.Status.Conditions, dynatracev1beta1.UpdatePendingConditionType))
+		assert.True(t, now.Add(15*time.Minute).Equal(dynakube.Status.UpdatesDeferredUntil.Time))
+		assert.Equal(t, 15*time.Minute, controller.requeueAfter)
+	})
+	t.Run("error for invalid maintenance window", func(t *testing.T) {
+		controller := &Controller{timeProvider: timeProvider, requeueAfter: defaultUpdateInterval}
+		dynakube := newDynakube(maintenancewindow.Window{Start: "invalid"})
+
+		require.Error(t, controller.reconcileMaintenanceWindows(dynakube))
+	})
+}
+
+func TestReconcileSettings(t *testing.T) {
+	ctx := context.Background()
+	settingsSpec := settings.Spec{
+		Name:     "workload-anomalies",
+		SchemaId: "builtin:anomaly-detection.kubernetes.workload",
+		Value:    apiextensionsv1.JSON{Raw: []byte(`{"enabled":true}`)},
+	}
+
+	t.Run("condition is removed without settings objects", func(t *testing.T) {
+		controller := &Controller{timeProvider: timeprovider.New().Freeze()}
+		dynakube := &dynatracev1beta1.DynaKube{}
+		dynakube.SetConditionReconciled(dynatracev1beta1.SettingsConditionType)
+
+		controller.reconcileSettings(ctx, dynakube, mockedclient.NewClient(t))
+
+		assert.Nil(t, meta.FindStatusCondition(dynakube.Status.Conditions, dynatracev1beta1.SettingsConditionType))
+	})
+	t.Run("settings objects are reconciled", func(t *testing.T) {
+		controller := &Controller{timeProvider: timeprovider.New().Freeze()}
+		dynakube := &dynatracev1beta1.DynaKube{Spec: dynatracev1beta1.DynaKubeSpec{Settings: []settings.Spec{settingsSpec}}}
+		dtc := mockedclient.NewClient(t)
+		dtc.On("CreateSettingsObject", mock.Anything, mock.Anything).Return("object-id", nil)
+
+		controller.reconcileSettings(ctx, dynakube, dtc)
+
+		condition := meta.FindStatusCondition(dynakube.Status.Conditions, dynatracev1beta1.SettingsConditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionTrue, condition.Status)
+		require.Len(t, dynakube.Status.Settings, 1)
+		assert.Equal(t, "object-id", dynakube.Status.Settings[0].ObjectId)
+	})
+	t.Run("failing settings objects are reported in the condition", func(t *testing.T) {
+		controller := &Controller{timeProvider: timeprovider.New().Freeze()}
+		dynakube := &dynatracev1beta1.DynaKube{Spec: dynatracev1beta1.DynaKubeSpec{Settings: []settings.Spec{settingsSpec}}}
+		dtc := mockedclient.NewClient(t)
+		dtc.On("CreateSettingsObject", mock.Anything, mock.Anything).Return("", errors.New("constraint violation"))
+
+		controller.reconcileSettings(ctx, dynakube, dtc)
+
+		condition := meta.FindStatusCondition(dynakube.Status.Conditions, dynatracev1beta1.SettingsConditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionFalse, condition.Status)
+		assert.Contains(t, condition.Message, "constraint violation")
+	})
+}
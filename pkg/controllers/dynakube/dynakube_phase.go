@@ -59,7 +59,7 @@ func (controller *Controller) numberOfMissingOneagentPods(dynakube *dynatracev1b
 }
 
 func (controller *Controller) numberOfMissingActiveGatePods(dynakube *dynatracev1beta1.DynaKube) (int32, error) {
-	capabilities := capability.GenerateActiveGateCapabilities(dynakube)
+	capabilities := append(capability.GenerateActiveGateCapabilities(dynakube), capability.GenerateActiveGatePoolCapabilities(dynakube)...)
 
 	sum := int32(0)
 	capabilityFound := false
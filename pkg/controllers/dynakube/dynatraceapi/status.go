@@ -1,8 +1,10 @@
 package dynatraceapi
 
 import (
-	"net/http"
+	"context"
+	"net/url"
 
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/apierrors"
 	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
 	"github.com/pkg/errors"
 )
@@ -11,12 +13,15 @@ const (
 	NoError = 0
 )
 
+// IsUnreachable returns true if the Dynatrace API rejected the request because the request limit is reached or it failed to handle it
 func IsUnreachable(err error) bool {
-	var serverErr dtclient.ServerError
-	if errors.As(err, &serverErr) && (serverErr.Code == http.StatusTooManyRequests || serverErr.Code == http.StatusServiceUnavailable) {
-		return true
-	}
-	return false
+	return errors.Is(err, apierrors.ErrRateLimited) || errors.Is(err, apierrors.ErrServer)
+}
+
+// IsOffline returns true if the request failed because no connection to the Dynatrace API could be established
+func IsOffline(err error) bool {
+	var urlErr *url.Error
+	return errors.As(err, &urlErr) && !errors.Is(err, context.Canceled)
 }
 
 func StatusCode(err error) int {
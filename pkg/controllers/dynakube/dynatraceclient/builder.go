@@ -2,9 +2,17 @@ package dynatraceclient
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
 
 	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
 	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
+	dtcache "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace/cache"
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/httptransport"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/dynatraceapi"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/dynatraceevents"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/token"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/timeprovider"
 	"github.com/pkg/errors"
@@ -22,15 +30,19 @@ type Builder interface {
 }
 
 type builder struct {
-	ctx       context.Context
-	apiReader client.Reader
-	dynakube  dynatracev1beta1.DynaKube
-	tokens    token.Tokens
+	ctx        context.Context
+	apiReader  client.Reader
+	cache      *dtcache.Cache
+	transports *httptransport.Cache
+	dynakube   dynatracev1beta1.DynaKube
+	tokens     token.Tokens
 }
 
 func NewBuilder(apiReader client.Reader) Builder {
 	return builder{
-		apiReader: apiReader,
+		apiReader:  apiReader,
+		cache:      dtcache.Shared(),
+		transports: httptransport.Shared(),
 	}
 }
 
@@ -65,25 +77,30 @@ func (dynatraceClientBuilder builder) getTokens() token.Tokens {
 	return dynatraceClientBuilder.tokens
 }
 
-// Build creates a new Dynatrace client using the settings configured on the given instance.
-func (dynatraceClientBuilder builder) Build() (dtclient.Client, error) {
-	namespace := dynatraceClientBuilder.dynakube.Namespace
-	apiReader := dynatraceClientBuilder.apiReader
+// getTransports falls back to a private cache, so builders created without NewBuilder don't share their transports
+func (dynatraceClientBuilder builder) getTransports() *httptransport.Cache {
+	if dynatraceClientBuilder.transports == nil {
+		dynatraceClientBuilder.transports = httptransport.NewCache()
+	}
 
-	opts := newOptions(dynatraceClientBuilder.context())
-	opts.appendCertCheck(dynatraceClientBuilder.dynakube.Spec.SkipCertCheck)
-	opts.appendNetworkZone(dynatraceClientBuilder.dynakube.Spec.NetworkZone)
-	opts.appendDisableHostsRequests(dynatraceClientBuilder.dynakube.FeatureDisableHostsRequests())
+	return dynatraceClientBuilder.transports
+}
 
-	err := opts.appendProxySettings(apiReader, &dynatraceClientBuilder.dynakube)
+// Build creates a new Dynatrace client using the settings configured on the given instance.
+func (dynatraceClientBuilder builder) Build() (dtclient.Client, error) {
+	transport, err := dynatraceClientBuilder.getTransports().ForDynaKube(dynatraceClientBuilder.context(), dynatraceClientBuilder.apiReader, &dynatraceClientBuilder.dynakube)
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
 
-	err = opts.appendTrustedCerts(apiReader, dynatraceClientBuilder.dynakube.Spec.TrustedCAs, namespace)
-	if err != nil {
-		return nil, errors.WithStack(err)
-	}
+	opts := newOptions(dynatraceClientBuilder.context())
+	opts.appendTransport(transport)
+	opts.appendNetworkZone(dynatraceClientBuilder.dynakube.Spec.NetworkZone)
+	opts.appendDisableHostsRequests(dynatraceClientBuilder.dynakube.FeatureDisableHostsRequests())
+	opts.appendOwner(dynatraceClientBuilder.dynakube.Name)
+	opts.appendMirror(dynatraceClientBuilder.dynakube.MirrorURL())
+	opts.appendRetries(&dynatraceClientBuilder.dynakube)
+	opts.appendOAuthClient(dynatraceClientBuilder.getTokens(), &dynatraceClientBuilder.dynakube)
 
 	apiToken := dynatraceClientBuilder.getTokens().ApiToken().Value
 	paasToken := dynatraceClientBuilder.getTokens().PaasToken().Value
@@ -92,7 +109,30 @@ func (dynatraceClientBuilder builder) Build() (dtclient.Client, error) {
 		paasToken = apiToken
 	}
 
-	return dtclient.NewClient(dynatraceClientBuilder.dynakube.Spec.APIURL, apiToken, paasToken, opts.Opts...)
+	dynatraceClient, err := dtclient.NewClient(dynatraceClientBuilder.dynakube.Spec.APIURL, apiToken, paasToken, opts.Opts...)
+	if err != nil || dynatraceClientBuilder.cache == nil {
+		return dynatraceClient, err
+	}
+
+	return dtcache.NewClient(dynatraceClient, dynatraceClientBuilder.cache, dynatraceClientBuilder.cacheIdentity(), dynatraceClientBuilder.cacheTTL()), nil
+}
+
+// cacheIdentity makes sure that responses are only shared between clients which would receive the same responses
+func (dynatraceClientBuilder builder) cacheIdentity() string {
+	tokens := dynatraceClientBuilder.getTokens()
+
+	return dtcache.Identity(
+		dynatraceClientBuilder.dynakube.Spec.APIURL,
+		dynatraceClientBuilder.dynakube.Spec.NetworkZone,
+		dynatraceClientBuilder.dynakube.MirrorURL(),
+		tokens.ApiToken().Value,
+		tokens.PaasToken().Value,
+		tokens.OAuthClientID().Value,
+		tokens.OAuthClientSecret().Value)
+}
+
+func (dynatraceClientBuilder builder) cacheTTL() time.Duration {
+	return min(dtcache.DefaultTTL, dynatraceClientBuilder.dynakube.FeatureApiRequestThreshold())
 }
 
 func (dynatraceClientBuilder builder) BuildWithTokenVerification(dynaKubeStatus *dynatracev1beta1.DynaKubeStatus) (dtclient.Client, error) {
@@ -125,19 +165,42 @@ func (dynatraceClientBuilder builder) verifyTokenScopes(dynatraceClient dtclient
 		return lastErrorFromCondition(dynaKubeStatus)
 	}
 
-	err := dynatraceClientBuilder.tokens.VerifyScopes(dynatraceClient)
+	missingOptionalScopes, err := dynatraceClientBuilder.tokens.VerifyScopes(dynatraceClientBuilder.context(), dynatraceClient)
 	if err != nil {
+		// the event can't be delivered, if the Dynatrace API isn't reachable in the first place
+		if !dynatraceapi.IsOffline(err) && !dynatraceapi.IsUnreachable(err) {
+			dynatraceevents.NewSender(dynatraceClient, &dynatraceClientBuilder.dynakube).SendTokenScopeError(dynatraceClientBuilder.context(), err)
+		}
 		return err
 	}
 
 	log.Info("token verified")
+	dynatraceClientBuilder.setDynatraceEventsCondition(dynaKubeStatus, missingOptionalScopes)
 	dynaKubeStatus.DynatraceApi.LastTokenScopeRequest = metav1.Now()
 	return nil
 }
 
+// setDynatraceEventsCondition disables sending events, if the tokens lack the optional events ingest scope
+func (dynatraceClientBuilder builder) setDynatraceEventsCondition(dynaKubeStatus *dynatracev1beta1.DynaKubeStatus, missingOptionalScopes []string) {
+	if !slices.Contains(missingOptionalScopes, dtclient.TokenScopeEventsIngest) &&
+		!slices.Contains(missingOptionalScopes, dtclient.OAuthScopeEventsIngest) {
+		meta.RemoveStatusCondition(&dynaKubeStatus.Conditions, dynatracev1beta1.DynatraceEventsConditionType)
+		return
+	}
+
+	log.Info("tokens are missing the events ingest scope, events are not sent to Dynatrace", "dynakube", dynatraceClientBuilder.dynakube.Name)
+	meta.SetStatusCondition(&dynaKubeStatus.Conditions, metav1.Condition{
+		Type:               dynatracev1beta1.DynatraceEventsConditionType,
+		Status:             metav1.ConditionFalse,
+		Reason:             dynatracev1beta1.ReasonMissingScopes,
+		Message:            fmt.Sprintf("events are not sent to Dynatrace, the tokens are missing the following scopes: [ %s ]", strings.Join(missingOptionalScopes, ", ")),
+		ObservedGeneration: dynatraceClientBuilder.dynakube.Generation,
+	})
+}
+
 func lastErrorFromCondition(dynaKubeStatus *dynatracev1beta1.DynaKubeStatus) error {
 	oldCondition := meta.FindStatusCondition(dynaKubeStatus.Conditions, dynatracev1beta1.TokenConditionType)
-	if oldCondition != nil && oldCondition.Reason != dynatracev1beta1.ReasonTokenReady {
+	if oldCondition != nil && oldCondition.Reason != dynatracev1beta1.ReasonTokenReady && oldCondition.Reason != dynatracev1beta1.ReasonTokenUnverified {
 		return errors.New(oldCondition.Message)
 	}
 
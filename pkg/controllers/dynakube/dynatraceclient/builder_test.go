@@ -1,13 +1,21 @@
 package dynatraceclient
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
 	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
 	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
+	dtcache "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace/cache"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/token"
+	dtclientmock "github.com/Dynatrace/dynatrace-operator/test/mocks/pkg/clients/dynatrace"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -42,6 +50,72 @@ func TestBuildDynatraceClient(t *testing.T) {
 		assert.NoError(t, err)
 		assert.NotNil(t, dtc)
 	})
+	t.Run(`BuildDynatraceClient works with oauth client instead of tokens`, func(t *testing.T) {
+		instance := &dynatracev1beta1.DynaKube{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testNamespace,
+			},
+			Spec: dynatracev1beta1.DynaKubeSpec{
+				APIURL: testEndpoint,
+			}}
+		fakeClient := fake.NewClient(instance)
+		dynatraceClientBuilder := builder{
+			apiReader: fakeClient,
+			tokens: map[string]token.Token{
+				dtclient.DynatraceOAuthClientID:     {Value: testValue},
+				dtclient.DynatraceOAuthClientSecret: {Value: testValueAlternative},
+			},
+			dynakube: *instance,
+		}
+		dtc, err := dynatraceClientBuilder.Build()
+
+		assert.NoError(t, err)
+		assert.NotNil(t, dtc)
+	})
+	t.Run(`BuildDynatraceClient shares responses between clients of the same tenant`, func(t *testing.T) {
+		requests := 0
+		server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			requests++
+			_, _ = writer.Write([]byte(`{"tenantUUID": "abc123456", "tenantToken": "token"}`))
+		}))
+		defer server.Close()
+
+		instance := &dynatracev1beta1.DynaKube{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: testNamespace,
+			},
+			Spec: dynatracev1beta1.DynaKubeSpec{
+				APIURL: server.URL,
+			}}
+		dynatraceClientBuilder := builder{
+			apiReader: fake.NewClient(instance),
+			cache:     dtcache.New(),
+			tokens: map[string]token.Token{
+				dtclient.DynatraceApiToken: {Value: testValue},
+			},
+			dynakube: *instance,
+		}
+
+		for i := 0; i < 2; i++ {
+			dtc, err := dynatraceClientBuilder.Build()
+			require.NoError(t, err)
+
+			connectionInfo, err := dtc.GetOneAgentConnectionInfo(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, "abc123456", connectionInfo.TenantUUID)
+		}
+		assert.Equal(t, 1, requests)
+
+		dynatraceClientBuilder.tokens = map[string]token.Token{
+			dtclient.DynatraceApiToken: {Value: testValueAlternative},
+		}
+		dtc, err := dynatraceClientBuilder.Build()
+		require.NoError(t, err)
+
+		_, err = dtc.GetOneAgentConnectionInfo(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 2, requests)
+	})
 	t.Run(`BuildDynatraceClient handles nil instance`, func(t *testing.T) {
 		dtc, err := builder{}.Build()
 		assert.Nil(t, dtc)
@@ -129,3 +203,68 @@ func TestBuildDynatraceClient(t *testing.T) {
 		assert.Nil(t, dtc)
 	})
 }
+
+func TestVerifyTokenScopes(t *testing.T) {
+	instance := dynatracev1beta1.DynaKube{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Annotations: map[string]string{
+				dynatracev1beta1.AnnotationFeatureTokenScopeEvents: "true",
+			},
+		},
+		Spec: dynatracev1beta1.DynaKubeSpec{
+			APIURL: testEndpoint,
+		},
+	}
+	tokens := token.Tokens{
+		dtclient.DynatraceApiToken: {Value: testValue},
+	}.SetScopesForDynakube(instance)
+
+	t.Run("missing scopes are sent as event", func(t *testing.T) {
+		dtc := dtclientmock.NewClient(t)
+		dtc.On("GetTokenScopes", mock.Anything, testValue).Return(dtclient.TokenScopes{dtclient.TokenScopeInstallerDownload}, nil)
+		dtc.On("IngestEvent", mock.Anything, mock.Anything).Return(nil).Once()
+
+		dynatraceClientBuilder := builder{tokens: tokens, dynakube: instance}
+		err := dynatraceClientBuilder.verifyTokenScopes(dtc, &instance.Status)
+		require.Error(t, err)
+	})
+	t.Run("no event, if the api is not reachable", func(t *testing.T) {
+		dtc := dtclientmock.NewClient(t)
+		dtc.On("GetTokenScopes", mock.Anything, testValue).Return(nil, dtclient.ServerError{Code: http.StatusServiceUnavailable})
+
+		dynatraceClientBuilder := builder{tokens: tokens, dynakube: instance}
+		err := dynatraceClientBuilder.verifyTokenScopes(dtc, &instance.Status)
+		require.Error(t, err)
+	})
+	t.Run("missing events ingest scope disables events", func(t *testing.T) {
+		dtc := dtclientmock.NewClient(t)
+		dtc.On("GetTokenScopes", mock.Anything, testValue).
+			Return(dtclient.TokenScopes{dtclient.TokenScopeInstallerDownload, dtclient.TokenScopeDataExport}, nil)
+
+		status := dynatracev1beta1.DynaKubeStatus{}
+		dynatraceClientBuilder := builder{tokens: tokens, dynakube: instance}
+		err := dynatraceClientBuilder.verifyTokenScopes(dtc, &status)
+		require.NoError(t, err)
+
+		condition := meta.FindStatusCondition(status.Conditions, dynatracev1beta1.DynatraceEventsConditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionFalse, condition.Status)
+		assert.Equal(t, dynatracev1beta1.ReasonMissingScopes, condition.Reason)
+		assert.Contains(t, condition.Message, dtclient.TokenScopeEventsIngest)
+	})
+	t.Run("granted events ingest scope enables events again", func(t *testing.T) {
+		dtc := dtclientmock.NewClient(t)
+		dtc.On("GetTokenScopes", mock.Anything, testValue).
+			Return(dtclient.TokenScopes{dtclient.TokenScopeInstallerDownload, dtclient.TokenScopeDataExport, dtclient.TokenScopeEventsIngest}, nil)
+
+		status := dynatracev1beta1.DynaKubeStatus{
+			Conditions: []metav1.Condition{{Type: dynatracev1beta1.DynatraceEventsConditionType, Status: metav1.ConditionFalse}},
+		}
+		dynatraceClientBuilder := builder{tokens: tokens, dynakube: instance}
+		err := dynatraceClientBuilder.verifyTokenScopes(dtc, &status)
+		require.NoError(t, err)
+
+		assert.Nil(t, meta.FindStatusCondition(status.Conditions, dynatracev1beta1.DynatraceEventsConditionType))
+	})
+}
@@ -2,12 +2,11 @@ package dynatraceclient
 
 import (
 	"context"
+	"net/http"
 
 	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
 	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
-	"github.com/pkg/errors"
-	corev1 "k8s.io/api/core/v1"
-	"sigs.k8s.io/controller-runtime/pkg/client"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/token"
 )
 
 type options struct {
@@ -28,50 +27,45 @@ func (opts *options) appendNetworkZone(networkZone string) {
 	}
 }
 
-func (opts *options) appendCertCheck(skipCertCheck bool) {
-	opts.Opts = append(opts.Opts, dtclient.SkipCertificateValidation(skipCertCheck))
+func (opts *options) appendTransport(transport *http.Transport) {
+	opts.Opts = append(opts.Opts, dtclient.Transport(transport))
 }
 
 func (opts *options) appendDisableHostsRequests(disableHostsRequests bool) {
 	opts.Opts = append(opts.Opts, dtclient.DisableHostsRequests(disableHostsRequests))
 }
 
-func (opts *options) appendProxySettings(apiReader client.Reader, dynakube *dynatracev1beta1.DynaKube) error {
-	if dynakube == nil || !dynakube.HasProxy() {
-		return nil
+func (opts *options) appendOwner(dynakubeName string) {
+	if dynakubeName != "" {
+		opts.Opts = append(opts.Opts, dtclient.Owner(dynakubeName))
 	}
+}
 
-	proxyOption, err := opts.createProxyOption(apiReader, dynakube)
-	if err != nil {
-		return err
+func (opts *options) appendMirror(mirrorURL string) {
+	if mirrorURL != "" {
+		opts.Opts = append(opts.Opts, dtclient.Mirror(mirrorURL))
 	}
-
-	opts.Opts = append(opts.Opts, proxyOption)
-	return nil
 }
 
-func (opts *options) createProxyOption(apiReader client.Reader, dynakube *dynatracev1beta1.DynaKube) (dtclient.Option, error) {
-	var proxyOption dtclient.Option
+func (opts *options) appendRetries(dynakube *dynatracev1beta1.DynaKube) {
+	policy := dtclient.DefaultRetryPolicy()
+	policy.MaxRetries = dynakube.FeatureApiRequestRetries()
+	policy.MaxBackoff = dynakube.FeatureApiRequestMaxBackoff()
+	policy.InitialBackoff = min(policy.InitialBackoff, policy.MaxBackoff)
+	policy.RequestTimeout = dynakube.FeatureApiRequestTimeout()
 
-	proxyUrl, err := dynakube.Proxy(opts.ctx, apiReader)
-	if err != nil {
-		return proxyOption, err
-	}
-
-	proxyOption = dtclient.Proxy(proxyUrl, dynakube.FeatureNoProxy())
-	return proxyOption, nil
+	opts.Opts = append(opts.Opts, dtclient.Retries(policy))
 }
 
-func (opts *options) appendTrustedCerts(apiReader client.Reader, trustedCerts string, namespace string) error {
-	if trustedCerts != "" {
-		certs := &corev1.ConfigMap{}
-		if err := apiReader.Get(opts.ctx, client.ObjectKey{Namespace: namespace, Name: trustedCerts}, certs); err != nil {
-			return errors.WithMessage(err, "failed to get certificate configmap")
-		}
-		if certs.Data[dynatracev1beta1.TrustedCAKey] == "" {
-			return errors.New("failed to extract certificate configmap field: missing field certs")
-		}
-		opts.Opts = append(opts.Opts, dtclient.Certs([]byte(certs.Data[dynatracev1beta1.TrustedCAKey])))
+func (opts *options) appendOAuthClient(tokens token.Tokens, dynakube *dynatracev1beta1.DynaKube) {
+	if !tokens.UsesOAuth() {
+		return
 	}
-	return nil
+
+	opts.Opts = append(opts.Opts, dtclient.OAuthClientCredentials(
+		tokens.OAuthClientID().Value,
+		tokens.OAuthClientSecret().Value,
+		dynakube.Spec.OAuth.GetEndpoint(),
+		dynakube.Spec.OAuth.GetResource(),
+		token.OAuthScopesForDynakube(*dynakube)))
 }
@@ -2,30 +2,17 @@ package dynatraceclient
 
 import (
 	"context"
+	"net/http"
 	"testing"
 
-	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
 	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
 	"github.com/stretchr/testify/assert"
-	corev1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const (
-	testName        = "test-name"
 	testNetworkZone = "zone-1"
 )
 
-func createTestDynakubeWithProxy(proxy dynatracev1beta1.DynaKubeProxy) *dynatracev1beta1.DynaKube {
-	dk := &dynatracev1beta1.DynaKube{
-		Spec: dynatracev1beta1.DynaKubeSpec{
-			Proxy: &proxy,
-		},
-	}
-	dk.Namespace = testNamespace
-	return dk
-}
-
 func TestOptions(t *testing.T) {
 	t.Run(`Test append network zone`, func(t *testing.T) {
 		opts := newOptions(context.Background())
@@ -41,123 +28,46 @@ func TestOptions(t *testing.T) {
 
 		assert.NotEmpty(t, opts.Opts)
 	})
-	t.Run(`Test append cert check`, func(t *testing.T) {
+	t.Run(`Test append transport`, func(t *testing.T) {
 		opts := newOptions(context.Background())
 
 		assert.NotNil(t, opts)
 		assert.Empty(t, opts.Opts)
 
-		opts.appendCertCheck(false)
+		opts.appendTransport(&http.Transport{})
 
-		assert.NotNil(t, opts)
-		assert.NotEmpty(t, opts.Opts)
-
-		opts = newOptions(context.Background())
-		opts.appendCertCheck(true)
-
-		assert.NotNil(t, opts)
-		assert.NotEmpty(t, opts.Opts)
+		assert.Len(t, opts.Opts, 1)
 	})
-	t.Run(`Test append proxy settings`, func(t *testing.T) {
+	t.Run(`Test append owner`, func(t *testing.T) {
 		opts := newOptions(context.Background())
 
-		assert.NotNil(t, opts)
-		assert.Empty(t, opts.Opts)
+		opts.appendOwner("")
 
-		err := opts.appendProxySettings(nil, nil)
-		assert.NoError(t, err)
 		assert.Empty(t, opts.Opts)
 
-		err = opts.appendProxySettings(nil, createTestDynakubeWithProxy(dynatracev1beta1.DynaKubeProxy{Value: testValue}))
+		opts.appendOwner("dynakube")
 
-		assert.NoError(t, err)
-		assert.NotEmpty(t, opts.Opts)
-
-		fakeClient := fake.NewClient(
-			&corev1.Secret{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      testName,
-					Namespace: testNamespace,
-				},
-				Data: map[string][]byte{
-					dynatracev1beta1.ProxyKey: []byte(testValue),
-				},
-			})
-		opts = newOptions(context.Background())
-		err = opts.appendProxySettings(fakeClient, createTestDynakubeWithProxy(dynatracev1beta1.DynaKubeProxy{ValueFrom: testName}))
-
-		assert.NoError(t, err)
-		assert.NotEmpty(t, opts.Opts)
+		assert.Len(t, opts.Opts, 1)
 	})
-	t.Run(`AppendProxySettings handles missing or malformed secret`, func(t *testing.T) {
-		fakeClient := fake.NewClient()
+	t.Run(`Test append mirror`, func(t *testing.T) {
 		opts := newOptions(context.Background())
-		err := opts.appendProxySettings(fakeClient, createTestDynakubeWithProxy(dynatracev1beta1.DynaKubeProxy{ValueFrom: testName}))
 
-		assert.Error(t, err)
-		assert.Empty(t, opts.Opts)
+		opts.appendMirror("")
 
-		fakeClient = fake.NewClient(
-			&corev1.Secret{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      testName,
-					Namespace: testNamespace,
-				},
-				Data: map[string][]byte{},
-			})
-		opts = newOptions(context.Background())
-		err = opts.appendProxySettings(fakeClient, createTestDynakubeWithProxy(dynatracev1beta1.DynaKubeProxy{ValueFrom: testName}))
-
-		assert.Error(t, err)
 		assert.Empty(t, opts.Opts)
-	})
-	t.Run(`Test append trusted certificates`, func(t *testing.T) {
-		opts := newOptions(context.Background())
 
-		assert.NotNil(t, opts)
-		assert.Empty(t, opts.Opts)
-
-		err := opts.appendTrustedCerts(nil, "", "")
+		opts.appendMirror("file:///mirror")
 
-		assert.NoError(t, err)
-		assert.Empty(t, opts.Opts)
-
-		fakeClient := fake.NewClient(
-			&corev1.ConfigMap{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      testName,
-					Namespace: testNamespace,
-				},
-				Data: map[string]string{
-					dynatracev1beta1.TrustedCAKey: testValue,
-				}})
-		err = opts.appendTrustedCerts(fakeClient, testName, testNamespace)
-
-		assert.NoError(t, err)
-		assert.NotEmpty(t, opts.Opts)
+		assert.Len(t, opts.Opts, 1)
 	})
-	t.Run(`AppendTrustedCerts handles missing or malformed config map`, func(t *testing.T) {
+	t.Run(`Test append retries`, func(t *testing.T) {
 		opts := newOptions(context.Background())
 
 		assert.NotNil(t, opts)
 		assert.Empty(t, opts.Opts)
 
-		fakeClient := fake.NewClient()
-		err := opts.appendTrustedCerts(fakeClient, testName, testNamespace)
-
-		assert.Error(t, err)
-		assert.Empty(t, opts.Opts)
-
-		fakeClient = fake.NewClient(
-			&corev1.ConfigMap{
-				ObjectMeta: metav1.ObjectMeta{
-					Name:      testName,
-					Namespace: testNamespace,
-				},
-				Data: map[string]string{}})
-		err = opts.appendTrustedCerts(fakeClient, testName, testNamespace)
+		opts.appendRetries(&dynatracev1beta1.DynaKube{})
 
-		assert.Error(t, err)
-		assert.Empty(t, opts.Opts)
+		assert.Len(t, opts.Opts, 1)
 	})
 }
@@ -13,6 +13,9 @@ import (
 type StubBuilder struct {
 	DynatraceClient dtclient.Client
 	Err             error
+
+	// TokenVerificationErr is only returned by BuildWithTokenVerification, while Build still succeeds
+	TokenVerificationErr error
 }
 
 func (stubBuilder StubBuilder) SetContext(context.Context) Builder {
@@ -36,5 +39,8 @@ func (stubBuilder StubBuilder) Build() (dtclient.Client, error) {
 }
 
 func (stubBuilder StubBuilder) BuildWithTokenVerification(*dynatracev1beta1.DynaKubeStatus) (dtclient.Client, error) {
+	if stubBuilder.TokenVerificationErr != nil {
+		return nil, stubBuilder.TokenVerificationErr
+	}
 	return stubBuilder.DynatraceClient, stubBuilder.Err
 }
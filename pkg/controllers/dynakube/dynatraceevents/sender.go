@@ -0,0 +1,128 @@
+package dynatraceevents
+
+import (
+	"context"
+
+	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
+	"github.com/Dynatrace/dynatrace-operator/pkg/version"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Properties every event carries, so the events of the operator can be found and assigned to a DynaKube
+const (
+	PropertySource            = "dt.event.source"
+	PropertyOperatorVersion   = "dt.operator.version"
+	PropertyDynakubeName      = "dt.dynakube.name"
+	PropertyDynakubeNamespace = "dt.dynakube.namespace"
+
+	PropertyPreviousVersion = "previousVersion"
+	PropertyVersion         = "version"
+	PropertyError           = "error"
+	PropertyPodName         = "pod.name"
+	PropertyPodNamespace    = "pod.namespace"
+
+	source = "Dynatrace Operator"
+)
+
+// Sender sends the lifecycle events of a DynaKube to the events API of its Dynatrace environment.
+// Every event type is only sent if it is enabled via its feature flag in the DynaKube.
+// Sending is best effort, failures are logged but don't affect the reconciliation.
+type Sender struct {
+	dtc      dtclient.Client
+	dynakube *dynatracev1beta1.DynaKube
+}
+
+func NewSender(dtc dtclient.Client, dynakube *dynatracev1beta1.DynaKube) *Sender {
+	return &Sender{
+		dtc:      dtc,
+		dynakube: dynakube,
+	}
+}
+
+func (sender *Sender) SendOneAgentVersionChange(ctx context.Context, previousVersion, newVersion string) {
+	if !sender.dynakube.FeatureOneAgentVersionEvents() {
+		return
+	}
+
+	sender.send(ctx, dtclient.IngestEventTypeCustomDeployment, "OneAgent version changed", map[string]string{
+		PropertyPreviousVersion: previousVersion,
+		PropertyVersion:         newVersion,
+	})
+}
+
+func (sender *Sender) SendActiveGateVersionChange(ctx context.Context, previousVersion, newVersion string) {
+	if !sender.dynakube.FeatureActiveGateVersionEvents() {
+		return
+	}
+
+	sender.send(ctx, dtclient.IngestEventTypeCustomDeployment, "ActiveGate version changed", map[string]string{
+		PropertyPreviousVersion: previousVersion,
+		PropertyVersion:         newVersion,
+	})
+}
+
+func (sender *Sender) SendInstallFailure(ctx context.Context, agentVersion string, err error) {
+	if !sender.dynakube.FeatureInstallFailureEvents() {
+		return
+	}
+
+	sender.send(ctx, dtclient.IngestEventTypeCustomInfo, "Failed to install code modules", map[string]string{
+		PropertyVersion: agentVersion,
+		PropertyError:   err.Error(),
+	})
+}
+
+func (sender *Sender) SendTokenScopeError(ctx context.Context, err error) {
+	if !sender.dynakube.FeatureTokenScopeEvents() {
+		return
+	}
+
+	sender.send(ctx, dtclient.IngestEventTypeCustomInfo, "Tokens are missing required scopes", map[string]string{
+		PropertyError: err.Error(),
+	})
+}
+
+func (sender *Sender) SendInjectionFailure(ctx context.Context, pod *corev1.Pod, err error) {
+	if !sender.dynakube.FeatureInjectionFailureEvents() {
+		return
+	}
+
+	podName := pod.Name
+	if podName == "" {
+		podName = pod.GenerateName
+	}
+
+	sender.send(ctx, dtclient.IngestEventTypeCustomInfo, "Failed to inject into pod", map[string]string{
+		PropertyPodName:      podName,
+		PropertyPodNamespace: pod.Namespace,
+		PropertyError:        err.Error(),
+	})
+}
+
+func (sender *Sender) send(ctx context.Context, eventType, title string, properties map[string]string) {
+	if !sender.dynakube.IsDynatraceEventsIngestAllowed() {
+		log.Info("events ingest scope is missing, event not sent", "dynakube", sender.dynakube.Name, "title", title)
+		return
+	}
+
+	properties[PropertySource] = source
+	properties[PropertyOperatorVersion] = version.Version
+	properties[PropertyDynakubeName] = sender.dynakube.Name
+	properties[PropertyDynakubeNamespace] = sender.dynakube.Namespace
+
+	event := &dtclient.IngestEventData{
+		EventType:  eventType,
+		Title:      title,
+		Properties: properties,
+	}
+
+	// without the UUID of the cluster the event is attached to the environment
+	if sender.dynakube.Status.KubeSystemUUID != "" {
+		event.EntitySelector = dtclient.KubernetesClusterEntitySelector(sender.dynakube.Status.KubeSystemUUID)
+	}
+
+	if err := sender.dtc.IngestEvent(ctx, event); err != nil {
+		log.Info("failed to send event to Dynatrace", "dynakube", sender.dynakube.Name, "title", title, "error", err.Error())
+	}
+}
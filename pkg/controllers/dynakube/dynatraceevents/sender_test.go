@@ -0,0 +1,114 @@
+package dynatraceevents
+
+import (
+	"context"
+	"testing"
+
+	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
+	"github.com/Dynatrace/dynatrace-operator/pkg/version"
+	dtclientmock "github.com/Dynatrace/dynatrace-operator/test/mocks/pkg/clients/dynatrace"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	testName           = "dynakube"
+	testNamespace      = "dynatrace"
+	testKubeSystemUUID = "kube-system-uuid"
+)
+
+func createTestDynakube(annotations map[string]string) *dynatracev1beta1.DynaKube {
+	return &dynatracev1beta1.DynaKube{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        testName,
+			Namespace:   testNamespace,
+			Annotations: annotations,
+		},
+		Status: dynatracev1beta1.DynaKubeStatus{
+			KubeSystemUUID: testKubeSystemUUID,
+		},
+	}
+}
+
+func TestSender(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("disabled events are not sent", func(t *testing.T) {
+		sender := NewSender(dtclientmock.NewClient(t), createTestDynakube(nil))
+
+		sender.SendOneAgentVersionChange(ctx, "1.0", "2.0")
+		sender.SendActiveGateVersionChange(ctx, "1.0", "2.0")
+		sender.SendInstallFailure(ctx, "1.0", errors.New("failed"))
+		sender.SendTokenScopeError(ctx, errors.New("missing scopes"))
+		sender.SendInjectionFailure(ctx, &corev1.Pod{}, errors.New("failed"))
+	})
+	t.Run("events are not sent without the events ingest scope", func(t *testing.T) {
+		dynakube := createTestDynakube(map[string]string{
+			dynatracev1beta1.AnnotationFeatureOneAgentVersionEvents: "true",
+		})
+		dynakube.Status.Conditions = []metav1.Condition{{
+			Type:   dynatracev1beta1.DynatraceEventsConditionType,
+			Status: metav1.ConditionFalse,
+			Reason: dynatracev1beta1.ReasonMissingScopes,
+		}}
+
+		NewSender(dtclientmock.NewClient(t), dynakube).SendOneAgentVersionChange(ctx, "1.0", "2.0")
+	})
+	t.Run("events carry the common properties", func(t *testing.T) {
+		dtc := dtclientmock.NewClient(t)
+		dtc.On("IngestEvent", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			event := args.Get(1).(*dtclient.IngestEventData)
+			assert.Equal(t, dtclient.IngestEventTypeCustomDeployment, event.EventType)
+			assert.Equal(t, dtclient.KubernetesClusterEntitySelector(testKubeSystemUUID), event.EntitySelector)
+			assert.Equal(t, map[string]string{
+				PropertySource:            source,
+				PropertyOperatorVersion:   version.Version,
+				PropertyDynakubeName:      testName,
+				PropertyDynakubeNamespace: testNamespace,
+				PropertyPreviousVersion:   "1.0",
+				PropertyVersion:           "2.0",
+			}, event.Properties)
+		}).Return(nil).Once()
+
+		sender := NewSender(dtc, createTestDynakube(map[string]string{
+			dynatracev1beta1.AnnotationFeatureOneAgentVersionEvents: "true",
+		}))
+
+		sender.SendOneAgentVersionChange(ctx, "1.0", "2.0")
+		sender.SendActiveGateVersionChange(ctx, "1.0", "2.0")
+	})
+	t.Run("injection failure", func(t *testing.T) {
+		dtc := dtclientmock.NewClient(t)
+		dtc.On("IngestEvent", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			event := args.Get(1).(*dtclient.IngestEventData)
+			assert.Equal(t, dtclient.IngestEventTypeCustomInfo, event.EventType)
+			assert.Equal(t, "app-", event.Properties[PropertyPodName])
+			assert.Equal(t, "app-namespace", event.Properties[PropertyPodNamespace])
+			assert.Equal(t, "failed", event.Properties[PropertyError])
+		}).Return(nil).Once()
+
+		sender := NewSender(dtc, createTestDynakube(map[string]string{
+			dynatracev1beta1.AnnotationFeatureInjectionFailureEvents: "true",
+		}))
+
+		sender.SendInjectionFailure(ctx, &corev1.Pod{ObjectMeta: metav1.ObjectMeta{GenerateName: "app-", Namespace: "app-namespace"}}, errors.New("failed"))
+	})
+	t.Run("event is attached to the environment without cluster uuid", func(t *testing.T) {
+		dtc := dtclientmock.NewClient(t)
+		dtc.On("IngestEvent", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			assert.Empty(t, args.Get(1).(*dtclient.IngestEventData).EntitySelector)
+		}).Return(errors.New("not sent")).Once()
+
+		dynakube := createTestDynakube(map[string]string{
+			dynatracev1beta1.AnnotationFeatureTokenScopeEvents: "true",
+		})
+		dynakube.Status.KubeSystemUUID = ""
+
+		// failures are only logged
+		NewSender(dtc, dynakube).SendTokenScopeError(ctx, errors.New("missing scopes"))
+	})
+}
@@ -0,0 +1,71 @@
+package oneagent
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/rollout"
+	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// setRolloutCondition sets the OneAgent condition depending on the readiness of the pods of the OneAgent DaemonSet
+func (r *Reconciler) setRolloutCondition(ctx context.Context, dynakube *dynatracev1beta1.DynaKube) error {
+	if rolloutStatus := dynakube.Status.OneAgent.Rollout; rolloutStatus != nil && rolloutStatus.Phase != rollout.PhaseCompleted {
+		setStagedRolloutCondition(dynakube, rolloutStatus)
+		return nil
+	}
+
+	daemonSet := &appsv1.DaemonSet{}
+	name := dynakube.OneAgentDaemonsetName()
+	err := r.client.Get(ctx, types.NamespacedName{Name: name, Namespace: dynakube.Namespace}, daemonSet)
+	if k8serrors.IsNotFound(err) {
+		dynakube.SetCondition(dynatracev1beta1.OneAgentConditionType, metav1.ConditionFalse,
+			dynatracev1beta1.ReasonRolloutInProgress, fmt.Sprintf("DaemonSet %s is not yet available", name))
+		return nil
+	} else if err != nil {
+		dynakube.SetConditionError(dynatracev1beta1.OneAgentConditionType, err)
+		return errors.WithStack(err)
+	}
+
+	if message := daemonSetRolloutMessage(daemonSet); message != "" {
+		dynakube.SetCondition(dynatracev1beta1.OneAgentConditionType, metav1.ConditionFalse, dynatracev1beta1.ReasonRolloutInProgress, message)
+		return nil
+	}
+
+	dynakube.SetConditionReconciled(dynatracev1beta1.OneAgentConditionType)
+	return nil
+}
+
+// daemonSetRolloutMessage follows the kstatus rules for DaemonSets, it describes why the rollout isn't done yet or is empty once the latest spec runs on every node
+func daemonSetRolloutMessage(daemonSet *appsv1.DaemonSet) string {
+	switch {
+	case daemonSet.Status.ObservedGeneration < daemonSet.Generation:
+		return fmt.Sprintf("latest changes of DaemonSet %s are not yet observed", daemonSet.Name)
+	case daemonSet.Status.UpdatedNumberScheduled < daemonSet.Status.DesiredNumberScheduled:
+		return fmt.Sprintf("%d of %d OneAgent pods are updated", daemonSet.Status.UpdatedNumberScheduled, daemonSet.Status.DesiredNumberScheduled)
+	case daemonSet.Status.NumberReady < daemonSet.Status.CurrentNumberScheduled:
+		return fmt.Sprintf("%d of %d OneAgent pods are ready", daemonSet.Status.NumberReady, daemonSet.Status.CurrentNumberScheduled)
+	}
+	return ""
+}
+
+// setStagedRolloutCondition sets the OneAgent condition depending on the progress of a staged rollout
+func setStagedRolloutCondition(dynakube *dynatracev1beta1.DynaKube, rolloutStatus *rollout.Status) {
+	switch rolloutStatus.Phase {
+	case rollout.PhasePaused:
+		dynakube.SetCondition(dynatracev1beta1.OneAgentConditionType, metav1.ConditionFalse, dynatracev1beta1.ReasonRolloutPaused,
+			fmt.Sprintf("rollout paused in stage %s, OneAgent pods on nodes %s are not ready", rolloutStatus.Stage, strings.Join(rolloutStatus.FailedNodes, ", ")))
+	case rollout.PhaseRolledBack:
+		dynakube.SetCondition(dynatracev1beta1.OneAgentConditionType, metav1.ConditionFalse, dynatracev1beta1.ReasonRolledBack,
+			fmt.Sprintf("rollout rolled back, OneAgent pods on nodes %s didn't become ready", strings.Join(rolloutStatus.FailedNodes, ", ")))
+	default:
+		dynakube.SetCondition(dynatracev1beta1.OneAgentConditionType, metav1.ConditionFalse, dynatracev1beta1.ReasonRolloutInProgress,
+			fmt.Sprintf("rolling out stage %s, %d of %d OneAgent pods are updated and ready", rolloutStatus.Stage, rolloutStatus.UpdatedNodes, rolloutStatus.TotalNodes))
+	}
+}
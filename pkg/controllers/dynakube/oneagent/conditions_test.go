@@ -0,0 +1,63 @@
+package oneagent
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDaemonSetRolloutMessage(t *testing.T) {
+	newDaemonSet := func(generation int64, status appsv1.DaemonSetStatus) *appsv1.DaemonSet {
+		return &appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "oneagent", Generation: generation},
+			Status:     status,
+		}
+	}
+
+	t.Run("rollout is done", func(t *testing.T) {
+		daemonSet := newDaemonSet(2, appsv1.DaemonSetStatus{
+			ObservedGeneration:     2,
+			DesiredNumberScheduled: 3,
+			CurrentNumberScheduled: 3,
+			UpdatedNumberScheduled: 3,
+			NumberReady:            3,
+		})
+
+		assert.Empty(t, daemonSetRolloutMessage(daemonSet))
+	})
+	t.Run("latest spec is not yet observed", func(t *testing.T) {
+		daemonSet := newDaemonSet(3, appsv1.DaemonSetStatus{
+			ObservedGeneration:     2,
+			DesiredNumberScheduled: 3,
+			CurrentNumberScheduled: 3,
+			UpdatedNumberScheduled: 3,
+			NumberReady:            3,
+		})
+
+		assert.Equal(t, "latest changes of DaemonSet oneagent are not yet observed", daemonSetRolloutMessage(daemonSet))
+	})
+	t.Run("ready pods aren't updated yet", func(t *testing.T) {
+		daemonSet := newDaemonSet(2, appsv1.DaemonSetStatus{
+			ObservedGeneration:     2,
+			DesiredNumberScheduled: 3,
+			CurrentNumberScheduled: 3,
+			UpdatedNumberScheduled: 1,
+			NumberReady:            3,
+		})
+
+		assert.Equal(t, "1 of 3 OneAgent pods are updated", daemonSetRolloutMessage(daemonSet))
+	})
+	t.Run("updated pods aren't ready yet", func(t *testing.T) {
+		daemonSet := newDaemonSet(2, appsv1.DaemonSetStatus{
+			ObservedGeneration:     2,
+			DesiredNumberScheduled: 3,
+			CurrentNumberScheduled: 3,
+			UpdatedNumberScheduled: 3,
+			NumberReady:            2,
+		})
+
+		assert.Equal(t, "2 of 3 OneAgent pods are ready", daemonSetRolloutMessage(daemonSet))
+	})
+}
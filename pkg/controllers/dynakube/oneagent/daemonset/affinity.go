@@ -17,14 +17,22 @@ func (dsInfo *builderInfo) affinity() *corev1.Affinity {
 
 func (dsInfo *builderInfo) affinityNodeSelectorTerms() []corev1.NodeSelectorTerm {
 	nodeSelectorTerms := []corev1.NodeSelectorTerm{
-		kubernetesArchOsSelectorTerm(),
+		kubernetesArchOsSelectorTerm(dsInfo.nodeOs()),
 	}
 
 	return nodeSelectorTerms
 }
 
-func kubernetesArchOsSelectorTerm() corev1.NodeSelectorTerm {
+// nodeOs returns the operating system the OneAgent pods are restricted to by the node selector, empty if there is none
+func (dsInfo *builderInfo) nodeOs() string {
+	if dsInfo.hostInjectSpec == nil {
+		return ""
+	}
+	return dsInfo.hostInjectSpec.NodeSelector[corev1.LabelOSStable]
+}
+
+func kubernetesArchOsSelectorTerm(os string) corev1.NodeSelectorTerm {
 	return corev1.NodeSelectorTerm{
-		MatchExpressions: node.AffinityNodeRequirementForSupportedArches(),
+		MatchExpressions: node.AffinityNodeRequirementForSupportedArchesOnOs(os),
 	}
 }
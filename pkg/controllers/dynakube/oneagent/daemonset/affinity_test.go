@@ -3,6 +3,7 @@ package daemonset
 import (
 	"testing"
 
+	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -39,3 +40,28 @@ func TestAffinity(t *testing.T) {
 		},
 	})
 }
+
+func TestAffinityOnWindows(t *testing.T) {
+	dsInfo := builderInfo{
+		hostInjectSpec: &dynatracev1beta1.HostInjectSpec{
+			NodeSelector: map[string]string{corev1.LabelOSStable: "windows"},
+		},
+	}
+	affinity := dsInfo.affinity()
+	assert.Equal(t, []corev1.NodeSelectorTerm{
+		{
+			MatchExpressions: []corev1.NodeSelectorRequirement{
+				{
+					Key:      "kubernetes.io/arch",
+					Operator: corev1.NodeSelectorOpIn,
+					Values:   []string{"amd64"},
+				},
+				{
+					Key:      "kubernetes.io/os",
+					Operator: corev1.NodeSelectorOpIn,
+					Values:   []string{"windows"},
+				},
+			},
+		},
+	}, affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms)
+}
@@ -143,7 +143,6 @@ func (dsInfo *builderInfo) BuildDaemonSet() (*appsv1.DaemonSet, error) {
 		appLabels.BuildLabels(),
 		dsInfo.hostInjectSpec.Labels,
 	)
-	maxUnavailable := intstr.FromInt(dynakube.FeatureOneAgentMaxUnavailable())
 	annotations := map[string]string{
 		annotationUnprivileged:            annotationUnprivilegedValue,
 		webhook.AnnotationDynatraceInject: "false",
@@ -169,17 +168,29 @@ func (dsInfo *builderInfo) BuildDaemonSet() (*appsv1.DaemonSet, error) {
 				},
 				Spec: podSpec,
 			},
-			UpdateStrategy: appsv1.DaemonSetUpdateStrategy{
-				RollingUpdate: &appsv1.RollingUpdateDaemonSet{
-					MaxUnavailable: &maxUnavailable,
-				},
-			},
+			UpdateStrategy: dsInfo.updateStrategy(),
 		},
 	}
 
 	return result, nil
 }
 
+// updateStrategy lets the operator restart the pods if they are rolled out in stages
+func (dsInfo *builderInfo) updateStrategy() appsv1.DaemonSetUpdateStrategy {
+	if dsInfo.hostInjectSpec != nil && dsInfo.hostInjectSpec.Rollout != nil {
+		return appsv1.DaemonSetUpdateStrategy{
+			Type: appsv1.OnDeleteDaemonSetStrategyType,
+		}
+	}
+
+	maxUnavailable := intstr.FromInt(dsInfo.dynakube.FeatureOneAgentMaxUnavailable())
+	return appsv1.DaemonSetUpdateStrategy{
+		RollingUpdate: &appsv1.RollingUpdateDaemonSet{
+			MaxUnavailable: &maxUnavailable,
+		},
+	}
+}
+
 func (dsInfo *builderInfo) podSpec() corev1.PodSpec {
 	resources := dsInfo.resources()
 	dnsPolicy := dsInfo.dnsPolicy()
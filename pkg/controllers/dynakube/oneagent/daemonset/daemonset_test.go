@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/rollout"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/status"
 	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/deploymentmetadata"
@@ -15,6 +16,7 @@ import (
 	containerv1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -609,6 +611,31 @@ func TestDNSPolicy(t *testing.T) {
 	})
 }
 
+func TestUpdateStrategy(t *testing.T) {
+	t.Run("rolling update by default", func(t *testing.T) {
+		dsInfo := builderInfo{
+			dynakube:       &dynatracev1beta1.DynaKube{},
+			hostInjectSpec: &dynatracev1beta1.HostInjectSpec{},
+		}
+		updateStrategy := dsInfo.updateStrategy()
+
+		require.NotNil(t, updateStrategy.RollingUpdate)
+		assert.Equal(t, 1, updateStrategy.RollingUpdate.MaxUnavailable.IntValue())
+	})
+	t.Run("on delete for staged rollouts", func(t *testing.T) {
+		dsInfo := builderInfo{
+			dynakube: &dynatracev1beta1.DynaKube{},
+			hostInjectSpec: &dynatracev1beta1.HostInjectSpec{
+				Rollout: &rollout.Strategy{},
+			},
+		}
+		updateStrategy := dsInfo.updateStrategy()
+
+		assert.Equal(t, appsv1.OnDeleteDaemonSetStrategyType, updateStrategy.Type)
+		assert.Nil(t, updateStrategy.RollingUpdate)
+	})
+}
+
 func TestNodeSelector(t *testing.T) {
 	t.Run("returns empty map if hostInjectSpec is nil", func(t *testing.T) {
 		dsInfo := builderInfo{}
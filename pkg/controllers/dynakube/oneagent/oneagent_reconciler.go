@@ -70,10 +70,21 @@ func (r *Reconciler) Reconcile(ctx context.Context, dynakube *dynatracev1beta1.D
 		if len(dynakube.Spec.NetworkZone) > 0 {
 			log.Info("A network zone has been configured for DynaKube, check that there a working ActiveGate ready for that network zone", "network zone", dynakube.Spec.NetworkZone, "dynakube", dynakube.Name)
 		}
+		dynakube.SetCondition(dynatracev1beta1.OneAgentConditionType, metav1.ConditionFalse, dynatracev1beta1.ReasonRolloutPostponed,
+			"no direct route or ready ActiveGate available to communicate with the tenant")
 		return nil
 	}
 	log.Info("At least one ActiveGate is operational, deploying OneAgent")
 
+	err := r.reconcile(ctx, dynakube)
+	if err != nil {
+		dynakube.SetConditionError(dynatracev1beta1.OneAgentConditionType, err)
+		return err
+	}
+	return r.setRolloutCondition(ctx, dynakube)
+}
+
+func (r *Reconciler) reconcile(ctx context.Context, dynakube *dynatracev1beta1.DynaKube) error {
 	err := r.createOneAgentTenantConnectionInfoConfigMap(ctx, dynakube)
 	if err != nil {
 		return err
@@ -94,8 +105,9 @@ func (r *Reconciler) Reconcile(ctx context.Context, dynakube *dynatracev1beta1.D
 		}
 	}
 
+	// a staged rollout waits for the restarted pods to be recorded as instances, so they are kept up to date while it's in progress
 	now := metav1.Now()
-	if timeprovider.TimeoutReached(dynakube.Status.OneAgent.LastInstanceStatusUpdate, &now, updInterval) {
+	if dynakube.Status.OneAgent.Rollout.IsInProgress() || timeprovider.TimeoutReached(dynakube.Status.OneAgent.LastInstanceStatusUpdate, &now, updInterval) {
 		err = r.reconcileInstanceStatuses(ctx, dynakube)
 		if err != nil {
 			return err
@@ -153,6 +165,35 @@ func (r *Reconciler) reconcileRollout(ctx context.Context, dynakube *dynatracev1
 		return err
 	}
 
+	if dynakube.IsUpdateDeferred() {
+		updateNeeded, err := k8sdaemonset.IsUpdateNeeded(r.client, dsDesired)
+		if err != nil {
+			return err
+		}
+		if updateNeeded {
+			log.Info("update of OneAgent DaemonSet deferred until the next maintenance window", "until", dynakube.Status.UpdatesDeferredUntil)
+			dynakube.SetConditionUpdatePending()
+			return nil
+		}
+	}
+
+	if dynakube.OneAgentRolloutStrategy() == nil {
+		dynakube.Status.OneAgent.Rollout = nil
+	}
+
+	if isRolledBack(dynakube.Status.OneAgent.Rollout, dsDesired) {
+		log.Info("OneAgent DaemonSet was rolled back, keeping the previous version until the configuration changes")
+	} else if err := r.createOrUpdateDaemonSet(ctx, dynakube, dsDesired); err != nil {
+		return err
+	}
+
+	if dynakube.OneAgentRolloutStrategy() != nil {
+		return r.reconcileStagedRollout(ctx, dynakube, dsDesired.Annotations[hasher.AnnotationHash])
+	}
+	return nil
+}
+
+func (r *Reconciler) createOrUpdateDaemonSet(ctx context.Context, dynakube *dynatracev1beta1.DynaKube, dsDesired *appsv1.DaemonSet) error {
 	updated, err := k8sdaemonset.CreateOrUpdateDaemonSet(r.client, log, dsDesired)
 	if err != nil {
 		log.Info("failed to roll out new OneAgent DaemonSet")
@@ -212,6 +253,10 @@ func (r *Reconciler) buildDesiredDaemonSet(dynakube *dynatracev1beta1.DynaKube)
 		return nil, err
 	}
 	ds.Annotations[hasher.AnnotationHash] = dsHash
+	if dynakube.OneAgentRolloutStrategy() != nil {
+		// the pods carry the hash to tell which of them still have to be restarted
+		ds.Spec.Template.Annotations[annotationTemplateHash] = dsHash
+	}
 
 	return ds, nil
 }
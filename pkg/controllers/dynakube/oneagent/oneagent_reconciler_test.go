@@ -3,6 +3,7 @@ package oneagent
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
@@ -90,6 +91,7 @@ func TestReconcileOneAgent_ReconcileOnEmptyEnvironmentAndDNSPolicy(t *testing.T)
 	assert.Equal(t, namespace, dsActual.Namespace, "wrong namespace")
 	assert.Equal(t, dynakube.OneAgentDaemonsetName(), dsActual.GetObjectMeta().GetName(), "wrong name")
 	assert.Equal(t, corev1.DNSClusterFirstWithHostNet, dsActual.Spec.Template.Spec.DNSPolicy, "wrong policy")
+	assert.True(t, meta.IsStatusConditionTrue(dynakube.Status.Conditions, dynatracev1beta1.OneAgentConditionType))
 	mock.AssertExpectationsForObjects(t, dtClient)
 }
 
@@ -156,6 +158,11 @@ func TestReconcile_PostponeOnEmptyCommunicationHosts(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Nil(t, dynaKube.Status.OneAgent.Instances)
 	assert.Empty(t, dynaKube.Status.OneAgent.ConnectionInfoStatus.CommunicationHosts)
+
+	condition := meta.FindStatusCondition(dynaKube.Status.Conditions, dynatracev1beta1.OneAgentConditionType)
+	require.NotNil(t, condition)
+	assert.Equal(t, metav1.ConditionFalse, condition.Status)
+	assert.Equal(t, dynatracev1beta1.ReasonRolloutPostponed, condition.Reason)
 }
 
 func TestReconcile_InstancesSet(t *testing.T) {
@@ -290,6 +297,75 @@ func TestMigrationForDaemonSetWithoutAnnotation(t *testing.T) {
 	assert.True(t, hasher.IsAnnotationDifferent(ds1, ds2))
 }
 
+func TestReconcileRollout_MaintenanceWindow(t *testing.T) {
+	dkKey := metav1.ObjectMeta{Name: "my-dynakube", Namespace: "my-namespace"}
+	newDynakube := func(updatesDeferred bool) *dynatracev1beta1.DynaKube {
+		dynakube := &dynatracev1beta1.DynaKube{
+			ObjectMeta: dkKey,
+			Spec: dynatracev1beta1.DynaKubeSpec{
+				OneAgent: dynatracev1beta1.OneAgentSpec{
+					HostMonitoring: &dynatracev1beta1.HostInjectSpec{},
+				},
+			},
+		}
+		if updatesDeferred {
+			dynakube.Status.UpdatesDeferredUntil = &metav1.Time{Time: metav1.Now().Add(time.Hour)}
+		}
+		return dynakube
+	}
+	newOutdatedDaemonSet := func(dynakube *dynatracev1beta1.DynaKube) *appsv1.DaemonSet {
+		return &appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        dynakube.OneAgentDaemonsetName(),
+				Namespace:   dynakube.Namespace,
+				Annotations: map[string]string{hasher.AnnotationHash: "old"},
+			},
+			Spec: appsv1.DaemonSetSpec{
+				Selector: &metav1.LabelSelector{},
+			},
+		}
+	}
+	getHash := func(t *testing.T, fakeClient client.Client, dynakube *dynatracev1beta1.DynaKube) string {
+		var daemonSet appsv1.DaemonSet
+		err := fakeClient.Get(context.TODO(), types.NamespacedName{Name: dynakube.OneAgentDaemonsetName(), Namespace: dynakube.Namespace}, &daemonSet)
+		require.NoError(t, err)
+		return daemonSet.Annotations[hasher.AnnotationHash]
+	}
+
+	t.Run("update is deferred outside of maintenance window", func(t *testing.T) {
+		dynakube := newDynakube(true)
+		fakeClient := fake.NewClient(newOutdatedDaemonSet(dynakube))
+		reconciler := NewOneAgentReconciler(fakeClient, fakeClient, scheme.Scheme, testClusterID)
+
+		err := reconciler.reconcileRollout(context.TODO(), dynakube)
+		require.NoError(t, err)
+		assert.Equal(t, "old", getHash(t, fakeClient, dynakube))
+
+		condition := meta.FindStatusCondition(dynakube.Status.Conditions, dynatracev1beta1.UpdatePendingConditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, dynatracev1beta1.ReasonOutsideMaintenanceWindow, condition.Reason)
+	})
+	t.Run("daemonset is created outside of maintenance window", func(t *testing.T) {
+		dynakube := newDynakube(true)
+		fakeClient := fake.NewClient()
+		reconciler := NewOneAgentReconciler(fakeClient, fakeClient, scheme.Scheme, testClusterID)
+
+		err := reconciler.reconcileRollout(context.TODO(), dynakube)
+		require.NoError(t, err)
+		assert.NotEmpty(t, getHash(t, fakeClient, dynakube))
+		assert.Nil(t, meta.FindStatusCondition(dynakube.Status.Conditions, dynatracev1beta1.UpdatePendingConditionType))
+	})
+	t.Run("update is applied inside of maintenance window", func(t *testing.T) {
+		dynakube := newDynakube(false)
+		fakeClient := fake.NewClient(newOutdatedDaemonSet(dynakube))
+		reconciler := NewOneAgentReconciler(fakeClient, fakeClient, scheme.Scheme, testClusterID)
+
+		err := reconciler.reconcileRollout(context.TODO(), dynakube)
+		require.NoError(t, err)
+		assert.NotEqual(t, "old", getHash(t, fakeClient, dynakube))
+	})
+}
+
 func TestHasSpecChanged(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -0,0 +1,352 @@
+package oneagent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/rollout"
+	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/hasher"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/address"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/timeprovider"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8slabels "k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// annotationTemplateHash marks the OneAgent pods with the hash of the DaemonSet they were created from
+	annotationTemplateHash = "internal.operator.dynatrace.com/template-hash"
+
+	canaryStage = "canary"
+)
+
+// rolloutNodes sorts the OneAgent pods of a staged rollout by the state of their node
+type rolloutNodes struct {
+	// ready pods running the rolled out template
+	updated []corev1.Pod
+
+	// pods running the rolled out template that are not ready yet or not yet recorded as OneAgent instance, including pods that are being restarted
+	pending []corev1.Pod
+
+	// pods running a previous template
+	outdated []corev1.Pod
+}
+
+// isRolledBack is true if the desired DaemonSet was rolled back and must not be applied again
+func isRolledBack(rolloutStatus *rollout.Status, desiredDaemonSet *appsv1.DaemonSet) bool {
+	return rolloutStatus != nil &&
+		rolloutStatus.Phase == rollout.PhaseRolledBack &&
+		rolloutStatus.TemplateHash == desiredDaemonSet.Annotations[hasher.AnnotationHash]
+}
+
+// reconcileStagedRollout restarts the outdated OneAgent pods stage by stage, as the DaemonSet itself uses the OnDelete update strategy.
+// A stage is only started after all updated pods are ready and recorded as OneAgent instances, if they don't become ready in time the failure policy is applied.
+func (r *Reconciler) reconcileStagedRollout(ctx context.Context, dynakube *dynatracev1beta1.DynaKube, desiredHash string) error {
+	strategy := dynakube.OneAgentRolloutStrategy()
+
+	daemonSet := &appsv1.DaemonSet{}
+	err := r.client.Get(ctx, types.NamespacedName{Name: dynakube.OneAgentDaemonsetName(), Namespace: dynakube.Namespace}, daemonSet)
+	if k8serrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.WithStack(err)
+	}
+
+	rolloutStatus := dynakube.Status.OneAgent.Rollout
+	if rolloutStatus == nil || rolloutStatus.TemplateHash != desiredHash {
+		log.Info("starting staged rollout of OneAgent DaemonSet", "hash", desiredHash)
+		// pods the DaemonSet controller creates for the new template form the first stage
+		rolloutStatus = &rollout.Status{
+			TemplateHash:   desiredHash,
+			Phase:          rollout.PhaseProgressing,
+			StageStartedAt: address.Of(metav1.Now()),
+		}
+		dynakube.Status.OneAgent.Rollout = rolloutStatus
+	}
+
+	pods, err := r.getDaemonSetPods(ctx, daemonSet)
+	if err != nil {
+		return err
+	}
+
+	nodes := sortRolloutNodes(pods, daemonSet.Spec.Template.Annotations[annotationTemplateHash], dynakube.Status.OneAgent.Instances)
+	rolloutStatus.TotalNodes = int32(len(pods))
+	rolloutStatus.UpdatedNodes = int32(len(nodes.updated))
+
+	if len(nodes.pending) > 0 {
+		return r.checkStageHealth(ctx, dynakube, daemonSet, nodes)
+	}
+
+	if rolloutStatus.Phase == rollout.PhasePaused {
+		log.Info("updated OneAgent pods are ready again, resuming staged rollout")
+		rolloutStatus.Phase = rollout.PhaseProgressing
+		rolloutStatus.FailedNodes = nil
+	}
+
+	if len(nodes.outdated) == 0 {
+		if rolloutStatus.Phase == rollout.PhaseProgressing {
+			log.Info("staged rollout of OneAgent DaemonSet completed")
+			rolloutStatus.Phase = rollout.PhaseCompleted
+		}
+		rolloutStatus.Stage = ""
+		rolloutStatus.StageStartedAt = nil
+		return nil
+	}
+
+	stage, batch := "rollback", nodes.outdated
+	if rolloutStatus.Phase != rollout.PhaseRolledBack {
+		stage, batch, err = r.nextStage(ctx, strategy, pods, nodes.outdated)
+		if err != nil {
+			return err
+		}
+	}
+
+	log.Info("restarting OneAgent pods of the next stage", "stage", stage, "pods", len(batch))
+	for i := range batch {
+		err = r.client.Delete(ctx, &batch[i])
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return errors.WithStack(err)
+		}
+	}
+
+	now := metav1.Now()
+	rolloutStatus.Stage = stage
+	rolloutStatus.StageStartedAt = &now
+	return nil
+}
+
+// checkStageHealth applies the failure policy if the updated pods of the current stage didn't become ready in time
+func (r *Reconciler) checkStageHealth(ctx context.Context, dynakube *dynatracev1beta1.DynaKube, daemonSet *appsv1.DaemonSet, nodes rolloutNodes) error {
+	strategy := dynakube.OneAgentRolloutStrategy()
+	rolloutStatus := dynakube.Status.OneAgent.Rollout
+
+	if rolloutStatus.Phase != rollout.PhaseProgressing {
+		return nil
+	}
+
+	now := metav1.Now()
+	if rolloutStatus.StageStartedAt == nil {
+		// the stage wasn't started by the operator, e.g. by a previous version without start time, so its timeout starts now
+		rolloutStatus.StageStartedAt = &now
+		return nil
+	}
+
+	if !timeprovider.TimeoutReached(rolloutStatus.StageStartedAt, &now, strategy.GetHealthTimeout()) {
+		return nil
+	}
+
+	rolloutStatus.FailedNodes = nodeNames(nodes.pending)
+	log.Info("OneAgent pods didn't become ready in time, staged rollout failed",
+		"stage", rolloutStatus.Stage, "nodes", rolloutStatus.FailedNodes, "failurePolicy", strategy.GetFailurePolicy())
+
+	previousHash := mostCommonTemplateHash(nodes.outdated)
+	if strategy.GetFailurePolicy() != rollout.FailurePolicyRollback || previousHash == "" {
+		rolloutStatus.Phase = rollout.PhasePaused
+		return nil
+	}
+
+	err := r.rollbackDaemonSet(ctx, daemonSet, previousHash)
+	if err != nil {
+		return err
+	}
+	rolloutStatus.Phase = rollout.PhaseRolledBack
+	rolloutStatus.Stage = ""
+	rolloutStatus.StageStartedAt = nil
+	return nil
+}
+
+// rollbackDaemonSet restores the template with the given hash from the revision history of the DaemonSet
+func (r *Reconciler) rollbackDaemonSet(ctx context.Context, daemonSet *appsv1.DaemonSet, previousHash string) error {
+	revisions := &appsv1.ControllerRevisionList{}
+	err := r.client.List(ctx, revisions,
+		client.InNamespace(daemonSet.Namespace),
+		client.MatchingLabels(daemonSet.Spec.Selector.MatchLabels))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	for i := range revisions.Items {
+		revision := &revisions.Items[i]
+		if !metav1.IsControlledBy(revision, daemonSet) {
+			continue
+		}
+
+		template, err := templateFromRevision(revision)
+		if err != nil {
+			return err
+		}
+		if template.Annotations[annotationTemplateHash] != previousHash {
+			continue
+		}
+
+		log.Info("rolling back OneAgent DaemonSet", "revision", revision.Name)
+		daemonSet.Spec.Template = *template
+		if daemonSet.Annotations == nil {
+			daemonSet.Annotations = map[string]string{}
+		}
+		daemonSet.Annotations[hasher.AnnotationHash] = previousHash
+		return errors.WithStack(r.client.Update(ctx, daemonSet))
+	}
+	return errors.Errorf("no revision of DaemonSet %s with hash %s found to roll back to", daemonSet.Name, previousHash)
+}
+
+// templateFromRevision extracts the pod template from the patch the DaemonSet controller stores in its revisions
+func templateFromRevision(revision *appsv1.ControllerRevision) (*corev1.PodTemplateSpec, error) {
+	var patch struct {
+		Spec struct {
+			Template corev1.PodTemplateSpec `json:"template"`
+		} `json:"spec"`
+	}
+	err := json.Unmarshal(revision.Data.Raw, &patch)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to read revision %s", revision.Name)
+	}
+	return &patch.Spec.Template, nil
+}
+
+// nextStage selects the outdated pods that are restarted next, first the ones on canary nodes, then a batch of the first node pool with outdated pods
+func (r *Reconciler) nextStage(ctx context.Context, strategy *rollout.Strategy, pods []corev1.Pod, outdated []corev1.Pod) (string, []corev1.Pod, error) {
+	nodeList := &corev1.NodeList{}
+	err := r.client.List(ctx, nodeList)
+	if err != nil {
+		return "", nil, errors.WithStack(err)
+	}
+	nodeLabels := make(map[string]k8slabels.Set, len(nodeList.Items))
+	for _, node := range nodeList.Items {
+		nodeLabels[node.Name] = node.Labels
+	}
+
+	if strategy.Canary != nil {
+		canarySelector, err := metav1.LabelSelectorAsSelector(strategy.Canary)
+		if err != nil {
+			return "", nil, errors.WithStack(err)
+		}
+
+		var canaryPods []corev1.Pod
+		for _, pod := range outdated {
+			if canarySelector.Matches(nodeLabels[pod.Spec.NodeName]) {
+				canaryPods = append(canaryPods, pod)
+			}
+		}
+		if len(canaryPods) > 0 {
+			return canaryStage, canaryPods, nil
+		}
+	}
+
+	poolOf := func(pod corev1.Pod) string {
+		return nodeLabels[pod.Spec.NodeName][strategy.NodePoolLabel]
+	}
+	poolSizes := map[string]int{}
+	for _, pod := range pods {
+		poolSizes[poolOf(pod)]++
+	}
+
+	outdatedPools := map[string][]corev1.Pod{}
+	for _, pod := range outdated {
+		outdatedPools[poolOf(pod)] = append(outdatedPools[poolOf(pod)], pod)
+	}
+	poolNames := make([]string, 0, len(outdatedPools))
+	for pool := range outdatedPools {
+		poolNames = append(poolNames, pool)
+	}
+	// nodes without the pool label are updated last
+	sort.Slice(poolNames, func(i, j int) bool {
+		if poolNames[i] == "" || poolNames[j] == "" {
+			return poolNames[j] == ""
+		}
+		return poolNames[i] < poolNames[j]
+	})
+
+	pool := poolNames[0]
+	batch := outdatedPools[pool]
+	if batchSize := strategy.GetBatchSize(poolSizes[pool]); len(batch) > batchSize {
+		batch = batch[:batchSize]
+	}
+	return poolStageName(strategy.NodePoolLabel, pool), batch, nil
+}
+
+func poolStageName(nodePoolLabel, pool string) string {
+	switch {
+	case nodePoolLabel == "":
+		return "nodes"
+	case pool == "":
+		return fmt.Sprintf("nodes without %s", nodePoolLabel)
+	default:
+		return fmt.Sprintf("%s=%s", nodePoolLabel, pool)
+	}
+}
+
+func (r *Reconciler) getDaemonSetPods(ctx context.Context, daemonSet *appsv1.DaemonSet) ([]corev1.Pod, error) {
+	podList := &corev1.PodList{}
+	err := r.client.List(ctx, podList,
+		client.InNamespace(daemonSet.Namespace),
+		client.MatchingLabels(daemonSet.Spec.Selector.MatchLabels))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	pods := make([]corev1.Pod, 0, len(podList.Items))
+	for _, pod := range podList.Items {
+		if pod.Spec.NodeName != "" {
+			pods = append(pods, pod)
+		}
+	}
+	sort.Slice(pods, func(i, j int) bool {
+		return pods[i].Spec.NodeName < pods[j].Spec.NodeName
+	})
+	return pods, nil
+}
+
+func sortRolloutNodes(pods []corev1.Pod, targetHash string, instances map[string]dynatracev1beta1.OneAgentInstance) rolloutNodes {
+	var nodes rolloutNodes
+	for _, pod := range pods {
+		switch {
+		case pod.Annotations[annotationTemplateHash] != targetHash:
+			nodes.outdated = append(nodes.outdated, pod)
+		case pod.DeletionTimestamp != nil || !isPodReady(pod) || instances[pod.Spec.NodeName].PodName != pod.Name:
+			nodes.pending = append(nodes.pending, pod)
+		default:
+			nodes.updated = append(nodes.updated, pod)
+		}
+	}
+	return nodes
+}
+
+// isPodReady evaluates the readiness probe of the OneAgent, which is based on the HEALTHCHECK of its image
+func isPodReady(pod corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func mostCommonTemplateHash(pods []corev1.Pod) string {
+	counts := map[string]int{}
+	mostCommon := ""
+	for _, pod := range pods {
+		hash := pod.Annotations[annotationTemplateHash]
+		counts[hash]++
+		if counts[hash] > counts[mostCommon] {
+			mostCommon = hash
+		}
+	}
+	return mostCommon
+}
+
+func nodeNames(pods []corev1.Pod) []string {
+	names := make([]string, 0, len(pods))
+	for _, pod := range pods {
+		names = append(names, pod.Spec.NodeName)
+	}
+	return names
+}
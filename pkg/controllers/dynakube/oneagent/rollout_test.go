@@ -0,0 +1,468 @@
+package oneagent
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/rollout"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
+	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/hasher"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/address"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	testZoneLabel = "topology.kubernetes.io/zone"
+	testNewHash   = "new"
+	testOldHash   = "old"
+)
+
+var testPodLabels = map[string]string{"app": "oneagent"}
+
+func newRolloutDynakube(strategy *rollout.Strategy) *dynatracev1beta1.DynaKube {
+	return &dynatracev1beta1.DynaKube{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-dynakube", Namespace: "my-namespace"},
+		Spec: dynatracev1beta1.DynaKubeSpec{
+			OneAgent: dynatracev1beta1.OneAgentSpec{
+				HostMonitoring: &dynatracev1beta1.HostInjectSpec{
+					Rollout: strategy,
+				},
+			},
+		},
+	}
+}
+
+func newRolloutDaemonSet(dynakube *dynatracev1beta1.DynaKube, hash string) *appsv1.DaemonSet {
+	return &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        dynakube.OneAgentDaemonsetName(),
+			Namespace:   dynakube.Namespace,
+			UID:         "daemonset-uid",
+			Annotations: map[string]string{hasher.AnnotationHash: hash},
+		},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: testPodLabels},
+			Template: newRolloutTemplate(hash),
+		},
+	}
+}
+
+func newRolloutTemplate(hash string) corev1.PodTemplateSpec {
+	return corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:      testPodLabels,
+			Annotations: map[string]string{annotationTemplateHash: hash},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "dynatrace-oneagent", Image: "oneagent:" + hash}},
+		},
+	}
+}
+
+func newRolloutPod(dynakube *dynatracev1beta1.DynaKube, nodeName, hash string, ready bool) *corev1.Pod {
+	readyStatus := corev1.ConditionFalse
+	if ready {
+		readyStatus = corev1.ConditionTrue
+	}
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "oneagent-" + nodeName,
+			Namespace:   dynakube.Namespace,
+			Labels:      testPodLabels,
+			Annotations: map[string]string{annotationTemplateHash: hash},
+		},
+		Spec: corev1.PodSpec{NodeName: nodeName},
+		Status: corev1.PodStatus{
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: readyStatus}},
+		},
+	}
+}
+
+func newRolloutNode(name string, nodeLabels map[string]string) *corev1.Node {
+	return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: nodeLabels}}
+}
+
+// newRolloutCluster creates five nodes, node-a is the canary, node-a and node-b are in zone a, node-c and node-d in zone b and node-e has no zone
+func newRolloutCluster(dynakube *dynatracev1beta1.DynaKube, podHashes map[string]string, notReady ...string) []client.Object {
+	objects := []client.Object{
+		newRolloutNode("node-a", map[string]string{"canary": "true", testZoneLabel: "a"}),
+		newRolloutNode("node-b", map[string]string{testZoneLabel: "a"}),
+		newRolloutNode("node-c", map[string]string{testZoneLabel: "b"}),
+		newRolloutNode("node-d", map[string]string{testZoneLabel: "b"}),
+		newRolloutNode("node-e", nil),
+	}
+	for _, nodeName := range []string{"node-a", "node-b", "node-c", "node-d", "node-e"} {
+		ready := true
+		for _, notReadyNode := range notReady {
+			ready = ready && notReadyNode != nodeName
+		}
+		pod := newRolloutPod(dynakube, nodeName, podHashes[nodeName], ready)
+		objects = append(objects, pod)
+		registerInstance(dynakube, pod)
+	}
+	return objects
+}
+
+func registerInstance(dynakube *dynatracev1beta1.DynaKube, pod *corev1.Pod) {
+	if dynakube.Status.OneAgent.Instances == nil {
+		dynakube.Status.OneAgent.Instances = map[string]dynatracev1beta1.OneAgentInstance{}
+	}
+	dynakube.Status.OneAgent.Instances[pod.Spec.NodeName] = dynatracev1beta1.OneAgentInstance{PodName: pod.Name}
+}
+
+func podHashes(hashes ...string) map[string]string {
+	result := map[string]string{}
+	for i, nodeName := range []string{"node-a", "node-b", "node-c", "node-d", "node-e"} {
+		result[nodeName] = hashes[i]
+	}
+	return result
+}
+
+func remainingPods(t *testing.T, fakeClient client.Client) []string {
+	podList := &corev1.PodList{}
+	require.NoError(t, fakeClient.List(context.TODO(), podList))
+
+	var nodeNames []string
+	for _, pod := range podList.Items {
+		nodeNames = append(nodeNames, pod.Spec.NodeName)
+	}
+	return nodeNames
+}
+
+func newTestStrategy() *rollout.Strategy {
+	batchSize := intstr.FromInt(1)
+	return &rollout.Strategy{
+		Canary:        &metav1.LabelSelector{MatchLabels: map[string]string{"canary": "true"}},
+		NodePoolLabel: testZoneLabel,
+		BatchSize:     &batchSize,
+		HealthTimeout: &metav1.Duration{Duration: 10 * time.Minute},
+	}
+}
+
+func TestReconcileStagedRollout(t *testing.T) {
+	t.Run("canary nodes are updated first", func(t *testing.T) {
+		dynakube := newRolloutDynakube(newTestStrategy())
+		objects := newRolloutCluster(dynakube, podHashes(testOldHash, testOldHash, testOldHash, testOldHash, testOldHash))
+		fakeClient := fake.NewClient(append(objects, newRolloutDaemonSet(dynakube, testNewHash))...)
+		reconciler := NewOneAgentReconciler(fakeClient, fakeClient, scheme.Scheme, testClusterID)
+
+		err := reconciler.reconcileStagedRollout(context.TODO(), dynakube, testNewHash)
+		require.NoError(t, err)
+
+		assert.ElementsMatch(t, []string{"node-b", "node-c", "node-d", "node-e"}, remainingPods(t, fakeClient))
+		rolloutStatus := dynakube.Status.OneAgent.Rollout
+		require.NotNil(t, rolloutStatus)
+		assert.Equal(t, testNewHash, rolloutStatus.TemplateHash)
+		assert.Equal(t, rollout.PhaseProgressing, rolloutStatus.Phase)
+		assert.Equal(t, canaryStage, rolloutStatus.Stage)
+		assert.NotNil(t, rolloutStatus.StageStartedAt)
+		assert.Equal(t, int32(5), rolloutStatus.TotalNodes)
+		assert.Equal(t, int32(0), rolloutStatus.UpdatedNodes)
+	})
+	t.Run("next stage waits until updated pods are ready", func(t *testing.T) {
+		dynakube := newRolloutDynakube(newTestStrategy())
+		dynakube.Status.OneAgent.Rollout = &rollout.Status{
+			TemplateHash:   testNewHash,
+			Phase:          rollout.PhaseProgressing,
+			Stage:          canaryStage,
+			StageStartedAt: address.Of(metav1.Now()),
+		}
+		objects := newRolloutCluster(dynakube, podHashes(testNewHash, testOldHash, testOldHash, testOldHash, testOldHash), "node-a")
+		fakeClient := fake.NewClient(append(objects, newRolloutDaemonSet(dynakube, testNewHash))...)
+		reconciler := NewOneAgentReconciler(fakeClient, fakeClient, scheme.Scheme, testClusterID)
+
+		err := reconciler.reconcileStagedRollout(context.TODO(), dynakube, testNewHash)
+		require.NoError(t, err)
+
+		assert.Len(t, remainingPods(t, fakeClient), 5)
+		assert.Equal(t, rollout.PhaseProgressing, dynakube.Status.OneAgent.Rollout.Phase)
+		assert.Equal(t, canaryStage, dynakube.Status.OneAgent.Rollout.Stage)
+	})
+	t.Run("next stage waits until updated pods are recorded as instances", func(t *testing.T) {
+		dynakube := newRolloutDynakube(newTestStrategy())
+		dynakube.Status.OneAgent.Rollout = &rollout.Status{
+			TemplateHash:   testNewHash,
+			Phase:          rollout.PhaseProgressing,
+			Stage:          canaryStage,
+			StageStartedAt: address.Of(metav1.Now()),
+		}
+		objects := newRolloutCluster(dynakube, podHashes(testNewHash, testOldHash, testOldHash, testOldHash, testOldHash))
+		delete(dynakube.Status.OneAgent.Instances, "node-a")
+		fakeClient := fake.NewClient(append(objects, newRolloutDaemonSet(dynakube, testNewHash))...)
+		reconciler := NewOneAgentReconciler(fakeClient, fakeClient, scheme.Scheme, testClusterID)
+
+		err := reconciler.reconcileStagedRollout(context.TODO(), dynakube, testNewHash)
+		require.NoError(t, err)
+
+		assert.Len(t, remainingPods(t, fakeClient), 5)
+		assert.Equal(t, rollout.PhaseProgressing, dynakube.Status.OneAgent.Rollout.Phase)
+		assert.Equal(t, int32(0), dynakube.Status.OneAgent.Rollout.UpdatedNodes)
+	})
+	t.Run("first stage created by the DaemonSet controller is not failed at once", func(t *testing.T) {
+		dynakube := newRolloutDynakube(newTestStrategy())
+		objects := newRolloutCluster(dynakube, podHashes(testNewHash, testNewHash, testNewHash, testNewHash, testNewHash), "node-a", "node-b")
+		fakeClient := fake.NewClient(append(objects, newRolloutDaemonSet(dynakube, testNewHash))...)
+		reconciler := NewOneAgentReconciler(fakeClient, fakeClient, scheme.Scheme, testClusterID)
+
+		err := reconciler.reconcileStagedRollout(context.TODO(), dynakube, testNewHash)
+		require.NoError(t, err)
+
+		rolloutStatus := dynakube.Status.OneAgent.Rollout
+		assert.Equal(t, rollout.PhaseProgressing, rolloutStatus.Phase)
+		assert.NotNil(t, rolloutStatus.StageStartedAt)
+		assert.Empty(t, rolloutStatus.FailedNodes)
+	})
+	t.Run("stage without start time is not failed at once", func(t *testing.T) {
+		dynakube := newRolloutDynakube(newTestStrategy())
+		dynakube.Status.OneAgent.Rollout = &rollout.Status{
+			TemplateHash: testNewHash,
+			Phase:        rollout.PhaseProgressing,
+			Stage:        canaryStage,
+		}
+		objects := newRolloutCluster(dynakube, podHashes(testNewHash, testOldHash, testOldHash, testOldHash, testOldHash), "node-a")
+		fakeClient := fake.NewClient(append(objects, newRolloutDaemonSet(dynakube, testNewHash))...)
+		reconciler := NewOneAgentReconciler(fakeClient, fakeClient, scheme.Scheme, testClusterID)
+
+		err := reconciler.reconcileStagedRollout(context.TODO(), dynakube, testNewHash)
+		require.NoError(t, err)
+
+		rolloutStatus := dynakube.Status.OneAgent.Rollout
+		assert.Equal(t, rollout.PhaseProgressing, rolloutStatus.Phase)
+		assert.NotNil(t, rolloutStatus.StageStartedAt)
+	})
+	t.Run("node pools are updated in batches after the canary", func(t *testing.T) {
+		dynakube := newRolloutDynakube(newTestStrategy())
+		objects := newRolloutCluster(dynakube, podHashes(testNewHash, testOldHash, testOldHash, testOldHash, testOldHash))
+		fakeClient := fake.NewClient(append(objects, newRolloutDaemonSet(dynakube, testNewHash))...)
+		reconciler := NewOneAgentReconciler(fakeClient, fakeClient, scheme.Scheme, testClusterID)
+
+		err := reconciler.reconcileStagedRollout(context.TODO(), dynakube, testNewHash)
+		require.NoError(t, err)
+
+		assert.ElementsMatch(t, []string{"node-a", "node-c", "node-d", "node-e"}, remainingPods(t, fakeClient))
+		assert.Equal(t, testZoneLabel+"=a", dynakube.Status.OneAgent.Rollout.Stage)
+		assert.Equal(t, int32(1), dynakube.Status.OneAgent.Rollout.UpdatedNodes)
+	})
+	t.Run("batch size is relative to the node pool", func(t *testing.T) {
+		strategy := newTestStrategy()
+		batchSize := intstr.FromString("100%")
+		strategy.BatchSize = &batchSize
+		dynakube := newRolloutDynakube(strategy)
+		objects := newRolloutCluster(dynakube, podHashes(testNewHash, testNewHash, testOldHash, testOldHash, testOldHash))
+		fakeClient := fake.NewClient(append(objects, newRolloutDaemonSet(dynakube, testNewHash))...)
+		reconciler := NewOneAgentReconciler(fakeClient, fakeClient, scheme.Scheme, testClusterID)
+
+		err := reconciler.reconcileStagedRollout(context.TODO(), dynakube, testNewHash)
+		require.NoError(t, err)
+
+		assert.ElementsMatch(t, []string{"node-a", "node-b", "node-e"}, remainingPods(t, fakeClient))
+		assert.Equal(t, testZoneLabel+"=b", dynakube.Status.OneAgent.Rollout.Stage)
+	})
+	t.Run("nodes without node pool are updated last", func(t *testing.T) {
+		dynakube := newRolloutDynakube(newTestStrategy())
+		objects := newRolloutCluster(dynakube, podHashes(testNewHash, testNewHash, testNewHash, testNewHash, testOldHash))
+		fakeClient := fake.NewClient(append(objects, newRolloutDaemonSet(dynakube, testNewHash))...)
+		reconciler := NewOneAgentReconciler(fakeClient, fakeClient, scheme.Scheme, testClusterID)
+
+		err := reconciler.reconcileStagedRollout(context.TODO(), dynakube, testNewHash)
+		require.NoError(t, err)
+
+		assert.Len(t, remainingPods(t, fakeClient), 4)
+		assert.Equal(t, "nodes without "+testZoneLabel, dynakube.Status.OneAgent.Rollout.Stage)
+	})
+	t.Run("rollout is completed when all pods are updated and ready", func(t *testing.T) {
+		dynakube := newRolloutDynakube(newTestStrategy())
+		objects := newRolloutCluster(dynakube, podHashes(testNewHash, testNewHash, testNewHash, testNewHash, testNewHash))
+		fakeClient := fake.NewClient(append(objects, newRolloutDaemonSet(dynakube, testNewHash))...)
+		reconciler := NewOneAgentReconciler(fakeClient, fakeClient, scheme.Scheme, testClusterID)
+
+		err := reconciler.reconcileStagedRollout(context.TODO(), dynakube, testNewHash)
+		require.NoError(t, err)
+
+		rolloutStatus := dynakube.Status.OneAgent.Rollout
+		assert.Equal(t, rollout.PhaseCompleted, rolloutStatus.Phase)
+		assert.Empty(t, rolloutStatus.Stage)
+		assert.Equal(t, int32(5), rolloutStatus.UpdatedNodes)
+		assert.False(t, rolloutStatus.IsInProgress())
+	})
+}
+
+func TestReconcileStagedRollout_FailurePolicy(t *testing.T) {
+	failedStage := func() *rollout.Status {
+		return &rollout.Status{
+			TemplateHash:   testNewHash,
+			Phase:          rollout.PhaseProgressing,
+			Stage:          canaryStage,
+			StageStartedAt: &metav1.Time{Time: time.Now().Add(-time.Hour)},
+		}
+	}
+
+	t.Run("rollout is paused if the updated pods don't become ready in time", func(t *testing.T) {
+		dynakube := newRolloutDynakube(newTestStrategy())
+		dynakube.Status.OneAgent.Rollout = failedStage()
+		objects := newRolloutCluster(dynakube, podHashes(testNewHash, testOldHash, testOldHash, testOldHash, testOldHash), "node-a")
+		fakeClient := fake.NewClient(append(objects, newRolloutDaemonSet(dynakube, testNewHash))...)
+		reconciler := NewOneAgentReconciler(fakeClient, fakeClient, scheme.Scheme, testClusterID)
+
+		err := reconciler.reconcileStagedRollout(context.TODO(), dynakube, testNewHash)
+		require.NoError(t, err)
+
+		assert.Len(t, remainingPods(t, fakeClient), 5)
+		assert.Equal(t, rollout.PhasePaused, dynakube.Status.OneAgent.Rollout.Phase)
+		assert.Equal(t, []string{"node-a"}, dynakube.Status.OneAgent.Rollout.FailedNodes)
+
+		err = reconciler.setRolloutCondition(context.TODO(), dynakube)
+		require.NoError(t, err)
+		condition := meta.FindStatusCondition(dynakube.Status.Conditions, dynatracev1beta1.OneAgentConditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionFalse, condition.Status)
+		assert.Equal(t, dynatracev1beta1.ReasonRolloutPaused, condition.Reason)
+	})
+	t.Run("paused rollout resumes when the updated pods are ready", func(t *testing.T) {
+		dynakube := newRolloutDynakube(newTestStrategy())
+		dynakube.Status.OneAgent.Rollout = failedStage()
+		dynakube.Status.OneAgent.Rollout.Phase = rollout.PhasePaused
+		dynakube.Status.OneAgent.Rollout.FailedNodes = []string{"node-a"}
+		objects := newRolloutCluster(dynakube, podHashes(testNewHash, testOldHash, testOldHash, testOldHash, testOldHash))
+		fakeClient := fake.NewClient(append(objects, newRolloutDaemonSet(dynakube, testNewHash))...)
+		reconciler := NewOneAgentReconciler(fakeClient, fakeClient, scheme.Scheme, testClusterID)
+
+		err := reconciler.reconcileStagedRollout(context.TODO(), dynakube, testNewHash)
+		require.NoError(t, err)
+
+		assert.Len(t, remainingPods(t, fakeClient), 4)
+		assert.Equal(t, rollout.PhaseProgressing, dynakube.Status.OneAgent.Rollout.Phase)
+		assert.Empty(t, dynakube.Status.OneAgent.Rollout.FailedNodes)
+	})
+	t.Run("rollout is rolled back to the previous revision", func(t *testing.T) {
+		strategy := newTestStrategy()
+		strategy.FailurePolicy = rollout.FailurePolicyRollback
+		dynakube := newRolloutDynakube(strategy)
+		dynakube.Status.OneAgent.Rollout = failedStage()
+		daemonSet := newRolloutDaemonSet(dynakube, testNewHash)
+		objects := newRolloutCluster(dynakube, podHashes(testNewHash, testOldHash, testOldHash, testOldHash, testOldHash), "node-a")
+		objects = append(objects, daemonSet,
+			newRevision(t, daemonSet, "revision-1", testOldHash),
+			newRevision(t, daemonSet, "revision-2", testNewHash))
+		fakeClient := fake.NewClient(objects...)
+		reconciler := NewOneAgentReconciler(fakeClient, fakeClient, scheme.Scheme, testClusterID)
+
+		err := reconciler.reconcileStagedRollout(context.TODO(), dynakube, testNewHash)
+		require.NoError(t, err)
+
+		var rolledBack appsv1.DaemonSet
+		require.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Name: daemonSet.Name, Namespace: daemonSet.Namespace}, &rolledBack))
+		assert.Equal(t, testOldHash, rolledBack.Annotations[hasher.AnnotationHash])
+		assert.Equal(t, testOldHash, rolledBack.Spec.Template.Annotations[annotationTemplateHash])
+		assert.Equal(t, "oneagent:"+testOldHash, rolledBack.Spec.Template.Spec.Containers[0].Image)
+		assert.Equal(t, rollout.PhaseRolledBack, dynakube.Status.OneAgent.Rollout.Phase)
+		assert.True(t, isRolledBack(dynakube.Status.OneAgent.Rollout, daemonSet))
+
+		// the already updated pods are restarted with the previous template at once
+		err = reconciler.reconcileStagedRollout(context.TODO(), dynakube, testNewHash)
+		require.NoError(t, err)
+
+		assert.ElementsMatch(t, []string{"node-b", "node-c", "node-d", "node-e"}, remainingPods(t, fakeClient))
+		assert.Equal(t, "rollback", dynakube.Status.OneAgent.Rollout.Stage)
+		assert.True(t, dynakube.Status.OneAgent.Rollout.IsInProgress())
+	})
+	t.Run("DaemonSet without annotations is rolled back", func(t *testing.T) {
+		dynakube := newRolloutDynakube(newTestStrategy())
+		daemonSet := newRolloutDaemonSet(dynakube, testNewHash)
+		fakeClient := fake.NewClient(daemonSet, newRevision(t, daemonSet, "revision-1", testOldHash))
+		reconciler := NewOneAgentReconciler(fakeClient, fakeClient, scheme.Scheme, testClusterID)
+
+		var current appsv1.DaemonSet
+		require.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Name: daemonSet.Name, Namespace: daemonSet.Namespace}, &current))
+		current.Annotations = nil
+
+		err := reconciler.rollbackDaemonSet(context.TODO(), &current, testOldHash)
+		require.NoError(t, err)
+		assert.Equal(t, testOldHash, current.Annotations[hasher.AnnotationHash])
+	})
+	t.Run("rollback fails without a previous revision", func(t *testing.T) {
+		strategy := newTestStrategy()
+		strategy.FailurePolicy = rollout.FailurePolicyRollback
+		dynakube := newRolloutDynakube(strategy)
+		dynakube.Status.OneAgent.Rollout = failedStage()
+		objects := newRolloutCluster(dynakube, podHashes(testNewHash, testOldHash, testOldHash, testOldHash, testOldHash), "node-a")
+		fakeClient := fake.NewClient(append(objects, newRolloutDaemonSet(dynakube, testNewHash))...)
+		reconciler := NewOneAgentReconciler(fakeClient, fakeClient, scheme.Scheme, testClusterID)
+
+		err := reconciler.reconcileStagedRollout(context.TODO(), dynakube, testNewHash)
+		require.Error(t, err)
+	})
+	t.Run("new configuration starts a new rollout", func(t *testing.T) {
+		dynakube := newRolloutDynakube(newTestStrategy())
+		dynakube.Status.OneAgent.Rollout = failedStage()
+		dynakube.Status.OneAgent.Rollout.Phase = rollout.PhaseRolledBack
+		objects := newRolloutCluster(dynakube, podHashes(testOldHash, testOldHash, testOldHash, testOldHash, testOldHash))
+		fakeClient := fake.NewClient(append(objects, newRolloutDaemonSet(dynakube, "newer"))...)
+		reconciler := NewOneAgentReconciler(fakeClient, fakeClient, scheme.Scheme, testClusterID)
+
+		err := reconciler.reconcileStagedRollout(context.TODO(), dynakube, "newer")
+		require.NoError(t, err)
+
+		assert.Equal(t, "newer", dynakube.Status.OneAgent.Rollout.TemplateHash)
+		assert.Equal(t, rollout.PhaseProgressing, dynakube.Status.OneAgent.Rollout.Phase)
+		assert.Equal(t, canaryStage, dynakube.Status.OneAgent.Rollout.Stage)
+	})
+}
+
+func TestBuildDesiredDaemonSet_StagedRollout(t *testing.T) {
+	reconciler := NewOneAgentReconciler(fake.NewClient(), nil, scheme.Scheme, testClusterID)
+
+	t.Run("pods are marked with the hash", func(t *testing.T) {
+		daemonSet, err := reconciler.buildDesiredDaemonSet(newRolloutDynakube(&rollout.Strategy{}))
+		require.NoError(t, err)
+
+		assert.Equal(t, appsv1.OnDeleteDaemonSetStrategyType, daemonSet.Spec.UpdateStrategy.Type)
+		assert.Equal(t, daemonSet.Annotations[hasher.AnnotationHash], daemonSet.Spec.Template.Annotations[annotationTemplateHash])
+	})
+	t.Run("pods are not marked without staged rollout", func(t *testing.T) {
+		daemonSet, err := reconciler.buildDesiredDaemonSet(newRolloutDynakube(nil))
+		require.NoError(t, err)
+
+		assert.NotContains(t, daemonSet.Spec.Template.Annotations, annotationTemplateHash)
+	})
+}
+
+// newRevision creates a revision like the DaemonSet controller does, it contains a patch with the pod template
+func newRevision(t *testing.T, daemonSet *appsv1.DaemonSet, name, hash string) *appsv1.ControllerRevision {
+	patch := map[string]any{
+		"spec": map[string]any{
+			"template": newRolloutTemplate(hash),
+		},
+	}
+	data, err := json.Marshal(patch)
+	require.NoError(t, err)
+
+	return &appsv1.ControllerRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: daemonSet.Namespace,
+			Labels:    testPodLabels,
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "apps/v1",
+				Kind:       "DaemonSet",
+				Name:       daemonSet.Name,
+				UID:        daemonSet.UID,
+				Controller: address.Of(true),
+			}},
+		},
+		Data: runtime.RawExtension{Raw: data},
+	}
+}
@@ -0,0 +1,9 @@
+package token
+
+import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/logger"
+)
+
+var (
+	log = logger.Factory.GetLogger("dynakube-token")
+)
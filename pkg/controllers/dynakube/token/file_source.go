@@ -0,0 +1,63 @@
+package token
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/tokensource"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+)
+
+// fileSource reads the tokens from a directory holding one file per token.
+// The files are read on every call, so tokens rotated by e.g. the Secrets Store CSI driver are picked up right away.
+type fileSource struct {
+	fs   afero.Fs
+	path string
+}
+
+var _ Source = fileSource{}
+
+func newFileSource(fs afero.Fs, spec tokensource.FileSpec) fileSource {
+	return fileSource{
+		fs:   fs,
+		path: spec.Path,
+	}
+}
+
+func (source fileSource) Read(_ context.Context) (map[string]string, error) {
+	entries, err := afero.ReadDir(source.fs, source.path)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to list the token files in %s", source.path)
+	}
+
+	values := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		// mounted secret volumes hold their data in hidden directories (..data), which the token files link to
+		if strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		tokenFile := filepath.Join(source.path, entry.Name())
+		info, err := source.fs.Stat(tokenFile)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		if info.IsDir() {
+			continue
+		}
+
+		rawToken, err := afero.ReadFile(source.fs, tokenFile)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed to read the token file %s", tokenFile)
+		}
+		values[entry.Name()] = strings.TrimSpace(string(rawToken))
+	}
+	return values, nil
+}
+
+func (source fileSource) Description() string {
+	return fmt.Sprintf("the token directory '%s'", source.path)
+}
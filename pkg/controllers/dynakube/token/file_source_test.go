@@ -0,0 +1,65 @@
+package token
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/tokensource"
+	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testTokenDir = "/var/run/secrets/dynatrace"
+
+func TestFileSource(t *testing.T) {
+	t.Run("read one token per file", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		require.NoError(t, afero.WriteFile(fs, filepath.Join(testTokenDir, dtclient.DynatraceApiToken), []byte(testApiToken+"\n"), 0600))
+		require.NoError(t, afero.WriteFile(fs, filepath.Join(testTokenDir, dtclient.DynatracePaasToken), []byte(testPaasToken), 0600))
+		require.NoError(t, afero.WriteFile(fs, filepath.Join(testTokenDir, "..data", dtclient.DynatraceApiToken), []byte(testApiToken), 0600))
+		require.NoError(t, fs.MkdirAll(filepath.Join(testTokenDir, "subdir"), 0700))
+
+		values, err := newFileSource(fs, tokensource.FileSpec{Path: testTokenDir}).Read(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			dtclient.DynatraceApiToken:  testApiToken,
+			dtclient.DynatracePaasToken: testPaasToken,
+		}, values)
+	})
+	t.Run("rotated token is read again", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		source := newFileSource(fs, tokensource.FileSpec{Path: testTokenDir})
+		require.NoError(t, afero.WriteFile(fs, filepath.Join(testTokenDir, dtclient.DynatraceApiToken), []byte(testApiToken), 0600))
+
+		values, err := source.Read(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, testApiToken, values[dtclient.DynatraceApiToken])
+
+		require.NoError(t, afero.WriteFile(fs, filepath.Join(testTokenDir, dtclient.DynatraceApiToken), []byte("rotated"), 0600))
+
+		values, err = source.Read(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "rotated", values[dtclient.DynatraceApiToken])
+	})
+	t.Run("follows symlinks of mounted secret volumes", func(t *testing.T) {
+		tokenDir := t.TempDir()
+		require.NoError(t, os.MkdirAll(filepath.Join(tokenDir, "..data"), 0700))
+		require.NoError(t, os.WriteFile(filepath.Join(tokenDir, "..data", dtclient.DynatraceApiToken), []byte(testApiToken), 0600))
+		require.NoError(t, os.Symlink(filepath.Join("..data", dtclient.DynatraceApiToken), filepath.Join(tokenDir, dtclient.DynatraceApiToken)))
+
+		values, err := newFileSource(afero.NewOsFs(), tokensource.FileSpec{Path: tokenDir}).Read(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{dtclient.DynatraceApiToken: testApiToken}, values)
+	})
+	t.Run("error if directory is missing", func(t *testing.T) {
+		_, err := newFileSource(afero.NewMemMapFs(), tokensource.FileSpec{Path: testTokenDir}).Read(context.Background())
+
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,155 @@
+package token
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/tokensource"
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/httptransport"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+)
+
+const (
+	vaultTokenHeader     = "X-Vault-Token"
+	vaultNamespaceHeader = "X-Vault-Namespace"
+
+	httpSourceTimeout = 15 * time.Second
+)
+
+// httpSource reads the tokens from a secret store with a Vault KV compatible HTTP API.
+// Its transport is kept in the shared transport cache, so the connections are reused between reads.
+type httpSource struct {
+	fs           afero.Fs
+	spec         tokensource.HTTPSpec
+	httpClient   *http.Client
+	transportKey string
+}
+
+var _ Source = httpSource{}
+
+func newHTTPSource(fs afero.Fs, spec tokensource.HTTPSpec, transportKey string) httpSource {
+	return httpSource{
+		fs:           fs,
+		spec:         spec,
+		transportKey: transportKey,
+	}
+}
+
+// kvResponse covers KV version 1 ({"data": {<tokens>}}) and version 2 ({"data": {"data": {<tokens>}, "metadata": {...}}}) responses
+type kvResponse struct {
+	Data map[string]json.RawMessage `json:"data"`
+}
+
+func (source httpSource) Read(ctx context.Context) (map[string]string, error) {
+	request, err := source.buildRequest(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient, err := source.getHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "failed to query %s", source.Description())
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("%s responded with status code %d", source.Description(), response.StatusCode)
+	}
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return parseKVResponse(body)
+}
+
+func (source httpSource) Description() string {
+	return fmt.Sprintf("the secret store '%s'", source.secretURL())
+}
+
+func (source httpSource) secretURL() string {
+	return strings.TrimSuffix(source.spec.URL, "/") + "/v1/" + strings.TrimPrefix(source.spec.Path, "/")
+}
+
+func (source httpSource) buildRequest(ctx context.Context) (*http.Request, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, source.secretURL(), nil)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if source.spec.TokenFile != "" {
+		storeToken, err := afero.ReadFile(source.fs, source.spec.TokenFile)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed to read the secret store token file %s", source.spec.TokenFile)
+		}
+		request.Header.Set(vaultTokenHeader, strings.TrimSpace(string(storeToken)))
+	}
+	if source.spec.Namespace != "" {
+		request.Header.Set(vaultNamespaceHeader, source.spec.Namespace)
+	}
+	return request, nil
+}
+
+func (source httpSource) getHTTPClient() (*http.Client, error) {
+	if source.httpClient != nil {
+		return source.httpClient, nil
+	}
+
+	var settings httptransport.Settings
+
+	if source.spec.CAFile != "" {
+		caCerts, err := afero.ReadFile(source.fs, source.spec.CAFile)
+		if err != nil {
+			return nil, errors.WithMessagef(err, "failed to read the secret store CA file %s", source.spec.CAFile)
+		}
+		settings.TrustedCAs = caCerts
+	}
+
+	transport, err := httptransport.Shared().Get(source.transportKey, settings)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "the secret store CA file %s contains no PEM encoded certificate", source.spec.CAFile)
+	}
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   httpSourceTimeout,
+	}, nil
+}
+
+func parseKVResponse(body []byte) (map[string]string, error) {
+	var response kvResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, errors.WithMessage(err, "failed to parse the response of the secret store")
+	}
+
+	data := response.Data
+	nested, isNested := response.Data["data"]
+	if _, hasMetadata := response.Data["metadata"]; isNested && hasMetadata {
+		data = map[string]json.RawMessage{}
+		if err := json.Unmarshal(nested, &data); err != nil {
+			return nil, errors.WithMessage(err, "failed to parse the response of the secret store")
+		}
+	}
+
+	values := make(map[string]string, len(data))
+	for tokenType, rawValue := range data {
+		var value string
+		if err := json.Unmarshal(rawValue, &value); err != nil {
+			log.Info("ignoring non-string value in the secret store", "key", tokenType)
+			continue
+		}
+		values[tokenType] = value
+	}
+	return values, nil
+}
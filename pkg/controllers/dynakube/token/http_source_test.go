@@ -0,0 +1,112 @@
+package token
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/tokensource"
+	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testStoreToken     = "test-store-token"
+	testStoreTokenFile = "/var/run/secrets/vault/token"
+	testStoreNamespace = "test-store-namespace"
+	testTransportKey   = "dynatrace/dynakube/token-source"
+)
+
+func TestHTTPSource(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	require.NoError(t, afero.WriteFile(fs, testStoreTokenFile, []byte(testStoreToken+"\n"), 0600))
+
+	newStore := func(t *testing.T, path string, body string) *httptest.Server {
+		store := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+			if request.URL.Path != path {
+				writer.WriteHeader(http.StatusNotFound)
+				return
+			}
+			if request.Header.Get(vaultTokenHeader) != testStoreToken || request.Header.Get(vaultNamespaceHeader) != testStoreNamespace {
+				writer.WriteHeader(http.StatusForbidden)
+				return
+			}
+			_, _ = writer.Write([]byte(body))
+		}))
+		t.Cleanup(store.Close)
+		return store
+	}
+	newSpec := func(store *httptest.Server, path string) tokensource.HTTPSpec {
+		return tokensource.HTTPSpec{
+			URL:       store.URL + "/",
+			Path:      path,
+			TokenFile: testStoreTokenFile,
+			Namespace: testStoreNamespace,
+		}
+	}
+
+	t.Run("read KV version 2 secret", func(t *testing.T) {
+		store := newStore(t, "/v1/secret/data/dynatrace",
+			`{"data": {"data": {"apiToken": "`+testApiToken+`", "paasToken": "`+testPaasToken+`"}, "metadata": {"version": 3}}}`)
+
+		values, err := newHTTPSource(fs, newSpec(store, "secret/data/dynatrace"), testTransportKey).Read(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			dtclient.DynatraceApiToken:  testApiToken,
+			dtclient.DynatracePaasToken: testPaasToken,
+		}, values)
+	})
+	t.Run("read KV version 1 secret", func(t *testing.T) {
+		store := newStore(t, "/v1/kv/dynatrace",
+			`{"data": {"apiToken": "`+testApiToken+`", "ttl": 3600}, "lease_duration": 3600}`)
+
+		values, err := newHTTPSource(fs, newSpec(store, "/kv/dynatrace"), testTransportKey).Read(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{dtclient.DynatraceApiToken: testApiToken}, values)
+	})
+	t.Run("error on unexpected status code", func(t *testing.T) {
+		store := newStore(t, "/v1/secret/data/dynatrace", `{}`)
+		spec := newSpec(store, "secret/data/dynatrace")
+		spec.Namespace = "other"
+
+		_, err := newHTTPSource(fs, spec, testTransportKey).Read(context.Background())
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "403")
+		assert.NotContains(t, err.Error(), testStoreToken)
+	})
+	t.Run("error if token file is missing", func(t *testing.T) {
+		store := newStore(t, "/v1/secret/data/dynatrace", `{}`)
+		spec := newSpec(store, "secret/data/dynatrace")
+		spec.TokenFile = "/missing"
+
+		_, err := newHTTPSource(fs, spec, testTransportKey).Read(context.Background())
+
+		require.Error(t, err)
+	})
+	t.Run("transport is reused between reads", func(t *testing.T) {
+		store := newStore(t, "/v1/kv/dynatrace", `{"data": {"apiToken": "`+testApiToken+`"}}`)
+		source := newHTTPSource(fs, newSpec(store, "/kv/dynatrace"), testTransportKey)
+
+		first, err := source.getHTTPClient()
+		require.NoError(t, err)
+
+		second, err := source.getHTTPClient()
+		require.NoError(t, err)
+
+		assert.Same(t, first.Transport, second.Transport)
+	})
+	t.Run("error if CA file holds no certificate", func(t *testing.T) {
+		require.NoError(t, afero.WriteFile(fs, "/ca.pem", []byte("no certificate"), 0600))
+		spec := tokensource.HTTPSpec{URL: "https://vault.example.com", Path: "secret/data/dynatrace", CAFile: "/ca.pem"}
+
+		_, err := newHTTPSource(fs, spec, testTransportKey).Read(context.Background())
+
+		require.Error(t, err)
+	})
+}
@@ -7,13 +7,14 @@ import (
 	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
 	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
 	"github.com/pkg/errors"
-	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// Reader reads the tokens of a DynaKube from the token source configured in the DynaKube, the token secret by default
 type Reader struct {
 	apiReader client.Reader
 	dynakube  *dynatracev1beta1.DynaKube
+	source    Source
 }
 
 func NewReader(apiReader client.Reader, dynakube *dynatracev1beta1.DynaKube) Reader {
@@ -40,32 +41,57 @@ func (reader Reader) ReadTokens(ctx context.Context) (Tokens, error) {
 }
 
 func (reader Reader) readTokens(ctx context.Context) (Tokens, error) {
-	var tokenSecret corev1.Secret
-	result := make(Tokens)
-
-	err := reader.apiReader.Get(ctx, client.ObjectKey{
-		Name:      reader.dynakube.Tokens(),
-		Namespace: reader.dynakube.Namespace,
-	}, &tokenSecret)
+	source, err := reader.getSource()
+	if err != nil {
+		return nil, err
+	}
 
+	values, err := source.Read(ctx)
 	if err != nil {
-		return nil, errors.WithStack(err)
+		return nil, err
 	}
 
-	for tokenType, rawToken := range tokenSecret.Data {
+	result := make(Tokens, len(values))
+	for tokenType, value := range values {
 		result[tokenType] = Token{
-			Value: string(rawToken),
+			Value: value,
 		}
 	}
 
 	return result, nil
 }
 
+func (reader Reader) getSource() (Source, error) {
+	if reader.source != nil {
+		return reader.source, nil
+	}
+	return newSource(reader.apiReader, reader.dynakube)
+}
+
+// verifyApiTokenExists checks that an api token is available, or a paas or api token next to the credentials of an oauth client.
+// The oauth client only replaces the tokens for the api requests of the operator,
+// the image pull secret and the init secret of the code modules still need a paas or api token.
 func (reader Reader) verifyApiTokenExists(tokens Tokens) error {
+	if tokens.UsesOAuth() {
+		if tokens.ApiToken().Value != "" || tokens.PaasToken().Value != "" {
+			return nil
+		}
+
+		source, err := reader.getSource()
+		if err != nil {
+			return err
+		}
+		return errors.New(fmt.Sprintf("the API or PaaS token is missing from %s, it's still needed for pull secrets and code modules if an OAuth client is used", source.Description()))
+	}
+
 	apiToken, hasApiToken := tokens[dtclient.DynatraceApiToken]
 
 	if !hasApiToken || len(apiToken.Value) == 0 {
-		return errors.New(fmt.Sprintf("the API token is missing from the token secret '%s:%s'", reader.dynakube.Namespace, reader.dynakube.Tokens()))
+		source, err := reader.getSource()
+		if err != nil {
+			return err
+		}
+		return errors.New(fmt.Sprintf("the API token is missing from %s", source.Description()))
 	}
 
 	return nil
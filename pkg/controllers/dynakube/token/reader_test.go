@@ -2,14 +2,18 @@ package token
 
 import (
 	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/tokensource"
 	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
 	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/secret"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -29,6 +33,60 @@ const (
 func TestReader(t *testing.T) {
 	t.Run("read tokens", testReadTokens)
 	t.Run("verify tokens", testVerifyTokens)
+	t.Run("read tokens from token source", testReadTokensFromSource)
+}
+
+func testReadTokensFromSource(t *testing.T) {
+	t.Run("tokens are read from files", func(t *testing.T) {
+		tokenDir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(tokenDir, dtclient.DynatraceApiToken), []byte(testApiToken), 0600))
+		dynakube := dynatracev1beta1.DynaKube{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      dynakubeName,
+				Namespace: dynatraceNamespace,
+			},
+			Spec: dynatracev1beta1.DynaKubeSpec{
+				TokenSource: &tokensource.Spec{
+					Type: tokensource.FileType,
+					File: &tokensource.FileSpec{Path: tokenDir},
+				},
+			},
+		}
+		reader := NewReader(fake.NewClient(), &dynakube)
+
+		tokens, err := reader.ReadTokens(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, testApiToken, tokens.ApiToken().Value)
+	})
+	t.Run("error names the token source if api token is missing", func(t *testing.T) {
+		tokenDir := t.TempDir()
+		dynakube := dynatracev1beta1.DynaKube{
+			Spec: dynatracev1beta1.DynaKubeSpec{
+				TokenSource: &tokensource.Spec{
+					Type: tokensource.FileType,
+					File: &tokensource.FileSpec{Path: tokenDir},
+				},
+			},
+		}
+		reader := NewReader(fake.NewClient(), &dynakube)
+
+		_, err := reader.ReadTokens(context.Background())
+
+		assert.EqualError(t, err, "the API token is missing from the token directory '"+tokenDir+"'")
+	})
+	t.Run("error if section of the type is missing", func(t *testing.T) {
+		dynakube := dynatracev1beta1.DynaKube{
+			Spec: dynatracev1beta1.DynaKubeSpec{
+				TokenSource: &tokensource.Spec{Type: tokensource.HTTPType},
+			},
+		}
+		reader := NewReader(fake.NewClient(), &dynakube)
+
+		_, err := reader.ReadTokens(context.Background())
+
+		require.Error(t, err)
+	})
 }
 
 func testReadTokens(t *testing.T) {
@@ -90,6 +148,31 @@ func testVerifyTokens(t *testing.T) {
 
 		assert.EqualError(t, err, "the API token is missing from the token secret 'dynatrace:dynakube'")
 	})
+	t.Run("error if only oauth client credentials exist", func(t *testing.T) {
+		reader := NewReader(nil, &dynatracev1beta1.DynaKube{ObjectMeta: metav1.ObjectMeta{
+			Name:      dynakubeName,
+			Namespace: dynatraceNamespace,
+		}})
+
+		err := reader.verifyApiTokenExists(map[string]Token{
+			dtclient.DynatraceOAuthClientID:     {Value: "client-id"},
+			dtclient.DynatraceOAuthClientSecret: {Value: "client-secret"},
+		})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "the API or PaaS token is missing from the token secret 'dynatrace:dynakube'")
+	})
+	t.Run("no error if paas token exists next to oauth client credentials", func(t *testing.T) {
+		reader := NewReader(nil, nil)
+
+		err := reader.verifyApiTokenExists(map[string]Token{
+			dtclient.DynatraceOAuthClientID:     {Value: "client-id"},
+			dtclient.DynatraceOAuthClientSecret: {Value: "client-secret"},
+			dtclient.DynatracePaasToken:         {Value: testPaasToken},
+		})
+
+		assert.NoError(t, err)
+	})
 	t.Run("no error if api token exists", func(t *testing.T) {
 		reader := NewReader(nil, nil)
 
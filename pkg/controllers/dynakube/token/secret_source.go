@@ -0,0 +1,49 @@
+package token
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// secretSource reads the tokens from the Kubernetes secret referenced by the DynaKube
+type secretSource struct {
+	apiReader client.Reader
+	name      string
+	namespace string
+}
+
+var _ Source = secretSource{}
+
+func newSecretSource(apiReader client.Reader, name, namespace string) secretSource {
+	return secretSource{
+		apiReader: apiReader,
+		name:      name,
+		namespace: namespace,
+	}
+}
+
+func (source secretSource) Read(ctx context.Context) (map[string]string, error) {
+	var tokenSecret corev1.Secret
+
+	err := source.apiReader.Get(ctx, client.ObjectKey{
+		Name:      source.name,
+		Namespace: source.namespace,
+	}, &tokenSecret)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	values := make(map[string]string, len(tokenSecret.Data))
+	for tokenType, rawToken := range tokenSecret.Data {
+		values[tokenType] = string(rawToken)
+	}
+	return values, nil
+}
+
+func (source secretSource) Description() string {
+	return fmt.Sprintf("the token secret '%s:%s'", source.namespace, source.name)
+}
@@ -0,0 +1,46 @@
+package token
+
+import (
+	"context"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/tokensource"
+	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/httptransport"
+	"github.com/pkg/errors"
+	"github.com/spf13/afero"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Source provides the raw token values of a DynaKube, keyed by the token type (apiToken, paasToken, ...)
+type Source interface {
+	Read(ctx context.Context) (map[string]string, error)
+
+	// Description names the source in error messages, it must not contain any token value
+	Description() string
+}
+
+// TransportKey returns the key of the transport of the token source of the DynaKube in the shared transport cache
+func TransportKey(dynakube *dynatracev1beta1.DynaKube) string {
+	return httptransport.Key(dynakube) + "/token-source"
+}
+
+func newSource(apiReader client.Reader, dynakube *dynatracev1beta1.DynaKube) (Source, error) {
+	fs := afero.NewOsFs()
+	spec := dynakube.Spec.TokenSource
+
+	switch dynakube.TokenSourceType() {
+	case tokensource.SecretType:
+		return newSecretSource(apiReader, dynakube.Tokens(), dynakube.Namespace), nil
+	case tokensource.FileType:
+		if spec.File == nil {
+			return nil, errors.New("the token source is of type file, but the file section is missing")
+		}
+		return newFileSource(fs, *spec.File), nil
+	case tokensource.HTTPType:
+		if spec.HTTP == nil {
+			return nil, errors.New("the token source is of type http, but the http section is missing")
+		}
+		return newHTTPSource(fs, *spec.HTTP, TransportKey(dynakube)), nil
+	}
+	return nil, errors.Errorf("unknown token source type '%s'", dynakube.TokenSourceType())
+}
@@ -1,17 +1,24 @@
 package token
 
 import (
+	"context"
+
 	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/apierrors"
 	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
 )
 
 type Token struct {
 	Value          string
 	RequiredScopes []string
+
+	// OptionalScopes enable features that are turned off if the token lacks them, instead of failing the verification
+	OptionalScopes []string
 }
 
 func (token Token) setApiTokenScopes(dynakube dynatracev1beta1.DynaKube, hasPaasToken bool) Token {
 	token.RequiredScopes = make([]string, 0)
+	token.OptionalScopes = nil
 
 	if !hasPaasToken {
 		token.RequiredScopes = append(token.RequiredScopes, dtclient.TokenScopeInstallerDownload)
@@ -27,6 +34,13 @@ func (token Token) setApiTokenScopes(dynakube dynatracev1beta1.DynaKube, hasPaas
 			dtclient.TokenScopeEntitiesRead,
 			dtclient.TokenScopeSettingsRead,
 			dtclient.TokenScopeSettingsWrite)
+	} else if dynakube.ManagesSettings() {
+		if dynakube.ManagesKubernetesClusterSettings() {
+			token.RequiredScopes = append(token.RequiredScopes, dtclient.TokenScopeEntitiesRead)
+		}
+		token.RequiredScopes = append(token.RequiredScopes,
+			dtclient.TokenScopeSettingsRead,
+			dtclient.TokenScopeSettingsWrite)
 	}
 
 	if dynakube.UseActiveGateAuthToken() {
@@ -34,6 +48,11 @@ func (token Token) setApiTokenScopes(dynakube dynatracev1beta1.DynaKube, hasPaas
 			dtclient.TokenScopeActiveGateTokenCreate)
 	}
 
+	if dynakube.FeatureDynatraceEvents() {
+		token.OptionalScopes = append(token.OptionalScopes,
+			dtclient.TokenScopeEventsIngest)
+	}
+
 	return token
 }
 
@@ -48,13 +67,38 @@ func (token Token) setDataIngestScopes() Token {
 }
 
 func (token Token) getMissingScopes(scopes dtclient.TokenScopes) []string {
+	return getMissingScopes(token.RequiredScopes, scopes)
+}
+
+func getMissingScopes(expectedScopes []string, scopes dtclient.TokenScopes) []string {
 	missingScopes := make([]string, 0)
 
-	for _, requiredScope := range token.RequiredScopes {
-		if !scopes.Contains(requiredScope) {
-			missingScopes = append(missingScopes, requiredScope)
+	for _, expectedScope := range expectedScopes {
+		if !scopes.Contains(expectedScope) {
+			missingScopes = append(missingScopes, expectedScope)
 		}
 	}
 
 	return missingScopes
 }
+
+// OAuthScopesForDynakube returns the OAuth scopes an OAuth client needs to have for the given dynakube, including the optional ones
+func OAuthScopesForDynakube(dynakube dynatracev1beta1.DynaKube) []string {
+	token := Token{}.setApiTokenScopes(dynakube, false)
+	return dtclient.OAuthScopes(append(token.RequiredScopes, token.OptionalScopes...))
+}
+
+// verifyOAuthScopes returns the optional scopes the oauth client lacks, or an error if it lacks required scopes
+func (token Token) verifyOAuthScopes(ctx context.Context, dtc dtclient.Client) ([]string, error) {
+	grantedScopes, err := dtc.GetOAuthScopes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	missingScopes := getMissingScopes(dtclient.OAuthScopes(token.RequiredScopes), grantedScopes)
+	if len(missingScopes) > 0 {
+		return nil, apierrors.MissingScopesError{Subject: "oauth client", Scopes: missingScopes}
+	}
+
+	return getMissingScopes(dtclient.OAuthScopes(token.OptionalScopes), grantedScopes), nil
+}
@@ -1,12 +1,16 @@
 package token
 
 import (
+	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/apierrors"
 	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/dynatraceapi"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/hasher"
 	"github.com/pkg/errors"
 )
 
@@ -24,6 +28,20 @@ func (tokens Tokens) DataIngestToken() Token {
 	return tokens.getToken(dtclient.DynatraceDataIngestToken)
 }
 
+func (tokens Tokens) OAuthClientID() Token {
+	return tokens.getToken(dtclient.DynatraceOAuthClientID)
+}
+
+func (tokens Tokens) OAuthClientSecret() Token {
+	return tokens.getToken(dtclient.DynatraceOAuthClientSecret)
+}
+
+// UsesOAuth returns true if the tokens contain the credentials of an OAuth client,
+// which is then used instead of the api and paas token to authenticate against the Dynatrace API
+func (tokens Tokens) UsesOAuth() bool {
+	return tokens.OAuthClientID().Value != "" && tokens.OAuthClientSecret().Value != ""
+}
+
 func (tokens Tokens) getToken(tokenName string) Token {
 	token, hasToken := tokens[tokenName]
 	if !hasToken {
@@ -33,13 +51,34 @@ func (tokens Tokens) getToken(tokenName string) Token {
 	return token
 }
 
+// Hash returns a hash of the token values, the required scopes are not part of it
+func (tokens Tokens) Hash() (string, error) {
+	values := make(map[string]string, len(tokens))
+	for tokenType, token := range tokens {
+		values[tokenType] = token.Value
+	}
+	return hasher.GenerateHash(values)
+}
+
 func (tokens Tokens) SetScopesForDynakube(dynakube dynatracev1beta1.DynaKube) Tokens {
 	_, hasPaasToken := tokens[dtclient.DynatracePaasToken]
+	usesOAuth := tokens.UsesOAuth()
 
 	for tokenType, token := range tokens {
 		switch tokenType {
 		case dtclient.DynatraceApiToken:
+			if usesOAuth {
+				// the api token isn't used for api requests if an oauth client is configured
+				token.RequiredScopes = nil
+				tokens[dtclient.DynatraceApiToken] = token
+				continue
+			}
 			tokens[dtclient.DynatraceApiToken] = token.setApiTokenScopes(dynakube, hasPaasToken)
+		case dtclient.DynatraceOAuthClientID:
+			if usesOAuth {
+				// the oauth client is used for all api requests, including the ones made with the paas token otherwise
+				tokens[dtclient.DynatraceOAuthClientID] = token.setApiTokenScopes(dynakube, false)
+			}
 		case dtclient.DynatracePaasToken:
 			tokens[dtclient.DynatracePaasToken] = token.setPaasTokenScopes()
 		case dtclient.DynatraceDataIngestToken:
@@ -50,15 +89,27 @@ func (tokens Tokens) SetScopesForDynakube(dynakube dynatracev1beta1.DynaKube) To
 	return tokens
 }
 
-func (tokens Tokens) VerifyScopes(dtc dtclient.Client) error {
+// VerifyScopes returns an error if a token lacks a required scope, the optional scopes the tokens lack are returned instead,
+// so the features depending on them can be turned off
+func (tokens Tokens) VerifyScopes(ctx context.Context, dtc dtclient.Client) ([]string, error) {
 	scopeErrors := make([]error, 0)
+	missingOptionalScopes := make([]string, 0)
 
 	for tokenType, token := range tokens {
-		if len(token.RequiredScopes) == 0 {
+		if len(token.RequiredScopes) == 0 && len(token.OptionalScopes) == 0 {
 			continue
 		}
 
-		scopes, err := dtc.GetTokenScopes(token.Value)
+		if tokenType == dtclient.DynatraceOAuthClientID {
+			missingScopes, err := token.verifyOAuthScopes(ctx, dtc)
+			if err != nil {
+				scopeErrors = append(scopeErrors, err)
+			}
+			missingOptionalScopes = append(missingOptionalScopes, missingScopes...)
+			continue
+		}
+
+		scopes, err := dtc.GetTokenScopes(ctx, token.Value)
 
 		if err != nil {
 			scopeErrors = append(scopeErrors, err)
@@ -69,15 +120,17 @@ func (tokens Tokens) VerifyScopes(dtc dtclient.Client) error {
 
 		if len(missingScopes) > 0 {
 			scopeErrors = append(scopeErrors,
-				errors.New(fmt.Sprintf("token '%s' is missing the following scopes: [ %s ]", tokenType, strings.Join(missingScopes, ", "))))
+				apierrors.MissingScopesError{Subject: fmt.Sprintf("token '%s'", tokenType), Scopes: missingScopes})
 		}
+
+		missingOptionalScopes = append(missingOptionalScopes, getMissingScopes(token.OptionalScopes, scopes)...)
 	}
 
 	if len(scopeErrors) > 0 {
-		return concatErrors(scopeErrors)
+		return nil, concatErrors(scopeErrors)
 	}
 
-	return nil
+	return missingOptionalScopes, nil
 }
 
 func (tokens Tokens) VerifyValues() error {
@@ -100,6 +153,7 @@ func (tokens Tokens) VerifyValues() error {
 func concatErrors(errs []error) error {
 	concatenatedError := ""
 	apiStatus := dynatraceapi.NoError
+	var retryAfter time.Duration
 
 	for index, err := range errs {
 		concatenatedError += err.Error()
@@ -110,14 +164,30 @@ func concatErrors(errs []error) error {
 
 		if apiStatus == dynatraceapi.NoError && dynatraceapi.IsUnreachable(err) {
 			apiStatus = dynatraceapi.StatusCode(err)
+			retryAfter, _ = apierrors.RetryAfter(err)
 		}
 	}
 
 	if apiStatus != dynatraceapi.NoError {
 		return dtclient.ServerError{
-			Code:    apiStatus,
-			Message: concatenatedError,
+			Code:       apiStatus,
+			Message:    concatenatedError,
+			RetryAfter: retryAfter,
 		}
 	}
-	return errors.New(concatenatedError)
+	return concatenatedErrors{message: concatenatedError, errs: errs}
+}
+
+// concatenatedErrors keeps the concatenated errors, so they can still be inspected with errors.Is and errors.As
+type concatenatedErrors struct {
+	message string
+	errs    []error
+}
+
+func (err concatenatedErrors) Error() string {
+	return err.message
+}
+
+func (err concatenatedErrors) Unwrap() []error {
+	return err.errs
 }
@@ -1,14 +1,21 @@
 package token
 
 import (
+	"context"
+
 	"net/http"
+	"net/url"
 	"testing"
 
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/settings"
 	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
 	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/dynatraceapi"
 	"github.com/Dynatrace/dynatrace-operator/test/mocks/pkg/clients/dynatrace"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -16,7 +23,9 @@ func TestTokens(t *testing.T) {
 	t.Run("set api token scopes", testSetApiTokenScopes)
 	t.Run("set paas token scopes", testPaasTokenScopes)
 	t.Run("set data ingest token scopes", testDataIngestTokenScopes)
+	t.Run("set oauth client scopes", testOAuthClientScopes)
 	t.Run("verify token scopes", testVerifyTokenScopes)
+	t.Run("verify oauth client scopes", testVerifyOAuthClientScopes)
 	t.Run("verify token values", testVerifyTokenValues)
 }
 
@@ -80,6 +89,65 @@ func testSetApiTokenScopes(t *testing.T) {
 			},
 			tokens.ApiToken().RequiredScopes)
 	})
+	t.Run("dynatrace events", func(t *testing.T) {
+		tokens := Tokens{
+			dtclient.DynatraceApiToken: {},
+		}
+		tokens = tokens.SetScopesForDynakube(dynatracev1beta1.DynaKube{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					dynatracev1beta1.AnnotationFeatureTokenScopeEvents: "true",
+				},
+			},
+		})
+
+		assert.Equal(t,
+			[]string{
+				dtclient.TokenScopeInstallerDownload,
+				dtclient.TokenScopeDataExport,
+			},
+			tokens.ApiToken().RequiredScopes)
+		assert.Equal(t, []string{dtclient.TokenScopeEventsIngest}, tokens.ApiToken().OptionalScopes)
+	})
+	t.Run("settings objects", func(t *testing.T) {
+		tokens := Tokens{
+			dtclient.DynatraceApiToken: {},
+		}
+		tokens = tokens.SetScopesForDynakube(dynatracev1beta1.DynaKube{
+			Spec: dynatracev1beta1.DynaKubeSpec{
+				Settings: []settings.Spec{{Name: "test", SchemaId: "builtin:test"}},
+			},
+		})
+
+		assert.Equal(t,
+			[]string{
+				dtclient.TokenScopeInstallerDownload,
+				dtclient.TokenScopeDataExport,
+				dtclient.TokenScopeSettingsRead,
+				dtclient.TokenScopeSettingsWrite,
+			},
+			tokens.ApiToken().RequiredScopes)
+	})
+	t.Run("settings objects in kubernetes cluster scope", func(t *testing.T) {
+		tokens := Tokens{
+			dtclient.DynatraceApiToken: {},
+		}
+		tokens = tokens.SetScopesForDynakube(dynatracev1beta1.DynaKube{
+			Spec: dynatracev1beta1.DynaKubeSpec{
+				Settings: []settings.Spec{{Name: "test", SchemaId: "builtin:test", Scope: settings.ScopeKubernetesCluster}},
+			},
+		})
+
+		assert.Equal(t,
+			[]string{
+				dtclient.TokenScopeInstallerDownload,
+				dtclient.TokenScopeDataExport,
+				dtclient.TokenScopeEntitiesRead,
+				dtclient.TokenScopeSettingsRead,
+				dtclient.TokenScopeSettingsWrite,
+			},
+			tokens.ApiToken().RequiredScopes)
+	})
 }
 
 func testPaasTokenScopes(t *testing.T) {
@@ -104,6 +172,88 @@ func testDataIngestTokenScopes(t *testing.T) {
 		tokens.DataIngestToken().RequiredScopes)
 }
 
+func testOAuthClientScopes(t *testing.T) {
+	tokens := Tokens{
+		dtclient.DynatraceOAuthClientID:     {Value: "client-id"},
+		dtclient.DynatraceOAuthClientSecret: {Value: "client-secret"},
+		dtclient.DynatraceApiToken:          {Value: "api-token"},
+		dtclient.DynatracePaasToken:         {Value: "paas-token"},
+	}
+	tokens = tokens.SetScopesForDynakube(dynatracev1beta1.DynaKube{})
+
+	assert.True(t, tokens.UsesOAuth())
+	assert.Equal(t,
+		[]string{
+			dtclient.TokenScopeInstallerDownload,
+			dtclient.TokenScopeDataExport,
+		},
+		tokens.OAuthClientID().RequiredScopes)
+	assert.Empty(t, tokens.OAuthClientSecret().RequiredScopes)
+	assert.Empty(t, tokens.ApiToken().RequiredScopes)
+	assert.Equal(t,
+		[]string{dtclient.TokenScopeInstallerDownload},
+		tokens.PaasToken().RequiredScopes)
+	assert.Equal(t,
+		[]string{dtclient.OAuthScopeInstallerDownload, dtclient.OAuthScopeEntitiesRead},
+		OAuthScopesForDynakube(dynatracev1beta1.DynaKube{}))
+	assert.Contains(t,
+		OAuthScopesForDynakube(dynatracev1beta1.DynaKube{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{dynatracev1beta1.AnnotationFeatureTokenScopeEvents: "true"},
+			},
+		}),
+		dtclient.OAuthScopeEventsIngest)
+}
+
+func testVerifyOAuthClientScopes(t *testing.T) {
+	tokens := Tokens{
+		dtclient.DynatraceOAuthClientID: Token{
+			Value:          "client-id",
+			RequiredScopes: []string{dtclient.TokenScopeInstallerDownload, dtclient.TokenScopeSettingsWrite},
+		},
+		dtclient.DynatraceOAuthClientSecret: Token{Value: "client-secret"},
+	}
+
+	t.Run("all scopes granted", func(t *testing.T) {
+		fakeDynatraceClient := mocks.NewClient(t)
+		fakeDynatraceClient.
+			On("GetOAuthScopes", mock.Anything).
+			Return(dtclient.TokenScopes{dtclient.OAuthScopeInstallerDownload, dtclient.OAuthScopeSettingsWrite}, nil)
+
+		missingOptionalScopes, err := tokens.VerifyScopes(context.Background(), fakeDynatraceClient)
+		require.NoError(t, err)
+		assert.Empty(t, missingOptionalScopes)
+	})
+	t.Run("missing scopes", func(t *testing.T) {
+		fakeDynatraceClient := mocks.NewClient(t)
+		fakeDynatraceClient.
+			On("GetOAuthScopes", mock.Anything).
+			Return(dtclient.TokenScopes{dtclient.OAuthScopeInstallerDownload}, nil)
+
+		_, err := tokens.VerifyScopes(context.Background(), fakeDynatraceClient)
+		assert.EqualError(t, err,
+			"oauth client is missing the following scopes: [ "+dtclient.OAuthScopeSettingsWrite+" ]")
+	})
+	t.Run("missing optional scopes", func(t *testing.T) {
+		tokens := Tokens{
+			dtclient.DynatraceOAuthClientID: Token{
+				Value:          "client-id",
+				RequiredScopes: []string{dtclient.TokenScopeInstallerDownload},
+				OptionalScopes: []string{dtclient.TokenScopeEventsIngest},
+			},
+			dtclient.DynatraceOAuthClientSecret: Token{Value: "client-secret"},
+		}
+		fakeDynatraceClient := mocks.NewClient(t)
+		fakeDynatraceClient.
+			On("GetOAuthScopes", mock.Anything).
+			Return(dtclient.TokenScopes{dtclient.OAuthScopeInstallerDownload}, nil)
+
+		missingOptionalScopes, err := tokens.VerifyScopes(context.Background(), fakeDynatraceClient)
+		require.NoError(t, err)
+		assert.Equal(t, []string{dtclient.OAuthScopeEventsIngest}, missingOptionalScopes)
+	})
+}
+
 func testVerifyTokenScopes(t *testing.T) {
 	validTokens := Tokens{
 		"empty-scopes": Token{
@@ -127,28 +277,44 @@ func testVerifyTokenScopes(t *testing.T) {
 			RequiredScopes: []string{"a", "c"},
 		},
 	}
+	optionalScopes := Tokens{
+		"optional-scopes": Token{
+			Value:          "optional-scopes",
+			RequiredScopes: []string{"a"},
+			OptionalScopes: []string{"b", "c"},
+		},
+	}
 	fakeDynatraceClient := mocks.NewClient(t)
 
 	fakeDynatraceClient.
-		On("GetTokenScopes", "empty-scopes").
+		On("GetTokenScopes", mock.Anything, "optional-scopes").
+		Return(dtclient.TokenScopes{"a", "c"}, nil)
+	fakeDynatraceClient.
+		On("GetTokenScopes", mock.Anything, "empty-scopes").
 		Return(dtclient.TokenScopes{"a", "c"}, nil).Maybe().Times(0)
 	fakeDynatraceClient.
-		On("GetTokenScopes", "valid-scopes").
+		On("GetTokenScopes", mock.Anything, "valid-scopes").
 		Return(dtclient.TokenScopes{"a", "c"}, nil)
 	fakeDynatraceClient.
-		On("GetTokenScopes", "invalid-scopes").
+		On("GetTokenScopes", mock.Anything, "invalid-scopes").
 		Return(dtclient.TokenScopes{"a", "c"}, nil)
 	fakeDynatraceClient.
-		On("GetTokenScopes", "api-error").
+		On("GetTokenScopes", mock.Anything, "api-error").
 		Return(dtclient.TokenScopes{}, errors.New("test api-error"))
 
-	assert.NoError(t, validTokens.VerifyScopes(fakeDynatraceClient))
-	assert.EqualError(t,
-		invalidTokens.VerifyScopes(fakeDynatraceClient),
-		"token 'invalid-scopes' is missing the following scopes: [ b, d ]")
-	assert.EqualError(t,
-		apiError.VerifyScopes(fakeDynatraceClient),
-		"test api-error")
+	missingOptionalScopes, err := validTokens.VerifyScopes(context.Background(), fakeDynatraceClient)
+	require.NoError(t, err)
+	assert.Empty(t, missingOptionalScopes)
+
+	_, err = invalidTokens.VerifyScopes(context.Background(), fakeDynatraceClient)
+	assert.EqualError(t, err, "token 'invalid-scopes' is missing the following scopes: [ b, d ]")
+
+	_, err = apiError.VerifyScopes(context.Background(), fakeDynatraceClient)
+	assert.EqualError(t, err, "test api-error")
+
+	missingOptionalScopes, err = optionalScopes.VerifyScopes(context.Background(), fakeDynatraceClient)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b"}, missingOptionalScopes)
 }
 
 func testVerifyTokenValues(t *testing.T) {
@@ -233,3 +399,41 @@ func TestConcatErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestConcatErrorsKeepsConnectionErrors(t *testing.T) {
+	connectionError := &url.Error{Op: "Get", URL: "https://test.live.dynatrace.com/api", Err: errors.New("connection refused")}
+
+	err := concatErrors([]error{errors.New("error 1"), connectionError})
+
+	assert.EqualError(t, err, "error 1\n\t"+connectionError.Error())
+	assert.True(t, dynatraceapi.IsOffline(err))
+	assert.False(t, dynatraceapi.IsOffline(concatErrors([]error{errors.New("error 1")})))
+}
+
+func TestTokensHash(t *testing.T) {
+	tokens := Tokens{
+		dtclient.DynatraceApiToken:  Token{Value: "api-token"},
+		dtclient.DynatracePaasToken: Token{Value: "paas-token"},
+	}
+	hash, err := tokens.Hash()
+	assert.NoError(t, err)
+
+	t.Run("scopes are ignored", func(t *testing.T) {
+		withScopes := Tokens{
+			dtclient.DynatraceApiToken:  Token{Value: "api-token", RequiredScopes: []string{dtclient.TokenScopeDataExport}},
+			dtclient.DynatracePaasToken: Token{Value: "paas-token"},
+		}
+		scopesHash, err := withScopes.Hash()
+		assert.NoError(t, err)
+		assert.Equal(t, hash, scopesHash)
+	})
+	t.Run("changed value changes the hash", func(t *testing.T) {
+		rotated := Tokens{
+			dtclient.DynatraceApiToken:  Token{Value: "rotated-api-token"},
+			dtclient.DynatracePaasToken: Token{Value: "paas-token"},
+		}
+		rotatedHash, err := rotated.Hash()
+		assert.NoError(t, err)
+		assert.NotEqual(t, hash, rotatedHash)
+	})
+}
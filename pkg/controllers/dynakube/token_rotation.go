@@ -0,0 +1,32 @@
+package dynakube
+
+import (
+	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/token"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// handleTokenRotation remembers the hash of the tokens in the status. If the tokens were changed since the last reconciliation,
+// the request throttling of the token verification and the connection info is reset, so they are done with the new tokens right away.
+// The pull secret and the init secrets don't need a reset, they are compared to the current tokens on every reconciliation.
+func (controller *Controller) handleTokenRotation(dynakube *dynatracev1beta1.DynaKube, tokens token.Tokens) error {
+	tokenHash, err := tokens.Hash()
+	if err != nil {
+		return errors.WithMessage(err, "failed to generate hash for the tokens")
+	}
+
+	lastTokenHash := dynakube.Status.DynatraceApi.TokenHash
+	dynakube.Status.DynatraceApi.TokenHash = tokenHash
+
+	if lastTokenHash == "" || lastTokenHash == tokenHash {
+		return nil
+	}
+
+	log.Info("tokens changed, verifying tokens and updating connection info immediately",
+		"dynakube", dynakube.Name, "namespace", dynakube.Namespace)
+	dynakube.Status.DynatraceApi.LastTokenScopeRequest = metav1.Time{}
+	dynakube.Status.OneAgent.ConnectionInfoStatus.LastRequest = metav1.Time{}
+	dynakube.Status.ActiveGate.ConnectionInfoStatus.LastRequest = metav1.Time{}
+	return nil
+}
@@ -0,0 +1,63 @@
+package dynakube
+
+import (
+	"testing"
+
+	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/token"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestHandleTokenRotation(t *testing.T) {
+	controller := &Controller{}
+	oldTokens := token.Tokens{dtclient.DynatraceApiToken: token.Token{Value: "old-api-token"}}
+	newTokens := token.Tokens{dtclient.DynatraceApiToken: token.Token{Value: "new-api-token"}}
+
+	newDynakube := func(t *testing.T, tokens token.Tokens) *dynatracev1beta1.DynaKube {
+		tokenHash, err := tokens.Hash()
+		require.NoError(t, err)
+
+		dynakube := &dynatracev1beta1.DynaKube{}
+		dynakube.Status.DynatraceApi.TokenHash = tokenHash
+		dynakube.Status.DynatraceApi.LastTokenScopeRequest = metav1.Now()
+		dynakube.Status.OneAgent.ConnectionInfoStatus.LastRequest = metav1.Now()
+		dynakube.Status.ActiveGate.ConnectionInfoStatus.LastRequest = metav1.Now()
+		return dynakube
+	}
+
+	t.Run("unchanged tokens keep the request throttling", func(t *testing.T) {
+		dynakube := newDynakube(t, oldTokens)
+
+		err := controller.handleTokenRotation(dynakube, oldTokens)
+		require.NoError(t, err)
+
+		assert.False(t, dynakube.Status.DynatraceApi.LastTokenScopeRequest.IsZero())
+		assert.False(t, dynakube.Status.OneAgent.ConnectionInfoStatus.LastRequest.IsZero())
+		assert.False(t, dynakube.Status.ActiveGate.ConnectionInfoStatus.LastRequest.IsZero())
+	})
+	t.Run("rotated tokens reset the request throttling", func(t *testing.T) {
+		dynakube := newDynakube(t, oldTokens)
+
+		err := controller.handleTokenRotation(dynakube, newTokens)
+		require.NoError(t, err)
+
+		expectedHash, _ := newTokens.Hash()
+		assert.Equal(t, expectedHash, dynakube.Status.DynatraceApi.TokenHash)
+		assert.True(t, dynakube.Status.DynatraceApi.LastTokenScopeRequest.IsZero())
+		assert.True(t, dynakube.Status.OneAgent.ConnectionInfoStatus.LastRequest.IsZero())
+		assert.True(t, dynakube.Status.ActiveGate.ConnectionInfoStatus.LastRequest.IsZero())
+	})
+	t.Run("first reconciliation only stores the hash", func(t *testing.T) {
+		dynakube := newDynakube(t, oldTokens)
+		dynakube.Status.DynatraceApi.TokenHash = ""
+
+		err := controller.handleTokenRotation(dynakube, newTokens)
+		require.NoError(t, err)
+
+		assert.NotEmpty(t, dynakube.Status.DynatraceApi.TokenHash)
+		assert.False(t, dynakube.Status.DynatraceApi.LastTokenScopeRequest.IsZero())
+	})
+}
@@ -5,8 +5,10 @@ import (
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/status"
 	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/versionpolicy"
 	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
 	"github.com/Dynatrace/dynatrace-operator/pkg/oci/registry"
+	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/pkg/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -16,6 +18,9 @@ type activeGateUpdater struct {
 	apiReader      client.Reader
 	dtClient       dtclient.Client
 	registryClient registry.ImageGetter
+
+	// pool is set if the updater manages the version status of an ActiveGate pool instead of the ActiveGate section
+	pool *dynatracev1beta1.ActiveGatePoolSpec
 }
 
 func newActiveGateUpdater(
@@ -32,19 +37,45 @@ func newActiveGateUpdater(
 	}
 }
 
+// newActiveGatePoolUpdater creates an updater for the version status of the given ActiveGate pool,
+// the status entry of the pool has to exist already
+func newActiveGatePoolUpdater(
+	dynakube *dynatracev1beta1.DynaKube,
+	pool dynatracev1beta1.ActiveGatePoolSpec,
+	apiReader client.Reader,
+	dtClient dtclient.Client,
+	registryClient registry.ImageGetter,
+) *activeGateUpdater {
+	updater := newActiveGateUpdater(dynakube, apiReader, dtClient, registryClient)
+	updater.pool = &pool
+	return updater
+}
+
 func (updater activeGateUpdater) Name() string {
+	if updater.pool != nil {
+		return dynatracev1beta1.ActiveGatePoolShortName(updater.pool.Name)
+	}
 	return "activegate"
 }
 
 func (updater activeGateUpdater) IsEnabled() bool {
+	if updater.pool != nil {
+		return true
+	}
 	return updater.dynakube.NeedsActiveGate()
 }
 
 func (updater *activeGateUpdater) Target() *status.VersionStatus {
+	if updater.pool != nil {
+		return updater.dynakube.ActiveGatePoolVersionStatus(updater.pool.Name)
+	}
 	return &updater.dynakube.Status.ActiveGate.VersionStatus
 }
 
 func (updater activeGateUpdater) CustomImage() string {
+	if updater.pool != nil {
+		return updater.pool.Image
+	}
 	return updater.dynakube.CustomActiveGateImage()
 }
 
@@ -60,8 +91,8 @@ func (updater activeGateUpdater) IsPublicRegistryEnabled() bool {
 	return updater.dynakube.FeaturePublicRegistry()
 }
 
-func (updater activeGateUpdater) LatestImageInfo() (*dtclient.LatestImageInfo, error) {
-	return updater.dtClient.GetLatestActiveGateImage()
+func (updater activeGateUpdater) LatestImageInfo(ctx context.Context) (*dtclient.LatestImageInfo, error) {
+	return updater.dtClient.GetLatestActiveGateImage(ctx)
 }
 
 func (updater *activeGateUpdater) CheckForDowngrade(latestVersion string) (bool, error) {
@@ -73,6 +104,26 @@ func (updater *activeGateUpdater) UseTenantRegistry(ctx context.Context) error {
 	return updateVersionStatusForTenantRegistry(ctx, updater.Target(), updater.registryClient, defaultImage)
 }
 
+func (updater activeGateUpdater) VersionPolicy() *versionpolicy.Spec {
+	return updater.dynakube.ActiveGateVersionPolicy()
+}
+
+func (updater activeGateUpdater) AvailableVersions(ctx context.Context) ([]string, error) {
+	ref, err := name.ParseReference(updater.dynakube.DefaultActiveGateImage())
+	if err != nil {
+		return nil, errors.WithMessage(err, "failed to parse image uri")
+	}
+	return updater.registryClient.ListTags(ctx, ref.Context().Name())
+}
+
+func (updater *activeGateUpdater) UseTenantRegistryVersion(ctx context.Context, version string) error {
+	image, err := imageWithTag(updater.dynakube.DefaultActiveGateImage(), version)
+	if err != nil {
+		return err
+	}
+	return updateVersionStatusForTenantRegistry(ctx, updater.Target(), updater.registryClient, image)
+}
+
 func (updater activeGateUpdater) ValidateStatus() error {
 	imageVersion := updater.Target().Version
 	if imageVersion == "" {
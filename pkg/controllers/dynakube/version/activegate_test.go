@@ -7,6 +7,7 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/status"
 	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/versionpolicy"
 	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
 	"github.com/Dynatrace/dynatrace-operator/pkg/oci/registry"
 	"github.com/Dynatrace/dynatrace-operator/pkg/oci/registry/mocks"
@@ -49,12 +50,46 @@ func TestActiveGateUpdater(t *testing.T) {
 		assert.Equal(t, dynakube.Spec.ActiveGate.Image, updater.CustomImage())
 		assert.Equal(t, "", updater.CustomVersion())
 		assert.False(t, updater.IsAutoUpdateEnabled())
-		imageInfo, err := updater.LatestImageInfo()
+		imageInfo, err := updater.LatestImageInfo(context.Background())
 		require.NoError(t, err)
 		assert.Equal(t, testImage, *imageInfo)
 	})
 }
 
+func TestActiveGatePoolUpdater(t *testing.T) {
+	t.Run("Getters work as expected", func(t *testing.T) {
+		pool := dynatracev1beta1.ActiveGatePoolSpec{
+			Name:         "zone-a",
+			Capabilities: []dynatracev1beta1.CapabilityDisplayName{dynatracev1beta1.RoutingCapability.DisplayName},
+			CapabilityProperties: dynatracev1beta1.CapabilityProperties{
+				Image: "some.registry.com/activegate:1.2.3",
+			},
+		}
+		dynakube := &dynatracev1beta1.DynaKube{
+			Spec: dynatracev1beta1.DynaKubeSpec{
+				ActiveGate: dynatracev1beta1.ActiveGateSpec{
+					CapabilityProperties: dynatracev1beta1.CapabilityProperties{
+						Image: "some.registry.com/activegate:4.5.6",
+					},
+					Pools: []dynatracev1beta1.ActiveGatePoolSpec{pool},
+				},
+			},
+			Status: dynatracev1beta1.DynaKubeStatus{
+				ActiveGate: dynatracev1beta1.ActiveGateStatus{
+					Pools: []dynatracev1beta1.ActiveGatePoolStatus{{Name: pool.Name}},
+				},
+			},
+		}
+
+		updater := newActiveGatePoolUpdater(dynakube, pool, fake.NewClient(), nil, nil)
+
+		assert.Equal(t, "activegate-zone-a", updater.Name())
+		assert.True(t, updater.IsEnabled())
+		assert.Equal(t, pool.Image, updater.CustomImage())
+		assert.Same(t, &dynakube.Status.ActiveGate.Pools[0].VersionStatus, updater.Target())
+	})
+}
+
 func TestActiveGateUseDefault(t *testing.T) {
 	t.Run("Set according to defaults, unset previous status", func(t *testing.T) {
 		dynakube := &dynatracev1beta1.DynaKube{
@@ -87,3 +122,32 @@ func TestActiveGateUseDefault(t *testing.T) {
 		assert.Equal(t, expectedVersion, dynakube.Status.ActiveGate.Version)
 	})
 }
+
+func TestActiveGateVersionPolicy(t *testing.T) {
+	t.Run("tags of the tenant registry are available, chosen version is used as tag", func(t *testing.T) {
+		dynakube := &dynatracev1beta1.DynaKube{
+			Spec: dynatracev1beta1.DynaKubeSpec{
+				APIURL: testApiUrl,
+				ActiveGate: dynatracev1beta1.ActiveGateSpec{
+					VersionPolicy: &versionpolicy.Spec{Constraint: "~1.281"},
+				},
+			},
+		}
+		repository := dynakube.ApiUrlHost() + "/linux/activegate"
+		chosenVersion := "1.281.0.20231201-100000"
+		mockImageGetter := mocks.MockImageGetter{}
+		mockImageGetter.On("ListTags", mock.Anything, repository).Return([]string{"latest", chosenVersion}, nil)
+		mockImageGetter.On("GetImageVersion", mock.Anything, repository+":"+chosenVersion).Return(registry.ImageVersion{Version: chosenVersion}, nil)
+
+		updater := newActiveGateUpdater(dynakube, fake.NewClient(), mockedclient.NewClient(t), &mockImageGetter)
+
+		assert.Equal(t, dynakube.Spec.ActiveGate.VersionPolicy, updater.VersionPolicy())
+		versions, err := updater.AvailableVersions(context.TODO())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"latest", chosenVersion}, versions)
+
+		err = updater.UseTenantRegistryVersion(context.TODO(), chosenVersion)
+		require.NoError(t, err)
+		assertStatusBasedOnTenantRegistry(t, repository+":"+chosenVersion, chosenVersion, dynakube.Status.ActiveGate.VersionStatus)
+	})
+}
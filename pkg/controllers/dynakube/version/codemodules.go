@@ -5,6 +5,8 @@ import (
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/status"
 	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/versionpolicy"
+	"github.com/Dynatrace/dynatrace-operator/pkg/arch"
 	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
 )
 
@@ -48,15 +50,15 @@ func (updater codeModulesUpdater) IsPublicRegistryEnabled() bool {
 	return updater.dynakube.FeaturePublicRegistry()
 }
 
-func (updater codeModulesUpdater) LatestImageInfo() (*dtclient.LatestImageInfo, error) {
-	return updater.dtClient.GetLatestCodeModulesImage()
+func (updater codeModulesUpdater) LatestImageInfo(ctx context.Context) (*dtclient.LatestImageInfo, error) {
+	return updater.dtClient.GetLatestCodeModulesImage(ctx)
 }
 
 func (updater *codeModulesUpdater) CheckForDowngrade(latestVersion string) (bool, error) {
 	return false, nil
 }
 
-func (updater *codeModulesUpdater) UseTenantRegistry(_ context.Context) error {
+func (updater *codeModulesUpdater) UseTenantRegistry(ctx context.Context) error {
 	customVersion := updater.CustomVersion()
 	if customVersion != "" {
 		updater.dynakube.Status.CodeModules = dynatracev1beta1.CodeModulesStatus{
@@ -67,7 +69,7 @@ func (updater *codeModulesUpdater) UseTenantRegistry(_ context.Context) error {
 		return nil
 	}
 
-	latestAgentVersionUnixPaas, err := updater.dtClient.GetLatestAgentVersion(
+	latestAgentVersionUnixPaas, err := updater.dtClient.GetLatestAgentVersion(ctx,
 		dtclient.OsUnix, dtclient.InstallerTypePaaS)
 	if err != nil {
 		log.Info("could not get agent paas unix version")
@@ -82,6 +84,23 @@ func (updater *codeModulesUpdater) UseTenantRegistry(_ context.Context) error {
 	return nil
 }
 
+func (updater codeModulesUpdater) VersionPolicy() *versionpolicy.Spec {
+	return updater.dynakube.CodeModulesVersionPolicy()
+}
+
+func (updater codeModulesUpdater) AvailableVersions(ctx context.Context) ([]string, error) {
+	return updater.dtClient.GetAgentVersions(ctx, dtclient.OsUnix, dtclient.InstallerTypePaaS, arch.Flavor, arch.Arch)
+}
+
+func (updater *codeModulesUpdater) UseTenantRegistryVersion(_ context.Context, version string) error {
+	updater.dynakube.Status.CodeModules = dynatracev1beta1.CodeModulesStatus{
+		VersionStatus: status.VersionStatus{
+			Version: version,
+		},
+	}
+	return nil
+}
+
 func (updater codeModulesUpdater) ValidateStatus() error {
 	return nil
 }
@@ -41,7 +41,7 @@ func TestCodeModulesUpdater(t *testing.T) {
 		assert.Equal(t, dynakube.Spec.OneAgent.ApplicationMonitoring.CodeModulesImage, updater.CustomImage())
 		assert.Equal(t, dynakube.Spec.OneAgent.ApplicationMonitoring.Version, updater.CustomVersion())
 		assert.True(t, updater.IsAutoUpdateEnabled())
-		imageInfo, err := updater.LatestImageInfo()
+		imageInfo, err := updater.LatestImageInfo(context.Background())
 		require.NoError(t, err)
 		assert.Equal(t, testImage, *imageInfo)
 	})
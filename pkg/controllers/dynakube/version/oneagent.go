@@ -5,6 +5,8 @@ import (
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/status"
 	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/versionpolicy"
+	"github.com/Dynatrace/dynatrace-operator/pkg/arch"
 	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
 	"github.com/Dynatrace/dynatrace-operator/pkg/oci/registry"
 	"github.com/pkg/errors"
@@ -60,15 +62,15 @@ func (updater oneAgentUpdater) IsPublicRegistryEnabled() bool {
 	return updater.dynakube.FeaturePublicRegistry() && !updater.dynakube.ClassicFullStackMode()
 }
 
-func (updater oneAgentUpdater) LatestImageInfo() (*dtclient.LatestImageInfo, error) {
-	return updater.dtClient.GetLatestOneAgentImage()
+func (updater oneAgentUpdater) LatestImageInfo(ctx context.Context) (*dtclient.LatestImageInfo, error) {
+	return updater.dtClient.GetLatestOneAgentImage(ctx)
 }
 
 func (updater oneAgentUpdater) UseTenantRegistry(ctx context.Context) error {
 	var err error
 	latestVersion := updater.CustomVersion()
 	if latestVersion == "" {
-		latestVersion, err = updater.dtClient.GetLatestAgentVersion(dtclient.OsUnix, dtclient.InstallerTypeDefault)
+		latestVersion, err = updater.dtClient.GetLatestAgentVersion(ctx, dtclient.OsUnix, dtclient.InstallerTypeDefault)
 		if err != nil {
 			return err
 		}
@@ -83,6 +85,19 @@ func (updater oneAgentUpdater) UseTenantRegistry(ctx context.Context) error {
 	return updateVersionStatusForTenantRegistry(ctx, updater.Target(), updater.registryClient, defaultImage)
 }
 
+func (updater oneAgentUpdater) VersionPolicy() *versionpolicy.Spec {
+	return updater.dynakube.OneAgentVersionPolicy()
+}
+
+func (updater oneAgentUpdater) AvailableVersions(ctx context.Context) ([]string, error) {
+	return updater.dtClient.GetAgentVersions(ctx, dtclient.OsUnix, dtclient.InstallerTypeDefault, arch.FlavorDefault, arch.Arch)
+}
+
+func (updater oneAgentUpdater) UseTenantRegistryVersion(ctx context.Context, version string) error {
+	image := updater.dynakube.DefaultOneAgentImageForVersion(version)
+	return updateVersionStatusForTenantRegistry(ctx, updater.Target(), updater.registryClient, image)
+}
+
 func (updater *oneAgentUpdater) CheckForDowngrade(latestVersion string) (bool, error) {
 	imageID := updater.Target().ImageID
 	if imageID == "" {
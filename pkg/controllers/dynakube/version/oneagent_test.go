@@ -45,7 +45,7 @@ func TestOneAgentUpdater(t *testing.T) {
 		assert.Equal(t, dynakube.Spec.OneAgent.ClassicFullStack.Image, updater.CustomImage())
 		assert.Equal(t, dynakube.Spec.OneAgent.ClassicFullStack.Version, updater.CustomVersion())
 		assert.False(t, updater.IsAutoUpdateEnabled())
-		imageInfo, err := updater.LatestImageInfo()
+		imageInfo, err := updater.LatestImageInfo(context.Background())
 		require.NoError(t, err)
 		assert.Equal(t, testImage, *imageInfo)
 	})
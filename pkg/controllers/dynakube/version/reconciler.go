@@ -2,17 +2,23 @@ package version
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/status"
 	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
 	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/dynatraceevents"
 	"github.com/Dynatrace/dynatrace-operator/pkg/oci/registry"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/timeprovider"
 	"github.com/spf13/afero"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// deferredReasonSuffix ends the reason of a version status whose update waits for the next maintenance window
+const deferredReasonSuffix = "deferred until the next maintenance window"
+
 type Reconciler struct {
 	dynakube       *dynatracev1beta1.DynaKube
 	dtClient       dtclient.Client
@@ -21,6 +27,9 @@ type Reconciler struct {
 
 	fs        afero.Afero
 	apiReader client.Reader
+
+	// blockedDowngrades are reported in the Version condition, they are collected while the version statuses are updated
+	blockedDowngrades []string
 }
 
 func NewReconciler(dynakube *dynatracev1beta1.DynaKube, apiReader client.Reader, dtClient dtclient.Client, registryClient registry.ImageGetter, fs afero.Afero, timeProvider *timeprovider.Provider) *Reconciler { //nolint:revive
@@ -43,20 +52,60 @@ func (reconciler *Reconciler) Reconcile(ctx context.Context) error {
 		newSyntheticUpdater(reconciler.dynakube, reconciler.apiReader, reconciler.dtClient, reconciler.registryClient),
 	}
 
+	reconciler.syncActiveGatePoolStatuses()
+	for _, pool := range reconciler.dynakube.Spec.ActiveGate.Pools {
+		updaters = append(updaters, newActiveGatePoolUpdater(reconciler.dynakube, pool, reconciler.apiReader, reconciler.dtClient, reconciler.registryClient))
+	}
+
+	reconciler.blockedDowngrades = nil
 	neededUpdaters := reconciler.needsReconcile(updaters)
 	if len(neededUpdaters) > 0 {
-		return reconciler.updateVersionStatuses(ctx, neededUpdaters)
+		err := reconciler.updateVersionStatuses(ctx, neededUpdaters)
+		if err != nil {
+			reconciler.dynakube.SetConditionError(dynatracev1beta1.VersionConditionType, err)
+			return err
+		}
 	}
+	reconciler.setConditionVersion()
 	return nil
 }
 
+// setConditionVersion marks the version statuses as reconciled, blocked downgrades are listed in the message without making the DynaKube unready
+func (reconciler *Reconciler) setConditionVersion() {
+	if len(reconciler.blockedDowngrades) > 0 {
+		reconciler.dynakube.SetCondition(dynatracev1beta1.VersionConditionType, metav1.ConditionTrue,
+			dynatracev1beta1.ReasonDowngradeBlocked, strings.Join(reconciler.blockedDowngrades, "; "))
+		return
+	}
+	reconciler.dynakube.SetConditionReconciled(dynatracev1beta1.VersionConditionType)
+}
+
+// syncActiveGatePoolStatuses keeps a version status for every ActiveGate pool and drops the ones of removed pools
+func (reconciler *Reconciler) syncActiveGatePoolStatuses() {
+	poolStatuses := []dynatracev1beta1.ActiveGatePoolStatus{}
+	for _, pool := range reconciler.dynakube.Spec.ActiveGate.Pools {
+		poolStatus := dynatracev1beta1.ActiveGatePoolStatus{Name: pool.Name}
+		if versionStatus := reconciler.dynakube.ActiveGatePoolVersionStatus(pool.Name); versionStatus != nil {
+			poolStatus.VersionStatus = *versionStatus
+		}
+		poolStatuses = append(poolStatuses, poolStatus)
+	}
+
+	if len(poolStatuses) == 0 {
+		poolStatuses = nil
+	}
+	reconciler.dynakube.Status.ActiveGate.Pools = poolStatuses
+}
+
 func (reconciler *Reconciler) updateVersionStatuses(ctx context.Context, updaters []StatusUpdater) error {
 	for _, updater := range updaters {
 		log.Info("updating version status", "updater", updater.Name())
-		err := reconciler.run(ctx, updater)
+		previousVersion := updater.Target().Version
+		err := reconciler.runOrDefer(ctx, updater)
 		if err != nil {
 			return err
 		}
+		reconciler.sendVersionChangeEvent(ctx, updater, previousVersion)
 
 		_, ok := updater.(*oneAgentUpdater)
 		if ok {
@@ -71,11 +120,72 @@ func (reconciler *Reconciler) updateVersionStatuses(ctx context.Context, updater
 	return nil
 }
 
+// sendVersionChangeEvent sends an event to Dynatrace if the OneAgent or ActiveGate version of an already deployed component changed
+func (reconciler *Reconciler) sendVersionChangeEvent(ctx context.Context, updater StatusUpdater, previousVersion string) {
+	newVersion := updater.Target().Version
+	if previousVersion == "" || newVersion == previousVersion {
+		return
+	}
+
+	sender := dynatraceevents.NewSender(reconciler.dtClient, reconciler.dynakube)
+	switch updater.(type) {
+	case *oneAgentUpdater:
+		sender.SendOneAgentVersionChange(ctx, previousVersion, newVersion)
+	case *activeGateUpdater:
+		sender.SendActiveGateVersionChange(ctx, previousVersion, newVersion)
+	}
+}
+
+// runOrDefer runs the updater, but outside of the maintenance windows a changed version of an already deployed component is reverted
+// and the UpdatePending condition is set instead. The deferred update is recorded in the reason of the version status,
+// so it is applied once a maintenance window opens, without probing for it again in between.
+func (reconciler *Reconciler) runOrDefer(ctx context.Context, updater StatusUpdater) error {
+	previous := *updater.Target()
+	if isDeferred(updater.Target()) {
+		updater.Target().Reason = ""
+	}
+
+	isDeployed := previous.ImageID != "" || previous.Version != ""
+	if !reconciler.dynakube.IsUpdateDeferred() || !isDeployed {
+		return reconciler.run(ctx, updater)
+	}
+
+	err := reconciler.run(ctx, updater)
+	if err != nil {
+		return err
+	}
+
+	target := updater.Target()
+	if target.ImageID != previous.ImageID || target.Version != previous.Version {
+		log.Info("version update deferred until the next maintenance window", "updater", updater.Name(),
+			"currentVersion", previous.Version, "newVersion", target.Version, "until", reconciler.dynakube.Status.UpdatesDeferredUntil)
+
+		next := target.Version
+		if next == "" || next == previous.Version {
+			next = target.ImageID
+		}
+
+		// the new LastProbeTimestamp is kept, so the deferred update isn't probed again on every reconciliation
+		target.Version = previous.Version
+		target.ImageID = previous.ImageID
+		target.Source = previous.Source
+		target.Reason = fmt.Sprintf("update to %s %s", next, deferredReasonSuffix)
+		reconciler.dynakube.SetConditionUpdatePending()
+	}
+	return nil
+}
+
+func isDeferred(target *status.VersionStatus) bool {
+	return strings.HasSuffix(target.Reason, deferredReasonSuffix)
+}
+
 func (reconciler *Reconciler) needsReconcile(updaters []StatusUpdater) []StatusUpdater {
 	neededUpdaters := []StatusUpdater{}
 	for _, updater := range updaters {
 		if reconciler.needsUpdate(updater) {
 			neededUpdaters = append(neededUpdaters, updater)
+		} else if updater.IsEnabled() && isDeferred(updater.Target()) {
+			reconciler.dynakube.SetConditionUpdatePending()
 		}
 	}
 	return neededUpdaters
@@ -92,6 +202,11 @@ func (reconciler *Reconciler) needsUpdate(updater StatusUpdater) bool {
 		return true
 	}
 
+	if isDeferred(updater.Target()) && !reconciler.dynakube.IsUpdateDeferred() {
+		log.Info("maintenance window is open, deferred update of version status is applied", "updater", updater.Name())
+		return true
+	}
+
 	if hasCustomFieldChanged(updater) {
 		return true
 	}
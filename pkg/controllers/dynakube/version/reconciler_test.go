@@ -9,6 +9,7 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/status"
 	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/versionpolicy"
 	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/dtpullsecret"
 	"github.com/Dynatrace/dynatrace-operator/pkg/oci/registry"
@@ -23,6 +24,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -178,6 +180,194 @@ func TestReconcile(t *testing.T) {
 	})
 }
 
+func TestSetConditionVersion(t *testing.T) {
+	t.Run("reconciled", func(t *testing.T) {
+		dynakube := &dynatracev1beta1.DynaKube{}
+		reconciler := Reconciler{dynakube: dynakube}
+
+		reconciler.setConditionVersion()
+
+		condition := meta.FindStatusCondition(dynakube.Status.Conditions, dynatracev1beta1.VersionConditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, dynatracev1beta1.ReasonReconciled, condition.Reason)
+	})
+	t.Run("blocked downgrades are reported", func(t *testing.T) {
+		dynakube := &dynatracev1beta1.DynaKube{}
+		reconciler := Reconciler{dynakube: dynakube, blockedDowngrades: []string{"oneagent: downgrade to 1.280 blocked, keeping version 1.281"}}
+
+		reconciler.setConditionVersion()
+
+		condition := meta.FindStatusCondition(dynakube.Status.Conditions, dynatracev1beta1.VersionConditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionTrue, condition.Status)
+		assert.Equal(t, dynatracev1beta1.ReasonDowngradeBlocked, condition.Reason)
+		assert.Equal(t, "oneagent: downgrade to 1.280 blocked, keeping version 1.281", condition.Message)
+	})
+}
+
+func TestRunOrDefer(t *testing.T) {
+	ctx := context.TODO()
+	timeProvider := timeprovider.New().Freeze()
+	availableVersions := []string{"1.280.3.20231115-100000", "1.281.1.20231201-100000"}
+	deployedStatus := status.VersionStatus{ImageID: "some.registry.com/oneagent:1.280.3.20231115-100000", Version: "1.280.3.20231115-100000"}
+
+	newUpdater := func(target *status.VersionStatus) StatusUpdater {
+		updater := newVersionPolicyUpdater(target, &versionpolicy.Spec{}, false)
+		updater.On("IsEnabled").Return(true)
+		updater.On("AvailableVersions", mock.Anything).Return(availableVersions, nil)
+		updater.On("UseTenantRegistryVersion", mock.Anything, "1.281.1.20231201-100000").Run(func(args mock.Arguments) {
+			target.ImageID = "some.registry.com/oneagent:1.281.1.20231201-100000"
+			target.Version = "1.281.1.20231201-100000"
+		}).Return(nil)
+		return updater
+	}
+	deferredDynakube := func() *dynatracev1beta1.DynaKube {
+		return &dynatracev1beta1.DynaKube{
+			Status: dynatracev1beta1.DynaKubeStatus{
+				UpdatesDeferredUntil: &metav1.Time{Time: timeProvider.Now().Add(time.Hour)},
+			},
+		}
+	}
+
+	t.Run("version of deployed component is kept outside of maintenance window", func(t *testing.T) {
+		dynakube := deferredDynakube()
+		target := deployedStatus
+		reconciler := Reconciler{dynakube: dynakube, timeProvider: timeProvider}
+
+		err := reconciler.runOrDefer(ctx, newUpdater(&target))
+		require.NoError(t, err)
+		assert.Equal(t, deployedStatus.Version, target.Version)
+		assert.Equal(t, deployedStatus.ImageID, target.ImageID)
+		assert.Equal(t, deployedStatus.Source, target.Source)
+		assert.Equal(t, timeProvider.Now(), target.LastProbeTimestamp)
+		assert.Equal(t, "update to 1.281.1.20231201-100000 "+deferredReasonSuffix, target.Reason)
+
+		condition := meta.FindStatusCondition(dynakube.Status.Conditions, dynatracev1beta1.UpdatePendingConditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionTrue, condition.Status)
+		assert.Equal(t, dynatracev1beta1.ReasonOutsideMaintenanceWindow, condition.Reason)
+		assert.Contains(t, condition.Message, dynakube.Status.UpdatesDeferredUntil.UTC().Format(time.RFC3339))
+	})
+	t.Run("deferred update isn't probed again until a maintenance window opens", func(t *testing.T) {
+		dynakube := deferredDynakube()
+		target := deployedStatus
+		target.Source = status.TenantRegistryVersionSource
+		target.LastProbeTimestamp = timeProvider.Now()
+		target.Reason = "update to 1.281.1.20231201-100000 " + deferredReasonSuffix
+		updater := newUpdater(&target)
+		reconciler := Reconciler{dynakube: dynakube, timeProvider: timeProvider}
+
+		assert.Empty(t, reconciler.needsReconcile([]StatusUpdater{updater}))
+
+		condition := meta.FindStatusCondition(dynakube.Status.Conditions, dynatracev1beta1.UpdatePendingConditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, metav1.ConditionTrue, condition.Status)
+
+		dynakube.Status.UpdatesDeferredUntil = nil
+
+		assert.Len(t, reconciler.needsReconcile([]StatusUpdater{updater}), 1)
+
+		err := reconciler.runOrDefer(ctx, updater)
+		require.NoError(t, err)
+		assert.Equal(t, "1.281.1.20231201-100000", target.Version)
+		assert.False(t, isDeferred(&target))
+	})
+	t.Run("initial version is set outside of maintenance window", func(t *testing.T) {
+		dynakube := deferredDynakube()
+		target := status.VersionStatus{}
+		reconciler := Reconciler{dynakube: dynakube, timeProvider: timeProvider}
+
+		err := reconciler.runOrDefer(ctx, newUpdater(&target))
+		require.NoError(t, err)
+		assert.Equal(t, "1.281.1.20231201-100000", target.Version)
+		assert.Nil(t, meta.FindStatusCondition(dynakube.Status.Conditions, dynatracev1beta1.UpdatePendingConditionType))
+	})
+	t.Run("version is updated inside of maintenance window", func(t *testing.T) {
+		dynakube := &dynatracev1beta1.DynaKube{}
+		target := deployedStatus
+		reconciler := Reconciler{dynakube: dynakube, timeProvider: timeProvider}
+
+		err := reconciler.runOrDefer(ctx, newUpdater(&target))
+		require.NoError(t, err)
+		assert.Equal(t, "1.281.1.20231201-100000", target.Version)
+		assert.Equal(t, timeProvider.Now(), target.LastProbeTimestamp)
+		assert.Nil(t, meta.FindStatusCondition(dynakube.Status.Conditions, dynatracev1beta1.UpdatePendingConditionType))
+	})
+}
+
+func TestSendVersionChangeEvent(t *testing.T) {
+	ctx := context.Background()
+	dynakube := &dynatracev1beta1.DynaKube{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: testNamespace,
+			Annotations: map[string]string{
+				dynatracev1beta1.AnnotationFeatureOneAgentVersionEvents: "true",
+			},
+		},
+	}
+	dynakube.Status.OneAgent.Version = "1.2.3"
+
+	t.Run("event is sent for changed version", func(t *testing.T) {
+		mockClient := mockedclient.NewClient(t)
+		mockClient.On("IngestEvent", mock.Anything, mock.MatchedBy(func(event *dtclient.IngestEventData) bool {
+			return event.Properties["previousVersion"] == "1.2.2" && event.Properties["version"] == "1.2.3"
+		})).Return(nil).Once()
+		reconciler := Reconciler{dynakube: dynakube, dtClient: mockClient}
+
+		reconciler.sendVersionChangeEvent(ctx, newOneAgentUpdater(dynakube, nil, mockClient, nil), "1.2.2")
+	})
+	t.Run("no event for initial or unchanged version, or disabled event type", func(t *testing.T) {
+		mockClient := mockedclient.NewClient(t)
+		reconciler := Reconciler{dynakube: dynakube, dtClient: mockClient}
+
+		reconciler.sendVersionChangeEvent(ctx, newOneAgentUpdater(dynakube, nil, mockClient, nil), "")
+		reconciler.sendVersionChangeEvent(ctx, newOneAgentUpdater(dynakube, nil, mockClient, nil), "1.2.3")
+		reconciler.sendVersionChangeEvent(ctx, newActiveGateUpdater(dynakube, nil, mockClient, nil), "1.2.2")
+	})
+}
+
+func TestSyncActiveGatePoolStatuses(t *testing.T) {
+	t.Run("status of new pools is added, status of removed pools is dropped", func(t *testing.T) {
+		dynakube := &dynatracev1beta1.DynaKube{
+			Spec: dynatracev1beta1.DynaKubeSpec{
+				ActiveGate: dynatracev1beta1.ActiveGateSpec{
+					Pools: []dynatracev1beta1.ActiveGatePoolSpec{{Name: "zone-a"}, {Name: "zone-b"}},
+				},
+			},
+			Status: dynatracev1beta1.DynaKubeStatus{
+				ActiveGate: dynatracev1beta1.ActiveGateStatus{
+					Pools: []dynatracev1beta1.ActiveGatePoolStatus{
+						{Name: "zone-a", VersionStatus: status.VersionStatus{Version: "1.2.3"}},
+						{Name: "removed", VersionStatus: status.VersionStatus{Version: "1.2.3"}},
+					},
+				},
+			},
+		}
+		reconciler := Reconciler{dynakube: dynakube}
+
+		reconciler.syncActiveGatePoolStatuses()
+
+		assert.Equal(t, []dynatracev1beta1.ActiveGatePoolStatus{
+			{Name: "zone-a", VersionStatus: status.VersionStatus{Version: "1.2.3"}},
+			{Name: "zone-b"},
+		}, dynakube.Status.ActiveGate.Pools)
+	})
+	t.Run("no pools, no status", func(t *testing.T) {
+		dynakube := &dynatracev1beta1.DynaKube{
+			Status: dynatracev1beta1.DynaKubeStatus{
+				ActiveGate: dynatracev1beta1.ActiveGateStatus{
+					Pools: []dynatracev1beta1.ActiveGatePoolStatus{{Name: "removed"}},
+				},
+			},
+		}
+		reconciler := Reconciler{dynakube: dynakube}
+
+		reconciler.syncActiveGatePoolStatuses()
+
+		assert.Nil(t, dynakube.Status.ActiveGate.Pools)
+	})
+}
+
 func TestNeedsReconcile(t *testing.T) {
 	timeProvider := timeprovider.New().Freeze()
 
@@ -388,17 +578,17 @@ func createTestPullSecret(fakeClient client.Client, dynakube dynatracev1beta1.Dy
 }
 
 func mockActiveGateImageInfo(mockClient *mockedclient.Client, imageInfo dtclient.LatestImageInfo) {
-	mockClient.On("GetLatestActiveGateImage").Return(&imageInfo, nil)
+	mockClient.On("GetLatestActiveGateImage", mock.Anything).Return(&imageInfo, nil)
 }
 
 func mockCodeModulesImageInfo(mockClient *mockedclient.Client, imageInfo dtclient.LatestImageInfo) {
-	mockClient.On("GetLatestCodeModulesImage").Return(&imageInfo, nil)
+	mockClient.On("GetLatestCodeModulesImage", mock.Anything).Return(&imageInfo, nil)
 }
 
 func mockOneAgentImageInfo(mockClient *mockedclient.Client, imageInfo dtclient.LatestImageInfo) {
-	mockClient.On("GetLatestOneAgentImage").Return(&imageInfo, nil)
+	mockClient.On("GetLatestOneAgentImage", mock.Anything).Return(&imageInfo, nil)
 }
 
 func mockLatestAgentVersion(mockClient *mockedclient.Client, latestVersion string) {
-	mockClient.On("GetLatestAgentVersion", mock.Anything, mock.Anything).Return(latestVersion, nil)
+	mockClient.On("GetLatestAgentVersion", mock.Anything, mock.Anything, mock.Anything).Return(latestVersion, nil)
 }
@@ -5,6 +5,7 @@ import (
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/status"
 	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/versionpolicy"
 	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
 	"github.com/Dynatrace/dynatrace-operator/pkg/oci/registry"
 	"github.com/pkg/errors"
@@ -60,7 +61,7 @@ func (updater syntheticUpdater) IsPublicRegistryEnabled() bool {
 	return false
 }
 
-func (updater syntheticUpdater) LatestImageInfo() (*dtclient.LatestImageInfo, error) {
+func (updater syntheticUpdater) LatestImageInfo(_ context.Context) (*dtclient.LatestImageInfo, error) {
 	return nil, errors.New("unsupported method")
 }
 
@@ -73,6 +74,18 @@ func (updater *syntheticUpdater) UseTenantRegistry(ctx context.Context) error {
 	return updateVersionStatusForTenantRegistry(ctx, updater.Target(), updater.registryClient, defaultImage)
 }
 
+func (updater syntheticUpdater) VersionPolicy() *versionpolicy.Spec {
+	return nil
+}
+
+func (updater syntheticUpdater) AvailableVersions(_ context.Context) ([]string, error) {
+	return nil, errors.New("unsupported method")
+}
+
+func (updater *syntheticUpdater) UseTenantRegistryVersion(_ context.Context, _ string) error {
+	return errors.New("unsupported method")
+}
+
 func (updater syntheticUpdater) ValidateStatus() error {
 	return nil
 }
@@ -2,9 +2,11 @@ package version
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/status"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/versionpolicy"
 	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
 	"github.com/Dynatrace/dynatrace-operator/pkg/oci/registry"
 	"github.com/Dynatrace/dynatrace-operator/pkg/version"
@@ -23,9 +25,13 @@ type StatusUpdater interface {
 	IsPublicRegistryEnabled() bool
 	CheckForDowngrade(latestVersion string) (bool, error)
 	ValidateStatus() error
-	LatestImageInfo() (*dtclient.LatestImageInfo, error)
+	LatestImageInfo(ctx context.Context) (*dtclient.LatestImageInfo, error)
 
 	UseTenantRegistry(context.Context) error
+
+	VersionPolicy() *versionpolicy.Spec
+	AvailableVersions(context.Context) ([]string, error)
+	UseTenantRegistryVersion(ctx context.Context, version string) error
 }
 
 func (reconciler *Reconciler) run(ctx context.Context, updater StatusUpdater) error {
@@ -41,6 +47,7 @@ func (reconciler *Reconciler) run(ctx context.Context, updater StatusUpdater) er
 	customImage := updater.CustomImage()
 	if customImage != "" {
 		log.Info("updating version status according to custom image", "updater", updater.Name())
+		updater.Target().Reason = ""
 		err = setImageIDWithDigest(ctx, updater.Target(), reconciler.registryClient, customImage)
 		if err != nil {
 			return err
@@ -59,6 +66,15 @@ func (reconciler *Reconciler) run(ctx context.Context, updater StatusUpdater) er
 		}
 	}
 
+	if versionPolicy := updater.VersionPolicy(); versionPolicy != nil {
+		err = reconciler.processVersionPolicy(ctx, updater, versionPolicy)
+		if err != nil {
+			return err
+		}
+		return updater.ValidateStatus()
+	}
+	updater.Target().Reason = ""
+
 	if updater.IsPublicRegistryEnabled() {
 		err = reconciler.processPublicRegistry(ctx, updater)
 		if err != nil {
@@ -79,15 +95,19 @@ func (reconciler *Reconciler) run(ctx context.Context, updater StatusUpdater) er
 func (reconciler *Reconciler) processPublicRegistry(ctx context.Context, updater StatusUpdater) error {
 	log.Info("updating version status according to public registry", "updater", updater.Name())
 	var publicImage *dtclient.LatestImageInfo
-	publicImage, err := updater.LatestImageInfo()
+	publicImage, err := updater.LatestImageInfo(ctx)
 	if err != nil {
 		log.Info("could not get public image", "updater", updater.Name())
 		return err
 	}
 	isDowngrade, err := updater.CheckForDowngrade(publicImage.Tag)
-	if err != nil || isDowngrade {
+	if err != nil {
 		return err
 	}
+	if isDowngrade {
+		reconciler.blockDowngrade(updater, publicImage.Tag)
+		return nil
+	}
 
 	err = setImageIDWithDigest(ctx, updater.Target(), reconciler.registryClient, publicImage.String())
 	if err != nil {
@@ -97,6 +117,66 @@ func (reconciler *Reconciler) processPublicRegistry(ctx context.Context, updater
 	return nil
 }
 
+func (reconciler *Reconciler) processVersionPolicy(ctx context.Context, updater StatusUpdater, versionPolicy *versionpolicy.Spec) error {
+	log.Info("updating version status according to version policy", "updater", updater.Name(),
+		"constraint", versionPolicy.Constraint, "minorVersionsBehindLatest", versionPolicy.GetMinorVersionsBehindLatest())
+
+	constraint, err := version.ParseConstraint(versionPolicy.Constraint)
+	if err != nil {
+		return err
+	}
+
+	var publicImage *dtclient.LatestImageInfo
+	var availableVersions []string
+	if updater.IsPublicRegistryEnabled() {
+		publicImage, err = updater.LatestImageInfo(ctx)
+		if err != nil {
+			log.Info("could not get public image", "updater", updater.Name())
+			return err
+		}
+		availableVersions, err = reconciler.registryClient.ListTags(ctx, publicImage.Source)
+	} else {
+		availableVersions, err = updater.AvailableVersions(ctx)
+	}
+	if err != nil {
+		log.Info("could not list available versions", "updater", updater.Name())
+		return err
+	}
+
+	chosenVersion, reason, err := version.SelectVersion(availableVersions, constraint, versionPolicy.GetMinorVersionsBehindLatest())
+	if err != nil {
+		return err
+	}
+	log.Info("version chosen according to version policy", "updater", updater.Name(), "version", chosenVersion, "reason", reason)
+
+	// the version policy is an explicit choice of the user, so the chosen version is used even if it is a downgrade
+	if previousVersion := updater.Target().Version; previousVersion != "" {
+		if downgrade, err := version.IsDowngrade(previousVersion, chosenVersion); err == nil && downgrade {
+			log.Info("downgrade selected by version policy", "updater", updater.Name(), "from", previousVersion, "to", chosenVersion)
+		}
+	}
+
+	if publicImage != nil {
+		publicImage.Tag = chosenVersion
+		err = setImageIDWithDigest(ctx, updater.Target(), reconciler.registryClient, publicImage.String())
+	} else {
+		err = updater.UseTenantRegistryVersion(ctx, chosenVersion)
+	}
+	if err != nil {
+		return err
+	}
+
+	updater.Target().Reason = reason
+	return nil
+}
+
+// blockDowngrade keeps the current version and records the blocked downgrade, so it is visible in the version status and the Version condition
+func (reconciler *Reconciler) blockDowngrade(updater StatusUpdater, blockedVersion string) {
+	reason := fmt.Sprintf("downgrade to %s blocked, keeping version %s", blockedVersion, updater.Target().Version)
+	updater.Target().Reason = reason
+	reconciler.blockedDowngrades = append(reconciler.blockedDowngrades, fmt.Sprintf("%s: %s", updater.Name(), reason))
+}
+
 func determineSource(updater StatusUpdater) status.VersionSource {
 	if updater.CustomImage() != "" {
 		return status.CustomImageVersionSource
@@ -181,6 +261,15 @@ func updateVersionStatusForTenantRegistry(
 	return nil
 }
 
+// imageWithTag replaces the tag of the given image, e.g. `registry.com/linux/activegate:latest` => `registry.com/linux/activegate:1.281.0`
+func imageWithTag(imageUri, tag string) (string, error) {
+	ref, err := name.ParseReference(imageUri)
+	if err != nil {
+		return "", errors.WithMessage(err, "failed to parse image uri")
+	}
+	return ref.Context().Tag(tag).String(), nil
+}
+
 func getTagFromImageID(imageID string) (string, error) {
 	ref, err := name.ParseReference(imageID, name.WithDefaultTag(""))
 	if err != nil {
@@ -8,9 +8,11 @@ import (
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/status"
 	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/versionpolicy"
 	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
 	"github.com/Dynatrace/dynatrace-operator/pkg/oci/registry"
 	registryMock "github.com/Dynatrace/dynatrace-operator/pkg/oci/registry/mocks"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/address"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/timeprovider"
 	mocks "github.com/Dynatrace/dynatrace-operator/test/mocks/pkg/controllers/dynakube/version"
 	"github.com/google/go-containerregistry/pkg/name"
@@ -152,6 +154,8 @@ func TestRun(t *testing.T) {
 		assert.Equal(t, status.PublicRegistryVersionSource, target.Source)
 		assert.Empty(t, target.Version)
 		assert.Empty(t, target.ImageID)
+		assert.Contains(t, target.Reason, "downgrade to "+testImage.Tag+" blocked")
+		assert.Len(t, versionReconciler.blockedDowngrades, 1)
 	})
 	t.Run("classicfullstack enabled, public registry is ignored", func(t *testing.T) {
 		mockImageGetter := registryMock.MockImageGetter{}
@@ -202,6 +206,91 @@ func TestRun(t *testing.T) {
 	})
 }
 
+func TestRunVersionPolicy(t *testing.T) {
+	ctx := context.TODO()
+	timeProvider := timeprovider.New().Freeze()
+	availableVersions := []string{"1.280.3.20231115-100000", "1.281.1.20231201-100000", "1.282.0.20231215-100000", "latest"}
+
+	t.Run("chooses version from the tenant", func(t *testing.T) {
+		target := &status.VersionStatus{}
+		versionReconciler := Reconciler{
+			dynakube:     &dynatracev1beta1.DynaKube{},
+			timeProvider: timeProvider,
+		}
+		updater := newVersionPolicyUpdater(target, &versionpolicy.Spec{Constraint: "<1.282"}, false)
+		updater.On("AvailableVersions", mock.Anything).Return(availableVersions, nil)
+		updater.On("UseTenantRegistryVersion", mock.Anything, "1.281.1.20231201-100000").Return(nil)
+
+		err := versionReconciler.run(ctx, updater)
+		require.NoError(t, err)
+		updater.AssertCalled(t, "UseTenantRegistryVersion", mock.Anything, "1.281.1.20231201-100000")
+		assert.Equal(t, status.TenantRegistryVersionSource, target.Source)
+		assert.Equal(t, `latest version matching constraint "<1.282"`, target.Reason)
+	})
+	t.Run("chooses tag from the public registry", func(t *testing.T) {
+		target := &status.VersionStatus{}
+		mockImageGetter := registryMock.MockImageGetter{}
+		mockImageGetter.On("ListTags", mock.Anything, "some.registry.com/oneagent").Return(availableVersions, nil)
+		mockImageGetter.On("GetImageVersion", mock.Anything, "some.registry.com/oneagent:1.281.1.20231201-100000").
+			Return(registry.ImageVersion{Version: "1.281.1.20231201-100000", Digest: getTestDigest()}, nil)
+		versionReconciler := Reconciler{
+			dynakube:       &dynatracev1beta1.DynaKube{},
+			timeProvider:   timeProvider,
+			registryClient: &mockImageGetter,
+		}
+		updater := newVersionPolicyUpdater(target, &versionpolicy.Spec{MinorVersionsBehindLatest: address.Of(1)}, true)
+		updater.On("LatestImageInfo", mock.Anything).Return(&dtclient.LatestImageInfo{Source: "some.registry.com/oneagent", Tag: "latest"}, nil)
+
+		err := versionReconciler.run(ctx, updater)
+		require.NoError(t, err)
+		assert.Equal(t, status.PublicRegistryVersionSource, target.Source)
+		assert.Equal(t, "some.registry.com/oneagent:1.281.1.20231201-100000@"+getTestDigest().String(), target.ImageID)
+		assert.Equal(t, "1.281.1.20231201-100000", target.Version)
+		assert.Equal(t, "1 minor version behind latest version 1.282.0.20231215-100000", target.Reason)
+	})
+	t.Run("downgrade selected by the version policy is used", func(t *testing.T) {
+		target := &status.VersionStatus{Version: "1.282.0.20231215-100000"}
+		versionReconciler := Reconciler{
+			dynakube:     &dynatracev1beta1.DynaKube{},
+			timeProvider: timeProvider,
+		}
+		updater := newVersionPolicyUpdater(target, &versionpolicy.Spec{Constraint: "<1.282"}, false)
+		updater.On("AvailableVersions", mock.Anything).Return(availableVersions, nil)
+		updater.On("UseTenantRegistryVersion", mock.Anything, "1.281.1.20231201-100000").Return(nil)
+
+		err := versionReconciler.run(ctx, updater)
+		require.NoError(t, err)
+		updater.AssertCalled(t, "UseTenantRegistryVersion", mock.Anything, "1.281.1.20231201-100000")
+		assert.Empty(t, versionReconciler.blockedDowngrades)
+	})
+	t.Run("no version matches the constraint", func(t *testing.T) {
+		target := &status.VersionStatus{}
+		versionReconciler := Reconciler{
+			dynakube:     &dynatracev1beta1.DynaKube{},
+			timeProvider: timeProvider,
+		}
+		updater := newVersionPolicyUpdater(target, &versionpolicy.Spec{Constraint: ">=2"}, false)
+		updater.On("AvailableVersions", mock.Anything).Return(availableVersions, nil)
+
+		err := versionReconciler.run(ctx, updater)
+		require.Error(t, err)
+		updater.AssertNotCalled(t, "UseTenantRegistryVersion", mock.Anything, mock.Anything)
+		assert.Empty(t, target.Source)
+	})
+	t.Run("reason is removed without version policy", func(t *testing.T) {
+		target := &status.VersionStatus{Reason: "latest version"}
+		versionReconciler := Reconciler{
+			dynakube:     &dynatracev1beta1.DynaKube{},
+			timeProvider: timeProvider,
+		}
+		updater := newDefaultUpdater(target, true)
+
+		err := versionReconciler.run(ctx, updater)
+		require.NoError(t, err)
+		assert.Empty(t, target.Reason)
+	})
+}
+
 func TestDetermineSource(t *testing.T) {
 	customImage := "my.special.image"
 	customVersion := "3.2.1.4-5"
@@ -462,10 +551,24 @@ func newPublicRegistryUpdater(target *status.VersionStatus, imageInfo *dtclient.
 	updater := newBaseUpdater(target, autoUpdate)
 	updater.On("CustomImage").Return("")
 	updater.On("IsPublicRegistryEnabled").Return(true)
-	updater.On("LatestImageInfo").Return(imageInfo, nil)
+	updater.On("LatestImageInfo", mock.Anything).Return(imageInfo, nil)
 	return updater
 }
 
+func newVersionPolicyUpdater(target *status.VersionStatus, versionPolicy *versionpolicy.Spec, publicRegistry bool) *mocks.StatusUpdater {
+	updater := mocks.StatusUpdater{}
+	updater.On("Name").Return("mock")
+	updater.On("Target").Return(target)
+	updater.On("IsAutoUpdateEnabled").Return(true)
+	updater.On("ValidateStatus").Return(nil)
+	updater.On("VersionPolicy").Return(versionPolicy)
+	updater.On("CustomImage").Return("")
+	updater.On("CustomVersion").Return("")
+	updater.On("IsPublicRegistryEnabled").Return(publicRegistry)
+	updater.On("CheckForDowngrade", mock.Anything).Return(false, nil)
+	return &updater
+}
+
 func newClassicFullStackUpdater(target *status.VersionStatus, autoUpdate bool) *mocks.StatusUpdater {
 	updater := newBaseUpdater(target, autoUpdate)
 	updater.On("IsPublicRegistryEnabled").Return(false)
@@ -479,6 +582,7 @@ func newBaseUpdater(target *status.VersionStatus, autoUpdate bool) *mocks.Status
 	updater.On("IsEnabled").Return(true)
 	updater.On("IsAutoUpdateEnabled").Return(autoUpdate)
 	updater.On("ValidateStatus").Return(nil)
+	updater.On("VersionPolicy").Return(nil)
 	return &updater
 }
 
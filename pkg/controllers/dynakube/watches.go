@@ -0,0 +1,47 @@
+package dynakube
+
+import (
+	"context"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/tokensource"
+	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// mapSecretToDynakubes enqueues every DynaKube that references the secret as token secret or custom pull secret
+func (controller *Controller) mapSecretToDynakubes(ctx context.Context, secret client.Object) []reconcile.Request {
+	return controller.mapToDynakubes(ctx, secret, func(dynakube *dynatracev1beta1.DynaKube) bool {
+		isTokenSecret := dynakube.TokenSourceType() == tokensource.SecretType && dynakube.Tokens() == secret.GetName()
+		return isTokenSecret || dynakube.Spec.CustomPullSecret == secret.GetName()
+	})
+}
+
+// mapConfigMapToDynakubes enqueues every DynaKube that references the config map as trusted CAs
+func (controller *Controller) mapConfigMapToDynakubes(ctx context.Context, configMap client.Object) []reconcile.Request {
+	return controller.mapToDynakubes(ctx, configMap, func(dynakube *dynatracev1beta1.DynaKube) bool {
+		return dynakube.Spec.TrustedCAs == configMap.GetName()
+	})
+}
+
+func (controller *Controller) mapToDynakubes(ctx context.Context, obj client.Object, isReferenced func(dynakube *dynatracev1beta1.DynaKube) bool) []reconcile.Request {
+	var dynakubeList dynatracev1beta1.DynaKubeList
+	err := controller.client.List(ctx, &dynakubeList, client.InNamespace(obj.GetNamespace()))
+	if err != nil {
+		log.Error(err, "failed to list dynakubes", "namespace", obj.GetNamespace())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for i := range dynakubeList.Items {
+		dynakube := &dynakubeList.Items[i]
+		if isReferenced(dynakube) {
+			log.Info("referenced object changed, reconciling DynaKube", "object", obj.GetName(), "dynakube", dynakube.Name)
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: dynakube.Name, Namespace: dynakube.Namespace},
+			})
+		}
+	}
+	return requests
+}
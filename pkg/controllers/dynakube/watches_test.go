@@ -0,0 +1,84 @@
+package dynakube
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/tokensource"
+	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestMapToDynakubes(t *testing.T) {
+	const namespace = "dynatrace"
+
+	defaultTokens := &dynatracev1beta1.DynaKube{
+		ObjectMeta: metav1.ObjectMeta{Name: "default-tokens", Namespace: namespace},
+	}
+	customTokens := &dynatracev1beta1.DynaKube{
+		ObjectMeta: metav1.ObjectMeta{Name: "custom-tokens", Namespace: namespace},
+		Spec: dynatracev1beta1.DynaKubeSpec{
+			Tokens:           "shared-tokens",
+			CustomPullSecret: "pull-secret",
+			TrustedCAs:       "certificates",
+		},
+	}
+	otherNamespace := &dynatracev1beta1.DynaKube{
+		ObjectMeta: metav1.ObjectMeta{Name: "other-namespace", Namespace: "other"},
+		Spec: dynatracev1beta1.DynaKubeSpec{
+			Tokens: "shared-tokens",
+		},
+	}
+	fileTokens := &dynatracev1beta1.DynaKube{
+		ObjectMeta: metav1.ObjectMeta{Name: "file-tokens", Namespace: namespace},
+		Spec: dynatracev1beta1.DynaKubeSpec{
+			TokenSource: &tokensource.Spec{
+				Type: tokensource.FileType,
+				File: &tokensource.FileSpec{Path: "/var/run/secrets/dynatrace"},
+			},
+		},
+	}
+	controller := &Controller{client: fake.NewClient(defaultTokens, customTokens, otherNamespace, fileTokens)}
+
+	requestFor := func(dynakube *dynatracev1beta1.DynaKube) reconcile.Request {
+		return reconcile.Request{NamespacedName: types.NamespacedName{Name: dynakube.Name, Namespace: dynakube.Namespace}}
+	}
+
+	t.Run("token secret named like the dynakube", func(t *testing.T) {
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: defaultTokens.Name, Namespace: namespace}}
+
+		assert.Equal(t, []reconcile.Request{requestFor(defaultTokens)}, controller.mapSecretToDynakubes(context.Background(), secret))
+	})
+	t.Run("token secret referenced by the dynakube", func(t *testing.T) {
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "shared-tokens", Namespace: namespace}}
+
+		assert.Equal(t, []reconcile.Request{requestFor(customTokens)}, controller.mapSecretToDynakubes(context.Background(), secret))
+	})
+	t.Run("secret named like a dynakube which reads its tokens from files", func(t *testing.T) {
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: fileTokens.Name, Namespace: namespace}}
+
+		assert.Empty(t, controller.mapSecretToDynakubes(context.Background(), secret))
+	})
+	t.Run("custom pull secret", func(t *testing.T) {
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "pull-secret", Namespace: namespace}}
+
+		assert.Equal(t, []reconcile.Request{requestFor(customTokens)}, controller.mapSecretToDynakubes(context.Background(), secret))
+	})
+	t.Run("trusted CAs config map", func(t *testing.T) {
+		configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "certificates", Namespace: namespace}}
+
+		assert.Equal(t, []reconcile.Request{requestFor(customTokens)}, controller.mapConfigMapToDynakubes(context.Background(), configMap))
+	})
+	t.Run("unrelated objects", func(t *testing.T) {
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "unrelated", Namespace: namespace}}
+		configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "shared-tokens", Namespace: namespace}}
+
+		assert.Empty(t, controller.mapSecretToDynakubes(context.Background(), secret))
+		assert.Empty(t, controller.mapConfigMapToDynakubes(context.Background(), configMap))
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"slices"
 	"time"
 
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/maintenancewindow"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/status"
 	edgeconnectv1alpha1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1alpha1/edgeconnect"
 	"github.com/Dynatrace/dynatrace-operator/pkg/clients/edgeconnect"
@@ -20,6 +21,7 @@ import (
 	"github.com/pkg/errors"
 	appsv1 "k8s.io/api/apps/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -133,6 +135,12 @@ func (controller *Controller) reconcileEdgeConnect(ctx context.Context, edgeConn
 
 	err := controller.reconcileEdgeConnectCR(ctx, edgeConnect)
 
+	// the UpdatePending condition is reset on every reconciliation, an unchanged condition keeps its lastTransitionTime
+	oldCondition := meta.FindStatusCondition(oldStatus.Conditions, edgeconnectv1alpha1.UpdatePendingConditionType)
+	if condition := meta.FindStatusCondition(edgeConnect.Status.Conditions, edgeconnectv1alpha1.UpdatePendingConditionType); oldCondition != nil && condition != nil {
+		condition.LastTransitionTime = oldCondition.LastTransitionTime
+	}
+
 	if err != nil {
 		edgeConnect.Status.SetPhase(status.Error)
 		log.Error(err, "error reconciling EdgeConnect", "namespace", edgeConnect.Namespace, "name", edgeConnect.Name)
@@ -154,7 +162,16 @@ func (controller *Controller) reconcileEdgeConnect(ctx context.Context, edgeConn
 	if err != nil {
 		return reconcile.Result{}, err
 	}
-	return reconcile.Result{RequeueAfter: defaultUpdateInterval}, err
+	return reconcile.Result{RequeueAfter: controller.requeueAfter(edgeConnect)}, err
+}
+
+// requeueAfter makes sure a deferred update is applied as soon as the next maintenance window opens
+func (controller *Controller) requeueAfter(edgeConnect *edgeconnectv1alpha1.EdgeConnect) time.Duration {
+	if !edgeConnect.IsUpdateDeferred() {
+		return defaultUpdateInterval
+	}
+	untilWindow := edgeConnect.Status.UpdatesDeferredUntil.Sub(controller.timeProvider.Now().Time)
+	return min(max(untilWindow, 0), defaultUpdateInterval)
 }
 
 func (controller *Controller) reconcileEdgeConnectCR(ctx context.Context, edgeConnect *edgeconnectv1alpha1.EdgeConnect) error {
@@ -162,6 +179,10 @@ func (controller *Controller) reconcileEdgeConnectCR(ctx context.Context, edgeCo
 		return err
 	}
 
+	if err := controller.reconcileMaintenanceWindows(edgeConnect); err != nil {
+		return err
+	}
+
 	if err := controller.updateVersionInfo(ctx, edgeConnect); err != nil {
 		return err
 	}
@@ -201,6 +222,25 @@ func (controller *Controller) updateFinalizers(ctx context.Context, edgeConnect
 	return nil
 }
 
+// reconcileMaintenanceWindows records until when updates of the deployment are deferred.
+// The UpdatePending condition is reset, it is set again as long as an update of the deployment is deferred.
+func (controller *Controller) reconcileMaintenanceWindows(edgeConnect *edgeconnectv1alpha1.EdgeConnect) error {
+	meta.RemoveStatusCondition(&edgeConnect.Status.Conditions, edgeconnectv1alpha1.UpdatePendingConditionType)
+
+	deferredUntil, err := maintenancewindow.DeferUpdatesUntil(edgeConnect.Spec.MaintenanceWindows, controller.timeProvider.Now().Time)
+	if err != nil {
+		return err
+	}
+
+	edgeConnect.Status.UpdatesDeferredUntil = deferredUntil
+	if deferredUntil == nil {
+		return nil
+	}
+
+	log.Info("outside of maintenance windows, updates of the deployment are deferred", "name", edgeConnect.Name, "namespace", edgeConnect.Namespace, "until", deferredUntil)
+	return nil
+}
+
 func (controller *Controller) updateVersionInfo(ctx context.Context, edgeConnect *edgeconnectv1alpha1.EdgeConnect) error {
 	log.Info("updating version info", "name", edgeConnect.Name, "namespace", edgeConnect.Namespace)
 
@@ -251,7 +291,7 @@ func (controller *Controller) reconcileEdgeConnectRegular(edgeConnect *edgeconne
 	}
 	desiredDeployment.Annotations[hasher.AnnotationHash] = ddHash
 
-	_, err = k8sdeployment.CreateOrUpdateDeployment(controller.client, log, desiredDeployment)
+	err = controller.createOrUpdateDeployment(edgeConnect, desiredDeployment)
 
 	if err != nil {
 		log.Info("could not create or update deployment for EdgeConnect", "name", desiredDeployment.Name)
@@ -356,6 +396,7 @@ func newEdgeConnectClient() func(ctx context.Context, edgeConnect *edgeconnectv1
 				"oauth2:clients:manage",
 			}),
 			edgeconnect.WithContext(ctx),
+			edgeconnect.WithOwner(edgeConnect.Name),
 		)
 		if err != nil {
 			return nil, errors.WithStack(err)
@@ -487,7 +528,7 @@ func (controller *Controller) createOrUpdateEdgeConnectDeployment(ctx context.Co
 	}
 	desiredDeployment.Annotations[hasher.AnnotationHash] = ddHash
 
-	_, err = k8sdeployment.CreateOrUpdateDeployment(controller.client, log, desiredDeployment)
+	err = controller.createOrUpdateDeployment(edgeConnect, desiredDeployment)
 
 	if err != nil {
 		log.Info("could not create or update deployment for EdgeConnect", "name", desiredDeployment.Name)
@@ -496,6 +537,24 @@ func (controller *Controller) createOrUpdateEdgeConnectDeployment(ctx context.Co
 	return nil
 }
 
+// createOrUpdateDeployment creates the deployment, but outside of the maintenance windows an outdated deployment is kept as it is
+func (controller *Controller) createOrUpdateDeployment(edgeConnect *edgeconnectv1alpha1.EdgeConnect, desiredDeployment *appsv1.Deployment) error {
+	if edgeConnect.IsUpdateDeferred() {
+		updateNeeded, err := k8sdeployment.IsUpdateNeeded(controller.client, desiredDeployment)
+		if err != nil {
+			return err
+		}
+		if updateNeeded {
+			log.Info("update of deployment deferred until the next maintenance window", "name", desiredDeployment.Name, "until", edgeConnect.Status.UpdatesDeferredUntil)
+			edgeConnect.SetConditionUpdatePending()
+			return nil
+		}
+	}
+
+	_, err := k8sdeployment.CreateOrUpdateDeployment(controller.client, log, desiredDeployment)
+	return err
+}
+
 func edgeConnectClientSecretName(edgeConnectName string) string {
 	return edgeConnectName + "-client"
 }
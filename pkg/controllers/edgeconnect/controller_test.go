@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/maintenancewindow"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/status"
@@ -139,6 +140,44 @@ func TestReconcile(t *testing.T) {
 	})
 }
 
+func TestReconcileMaintenanceWindows(t *testing.T) {
+	// 2024-01-06 is a saturday
+	now := metav1.NewTime(time.Date(2024, 1, 6, 9, 30, 0, 0, time.UTC))
+	timeProvider := timeprovider.New()
+	timeProvider.Set(&now)
+	controller := &Controller{timeProvider: timeProvider}
+
+	t.Run("updates are deferred outside of maintenance window", func(t *testing.T) {
+		edgeConnect := &edgeconnectv1alpha1.EdgeConnect{
+			Spec: edgeconnectv1alpha1.EdgeConnectSpec{
+				MaintenanceWindows: []maintenancewindow.Window{{Schedule: "0 10 * * SAT", Duration: &metav1.Duration{Duration: time.Hour}}},
+			},
+		}
+		// the condition of an update that isn't pending anymore must not be kept
+		edgeConnect.SetConditionUpdatePending()
+
+		require.NoError(t, controller.reconcileMaintenanceWindows(edgeConnect))
+		assert.Empty(t, edgeConnect.Status.Conditions)
+		require.True(t, edgeConnect.IsUpdateDeferred())
+		assert.True(t, now.Add(30*time.Minute).Equal(edgeConnect.Status.UpdatesDeferredUntil.Time))
+		assert.Equal(t, 30*time.Minute, controller.requeueAfter(edgeConnect))
+	})
+	t.Run("pending update is resolved inside of maintenance window", func(t *testing.T) {
+		edgeConnect := &edgeconnectv1alpha1.EdgeConnect{
+			Spec: edgeconnectv1alpha1.EdgeConnectSpec{
+				MaintenanceWindows: []maintenancewindow.Window{{Schedule: "0 9 * * SAT", Duration: &metav1.Duration{Duration: time.Hour}}},
+			},
+		}
+		edgeConnect.Status.UpdatesDeferredUntil = &metav1.Time{Time: now.Add(-time.Hour)}
+		edgeConnect.SetConditionUpdatePending()
+
+		require.NoError(t, controller.reconcileMaintenanceWindows(edgeConnect))
+		assert.False(t, edgeConnect.IsUpdateDeferred())
+		assert.Empty(t, edgeConnect.Status.Conditions)
+		assert.Equal(t, defaultUpdateInterval, controller.requeueAfter(edgeConnect))
+	})
+}
+
 func newSecret(name, namespace string, kv map[string]string) *corev1.Secret {
 	data := make(map[string][]byte)
 	for k, v := range kv {
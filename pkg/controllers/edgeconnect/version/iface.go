@@ -1,9 +1,14 @@
 package version
 
-import "context"
+import (
+	"context"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/status"
+)
 
 type versionStatusUpdater interface {
 	Name() string
+	Target() *status.VersionStatus
 	RequiresReconcile() bool
 	Update(ctx context.Context) error
 }
@@ -2,13 +2,19 @@ package version
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/status"
 	edgeconnectv1alpha1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1alpha1/edgeconnect"
 	"github.com/Dynatrace/dynatrace-operator/pkg/oci/registry"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/timeprovider"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+// deferredReasonSuffix ends the reason of the version status if its update waits for the next maintenance window
+const deferredReasonSuffix = "deferred until the next maintenance window"
+
 type Reconciler struct {
 	edgeConnect  *edgeconnectv1alpha1.EdgeConnect
 	timeProvider *timeprovider.Provider
@@ -35,7 +41,15 @@ func (reconciler *Reconciler) Reconcile(ctx context.Context) error {
 		log.Info("updating version status", "updater", updater.Name())
 
 		if updater.RequiresReconcile() {
-			return updater.Update(ctx)
+			return reconciler.updateOrDefer(ctx, updater)
+		}
+
+		if isDeferred(updater.Target()) {
+			if !reconciler.edgeConnect.IsUpdateDeferred() {
+				log.Info("maintenance window is open, applying deferred update", "updater", updater.Name())
+				return reconciler.updateOrDefer(ctx, updater)
+			}
+			reconciler.edgeConnect.SetConditionUpdatePending()
 		}
 
 		log.Info("no reconcile required", "updater", updater.Name())
@@ -43,3 +57,39 @@ func (reconciler *Reconciler) Reconcile(ctx context.Context) error {
 
 	return nil
 }
+
+// updateOrDefer runs the updater, but outside of the maintenance windows a changed image of an already deployed EdgeConnect is reverted
+// and the UpdatePending condition is set instead. The deferred update is recorded in the reason of the version status,
+// so it is applied once a maintenance window opens, without probing for it again in between.
+func (reconciler *Reconciler) updateOrDefer(ctx context.Context, updater versionStatusUpdater) error {
+	previous := *updater.Target()
+	if isDeferred(updater.Target()) {
+		updater.Target().Reason = ""
+	}
+
+	if !reconciler.edgeConnect.IsUpdateDeferred() || previous.ImageID == "" {
+		return updater.Update(ctx)
+	}
+
+	err := updater.Update(ctx)
+	if err != nil {
+		return err
+	}
+
+	target := updater.Target()
+	if target.ImageID != previous.ImageID {
+		log.Info("version update deferred until the next maintenance window", "updater", updater.Name(),
+			"currentImage", previous.ImageID, "newImage", target.ImageID, "until", reconciler.edgeConnect.Status.UpdatesDeferredUntil)
+
+		// the new LastProbeTimestamp is kept, so the deferred update isn't probed again on every reconciliation
+		target.Reason = fmt.Sprintf("update to %s %s", target.ImageID, deferredReasonSuffix)
+		target.ImageID = previous.ImageID
+		target.Source = previous.Source
+		reconciler.edgeConnect.SetConditionUpdatePending()
+	}
+	return nil
+}
+
+func isDeferred(target *status.VersionStatus) bool {
+	return strings.HasSuffix(target.Reason, deferredReasonSuffix)
+}
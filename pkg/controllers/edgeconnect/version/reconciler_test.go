@@ -3,13 +3,18 @@ package version
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
+	edgeconnectv1alpha1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1alpha1/edgeconnect"
 	"github.com/Dynatrace/dynatrace-operator/pkg/oci/registry"
 	"github.com/Dynatrace/dynatrace-operator/pkg/oci/registry/mocks"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/timeprovider"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestNewReconcile(t *testing.T) {
@@ -23,3 +28,60 @@ func TestNewReconcile(t *testing.T) {
 	require.NotNil(t, reconciler)
 	require.NoError(t, reconciler.Reconcile(context.Background()))
 }
+
+func TestReconcileMaintenanceWindow(t *testing.T) {
+	deployedImage := "docker.io/dynatrace/edgeconnect:latest@sha256:0000000000000000000000000000000000000000000000000000000000000000"
+	newEdgeConnect := func(updatesDeferred bool) *edgeconnectv1alpha1.EdgeConnect {
+		edgeConnect := createBasicEdgeConnect()
+		edgeConnect.Spec.AutoUpdate = true
+		edgeConnect.Status.Version.ImageID = deployedImage
+		if updatesDeferred {
+			edgeConnect.Status.UpdatesDeferredUntil = &metav1.Time{Time: time.Now().Add(time.Hour)}
+		}
+		return edgeConnect
+	}
+	fakeRegistryClient := &mocks.MockImageGetter{}
+	fakeRegistryClient.On("GetImageVersion", mock.Anything, mock.Anything).Return(registry.ImageVersion{Digest: fakeDigest}, nil)
+
+	t.Run("image update is deferred outside of maintenance window", func(t *testing.T) {
+		edgeConnect := newEdgeConnect(true)
+		reconciler := NewReconciler(fake.NewClient(), fakeRegistryClient, timeprovider.New(), edgeConnect)
+
+		require.NoError(t, reconciler.Reconcile(context.Background()))
+		assert.Equal(t, deployedImage, edgeConnect.Status.Version.ImageID)
+		assert.NotNil(t, edgeConnect.Status.Version.LastProbeTimestamp)
+		assert.True(t, isDeferred(&edgeConnect.Status.Version))
+
+		condition := meta.FindStatusCondition(edgeConnect.Status.Conditions, edgeconnectv1alpha1.UpdatePendingConditionType)
+		require.NotNil(t, condition)
+		assert.Equal(t, edgeconnectv1alpha1.ReasonOutsideMaintenanceWindow, condition.Reason)
+	})
+	t.Run("deferred update isn't probed again until a maintenance window opens", func(t *testing.T) {
+		edgeConnect := newEdgeConnect(true)
+		edgeConnect.Status.Version.LastProbeTimestamp = &metav1.Time{Time: time.Now()}
+		edgeConnect.Status.Version.Reason = "update to docker.io/dynatrace/edgeconnect:latest@" + fakeDigest + " " + deferredReasonSuffix
+		registryClient := &mocks.MockImageGetter{}
+		reconciler := NewReconciler(fake.NewClient(), registryClient, timeprovider.New(), edgeConnect)
+
+		require.NoError(t, reconciler.Reconcile(context.Background()))
+		assert.Equal(t, deployedImage, edgeConnect.Status.Version.ImageID)
+		assert.NotNil(t, meta.FindStatusCondition(edgeConnect.Status.Conditions, edgeconnectv1alpha1.UpdatePendingConditionType))
+		registryClient.AssertNotCalled(t, "GetImageVersion", mock.Anything, mock.Anything)
+
+		edgeConnect.Status.UpdatesDeferredUntil = nil
+		edgeConnect.Status.Conditions = nil
+		reconciler = NewReconciler(fake.NewClient(), fakeRegistryClient, timeprovider.New(), edgeConnect)
+
+		require.NoError(t, reconciler.Reconcile(context.Background()))
+		assert.Equal(t, "docker.io/dynatrace/edgeconnect:latest@"+fakeDigest, edgeConnect.Status.Version.ImageID)
+		assert.False(t, isDeferred(&edgeConnect.Status.Version))
+	})
+	t.Run("image is updated inside of maintenance window", func(t *testing.T) {
+		edgeConnect := newEdgeConnect(false)
+		reconciler := NewReconciler(fake.NewClient(), fakeRegistryClient, timeprovider.New(), edgeConnect)
+
+		require.NoError(t, reconciler.Reconcile(context.Background()))
+		assert.Equal(t, "docker.io/dynatrace/edgeconnect:latest@"+fakeDigest, edgeConnect.Status.Version.ImageID)
+		assert.Nil(t, meta.FindStatusCondition(edgeConnect.Status.Conditions, edgeconnectv1alpha1.UpdatePendingConditionType))
+	})
+}
@@ -153,7 +153,7 @@ func TestReconcile(t *testing.T) {
 		fakeClient := createDefaultFakeClient()
 
 		dtClient := mocks.NewClient(t)
-		dtClient.On("GetEntityIDForIP", mock.Anything).Return("", ErrNotFound)
+		dtClient.On("GetEntityIDForIP", mock.Anything, mock.Anything).Return("", ErrNotFound)
 
 		ctrl := createDefaultReconciler(fakeClient, dtClient)
 
@@ -166,7 +166,7 @@ func TestReconcile(t *testing.T) {
 		fakeClient := createDefaultFakeClient()
 
 		dtClient := mocks.NewClient(t)
-		dtClient.On("GetEntityIDForIP", mock.Anything).Return("", dtclient.HostNotFoundErr{IP: "1.2.3.4"})
+		dtClient.On("GetEntityIDForIP", mock.Anything, mock.Anything).Return("", dtclient.HostNotFoundErr{IP: "1.2.3.4"})
 
 		ctrl := createDefaultReconciler(fakeClient, dtClient)
 
@@ -259,8 +259,8 @@ func createDefaultReconciler(fakeClient client.Client, dtClient *mocks.Client) *
 
 func createDTMockClient(t *testing.T, ip, host string) *mocks.Client {
 	dtClient := mocks.NewClient(t)
-	dtClient.On("GetEntityIDForIP", ip).Return(host, nil)
-	dtClient.On("SendEvent", mock.MatchedBy(func(e *dtclient.EventData) bool {
+	dtClient.On("GetEntityIDForIP", mock.Anything, ip).Return(host, nil)
+	dtClient.On("SendEvent", mock.Anything, mock.MatchedBy(func(e *dtclient.EventData) bool {
 		return e.EventType == "MARKED_FOR_TERMINATION"
 	})).Return(nil)
 	return dtClient
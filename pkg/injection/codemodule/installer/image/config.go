@@ -8,6 +8,6 @@ import (
 )
 
 var (
-	CacheDir = filepath.Join(dtcsi.DataPath, "cache")
+	CacheDir = filepath.Join(dtcsi.DataPath, dtcsi.ImageCacheDir)
 	log      = logger.Factory.GetLogger("oneagent-image")
 )
@@ -2,16 +2,14 @@ package image
 
 import (
 	"context"
-	"crypto/tls"
-	"crypto/x509"
 	"fmt"
 	"net/http"
-	"net/url"
 	"os"
 	"path/filepath"
 	"strings"
 
 	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/httptransport"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/csi/metadata"
 	"github.com/Dynatrace/dynatrace-operator/pkg/injection/codemodule/installer"
 	"github.com/Dynatrace/dynatrace-operator/pkg/injection/codemodule/installer/common"
@@ -33,6 +31,8 @@ type Properties struct {
 	PathResolver metadata.PathResolver
 	Metadata     metadata.Access
 	ImageDigest  string
+	// Arch selects the platform of multi-arch images, if empty the default platform of the image is used
+	Arch string
 }
 
 func GetDigest(uri string) (string, error) {
@@ -49,43 +49,12 @@ func GetDigest(uri string) (string, error) {
 	return strings.TrimLeft(refDigest.DigestStr(), digest.Canonical.String()+":"), nil
 }
 
+// NewImageInstaller pulls the image via the shared transport of the DynaKube, so the proxy and the trusted certificates
+// of the DynaKube are applied like for its other clients and the connections are reused between installations.
 func NewImageInstaller(fs afero.Fs, props *Properties) (installer.Installer, error) {
-	// Create default transport
-	transport := http.DefaultTransport.(*http.Transport).Clone()
-
-	if props.Dynakube.HasProxy() {
-		proxy, err := props.Dynakube.Proxy(context.TODO(), props.ApiReader)
-		if err != nil {
-			log.Info("failed to get proxy from dynakube", "proxy", proxy)
-			return nil, err
-		}
-
-		proxyUrl, err := url.Parse(proxy)
-		if err != nil {
-			log.Info("invalid proxy url", "proxy", proxy)
-		}
-		log.Info("proxy spec", "proxyURL.Host", proxyUrl.Host, "proxyURL.Port()", proxyUrl.Port())
-
-		transport.Proxy = func(req *http.Request) (*url.URL, error) {
-			return proxyUrl, nil
-		}
-	}
-
-	if props.Dynakube.Spec.TrustedCAs != "" {
-		trustedCAs, err := props.Dynakube.TrustedCAs(context.TODO(), props.ApiReader)
-		if err != nil {
-			return nil, err
-		}
-
-		rootCAs := x509.NewCertPool()
-		if ok := rootCAs.AppendCertsFromPEM(trustedCAs); !ok {
-			log.Info("failed to append custom certs!")
-		}
-
-		if transport.TLSClientConfig == nil {
-			transport.TLSClientConfig = &tls.Config{} // nolint:gosec
-		}
-		transport.TLSClientConfig.RootCAs = rootCAs
+	transport, err := httptransport.Shared().ForDynaKube(context.TODO(), props.ApiReader, props.Dynakube)
+	if err != nil {
+		return nil, err
 	}
 
 	keychain, err := dockerkeychain.NewDockerKeychain(context.TODO(), props.ApiReader, props.Dynakube.PullSecretWithoutData())
@@ -110,7 +79,7 @@ type Installer struct {
 	keychain  authn.Keychain
 }
 
-func (installer *Installer) InstallAgent(targetDir string) (bool, error) {
+func (installer *Installer) InstallAgent(ctx context.Context, targetDir string) (bool, error) {
 	log.Info("installing agent from image")
 
 	if installer.isAlreadyPresent(targetDir) {
@@ -125,7 +94,7 @@ func (installer *Installer) InstallAgent(targetDir string) (bool, error) {
 	}
 
 	log.Info("installing agent", "target dir", targetDir)
-	if err := installer.installAgentFromImage(targetDir); err != nil {
+	if err := installer.installAgentFromImage(ctx, targetDir); err != nil {
 		_ = installer.fs.RemoveAll(targetDir)
 		log.Info("failed to install agent from image", "err", err)
 		return false, errors.WithStack(err)
@@ -139,7 +108,7 @@ func (installer *Installer) InstallAgent(targetDir string) (bool, error) {
 	return true, nil
 }
 
-func (installer *Installer) installAgentFromImage(targetDir string) error {
+func (installer *Installer) installAgentFromImage(ctx context.Context, targetDir string) error {
 	defer installer.fs.RemoveAll(CacheDir)
 	err := installer.fs.MkdirAll(CacheDir, common.MkDirFileMode)
 	if err != nil {
@@ -158,7 +127,7 @@ func (installer *Installer) installAgentFromImage(targetDir string) error {
 		return errors.WithStack(err)
 	}
 
-	err = installer.extractAgentBinariesFromImage(
+	err = installer.extractAgentBinariesFromImage(ctx,
 		imagePullInfo{
 			imageCacheDir: imageCacheDir,
 			targetDir:     targetDir,
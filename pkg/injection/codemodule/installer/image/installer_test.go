@@ -1,6 +1,8 @@
 package image
 
 import (
+	"context"
+
 	"fmt"
 	"io"
 	"net/http"
@@ -9,6 +11,7 @@ import (
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
 	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/httptransport"
 	"github.com/Dynatrace/dynatrace-operator/pkg/consts"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/csi/metadata"
 	"github.com/Dynatrace/dynatrace-operator/pkg/injection/codemodule/installer/zip"
@@ -110,7 +113,10 @@ func TestNewImageInstaller(t *testing.T) {
 	in, err := NewImageInstaller(testFS, props)
 	require.NoError(t, err)
 	assert.NotNil(t, in)
-	assert.NotNil(t, in)
+
+	sharedTransport, err := httptransport.Shared().ForDynaKube(context.Background(), fakeClient, dynakube)
+	require.NoError(t, err)
+	assert.Same(t, sharedTransport, in.(*Installer).transport)
 }
 
 type RoundTripFunc func(req *http.Request) *http.Response
@@ -176,7 +182,7 @@ func TestInstaller_InstallAgent(t *testing.T) {
 				props:     tt.fields.props,
 				transport: tt.fields.transport,
 			}
-			got, err := installer.InstallAgent(tt.args.targetDir)
+			got, err := installer.InstallAgent(context.Background(), tt.args.targetDir)
 			if !tt.wantErr(t, err, fmt.Sprintf("InstallAgent(%v)", tt.args.targetDir)) {
 				return
 			}
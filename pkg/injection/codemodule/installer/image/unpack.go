@@ -6,6 +6,7 @@ import (
 	"path"
 	"path/filepath"
 
+	"github.com/Dynatrace/dynatrace-operator/pkg/arch"
 	"github.com/Dynatrace/dynatrace-operator/pkg/injection/codemodule/installer/common"
 	"github.com/google/go-containerregistry/pkg/crane"
 	"github.com/google/go-containerregistry/pkg/name"
@@ -20,8 +21,8 @@ type imagePullInfo struct {
 	targetDir     string
 }
 
-func (installer Installer) extractAgentBinariesFromImage(pullInfo imagePullInfo, imageName string) error { //nolint
-	img, err := installer.pullImageInfo(imageName)
+func (installer Installer) extractAgentBinariesFromImage(ctx context.Context, pullInfo imagePullInfo, imageName string) error { //nolint
+	img, err := installer.pullImageInfo(ctx, imageName)
 	if err != nil {
 		log.Info("pullImageInfo", "error", err)
 		return err
@@ -38,19 +39,29 @@ func (installer Installer) extractAgentBinariesFromImage(pullInfo imagePullInfo,
 	return nil
 }
 
-func (installer Installer) pullImageInfo(imageName string) (*containerv1.Image, error) {
+func (installer Installer) pullImageInfo(ctx context.Context, imageName string) (*containerv1.Image, error) {
 	ref, err := name.ParseReference(imageName)
 	if err != nil {
 		return nil, errors.WithMessagef(err, "parsing reference %q:", imageName)
 	}
 
-	image, err := remote.Image(ref, remote.WithContext(context.TODO()), remote.WithAuthFromKeychain(installer.keychain), remote.WithTransport(installer.transport))
+	image, err := remote.Image(ref, installer.remoteOptions(ctx)...)
 	if err != nil {
 		return nil, errors.WithMessagef(err, "getting image %q", imageName)
 	}
 	return &image, nil
 }
 
+func (installer Installer) remoteOptions(ctx context.Context) []remote.Option {
+	options := []remote.Option{remote.WithContext(ctx), remote.WithAuthFromKeychain(installer.keychain), remote.WithTransport(installer.transport)}
+
+	// for image indexes the manifest of the node's platform is selected, single-platform images are used as they are
+	if kubernetesArch, ok := arch.KubernetesArchFromArch(installer.props.Arch); ok {
+		options = append(options, remote.WithPlatform(containerv1.Platform{OS: arch.KubernetesOsLinux, Architecture: kubernetesArch}))
+	}
+	return options
+}
+
 func (installer Installer) pullOCIimage(image containerv1.Image, imageName string, imageCacheDir string, targetDir string) error {
 	ref, err := name.ParseReference(imageName)
 	if err != nil {
@@ -1 +1,63 @@
 package image
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/arch"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	containerv1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPullImageInfo(t *testing.T) {
+	server := httptest.NewServer(registry.New())
+	defer server.Close()
+
+	imageName := strings.TrimPrefix(server.URL, "http://") + "/codemodules:multi-arch"
+	ref, err := name.ParseReference(imageName)
+	require.NoError(t, err)
+
+	amd64Image, err := random.Image(64, 1)
+	require.NoError(t, err)
+	arm64Image, err := random.Image(64, 1)
+	require.NoError(t, err)
+
+	index := mutate.AppendManifests(empty.Index,
+		mutate.IndexAddendum{Add: amd64Image, Descriptor: containerv1.Descriptor{Platform: &containerv1.Platform{OS: arch.KubernetesOsLinux, Architecture: arch.KubernetesArchAMD64}}},
+		mutate.IndexAddendum{Add: arm64Image, Descriptor: containerv1.Descriptor{Platform: &containerv1.Platform{OS: arch.KubernetesOsLinux, Architecture: arch.KubernetesArchARM64}}},
+	)
+	require.NoError(t, remote.WriteIndex(ref, index))
+
+	pullImage := func(agentArch string) containerv1.Hash {
+		installer := Installer{
+			props:     &Properties{Arch: agentArch},
+			transport: http.DefaultTransport,
+			keychain:  authn.DefaultKeychain,
+		}
+		image, err := installer.pullImageInfo(context.Background(), imageName)
+		require.NoError(t, err)
+
+		digest, err := (*image).Digest()
+		require.NoError(t, err)
+		return digest
+	}
+
+	amd64Digest, err := amd64Image.Digest()
+	require.NoError(t, err)
+	arm64Digest, err := arm64Image.Digest()
+	require.NoError(t, err)
+
+	assert.Equal(t, amd64Digest, pullImage(arch.ArchX86))
+	assert.Equal(t, arm64Digest, pullImage(arch.ArchARM))
+}
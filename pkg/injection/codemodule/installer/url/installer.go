@@ -1,9 +1,11 @@
 package url
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 
+	"github.com/Dynatrace/dynatrace-operator/pkg/arch"
 	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
 	"github.com/Dynatrace/dynatrace-operator/pkg/consts"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/csi/metadata"
@@ -32,6 +34,15 @@ func (props *Properties) fillEmptyWithDefaults() {
 	if props.Technologies == nil || len(props.Technologies) == 0 {
 		props.Technologies = []string{"all"}
 	}
+	if props.Os == "" {
+		props.Os = dtclient.OsUnix
+	}
+	if props.Arch == "" {
+		props.Arch = arch.Arch
+	}
+	if props.Flavor == "" {
+		props.Flavor = arch.DefaultFlavor(props.Os, props.Arch)
+	}
 }
 
 type Installer struct {
@@ -50,7 +61,7 @@ func NewUrlInstaller(fs afero.Fs, dtc dtclient.Client, props *Properties) instal
 	}
 }
 
-func (installer Installer) InstallAgent(targetDir string) (bool, error) {
+func (installer Installer) InstallAgent(ctx context.Context, targetDir string) (bool, error) {
 	log.Info("installing agent from url")
 
 	if installer.isAlreadyDownloaded(targetDir) {
@@ -66,12 +77,17 @@ func (installer Installer) InstallAgent(targetDir string) (bool, error) {
 
 	log.Info("installing agent", "target dir", targetDir)
 	installer.props.fillEmptyWithDefaults()
-	if err := installer.installAgent(targetDir); err != nil {
+	if err := installer.installAgent(ctx, targetDir); err != nil {
 		_ = installer.fs.RemoveAll(targetDir)
 		log.Info("failed to install agent", "targetDir", targetDir)
 		return false, err
 	}
 
+	// the current version symlink is only used for the preload of unix code modules
+	if installer.props.Os == dtclient.OsWindows {
+		return true, nil
+	}
+
 	if err := symlink.CreateSymlinkForCurrentVersionIfNotExists(installer.fs, targetDir); err != nil {
 		_ = installer.fs.RemoveAll(targetDir)
 		log.Info("failed to create symlink for agent installation", "targetDir", targetDir)
@@ -80,7 +96,7 @@ func (installer Installer) InstallAgent(targetDir string) (bool, error) {
 	return true, nil
 }
 
-func (installer Installer) installAgent(targetDir string) error {
+func (installer Installer) installAgent(ctx context.Context, targetDir string) error {
 	fs := installer.fs
 	path := ""
 	if installer.isInitContainerMode() {
@@ -101,7 +117,7 @@ func (installer Installer) installAgent(targetDir string) error {
 			log.Error(err, "failed to delete downloaded file", "path", tmpFile.Name())
 		}
 	}()
-	if err := installer.downloadOneAgentFromUrl(tmpFile); err != nil {
+	if err := installer.downloadOneAgentFromUrl(ctx, tmpFile); err != nil {
 		return err
 	}
 	return installer.unpackOneAgentZip(targetDir, tmpFile)
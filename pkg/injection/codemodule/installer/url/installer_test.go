@@ -1,6 +1,8 @@
 package url
 
 import (
+	"context"
+
 	"fmt"
 	"io"
 	"os"
@@ -43,19 +45,19 @@ func TestInstallAgentFromUrl(t *testing.T) {
 			fs: fs,
 		}
 
-		err := installer.installAgent("")
+		err := installer.installAgent(context.Background(), "")
 		assert.EqualError(t, err, testErrorMessage)
 	})
 	t.Run(`error when downloading latest agent`, func(t *testing.T) {
 		fs := afero.NewMemMapFs()
 		dtc := mocks.NewClient(t)
 		dtc.
-			On("GetAgent", dtclient.OsUnix, dtclient.InstallerTypePaaS, arch.FlavorMultidistro,
+			On("GetAgent", mock.Anything, dtclient.OsUnix, dtclient.InstallerTypePaaS, arch.FlavorMultidistro,
 				mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("[]string"),
 				mock.AnythingOfType("bool"), mock.AnythingOfType("*mem.File")).
 			Return(fmt.Errorf(testErrorMessage))
 		dtc.
-			On("GetAgentVersions", dtclient.OsUnix, dtclient.InstallerTypePaaS, arch.FlavorMultidistro, mock.AnythingOfType("string")).
+			On("GetAgentVersions", mock.Anything, dtclient.OsUnix, dtclient.InstallerTypePaaS, arch.FlavorMultidistro, mock.AnythingOfType("string")).
 			Return([]string{}, fmt.Errorf(testErrorMessage))
 		installer := &Installer{
 			fs:  fs,
@@ -67,7 +69,7 @@ func TestInstallAgentFromUrl(t *testing.T) {
 			},
 		}
 
-		err := installer.installAgent("")
+		err := installer.installAgent(context.Background(), "")
 		assert.EqualError(t, err, testErrorMessage)
 	})
 	t.Run(`error unzipping file`, func(t *testing.T) {
@@ -75,11 +77,11 @@ func TestInstallAgentFromUrl(t *testing.T) {
 
 		dtc := mocks.NewClient(t)
 		dtc.
-			On("GetAgent", dtclient.OsUnix, dtclient.InstallerTypePaaS, arch.FlavorMultidistro,
+			On("GetAgent", mock.Anything, dtclient.OsUnix, dtclient.InstallerTypePaaS, arch.FlavorMultidistro,
 				mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("[]string"),
 				mock.AnythingOfType("bool"), mock.AnythingOfType("*mem.File")).
 			Run(func(args mock.Arguments) {
-				writer, _ := args.Get(7).(io.Writer)
+				writer, _ := args.Get(8).(io.Writer)
 
 				zipFile := zip.SetupInvalidTestZip(t, fs)
 				defer func() { _ = zipFile.Close() }()
@@ -99,18 +101,18 @@ func TestInstallAgentFromUrl(t *testing.T) {
 			},
 		}
 
-		err := installer.installAgent("")
+		err := installer.installAgent(context.Background(), "")
 		assert.Error(t, err)
 	})
 	t.Run(`downloading and unzipping agent via version`, func(t *testing.T) {
 		fs := afero.NewMemMapFs()
 		dtc := mocks.NewClient(t)
 		dtc.
-			On("GetAgent", dtclient.OsUnix, dtclient.InstallerTypePaaS, arch.FlavorMultidistro,
+			On("GetAgent", mock.Anything, dtclient.OsUnix, dtclient.InstallerTypePaaS, arch.FlavorMultidistro,
 				mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("[]string"),
 				mock.AnythingOfType("bool"), mock.AnythingOfType("*mem.File")).
 			Run(func(args mock.Arguments) {
-				writer, _ := args.Get(7).(io.Writer)
+				writer, _ := args.Get(8).(io.Writer)
 
 				zipFile := zip.SetupTestArchive(t, fs, zip.TestRawZip)
 				defer func() { _ = zipFile.Close() }()
@@ -131,7 +133,7 @@ func TestInstallAgentFromUrl(t *testing.T) {
 			},
 		}
 
-		err := installer.installAgent(testDir)
+		err := installer.installAgent(context.Background(), testDir)
 		require.NoError(t, err)
 		// afero can't rename directories properly: https://github.com/spf13/afero/issues/141
 	})
@@ -139,11 +141,11 @@ func TestInstallAgentFromUrl(t *testing.T) {
 		fs := afero.NewMemMapFs()
 		dtc := mocks.NewClient(t)
 		dtc.
-			On("GetLatestAgent", dtclient.OsUnix, dtclient.InstallerTypePaaS, arch.FlavorMultidistro,
+			On("GetLatestAgent", mock.Anything, dtclient.OsUnix, dtclient.InstallerTypePaaS, arch.FlavorMultidistro,
 				mock.AnythingOfType("string"), mock.AnythingOfType("[]string"), mock.AnythingOfType("bool"),
 				mock.AnythingOfType("*mem.File")).
 			Run(func(args mock.Arguments) {
-				writer, _ := args.Get(6).(io.Writer)
+				writer, _ := args.Get(7).(io.Writer)
 
 				zipFile := zip.SetupTestArchive(t, fs, zip.TestRawZip)
 				defer func() { _ = zipFile.Close() }()
@@ -164,7 +166,7 @@ func TestInstallAgentFromUrl(t *testing.T) {
 			},
 		}
 
-		err := installer.installAgent(testDir)
+		err := installer.installAgent(context.Background(), testDir)
 		require.NoError(t, err)
 		// afero can't rename directories properly: https://github.com/spf13/afero/issues/141
 	})
@@ -172,9 +174,9 @@ func TestInstallAgentFromUrl(t *testing.T) {
 		fs := afero.NewMemMapFs()
 		dtc := mocks.NewClient(t)
 		dtc.
-			On("GetAgentViaInstallerUrl", testUrl, mock.AnythingOfType("*mem.File")).
+			On("GetAgentViaInstallerUrl", mock.Anything, testUrl, mock.AnythingOfType("*mem.File")).
 			Run(func(args mock.Arguments) {
-				writer, _ := args.Get(1).(io.Writer)
+				writer, _ := args.Get(2).(io.Writer)
 
 				zipFile := zip.SetupTestArchive(t, fs, zip.TestRawZip)
 				defer func() { _ = zipFile.Close() }()
@@ -192,7 +194,7 @@ func TestInstallAgentFromUrl(t *testing.T) {
 			},
 		}
 
-		err := installer.installAgent(testDir)
+		err := installer.installAgent(context.Background(), testDir)
 		require.NoError(t, err)
 		// afero can't rename directories properly: https://github.com/spf13/afero/issues/141
 	})
@@ -218,3 +220,37 @@ func TestIsAlreadyDownloaded(t *testing.T) {
 		assert.False(t, installer.isAlreadyDownloaded(targetDir))
 	})
 }
+
+func TestFillEmptyWithDefaults(t *testing.T) {
+	t.Run(`unix defaults`, func(t *testing.T) {
+		props := &Properties{}
+		props.fillEmptyWithDefaults()
+
+		assert.Equal(t, []string{"all"}, props.Technologies)
+		assert.Equal(t, dtclient.OsUnix, props.Os)
+		assert.Equal(t, arch.Arch, props.Arch)
+		assert.Equal(t, arch.Flavor, props.Flavor)
+	})
+	t.Run(`windows uses the default flavor`, func(t *testing.T) {
+		props := &Properties{
+			Os:   dtclient.OsWindows,
+			Arch: arch.ArchX86,
+		}
+		props.fillEmptyWithDefaults()
+
+		assert.Equal(t, arch.FlavorDefault, props.Flavor)
+	})
+	t.Run(`set values are kept`, func(t *testing.T) {
+		props := &Properties{
+			Os:           dtclient.OsUnix,
+			Arch:         arch.ArchARM,
+			Flavor:       arch.FlavorMultidistro,
+			Technologies: []string{"java"},
+		}
+		props.fillEmptyWithDefaults()
+
+		assert.Equal(t, arch.ArchARM, props.Arch)
+		assert.Equal(t, arch.FlavorMultidistro, props.Flavor)
+		assert.Equal(t, []string{"java"}, props.Technologies)
+	})
+}
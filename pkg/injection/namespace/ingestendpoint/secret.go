@@ -11,6 +11,7 @@ import (
 	"github.com/Dynatrace/dynatrace-operator/pkg/consts"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/capability"
 	agconsts "github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/activegate/consts"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/token"
 	"github.com/Dynatrace/dynatrace-operator/pkg/injection/namespace/mapper"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/labels"
 	k8ssecret "github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/secret"
@@ -156,14 +157,14 @@ func (g *EndpointSecretGenerator) prepare(ctx context.Context, dk *dynatracev1be
 func (g *EndpointSecretGenerator) PrepareFields(ctx context.Context, dk *dynatracev1beta1.DynaKube) (map[string]string, error) {
 	fields := make(map[string]string)
 
-	var tokens corev1.Secret
-	if err := g.client.Get(ctx, client.ObjectKey{Name: dk.Tokens(), Namespace: g.namespace}, &tokens); err != nil {
+	tokens, err := token.NewReader(g.client, dk).ReadTokens(ctx)
+	if err != nil {
 		return nil, errors.WithMessage(err, "failed to query tokens")
 	}
 
 	if !dk.FeatureDisableMetadataEnrichment() {
-		if token, ok := tokens.Data[dtclient.DynatraceDataIngestToken]; ok {
-			fields[MetricsTokenSecretField] = string(token)
+		if dataIngestToken, ok := tokens[dtclient.DynatraceDataIngestToken]; ok {
+			fields[MetricsTokenSecretField] = dataIngestToken.Value
 		}
 
 		if dataIngestUrl, err := dataIngestUrlFor(dk); err != nil {
@@ -5,8 +5,8 @@ import (
 	"encoding/json"
 
 	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
-	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
 	"github.com/Dynatrace/dynatrace-operator/pkg/consts"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/token"
 	"github.com/Dynatrace/dynatrace-operator/pkg/injection/namespace/mapper"
 	"github.com/Dynatrace/dynatrace-operator/pkg/injection/startup"
 	k8slabels "github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/labels"
@@ -131,13 +131,12 @@ func (g *InitGenerator) generate(ctx context.Context, dk *dynatracev1beta1.DynaK
 }
 
 func (g *InitGenerator) createSecretConfigForDynaKube(ctx context.Context, dynakube *dynatracev1beta1.DynaKube, kubeSystemUID types.UID, hostMonitoringNodes map[string]string) (*startup.SecretConfig, error) {
-	var tokens corev1.Secret
-	if err := g.client.Get(ctx, client.ObjectKey{Name: dynakube.Tokens(), Namespace: g.namespace}, &tokens); err != nil {
+	tokens, err := token.NewReader(g.client, dynakube).ReadTokens(ctx)
+	if err != nil {
 		return nil, errors.WithMessage(err, "failed to query tokens")
 	}
 
 	var proxy string
-	var err error
 	if dynakube.NeedsOneAgentProxy() {
 		proxy, err = dynakube.Proxy(ctx, g.apiReader)
 		if err != nil {
@@ -165,6 +164,7 @@ func (g *InitGenerator) createSecretConfigForDynaKube(ctx context.Context, dynak
 		NetworkZone:         dynakube.Spec.NetworkZone,
 		TrustedCAs:          string(trustedCAs),
 		SkipCertCheck:       dynakube.Spec.SkipCertCheck,
+		MirrorURL:           dynakube.MirrorURL(),
 		HasHost:             dynakube.CloudNativeFullstackMode(),
 		MonitoringNodes:     hostMonitoringNodes,
 		TlsCert:             tlsCert,
@@ -174,15 +174,15 @@ func (g *InitGenerator) createSecretConfigForDynaKube(ctx context.Context, dynak
 	}, nil
 }
 
-func getPaasToken(tokens corev1.Secret) string {
-	if len(tokens.Data[dtclient.DynatracePaasToken]) != 0 {
-		return string(tokens.Data[dtclient.DynatracePaasToken])
+func getPaasToken(tokens token.Tokens) string {
+	if paasToken := tokens.PaasToken().Value; paasToken != "" {
+		return paasToken
 	}
-	return string(tokens.Data[dtclient.DynatraceApiToken])
+	return tokens.ApiToken().Value
 }
 
-func getAPIToken(tokens corev1.Secret) string {
-	return string(tokens.Data[dtclient.DynatraceApiToken])
+func getAPIToken(tokens token.Tokens) string {
+	return tokens.ApiToken().Value
 }
 
 // getHostMonitoringNodes creates a mapping between all the nodes and the tenantUID for the host-monitoring dynakube on that node.
@@ -2,6 +2,7 @@ package startup
 
 import (
 	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
+	dtcache "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace/cache"
 )
 
 type dtclientBuilder struct {
@@ -29,7 +30,9 @@ func (builder *dtclientBuilder) createClient() (dtclient.Client, error) {
 		return nil, err
 	}
 	log.Info("dtclient created successfully")
-	return client, nil
+
+	identity := dtcache.Identity(builder.config.ApiUrl, builder.config.NetworkZone, builder.config.MirrorURL, builder.config.ApiToken, builder.config.PaasToken)
+	return dtcache.NewClient(client, dtcache.Shared(), identity, dtcache.DefaultTTL), nil
 }
 
 func (builder *dtclientBuilder) setOptions() {
@@ -37,6 +40,7 @@ func (builder *dtclientBuilder) setOptions() {
 	builder.addProxy()
 	builder.addNetworkZone()
 	builder.addTrustedCerts()
+	builder.addMirror()
 }
 
 func (builder *dtclientBuilder) addCertCheck() {
@@ -65,3 +69,10 @@ func (builder *dtclientBuilder) addTrustedCerts() {
 		builder.options = append(builder.options, dtclient.Certs([]byte(builder.config.TrustedCAs)))
 	}
 }
+
+func (builder *dtclientBuilder) addMirror() {
+	if builder.config.MirrorURL != "" {
+		log.Info("using the deployment API mirror", "mirror", builder.config.MirrorURL)
+		builder.options = append(builder.options, dtclient.Mirror(builder.config.MirrorURL))
+	}
+}
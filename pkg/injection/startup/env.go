@@ -28,6 +28,8 @@ type environment struct {
 	FailurePolicy string             `json:"failurePolicy"`
 	InstallerUrl  string             `json:"installerUrl"`
 
+	InstallerOs     string          `json:"installerOs"`
+	InstallerArch   string          `json:"installerArch"`
 	InstallerFlavor string          `json:"installerFlavor"`
 	InstallVersion  string          `json:"installVersion"`
 	InstallerTech   []string        `json:"installerTech"`
@@ -116,6 +118,8 @@ func (env *environment) getDataIngestFieldSetters() []func() error {
 
 func (env *environment) setOptionalFields() {
 	env.addInstallerUrl()
+	env.addInstallerOs()
+	env.addInstallerArch()
 	env.addInstallerFlavor()
 	env.addInstallVersion()
 }
@@ -151,10 +155,28 @@ func (env *environment) addFailurePolicy() error {
 	return nil
 }
 
+func (env *environment) addInstallerOs() {
+	os, _ := checkEnvVar(consts.AgentInstallerOsEnv)
+	if os == "" {
+		env.InstallerOs = arch.OsUnix
+	} else {
+		env.InstallerOs = os
+	}
+}
+
+func (env *environment) addInstallerArch() {
+	installerArch, _ := checkEnvVar(consts.AgentInstallerArchEnv)
+	if installerArch == "" {
+		env.InstallerArch = arch.Arch
+	} else {
+		env.InstallerArch = installerArch
+	}
+}
+
 func (env *environment) addInstallerFlavor() {
 	flavor, _ := checkEnvVar(consts.AgentInstallerFlavorEnv)
 	if flavor == "" {
-		env.InstallerFlavor = arch.Flavor
+		env.InstallerFlavor = arch.DefaultFlavor(env.InstallerOs, env.InstallerArch)
 	} else {
 		env.InstallerFlavor = flavor
 	}
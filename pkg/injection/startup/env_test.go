@@ -5,6 +5,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/Dynatrace/dynatrace-operator/pkg/arch"
 	"github.com/Dynatrace/dynatrace-operator/pkg/consts"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -121,6 +122,28 @@ func TestNewEnv(t *testing.T) {
 	})
 }
 
+func TestInstallerPlatform(t *testing.T) {
+	t.Run(`defaults to the platform of the binary`, func(t *testing.T) {
+		env := &environment{}
+		env.setOptionalFields()
+
+		assert.Equal(t, arch.OsUnix, env.InstallerOs)
+		assert.Equal(t, arch.Arch, env.InstallerArch)
+		assert.Equal(t, arch.Flavor, env.InstallerFlavor)
+	})
+	t.Run(`windows uses the default flavor`, func(t *testing.T) {
+		t.Setenv(consts.AgentInstallerOsEnv, arch.OsWindows)
+		t.Setenv(consts.AgentInstallerArchEnv, arch.ArchX86)
+
+		env := &environment{}
+		env.setOptionalFields()
+
+		assert.Equal(t, arch.OsWindows, env.InstallerOs)
+		assert.Equal(t, arch.ArchX86, env.InstallerArch)
+		assert.Equal(t, arch.FlavorDefault, env.InstallerFlavor)
+	})
+}
+
 func TestFailurePolicyModes(t *testing.T) {
 	modes := map[string]string{
 		failPhrase:   failPhrase,
@@ -1,11 +1,11 @@
 package startup
 
 import (
+	"context"
 	"fmt"
 	"path"
 	"path/filepath"
 
-	"github.com/Dynatrace/dynatrace-operator/pkg/arch"
 	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
 	"github.com/Dynatrace/dynatrace-operator/pkg/consts"
 	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/csi/metadata"
@@ -53,10 +53,10 @@ func NewRunner(fs afero.Fs) (*Runner, error) {
 			fs,
 			client,
 			&url.Properties{
-				Os:            dtclient.OsUnix,
+				Os:            env.InstallerOs,
 				Type:          dtclient.InstallerTypePaaS,
 				Flavor:        env.InstallerFlavor,
-				Arch:          arch.Arch,
+				Arch:          env.InstallerArch,
 				Technologies:  env.InstallerTech,
 				TargetVersion: targetVersion,
 				Url:           env.InstallerUrl,
@@ -75,7 +75,7 @@ func NewRunner(fs afero.Fs) (*Runner, error) {
 	}, nil
 }
 
-func (runner *Runner) Run() (resultedError error) {
+func (runner *Runner) Run(ctx context.Context) (resultedError error) {
 	log.Info("standalone agent init started")
 	defer runner.consumeErrorIfNecessary(&resultedError)
 
@@ -85,7 +85,7 @@ func (runner *Runner) Run() (resultedError error) {
 		}
 
 		if runner.env.Mode == consts.AgentInstallerMode {
-			if err := runner.installOneAgent(); err != nil {
+			if err := runner.installOneAgent(ctx); err != nil {
 				return err
 			}
 			log.Info("OneAgent download finished")
@@ -125,13 +125,13 @@ func (runner *Runner) setHostTenant() error {
 	return nil
 }
 
-func (runner *Runner) installOneAgent() error {
+func (runner *Runner) installOneAgent(ctx context.Context) error {
 	log.Info("downloading OneAgent")
-	_, err := runner.installer.InstallAgent(consts.AgentBinDirMount)
+	_, err := runner.installer.InstallAgent(ctx, consts.AgentBinDirMount)
 	if err != nil {
 		return err
 	}
-	processModuleConfig, err := runner.getProcessModuleConfig()
+	processModuleConfig, err := runner.getProcessModuleConfig(ctx)
 	if err != nil {
 		return err
 	}
@@ -142,8 +142,8 @@ func (runner *Runner) installOneAgent() error {
 	return nil
 }
 
-func (runner *Runner) getProcessModuleConfig() (*dtclient.ProcessModuleConfig, error) {
-	processModuleConfig, err := runner.dtclient.GetProcessModuleConfig(0)
+func (runner *Runner) getProcessModuleConfig(ctx context.Context) (*dtclient.ProcessModuleConfig, error) {
+	processModuleConfig, err := runner.dtclient.GetProcessModuleConfig(ctx, 0)
 	if err != nil {
 		return nil, err
 	}
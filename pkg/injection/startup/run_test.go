@@ -1,6 +1,8 @@
 package startup
 
 import (
+	"context"
+
 	"fmt"
 	"path/filepath"
 	"testing"
@@ -11,6 +13,7 @@ import (
 	mockedinstaller "github.com/Dynatrace/dynatrace-operator/test/mocks/pkg/injection/codemodule/installer"
 	"github.com/spf13/afero"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -75,19 +78,19 @@ func TestConsumeErrorIfNecessary(t *testing.T) {
 	runner := createMockedRunner(t)
 	t.Run("no error thrown", func(t *testing.T) {
 		runner.env.FailurePolicy = silentPhrase
-		err := runner.Run()
+		err := runner.Run(context.Background())
 		assert.Nil(t, err)
 	})
 	t.Run("error thrown, but consume error", func(t *testing.T) {
 		runner.env.K8NodeName = "" // create artificial error
 		runner.env.FailurePolicy = silentPhrase
-		err := runner.Run()
+		err := runner.Run(context.Background())
 		assert.Nil(t, err)
 	})
 	t.Run("error thrown, but don't consume error", func(t *testing.T) {
 		runner.env.K8NodeName = "" // create artificial error
 		runner.env.FailurePolicy = failPhrase
-		err := runner.Run()
+		err := runner.Run(context.Background())
 		assert.NotNil(t, err)
 	})
 }
@@ -135,49 +138,49 @@ func TestInstallOneAgent(t *testing.T) {
 		runner := createMockedRunner(t)
 		runner.fs.Create(filepath.Join(consts.AgentBinDirMount, "agent/conf/ruxitagentproc.conf"))
 		runner.dtclient.(*mockedclient.Client).
-			On("GetProcessModuleConfig", uint(0)).
+			On("GetProcessModuleConfig", mock.Anything, uint(0)).
 			Return(getTestProcessModuleConfig(), nil)
 		runner.installer.(*mockedinstaller.Installer).
-			On("InstallAgent", consts.AgentBinDirMount).
+			On("InstallAgent", mock.Anything, consts.AgentBinDirMount).
 			Return(true, nil)
 
-		err := runner.installOneAgent()
+		err := runner.installOneAgent(context.Background())
 
 		require.NoError(t, err)
 	})
 	t.Run("sad install -> install fail", func(t *testing.T) {
 		runner := createMockedRunner(t)
 		runner.installer.(*mockedinstaller.Installer).
-			On("InstallAgent", consts.AgentBinDirMount).
+			On("InstallAgent", mock.Anything, consts.AgentBinDirMount).
 			Return(false, fmt.Errorf("BOOM"))
 
-		err := runner.installOneAgent()
+		err := runner.installOneAgent(context.Background())
 
 		require.Error(t, err)
 	})
 	t.Run("sad install -> ruxitagent update fail", func(t *testing.T) {
 		runner := createMockedRunner(t)
 		runner.dtclient.(*mockedclient.Client).
-			On("GetProcessModuleConfig", uint(0)).
+			On("GetProcessModuleConfig", mock.Anything, uint(0)).
 			Return(getTestProcessModuleConfig(), nil)
 		runner.installer.(*mockedinstaller.Installer).
-			On("InstallAgent", consts.AgentBinDirMount).
+			On("InstallAgent", mock.Anything, consts.AgentBinDirMount).
 			Return(true, nil)
 
-		err := runner.installOneAgent()
+		err := runner.installOneAgent(context.Background())
 
 		require.Error(t, err)
 	})
 	t.Run("sad install -> ruxitagent endpoint fail", func(t *testing.T) {
 		runner := createMockedRunner(t)
 		runner.dtclient.(*mockedclient.Client).
-			On("GetProcessModuleConfig", uint(0)).
+			On("GetProcessModuleConfig", mock.Anything, uint(0)).
 			Return(&dtclient.ProcessModuleConfig{}, fmt.Errorf("BOOM"))
 		runner.installer.(*mockedinstaller.Installer).
-			On("InstallAgent", consts.AgentBinDirMount).
+			On("InstallAgent", mock.Anything, consts.AgentBinDirMount).
 			Return(true, nil)
 
-		err := runner.installOneAgent()
+		err := runner.installOneAgent(context.Background())
 
 		require.Error(t, err)
 	})
@@ -188,14 +191,14 @@ func TestRun(t *testing.T) {
 	runner.env.OneAgentInjected = true
 	runner.env.DataIngestInjected = true
 	runner.dtclient.(*mockedclient.Client).
-		On("GetProcessModuleConfig", uint(0)).
+		On("GetProcessModuleConfig", mock.Anything, uint(0)).
 		Return(getTestProcessModuleConfig(), nil)
 
 	t.Run("no install, just config generation", func(t *testing.T) {
 		runner.fs = prepReadOnlyCSIFilesystem(t, afero.NewMemMapFs())
 		runner.env.Mode = consts.AgentCsiMode
 
-		err := runner.Run()
+		err := runner.Run(context.Background())
 
 		require.NoError(t, err)
 		assertIfAgentFilesExists(t, *runner)
@@ -204,13 +207,13 @@ func TestRun(t *testing.T) {
 	})
 	t.Run("install + config generation", func(t *testing.T) {
 		runner.installer.(*mockedinstaller.Installer).
-			On("InstallAgent", consts.AgentBinDirMount).
+			On("InstallAgent", mock.Anything, consts.AgentBinDirMount).
 			Return(true, nil)
 		runner.fs = prepReadOnlyCSIFilesystem(t, afero.NewMemMapFs())
 		runner.env.Mode = consts.AgentInstallerMode
 		runner.fs.Create(filepath.Join(consts.AgentBinDirMount, "agent/conf/ruxitagentproc.conf"))
 
-		err := runner.Run()
+		err := runner.Run(context.Background())
 
 		require.NoError(t, err)
 		assertIfAgentFilesExists(t, *runner)
@@ -280,10 +283,10 @@ func TestGetProcessModuleConfig(t *testing.T) {
 	t.Run("error if api call fails", func(t *testing.T) {
 		runner := createMockedRunner(t)
 		runner.dtclient.(*mockedclient.Client).
-			On("GetProcessModuleConfig", uint(0)).
+			On("GetProcessModuleConfig", mock.Anything, uint(0)).
 			Return(&dtclient.ProcessModuleConfig{}, fmt.Errorf("BOOM"))
 
-		config, err := runner.getProcessModuleConfig()
+		config, err := runner.getProcessModuleConfig(context.Background())
 		require.Error(t, err)
 		require.Nil(t, config)
 	})
@@ -293,10 +296,10 @@ func TestGetProcessModuleConfig(t *testing.T) {
 		runner := createMockedRunner(t)
 		runner.config.Proxy = proxy
 		runner.dtclient.(*mockedclient.Client).
-			On("GetProcessModuleConfig", uint(0)).
+			On("GetProcessModuleConfig", mock.Anything, uint(0)).
 			Return(getTestProcessModuleConfig(), nil)
 
-		config, err := runner.getProcessModuleConfig()
+		config, err := runner.getProcessModuleConfig(context.Background())
 		require.NoError(t, err)
 		require.NotNil(t, config)
 
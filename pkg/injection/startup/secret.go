@@ -20,6 +20,7 @@ type SecretConfig struct {
 	NetworkZone   string `json:"networkZone"`
 	TrustedCAs    string `json:"trustedCAs"`
 	SkipCertCheck bool   `json:"skipCertCheck"`
+	MirrorURL     string `json:"mirrorUrl,omitempty"`
 
 	// For the injection
 	TenantUUID          string            `json:"tenantUUID"`
@@ -2,13 +2,11 @@ package registry
 
 import (
 	"context"
-	"crypto/tls"
-	"crypto/x509"
 	"fmt"
 	"net/http"
-	"net/url"
 
 	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/clients/httptransport"
 	"github.com/Dynatrace/dynatrace-operator/pkg/oci/dockerkeychain"
 	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/name"
@@ -16,7 +14,6 @@ import (
 	"github.com/google/go-containerregistry/pkg/v1/remote"
 	"github.com/opencontainers/go-digest"
 	"github.com/pkg/errors"
-	"golang.org/x/net/http/httpproxy"
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -26,6 +23,7 @@ type ClientBuilder func(options ...func(*Client)) (ImageGetter, error)
 type ImageGetter interface {
 	GetImageVersion(ctx context.Context, imageName string) (ImageVersion, error)
 	PullImageInfo(ctx context.Context, imageName string) (*containerv1.Image, error)
+	ListTags(ctx context.Context, repository string) ([]string, error)
 }
 
 type ImageVersion struct {
@@ -161,89 +159,40 @@ func (c *Client) PullImageInfo(ctx context.Context, imageName string) (*containe
 	return &image, nil
 }
 
-func BuildImageIDWithTagAndDigest(taggedRef name.Tag, digest digest.Digest) string {
-	return fmt.Sprintf("%s%s%s", taggedRef.String(), DigestDelimiter, digest.String())
-}
-
-func addProxy(transport *http.Transport, proxy string, noProxy string) (*http.Transport, error) {
-	proxyUrl, err := url.Parse(proxy)
+// ListTags returns all tags of the given repository, e.g. `registry.com/linux/oneagent`
+func (c *Client) ListTags(ctx context.Context, repository string) ([]string, error) {
+	repo, err := name.NewRepository(repository)
 	if err != nil {
-		return nil, errors.WithStack(err)
+		return nil, errors.WithMessagef(err, "parsing repository %q", repository)
 	}
 
-	proxyConfig := httpproxy.Config{
-		HTTPProxy:  proxyUrl.String(),
-		HTTPSProxy: proxyUrl.String(),
-		NoProxy:    noProxy,
+	options := []remote.Option{
+		remote.WithContext(ctx),
+		remote.WithTransport(c.transport),
 	}
-	transport.Proxy = proxyWrapper(proxyConfig)
-	return transport, nil
-}
-
-func proxyWrapper(proxyConfig httpproxy.Config) func(req *http.Request) (*url.URL, error) {
-	return func(req *http.Request) (*url.URL, error) {
-		return proxyConfig.ProxyFunc()(req.URL)
+	if c.keychain != nil {
+		options = append(options, remote.WithAuthFromKeychain(c.keychain))
 	}
-}
 
-func addCertificates(transport *http.Transport, trustedCAs []byte) (*http.Transport, error) {
-	rootCAs := x509.NewCertPool()
-	if ok := rootCAs.AppendCertsFromPEM(trustedCAs); !ok {
-		return nil, errors.New("failed to append custom certs")
-	}
-	if transport.TLSClientConfig == nil {
-		transport.TLSClientConfig = &tls.Config{} // nolint:gosec
+	tags, err := remote.List(repo, options...)
+	if err != nil {
+		return nil, errors.WithMessagef(err, "listing tags of %q", repository)
 	}
-	transport.TLSClientConfig.RootCAs = rootCAs
 
-	return transport, nil
+	return tags, nil
 }
 
-func addSkipCertCheck(transport *http.Transport, skipCertCheck bool) *http.Transport {
-	if transport.TLSClientConfig == nil {
-		transport.TLSClientConfig = &tls.Config{} // nolint:gosec
-	}
-	transport.TLSClientConfig.InsecureSkipVerify = skipCertCheck
-
-	return transport
+func BuildImageIDWithTagAndDigest(taggedRef name.Tag, digest digest.Digest) string {
+	return fmt.Sprintf("%s%s%s", taggedRef.String(), DigestDelimiter, digest.String())
 }
 
-// PrepareTransportForDynaKube creates default http transport and add proxy or trustedCAs if any
+// PrepareTransportForDynaKube adds the proxy, the trustedCAs and the skipCertCheck setting of the DynaKube to the given transport.
+// Long-lived clients should use the transport of the httptransport.Shared cache instead, which is only rebuilt if these settings change.
 func PrepareTransportForDynaKube(ctx context.Context, apiReader client.Reader, transport *http.Transport, dynakube *dynatracev1beta1.DynaKube) (*http.Transport, error) {
-	var (
-		proxy      string
-		trustedCAs []byte
-		err        error
-	)
-	if dynakube.HasProxy() {
-		proxy, err = dynakube.Proxy(ctx, apiReader)
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	if dynakube.Spec.TrustedCAs != "" {
-		trustedCAs, err = dynakube.TrustedCAs(ctx, apiReader)
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	if proxy != "" {
-		transport, err = addProxy(transport, proxy, dynakube.FeatureNoProxy())
-		if err != nil {
-			return nil, errors.WithMessage(err, "failed to add proxy to default transport")
-		}
-	}
-
-	if len(trustedCAs) > 0 {
-		transport, err = addCertificates(transport, trustedCAs)
-		if err != nil {
-			return nil, err
-		}
+	settings, err := httptransport.NewSettings(ctx, apiReader, dynakube)
+	if err != nil {
+		return nil, err
 	}
 
-	transport = addSkipCertCheck(transport, dynakube.Spec.SkipCertCheck)
-
-	return transport, nil
+	return settings.Apply(transport)
 }
@@ -77,6 +77,61 @@ func (_c *MockImageGetter_GetImageVersion_Call) RunAndReturn(run func(context.Co
 	return _c
 }
 
+// ListTags provides a mock function with given fields: ctx, repository
+func (_m *MockImageGetter) ListTags(ctx context.Context, repository string) ([]string, error) {
+	ret := _m.Called(ctx, repository)
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]string, error)); ok {
+		return rf(ctx, repository)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, repository)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, repository)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockImageGetter_ListTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTags'
+type MockImageGetter_ListTags_Call struct {
+	*mock.Call
+}
+
+// ListTags is a helper method to define mock.On call
+//   - ctx context.Context
+//   - repository string
+func (_e *MockImageGetter_Expecter) ListTags(ctx interface{}, repository interface{}) *MockImageGetter_ListTags_Call {
+	return &MockImageGetter_ListTags_Call{Call: _e.mock.On("ListTags", ctx, repository)}
+}
+
+func (_c *MockImageGetter_ListTags_Call) Run(run func(ctx context.Context, repository string)) *MockImageGetter_ListTags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockImageGetter_ListTags_Call) Return(_a0 []string, _a1 error) *MockImageGetter_ListTags_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockImageGetter_ListTags_Call) RunAndReturn(run func(context.Context, string) ([]string, error)) *MockImageGetter_ListTags_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // PullImageInfo provides a mock function with given fields: ctx, imageName
 func (_m *MockImageGetter) PullImageInfo(ctx context.Context, imageName string) (*v1.Image, error) {
 	ret := _m.Called(ctx, imageName)
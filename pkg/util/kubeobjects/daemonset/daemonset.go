@@ -48,6 +48,18 @@ func recreateDaemonSet(kubernetesClient client.Client, logger logr.Logger, curre
 	return true, kubernetesClient.Create(context.TODO(), desiredDaemonSet)
 }
 
+// IsUpdateNeeded returns true if the daemonset already exists and differs from the desired one.
+// Creating a missing daemonset is not considered an update.
+func IsUpdateNeeded(kubernetesClient client.Client, desiredDaemonSet *appsv1.DaemonSet) (bool, error) {
+	current, err := getDaemonSet(kubernetesClient, desiredDaemonSet)
+	if err != nil && k8serrors.IsNotFound(errors.Cause(err)) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return hasher.IsAnnotationDifferent(current, desiredDaemonSet), nil
+}
+
 func getDaemonSet(kubernetesClient client.Client, desiredDaemonSet *appsv1.DaemonSet) (*appsv1.DaemonSet, error) {
 	var actualDaemonSet appsv1.DaemonSet
 	err := kubernetesClient.Get(
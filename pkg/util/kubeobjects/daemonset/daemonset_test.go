@@ -73,6 +73,36 @@ func TestCreateOrUpdateDaemonSet(t *testing.T) {
 	})
 }
 
+func TestIsUpdateNeeded(t *testing.T) {
+	const namespaceName = "dynatrace"
+	const daemonsetName = "my-daemonset"
+
+	desiredDaemonSet := createTestDaemonSetWithMatchLabels(daemonsetName, namespaceName, map[string]string{hasher.AnnotationHash: "new"}, nil)
+
+	t.Run("no update needed when not exists", func(t *testing.T) {
+		needed, err := IsUpdateNeeded(fake.NewClient(), &desiredDaemonSet)
+
+		require.NoError(t, err)
+		assert.False(t, needed)
+	})
+	t.Run("update needed when exists and changed", func(t *testing.T) {
+		oldDaemonSet := createTestDaemonSetWithMatchLabels(daemonsetName, namespaceName, map[string]string{hasher.AnnotationHash: "old"}, nil)
+
+		needed, err := IsUpdateNeeded(fake.NewClient(&oldDaemonSet), &desiredDaemonSet)
+
+		require.NoError(t, err)
+		assert.True(t, needed)
+	})
+	t.Run("no update needed when exists and not changed", func(t *testing.T) {
+		currentDaemonSet := createTestDaemonSetWithMatchLabels(daemonsetName, namespaceName, map[string]string{hasher.AnnotationHash: "new"}, nil)
+
+		needed, err := IsUpdateNeeded(fake.NewClient(&currentDaemonSet), &desiredDaemonSet)
+
+		require.NoError(t, err)
+		assert.False(t, needed)
+	})
+}
+
 func createTestDaemonSetWithMatchLabels(name, namespace string, annotations, matchLabels map[string]string) appsv1.DaemonSet {
 	return appsv1.DaemonSet{
 		ObjectMeta: metav1.ObjectMeta{
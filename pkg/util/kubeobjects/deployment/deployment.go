@@ -74,6 +74,18 @@ func CreateOrUpdateDeployment(c client.Client, logger logr.Logger, desiredDeploy
 	return true, err
 }
 
+// IsUpdateNeeded returns true if the deployment already exists and differs from the desired one.
+// Creating a missing deployment is not considered an update.
+func IsUpdateNeeded(c client.Client, desiredDeployment *appsv1.Deployment) (bool, error) {
+	current, err := getDeployment(c, desiredDeployment)
+	if err != nil && k8serrors.IsNotFound(errors.Cause(err)) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return hasher.IsAnnotationDifferent(current, desiredDeployment), nil
+}
+
 func getDeployment(c client.Client, desiredDeployment *appsv1.Deployment) (*appsv1.Deployment, error) {
 	var actualDaemonSet appsv1.Deployment
 	err := c.Get(
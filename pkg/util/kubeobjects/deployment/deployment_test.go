@@ -127,3 +127,33 @@ func TestCreateOrUpdateDeployment(t *testing.T) {
 		assert.Equal(t, newMatchLabels, actualDepl.Spec.Selector.MatchLabels)
 	})
 }
+
+func TestIsUpdateNeeded(t *testing.T) {
+	const namespaceName = "dynatrace"
+	const deploymentName = "my-deployment"
+
+	desiredDepl := createTestDeploymentWithMatchLabels(deploymentName, namespaceName, map[string]string{hasher.AnnotationHash: "new"}, nil)
+
+	t.Run("no update needed when not exists", func(t *testing.T) {
+		needed, err := IsUpdateNeeded(fake.NewClient(), &desiredDepl)
+
+		require.NoError(t, err)
+		assert.False(t, needed)
+	})
+	t.Run("update needed when exists and changed", func(t *testing.T) {
+		oldDepl := createTestDeploymentWithMatchLabels(deploymentName, namespaceName, map[string]string{hasher.AnnotationHash: "old"}, nil)
+
+		needed, err := IsUpdateNeeded(fake.NewClient(&oldDepl), &desiredDepl)
+
+		require.NoError(t, err)
+		assert.True(t, needed)
+	})
+	t.Run("no update needed when exists and not changed", func(t *testing.T) {
+		currentDepl := createTestDeploymentWithMatchLabels(deploymentName, namespaceName, map[string]string{hasher.AnnotationHash: "new"}, nil)
+
+		needed, err := IsUpdateNeeded(fake.NewClient(&currentDepl), &desiredDepl)
+
+		require.NoError(t, err)
+		assert.False(t, needed)
+	})
+}
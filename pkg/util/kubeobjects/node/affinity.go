@@ -12,13 +12,23 @@ const (
 	arm64   = "arm64"
 	ppc64le = "ppc64le"
 	linux   = "linux"
+	windows = "windows"
 )
 
 func AffinityNodeRequirementForSupportedArches() []corev1.NodeSelectorRequirement {
-	return affinityNodeRequirementsForArches(amd64, arm64, ppc64le)
+	return AffinityNodeRequirementForSupportedArchesOnOs(linux)
 }
 
-func affinityNodeRequirementsForArches(arches ...string) []corev1.NodeSelectorRequirement {
+// AffinityNodeRequirementForSupportedArchesOnOs returns the requirements for the supported arches of the operating system,
+// given as value of the kubernetes.io/os label. Unknown operating systems are treated as linux.
+func AffinityNodeRequirementForSupportedArchesOnOs(os string) []corev1.NodeSelectorRequirement {
+	if os == windows {
+		return affinityNodeRequirementsForArches(windows, amd64)
+	}
+	return affinityNodeRequirementsForArches(linux, amd64, arm64, ppc64le)
+}
+
+func affinityNodeRequirementsForArches(os string, arches ...string) []corev1.NodeSelectorRequirement {
 	return []corev1.NodeSelectorRequirement{
 		{
 			Key:      kubernetesArch,
@@ -28,7 +38,7 @@ func affinityNodeRequirementsForArches(arches ...string) []corev1.NodeSelectorRe
 		{
 			Key:      kubernetesOS,
 			Operator: corev1.NodeSelectorOpIn,
-			Values:   []string{linux},
+			Values:   []string{os},
 		},
 	}
 }
@@ -8,14 +8,27 @@ import (
 )
 
 func TestAffinityNodeRequirement(t *testing.T) {
-	assert.Equal(t, AffinityNodeRequirementForSupportedArches(), affinityNodeRequirementsForArches(amd64, arm64, ppc64le))
-	assert.Contains(t, AffinityNodeRequirementForSupportedArches(), linuxRequirement())
+	assert.Equal(t, AffinityNodeRequirementForSupportedArches(), affinityNodeRequirementsForArches(linux, amd64, arm64, ppc64le))
+	assert.Contains(t, AffinityNodeRequirementForSupportedArches(), osRequirement(linux))
 }
 
-func linuxRequirement() corev1.NodeSelectorRequirement {
+func TestAffinityNodeRequirementOnOs(t *testing.T) {
+	assert.Equal(t, AffinityNodeRequirementForSupportedArches(), AffinityNodeRequirementForSupportedArchesOnOs(""))
+	assert.Equal(t, AffinityNodeRequirementForSupportedArches(), AffinityNodeRequirementForSupportedArchesOnOs(linux))
+
+	windowsRequirements := AffinityNodeRequirementForSupportedArchesOnOs(windows)
+	assert.Contains(t, windowsRequirements, osRequirement(windows))
+	assert.Contains(t, windowsRequirements, corev1.NodeSelectorRequirement{
+		Key:      kubernetesArch,
+		Operator: corev1.NodeSelectorOpIn,
+		Values:   []string{amd64},
+	})
+}
+
+func osRequirement(os string) corev1.NodeSelectorRequirement {
 	return corev1.NodeSelectorRequirement{
 		Key:      kubernetesOS,
 		Operator: corev1.NodeSelectorOpIn,
-		Values:   []string{linux},
+		Values:   []string{os},
 	}
 }
@@ -0,0 +1,259 @@
+package version
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// wildcard marks a part of a partial version that was omitted or set to x/*
+const wildcard = -1
+
+var (
+	candidateVersionRegex = regexp.MustCompile(`^(\d+)\.(\d+)\.(\d+)(?:\.(\d+-\d+))?$`)
+	constraintTermRegex   = regexp.MustCompile(`^(=|!=|>=|<=|>|<|~|\^)?v?(\d+|[xX*])(?:\.(\d+|[xX*]))?(?:\.(\d+|[xX*]))?$`)
+	operatorSpacingRegex  = regexp.MustCompile(`(!=|>=|<=|=|>|<|~|\^)\s+`)
+)
+
+// Constraint is a parsed semantic version constraint.
+// Terms separated by spaces or commas must all match, alternatives are separated by ||.
+// Only major, minor and release are compared, the build date is ignored.
+type Constraint struct {
+	raw          string
+	alternatives [][]versionRange
+}
+
+// versionRange is the half-open interval [lower, upper), a nil bound is unbounded
+type versionRange struct {
+	lower   *SemanticVersion
+	upper   *SemanticVersion
+	negated bool
+}
+
+// ParseConstraint parses constraints like `>=1.280 <1.290`, `~1.285.0`, `^1.280` or `1.285.x || 1.287.x`.
+// An empty constraint or `*` matches every version.
+func ParseConstraint(constraint string) (Constraint, error) {
+	parsed := Constraint{raw: strings.TrimSpace(constraint)}
+	if parsed.raw == "" {
+		return parsed, nil
+	}
+
+	for _, alternative := range strings.Split(parsed.raw, "||") {
+		terms := strings.FieldsFunc(normalizeOperators(alternative), func(r rune) bool {
+			return r == ' ' || r == ','
+		})
+		if len(terms) == 0 {
+			return Constraint{}, errors.Errorf("empty alternative in version constraint %q", constraint)
+		}
+
+		ranges := make([]versionRange, 0, len(terms))
+		for _, term := range terms {
+			parsedRange, err := parseTerm(term)
+			if err != nil {
+				return Constraint{}, errors.WithMessagef(err, "invalid version constraint %q", constraint)
+			}
+			ranges = append(ranges, parsedRange)
+		}
+		parsed.alternatives = append(parsed.alternatives, ranges)
+	}
+	return parsed, nil
+}
+
+// normalizeOperators removes the spaces between an operator and its version, so `>= 1.280` is handled like `>=1.280`
+func normalizeOperators(alternative string) string {
+	return operatorSpacingRegex.ReplaceAllString(alternative, "$1")
+}
+
+func (constraint Constraint) String() string {
+	return constraint.raw
+}
+
+// Matches returns true if the version satisfies at least one alternative of the constraint
+func (constraint Constraint) Matches(version SemanticVersion) bool {
+	if len(constraint.alternatives) == 0 {
+		return true
+	}
+
+	for _, alternative := range constraint.alternatives {
+		if matchesAll(alternative, version) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesAll(ranges []versionRange, version SemanticVersion) bool {
+	for _, versionRange := range ranges {
+		if !versionRange.contains(version) {
+			return false
+		}
+	}
+	return true
+}
+
+func (versionRange versionRange) contains(version SemanticVersion) bool {
+	contained := (versionRange.lower == nil || compareRelease(version, *versionRange.lower) >= 0) &&
+		(versionRange.upper == nil || compareRelease(version, *versionRange.upper) < 0)
+	return contained != versionRange.negated
+}
+
+func parseTerm(term string) (versionRange, error) {
+	match := constraintTermRegex.FindStringSubmatch(term)
+	if match == nil {
+		return versionRange{}, errors.Errorf("malformed term %q", term)
+	}
+
+	operator := match[1]
+	parts := [3]int{}
+	for i, part := range match[2:] {
+		parts[i] = parsePart(part)
+		if i > 0 && parts[i-1] == wildcard {
+			parts[i] = wildcard
+		}
+	}
+	major, minor, release := parts[0], parts[1], parts[2]
+
+	if major == wildcard {
+		if operator == "" || operator == "=" || operator == ">=" || operator == "<=" {
+			return versionRange{}, nil
+		}
+		return versionRange{}, errors.Errorf("wildcard can't be used with operator %q", operator)
+	}
+
+	// lower bound of the partial version, e.g. 1.280.x => 1.280.0
+	floor := SemanticVersion{major: major, minor: max(minor, 0), release: max(release, 0)}
+	// first version after the partial version, e.g. 1.280.x => 1.281.0
+	ceiling := nextVersion(major, minor, release)
+
+	switch operator {
+	case "", "=":
+		return versionRange{lower: &floor, upper: &ceiling}, nil
+	case "!=":
+		return versionRange{lower: &floor, upper: &ceiling, negated: true}, nil
+	case ">":
+		return versionRange{lower: &ceiling}, nil
+	case ">=":
+		return versionRange{lower: &floor}, nil
+	case "<":
+		return versionRange{upper: &floor}, nil
+	case "<=":
+		return versionRange{upper: &ceiling}, nil
+	case "~":
+		// ~1.280.5 => >=1.280.5 <1.281.0, ~1 => >=1.0.0 <2.0.0
+		upper := SemanticVersion{major: major + 1}
+		if minor != wildcard {
+			upper = SemanticVersion{major: major, minor: minor + 1}
+		}
+		return versionRange{lower: &floor, upper: &upper}, nil
+	case "^":
+		// ^1.280.5 => >=1.280.5 <2.0.0, ^0.5 => >=0.5.0 <0.6.0
+		upper := SemanticVersion{major: major + 1}
+		if major == 0 && minor != wildcard {
+			upper = SemanticVersion{major: 0, minor: minor + 1}
+		}
+		return versionRange{lower: &floor, upper: &upper}, nil
+	}
+	return versionRange{}, errors.Errorf("unknown operator %q", operator)
+}
+
+func parsePart(part string) int {
+	value, err := strconv.Atoi(part)
+	if err != nil {
+		return wildcard
+	}
+	return value
+}
+
+func nextVersion(major, minor, release int) SemanticVersion {
+	switch {
+	case minor == wildcard:
+		return SemanticVersion{major: major + 1}
+	case release == wildcard:
+		return SemanticVersion{major: major, minor: minor + 1}
+	default:
+		return SemanticVersion{major: major, minor: minor, release: release + 1}
+	}
+}
+
+func compareRelease(a SemanticVersion, b SemanticVersion) int {
+	return CompareSemanticVersions(
+		SemanticVersion{major: a.major, minor: a.minor, release: a.release},
+		SemanticVersion{major: b.major, minor: b.minor, release: b.release},
+	)
+}
+
+// parseCandidateVersion parses versions with or without build date, like 1.280.3.20231201-123456 or 1.280.3
+func parseCandidateVersion(versionString string) (SemanticVersion, bool) {
+	match := candidateVersionRegex.FindStringSubmatch(versionString)
+	if match == nil {
+		return SemanticVersion{}, false
+	}
+
+	major, _ := strconv.Atoi(match[1])
+	minor, _ := strconv.Atoi(match[2])
+	release, _ := strconv.Atoi(match[3])
+	return SemanticVersion{major: major, minor: minor, release: release, timestamp: match[4]}, true
+}
+
+// SelectVersion chooses the newest version out of the candidates that satisfies the constraint.
+// If minorVersionsBehind is greater than 0, the newest version of the minor version that many minor versions
+// behind the newest matching one is chosen instead (N-minus-k).
+// Candidates that are not a version, like `latest`, are ignored.
+// Returns the chosen candidate as it was given and a human-readable reason for the choice.
+func SelectVersion(candidates []string, constraint Constraint, minorVersionsBehind int) (string, string, error) {
+	type candidate struct {
+		raw     string
+		version SemanticVersion
+	}
+
+	matching := make([]candidate, 0, len(candidates))
+	for _, raw := range candidates {
+		version, ok := parseCandidateVersion(raw)
+		if ok && constraint.Matches(version) {
+			matching = append(matching, candidate{raw: raw, version: version})
+		}
+	}
+	if len(matching) == 0 {
+		return "", "", errors.Errorf("no version matches the constraint %q", constraint)
+	}
+
+	sort.SliceStable(matching, func(i, j int) bool {
+		return CompareSemanticVersions(matching[i].version, matching[j].version) > 0
+	})
+
+	selected := matching[0]
+	minorVersionsSeen := 0
+	for _, current := range matching {
+		if current.version.major != selected.version.major || current.version.minor != selected.version.minor {
+			minorVersionsSeen++
+			selected = current
+		}
+		if minorVersionsSeen == minorVersionsBehind {
+			break
+		}
+	}
+	if minorVersionsSeen < minorVersionsBehind {
+		return "", "", errors.Errorf("only %d minor versions are available that match the constraint %q, can't stay %d minor versions behind", minorVersionsSeen+1, constraint, minorVersionsBehind)
+	}
+
+	return selected.raw, selectionReason(constraint, minorVersionsBehind, matching[0].raw), nil
+}
+
+func selectionReason(constraint Constraint, minorVersionsBehind int, latest string) string {
+	reason := "latest version"
+	if minorVersionsBehind > 0 {
+		unit := "versions"
+		if minorVersionsBehind == 1 {
+			unit = "version"
+		}
+		reason = fmt.Sprintf("%d minor %s behind latest version %s", minorVersionsBehind, unit, latest)
+	}
+	if constraint.String() != "" {
+		reason = fmt.Sprintf("%s matching constraint %q", reason, constraint)
+	}
+	return reason
+}
@@ -0,0 +1,114 @@
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConstraintMatches(t *testing.T) {
+	testCases := []struct {
+		constraint  string
+		matching    []string
+		notMatching []string
+	}{
+		{"", []string{"0.0.1", "1.280.0.20231201-123456"}, nil},
+		{"*", []string{"1.280.0"}, nil},
+		{"1.280", []string{"1.280.0", "1.280.99.20231201-123456"}, []string{"1.279.99", "1.281.0"}},
+		{"=1.280.3", []string{"1.280.3", "1.280.3.20231201-123456"}, []string{"1.280.2", "1.280.4"}},
+		{"1.280.x", []string{"1.280.5"}, []string{"1.281.0"}},
+		{"!=1.280", []string{"1.279.9", "1.281.0"}, []string{"1.280.1"}},
+		{">1.280", []string{"1.281.0"}, []string{"1.280.99"}},
+		{">1.280.3", []string{"1.280.4"}, []string{"1.280.3"}},
+		{">=1.280", []string{"1.280.0", "2.0.0"}, []string{"1.279.99"}},
+		{"<1.280", []string{"1.279.99"}, []string{"1.280.0"}},
+		{"<=1.280", []string{"1.280.99"}, []string{"1.281.0"}},
+		{"~1.280.5", []string{"1.280.5", "1.280.9"}, []string{"1.280.4", "1.281.0"}},
+		{"~1", []string{"1.0.0", "1.999.0"}, []string{"2.0.0"}},
+		{"^1.280.5", []string{"1.280.5", "1.300.0"}, []string{"1.280.4", "2.0.0"}},
+		{"^0.5", []string{"0.5.1"}, []string{"0.6.0"}},
+		{">= 1.280, < 1.290", []string{"1.280.0", "1.289.9"}, []string{"1.279.0", "1.290.0"}},
+		{"1.280.x || 1.285.x", []string{"1.280.1", "1.285.2"}, []string{"1.282.0"}},
+	}
+
+	for _, testCase := range testCases {
+		constraint, err := ParseConstraint(testCase.constraint)
+		require.NoError(t, err, testCase.constraint)
+
+		for _, raw := range testCase.matching {
+			version, ok := parseCandidateVersion(raw)
+			require.True(t, ok, raw)
+			assert.True(t, constraint.Matches(version), "%q should match %q", testCase.constraint, raw)
+		}
+		for _, raw := range testCase.notMatching {
+			version, ok := parseCandidateVersion(raw)
+			require.True(t, ok, raw)
+			assert.False(t, constraint.Matches(version), "%q should not match %q", testCase.constraint, raw)
+		}
+	}
+}
+
+func TestParseConstraint(t *testing.T) {
+	t.Run("invalid constraints", func(t *testing.T) {
+		for _, constraint := range []string{"abc", "1.2.3.4", ">=", "1.280 ||", ">x", "=>1.280"} {
+			_, err := ParseConstraint(constraint)
+			assert.Error(t, err, constraint)
+		}
+	})
+	t.Run("keeps the raw constraint", func(t *testing.T) {
+		constraint, err := ParseConstraint(" >=1.280 ")
+		require.NoError(t, err)
+		assert.Equal(t, ">=1.280", constraint.String())
+	})
+}
+
+func TestSelectVersion(t *testing.T) {
+	candidates := []string{
+		"latest",
+		"1.279.10.20231101-100000",
+		"1.280.2.20231115-100000",
+		"1.280.10.20231120-100000",
+		"1.281.0.20231201-100000",
+		"1.282.5.20231215-100000",
+		"1.282.3.20231210-100000",
+	}
+
+	t.Run("latest version without constraint", func(t *testing.T) {
+		constraint, _ := ParseConstraint("")
+		selected, reason, err := SelectVersion(candidates, constraint, 0)
+		require.NoError(t, err)
+		assert.Equal(t, "1.282.5.20231215-100000", selected)
+		assert.Equal(t, "latest version", reason)
+	})
+	t.Run("latest version matching constraint", func(t *testing.T) {
+		constraint, _ := ParseConstraint("<1.282")
+		selected, reason, err := SelectVersion(candidates, constraint, 0)
+		require.NoError(t, err)
+		assert.Equal(t, "1.281.0.20231201-100000", selected)
+		assert.Equal(t, `latest version matching constraint "<1.282"`, reason)
+	})
+	t.Run("N minus k", func(t *testing.T) {
+		constraint, _ := ParseConstraint("")
+		selected, reason, err := SelectVersion(candidates, constraint, 2)
+		require.NoError(t, err)
+		assert.Equal(t, "1.280.10.20231120-100000", selected)
+		assert.Equal(t, "2 minor versions behind latest version 1.282.5.20231215-100000", reason)
+	})
+	t.Run("N minus k combined with constraint", func(t *testing.T) {
+		constraint, _ := ParseConstraint(">=1.280 <1.282")
+		selected, _, err := SelectVersion(candidates, constraint, 1)
+		require.NoError(t, err)
+		assert.Equal(t, "1.280.10.20231120-100000", selected)
+	})
+	t.Run("error if nothing matches", func(t *testing.T) {
+		constraint, _ := ParseConstraint(">=2")
+		_, _, err := SelectVersion(candidates, constraint, 0)
+		require.Error(t, err)
+	})
+	t.Run("error if not enough minor versions", func(t *testing.T) {
+		constraint, _ := ParseConstraint("1.281.x || 1.282.x")
+		_, _, err := SelectVersion(candidates, constraint, 2)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,180 @@
+package pod_mutator
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/dynatraceevents"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/token"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// dynatraceEventTimeout limits how long sending an event to Dynatrace may take, as it runs detached from the admission request
+	dynatraceEventTimeout = 30 * time.Second
+
+	// injectionFailureQueueSize limits the events waiting to be sent, further events are dropped
+	injectionFailureQueueSize = 100
+
+	// injectionFailureDeduplicationWindow is the time within which only one event is sent for failures of the same owner with the same reason
+	injectionFailureDeduplicationWindow = 10 * time.Minute
+)
+
+// sendInjectionFailureEvent queues the failed injection to be sent to Dynatrace in the background, so the admission response isn't delayed
+func (webhook *podMutatorWebhook) sendInjectionFailureEvent(dynakube dynatracev1beta1.DynaKube, pod *corev1.Pod, err error) {
+	if !dynakube.FeatureInjectionFailureEvents() {
+		return
+	}
+
+	webhook.injectionFailures.add(injectionFailure{
+		dynakube: dynakube,
+		pod:      pod.DeepCopy(),
+		err:      err,
+	})
+}
+
+func (webhook *podMutatorWebhook) ingestInjectionFailureEvent(ctx context.Context, dynakube *dynatracev1beta1.DynaKube, pod *corev1.Pod, injectionErr error) {
+	tokens, err := token.NewReader(webhook.apiReader, dynakube).ReadTokens(ctx)
+	if err != nil {
+		log.Info("failed to read tokens, injection failure event not sent", "dynakube", dynakube.Name, "error", err.Error())
+		return
+	}
+
+	dtc, err := webhook.dynatraceClientBuilder.
+		SetContext(ctx).
+		SetDynakube(*dynakube).
+		SetTokens(tokens).
+		Build()
+	if err != nil {
+		log.Info("failed to create Dynatrace client, injection failure event not sent", "dynakube", dynakube.Name, "error", err.Error())
+		return
+	}
+
+	dynatraceevents.NewSender(dtc, dynakube).SendInjectionFailure(ctx, pod, injectionErr)
+}
+
+func (webhook *podMutatorWebhook) ingestQueuedInjectionFailure(ctx context.Context, failure injectionFailure) {
+	webhook.ingestInjectionFailureEvent(ctx, &failure.dynakube, failure.pod, failure.err)
+}
+
+type injectionFailure struct {
+	dynakube dynatracev1beta1.DynaKube
+	pod      *corev1.Pod
+	err      error
+}
+
+// injectionFailureKey identifies failures that are reported by a single event, e.g. the failures of all pods of a deployment
+type injectionFailureKey struct {
+	namespace string
+	owner     string
+	reason    string
+}
+
+func newInjectionFailureKey(failure injectionFailure) injectionFailureKey {
+	owner := failure.pod.Name
+	if owner == "" {
+		owner = failure.pod.GenerateName
+	}
+
+	if controller := metav1.GetControllerOf(failure.pod); controller != nil {
+		owner = controller.Kind + "/" + controller.Name
+	}
+
+	return injectionFailureKey{
+		namespace: failure.pod.Namespace,
+		owner:     owner,
+		reason:    failure.err.Error(),
+	}
+}
+
+// injectionFailureQueue sends the injection failure events one after another, so failing admissions can't pile up requests to Dynatrace.
+// Failures of the same owner with the same reason are only sent once within the deduplication window and failures are dropped if the queue is full.
+type injectionFailureQueue struct {
+	failures chan injectionFailure
+	send     func(ctx context.Context, failure injectionFailure)
+
+	mutex    sync.Mutex
+	lastSent map[injectionFailureKey]time.Time
+
+	// Set for testing purposes, leave nil to use the current time.
+	now func() time.Time
+}
+
+func newInjectionFailureQueue(send func(ctx context.Context, failure injectionFailure)) *injectionFailureQueue {
+	return &injectionFailureQueue{
+		failures: make(chan injectionFailure, injectionFailureQueueSize),
+		send:     send,
+		lastSent: map[injectionFailureKey]time.Time{},
+	}
+}
+
+func (queue *injectionFailureQueue) add(failure injectionFailure) {
+	key := newInjectionFailureKey(failure)
+	if !queue.markSent(key) {
+		log.Info("injection failure event already sent recently, skipping", "namespace", key.namespace, "owner", key.owner)
+		return
+	}
+
+	select {
+	case queue.failures <- failure:
+	default:
+		log.Info("too many injection failure events queued, dropping event", "namespace", key.namespace, "owner", key.owner)
+		queue.unmarkSent(key)
+	}
+}
+
+func (queue *injectionFailureQueue) unmarkSent(key injectionFailureKey) {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+
+	delete(queue.lastSent, key)
+}
+
+// markSent returns false if an event with the same key was sent within the deduplication window
+func (queue *injectionFailureQueue) markSent(key injectionFailureKey) bool {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+
+	now := queue.currentTime()
+	if lastSent, ok := queue.lastSent[key]; ok && now.Sub(lastSent) < injectionFailureDeduplicationWindow {
+		return false
+	}
+
+	// expired keys are removed, so failures of many different pods can't grow the map without limit
+	if len(queue.lastSent) >= injectionFailureQueueSize {
+		for sentKey, sentAt := range queue.lastSent {
+			if now.Sub(sentAt) >= injectionFailureDeduplicationWindow {
+				delete(queue.lastSent, sentKey)
+			}
+		}
+	}
+
+	queue.lastSent[key] = now
+
+	return true
+}
+
+// run sends the queued events until the context is canceled, it is started by the manager
+func (queue *injectionFailureQueue) run(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case failure := <-queue.failures:
+			sendCtx, cancel := context.WithTimeout(ctx, dynatraceEventTimeout)
+			queue.send(sendCtx, failure)
+			cancel()
+		}
+	}
+}
+
+func (queue *injectionFailureQueue) currentTime() time.Time {
+	if queue.now != nil {
+		return queue.now()
+	}
+
+	return time.Now()
+}
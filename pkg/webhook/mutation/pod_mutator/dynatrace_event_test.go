@@ -0,0 +1,139 @@
+package pod_mutator
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+	dtclient "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/dynatraceclient"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/dynatraceevents"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/address"
+	dtclientmock "github.com/Dynatrace/dynatrace-operator/test/mocks/pkg/clients/dynatrace"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestIngestInjectionFailureEvent(t *testing.T) {
+	ctx := context.Background()
+	injectionErr := errors.New("injection failed")
+
+	dynakube := getTestDynakube()
+	dynakube.Annotations = map[string]string{
+		dynatracev1beta1.AnnotationFeatureInjectionFailureEvents: "true",
+	}
+	tokenSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      dynakube.Tokens(),
+			Namespace: dynakube.Namespace,
+		},
+		Data: map[string][]byte{
+			dtclient.DynatraceApiToken: []byte("api-token"),
+		},
+	}
+
+	t.Run("send event with pod details", func(t *testing.T) {
+		dtc := dtclientmock.NewClient(t)
+		dtc.On("IngestEvent", mock.Anything, mock.MatchedBy(func(event *dtclient.IngestEventData) bool {
+			return event.Properties[dynatraceevents.PropertyPodName] == testPodName &&
+				event.Properties[dynatraceevents.PropertyPodNamespace] == testNamespaceName &&
+				event.Properties[dynatraceevents.PropertyError] == injectionErr.Error()
+		})).Return(nil).Once()
+		webhook := createTestWebhookWithDynatraceClient(dtc, []client.Object{tokenSecret})
+
+		webhook.ingestInjectionFailureEvent(ctx, dynakube, getTestPod(), injectionErr)
+	})
+	t.Run("no event without tokens", func(t *testing.T) {
+		dtc := dtclientmock.NewClient(t)
+		webhook := createTestWebhookWithDynatraceClient(dtc, nil)
+
+		webhook.ingestInjectionFailureEvent(ctx, dynakube, getTestPod(), injectionErr)
+
+		dtc.AssertNotCalled(t, "IngestEvent", mock.Anything, mock.Anything)
+	})
+	t.Run("no event if feature flag is disabled", func(t *testing.T) {
+		dtc := dtclientmock.NewClient(t)
+		webhook := createTestWebhookWithDynatraceClient(dtc, []client.Object{tokenSecret})
+
+		webhook.ingestInjectionFailureEvent(ctx, getTestDynakube(), getTestPod(), injectionErr)
+
+		dtc.AssertNotCalled(t, "IngestEvent", mock.Anything, mock.Anything)
+	})
+}
+
+func TestInjectionFailureQueue(t *testing.T) {
+	injectionErr := errors.New("injection failed")
+	ownedPod := func(name string) *corev1.Pod {
+		pod := getTestPod()
+		pod.Name = name
+		pod.OwnerReferences = []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "app-123", Controller: address.Of(true)}}
+		return pod
+	}
+	noSend := func(context.Context, injectionFailure) {}
+
+	t.Run("failures of the same owner with the same reason are only sent once", func(t *testing.T) {
+		queue := newInjectionFailureQueue(noSend)
+
+		queue.add(injectionFailure{pod: ownedPod("app-123-a"), err: injectionErr})
+		queue.add(injectionFailure{pod: ownedPod("app-123-b"), err: injectionErr})
+		queue.add(injectionFailure{pod: ownedPod("app-123-c"), err: errors.New("other reason")})
+
+		assert.Len(t, queue.failures, 2)
+	})
+	t.Run("failures are sent again after the deduplication window", func(t *testing.T) {
+		now := time.Now()
+		queue := newInjectionFailureQueue(noSend)
+		queue.now = func() time.Time { return now }
+
+		queue.add(injectionFailure{pod: ownedPod("app-123-a"), err: injectionErr})
+		now = now.Add(injectionFailureDeduplicationWindow)
+		queue.add(injectionFailure{pod: ownedPod("app-123-b"), err: injectionErr})
+
+		assert.Len(t, queue.failures, 2)
+	})
+	t.Run("failures are dropped if the queue is full", func(t *testing.T) {
+		queue := newInjectionFailureQueue(noSend)
+
+		for i := 0; i <= injectionFailureQueueSize; i++ {
+			pod := getTestPod()
+			pod.Name = fmt.Sprintf("pod-%d", i)
+			queue.add(injectionFailure{pod: pod, err: injectionErr})
+		}
+
+		assert.Len(t, queue.failures, injectionFailureQueueSize)
+	})
+	t.Run("queued failures are sent by a single worker", func(t *testing.T) {
+		sent := make(chan injectionFailure)
+		queue := newInjectionFailureQueue(func(_ context.Context, failure injectionFailure) {
+			sent <- failure
+		})
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error)
+
+		go func() {
+			done <- queue.run(ctx)
+		}()
+
+		queue.add(injectionFailure{pod: ownedPod("app-123-a"), err: injectionErr})
+
+		failure := <-sent
+		assert.Equal(t, "app-123-a", failure.pod.Name)
+
+		cancel()
+		require.NoError(t, <-done)
+	})
+}
+
+func createTestWebhookWithDynatraceClient(dtc dtclient.Client, objects []client.Object) *podMutatorWebhook {
+	webhook := createTestWebhook(nil, objects)
+	webhook.dynatraceClientBuilder = dynatraceclient.StubBuilder{DynatraceClient: dtc}
+
+	return webhook
+}
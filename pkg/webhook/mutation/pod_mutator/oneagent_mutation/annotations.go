@@ -15,6 +15,8 @@ type installerInfo struct {
 	installPath  string
 	installerURL string
 	version      string
+	os           string
+	arch         string
 }
 
 func setInjectedAnnotation(pod *corev1.Pod) {
@@ -33,11 +35,14 @@ func setNotInjectedAnnotations(pod *corev1.Pod, reason string) {
 }
 
 func getInstallerInfo(pod *corev1.Pod, dynakube dynatracev1beta1.DynaKube) installerInfo {
+	podOs, podArch := getPodPlatform(pod)
 	return installerInfo{
 		flavor:       maputils.GetField(pod.Annotations, dtwebhook.AnnotationFlavor, ""),
 		technologies: url.QueryEscape(maputils.GetField(pod.Annotations, dtwebhook.AnnotationTechnologies, "all")),
 		installPath:  maputils.GetField(pod.Annotations, dtwebhook.AnnotationInstallPath, dtwebhook.DefaultInstallPath),
 		installerURL: maputils.GetField(pod.Annotations, dtwebhook.AnnotationInstallerUrl, ""),
 		version:      dynakube.CodeModulesVersion(),
+		os:           podOs,
+		arch:         podArch,
 	}
 }
@@ -8,6 +8,8 @@ const (
 	testInstallPath  = "testInstallPath"
 	testInstallerURL = "testInstallerUrl"
 	testVersion      = "testVersion"
+	testOs           = "testOs"
+	testArch         = "testArch"
 )
 
 func getTestInstallerInfo() installerInfo {
@@ -17,6 +19,8 @@ func getTestInstallerInfo() installerInfo {
 		installPath:  testInstallPath,
 		installerURL: testInstallerURL,
 		version:      testVersion,
+		os:           testOs,
+		arch:         testArch,
 	}
 }
 
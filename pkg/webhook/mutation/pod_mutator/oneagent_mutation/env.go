@@ -73,6 +73,8 @@ func addInstallerInitEnvs(initContainer *corev1.Container, installer installerIn
 		corev1.EnvVar{Name: consts.AgentInstallPathEnv, Value: installer.installPath},
 		corev1.EnvVar{Name: consts.AgentInstallerUrlEnv, Value: installer.installerURL},
 		corev1.EnvVar{Name: consts.AgentInstallerVersionEnv, Value: installer.version},
+		corev1.EnvVar{Name: consts.AgentInstallerOsEnv, Value: installer.os},
+		corev1.EnvVar{Name: consts.AgentInstallerArchEnv, Value: installer.arch},
 		corev1.EnvVar{Name: consts.AgentInstallModeEnv, Value: getVolumeMode(dynakube)},
 		corev1.EnvVar{Name: consts.AgentReadonlyCSI, Value: strconv.FormatBool(dynakube.FeatureReadOnlyCsiVolume())},
 		corev1.EnvVar{Name: consts.AgentInjectedEnv, Value: "true"},
@@ -98,9 +98,11 @@ func TestAddInstallerInitEnvs(t *testing.T) {
 		assert.Equal(t, installerInfo.installPath, container.Env[2].Value)
 		assert.Equal(t, installerInfo.installerURL, container.Env[3].Value)
 		assert.Equal(t, installerInfo.version, container.Env[4].Value)
-		assert.Equal(t, string(consts.AgentCsiMode), container.Env[5].Value)
-		assert.Equal(t, "false", container.Env[6].Value)
-		assert.Equal(t, "true", container.Env[7].Value)
+		assert.Equal(t, installerInfo.os, container.Env[5].Value)
+		assert.Equal(t, installerInfo.arch, container.Env[6].Value)
+		assert.Equal(t, string(consts.AgentCsiMode), container.Env[7].Value)
+		assert.Equal(t, "false", container.Env[8].Value)
+		assert.Equal(t, "true", container.Env[9].Value)
 	})
 
 	t.Run("Add readonly installer init env", func(t *testing.T) {
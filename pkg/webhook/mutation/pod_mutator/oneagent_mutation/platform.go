@@ -0,0 +1,30 @@
+package oneagent_mutation
+
+import (
+	"github.com/Dynatrace/dynatrace-operator/pkg/arch"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// getPodPlatform returns the os and arch of the code modules for the pod, in the notation of the deployment API.
+// They are taken from the kubernetes.io/os and kubernetes.io/arch node selectors, the os also from the spec.os of the pod.
+// Empty values are returned if the pod isn't pinned, so the init container falls back to its own platform.
+func getPodPlatform(pod *corev1.Pod) (string, string) {
+	kubernetesOs := pod.Spec.NodeSelector[corev1.LabelOSStable]
+	if kubernetesOs == "" && pod.Spec.OS != nil {
+		kubernetesOs = string(pod.Spec.OS.Name)
+	}
+
+	podOs, ok := arch.OsFromKubernetes(kubernetesOs)
+	if !ok && kubernetesOs != "" {
+		log.Info("unknown os for code modules, using the default", "os", kubernetesOs, "pod", pod.GenerateName)
+	}
+
+	kubernetesArch := pod.Spec.NodeSelector[corev1.LabelArchStable]
+
+	podArch, ok := arch.ArchFromKubernetes(kubernetesArch)
+	if !ok && kubernetesArch != "" {
+		log.Info("unknown arch for code modules, using the default", "arch", kubernetesArch, "pod", pod.GenerateName)
+	}
+
+	return podOs, podArch
+}
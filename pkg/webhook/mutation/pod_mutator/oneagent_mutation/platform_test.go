@@ -0,0 +1,61 @@
+package oneagent_mutation
+
+import (
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/arch"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestGetPodPlatform(t *testing.T) {
+	t.Run("not pinned", func(t *testing.T) {
+		podOs, podArch := getPodPlatform(&corev1.Pod{})
+
+		assert.Empty(t, podOs)
+		assert.Empty(t, podArch)
+	})
+	t.Run("node selector", func(t *testing.T) {
+		pod := &corev1.Pod{
+			Spec: corev1.PodSpec{
+				NodeSelector: map[string]string{
+					corev1.LabelOSStable:   "windows",
+					corev1.LabelArchStable: "amd64",
+				},
+			},
+		}
+
+		podOs, podArch := getPodPlatform(pod)
+
+		assert.Equal(t, arch.OsWindows, podOs)
+		assert.Equal(t, arch.ArchX86, podArch)
+	})
+	t.Run("pod os", func(t *testing.T) {
+		pod := &corev1.Pod{
+			Spec: corev1.PodSpec{
+				OS:           &corev1.PodOS{Name: corev1.Linux},
+				NodeSelector: map[string]string{corev1.LabelArchStable: "arm64"},
+			},
+		}
+
+		podOs, podArch := getPodPlatform(pod)
+
+		assert.Equal(t, arch.OsUnix, podOs)
+		assert.Equal(t, arch.ArchARM, podArch)
+	})
+	t.Run("unknown values are ignored", func(t *testing.T) {
+		pod := &corev1.Pod{
+			Spec: corev1.PodSpec{
+				NodeSelector: map[string]string{
+					corev1.LabelOSStable:   "plan9",
+					corev1.LabelArchStable: "mips",
+				},
+			},
+		}
+
+		podOs, podArch := getPodPlatform(pod)
+
+		assert.Empty(t, podOs)
+		assert.Empty(t, podArch)
+	})
+}
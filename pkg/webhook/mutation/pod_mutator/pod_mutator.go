@@ -7,6 +7,7 @@ import (
 	"os"
 
 	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/dynatraceclient"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/labels"
@@ -48,6 +49,9 @@ type podMutatorWebhook struct {
 	decoder   admission.Decoder
 	recorder  podMutatorEventRecorder
 
+	dynatraceClientBuilder dynatraceclient.Builder
+	injectionFailures      *injectionFailureQueue
+
 	webhookImage     string
 	webhookNamespace string
 	clusterID        string
@@ -127,6 +131,7 @@ func (webhook *podMutatorWebhook) Handle(ctx context.Context, request admission.
 	}
 
 	if err := webhook.handlePodMutation(ctx, mutationRequest); err != nil {
+		webhook.sendInjectionFailureEvent(mutationRequest.DynaKube, mutationRequest.Pod, err)
 		return silentErrorResponse(mutationRequest.Pod, err)
 	}
 	log.Info("injection finished for pod", "podName", podName, "namespace", request.Namespace)
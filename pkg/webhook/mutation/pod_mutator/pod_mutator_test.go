@@ -274,7 +274,7 @@ func getTestPodWithOcDebugPodAnnotations() *corev1.Pod {
 func createTestWebhook(mutators []dtwebhook.PodMutator, objects []client.Object) *podMutatorWebhook {
 	decoder := admission.NewDecoder(scheme.Scheme)
 
-	return &podMutatorWebhook{
+	webhook := &podMutatorWebhook{
 		apiReader:        fake.NewClient(objects...),
 		decoder:          *decoder,
 		recorder:         podMutatorEventRecorder{recorder: record.NewFakeRecorder(10), pod: &corev1.Pod{}, dynakube: getTestDynakube()},
@@ -284,6 +284,9 @@ func createTestWebhook(mutators []dtwebhook.PodMutator, objects []client.Object)
 		apmExists:        false,
 		mutators:         mutators,
 	}
+	webhook.injectionFailures = newInjectionFailureQueue(webhook.ingestQueuedInjectionFailure)
+
+	return webhook
 }
 
 func createSimplePodMutatorMock(t *testing.T) *mocks.PodMutator {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 
+	"github.com/Dynatrace/dynatrace-operator/pkg/controllers/dynakube/dynatraceclient"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/container"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/pod"
 	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubesystem"
@@ -67,13 +68,14 @@ func registerInjectEndpoint(mgr manager.Manager, webhookNamespace string, webhoo
 		return errors.WithStack(err)
 	}
 
-	mgr.GetWebhookServer().Register("/inject", &webhook.Admission{Handler: &podMutatorWebhook{
-		apiReader:        apiReader,
-		webhookNamespace: webhookNamespace,
-		webhookImage:     webhookPodImage,
-		deployedViaOLM:   kubesystem.IsDeployedViaOlm(*webhookPod),
-		clusterID:        clusterID,
-		recorder:         eventRecorder,
+	podMutator := &podMutatorWebhook{
+		apiReader:              apiReader,
+		webhookNamespace:       webhookNamespace,
+		webhookImage:           webhookPodImage,
+		deployedViaOLM:         kubesystem.IsDeployedViaOlm(*webhookPod),
+		clusterID:              clusterID,
+		recorder:               eventRecorder,
+		dynatraceClientBuilder: dynatraceclient.NewBuilder(apiReader),
 		mutators: []dtwebhook.PodMutator{
 			oneagent_mutation.NewOneAgentPodMutator(
 				webhookPodImage,
@@ -94,7 +96,14 @@ func registerInjectEndpoint(mgr manager.Manager, webhookNamespace string, webhoo
 		otelMeter:  otel.Meter(otelName),
 
 		requestCounter: requestCounter,
-	}})
+	}
+
+	podMutator.injectionFailures = newInjectionFailureQueue(podMutator.ingestQueuedInjectionFailure)
+	if err := mgr.Add(manager.RunnableFunc(podMutator.injectionFailures.run)); err != nil {
+		return errors.WithStack(err)
+	}
+
+	mgr.GetWebhookServer().Register("/inject", &webhook.Admission{Handler: podMutator})
 	log.Info("registered /inject endpoint")
 	return nil
 }
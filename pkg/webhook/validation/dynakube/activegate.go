@@ -6,6 +6,7 @@ import (
 
 	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 const (
@@ -24,6 +25,33 @@ Make sure you don't duplicate an Activegate capability in your custom resource.
 	errorJoinedSyntheticActiveGateCapability = `The DynaKube's specification tries to specify both the synthetic capability along other (%v) capabilities.
 The synthetic capability can't be configured alongside other capabilities in the same DynaKube. Try using a different DynaKubes, 1 for synthetic and 1 for the other capabilities.
 `
+
+	errorInvalidActiveGateAutoscaling = `The DynaKube's specification contains an invalid ActiveGate autoscaling configuration: minReplicas (%d) must not be greater than maxReplicas (%d).`
+
+	errorConflictingActiveGatePodDisruptionBudget = `The DynaKube's specification sets both minAvailable and maxUnavailable in the ActiveGate podDisruptionBudget section, only one of them can be set.`
+
+	errorInvalidActiveGatePodDisruptionBudget = `The DynaKube's specification contains an invalid value in the ActiveGate podDisruptionBudget section: %s`
+
+	errorConflictingActiveGatePools = `The DynaKube's specification tries to use ActiveGate pools alongside the deprecated ActiveGate section(s) or the synthetic capability, which is not supported.
+`
+
+	errorDuplicateActiveGatePool = `The DynaKube's specification contains the ActiveGate pool %s more than once.
+Make sure the names of the ActiveGate pools are unique.
+`
+
+	errorInvalidActiveGatePoolCapability = `The DynaKube's specification tries to use an invalid capability in the ActiveGate pool %s, invalid capability=%s.
+Make sure you correctly specify the ActiveGate capabilities in your custom resource.
+`
+
+	errorDuplicateActiveGatePoolCapability = `The DynaKube's specification tries to specify duplicate capabilities in the ActiveGate pool %s, duplicate capability=%s.
+Make sure you don't duplicate an Activegate capability in your custom resource.
+`
+
+	errorActiveGatePoolNameTooLong = `The name of the ActiveGate pool %s is too long, the DynaKube name and the pool name combined must not exceed %d characters.
+`
+
+	// maxActiveGatePoolStatefulSetNameLength keeps the controller-revision-hash label of the StatefulSet pods below the label value limit
+	maxActiveGatePoolStatefulSetNameLength = 52
 )
 
 func conflictingActiveGateConfiguration(_ context.Context, dv *dynakubeValidator, dynakube *dynatracev1beta1.DynaKube) string {
@@ -85,3 +113,78 @@ func exclusiveSyntheticCapability(_ context.Context, dv *dynakubeValidator, dyna
 	}
 	return ""
 }
+
+func invalidActiveGateAutoscaling(_ context.Context, _ *dynakubeValidator, dynakube *dynatracev1beta1.DynaKube) string {
+	autoscaling := dynakube.ActiveGateAutoscaling()
+	if autoscaling == nil {
+		return ""
+	}
+	if autoscaling.GetMinReplicas() > autoscaling.MaxReplicas {
+		log.Info("requested dynakube has an invalid ActiveGate autoscaling configuration", "name", dynakube.Name, "namespace", dynakube.Namespace)
+		return fmt.Sprintf(errorInvalidActiveGateAutoscaling, autoscaling.GetMinReplicas(), autoscaling.MaxReplicas)
+	}
+	return ""
+}
+
+func invalidActiveGatePodDisruptionBudget(_ context.Context, _ *dynakubeValidator, dynakube *dynatracev1beta1.DynaKube) string {
+	podDisruptionBudget := dynakube.ActiveGatePodDisruptionBudget()
+	if podDisruptionBudget == nil {
+		return ""
+	}
+	if podDisruptionBudget.MinAvailable != nil && podDisruptionBudget.MaxUnavailable != nil {
+		log.Info("requested dynakube has a conflicting ActiveGate pod disruption budget", "name", dynakube.Name, "namespace", dynakube.Namespace)
+		return errorConflictingActiveGatePodDisruptionBudget
+	}
+	for _, value := range []*intstr.IntOrString{podDisruptionBudget.MinAvailable, podDisruptionBudget.MaxUnavailable} {
+		if value == nil {
+			continue
+		}
+		if scaled, err := intstr.GetScaledValueFromIntOrPercent(value, 100, true); err != nil || scaled < 0 {
+			log.Info("requested dynakube has an invalid ActiveGate pod disruption budget", "name", dynakube.Name, "namespace", dynakube.Namespace)
+			return fmt.Sprintf(errorInvalidActiveGatePodDisruptionBudget, value.String())
+		}
+	}
+	return ""
+}
+
+func conflictingActiveGatePools(_ context.Context, _ *dynakubeValidator, dynakube *dynatracev1beta1.DynaKube) string {
+	if !dynakube.HasActiveGatePools() {
+		return ""
+	}
+	if dynakube.DeprecatedActiveGateMode() || dynakube.IsSyntheticMonitoringEnabled() {
+		log.Info("requested dynakube has conflicting ActiveGate pools", "name", dynakube.Name, "namespace", dynakube.Namespace)
+		return errorConflictingActiveGatePools
+	}
+	return ""
+}
+
+func invalidActiveGatePools(_ context.Context, _ *dynakubeValidator, dynakube *dynatracev1beta1.DynaKube) string {
+	poolNames := map[string]bool{}
+	for _, pool := range dynakube.Spec.ActiveGate.Pools {
+		if poolNames[pool.Name] {
+			log.Info("requested dynakube has duplicate ActiveGate pools", "name", dynakube.Name, "namespace", dynakube.Namespace)
+			return fmt.Sprintf(errorDuplicateActiveGatePool, pool.Name)
+		}
+		poolNames[pool.Name] = true
+
+		statefulSetName := dynakube.Name + "-" + dynatracev1beta1.ActiveGatePoolShortName(pool.Name)
+		if len(statefulSetName) > maxActiveGatePoolStatefulSetNameLength {
+			log.Info("requested dynakube has an ActiveGate pool with a too long name", "name", dynakube.Name, "namespace", dynakube.Namespace)
+			return fmt.Sprintf(errorActiveGatePoolNameTooLong, pool.Name, maxActiveGatePoolStatefulSetNameLength-len(dynatracev1beta1.ActiveGatePoolPrefix)-1)
+		}
+
+		capabilities := map[dynatracev1beta1.CapabilityDisplayName]bool{}
+		for _, capability := range pool.Capabilities {
+			if _, ok := dynatracev1beta1.ActiveGateDisplayNames[capability]; !ok {
+				log.Info("requested dynakube has an invalid capability in an ActiveGate pool", "name", dynakube.Name, "namespace", dynakube.Namespace)
+				return fmt.Sprintf(errorInvalidActiveGatePoolCapability, pool.Name, capability)
+			}
+			if capabilities[capability] {
+				log.Info("requested dynakube has duplicate capabilities in an ActiveGate pool", "name", dynakube.Name, "namespace", dynakube.Namespace)
+				return fmt.Sprintf(errorDuplicateActiveGatePoolCapability, pool.Name, capability)
+			}
+			capabilities[capability] = true
+		}
+	}
+	return ""
+}
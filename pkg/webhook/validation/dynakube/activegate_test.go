@@ -2,11 +2,13 @@ package dynakube
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 func TestConflictingActiveGateConfiguration(t *testing.T) {
@@ -171,3 +173,124 @@ func TestSyntheticMonitoring(t *testing.T) {
 			})
 	})
 }
+
+func TestActiveGateAutoscaling(t *testing.T) {
+	dynakubeWithAutoscaling := func(autoscaling *dynatracev1beta1.ActiveGateAutoscalingSpec) *dynatracev1beta1.DynaKube {
+		return &dynatracev1beta1.DynaKube{
+			ObjectMeta: defaultDynakubeObjectMeta,
+			Spec: dynatracev1beta1.DynaKubeSpec{
+				APIURL: testApiUrl,
+				ActiveGate: dynatracev1beta1.ActiveGateSpec{
+					Capabilities: []dynatracev1beta1.CapabilityDisplayName{
+						dynatracev1beta1.RoutingCapability.DisplayName,
+					},
+					Autoscaling: autoscaling,
+				},
+			},
+		}
+	}
+	minReplicas := int32(3)
+
+	t.Run(`valid autoscaling`, func(t *testing.T) {
+		assertAllowedResponseWithWarnings(t, 1, dynakubeWithAutoscaling(&dynatracev1beta1.ActiveGateAutoscalingSpec{MaxReplicas: 3}))
+		assertAllowedResponseWithWarnings(t, 1, dynakubeWithAutoscaling(&dynatracev1beta1.ActiveGateAutoscalingSpec{MinReplicas: &minReplicas, MaxReplicas: 3}))
+	})
+	t.Run(`min replicas greater than max replicas`, func(t *testing.T) {
+		assertDeniedResponse(t,
+			[]string{fmt.Sprintf(errorInvalidActiveGateAutoscaling, 3, 2)},
+			dynakubeWithAutoscaling(&dynatracev1beta1.ActiveGateAutoscalingSpec{MinReplicas: &minReplicas, MaxReplicas: 2}))
+	})
+}
+
+func TestActiveGatePodDisruptionBudget(t *testing.T) {
+	dynakubeWithPodDisruptionBudget := func(podDisruptionBudget *dynatracev1beta1.ActiveGatePodDisruptionBudgetSpec) *dynatracev1beta1.DynaKube {
+		return &dynatracev1beta1.DynaKube{
+			ObjectMeta: defaultDynakubeObjectMeta,
+			Spec: dynatracev1beta1.DynaKubeSpec{
+				APIURL: testApiUrl,
+				ActiveGate: dynatracev1beta1.ActiveGateSpec{
+					Capabilities: []dynatracev1beta1.CapabilityDisplayName{
+						dynatracev1beta1.RoutingCapability.DisplayName,
+					},
+					PodDisruptionBudget: podDisruptionBudget,
+				},
+			},
+		}
+	}
+	one := intstr.FromInt(1)
+	half := intstr.FromString("50%")
+	invalid := intstr.FromString("half")
+
+	t.Run(`valid pod disruption budget`, func(t *testing.T) {
+		assertAllowedResponseWithWarnings(t, 1, dynakubeWithPodDisruptionBudget(&dynatracev1beta1.ActiveGatePodDisruptionBudgetSpec{}))
+		assertAllowedResponseWithWarnings(t, 1, dynakubeWithPodDisruptionBudget(&dynatracev1beta1.ActiveGatePodDisruptionBudgetSpec{MinAvailable: &half}))
+		assertAllowedResponseWithWarnings(t, 1, dynakubeWithPodDisruptionBudget(&dynatracev1beta1.ActiveGatePodDisruptionBudgetSpec{MaxUnavailable: &one}))
+	})
+	t.Run(`both min available and max unavailable`, func(t *testing.T) {
+		assertDeniedResponse(t,
+			[]string{errorConflictingActiveGatePodDisruptionBudget},
+			dynakubeWithPodDisruptionBudget(&dynatracev1beta1.ActiveGatePodDisruptionBudgetSpec{MinAvailable: &one, MaxUnavailable: &one}))
+	})
+	t.Run(`invalid value`, func(t *testing.T) {
+		assertDeniedResponse(t,
+			[]string{fmt.Sprintf(errorInvalidActiveGatePodDisruptionBudget, "half")},
+			dynakubeWithPodDisruptionBudget(&dynatracev1beta1.ActiveGatePodDisruptionBudgetSpec{MaxUnavailable: &invalid}))
+	})
+}
+
+func TestActiveGatePools(t *testing.T) {
+	routing := []dynatracev1beta1.CapabilityDisplayName{dynatracev1beta1.RoutingCapability.DisplayName}
+	dynakubeWithPools := func(pools ...dynatracev1beta1.ActiveGatePoolSpec) *dynatracev1beta1.DynaKube {
+		return &dynatracev1beta1.DynaKube{
+			ObjectMeta: defaultDynakubeObjectMeta,
+			Spec: dynatracev1beta1.DynaKubeSpec{
+				APIURL: testApiUrl,
+				ActiveGate: dynatracev1beta1.ActiveGateSpec{
+					Pools: pools,
+				},
+			},
+		}
+	}
+
+	t.Run(`valid pools`, func(t *testing.T) {
+		assertAllowedResponseWithoutWarnings(t, dynakubeWithPools(
+			dynatracev1beta1.ActiveGatePoolSpec{Name: "zone-a", Capabilities: routing},
+			dynatracev1beta1.ActiveGatePoolSpec{Name: "zone-b", Capabilities: routing, NetworkZone: "zone-b"}))
+	})
+	t.Run(`pools alongside deprecated section`, func(t *testing.T) {
+		dynakube := dynakubeWithPools(dynatracev1beta1.ActiveGatePoolSpec{Name: "zone-a", Capabilities: routing})
+		dynakube.Spec.Routing.Enabled = true
+		assertDeniedResponse(t, []string{errorConflictingActiveGatePools}, dynakube)
+	})
+	t.Run(`pools alongside synthetic`, func(t *testing.T) {
+		dynakube := dynakubeWithPools(dynatracev1beta1.ActiveGatePoolSpec{Name: "zone-a", Capabilities: routing})
+		dynakube.ObjectMeta = *defaultDynakubeObjectMeta.DeepCopy()
+		dynakube.Annotations = map[string]string{
+			dynatracev1beta1.AnnotationFeatureSyntheticLocationEntityId: "doctored",
+		}
+		assertDeniedResponse(t, []string{errorConflictingActiveGatePools}, dynakube)
+	})
+	t.Run(`duplicate pool`, func(t *testing.T) {
+		assertDeniedResponse(t,
+			[]string{fmt.Sprintf(errorDuplicateActiveGatePool, "zone-a")},
+			dynakubeWithPools(
+				dynatracev1beta1.ActiveGatePoolSpec{Name: "zone-a", Capabilities: routing},
+				dynatracev1beta1.ActiveGatePoolSpec{Name: "zone-a", Capabilities: routing}))
+	})
+	t.Run(`invalid capability`, func(t *testing.T) {
+		assertDeniedResponse(t,
+			[]string{fmt.Sprintf(errorInvalidActiveGatePoolCapability, "zone-a", "invalid")},
+			dynakubeWithPools(dynatracev1beta1.ActiveGatePoolSpec{Name: "zone-a", Capabilities: []dynatracev1beta1.CapabilityDisplayName{"invalid"}}))
+	})
+	t.Run(`duplicate capability`, func(t *testing.T) {
+		assertDeniedResponse(t,
+			[]string{fmt.Sprintf(errorDuplicateActiveGatePoolCapability, "zone-a", dynatracev1beta1.RoutingCapability.DisplayName)},
+			dynakubeWithPools(dynatracev1beta1.ActiveGatePoolSpec{Name: "zone-a", Capabilities: append(routing, routing...)}))
+	})
+	t.Run(`pool name too long`, func(t *testing.T) {
+		poolName := strings.Repeat("a", 40)
+		assertDeniedResponse(t,
+			[]string{fmt.Sprintf(errorActiveGatePoolNameTooLong, poolName, 40)},
+			dynakubeWithPools(dynatracev1beta1.ActiveGatePoolSpec{Name: poolName, Capabilities: routing}))
+	})
+}
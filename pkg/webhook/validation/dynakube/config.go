@@ -25,6 +25,10 @@ var validators = []validator{
 	invalidActiveGateCapabilities,
 	duplicateActiveGateCapabilities,
 	invalidActiveGateProxyUrl,
+	invalidActiveGateAutoscaling,
+	invalidActiveGatePodDisruptionBudget,
+	conflictingActiveGatePools,
+	invalidActiveGatePools,
 	conflictingOneAgentConfiguration,
 	conflictingNodeSelector,
 	conflictingNamespaceSelector,
@@ -36,6 +40,11 @@ var validators = []validator{
 	nameViolatesDNS1035,
 	nameTooLong,
 	namespaceSelectorMatchLabelsViolateLabelSpec,
+	invalidVersionPolicyConstraint,
+	conflictingVersionPolicy,
+	invalidMaintenanceWindow,
+	invalidTokenSource,
+	invalidOneAgentRolloutStrategy,
 }
 
 var warnings = []validator{
@@ -50,6 +59,8 @@ var warnings = []validator{
 	ineffectiveReadOnlyHostFsFeatureFlag,
 	syntheticPreviewWarning,
 	deprecatedFeatureFlag,
+	invalidFeatureFlagValues,
+	ineffectiveMaxUnavailableFeatureFlag,
 }
 
 func SetLogger(logger logr.Logger) {
@@ -0,0 +1,122 @@
+package dynakube
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+)
+
+const (
+	warningInvalidFeatureFlagValues = `Some feature flags have an invalid value, their default is used instead: %s`
+)
+
+type featureFlagValueCheck struct {
+	isValid  func(raw string) bool
+	expected string
+}
+
+var (
+	boolFeatureFlag = featureFlagValueCheck{
+		isValid: func(raw string) bool {
+			return raw == "true" || raw == "false"
+		},
+		expected: "true or false",
+	}
+
+	stringListFeatureFlag = featureFlagValueCheck{
+		isValid: func(raw string) bool {
+			return json.Unmarshal([]byte(raw), &[]string{}) == nil
+		},
+		expected: "a JSON list of strings",
+	}
+)
+
+func minIntFeatureFlag(minValue int) featureFlagValueCheck {
+	return featureFlagValueCheck{
+		isValid: func(raw string) bool {
+			value, err := strconv.Atoi(raw)
+			return err == nil && value >= minValue
+		},
+		expected: fmt.Sprintf("a number >= %d", minValue),
+	}
+}
+
+func enumFeatureFlag(allowed ...string) featureFlagValueCheck {
+	return featureFlagValueCheck{
+		isValid: func(raw string) bool {
+			for _, value := range allowed {
+				if raw == value {
+					return true
+				}
+			}
+			return false
+		},
+		expected: "one of " + strings.Join(allowed, ", "),
+	}
+}
+
+// getFeatureFlagValueChecks contains the rules for the feature flags that have a typed field in v1beta2,
+// the same rules are part of the CRD schema of v1beta2
+func getFeatureFlagValueChecks() map[string]featureFlagValueCheck {
+	return map[string]featureFlagValueCheck{
+		dynatracev1beta1.AnnotationFeaturePublicRegistry:                 boolFeatureFlag,
+		dynatracev1beta1.AnnotationFeatureApiRequestThreshold:            minIntFeatureFlag(0),
+		dynatracev1beta1.AnnotationFeatureHostsRequests:                  boolFeatureFlag,
+		dynatracev1beta1.AnnotationFeatureApiRequestRetries:              minIntFeatureFlag(0),
+		dynatracev1beta1.AnnotationFeatureApiRequestMaxBackoff:           minIntFeatureFlag(0),
+		dynatracev1beta1.AnnotationFeatureApiRequestTimeout:              minIntFeatureFlag(1),
+		dynatracev1beta1.AnnotationFeatureOneAgentVersionEvents:          boolFeatureFlag,
+		dynatracev1beta1.AnnotationFeatureActiveGateVersionEvents:        boolFeatureFlag,
+		dynatracev1beta1.AnnotationFeatureInstallFailureEvents:           boolFeatureFlag,
+		dynatracev1beta1.AnnotationFeatureTokenScopeEvents:               boolFeatureFlag,
+		dynatracev1beta1.AnnotationFeatureInjectionFailureEvents:         boolFeatureFlag,
+		dynatracev1beta1.AnnotationFeatureAutomaticK8sApiMonitoring:      boolFeatureFlag,
+		dynatracev1beta1.AnnotationFeatureK8sAppEnabled:                  boolFeatureFlag,
+		dynatracev1beta1.AnnotationFeatureOneAgentMaxUnavailable:         minIntFeatureFlag(1),
+		dynatracev1beta1.AnnotationFeatureOneAgentInitialConnectRetry:    minIntFeatureFlag(-1),
+		dynatracev1beta1.AnnotationFeatureOneAgentIgnoreProxy:            boolFeatureFlag,
+		dynatracev1beta1.AnnotationFeatureRunOneAgentContainerPrivileged: boolFeatureFlag,
+		dynatracev1beta1.AnnotationFeatureReadOnlyOneAgent:               boolFeatureFlag,
+		dynatracev1beta1.AnnotationFeatureMultipleOsAgentsOnNode:         boolFeatureFlag,
+		dynatracev1beta1.AnnotationFeatureActiveGateUpdates:              boolFeatureFlag,
+		dynatracev1beta1.AnnotationFeatureActiveGateRawImage:             boolFeatureFlag,
+		dynatracev1beta1.AnnotationFeatureActiveGateAuthToken:            boolFeatureFlag,
+		dynatracev1beta1.AnnotationFeatureActiveGateAppArmor:             boolFeatureFlag,
+		dynatracev1beta1.AnnotationFeatureActiveGateReadOnlyFilesystem:   boolFeatureFlag,
+		dynatracev1beta1.AnnotationFeatureActiveGateIgnoreProxy:          boolFeatureFlag,
+		dynatracev1beta1.AnnotationFeatureIgnoredNamespaces:              stringListFeatureFlag,
+		dynatracev1beta1.AnnotationInjectionFailurePolicy:                enumFeatureFlag("silent", "fail", "force"),
+		dynatracev1beta1.AnnotationFeatureAutomaticInjection:             boolFeatureFlag,
+		dynatracev1beta1.AnnotationFeatureWebhookReinvocationPolicy:      boolFeatureFlag,
+		dynatracev1beta1.AnnotationFeatureMetadataEnrichment:             boolFeatureFlag,
+		dynatracev1beta1.AnnotationFeatureIgnoreUnknownState:             boolFeatureFlag,
+		dynatracev1beta1.AnnotationFeatureLabelVersionDetection:          boolFeatureFlag,
+		dynatracev1beta1.AnnotationFeatureInitContainerSeccomp:           boolFeatureFlag,
+		dynatracev1beta1.AnnotationFeatureMaxFailedCsiMountAttempts:      minIntFeatureFlag(0),
+		dynatracev1beta1.AnnotationFeatureReadOnlyCsiVolume:              boolFeatureFlag,
+		dynatracev1beta1.AnnotationFeatureSyntheticReplicas:              minIntFeatureFlag(0),
+	}
+}
+
+func invalidFeatureFlagValues(_ context.Context, _ *dynakubeValidator, dynakube *dynatracev1beta1.DynaKube) string {
+	var invalidFlags []string
+	for annotation, check := range getFeatureFlagValueChecks() {
+		raw, ok := dynakube.Annotations[annotation]
+		if !ok || check.isValid(raw) {
+			continue
+		}
+		invalidFlags = append(invalidFlags, fmt.Sprintf("'%s' (expected %s, got '%s')", annotation, check.expected, raw))
+	}
+
+	if len(invalidFlags) == 0 {
+		return ""
+	}
+	sort.Strings(invalidFlags)
+	log.Info("requested dynakube has feature flags with invalid values", "name", dynakube.Name, "namespace", dynakube.Namespace)
+	return fmt.Sprintf(warningInvalidFeatureFlagValues, strings.Join(invalidFlags, ", "))
+}
@@ -0,0 +1,65 @@
+package dynakube
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestInvalidFeatureFlagValues(t *testing.T) {
+	t.Run(`valid feature flags`, func(t *testing.T) {
+		dynakube := &dynatracev1beta1.DynaKube{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					dynatracev1beta1.AnnotationFeaturePublicRegistry:              "true",
+					dynatracev1beta1.AnnotationFeatureApiRequestThreshold:         "0",
+					dynatracev1beta1.AnnotationFeatureOneAgentInitialConnectRetry: "-1",
+					dynatracev1beta1.AnnotationFeatureIgnoredNamespaces:           `["kube-.*"]`,
+					dynatracev1beta1.AnnotationInjectionFailurePolicy:             "fail",
+					dynatracev1beta1.AnnotationFeatureNoProxy:                     "anything",
+					dynatracev1beta1.AnnotationFeatureApiRequestRetries:           "0",
+					dynatracev1beta1.AnnotationFeatureApiRequestTimeout:           "1",
+					dynatracev1beta1.AnnotationFeatureTokenScopeEvents:            "false",
+				},
+			},
+		}
+
+		assert.Empty(t, invalidFeatureFlagValues(context.Background(), nil, dynakube))
+	})
+	t.Run(`invalid feature flags`, func(t *testing.T) {
+		dynakube := &dynatracev1beta1.DynaKube{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					dynatracev1beta1.AnnotationFeaturePublicRegistry:         "yes",
+					dynatracev1beta1.AnnotationFeatureOneAgentMaxUnavailable: "0",
+					dynatracev1beta1.AnnotationFeatureIgnoredNamespaces:      "kube-.*",
+					dynatracev1beta1.AnnotationInjectionFailurePolicy:        "ignore",
+				},
+			},
+		}
+
+		expected := fmt.Sprintf(warningInvalidFeatureFlagValues,
+			"'"+dynatracev1beta1.AnnotationFeatureIgnoredNamespaces+"' (expected a JSON list of strings, got 'kube-.*'), "+
+				"'"+dynatracev1beta1.AnnotationInjectionFailurePolicy+"' (expected one of silent, fail, force, got 'ignore'), "+
+				"'"+dynatracev1beta1.AnnotationFeatureOneAgentMaxUnavailable+"' (expected a number >= 1, got '0'), "+
+				"'"+dynatracev1beta1.AnnotationFeaturePublicRegistry+"' (expected true or false, got 'yes')")
+		assert.Equal(t, expected, invalidFeatureFlagValues(context.Background(), nil, dynakube))
+	})
+	t.Run(`warning in response`, func(t *testing.T) {
+		dynakubeMeta := defaultDynakubeObjectMeta
+		dynakubeMeta.Annotations = map[string]string{
+			dynatracev1beta1.AnnotationFeatureActiveGateAppArmor: "enabled",
+		}
+		dynakube := &dynatracev1beta1.DynaKube{
+			ObjectMeta: dynakubeMeta,
+			Spec: dynatracev1beta1.DynaKubeSpec{
+				APIURL: testApiUrl,
+			},
+		}
+		assertAllowedResponseWithWarnings(t, 1, dynakube)
+	})
+}
@@ -0,0 +1,22 @@
+package dynakube
+
+import (
+	"context"
+	"fmt"
+
+	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+)
+
+const (
+	errorInvalidMaintenanceWindow = `The DynaKube's specification contains an invalid maintenance window at index %d: %s`
+)
+
+func invalidMaintenanceWindow(_ context.Context, _ *dynakubeValidator, dynakube *dynatracev1beta1.DynaKube) string {
+	for i, window := range dynakube.Spec.MaintenanceWindows {
+		if err := window.Validate(); err != nil {
+			log.Info("requested dynakube has an invalid maintenance window", "name", dynakube.Name, "namespace", dynakube.Namespace, "index", i)
+			return fmt.Sprintf(errorInvalidMaintenanceWindow, i, err.Error())
+		}
+	}
+	return ""
+}
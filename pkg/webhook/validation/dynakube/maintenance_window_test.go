@@ -0,0 +1,38 @@
+package dynakube
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/maintenancewindow"
+	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMaintenanceWindow(t *testing.T) {
+	t.Run(`valid maintenance windows`, func(t *testing.T) {
+		assertAllowedResponse(t, &dynatracev1beta1.DynaKube{
+			ObjectMeta: defaultDynakubeObjectMeta,
+			Spec: dynatracev1beta1.DynaKubeSpec{
+				APIURL: testApiUrl,
+				MaintenanceWindows: []maintenancewindow.Window{
+					{Schedule: "0 2 * * SAT", Duration: &metav1.Duration{Duration: 2 * time.Hour}, TimeZone: "Europe/Vienna"},
+					{Days: []string{"Sun"}, Start: "22:00", End: "02:00"},
+				},
+			},
+		})
+	})
+	t.Run(`invalid maintenance window`, func(t *testing.T) {
+		assertDeniedResponse(t, []string{fmt.Sprintf(errorInvalidMaintenanceWindow, 1, "")}, &dynatracev1beta1.DynaKube{
+			ObjectMeta: defaultDynakubeObjectMeta,
+			Spec: dynatracev1beta1.DynaKubeSpec{
+				APIURL: testApiUrl,
+				MaintenanceWindows: []maintenancewindow.Window{
+					{Start: "01:00", End: "02:00"},
+					{Schedule: "0 2 * * SAT"},
+				},
+			},
+		})
+	})
+}
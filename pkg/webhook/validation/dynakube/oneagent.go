@@ -19,7 +19,11 @@ The conflicting Dynakube: %s
 `
 	errorVolumeStorageReadOnlyModeConflict = `The DynaKube's specification specifies a read-only host file system and OneAgent has volume storage enabled.`
 
+	errorInvalidOneAgentRollout = `The DynaKube's specification contains an invalid OneAgent rollout strategy: %s`
+
 	warningIneffectiveFeatureFlag = `Feature flag %s has no effect in classic full stack mode.`
+
+	warningIneffectiveMaxUnavailable = `Feature flag %s has no effect if the OneAgent is rolled out in stages.`
 )
 
 func conflictingOneAgentConfiguration(_ context.Context, dv *dynakubeValidator, dynakube *dynatracev1beta1.DynaKube) string {
@@ -125,3 +129,22 @@ func ineffectiveReadOnlyHostFsFeatureFlag(_ context.Context, dv *dynakubeValidat
 func readonlyHostFsFlagWarning(featureFlag string) string {
 	return fmt.Sprintf(warningIneffectiveFeatureFlag, featureFlag)
 }
+
+func invalidOneAgentRolloutStrategy(_ context.Context, _ *dynakubeValidator, dynakube *dynatracev1beta1.DynaKube) string {
+	strategy := dynakube.OneAgentRolloutStrategy()
+	if strategy == nil {
+		return ""
+	}
+	if err := strategy.Validate(); err != nil {
+		log.Info("requested dynakube has an invalid OneAgent rollout strategy", "name", dynakube.Name, "namespace", dynakube.Namespace)
+		return fmt.Sprintf(errorInvalidOneAgentRollout, err.Error())
+	}
+	return ""
+}
+
+func ineffectiveMaxUnavailableFeatureFlag(_ context.Context, _ *dynakubeValidator, dynakube *dynatracev1beta1.DynaKube) string {
+	if _, hasMaxUnavailable := dynakube.Annotations[dynatracev1beta1.AnnotationFeatureOneAgentMaxUnavailable]; hasMaxUnavailable && dynakube.OneAgentRolloutStrategy() != nil {
+		return fmt.Sprintf(warningIneffectiveMaxUnavailable, dynatracev1beta1.AnnotationFeatureOneAgentMaxUnavailable)
+	}
+	return ""
+}
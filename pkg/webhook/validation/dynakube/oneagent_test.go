@@ -5,6 +5,7 @@ import (
 	"strconv"
 	"testing"
 
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/rollout"
 	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -556,6 +557,43 @@ func TestReadOnlyModeClassicFullstackWarning(t *testing.T) {
 	}
 }
 
+func TestOneAgentRolloutStrategy(t *testing.T) {
+	createDynakube := func(strategy *rollout.Strategy, annotations map[string]string) *dynatracev1beta1.DynaKube {
+		return &dynatracev1beta1.DynaKube{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        testName,
+				Namespace:   testNamespace,
+				Annotations: annotations,
+			},
+			Spec: dynatracev1beta1.DynaKubeSpec{
+				APIURL: testApiUrl,
+				OneAgent: dynatracev1beta1.OneAgentSpec{
+					ClassicFullStack: &dynatracev1beta1.HostInjectSpec{
+						Rollout: strategy,
+					},
+				},
+			},
+		}
+	}
+
+	t.Run(`valid rollout strategy`, func(t *testing.T) {
+		assertAllowedResponseWithoutWarnings(t, createDynakube(&rollout.Strategy{
+			Canary:        &metav1.LabelSelector{MatchLabels: map[string]string{"canary": "true"}},
+			NodePoolLabel: "topology.kubernetes.io/zone",
+			FailurePolicy: rollout.FailurePolicyRollback,
+		}, nil))
+	})
+	t.Run(`invalid rollout strategy`, func(t *testing.T) {
+		assertDeniedResponse(t, []string{fmt.Sprintf(errorInvalidOneAgentRollout, "")},
+			createDynakube(&rollout.Strategy{NodePoolLabel: "not a label"}, nil))
+	})
+	t.Run(`max unavailable feature flag has no effect`, func(t *testing.T) {
+		assertAllowedResponseWithWarnings(t, 1, createDynakube(&rollout.Strategy{}, map[string]string{
+			dynatracev1beta1.AnnotationFeatureOneAgentMaxUnavailable: "2",
+		}))
+	})
+}
+
 func createHostInjectSpecWithOneAgentVolumeStorage(variable string, flag bool) *dynatracev1beta1.HostInjectSpec {
 	his := &dynatracev1beta1.HostInjectSpec{}
 
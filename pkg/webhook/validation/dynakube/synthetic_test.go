@@ -39,6 +39,6 @@ func TestSyntheticInvalidSettings(t *testing.T) {
 		dynaKube.ObjectMeta.Annotations,
 		dynatracev1beta1.AnnotationFeatureSyntheticNodeType)
 	t.Run("valid replicas", func(t *testing.T) {
-		assertAllowedResponseWithWarnings(t, 2, &dynaKube)
+		assertAllowedResponseWithWarnings(t, 3, &dynaKube)
 	})
 }
@@ -0,0 +1,20 @@
+package dynakube
+
+import (
+	"context"
+	"fmt"
+
+	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+)
+
+const (
+	errorInvalidTokenSource = `The DynaKube's specification contains an invalid token source: %s`
+)
+
+func invalidTokenSource(_ context.Context, _ *dynakubeValidator, dynakube *dynatracev1beta1.DynaKube) string {
+	if err := dynakube.Spec.TokenSource.Validate(); err != nil {
+		log.Info("requested dynakube has an invalid token source", "name", dynakube.Name, "namespace", dynakube.Namespace)
+		return fmt.Sprintf(errorInvalidTokenSource, err.Error())
+	}
+	return ""
+}
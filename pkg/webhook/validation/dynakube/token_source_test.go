@@ -0,0 +1,47 @@
+package dynakube
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/tokensource"
+	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+)
+
+func TestTokenSource(t *testing.T) {
+	dynakubeWithTokenSource := func(tokenSource *tokensource.Spec) *dynatracev1beta1.DynaKube {
+		return &dynatracev1beta1.DynaKube{
+			ObjectMeta: defaultDynakubeObjectMeta,
+			Spec: dynatracev1beta1.DynaKubeSpec{
+				APIURL:      testApiUrl,
+				TokenSource: tokenSource,
+			},
+		}
+	}
+
+	t.Run(`valid token sources`, func(t *testing.T) {
+		assertAllowedResponse(t, dynakubeWithTokenSource(nil))
+		assertAllowedResponse(t, dynakubeWithTokenSource(&tokensource.Spec{Type: tokensource.SecretType}))
+		assertAllowedResponse(t, dynakubeWithTokenSource(&tokensource.Spec{
+			Type: tokensource.FileType,
+			File: &tokensource.FileSpec{Path: "/var/run/secrets/dynatrace"},
+		}))
+		assertAllowedResponse(t, dynakubeWithTokenSource(&tokensource.Spec{
+			Type: tokensource.HTTPType,
+			HTTP: &tokensource.HTTPSpec{URL: "https://vault.vault.svc:8200", Path: "secret/data/dynatrace"},
+		}))
+	})
+	t.Run(`file token source without path`, func(t *testing.T) {
+		assertDeniedResponse(t,
+			[]string{fmt.Sprintf(errorInvalidTokenSource, "")},
+			dynakubeWithTokenSource(&tokensource.Spec{Type: tokensource.FileType}))
+	})
+	t.Run(`http token source with invalid url`, func(t *testing.T) {
+		assertDeniedResponse(t,
+			[]string{fmt.Sprintf(errorInvalidTokenSource, "")},
+			dynakubeWithTokenSource(&tokensource.Spec{
+				Type: tokensource.HTTPType,
+				HTTP: &tokensource.HTTPSpec{URL: "vault:8200", Path: "secret/data/dynatrace"},
+			}))
+	})
+}
@@ -7,6 +7,8 @@ import (
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme"
 	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta2"
+	dynatracev1beta2 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta2/dynakube"
 	"github.com/Dynatrace/dynatrace-operator/pkg/webhook/validation"
 	"github.com/pkg/errors"
 	"k8s.io/client-go/rest"
@@ -73,9 +75,20 @@ func (validator *dynakubeValidator) runValidators(ctx context.Context, validator
 	return results
 }
 
+// decodeRequestToDynakube decodes the request into the storage version of the DynaKube,
+// the typed settings of v1beta2 end up as feature flags, so the validators only have to know v1beta1
 func decodeRequestToDynakube(request admission.Request, dynakube *dynatracev1beta1.DynaKube) error {
 	decoder := admission.NewDecoder(scheme.Scheme)
 
+	if request.Kind.Version == v1beta2.GroupVersion.Version {
+		dynakubeV1beta2 := &dynatracev1beta2.DynaKube{}
+		err := decoder.Decode(request, dynakubeV1beta2)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		return errors.WithStack(dynakubeV1beta2.ConvertTo(dynakube))
+	}
+
 	err := decoder.Decode(request, dynakube)
 	if err != nil {
 		return errors.WithStack(err)
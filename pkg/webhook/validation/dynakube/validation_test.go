@@ -8,7 +8,10 @@ import (
 
 	"github.com/Dynatrace/dynatrace-operator/pkg/api/scheme/fake"
 	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta2"
+	dynatracev1beta2 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta2/dynakube"
 	dtcsi "github.com/Dynatrace/dynatrace-operator/pkg/controllers/csi"
+	"github.com/Dynatrace/dynatrace-operator/pkg/util/kubeobjects/address"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	admissionv1 "k8s.io/api/admission/v1"
@@ -225,3 +228,59 @@ func handleRequest(t *testing.T, dynakube *dynatracev1beta1.DynaKube, other ...c
 		},
 	})
 }
+
+func TestDynakubeValidator_HandleV1beta2(t *testing.T) {
+	t.Run(`typed settings are validated as feature flags`, func(t *testing.T) {
+		dynakube := &dynatracev1beta2.DynaKube{
+			ObjectMeta: defaultDynakubeObjectMeta,
+			Spec: dynatracev1beta2.DynaKubeSpec{
+				APIURL: testApiUrl,
+				OneAgent: dynatracev1beta2.OneAgentSpec{
+					ClassicFullStack: &dynatracev1beta2.HostInjectSpec{},
+					ReadOnlyHostFs:   address.Of(true),
+				},
+			},
+		}
+
+		converted := &dynatracev1beta1.DynaKube{}
+		err := decodeRequestToDynakube(newV1beta2Request(t, dynakube), converted)
+		require.NoError(t, err)
+
+		assert.Equal(t, testApiUrl, converted.Spec.APIURL)
+		assert.NotNil(t, converted.Spec.OneAgent.ClassicFullStack)
+		assert.Equal(t, "true", converted.Annotations[dynatracev1beta1.AnnotationFeatureReadOnlyOneAgent])
+	})
+	t.Run(`missing api url is denied`, func(t *testing.T) {
+		dynakube := &dynatracev1beta2.DynaKube{
+			ObjectMeta: defaultDynakubeObjectMeta,
+		}
+		validator := &dynakubeValidator{
+			clt:       fake.NewClient(),
+			apiReader: fake.NewClient(),
+			cfg:       &rest.Config{},
+		}
+
+		response := validator.Handle(context.Background(), newV1beta2Request(t, dynakube))
+
+		assert.False(t, response.Allowed)
+		assert.Contains(t, response.Result.Message, errorNoApiUrl)
+	})
+}
+
+func newV1beta2Request(t *testing.T, dynakube *dynatracev1beta2.DynaKube) admission.Request {
+	data, err := json.Marshal(*dynakube)
+	require.NoError(t, err)
+
+	return admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Name:      testName,
+			Namespace: testNamespace,
+			Kind: metav1.GroupVersionKind{
+				Group:   v1beta2.GroupVersion.Group,
+				Version: v1beta2.GroupVersion.Version,
+				Kind:    "DynaKube",
+			},
+			Object: runtime.RawExtension{Raw: data},
+		},
+	}
+}
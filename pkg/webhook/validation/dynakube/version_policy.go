@@ -0,0 +1,50 @@
+package dynakube
+
+import (
+	"context"
+	"fmt"
+
+	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/versionpolicy"
+	"github.com/Dynatrace/dynatrace-operator/pkg/version"
+)
+
+const (
+	errorInvalidVersionPolicyConstraint = `The DynaKube's specification contains an invalid version constraint for %s: %s`
+
+	errorConflictingVersionPolicy = `The DynaKube's specification sets a version policy for %s together with a fixed version or a custom image, which is not supported.`
+)
+
+func invalidVersionPolicyConstraint(_ context.Context, _ *dynakubeValidator, dynakube *dynatracev1beta1.DynaKube) string {
+	policies := []struct {
+		component string
+		policy    *versionpolicy.Spec
+	}{
+		{"OneAgent", dynakube.OneAgentVersionPolicy()},
+		{"CodeModules", dynakube.CodeModulesVersionPolicy()},
+		{"ActiveGate", dynakube.ActiveGateVersionPolicy()},
+	}
+
+	for _, entry := range policies {
+		if entry.policy == nil {
+			continue
+		}
+		if _, err := version.ParseConstraint(entry.policy.Constraint); err != nil {
+			log.Info("requested dynakube has an invalid version constraint", "name", dynakube.Name, "namespace", dynakube.Namespace, "component", entry.component)
+			return fmt.Sprintf(errorInvalidVersionPolicyConstraint, entry.component, err.Error())
+		}
+	}
+	return ""
+}
+
+func conflictingVersionPolicy(_ context.Context, _ *dynakubeValidator, dynakube *dynatracev1beta1.DynaKube) string {
+	switch {
+	case dynakube.OneAgentVersionPolicy() != nil && (dynakube.CustomOneAgentVersion() != "" || dynakube.CustomOneAgentImage() != ""):
+		return fmt.Sprintf(errorConflictingVersionPolicy, "OneAgent")
+	case dynakube.CodeModulesVersionPolicy() != nil && (dynakube.CustomCodeModulesVersion() != "" || dynakube.CustomCodeModulesImage() != ""):
+		return fmt.Sprintf(errorConflictingVersionPolicy, "CodeModules")
+	case dynakube.ActiveGateVersionPolicy() != nil && dynakube.CustomActiveGateImage() != "":
+		return fmt.Sprintf(errorConflictingVersionPolicy, "ActiveGate")
+	}
+	return ""
+}
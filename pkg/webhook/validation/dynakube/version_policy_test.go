@@ -0,0 +1,72 @@
+package dynakube
+
+import (
+	"fmt"
+	"testing"
+
+	dynatracev1beta1 "github.com/Dynatrace/dynatrace-operator/pkg/api/v1beta1/dynakube"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/versionpolicy"
+)
+
+func TestVersionPolicy(t *testing.T) {
+	t.Run(`valid version policies`, func(t *testing.T) {
+		assertAllowedResponse(t, &dynatracev1beta1.DynaKube{
+			ObjectMeta: defaultDynakubeObjectMeta,
+			Spec: dynatracev1beta1.DynaKubeSpec{
+				APIURL: testApiUrl,
+				OneAgent: dynatracev1beta1.OneAgentSpec{
+					HostMonitoring: &dynatracev1beta1.HostInjectSpec{
+						VersionPolicy: &versionpolicy.Spec{Constraint: ">=1.280 <1.290"},
+					},
+				},
+				ActiveGate: dynatracev1beta1.ActiveGateSpec{
+					Capabilities:  []dynatracev1beta1.CapabilityDisplayName{dynatracev1beta1.RoutingCapability.DisplayName},
+					VersionPolicy: &versionpolicy.Spec{Constraint: "~1.281"},
+				},
+			},
+		}, &defaultCSIDaemonSet)
+	})
+	t.Run(`invalid constraint`, func(t *testing.T) {
+		assertDeniedResponse(t, []string{fmt.Sprintf(errorInvalidVersionPolicyConstraint, "OneAgent", "")}, &dynatracev1beta1.DynaKube{
+			ObjectMeta: defaultDynakubeObjectMeta,
+			Spec: dynatracev1beta1.DynaKubeSpec{
+				APIURL: testApiUrl,
+				OneAgent: dynatracev1beta1.OneAgentSpec{
+					ClassicFullStack: &dynatracev1beta1.HostInjectSpec{
+						VersionPolicy: &versionpolicy.Spec{Constraint: "newest"},
+					},
+				},
+			},
+		})
+	})
+	t.Run(`version policy together with fixed version`, func(t *testing.T) {
+		assertDeniedResponse(t, []string{fmt.Sprintf(errorConflictingVersionPolicy, "OneAgent")}, &dynatracev1beta1.DynaKube{
+			ObjectMeta: defaultDynakubeObjectMeta,
+			Spec: dynatracev1beta1.DynaKubeSpec{
+				APIURL: testApiUrl,
+				OneAgent: dynatracev1beta1.OneAgentSpec{
+					ClassicFullStack: &dynatracev1beta1.HostInjectSpec{
+						Version:       "1.280.0.20231201-100000",
+						VersionPolicy: &versionpolicy.Spec{Constraint: "1.280.x"},
+					},
+				},
+			},
+		})
+	})
+	t.Run(`version policy together with custom image`, func(t *testing.T) {
+		assertDeniedResponse(t, []string{fmt.Sprintf(errorConflictingVersionPolicy, "CodeModules")}, &dynatracev1beta1.DynaKube{
+			ObjectMeta: defaultDynakubeObjectMeta,
+			Spec: dynatracev1beta1.DynaKubeSpec{
+				APIURL: testApiUrl,
+				OneAgent: dynatracev1beta1.OneAgentSpec{
+					CloudNativeFullStack: &dynatracev1beta1.CloudNativeFullStackSpec{
+						AppInjectionSpec: dynatracev1beta1.AppInjectionSpec{
+							CodeModulesImage:         "some.registry.com/codemodules:1.280.0",
+							CodeModulesVersionPolicy: &versionpolicy.Spec{Constraint: "1.280.x"},
+						},
+					},
+				},
+			},
+		}, &defaultCSIDaemonSet)
+	})
+}
@@ -0,0 +1,22 @@
+package edgeconnect
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1alpha1/edgeconnect"
+)
+
+const (
+	errorInvalidMaintenanceWindow = `The EdgeConnect's specification contains an invalid maintenance window at index %d: %s`
+)
+
+func invalidMaintenanceWindow(_ context.Context, _ *edgeconnectValidator, edgeConnect *edgeconnect.EdgeConnect) string {
+	for i, window := range edgeConnect.Spec.MaintenanceWindows {
+		if err := window.Validate(); err != nil {
+			log.Info("requested EdgeConnect has an invalid maintenance window", "name", edgeConnect.Name, "namespace", edgeConnect.Namespace, "index", i)
+			return fmt.Sprintf(errorInvalidMaintenanceWindow, i, err.Error())
+		}
+	}
+	return ""
+}
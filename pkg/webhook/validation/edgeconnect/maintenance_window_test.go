@@ -0,0 +1,34 @@
+package edgeconnect
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/maintenancewindow"
+	"github.com/Dynatrace/dynatrace-operator/pkg/api/v1alpha1/edgeconnect"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestInvalidMaintenanceWindow(t *testing.T) {
+	t.Run("valid maintenance windows", func(t *testing.T) {
+		assertAllowedResponse(t, &edgeconnect.EdgeConnect{
+			Spec: edgeconnect.EdgeConnectSpec{
+				ApiServer: "id." + allowedSuffix[0],
+				MaintenanceWindows: []maintenancewindow.Window{
+					{Schedule: "30 3 * * 1-5", Duration: &metav1.Duration{Duration: time.Hour}},
+				},
+			},
+		})
+	})
+	t.Run("invalid maintenance window", func(t *testing.T) {
+		assertDeniedResponse(t, []string{fmt.Sprintf(errorInvalidMaintenanceWindow, 0, "")}, &edgeconnect.EdgeConnect{
+			Spec: edgeconnect.EdgeConnectSpec{
+				ApiServer: "id." + allowedSuffix[0],
+				MaintenanceWindows: []maintenancewindow.Window{
+					{Days: []string{"Mon"}, Start: "3pm", End: "17:00"},
+				},
+			},
+		})
+	})
+}
@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	io "io"
 
 	dynatrace "github.com/Dynatrace/dynatrace-operator/pkg/clients/dynatrace"
@@ -23,23 +25,23 @@ func (_m *Client) EXPECT() *Client_Expecter {
 	return &Client_Expecter{mock: &_m.Mock}
 }
 
-// CreateOrUpdateKubernetesAppSetting provides a mock function with given fields: scope
-func (_m *Client) CreateOrUpdateKubernetesAppSetting(scope string) (string, error) {
-	ret := _m.Called(scope)
+// CreateOrUpdateKubernetesAppSetting provides a mock function with given fields: ctx, scope
+func (_m *Client) CreateOrUpdateKubernetesAppSetting(ctx context.Context, scope string) (string, error) {
+	ret := _m.Called(ctx, scope)
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
-		return rf(scope)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, scope)
 	}
-	if rf, ok := ret.Get(0).(func(string) string); ok {
-		r0 = rf(scope)
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, scope)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(scope)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, scope)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -53,14 +55,15 @@ type Client_CreateOrUpdateKubernetesAppSetting_Call struct {
 }
 
 // CreateOrUpdateKubernetesAppSetting is a helper method to define mock.On call
+//   - ctx context.Context
 //   - scope string
-func (_e *Client_Expecter) CreateOrUpdateKubernetesAppSetting(scope interface{}) *Client_CreateOrUpdateKubernetesAppSetting_Call {
-	return &Client_CreateOrUpdateKubernetesAppSetting_Call{Call: _e.mock.On("CreateOrUpdateKubernetesAppSetting", scope)}
+func (_e *Client_Expecter) CreateOrUpdateKubernetesAppSetting(ctx interface{}, scope interface{}) *Client_CreateOrUpdateKubernetesAppSetting_Call {
+	return &Client_CreateOrUpdateKubernetesAppSetting_Call{Call: _e.mock.On("CreateOrUpdateKubernetesAppSetting", ctx, scope)}
 }
 
-func (_c *Client_CreateOrUpdateKubernetesAppSetting_Call) Run(run func(scope string)) *Client_CreateOrUpdateKubernetesAppSetting_Call {
+func (_c *Client_CreateOrUpdateKubernetesAppSetting_Call) Run(run func(ctx context.Context, scope string)) *Client_CreateOrUpdateKubernetesAppSetting_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
@@ -70,28 +73,28 @@ func (_c *Client_CreateOrUpdateKubernetesAppSetting_Call) Return(_a0 string, _a1
 	return _c
 }
 
-func (_c *Client_CreateOrUpdateKubernetesAppSetting_Call) RunAndReturn(run func(string) (string, error)) *Client_CreateOrUpdateKubernetesAppSetting_Call {
+func (_c *Client_CreateOrUpdateKubernetesAppSetting_Call) RunAndReturn(run func(context.Context, string) (string, error)) *Client_CreateOrUpdateKubernetesAppSetting_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateOrUpdateKubernetesSetting provides a mock function with given fields: name, kubeSystemUUID, scope
-func (_m *Client) CreateOrUpdateKubernetesSetting(name string, kubeSystemUUID string, scope string) (string, error) {
-	ret := _m.Called(name, kubeSystemUUID, scope)
+// CreateOrUpdateKubernetesSetting provides a mock function with given fields: ctx, name, kubeSystemUUID, scope
+func (_m *Client) CreateOrUpdateKubernetesSetting(ctx context.Context, name string, kubeSystemUUID string, scope string) (string, error) {
+	ret := _m.Called(ctx, name, kubeSystemUUID, scope)
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string, string) (string, error)); ok {
-		return rf(name, kubeSystemUUID, scope)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (string, error)); ok {
+		return rf(ctx, name, kubeSystemUUID, scope)
 	}
-	if rf, ok := ret.Get(0).(func(string, string, string) string); ok {
-		r0 = rf(name, kubeSystemUUID, scope)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) string); ok {
+		r0 = rf(ctx, name, kubeSystemUUID, scope)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
-		r1 = rf(name, kubeSystemUUID, scope)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, name, kubeSystemUUID, scope)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -105,16 +108,17 @@ type Client_CreateOrUpdateKubernetesSetting_Call struct {
 }
 
 // CreateOrUpdateKubernetesSetting is a helper method to define mock.On call
+//   - ctx context.Context
 //   - name string
 //   - kubeSystemUUID string
 //   - scope string
-func (_e *Client_Expecter) CreateOrUpdateKubernetesSetting(name interface{}, kubeSystemUUID interface{}, scope interface{}) *Client_CreateOrUpdateKubernetesSetting_Call {
-	return &Client_CreateOrUpdateKubernetesSetting_Call{Call: _e.mock.On("CreateOrUpdateKubernetesSetting", name, kubeSystemUUID, scope)}
+func (_e *Client_Expecter) CreateOrUpdateKubernetesSetting(ctx interface{}, name interface{}, kubeSystemUUID interface{}, scope interface{}) *Client_CreateOrUpdateKubernetesSetting_Call {
+	return &Client_CreateOrUpdateKubernetesSetting_Call{Call: _e.mock.On("CreateOrUpdateKubernetesSetting", ctx, name, kubeSystemUUID, scope)}
 }
 
-func (_c *Client_CreateOrUpdateKubernetesSetting_Call) Run(run func(name string, kubeSystemUUID string, scope string)) *Client_CreateOrUpdateKubernetesSetting_Call {
+func (_c *Client_CreateOrUpdateKubernetesSetting_Call) Run(run func(ctx context.Context, name string, kubeSystemUUID string, scope string)) *Client_CreateOrUpdateKubernetesSetting_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string), args[2].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
 	})
 	return _c
 }
@@ -124,30 +128,126 @@ func (_c *Client_CreateOrUpdateKubernetesSetting_Call) Return(_a0 string, _a1 er
 	return _c
 }
 
-func (_c *Client_CreateOrUpdateKubernetesSetting_Call) RunAndReturn(run func(string, string, string) (string, error)) *Client_CreateOrUpdateKubernetesSetting_Call {
+func (_c *Client_CreateOrUpdateKubernetesSetting_Call) RunAndReturn(run func(context.Context, string, string, string) (string, error)) *Client_CreateOrUpdateKubernetesSetting_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateSettingsObject provides a mock function with given fields: ctx, object
+func (_m *Client) CreateSettingsObject(ctx context.Context, object dynatrace.SettingsObject) (string, error) {
+	ret := _m.Called(ctx, object)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, dynatrace.SettingsObject) (string, error)); ok {
+		return rf(ctx, object)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, dynatrace.SettingsObject) string); ok {
+		r0 = rf(ctx, object)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, dynatrace.SettingsObject) error); ok {
+		r1 = rf(ctx, object)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_CreateSettingsObject_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateSettingsObject'
+type Client_CreateSettingsObject_Call struct {
+	*mock.Call
+}
+
+// CreateSettingsObject is a helper method to define mock.On call
+//   - ctx context.Context
+//   - object dynatrace.SettingsObject
+func (_e *Client_Expecter) CreateSettingsObject(ctx interface{}, object interface{}) *Client_CreateSettingsObject_Call {
+	return &Client_CreateSettingsObject_Call{Call: _e.mock.On("CreateSettingsObject", ctx, object)}
+}
+
+func (_c *Client_CreateSettingsObject_Call) Run(run func(ctx context.Context, object dynatrace.SettingsObject)) *Client_CreateSettingsObject_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(dynatrace.SettingsObject))
+	})
+	return _c
+}
+
+func (_c *Client_CreateSettingsObject_Call) Return(_a0 string, _a1 error) *Client_CreateSettingsObject_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_CreateSettingsObject_Call) RunAndReturn(run func(context.Context, dynatrace.SettingsObject) (string, error)) *Client_CreateSettingsObject_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteSettingsObject provides a mock function with given fields: ctx, objectId
+func (_m *Client) DeleteSettingsObject(ctx context.Context, objectId string) error {
+	ret := _m.Called(ctx, objectId)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, objectId)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Client_DeleteSettingsObject_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSettingsObject'
+type Client_DeleteSettingsObject_Call struct {
+	*mock.Call
+}
+
+// DeleteSettingsObject is a helper method to define mock.On call
+//   - ctx context.Context
+//   - objectId string
+func (_e *Client_Expecter) DeleteSettingsObject(ctx interface{}, objectId interface{}) *Client_DeleteSettingsObject_Call {
+	return &Client_DeleteSettingsObject_Call{Call: _e.mock.On("DeleteSettingsObject", ctx, objectId)}
+}
+
+func (_c *Client_DeleteSettingsObject_Call) Run(run func(ctx context.Context, objectId string)) *Client_DeleteSettingsObject_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Client_DeleteSettingsObject_Call) Return(_a0 error) *Client_DeleteSettingsObject_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Client_DeleteSettingsObject_Call) RunAndReturn(run func(context.Context, string) error) *Client_DeleteSettingsObject_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetActiveGateAuthToken provides a mock function with given fields: dynakubeName
-func (_m *Client) GetActiveGateAuthToken(dynakubeName string) (*dynatrace.ActiveGateAuthTokenInfo, error) {
-	ret := _m.Called(dynakubeName)
+// GetActiveGateAuthToken provides a mock function with given fields: ctx, dynakubeName
+func (_m *Client) GetActiveGateAuthToken(ctx context.Context, dynakubeName string) (*dynatrace.ActiveGateAuthTokenInfo, error) {
+	ret := _m.Called(ctx, dynakubeName)
 
 	var r0 *dynatrace.ActiveGateAuthTokenInfo
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (*dynatrace.ActiveGateAuthTokenInfo, error)); ok {
-		return rf(dynakubeName)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*dynatrace.ActiveGateAuthTokenInfo, error)); ok {
+		return rf(ctx, dynakubeName)
 	}
-	if rf, ok := ret.Get(0).(func(string) *dynatrace.ActiveGateAuthTokenInfo); ok {
-		r0 = rf(dynakubeName)
+	if rf, ok := ret.Get(0).(func(context.Context, string) *dynatrace.ActiveGateAuthTokenInfo); ok {
+		r0 = rf(ctx, dynakubeName)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*dynatrace.ActiveGateAuthTokenInfo)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(dynakubeName)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, dynakubeName)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -161,14 +261,15 @@ type Client_GetActiveGateAuthToken_Call struct {
 }
 
 // GetActiveGateAuthToken is a helper method to define mock.On call
+//   - ctx context.Context
 //   - dynakubeName string
-func (_e *Client_Expecter) GetActiveGateAuthToken(dynakubeName interface{}) *Client_GetActiveGateAuthToken_Call {
-	return &Client_GetActiveGateAuthToken_Call{Call: _e.mock.On("GetActiveGateAuthToken", dynakubeName)}
+func (_e *Client_Expecter) GetActiveGateAuthToken(ctx interface{}, dynakubeName interface{}) *Client_GetActiveGateAuthToken_Call {
+	return &Client_GetActiveGateAuthToken_Call{Call: _e.mock.On("GetActiveGateAuthToken", ctx, dynakubeName)}
 }
 
-func (_c *Client_GetActiveGateAuthToken_Call) Run(run func(dynakubeName string)) *Client_GetActiveGateAuthToken_Call {
+func (_c *Client_GetActiveGateAuthToken_Call) Run(run func(ctx context.Context, dynakubeName string)) *Client_GetActiveGateAuthToken_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
@@ -178,28 +279,28 @@ func (_c *Client_GetActiveGateAuthToken_Call) Return(_a0 *dynatrace.ActiveGateAu
 	return _c
 }
 
-func (_c *Client_GetActiveGateAuthToken_Call) RunAndReturn(run func(string) (*dynatrace.ActiveGateAuthTokenInfo, error)) *Client_GetActiveGateAuthToken_Call {
+func (_c *Client_GetActiveGateAuthToken_Call) RunAndReturn(run func(context.Context, string) (*dynatrace.ActiveGateAuthTokenInfo, error)) *Client_GetActiveGateAuthToken_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetActiveGateConnectionInfo provides a mock function with given fields:
-func (_m *Client) GetActiveGateConnectionInfo() (dynatrace.ActiveGateConnectionInfo, error) {
-	ret := _m.Called()
+// GetActiveGateConnectionInfo provides a mock function with given fields: ctx
+func (_m *Client) GetActiveGateConnectionInfo(ctx context.Context) (dynatrace.ActiveGateConnectionInfo, error) {
+	ret := _m.Called(ctx)
 
 	var r0 dynatrace.ActiveGateConnectionInfo
 	var r1 error
-	if rf, ok := ret.Get(0).(func() (dynatrace.ActiveGateConnectionInfo, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(context.Context) (dynatrace.ActiveGateConnectionInfo, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func() dynatrace.ActiveGateConnectionInfo); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) dynatrace.ActiveGateConnectionInfo); ok {
+		r0 = rf(ctx)
 	} else {
 		r0 = ret.Get(0).(dynatrace.ActiveGateConnectionInfo)
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -213,13 +314,14 @@ type Client_GetActiveGateConnectionInfo_Call struct {
 }
 
 // GetActiveGateConnectionInfo is a helper method to define mock.On call
-func (_e *Client_Expecter) GetActiveGateConnectionInfo() *Client_GetActiveGateConnectionInfo_Call {
-	return &Client_GetActiveGateConnectionInfo_Call{Call: _e.mock.On("GetActiveGateConnectionInfo")}
+//   - ctx context.Context
+func (_e *Client_Expecter) GetActiveGateConnectionInfo(ctx interface{}) *Client_GetActiveGateConnectionInfo_Call {
+	return &Client_GetActiveGateConnectionInfo_Call{Call: _e.mock.On("GetActiveGateConnectionInfo", ctx)}
 }
 
-func (_c *Client_GetActiveGateConnectionInfo_Call) Run(run func()) *Client_GetActiveGateConnectionInfo_Call {
+func (_c *Client_GetActiveGateConnectionInfo_Call) Run(run func(ctx context.Context)) *Client_GetActiveGateConnectionInfo_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(context.Context))
 	})
 	return _c
 }
@@ -229,18 +331,18 @@ func (_c *Client_GetActiveGateConnectionInfo_Call) Return(_a0 dynatrace.ActiveGa
 	return _c
 }
 
-func (_c *Client_GetActiveGateConnectionInfo_Call) RunAndReturn(run func() (dynatrace.ActiveGateConnectionInfo, error)) *Client_GetActiveGateConnectionInfo_Call {
+func (_c *Client_GetActiveGateConnectionInfo_Call) RunAndReturn(run func(context.Context) (dynatrace.ActiveGateConnectionInfo, error)) *Client_GetActiveGateConnectionInfo_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetAgent provides a mock function with given fields: os, installerType, flavor, arch, version, technologies, skipMetadata, writer
-func (_m *Client) GetAgent(os string, installerType string, flavor string, arch string, version string, technologies []string, skipMetadata bool, writer io.Writer) error {
-	ret := _m.Called(os, installerType, flavor, arch, version, technologies, skipMetadata, writer)
+// GetAgent provides a mock function with given fields: ctx, os, installerType, flavor, arch, version, technologies, skipMetadata, writer
+func (_m *Client) GetAgent(ctx context.Context, os string, installerType string, flavor string, arch string, version string, technologies []string, skipMetadata bool, writer io.Writer) error {
+	ret := _m.Called(ctx, os, installerType, flavor, arch, version, technologies, skipMetadata, writer)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string, string, string, string, []string, bool, io.Writer) error); ok {
-		r0 = rf(os, installerType, flavor, arch, version, technologies, skipMetadata, writer)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, string, []string, bool, io.Writer) error); ok {
+		r0 = rf(ctx, os, installerType, flavor, arch, version, technologies, skipMetadata, writer)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -254,6 +356,7 @@ type Client_GetAgent_Call struct {
 }
 
 // GetAgent is a helper method to define mock.On call
+//   - ctx context.Context
 //   - os string
 //   - installerType string
 //   - flavor string
@@ -262,13 +365,13 @@ type Client_GetAgent_Call struct {
 //   - technologies []string
 //   - skipMetadata bool
 //   - writer io.Writer
-func (_e *Client_Expecter) GetAgent(os interface{}, installerType interface{}, flavor interface{}, arch interface{}, version interface{}, technologies interface{}, skipMetadata interface{}, writer interface{}) *Client_GetAgent_Call {
-	return &Client_GetAgent_Call{Call: _e.mock.On("GetAgent", os, installerType, flavor, arch, version, technologies, skipMetadata, writer)}
+func (_e *Client_Expecter) GetAgent(ctx interface{}, os interface{}, installerType interface{}, flavor interface{}, arch interface{}, version interface{}, technologies interface{}, skipMetadata interface{}, writer interface{}) *Client_GetAgent_Call {
+	return &Client_GetAgent_Call{Call: _e.mock.On("GetAgent", ctx, os, installerType, flavor, arch, version, technologies, skipMetadata, writer)}
 }
 
-func (_c *Client_GetAgent_Call) Run(run func(os string, installerType string, flavor string, arch string, version string, technologies []string, skipMetadata bool, writer io.Writer)) *Client_GetAgent_Call {
+func (_c *Client_GetAgent_Call) Run(run func(ctx context.Context, os string, installerType string, flavor string, arch string, version string, technologies []string, skipMetadata bool, writer io.Writer)) *Client_GetAgent_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string), args[2].(string), args[3].(string), args[4].(string), args[5].([]string), args[6].(bool), args[7].(io.Writer))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(string), args[5].(string), args[6].([]string), args[7].(bool), args[8].(io.Writer))
 	})
 	return _c
 }
@@ -278,30 +381,30 @@ func (_c *Client_GetAgent_Call) Return(_a0 error) *Client_GetAgent_Call {
 	return _c
 }
 
-func (_c *Client_GetAgent_Call) RunAndReturn(run func(string, string, string, string, string, []string, bool, io.Writer) error) *Client_GetAgent_Call {
+func (_c *Client_GetAgent_Call) RunAndReturn(run func(context.Context, string, string, string, string, string, []string, bool, io.Writer) error) *Client_GetAgent_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetAgentVersions provides a mock function with given fields: os, installerType, flavor, arch
-func (_m *Client) GetAgentVersions(os string, installerType string, flavor string, arch string) ([]string, error) {
-	ret := _m.Called(os, installerType, flavor, arch)
+// GetAgentVersions provides a mock function with given fields: ctx, os, installerType, flavor, arch
+func (_m *Client) GetAgentVersions(ctx context.Context, os string, installerType string, flavor string, arch string) ([]string, error) {
+	ret := _m.Called(ctx, os, installerType, flavor, arch)
 
 	var r0 []string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string, string, string) ([]string, error)); ok {
-		return rf(os, installerType, flavor, arch)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) ([]string, error)); ok {
+		return rf(ctx, os, installerType, flavor, arch)
 	}
-	if rf, ok := ret.Get(0).(func(string, string, string, string) []string); ok {
-		r0 = rf(os, installerType, flavor, arch)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) []string); ok {
+		r0 = rf(ctx, os, installerType, flavor, arch)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]string)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string, string, string) error); ok {
-		r1 = rf(os, installerType, flavor, arch)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string) error); ok {
+		r1 = rf(ctx, os, installerType, flavor, arch)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -315,17 +418,18 @@ type Client_GetAgentVersions_Call struct {
 }
 
 // GetAgentVersions is a helper method to define mock.On call
+//   - ctx context.Context
 //   - os string
 //   - installerType string
 //   - flavor string
 //   - arch string
-func (_e *Client_Expecter) GetAgentVersions(os interface{}, installerType interface{}, flavor interface{}, arch interface{}) *Client_GetAgentVersions_Call {
-	return &Client_GetAgentVersions_Call{Call: _e.mock.On("GetAgentVersions", os, installerType, flavor, arch)}
+func (_e *Client_Expecter) GetAgentVersions(ctx interface{}, os interface{}, installerType interface{}, flavor interface{}, arch interface{}) *Client_GetAgentVersions_Call {
+	return &Client_GetAgentVersions_Call{Call: _e.mock.On("GetAgentVersions", ctx, os, installerType, flavor, arch)}
 }
 
-func (_c *Client_GetAgentVersions_Call) Run(run func(os string, installerType string, flavor string, arch string)) *Client_GetAgentVersions_Call {
+func (_c *Client_GetAgentVersions_Call) Run(run func(ctx context.Context, os string, installerType string, flavor string, arch string)) *Client_GetAgentVersions_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string), args[2].(string), args[3].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(string))
 	})
 	return _c
 }
@@ -335,18 +439,18 @@ func (_c *Client_GetAgentVersions_Call) Return(_a0 []string, _a1 error) *Client_
 	return _c
 }
 
-func (_c *Client_GetAgentVersions_Call) RunAndReturn(run func(string, string, string, string) ([]string, error)) *Client_GetAgentVersions_Call {
+func (_c *Client_GetAgentVersions_Call) RunAndReturn(run func(context.Context, string, string, string, string) ([]string, error)) *Client_GetAgentVersions_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetAgentViaInstallerUrl provides a mock function with given fields: url, writer
-func (_m *Client) GetAgentViaInstallerUrl(url string, writer io.Writer) error {
-	ret := _m.Called(url, writer)
+// GetAgentViaInstallerUrl provides a mock function with given fields: ctx, url, writer
+func (_m *Client) GetAgentViaInstallerUrl(ctx context.Context, url string, writer io.Writer) error {
+	ret := _m.Called(ctx, url, writer)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, io.Writer) error); ok {
-		r0 = rf(url, writer)
+	if rf, ok := ret.Get(0).(func(context.Context, string, io.Writer) error); ok {
+		r0 = rf(ctx, url, writer)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -360,15 +464,16 @@ type Client_GetAgentViaInstallerUrl_Call struct {
 }
 
 // GetAgentViaInstallerUrl is a helper method to define mock.On call
+//   - ctx context.Context
 //   - url string
 //   - writer io.Writer
-func (_e *Client_Expecter) GetAgentViaInstallerUrl(url interface{}, writer interface{}) *Client_GetAgentViaInstallerUrl_Call {
-	return &Client_GetAgentViaInstallerUrl_Call{Call: _e.mock.On("GetAgentViaInstallerUrl", url, writer)}
+func (_e *Client_Expecter) GetAgentViaInstallerUrl(ctx interface{}, url interface{}, writer interface{}) *Client_GetAgentViaInstallerUrl_Call {
+	return &Client_GetAgentViaInstallerUrl_Call{Call: _e.mock.On("GetAgentViaInstallerUrl", ctx, url, writer)}
 }
 
-func (_c *Client_GetAgentViaInstallerUrl_Call) Run(run func(url string, writer io.Writer)) *Client_GetAgentViaInstallerUrl_Call {
+func (_c *Client_GetAgentViaInstallerUrl_Call) Run(run func(ctx context.Context, url string, writer io.Writer)) *Client_GetAgentViaInstallerUrl_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(io.Writer))
+		run(args[0].(context.Context), args[1].(string), args[2].(io.Writer))
 	})
 	return _c
 }
@@ -378,28 +483,28 @@ func (_c *Client_GetAgentViaInstallerUrl_Call) Return(_a0 error) *Client_GetAgen
 	return _c
 }
 
-func (_c *Client_GetAgentViaInstallerUrl_Call) RunAndReturn(run func(string, io.Writer) error) *Client_GetAgentViaInstallerUrl_Call {
+func (_c *Client_GetAgentViaInstallerUrl_Call) RunAndReturn(run func(context.Context, string, io.Writer) error) *Client_GetAgentViaInstallerUrl_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetCommunicationHostForClient provides a mock function with given fields:
-func (_m *Client) GetCommunicationHostForClient() (dynatrace.CommunicationHost, error) {
-	ret := _m.Called()
+// GetCommunicationHostForClient provides a mock function with given fields: ctx
+func (_m *Client) GetCommunicationHostForClient(ctx context.Context) (dynatrace.CommunicationHost, error) {
+	ret := _m.Called(ctx)
 
 	var r0 dynatrace.CommunicationHost
 	var r1 error
-	if rf, ok := ret.Get(0).(func() (dynatrace.CommunicationHost, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(context.Context) (dynatrace.CommunicationHost, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func() dynatrace.CommunicationHost); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) dynatrace.CommunicationHost); ok {
+		r0 = rf(ctx)
 	} else {
 		r0 = ret.Get(0).(dynatrace.CommunicationHost)
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -413,13 +518,14 @@ type Client_GetCommunicationHostForClient_Call struct {
 }
 
 // GetCommunicationHostForClient is a helper method to define mock.On call
-func (_e *Client_Expecter) GetCommunicationHostForClient() *Client_GetCommunicationHostForClient_Call {
-	return &Client_GetCommunicationHostForClient_Call{Call: _e.mock.On("GetCommunicationHostForClient")}
+//   - ctx context.Context
+func (_e *Client_Expecter) GetCommunicationHostForClient(ctx interface{}) *Client_GetCommunicationHostForClient_Call {
+	return &Client_GetCommunicationHostForClient_Call{Call: _e.mock.On("GetCommunicationHostForClient", ctx)}
 }
 
-func (_c *Client_GetCommunicationHostForClient_Call) Run(run func()) *Client_GetCommunicationHostForClient_Call {
+func (_c *Client_GetCommunicationHostForClient_Call) Run(run func(ctx context.Context)) *Client_GetCommunicationHostForClient_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(context.Context))
 	})
 	return _c
 }
@@ -429,28 +535,28 @@ func (_c *Client_GetCommunicationHostForClient_Call) Return(_a0 dynatrace.Commun
 	return _c
 }
 
-func (_c *Client_GetCommunicationHostForClient_Call) RunAndReturn(run func() (dynatrace.CommunicationHost, error)) *Client_GetCommunicationHostForClient_Call {
+func (_c *Client_GetCommunicationHostForClient_Call) RunAndReturn(run func(context.Context) (dynatrace.CommunicationHost, error)) *Client_GetCommunicationHostForClient_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetEntityIDForIP provides a mock function with given fields: ip
-func (_m *Client) GetEntityIDForIP(ip string) (string, error) {
-	ret := _m.Called(ip)
+// GetEntityIDForIP provides a mock function with given fields: ctx, ip
+func (_m *Client) GetEntityIDForIP(ctx context.Context, ip string) (string, error) {
+	ret := _m.Called(ctx, ip)
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
-		return rf(ip)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, ip)
 	}
-	if rf, ok := ret.Get(0).(func(string) string); ok {
-		r0 = rf(ip)
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, ip)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(ip)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, ip)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -464,14 +570,15 @@ type Client_GetEntityIDForIP_Call struct {
 }
 
 // GetEntityIDForIP is a helper method to define mock.On call
+//   - ctx context.Context
 //   - ip string
-func (_e *Client_Expecter) GetEntityIDForIP(ip interface{}) *Client_GetEntityIDForIP_Call {
-	return &Client_GetEntityIDForIP_Call{Call: _e.mock.On("GetEntityIDForIP", ip)}
+func (_e *Client_Expecter) GetEntityIDForIP(ctx interface{}, ip interface{}) *Client_GetEntityIDForIP_Call {
+	return &Client_GetEntityIDForIP_Call{Call: _e.mock.On("GetEntityIDForIP", ctx, ip)}
 }
 
-func (_c *Client_GetEntityIDForIP_Call) Run(run func(ip string)) *Client_GetEntityIDForIP_Call {
+func (_c *Client_GetEntityIDForIP_Call) Run(run func(ctx context.Context, ip string)) *Client_GetEntityIDForIP_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
@@ -481,30 +588,30 @@ func (_c *Client_GetEntityIDForIP_Call) Return(_a0 string, _a1 error) *Client_Ge
 	return _c
 }
 
-func (_c *Client_GetEntityIDForIP_Call) RunAndReturn(run func(string) (string, error)) *Client_GetEntityIDForIP_Call {
+func (_c *Client_GetEntityIDForIP_Call) RunAndReturn(run func(context.Context, string) (string, error)) *Client_GetEntityIDForIP_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetLatestActiveGateImage provides a mock function with given fields:
-func (_m *Client) GetLatestActiveGateImage() (*dynatrace.LatestImageInfo, error) {
-	ret := _m.Called()
+// GetLatestActiveGateImage provides a mock function with given fields: ctx
+func (_m *Client) GetLatestActiveGateImage(ctx context.Context) (*dynatrace.LatestImageInfo, error) {
+	ret := _m.Called(ctx)
 
 	var r0 *dynatrace.LatestImageInfo
 	var r1 error
-	if rf, ok := ret.Get(0).(func() (*dynatrace.LatestImageInfo, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(context.Context) (*dynatrace.LatestImageInfo, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func() *dynatrace.LatestImageInfo); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) *dynatrace.LatestImageInfo); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*dynatrace.LatestImageInfo)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -518,13 +625,14 @@ type Client_GetLatestActiveGateImage_Call struct {
 }
 
 // GetLatestActiveGateImage is a helper method to define mock.On call
-func (_e *Client_Expecter) GetLatestActiveGateImage() *Client_GetLatestActiveGateImage_Call {
-	return &Client_GetLatestActiveGateImage_Call{Call: _e.mock.On("GetLatestActiveGateImage")}
+//   - ctx context.Context
+func (_e *Client_Expecter) GetLatestActiveGateImage(ctx interface{}) *Client_GetLatestActiveGateImage_Call {
+	return &Client_GetLatestActiveGateImage_Call{Call: _e.mock.On("GetLatestActiveGateImage", ctx)}
 }
 
-func (_c *Client_GetLatestActiveGateImage_Call) Run(run func()) *Client_GetLatestActiveGateImage_Call {
+func (_c *Client_GetLatestActiveGateImage_Call) Run(run func(ctx context.Context)) *Client_GetLatestActiveGateImage_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(context.Context))
 	})
 	return _c
 }
@@ -534,18 +642,18 @@ func (_c *Client_GetLatestActiveGateImage_Call) Return(_a0 *dynatrace.LatestImag
 	return _c
 }
 
-func (_c *Client_GetLatestActiveGateImage_Call) RunAndReturn(run func() (*dynatrace.LatestImageInfo, error)) *Client_GetLatestActiveGateImage_Call {
+func (_c *Client_GetLatestActiveGateImage_Call) RunAndReturn(run func(context.Context) (*dynatrace.LatestImageInfo, error)) *Client_GetLatestActiveGateImage_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetLatestAgent provides a mock function with given fields: os, installerType, flavor, arch, technologies, skipMetadata, writer
-func (_m *Client) GetLatestAgent(os string, installerType string, flavor string, arch string, technologies []string, skipMetadata bool, writer io.Writer) error {
-	ret := _m.Called(os, installerType, flavor, arch, technologies, skipMetadata, writer)
+// GetLatestAgent provides a mock function with given fields: ctx, os, installerType, flavor, arch, technologies, skipMetadata, writer
+func (_m *Client) GetLatestAgent(ctx context.Context, os string, installerType string, flavor string, arch string, technologies []string, skipMetadata bool, writer io.Writer) error {
+	ret := _m.Called(ctx, os, installerType, flavor, arch, technologies, skipMetadata, writer)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string, string, string, []string, bool, io.Writer) error); ok {
-		r0 = rf(os, installerType, flavor, arch, technologies, skipMetadata, writer)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string, []string, bool, io.Writer) error); ok {
+		r0 = rf(ctx, os, installerType, flavor, arch, technologies, skipMetadata, writer)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -559,6 +667,7 @@ type Client_GetLatestAgent_Call struct {
 }
 
 // GetLatestAgent is a helper method to define mock.On call
+//   - ctx context.Context
 //   - os string
 //   - installerType string
 //   - flavor string
@@ -566,13 +675,13 @@ type Client_GetLatestAgent_Call struct {
 //   - technologies []string
 //   - skipMetadata bool
 //   - writer io.Writer
-func (_e *Client_Expecter) GetLatestAgent(os interface{}, installerType interface{}, flavor interface{}, arch interface{}, technologies interface{}, skipMetadata interface{}, writer interface{}) *Client_GetLatestAgent_Call {
-	return &Client_GetLatestAgent_Call{Call: _e.mock.On("GetLatestAgent", os, installerType, flavor, arch, technologies, skipMetadata, writer)}
+func (_e *Client_Expecter) GetLatestAgent(ctx interface{}, os interface{}, installerType interface{}, flavor interface{}, arch interface{}, technologies interface{}, skipMetadata interface{}, writer interface{}) *Client_GetLatestAgent_Call {
+	return &Client_GetLatestAgent_Call{Call: _e.mock.On("GetLatestAgent", ctx, os, installerType, flavor, arch, technologies, skipMetadata, writer)}
 }
 
-func (_c *Client_GetLatestAgent_Call) Run(run func(os string, installerType string, flavor string, arch string, technologies []string, skipMetadata bool, writer io.Writer)) *Client_GetLatestAgent_Call {
+func (_c *Client_GetLatestAgent_Call) Run(run func(ctx context.Context, os string, installerType string, flavor string, arch string, technologies []string, skipMetadata bool, writer io.Writer)) *Client_GetLatestAgent_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string), args[2].(string), args[3].(string), args[4].([]string), args[5].(bool), args[6].(io.Writer))
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(string), args[5].([]string), args[6].(bool), args[7].(io.Writer))
 	})
 	return _c
 }
@@ -582,28 +691,28 @@ func (_c *Client_GetLatestAgent_Call) Return(_a0 error) *Client_GetLatestAgent_C
 	return _c
 }
 
-func (_c *Client_GetLatestAgent_Call) RunAndReturn(run func(string, string, string, string, []string, bool, io.Writer) error) *Client_GetLatestAgent_Call {
+func (_c *Client_GetLatestAgent_Call) RunAndReturn(run func(context.Context, string, string, string, string, []string, bool, io.Writer) error) *Client_GetLatestAgent_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetLatestAgentVersion provides a mock function with given fields: os, installerType
-func (_m *Client) GetLatestAgentVersion(os string, installerType string) (string, error) {
-	ret := _m.Called(os, installerType)
+// GetLatestAgentVersion provides a mock function with given fields: ctx, os, installerType
+func (_m *Client) GetLatestAgentVersion(ctx context.Context, os string, installerType string) (string, error) {
+	ret := _m.Called(ctx, os, installerType)
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string) (string, error)); ok {
-		return rf(os, installerType)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
+		return rf(ctx, os, installerType)
 	}
-	if rf, ok := ret.Get(0).(func(string, string) string); ok {
-		r0 = rf(os, installerType)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = rf(ctx, os, installerType)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string) error); ok {
-		r1 = rf(os, installerType)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, os, installerType)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -617,15 +726,16 @@ type Client_GetLatestAgentVersion_Call struct {
 }
 
 // GetLatestAgentVersion is a helper method to define mock.On call
+//   - ctx context.Context
 //   - os string
 //   - installerType string
-func (_e *Client_Expecter) GetLatestAgentVersion(os interface{}, installerType interface{}) *Client_GetLatestAgentVersion_Call {
-	return &Client_GetLatestAgentVersion_Call{Call: _e.mock.On("GetLatestAgentVersion", os, installerType)}
+func (_e *Client_Expecter) GetLatestAgentVersion(ctx interface{}, os interface{}, installerType interface{}) *Client_GetLatestAgentVersion_Call {
+	return &Client_GetLatestAgentVersion_Call{Call: _e.mock.On("GetLatestAgentVersion", ctx, os, installerType)}
 }
 
-func (_c *Client_GetLatestAgentVersion_Call) Run(run func(os string, installerType string)) *Client_GetLatestAgentVersion_Call {
+func (_c *Client_GetLatestAgentVersion_Call) Run(run func(ctx context.Context, os string, installerType string)) *Client_GetLatestAgentVersion_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
 	})
 	return _c
 }
@@ -635,30 +745,30 @@ func (_c *Client_GetLatestAgentVersion_Call) Return(_a0 string, _a1 error) *Clie
 	return _c
 }
 
-func (_c *Client_GetLatestAgentVersion_Call) RunAndReturn(run func(string, string) (string, error)) *Client_GetLatestAgentVersion_Call {
+func (_c *Client_GetLatestAgentVersion_Call) RunAndReturn(run func(context.Context, string, string) (string, error)) *Client_GetLatestAgentVersion_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetLatestCodeModulesImage provides a mock function with given fields:
-func (_m *Client) GetLatestCodeModulesImage() (*dynatrace.LatestImageInfo, error) {
-	ret := _m.Called()
+// GetLatestCodeModulesImage provides a mock function with given fields: ctx
+func (_m *Client) GetLatestCodeModulesImage(ctx context.Context) (*dynatrace.LatestImageInfo, error) {
+	ret := _m.Called(ctx)
 
 	var r0 *dynatrace.LatestImageInfo
 	var r1 error
-	if rf, ok := ret.Get(0).(func() (*dynatrace.LatestImageInfo, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(context.Context) (*dynatrace.LatestImageInfo, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func() *dynatrace.LatestImageInfo); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) *dynatrace.LatestImageInfo); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*dynatrace.LatestImageInfo)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -672,13 +782,14 @@ type Client_GetLatestCodeModulesImage_Call struct {
 }
 
 // GetLatestCodeModulesImage is a helper method to define mock.On call
-func (_e *Client_Expecter) GetLatestCodeModulesImage() *Client_GetLatestCodeModulesImage_Call {
-	return &Client_GetLatestCodeModulesImage_Call{Call: _e.mock.On("GetLatestCodeModulesImage")}
+//   - ctx context.Context
+func (_e *Client_Expecter) GetLatestCodeModulesImage(ctx interface{}) *Client_GetLatestCodeModulesImage_Call {
+	return &Client_GetLatestCodeModulesImage_Call{Call: _e.mock.On("GetLatestCodeModulesImage", ctx)}
 }
 
-func (_c *Client_GetLatestCodeModulesImage_Call) Run(run func()) *Client_GetLatestCodeModulesImage_Call {
+func (_c *Client_GetLatestCodeModulesImage_Call) Run(run func(ctx context.Context)) *Client_GetLatestCodeModulesImage_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(context.Context))
 	})
 	return _c
 }
@@ -688,30 +799,30 @@ func (_c *Client_GetLatestCodeModulesImage_Call) Return(_a0 *dynatrace.LatestIma
 	return _c
 }
 
-func (_c *Client_GetLatestCodeModulesImage_Call) RunAndReturn(run func() (*dynatrace.LatestImageInfo, error)) *Client_GetLatestCodeModulesImage_Call {
+func (_c *Client_GetLatestCodeModulesImage_Call) RunAndReturn(run func(context.Context) (*dynatrace.LatestImageInfo, error)) *Client_GetLatestCodeModulesImage_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetLatestOneAgentImage provides a mock function with given fields:
-func (_m *Client) GetLatestOneAgentImage() (*dynatrace.LatestImageInfo, error) {
-	ret := _m.Called()
+// GetLatestOneAgentImage provides a mock function with given fields: ctx
+func (_m *Client) GetLatestOneAgentImage(ctx context.Context) (*dynatrace.LatestImageInfo, error) {
+	ret := _m.Called(ctx)
 
 	var r0 *dynatrace.LatestImageInfo
 	var r1 error
-	if rf, ok := ret.Get(0).(func() (*dynatrace.LatestImageInfo, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(context.Context) (*dynatrace.LatestImageInfo, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func() *dynatrace.LatestImageInfo); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) *dynatrace.LatestImageInfo); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*dynatrace.LatestImageInfo)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -725,13 +836,14 @@ type Client_GetLatestOneAgentImage_Call struct {
 }
 
 // GetLatestOneAgentImage is a helper method to define mock.On call
-func (_e *Client_Expecter) GetLatestOneAgentImage() *Client_GetLatestOneAgentImage_Call {
-	return &Client_GetLatestOneAgentImage_Call{Call: _e.mock.On("GetLatestOneAgentImage")}
+//   - ctx context.Context
+func (_e *Client_Expecter) GetLatestOneAgentImage(ctx interface{}) *Client_GetLatestOneAgentImage_Call {
+	return &Client_GetLatestOneAgentImage_Call{Call: _e.mock.On("GetLatestOneAgentImage", ctx)}
 }
 
-func (_c *Client_GetLatestOneAgentImage_Call) Run(run func()) *Client_GetLatestOneAgentImage_Call {
+func (_c *Client_GetLatestOneAgentImage_Call) Run(run func(ctx context.Context)) *Client_GetLatestOneAgentImage_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(context.Context))
 	})
 	return _c
 }
@@ -741,30 +853,30 @@ func (_c *Client_GetLatestOneAgentImage_Call) Return(_a0 *dynatrace.LatestImageI
 	return _c
 }
 
-func (_c *Client_GetLatestOneAgentImage_Call) RunAndReturn(run func() (*dynatrace.LatestImageInfo, error)) *Client_GetLatestOneAgentImage_Call {
+func (_c *Client_GetLatestOneAgentImage_Call) RunAndReturn(run func(context.Context) (*dynatrace.LatestImageInfo, error)) *Client_GetLatestOneAgentImage_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetMonitoredEntitiesForKubeSystemUUID provides a mock function with given fields: kubeSystemUUID
-func (_m *Client) GetMonitoredEntitiesForKubeSystemUUID(kubeSystemUUID string) ([]dynatrace.MonitoredEntity, error) {
-	ret := _m.Called(kubeSystemUUID)
+// GetMonitoredEntitiesForKubeSystemUUID provides a mock function with given fields: ctx, kubeSystemUUID
+func (_m *Client) GetMonitoredEntitiesForKubeSystemUUID(ctx context.Context, kubeSystemUUID string) ([]dynatrace.MonitoredEntity, error) {
+	ret := _m.Called(ctx, kubeSystemUUID)
 
 	var r0 []dynatrace.MonitoredEntity
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) ([]dynatrace.MonitoredEntity, error)); ok {
-		return rf(kubeSystemUUID)
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]dynatrace.MonitoredEntity, error)); ok {
+		return rf(ctx, kubeSystemUUID)
 	}
-	if rf, ok := ret.Get(0).(func(string) []dynatrace.MonitoredEntity); ok {
-		r0 = rf(kubeSystemUUID)
+	if rf, ok := ret.Get(0).(func(context.Context, string) []dynatrace.MonitoredEntity); ok {
+		r0 = rf(ctx, kubeSystemUUID)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]dynatrace.MonitoredEntity)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(kubeSystemUUID)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, kubeSystemUUID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -778,14 +890,15 @@ type Client_GetMonitoredEntitiesForKubeSystemUUID_Call struct {
 }
 
 // GetMonitoredEntitiesForKubeSystemUUID is a helper method to define mock.On call
+//   - ctx context.Context
 //   - kubeSystemUUID string
-func (_e *Client_Expecter) GetMonitoredEntitiesForKubeSystemUUID(kubeSystemUUID interface{}) *Client_GetMonitoredEntitiesForKubeSystemUUID_Call {
-	return &Client_GetMonitoredEntitiesForKubeSystemUUID_Call{Call: _e.mock.On("GetMonitoredEntitiesForKubeSystemUUID", kubeSystemUUID)}
+func (_e *Client_Expecter) GetMonitoredEntitiesForKubeSystemUUID(ctx interface{}, kubeSystemUUID interface{}) *Client_GetMonitoredEntitiesForKubeSystemUUID_Call {
+	return &Client_GetMonitoredEntitiesForKubeSystemUUID_Call{Call: _e.mock.On("GetMonitoredEntitiesForKubeSystemUUID", ctx, kubeSystemUUID)}
 }
 
-func (_c *Client_GetMonitoredEntitiesForKubeSystemUUID_Call) Run(run func(kubeSystemUUID string)) *Client_GetMonitoredEntitiesForKubeSystemUUID_Call {
+func (_c *Client_GetMonitoredEntitiesForKubeSystemUUID_Call) Run(run func(ctx context.Context, kubeSystemUUID string)) *Client_GetMonitoredEntitiesForKubeSystemUUID_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
@@ -795,28 +908,82 @@ func (_c *Client_GetMonitoredEntitiesForKubeSystemUUID_Call) Return(_a0 []dynatr
 	return _c
 }
 
-func (_c *Client_GetMonitoredEntitiesForKubeSystemUUID_Call) RunAndReturn(run func(string) ([]dynatrace.MonitoredEntity, error)) *Client_GetMonitoredEntitiesForKubeSystemUUID_Call {
+func (_c *Client_GetMonitoredEntitiesForKubeSystemUUID_Call) RunAndReturn(run func(context.Context, string) ([]dynatrace.MonitoredEntity, error)) *Client_GetMonitoredEntitiesForKubeSystemUUID_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetOneAgentConnectionInfo provides a mock function with given fields:
-func (_m *Client) GetOneAgentConnectionInfo() (dynatrace.OneAgentConnectionInfo, error) {
-	ret := _m.Called()
+// GetOAuthScopes provides a mock function with given fields: ctx
+func (_m *Client) GetOAuthScopes(ctx context.Context) (dynatrace.TokenScopes, error) {
+	ret := _m.Called(ctx)
+
+	var r0 dynatrace.TokenScopes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (dynatrace.TokenScopes, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) dynatrace.TokenScopes); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(dynatrace.TokenScopes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_GetOAuthScopes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOAuthScopes'
+type Client_GetOAuthScopes_Call struct {
+	*mock.Call
+}
+
+// GetOAuthScopes is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Client_Expecter) GetOAuthScopes(ctx interface{}) *Client_GetOAuthScopes_Call {
+	return &Client_GetOAuthScopes_Call{Call: _e.mock.On("GetOAuthScopes", ctx)}
+}
+
+func (_c *Client_GetOAuthScopes_Call) Run(run func(ctx context.Context)) *Client_GetOAuthScopes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Client_GetOAuthScopes_Call) Return(_a0 dynatrace.TokenScopes, _a1 error) *Client_GetOAuthScopes_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_GetOAuthScopes_Call) RunAndReturn(run func(context.Context) (dynatrace.TokenScopes, error)) *Client_GetOAuthScopes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetOneAgentConnectionInfo provides a mock function with given fields: ctx
+func (_m *Client) GetOneAgentConnectionInfo(ctx context.Context) (dynatrace.OneAgentConnectionInfo, error) {
+	ret := _m.Called(ctx)
 
 	var r0 dynatrace.OneAgentConnectionInfo
 	var r1 error
-	if rf, ok := ret.Get(0).(func() (dynatrace.OneAgentConnectionInfo, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(context.Context) (dynatrace.OneAgentConnectionInfo, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func() dynatrace.OneAgentConnectionInfo); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) dynatrace.OneAgentConnectionInfo); ok {
+		r0 = rf(ctx)
 	} else {
 		r0 = ret.Get(0).(dynatrace.OneAgentConnectionInfo)
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -830,13 +997,14 @@ type Client_GetOneAgentConnectionInfo_Call struct {
 }
 
 // GetOneAgentConnectionInfo is a helper method to define mock.On call
-func (_e *Client_Expecter) GetOneAgentConnectionInfo() *Client_GetOneAgentConnectionInfo_Call {
-	return &Client_GetOneAgentConnectionInfo_Call{Call: _e.mock.On("GetOneAgentConnectionInfo")}
+//   - ctx context.Context
+func (_e *Client_Expecter) GetOneAgentConnectionInfo(ctx interface{}) *Client_GetOneAgentConnectionInfo_Call {
+	return &Client_GetOneAgentConnectionInfo_Call{Call: _e.mock.On("GetOneAgentConnectionInfo", ctx)}
 }
 
-func (_c *Client_GetOneAgentConnectionInfo_Call) Run(run func()) *Client_GetOneAgentConnectionInfo_Call {
+func (_c *Client_GetOneAgentConnectionInfo_Call) Run(run func(ctx context.Context)) *Client_GetOneAgentConnectionInfo_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(context.Context))
 	})
 	return _c
 }
@@ -846,30 +1014,30 @@ func (_c *Client_GetOneAgentConnectionInfo_Call) Return(_a0 dynatrace.OneAgentCo
 	return _c
 }
 
-func (_c *Client_GetOneAgentConnectionInfo_Call) RunAndReturn(run func() (dynatrace.OneAgentConnectionInfo, error)) *Client_GetOneAgentConnectionInfo_Call {
+func (_c *Client_GetOneAgentConnectionInfo_Call) RunAndReturn(run func(context.Context) (dynatrace.OneAgentConnectionInfo, error)) *Client_GetOneAgentConnectionInfo_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetProcessModuleConfig provides a mock function with given fields: prevRevision
-func (_m *Client) GetProcessModuleConfig(prevRevision uint) (*dynatrace.ProcessModuleConfig, error) {
-	ret := _m.Called(prevRevision)
+// GetProcessModuleConfig provides a mock function with given fields: ctx, prevRevision
+func (_m *Client) GetProcessModuleConfig(ctx context.Context, prevRevision uint) (*dynatrace.ProcessModuleConfig, error) {
+	ret := _m.Called(ctx, prevRevision)
 
 	var r0 *dynatrace.ProcessModuleConfig
 	var r1 error
-	if rf, ok := ret.Get(0).(func(uint) (*dynatrace.ProcessModuleConfig, error)); ok {
-		return rf(prevRevision)
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (*dynatrace.ProcessModuleConfig, error)); ok {
+		return rf(ctx, prevRevision)
 	}
-	if rf, ok := ret.Get(0).(func(uint) *dynatrace.ProcessModuleConfig); ok {
-		r0 = rf(prevRevision)
+	if rf, ok := ret.Get(0).(func(context.Context, uint) *dynatrace.ProcessModuleConfig); ok {
+		r0 = rf(ctx, prevRevision)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*dynatrace.ProcessModuleConfig)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(uint) error); ok {
-		r1 = rf(prevRevision)
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, prevRevision)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -883,14 +1051,15 @@ type Client_GetProcessModuleConfig_Call struct {
 }
 
 // GetProcessModuleConfig is a helper method to define mock.On call
+//   - ctx context.Context
 //   - prevRevision uint
-func (_e *Client_Expecter) GetProcessModuleConfig(prevRevision interface{}) *Client_GetProcessModuleConfig_Call {
-	return &Client_GetProcessModuleConfig_Call{Call: _e.mock.On("GetProcessModuleConfig", prevRevision)}
+func (_e *Client_Expecter) GetProcessModuleConfig(ctx interface{}, prevRevision interface{}) *Client_GetProcessModuleConfig_Call {
+	return &Client_GetProcessModuleConfig_Call{Call: _e.mock.On("GetProcessModuleConfig", ctx, prevRevision)}
 }
 
-func (_c *Client_GetProcessModuleConfig_Call) Run(run func(prevRevision uint)) *Client_GetProcessModuleConfig_Call {
+func (_c *Client_GetProcessModuleConfig_Call) Run(run func(ctx context.Context, prevRevision uint)) *Client_GetProcessModuleConfig_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(uint))
+		run(args[0].(context.Context), args[1].(uint))
 	})
 	return _c
 }
@@ -900,28 +1069,28 @@ func (_c *Client_GetProcessModuleConfig_Call) Return(_a0 *dynatrace.ProcessModul
 	return _c
 }
 
-func (_c *Client_GetProcessModuleConfig_Call) RunAndReturn(run func(uint) (*dynatrace.ProcessModuleConfig, error)) *Client_GetProcessModuleConfig_Call {
+func (_c *Client_GetProcessModuleConfig_Call) RunAndReturn(run func(context.Context, uint) (*dynatrace.ProcessModuleConfig, error)) *Client_GetProcessModuleConfig_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetSettingsForMonitoredEntities provides a mock function with given fields: monitoredEntities, schemaId
-func (_m *Client) GetSettingsForMonitoredEntities(monitoredEntities []dynatrace.MonitoredEntity, schemaId string) (dynatrace.GetSettingsResponse, error) {
-	ret := _m.Called(monitoredEntities, schemaId)
+// GetSettingsForMonitoredEntities provides a mock function with given fields: ctx, monitoredEntities, schemaId
+func (_m *Client) GetSettingsForMonitoredEntities(ctx context.Context, monitoredEntities []dynatrace.MonitoredEntity, schemaId string) (dynatrace.GetSettingsResponse, error) {
+	ret := _m.Called(ctx, monitoredEntities, schemaId)
 
 	var r0 dynatrace.GetSettingsResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func([]dynatrace.MonitoredEntity, string) (dynatrace.GetSettingsResponse, error)); ok {
-		return rf(monitoredEntities, schemaId)
+	if rf, ok := ret.Get(0).(func(context.Context, []dynatrace.MonitoredEntity, string) (dynatrace.GetSettingsResponse, error)); ok {
+		return rf(ctx, monitoredEntities, schemaId)
 	}
-	if rf, ok := ret.Get(0).(func([]dynatrace.MonitoredEntity, string) dynatrace.GetSettingsResponse); ok {
-		r0 = rf(monitoredEntities, schemaId)
+	if rf, ok := ret.Get(0).(func(context.Context, []dynatrace.MonitoredEntity, string) dynatrace.GetSettingsResponse); ok {
+		r0 = rf(ctx, monitoredEntities, schemaId)
 	} else {
 		r0 = ret.Get(0).(dynatrace.GetSettingsResponse)
 	}
 
-	if rf, ok := ret.Get(1).(func([]dynatrace.MonitoredEntity, string) error); ok {
-		r1 = rf(monitoredEntities, schemaId)
+	if rf, ok := ret.Get(1).(func(context.Context, []dynatrace.MonitoredEntity, string) error); ok {
+		r1 = rf(ctx, monitoredEntities, schemaId)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -935,15 +1104,16 @@ type Client_GetSettingsForMonitoredEntities_Call struct {
 }
 
 // GetSettingsForMonitoredEntities is a helper method to define mock.On call
+//   - ctx context.Context
 //   - monitoredEntities []dynatrace.MonitoredEntity
 //   - schemaId string
-func (_e *Client_Expecter) GetSettingsForMonitoredEntities(monitoredEntities interface{}, schemaId interface{}) *Client_GetSettingsForMonitoredEntities_Call {
-	return &Client_GetSettingsForMonitoredEntities_Call{Call: _e.mock.On("GetSettingsForMonitoredEntities", monitoredEntities, schemaId)}
+func (_e *Client_Expecter) GetSettingsForMonitoredEntities(ctx interface{}, monitoredEntities interface{}, schemaId interface{}) *Client_GetSettingsForMonitoredEntities_Call {
+	return &Client_GetSettingsForMonitoredEntities_Call{Call: _e.mock.On("GetSettingsForMonitoredEntities", ctx, monitoredEntities, schemaId)}
 }
 
-func (_c *Client_GetSettingsForMonitoredEntities_Call) Run(run func(monitoredEntities []dynatrace.MonitoredEntity, schemaId string)) *Client_GetSettingsForMonitoredEntities_Call {
+func (_c *Client_GetSettingsForMonitoredEntities_Call) Run(run func(ctx context.Context, monitoredEntities []dynatrace.MonitoredEntity, schemaId string)) *Client_GetSettingsForMonitoredEntities_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].([]dynatrace.MonitoredEntity), args[1].(string))
+		run(args[0].(context.Context), args[1].([]dynatrace.MonitoredEntity), args[2].(string))
 	})
 	return _c
 }
@@ -953,30 +1123,85 @@ func (_c *Client_GetSettingsForMonitoredEntities_Call) Return(_a0 dynatrace.GetS
 	return _c
 }
 
-func (_c *Client_GetSettingsForMonitoredEntities_Call) RunAndReturn(run func([]dynatrace.MonitoredEntity, string) (dynatrace.GetSettingsResponse, error)) *Client_GetSettingsForMonitoredEntities_Call {
+func (_c *Client_GetSettingsForMonitoredEntities_Call) RunAndReturn(run func(context.Context, []dynatrace.MonitoredEntity, string) (dynatrace.GetSettingsResponse, error)) *Client_GetSettingsForMonitoredEntities_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSettingsObject provides a mock function with given fields: ctx, objectId
+func (_m *Client) GetSettingsObject(ctx context.Context, objectId string) (*dynatrace.SettingsObject, error) {
+	ret := _m.Called(ctx, objectId)
+
+	var r0 *dynatrace.SettingsObject
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*dynatrace.SettingsObject, error)); ok {
+		return rf(ctx, objectId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *dynatrace.SettingsObject); ok {
+		r0 = rf(ctx, objectId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*dynatrace.SettingsObject)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, objectId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Client_GetSettingsObject_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSettingsObject'
+type Client_GetSettingsObject_Call struct {
+	*mock.Call
+}
+
+// GetSettingsObject is a helper method to define mock.On call
+//   - ctx context.Context
+//   - objectId string
+func (_e *Client_Expecter) GetSettingsObject(ctx interface{}, objectId interface{}) *Client_GetSettingsObject_Call {
+	return &Client_GetSettingsObject_Call{Call: _e.mock.On("GetSettingsObject", ctx, objectId)}
+}
+
+func (_c *Client_GetSettingsObject_Call) Run(run func(ctx context.Context, objectId string)) *Client_GetSettingsObject_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Client_GetSettingsObject_Call) Return(_a0 *dynatrace.SettingsObject, _a1 error) *Client_GetSettingsObject_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Client_GetSettingsObject_Call) RunAndReturn(run func(context.Context, string) (*dynatrace.SettingsObject, error)) *Client_GetSettingsObject_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetTokenScopes provides a mock function with given fields: token
-func (_m *Client) GetTokenScopes(token string) (dynatrace.TokenScopes, error) {
-	ret := _m.Called(token)
+// GetTokenScopes provides a mock function with given fields: ctx, token
+func (_m *Client) GetTokenScopes(ctx context.Context, token string) (dynatrace.TokenScopes, error) {
+	ret := _m.Called(ctx, token)
 
 	var r0 dynatrace.TokenScopes
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (dynatrace.TokenScopes, error)); ok {
-		return rf(token)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (dynatrace.TokenScopes, error)); ok {
+		return rf(ctx, token)
 	}
-	if rf, ok := ret.Get(0).(func(string) dynatrace.TokenScopes); ok {
-		r0 = rf(token)
+	if rf, ok := ret.Get(0).(func(context.Context, string) dynatrace.TokenScopes); ok {
+		r0 = rf(ctx, token)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(dynatrace.TokenScopes)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(token)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -990,14 +1215,15 @@ type Client_GetTokenScopes_Call struct {
 }
 
 // GetTokenScopes is a helper method to define mock.On call
+//   - ctx context.Context
 //   - token string
-func (_e *Client_Expecter) GetTokenScopes(token interface{}) *Client_GetTokenScopes_Call {
-	return &Client_GetTokenScopes_Call{Call: _e.mock.On("GetTokenScopes", token)}
+func (_e *Client_Expecter) GetTokenScopes(ctx interface{}, token interface{}) *Client_GetTokenScopes_Call {
+	return &Client_GetTokenScopes_Call{Call: _e.mock.On("GetTokenScopes", ctx, token)}
 }
 
-func (_c *Client_GetTokenScopes_Call) Run(run func(token string)) *Client_GetTokenScopes_Call {
+func (_c *Client_GetTokenScopes_Call) Run(run func(ctx context.Context, token string)) *Client_GetTokenScopes_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
@@ -1007,18 +1233,61 @@ func (_c *Client_GetTokenScopes_Call) Return(_a0 dynatrace.TokenScopes, _a1 erro
 	return _c
 }
 
-func (_c *Client_GetTokenScopes_Call) RunAndReturn(run func(string) (dynatrace.TokenScopes, error)) *Client_GetTokenScopes_Call {
+func (_c *Client_GetTokenScopes_Call) RunAndReturn(run func(context.Context, string) (dynatrace.TokenScopes, error)) *Client_GetTokenScopes_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SendEvent provides a mock function with given fields: eventData
-func (_m *Client) SendEvent(eventData *dynatrace.EventData) error {
-	ret := _m.Called(eventData)
+// IngestEvent provides a mock function with given fields: ctx, event
+func (_m *Client) IngestEvent(ctx context.Context, event *dynatrace.IngestEventData) error {
+	ret := _m.Called(ctx, event)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*dynatrace.EventData) error); ok {
-		r0 = rf(eventData)
+	if rf, ok := ret.Get(0).(func(context.Context, *dynatrace.IngestEventData) error); ok {
+		r0 = rf(ctx, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Client_IngestEvent_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IngestEvent'
+type Client_IngestEvent_Call struct {
+	*mock.Call
+}
+
+// IngestEvent is a helper method to define mock.On call
+//   - ctx context.Context
+//   - event *dynatrace.IngestEventData
+func (_e *Client_Expecter) IngestEvent(ctx interface{}, event interface{}) *Client_IngestEvent_Call {
+	return &Client_IngestEvent_Call{Call: _e.mock.On("IngestEvent", ctx, event)}
+}
+
+func (_c *Client_IngestEvent_Call) Run(run func(ctx context.Context, event *dynatrace.IngestEventData)) *Client_IngestEvent_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*dynatrace.IngestEventData))
+	})
+	return _c
+}
+
+func (_c *Client_IngestEvent_Call) Return(_a0 error) *Client_IngestEvent_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Client_IngestEvent_Call) RunAndReturn(run func(context.Context, *dynatrace.IngestEventData) error) *Client_IngestEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendEvent provides a mock function with given fields: ctx, eventData
+func (_m *Client) SendEvent(ctx context.Context, eventData *dynatrace.EventData) error {
+	ret := _m.Called(ctx, eventData)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *dynatrace.EventData) error); ok {
+		r0 = rf(ctx, eventData)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -1032,14 +1301,15 @@ type Client_SendEvent_Call struct {
 }
 
 // SendEvent is a helper method to define mock.On call
+//   - ctx context.Context
 //   - eventData *dynatrace.EventData
-func (_e *Client_Expecter) SendEvent(eventData interface{}) *Client_SendEvent_Call {
-	return &Client_SendEvent_Call{Call: _e.mock.On("SendEvent", eventData)}
+func (_e *Client_Expecter) SendEvent(ctx interface{}, eventData interface{}) *Client_SendEvent_Call {
+	return &Client_SendEvent_Call{Call: _e.mock.On("SendEvent", ctx, eventData)}
 }
 
-func (_c *Client_SendEvent_Call) Run(run func(eventData *dynatrace.EventData)) *Client_SendEvent_Call {
+func (_c *Client_SendEvent_Call) Run(run func(ctx context.Context, eventData *dynatrace.EventData)) *Client_SendEvent_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(*dynatrace.EventData))
+		run(args[0].(context.Context), args[1].(*dynatrace.EventData))
 	})
 	return _c
 }
@@ -1049,7 +1319,51 @@ func (_c *Client_SendEvent_Call) Return(_a0 error) *Client_SendEvent_Call {
 	return _c
 }
 
-func (_c *Client_SendEvent_Call) RunAndReturn(run func(*dynatrace.EventData) error) *Client_SendEvent_Call {
+func (_c *Client_SendEvent_Call) RunAndReturn(run func(context.Context, *dynatrace.EventData) error) *Client_SendEvent_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateSettingsObject provides a mock function with given fields: ctx, objectId, object
+func (_m *Client) UpdateSettingsObject(ctx context.Context, objectId string, object dynatrace.SettingsObject) error {
+	ret := _m.Called(ctx, objectId, object)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, dynatrace.SettingsObject) error); ok {
+		r0 = rf(ctx, objectId, object)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Client_UpdateSettingsObject_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateSettingsObject'
+type Client_UpdateSettingsObject_Call struct {
+	*mock.Call
+}
+
+// UpdateSettingsObject is a helper method to define mock.On call
+//   - ctx context.Context
+//   - objectId string
+//   - object dynatrace.SettingsObject
+func (_e *Client_Expecter) UpdateSettingsObject(ctx interface{}, objectId interface{}, object interface{}) *Client_UpdateSettingsObject_Call {
+	return &Client_UpdateSettingsObject_Call{Call: _e.mock.On("UpdateSettingsObject", ctx, objectId, object)}
+}
+
+func (_c *Client_UpdateSettingsObject_Call) Run(run func(ctx context.Context, objectId string, object dynatrace.SettingsObject)) *Client_UpdateSettingsObject_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(dynatrace.SettingsObject))
+	})
+	return _c
+}
+
+func (_c *Client_UpdateSettingsObject_Call) Return(_a0 error) *Client_UpdateSettingsObject_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Client_UpdateSettingsObject_Call) RunAndReturn(run func(context.Context, string, dynatrace.SettingsObject) error) *Client_UpdateSettingsObject_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -9,6 +9,8 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	status "github.com/Dynatrace/dynatrace-operator/pkg/api/status"
+
+	versionpolicy "github.com/Dynatrace/dynatrace-operator/pkg/api/versionpolicy"
 )
 
 // StatusUpdater is an autogenerated mock type for the StatusUpdater type
@@ -24,6 +26,60 @@ func (_m *StatusUpdater) EXPECT() *StatusUpdater_Expecter {
 	return &StatusUpdater_Expecter{mock: &_m.Mock}
 }
 
+// AvailableVersions provides a mock function with given fields: _a0
+func (_m *StatusUpdater) AvailableVersions(_a0 context.Context) ([]string, error) {
+	ret := _m.Called(_a0)
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]string, error)); ok {
+		return rf(_a0)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []string); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(_a0)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StatusUpdater_AvailableVersions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AvailableVersions'
+type StatusUpdater_AvailableVersions_Call struct {
+	*mock.Call
+}
+
+// AvailableVersions is a helper method to define mock.On call
+//   - _a0 context.Context
+func (_e *StatusUpdater_Expecter) AvailableVersions(_a0 interface{}) *StatusUpdater_AvailableVersions_Call {
+	return &StatusUpdater_AvailableVersions_Call{Call: _e.mock.On("AvailableVersions", _a0)}
+}
+
+func (_c *StatusUpdater_AvailableVersions_Call) Run(run func(_a0 context.Context)) *StatusUpdater_AvailableVersions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *StatusUpdater_AvailableVersions_Call) Return(_a0 []string, _a1 error) *StatusUpdater_AvailableVersions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *StatusUpdater_AvailableVersions_Call) RunAndReturn(run func(context.Context) ([]string, error)) *StatusUpdater_AvailableVersions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CheckForDowngrade provides a mock function with given fields: latestVersion
 func (_m *StatusUpdater) CheckForDowngrade(latestVersion string) (bool, error) {
 	ret := _m.Called(latestVersion)
@@ -281,25 +337,25 @@ func (_c *StatusUpdater_IsPublicRegistryEnabled_Call) RunAndReturn(run func() bo
 	return _c
 }
 
-// LatestImageInfo provides a mock function with given fields:
-func (_m *StatusUpdater) LatestImageInfo() (*dynatrace.LatestImageInfo, error) {
-	ret := _m.Called()
+// LatestImageInfo provides a mock function with given fields: ctx
+func (_m *StatusUpdater) LatestImageInfo(ctx context.Context) (*dynatrace.LatestImageInfo, error) {
+	ret := _m.Called(ctx)
 
 	var r0 *dynatrace.LatestImageInfo
 	var r1 error
-	if rf, ok := ret.Get(0).(func() (*dynatrace.LatestImageInfo, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(context.Context) (*dynatrace.LatestImageInfo, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func() *dynatrace.LatestImageInfo); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) *dynatrace.LatestImageInfo); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*dynatrace.LatestImageInfo)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -313,13 +369,14 @@ type StatusUpdater_LatestImageInfo_Call struct {
 }
 
 // LatestImageInfo is a helper method to define mock.On call
-func (_e *StatusUpdater_Expecter) LatestImageInfo() *StatusUpdater_LatestImageInfo_Call {
-	return &StatusUpdater_LatestImageInfo_Call{Call: _e.mock.On("LatestImageInfo")}
+//   - ctx context.Context
+func (_e *StatusUpdater_Expecter) LatestImageInfo(ctx interface{}) *StatusUpdater_LatestImageInfo_Call {
+	return &StatusUpdater_LatestImageInfo_Call{Call: _e.mock.On("LatestImageInfo", ctx)}
 }
 
-func (_c *StatusUpdater_LatestImageInfo_Call) Run(run func()) *StatusUpdater_LatestImageInfo_Call {
+func (_c *StatusUpdater_LatestImageInfo_Call) Run(run func(ctx context.Context)) *StatusUpdater_LatestImageInfo_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run()
+		run(args[0].(context.Context))
 	})
 	return _c
 }
@@ -329,7 +386,7 @@ func (_c *StatusUpdater_LatestImageInfo_Call) Return(_a0 *dynatrace.LatestImageI
 	return _c
 }
 
-func (_c *StatusUpdater_LatestImageInfo_Call) RunAndReturn(run func() (*dynatrace.LatestImageInfo, error)) *StatusUpdater_LatestImageInfo_Call {
+func (_c *StatusUpdater_LatestImageInfo_Call) RunAndReturn(run func(context.Context) (*dynatrace.LatestImageInfo, error)) *StatusUpdater_LatestImageInfo_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -460,6 +517,49 @@ func (_c *StatusUpdater_UseTenantRegistry_Call) RunAndReturn(run func(context.Co
 	return _c
 }
 
+// UseTenantRegistryVersion provides a mock function with given fields: ctx, version
+func (_m *StatusUpdater) UseTenantRegistryVersion(ctx context.Context, version string) error {
+	ret := _m.Called(ctx, version)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, version)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StatusUpdater_UseTenantRegistryVersion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UseTenantRegistryVersion'
+type StatusUpdater_UseTenantRegistryVersion_Call struct {
+	*mock.Call
+}
+
+// UseTenantRegistryVersion is a helper method to define mock.On call
+//   - ctx context.Context
+//   - version string
+func (_e *StatusUpdater_Expecter) UseTenantRegistryVersion(ctx interface{}, version interface{}) *StatusUpdater_UseTenantRegistryVersion_Call {
+	return &StatusUpdater_UseTenantRegistryVersion_Call{Call: _e.mock.On("UseTenantRegistryVersion", ctx, version)}
+}
+
+func (_c *StatusUpdater_UseTenantRegistryVersion_Call) Run(run func(ctx context.Context, version string)) *StatusUpdater_UseTenantRegistryVersion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *StatusUpdater_UseTenantRegistryVersion_Call) Return(_a0 error) *StatusUpdater_UseTenantRegistryVersion_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *StatusUpdater_UseTenantRegistryVersion_Call) RunAndReturn(run func(context.Context, string) error) *StatusUpdater_UseTenantRegistryVersion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ValidateStatus provides a mock function with given fields:
 func (_m *StatusUpdater) ValidateStatus() error {
 	ret := _m.Called()
@@ -501,6 +601,49 @@ func (_c *StatusUpdater_ValidateStatus_Call) RunAndReturn(run func() error) *Sta
 	return _c
 }
 
+// VersionPolicy provides a mock function with given fields:
+func (_m *StatusUpdater) VersionPolicy() *versionpolicy.Spec {
+	ret := _m.Called()
+
+	var r0 *versionpolicy.Spec
+	if rf, ok := ret.Get(0).(func() *versionpolicy.Spec); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*versionpolicy.Spec)
+		}
+	}
+
+	return r0
+}
+
+// StatusUpdater_VersionPolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'VersionPolicy'
+type StatusUpdater_VersionPolicy_Call struct {
+	*mock.Call
+}
+
+// VersionPolicy is a helper method to define mock.On call
+func (_e *StatusUpdater_Expecter) VersionPolicy() *StatusUpdater_VersionPolicy_Call {
+	return &StatusUpdater_VersionPolicy_Call{Call: _e.mock.On("VersionPolicy")}
+}
+
+func (_c *StatusUpdater_VersionPolicy_Call) Run(run func()) *StatusUpdater_VersionPolicy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *StatusUpdater_VersionPolicy_Call) Return(_a0 *versionpolicy.Spec) *StatusUpdater_VersionPolicy_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *StatusUpdater_VersionPolicy_Call) RunAndReturn(run func() *versionpolicy.Spec) *StatusUpdater_VersionPolicy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewStatusUpdater creates a new instance of StatusUpdater. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewStatusUpdater(t interface {
@@ -2,7 +2,11 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
 
 // Installer is an autogenerated mock type for the Installer type
 type Installer struct {
@@ -17,23 +21,23 @@ func (_m *Installer) EXPECT() *Installer_Expecter {
 	return &Installer_Expecter{mock: &_m.Mock}
 }
 
-// InstallAgent provides a mock function with given fields: targetDir
-func (_m *Installer) InstallAgent(targetDir string) (bool, error) {
-	ret := _m.Called(targetDir)
+// InstallAgent provides a mock function with given fields: ctx, targetDir
+func (_m *Installer) InstallAgent(ctx context.Context, targetDir string) (bool, error) {
+	ret := _m.Called(ctx, targetDir)
 
 	var r0 bool
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (bool, error)); ok {
-		return rf(targetDir)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, targetDir)
 	}
-	if rf, ok := ret.Get(0).(func(string) bool); ok {
-		r0 = rf(targetDir)
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, targetDir)
 	} else {
 		r0 = ret.Get(0).(bool)
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(targetDir)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, targetDir)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -47,14 +51,15 @@ type Installer_InstallAgent_Call struct {
 }
 
 // InstallAgent is a helper method to define mock.On call
+//   - ctx context.Context
 //   - targetDir string
-func (_e *Installer_Expecter) InstallAgent(targetDir interface{}) *Installer_InstallAgent_Call {
-	return &Installer_InstallAgent_Call{Call: _e.mock.On("InstallAgent", targetDir)}
+func (_e *Installer_Expecter) InstallAgent(ctx interface{}, targetDir interface{}) *Installer_InstallAgent_Call {
+	return &Installer_InstallAgent_Call{Call: _e.mock.On("InstallAgent", ctx, targetDir)}
 }
 
-func (_c *Installer_InstallAgent_Call) Run(run func(targetDir string)) *Installer_InstallAgent_Call {
+func (_c *Installer_InstallAgent_Call) Run(run func(ctx context.Context, targetDir string)) *Installer_InstallAgent_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(context.Context), args[1].(string))
 	})
 	return _c
 }
@@ -64,7 +69,7 @@ func (_c *Installer_InstallAgent_Call) Return(_a0 bool, _a1 error) *Installer_In
 	return _c
 }
 
-func (_c *Installer_InstallAgent_Call) RunAndReturn(run func(string) (bool, error)) *Installer_InstallAgent_Call {
+func (_c *Installer_InstallAgent_Call) RunAndReturn(run func(context.Context, string) (bool, error)) *Installer_InstallAgent_Call {
 	_c.Call.Return(run)
 	return _c
 }